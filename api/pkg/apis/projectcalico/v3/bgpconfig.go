@@ -98,6 +98,12 @@ type BGPConfigurationSpec struct {
 	// +optional
 	NodeMeshMaxRestartTime *metav1.Duration `json:"nodeMeshMaxRestartTime,omitempty" confignamev1:"node_mesh_restart_time"`
 
+	// BFD settings for node-to-node mesh peerings.  When enabled, Bidirectional Forwarding Detection
+	// is used to detect the failure of a mesh peer.
+	// This field can only be set on the default BGPConfiguration instance and requires that NodeMesh is enabled
+	// +optional
+	NodeMeshBFD *BGPBFD `json:"nodeMeshBFD,omitempty" validate:"omitempty" confignamev1:"node_mesh_bfd"`
+
 	// BindMode indicates whether to listen for BGP connections on all addresses (None)
 	// or only on the node's canonical IP address Node.Spec.BGP.IPvXAddress (NodeIP).
 	// Default behaviour is to listen for BGP connections on all addresses.
@@ -15,6 +15,7 @@
 package v3
 
 import (
+	"github.com/projectcalico/api/pkg/lib/numorstring"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -72,6 +73,18 @@ type BGPFilterRuleV4 struct {
 
 	MatchOperator BGPFilterMatchOperator `json:"matchOperator,omitempty" validate:"omitempty,matchOperator"`
 
+	// Communities, if set, matches routes that carry at least one of the given communities.
+	// For standard communities use `aa:nn` format, where `aa` and `nn` are 16 bit numbers.
+	// For large communities use `aa:nn:mm` format, where `aa`, `nn` and `mm` are 32 bit numbers.
+	Communities []string `json:"communities,omitempty" validate:"omitempty,dive,bgpCommunityValue"`
+
+	// ASPathPrefix, if set, matches routes whose AS path starts with the given sequence of AS numbers.
+	ASPathPrefix []numorstring.ASNumber `json:"asPathPrefix,omitempty"`
+
+	// Operations is an ordered list of modifications that are made to matching routes
+	// before the action is applied.  Operations cannot be used with the Reject action.
+	Operations []BGPFilterOperation `json:"operations,omitempty" validate:"omitempty,dive"`
+
 	Action BGPFilterAction `json:"action" validate:"required,filterAction"`
 }
 
@@ -87,6 +100,18 @@ type BGPFilterRuleV6 struct {
 
 	MatchOperator BGPFilterMatchOperator `json:"matchOperator,omitempty" validate:"omitempty,matchOperator"`
 
+	// Communities, if set, matches routes that carry at least one of the given communities.
+	// For standard communities use `aa:nn` format, where `aa` and `nn` are 16 bit numbers.
+	// For large communities use `aa:nn:mm` format, where `aa`, `nn` and `mm` are 32 bit numbers.
+	Communities []string `json:"communities,omitempty" validate:"omitempty,dive,bgpCommunityValue"`
+
+	// ASPathPrefix, if set, matches routes whose AS path starts with the given sequence of AS numbers.
+	ASPathPrefix []numorstring.ASNumber `json:"asPathPrefix,omitempty"`
+
+	// Operations is an ordered list of modifications that are made to matching routes
+	// before the action is applied.  Operations cannot be used with the Reject action.
+	Operations []BGPFilterOperation `json:"operations,omitempty" validate:"omitempty,dive"`
+
 	Action BGPFilterAction `json:"action" validate:"required,filterAction"`
 }
 
@@ -108,6 +133,27 @@ type BGPFilterPrefixLengthV6 struct {
 	Max *int32 `json:"max,omitempty" validate:"omitempty,bgpFilterPrefixLengthV6"`
 }
 
+// BGPFilterOperation is a modification made to a route matched by a BGPFilter rule.  Exactly one
+// field must be set.
+type BGPFilterOperation struct {
+	// AddCommunity adds a standard (`aa:nn`) or large (`aa:nn:mm`) community to the route.
+	AddCommunity string `json:"addCommunity,omitempty" validate:"omitempty,bgpCommunityValue"`
+
+	// RemoveCommunity removes a standard (`aa:nn`) or large (`aa:nn:mm`) community from the route.
+	RemoveCommunity string `json:"removeCommunity,omitempty" validate:"omitempty,bgpCommunityValue"`
+
+	// SetMED sets the multi-exit discriminator of the route.
+	SetMED *uint32 `json:"setMED,omitempty"`
+
+	// SetLocalPreference sets the local preference of the route.  It only has an effect on
+	// imported routes and on routes exported to iBGP peers.
+	SetLocalPreference *uint32 `json:"setLocalPreference,omitempty"`
+
+	// PrependASPath prepends the given AS numbers to the AS path of the route, so that the
+	// first AS number in the list becomes the first in the path.
+	PrependASPath []numorstring.ASNumber `json:"prependASPath,omitempty"`
+}
+
 type BGPFilterMatchSource string
 
 const (
@@ -117,6 +117,38 @@ type BGPPeerSpec struct {
 	// and the ASNumber must not be empty.
 	// +optional
 	LocalWorkloadSelector string `json:"localWorkloadSelector,omitempty" validate:"omitempty,selector"`
+
+	// BFD configures Bidirectional Forwarding Detection for the peerings generated by this
+	// BGPPeer resource, so that the failure of a peer is detected in well under a second
+	// rather than when the BGP hold timer expires.
+	// +optional
+	BFD *BGPBFD `json:"bfd,omitempty" validate:"omitempty"`
+}
+
+// BGPBFD contains the Bidirectional Forwarding Detection settings for a set of BGP sessions.
+// BIRD uses one set of BFD timers for all the BFD sessions on a node, so where the peerings of a
+// node ask for different timers, the smallest value of each timer is used.
+type BGPBFD struct {
+	// Enabled sets whether BFD is used to detect the failure of the BGP sessions. When a BFD
+	// session goes down, BIRD closes the corresponding BGP session. [Default: false]
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinRxInterval is the minimum interval between received BFD control packets that this
+	// node supports. [Default: 10ms]
+	// +optional
+	MinRxInterval *metav1.Duration `json:"minRxInterval,omitempty"`
+
+	// MinTxInterval is the minimum interval at which this node sends BFD control packets
+	// while the session is up. [Default: 100ms]
+	// +optional
+	MinTxInterval *metav1.Duration `json:"minTxInterval,omitempty"`
+
+	// Multiplier is the number of consecutive BFD control packets that can be missed before
+	// the session is declared down. [Default: 5]
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=255
+	// +optional
+	Multiplier *int32 `json:"multiplier,omitempty" validate:"omitempty,gte=1,lte=255"`
 }
 
 type SourceAddress string
@@ -130,6 +130,15 @@ const (
 	NATOutgoingExclusionsIPPoolsAndHostIPs NATOutgoingExclusionsType = "IPPoolsAndHostIPs"
 )
 
+// +kubebuilder:validation:Enum=Disabled;NoDelay;DelayDNSResponse
+type DNSPolicyMode string
+
+const (
+	DNSPolicyModeDisabled         DNSPolicyMode = "Disabled"
+	DNSPolicyModeNoDelay          DNSPolicyMode = "NoDelay"
+	DNSPolicyModeDelayDNSResponse DNSPolicyMode = "DelayDNSResponse"
+)
+
 // FelixConfigurationSpec contains the values of the Felix configuration.
 type FelixConfigurationSpec struct {
 	// UseInternalDataplaneDriver, if true, Felix will use its internal dataplane programming logic.  If false, it
@@ -859,6 +868,49 @@ type FelixConfigurationSpec struct {
 	// +kubebuilder:validation:Enum=Disabled;Enabled
 	FlowLogsLocalReporter *string `json:"flowLogsLocalReporter,omitempty"`
 
+	// FlowLogsIPFIXCollector is the address, in host:port form, of an IPFIX collector to which Felix
+	// should export flow records over UDP. IPFIX records are not aggregated, each describes a single
+	// connection. [Default: unset - IPFIX export is disabled]
+	FlowLogsIPFIXCollector *string `json:"flowLogsIPFIXCollector,omitempty"`
+
+	// FlowLogsIPFIXObservationDomainID is the observation domain ID included in the IPFIX messages that
+	// Felix sends. Collectors use it to distinguish between exporters. [Default: 0]
+	FlowLogsIPFIXObservationDomainID *uint32 `json:"flowLogsIPFIXObservationDomainID,omitempty"`
+
+	// FlowLogsIPFIXEnterpriseNumber is the IANA Private Enterprise Number under which Felix exports
+	// Calico-specific information elements, such as endpoint names, namespaces and policies, in IPFIX
+	// records. When zero, only standard information elements are exported. [Default: 0]
+	FlowLogsIPFIXEnterpriseNumber *uint32 `json:"flowLogsIPFIXEnterpriseNumber,omitempty"`
+
+	// FlowLogsIPFIXTemplateRefreshInterval is the interval at which Felix resends its IPFIX templates, so
+	// that collectors that restart can decode its records. [Default: 10m]
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\\.[0-9]+)?(ms|s|m|h))*$`
+	FlowLogsIPFIXTemplateRefreshInterval *metav1.Duration `json:"flowLogsIPFIXTemplateRefreshInterval,omitempty" configv1timescale:"seconds"`
+
+	// DNSPolicyMode controls whether Felix snoops on the DNS responses sent to local workloads in order to
+	// learn the IPs of the domains used in egress policy rules. Only responses from the DNSTrustedServers
+	// that answer a query the workload sent are used, and only DNS over UDP is snooped; answers received
+	// over TCP are not learned. With NoDelay, responses are released immediately, so a workload may try
+	// to connect before the learned IPs are programmed. With DelayDNSResponse, responses that contain new
+	// information are held until the dataplane has been updated, or until DNSPolicyNfqueueMaxHoldDuration
+	// has passed. Snooping is not supported in BPF mode. [Default: Disabled]
+	DNSPolicyMode *DNSPolicyMode `json:"dnsPolicyMode,omitempty"`
+
+	// DNSPolicyNfqueueID is the NFQUEUE number that Felix uses to snoop on DNS responses. [Default: 100]
+	DNSPolicyNfqueueID *int `json:"dnsPolicyNfqueueID,omitempty" validate:"omitempty,gte=0,lte=65535"`
+
+	// DNSPolicyNfqueueMaxHoldDuration is the longest time that Felix holds a DNS response while waiting for
+	// the dataplane to be updated, when DNSPolicyMode is DelayDNSResponse. [Default: 3s]
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\\.[0-9]+)?(ms|s|m|h))*$`
+	DNSPolicyNfqueueMaxHoldDuration *metav1.Duration `json:"dnsPolicyNfqueueMaxHoldDuration,omitempty" configv1timescale:"seconds"`
+
+	// DNSTrustedServers is the list of DNS servers (IPs or CIDRs) whose responses Felix snoops on when
+	// DNSPolicyMode is enabled. A server is matched on the address that the workload sent its query to,
+	// before any DNAT, so a Kubernetes DNS service can be trusted by listing its cluster IP. [Default: none]
+	DNSTrustedServers *[]string `json:"dnsTrustedServers,omitempty" validate:"omitempty,cidrs"`
+
 	// BPFProfiling controls profiling of BPF programs. At the monent, it can be
 	// Disabled or Enabled. [Default: Disabled]
 	//+kubebuilder:validation:Enum=Enabled;Disabled
@@ -925,6 +977,14 @@ type FelixConfigurationSpec struct {
 	// +kubebuilder:validation:Pattern=`^([0-9]+(\\.[0-9]+)?(ms|s|m|h))*$`
 	WireguardPersistentKeepAlive *metav1.Duration `json:"wireguardKeepAlive,omitempty"`
 
+	// WireguardKeyRotationInterval controls how often Felix rotates the node's Wireguard key pair. When it is time to
+	// rotate, Felix generates a new key pair and publishes the new public key alongside the current one so that peers
+	// can prepare for the switch; after a short grace period it moves the Wireguard device over to the new key.
+	// Set 0 to disable. [Default: 0]
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\\.[0-9]+)?(ms|s|m|h))*$`
+	WireguardKeyRotationInterval *metav1.Duration `json:"wireguardKeyRotationInterval,omitempty" configv1timescale:"seconds"`
+
 	// AWSSrcDstCheck controls whether Felix will try to change the "source/dest check" setting on the EC2 instance
 	// on which it is running. A value of "Disable" will try to disable the source/dest check. Disabling the check
 	// allows for sending workload traffic without encapsulation within the same AWS subnet.
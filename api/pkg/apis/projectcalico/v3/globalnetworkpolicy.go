@@ -133,6 +133,18 @@ type GlobalNetworkPolicySpec struct {
 	// If the policy is _not_ used on a particular node then the work
 	// done to preload the policy (and to maintain it) is wasted.
 	PerformanceHints []PolicyPerformanceHint `json:"performanceHints,omitempty" validate:"omitempty,unique,dive,oneof=AssumeNeededOnEveryNode"`
+
+	// ActiveFrom is an optional time before which the policy is not enforced: until then, Calico
+	// behaves as if the policy did not exist.
+	ActiveFrom *metav1.Time `json:"activeFrom,omitempty"`
+
+	// ActiveUntil is an optional time from which the policy is no longer enforced.
+	ActiveUntil *metav1.Time `json:"activeUntil,omitempty"`
+
+	// ActiveSchedule optionally restricts the policy to recurring windows of time, such as a
+	// weekly maintenance window.  If set, the policy is only enforced during one of the windows
+	// (and between ActiveFrom and ActiveUntil, if they are set).
+	ActiveSchedule []PolicyScheduleWindow `json:"activeSchedule,omitempty" validate:"omitempty,dive"`
 }
 
 // NewGlobalNetworkPolicy creates a new (zeroed) GlobalNetworkPolicy struct with the TypeMetadata initialised to the current
@@ -89,6 +89,24 @@ type IPPoolSpec struct {
 	// Determines the mode how IP addresses should be assigned from this pool
 	// +optional
 	AssignmentMode *AssignmentMode `json:"assignmentMode,omitempty" validate:"omitempty,assignmentMode"`
+
+	// Quotas limit the number of addresses from this pool that Calico IPAM will assign to the pods in
+	// each namespace.  If more than one quota selects a namespace, the lowest limit applies.
+	// +optional
+	Quotas []IPPoolQuota `json:"quotas,omitempty" validate:"omitempty,dive"`
+}
+
+// IPPoolQuota limits the number of addresses from an IP pool that may be assigned to the pods in
+// each of a set of namespaces.
+type IPPoolQuota struct {
+	// NamespaceSelector selects the namespaces that the quota applies to, based on the namespaces'
+	// labels.  The limit applies to each selected namespace separately; for example, "all()" limits
+	// every namespace and "projectcalico.org/name == 'dev'" limits only the "dev" namespace.
+	NamespaceSelector string `json:"namespaceSelector" validate:"selector"`
+
+	// MaxAddresses is the maximum number of addresses from the pool that may be assigned to the pods
+	// in each selected namespace.
+	MaxAddresses int `json:"maxAddresses" validate:"gte=0"`
 }
 
 type IPPoolAllowedUse string
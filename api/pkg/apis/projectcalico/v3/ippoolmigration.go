@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindIPPoolMigration     = "IPPoolMigration"
+	KindIPPoolMigrationList = "IPPoolMigrationList"
+)
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPPoolMigrationList contains a list of IPPoolMigration resources.
+type IPPoolMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []IPPoolMigration `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IPPoolMigration moves the pods using addresses from one IP pool to another.  The Calico kube-controllers
+// disable the old pool, then evict the pods using its addresses in batches, respecting PodDisruptionBudgets,
+// so that their controllers recreate them with addresses from the new pool.  Once no addresses in the old pool
+// are assigned, including tunnel addresses and those assigned outside of Kubernetes, the pool and its blocks
+// are deleted.
+type IPPoolMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   IPPoolMigrationSpec   `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	Status IPPoolMigrationStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// IPPoolMigrationSpec contains the specification for an IPPoolMigration resource.
+type IPPoolMigrationSpec struct {
+	// FromPool is the name of the IP pool to move pods out of.  The pool is disabled when the
+	// migration starts, and deleted when it completes.
+	FromPool string `json:"fromPool" validate:"name"`
+
+	// ToPool is the name of the IP pool that the pods should get their new addresses from.  It
+	// must be enabled, and select the nodes that the old pool selects.  The migration doesn't
+	// start while any other enabled pool assigns addresses to pods on those nodes.
+	ToPool string `json:"toPool" validate:"name"`
+
+	// BatchSize is the maximum number of pods that are being moved at any one time. [Default: 10]
+	// +optional
+	BatchSize int `json:"batchSize,omitempty" validate:"omitempty,gt=0"`
+}
+
+type IPPoolMigrationPhase string
+
+const (
+	IPPoolMigrationPhasePending   IPPoolMigrationPhase = "Pending"
+	IPPoolMigrationPhaseMigrating IPPoolMigrationPhase = "Migrating"
+	IPPoolMigrationPhaseComplete  IPPoolMigrationPhase = "Complete"
+	IPPoolMigrationPhaseFailed    IPPoolMigrationPhase = "Failed"
+)
+
+// IPPoolMigrationStatus reports the progress of an IPPoolMigration.  It is updated by the Calico
+// kube-controllers.
+type IPPoolMigrationStatus struct {
+	// Phase is the stage that the migration has reached: Pending, Migrating, Complete or Failed.
+	Phase IPPoolMigrationPhase `json:"phase,omitempty"`
+
+	// Message describes the current state of the migration, for example why it has failed or
+	// what it is waiting for.
+	Message string `json:"message,omitempty"`
+
+	// TotalPods is the number of pods that were using addresses from the old pool when the
+	// migration started.
+	TotalPods int `json:"totalPods,omitempty"`
+
+	// RemainingPods is the number of pods that are still using addresses from the old pool.
+	RemainingPods int `json:"remainingPods,omitempty"`
+
+	// UnmanagedPods is the number of remaining pods that aren't owned by a controller.  They
+	// would not be recreated if they were evicted, so they must be deleted manually.
+	UnmanagedPods int `json:"unmanagedPods,omitempty"`
+
+	// StartTime is when the migration started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the old pool was deleted.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// NewIPPoolMigration creates a new (zeroed) IPPoolMigration struct with the TypeMetadata initialised to the
+// current version.
+func NewIPPoolMigration() *IPPoolMigration {
+	return &IPPoolMigration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindIPPoolMigration,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
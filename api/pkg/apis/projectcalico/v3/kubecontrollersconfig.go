@@ -105,6 +105,12 @@ type NodeControllerConfig struct {
 	// Set to 0 to disable IP garbage collection. [Default: 15m]
 	// +optional
 	LeakGracePeriod *metav1.Duration `json:"leakGracePeriod,omitempty"`
+
+	// IPAMDefragPeriod is the period at which the controller drains sparsely-used IPAM blocks and releases
+	// the affinity of blocks that have drained, so that their addresses can be used by other nodes.
+	// Set to 0 to disable IPAM block defragmentation. [Default: 0]
+	// +optional
+	IPAMDefragPeriod *metav1.Duration `json:"ipamDefragPeriod,omitempty"`
 }
 
 type AutoHostEndpointConfig struct {
@@ -118,6 +118,18 @@ type NetworkPolicySpec struct {
 	// If the policy is _not_ used on a particular node then the work
 	// done to preload the policy (and to maintain it) is wasted.
 	PerformanceHints []PolicyPerformanceHint `json:"performanceHints,omitempty" validate:"omitempty,unique,dive,oneof=AssumeNeededOnEveryNode"`
+
+	// ActiveFrom is an optional time before which the policy is not enforced: until then, Calico
+	// behaves as if the policy did not exist.
+	ActiveFrom *metav1.Time `json:"activeFrom,omitempty"`
+
+	// ActiveUntil is an optional time from which the policy is no longer enforced.
+	ActiveUntil *metav1.Time `json:"activeUntil,omitempty"`
+
+	// ActiveSchedule optionally restricts the policy to recurring windows of time, such as a
+	// weekly maintenance window.  If set, the policy is only enforced during one of the windows
+	// (and between ActiveFrom and ActiveUntil, if they are set).
+	ActiveSchedule []PolicyScheduleWindow `json:"activeSchedule,omitempty" validate:"omitempty,dive"`
 }
 
 type PolicyPerformanceHint string
@@ -126,6 +138,24 @@ const (
 	PerfHintAssumeNeededOnEveryNode PolicyPerformanceHint = "AssumeNeededOnEveryNode"
 )
 
+// PolicyScheduleWindow is a recurring window of time during which a policy is enforced.
+type PolicyScheduleWindow struct {
+	// Days are the days of the week on which the window starts, for example "Saturday".  If
+	// omitted, the window starts every day.
+	Days []string `json:"days,omitempty" validate:"omitempty,unique,dive,oneof=Monday Tuesday Wednesday Thursday Friday Saturday Sunday"`
+
+	// StartTime is the time of day at which the window starts, in 24-hour "HH:MM" format.
+	StartTime string `json:"startTime" validate:"timeOfDay"`
+
+	// EndTime is the time of day at which the window ends, in 24-hour "HH:MM" format.  If it is
+	// not after StartTime, the window ends on the following day.
+	EndTime string `json:"endTime" validate:"timeOfDay"`
+
+	// TimeZone is the IANA name of the time zone that StartTime and EndTime are in, for example
+	// "Europe/London". [Default: UTC]
+	TimeZone string `json:"timeZone,omitempty" validate:"omitempty,timeZone"`
+}
+
 // NewNetworkPolicy creates a new (zeroed) NetworkPolicy struct with the TypeMetadata initialised to the current
 // version.
 func NewNetworkPolicy() *NetworkPolicy {
@@ -61,6 +61,18 @@ type Rule struct {
 	// HTTP contains match criteria that apply to HTTP requests.
 	HTTP *HTTPMatch `json:"http,omitempty" validate:"omitempty"`
 
+	// DSCP is the Differentiated Services Code Point that a SetDSCP rule sets on the packets that
+	// it matches, either as a number between 0 and 63, or as the name of a standard class, such as
+	// "EF", "AF41" or "CS3".  Required if, and only if, the Action is SetDSCP.
+	//
+	// SetDSCP rules may only be used in the egress rules of policies that are not DoNotTrack.  They
+	// are evaluated against every packet that the endpoint sends on the connections that it opens,
+	// independently of the rules that allow or deny traffic: the first SetDSCP rule, in policy
+	// order, that matches a packet sets its DSCP.  Replies to connections that the endpoint
+	// accepted are not marked.  If the packet is encapsulated (VXLAN or IPIP) on its way to another
+	// node, the DSCP is copied to the outer header.
+	DSCP *numorstring.DSCP `json:"dscp,omitempty" validate:"omitempty"`
+
 	// Metadata contains additional information for this rule
 	Metadata *RuleMetadata `json:"metadata,omitempty" validate:"omitempty"`
 }
@@ -73,8 +85,31 @@ type HTTPPath struct {
 	Prefix string `json:"prefix,omitempty" validate:"omitempty"`
 }
 
+// HTTPHeaderMatch specifies an HTTP request header to match. Name is required and at most one of Exact,
+// Regex or Present may be specified. If none are specified, the rule matches any request that has the header.
+type HTTPHeaderMatch struct {
+	// Name is the name of the header to match. Header names are matched case-insensitively.
+	Name string `json:"name" validate:"required"`
+	// Exact matches requests whose header value is exactly equal to the given value.
+	Exact string `json:"exact,omitempty" validate:"omitempty"`
+	// Regex matches requests whose header value matches the given RE2 regular expression. The
+	// whole value must match.
+	Regex string `json:"regex,omitempty" validate:"omitempty"`
+	// Present, if true, matches requests that have the header, with any value. If false, it matches
+	// requests that do not have the header.
+	Present *bool `json:"present,omitempty" validate:"omitempty"`
+}
+
+// GRPCMethodMatch specifies a gRPC service, and optionally a method of that service, to match.
+type GRPCMethodMatch struct {
+	// Service is the fully qualified name of the gRPC service, e.g. "helloworld.Greeter".
+	Service string `json:"service" validate:"required"`
+	// Method is an optional method name. If omitted, all methods of the service are matched.
+	Method string `json:"method,omitempty" validate:"omitempty"`
+}
+
 // HTTPMatch is an optional field that apply only to HTTP requests
-// The Methods and Path fields are joined with AND
+// The Methods, Paths, Headers, Hosts and GRPCMethods fields are joined with AND
 type HTTPMatch struct {
 	// Methods is an optional field that restricts the rule to apply only to HTTP requests that use one of the listed
 	// HTTP Methods (e.g. GET, PUT, etc.)
@@ -88,6 +123,23 @@ type HTTPMatch struct {
 	// - prefix: /bar
 	// NOTE: Each entry may ONLY specify either a `exact` or a `prefix` match. The validator will check for it.
 	Paths []HTTPPath `json:"paths,omitempty" validate:"omitempty"`
+	// Headers is an optional field that restricts the rule to apply to HTTP requests whose headers match
+	// all of the listed header matches.
+	// Multiple headers are AND'd together.
+	// e.g:
+	// - {name: x-version, exact: v2}
+	// - {name: authorization, present: true}
+	Headers []HTTPHeaderMatch `json:"headers,omitempty" validate:"omitempty"`
+	// Hosts is an optional field that restricts the rule to apply to HTTP requests for one of the listed
+	// hosts, as given by the :authority pseudo-header (or the Host header for HTTP/1.1). Hosts are matched
+	// case-insensitively. A host may start with "*." to match any subdomain. If a host does not specify a
+	// port, it matches requests for any port.
+	// Multiple hosts are OR'd together.
+	Hosts []string `json:"hosts,omitempty" validate:"omitempty"`
+	// GRPCMethods is an optional field that restricts the rule to apply to gRPC requests for one of the
+	// listed services or methods.
+	// Multiple methods are OR'd together.
+	GRPCMethods []GRPCMethodMatch `json:"grpcMethods,omitempty" validate:"omitempty"`
 }
 
 // ICMPFields defines structure for ICMP and NotICMP sub-struct for ICMP code and type
@@ -177,6 +229,16 @@ type EntityRule struct {
 	// ServiceAccounts is an optional field that restricts the rule to only apply to traffic that originates from (or
 	// terminates at) a pod running as a matching service account.
 	ServiceAccounts *ServiceAccountMatch `json:"serviceAccounts,omitempty" validate:"omitempty"`
+
+	// Domains is an optional field, valid only on the destination of egress rules, that restricts the rule
+	// to traffic to IP addresses that the domain names resolve to. Felix learns the addresses by snooping
+	// the DNS responses that workloads receive over UDP from the FelixConfiguration DNSTrustedServers;
+	// names resolved over TCP, or through other servers, are not learned. A leading "*." matches any
+	// subdomain, so "*.example.com" matches "api.example.com" but not "example.com".
+	//
+	// Domains cannot be specified on the same rule as Selector, NotSelector, NamespaceSelector, Nets,
+	// NotNets or Services.
+	Domains []string `json:"domains,omitempty" validate:"omitempty"`
 }
 
 type ServiceMatch struct {
@@ -206,6 +268,9 @@ const (
 	Deny  Action = "Deny"
 	Log   Action = "Log"
 	Pass  Action = "Pass"
+	// SetDSCP sets the DSCP field of the IP header of matching packets; like Log, it doesn't end
+	// policy processing.
+	SetDSCP Action = "SetDSCP"
 )
 
 type StagedAction string
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindQoSPolicy     = "QoSPolicy"
+	KindQoSPolicyList = "QoSPolicyList"
+)
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// QoSPolicyList contains a list of QoSPolicy resources.
+type QoSPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []QoSPolicy `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// QoSPolicy applies bandwidth, packet rate and connection limits to the workloads that it selects,
+// as an alternative to setting the qos.projectcalico.org annotations on each pod.  When more than
+// one QoSPolicy selects a workload, only the one that is first in order is applied.
+type QoSPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec QoSPolicySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// QoSPolicySpec contains the specification for a QoSPolicy resource.
+type QoSPolicySpec struct {
+	// Order is an optional field that specifies the order in which the QoS policy is considered.
+	// When more than one QoS policy selects a workload, the one with the lowest order is applied,
+	// and QoS policies with the same order are ordered by name.  QoS policies without an order
+	// come after all of those with one.
+	Order *float64 `json:"order,omitempty"`
+
+	// NamespaceSelector is an expression used to pick out the namespaces of the workloads that
+	// the QoS policy applies to.  If empty, workloads in any namespace may be selected.
+	NamespaceSelector string `json:"namespaceSelector,omitempty" validate:"selector"`
+
+	// Selector is an expression used to pick out the workloads that the QoS policy applies to.
+	// If empty, all workloads in the selected namespaces are selected.
+	Selector string `json:"selector,omitempty" validate:"selector"`
+
+	// Controls are the limits applied to the selected workloads.
+	Controls QoSPolicyControls `json:"controls"`
+
+	// PodAnnotationOverride controls whether the QoS annotations of a selected pod may override
+	// the limits set by this QoS policy:
+	// - Allow: the pod annotations take precedence over the QoS policy.
+	// - StricterOnly: a pod annotation takes precedence only if it sets a lower limit.
+	// - Deny: the pod annotations are ignored for the limits that the QoS policy sets.
+	// Pod annotations always apply to the limits that the QoS policy doesn't set.
+	// [Default: Allow]
+	PodAnnotationOverride QoSPodAnnotationOverride `json:"podAnnotationOverride,omitempty" validate:"omitempty,oneof=Allow StricterOnly Deny"`
+}
+
+// QoSPolicyControls are the QoS limits that a QoSPolicy may set.  Unset fields are not limited by
+// the QoS policy.
+type QoSPolicyControls struct {
+	// IngressBandwidth is the maximum bandwidth, in bits per second, of traffic to the workload.
+	// +kubebuilder:validation:Minimum=1000
+	// +kubebuilder:validation:Maximum=1000000000000000
+	IngressBandwidth *int64 `json:"ingressBandwidth,omitempty" validate:"omitempty,gte=1000,lte=1000000000000000"`
+
+	// EgressBandwidth is the maximum bandwidth, in bits per second, of traffic from the workload.
+	// +kubebuilder:validation:Minimum=1000
+	// +kubebuilder:validation:Maximum=1000000000000000
+	EgressBandwidth *int64 `json:"egressBandwidth,omitempty" validate:"omitempty,gte=1000,lte=1000000000000000"`
+
+	// IngressBurst is the burst size, in bits, of traffic to the workload.  It may only be set
+	// along with IngressBandwidth, and must be at least as large.  [Default: 4Gi]
+	// +kubebuilder:validation:Maximum=4294967296
+	IngressBurst *int64 `json:"ingressBurst,omitempty" validate:"omitempty,lte=4294967296"`
+
+	// EgressBurst is the burst size, in bits, of traffic from the workload.  It may only be set
+	// along with EgressBandwidth, and must be at least as large.  [Default: 4Gi]
+	// +kubebuilder:validation:Maximum=4294967296
+	EgressBurst *int64 `json:"egressBurst,omitempty" validate:"omitempty,lte=4294967296"`
+
+	// IngressPacketRate is the maximum rate, in packets per second, of traffic to the workload.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=1000000000000
+	IngressPacketRate *int64 `json:"ingressPacketRate,omitempty" validate:"omitempty,gte=10,lte=1000000000000"`
+
+	// EgressPacketRate is the maximum rate, in packets per second, of traffic from the workload.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=1000000000000
+	EgressPacketRate *int64 `json:"egressPacketRate,omitempty" validate:"omitempty,gte=10,lte=1000000000000"`
+
+	// IngressMaxConnections is the maximum number of connections to the workload.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100000000000
+	IngressMaxConnections *int64 `json:"ingressMaxConnections,omitempty" validate:"omitempty,gte=1,lte=100000000000"`
+
+	// EgressMaxConnections is the maximum number of connections from the workload.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100000000000
+	EgressMaxConnections *int64 `json:"egressMaxConnections,omitempty" validate:"omitempty,gte=1,lte=100000000000"`
+}
+
+type QoSPodAnnotationOverride string
+
+const (
+	QoSPodAnnotationOverrideAllow        QoSPodAnnotationOverride = "Allow"
+	QoSPodAnnotationOverrideStricterOnly QoSPodAnnotationOverride = "StricterOnly"
+	QoSPodAnnotationOverrideDeny         QoSPodAnnotationOverride = "Deny"
+)
+
+// NewQoSPolicy creates a new (zeroed) QoSPolicy struct with the TypeMetadata initialised to the current
+// version.
+func NewQoSPolicy() *QoSPolicy {
+	return &QoSPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       KindQoSPolicy,
+			APIVersion: GroupVersionCurrent,
+		},
+	}
+}
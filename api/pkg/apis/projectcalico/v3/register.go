@@ -42,8 +42,12 @@ var (
 		&HostEndpointList{},
 		&IPPool{},
 		&IPPoolList{},
+		&IPPoolMigration{},
+		&IPPoolMigrationList{},
 		&IPReservation{},
 		&IPReservationList{},
+		&QoSPolicy{},
+		&QoSPolicyList{},
 		&BGPConfiguration{},
 		&BGPConfigurationList{},
 		&BGPFilter{},
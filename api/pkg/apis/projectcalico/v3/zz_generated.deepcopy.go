@@ -38,6 +38,37 @@ func (in *AutoHostEndpointConfig) DeepCopy() *AutoHostEndpointConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPBFD) DeepCopyInto(out *BGPBFD) {
+	*out = *in
+	if in.MinRxInterval != nil {
+		in, out := &in.MinRxInterval, &out.MinRxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinTxInterval != nil {
+		in, out := &in.MinTxInterval, &out.MinTxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Multiplier != nil {
+		in, out := &in.Multiplier, &out.Multiplier
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPBFD.
+func (in *BGPBFD) DeepCopy() *BGPBFD {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPBFD)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BGPConfiguration) DeepCopyInto(out *BGPConfiguration) {
 	*out = *in
@@ -148,6 +179,11 @@ func (in *BGPConfigurationSpec) DeepCopyInto(out *BGPConfigurationSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.NodeMeshBFD != nil {
+		in, out := &in.NodeMeshBFD, &out.NodeMeshBFD
+		*out = new(BGPBFD)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BindMode != nil {
 		in, out := &in.BindMode, &out.BindMode
 		*out = new(BindMode)
@@ -247,6 +283,37 @@ func (in *BGPFilterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPFilterOperation) DeepCopyInto(out *BGPFilterOperation) {
+	*out = *in
+	if in.SetMED != nil {
+		in, out := &in.SetMED, &out.SetMED
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.SetLocalPreference != nil {
+		in, out := &in.SetLocalPreference, &out.SetLocalPreference
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.PrependASPath != nil {
+		in, out := &in.PrependASPath, &out.PrependASPath
+		*out = make([]numorstring.ASNumber, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPFilterOperation.
+func (in *BGPFilterOperation) DeepCopy() *BGPFilterOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPFilterOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BGPFilterPrefixLengthV4) DeepCopyInto(out *BGPFilterPrefixLengthV4) {
 	*out = *in
@@ -307,6 +374,23 @@ func (in *BGPFilterRuleV4) DeepCopyInto(out *BGPFilterRuleV4) {
 		*out = new(BGPFilterPrefixLengthV4)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ASPathPrefix != nil {
+		in, out := &in.ASPathPrefix, &out.ASPathPrefix
+		*out = make([]numorstring.ASNumber, len(*in))
+		copy(*out, *in)
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]BGPFilterOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -328,6 +412,23 @@ func (in *BGPFilterRuleV6) DeepCopyInto(out *BGPFilterRuleV6) {
 		*out = new(BGPFilterPrefixLengthV6)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ASPathPrefix != nil {
+		in, out := &in.ASPathPrefix, &out.ASPathPrefix
+		*out = make([]numorstring.ASNumber, len(*in))
+		copy(*out, *in)
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]BGPFilterOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -494,6 +595,11 @@ func (in *BGPPeerSpec) DeepCopyInto(out *BGPPeerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BGPBFD)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -657,6 +763,62 @@ func (in *CalicoNodeAgentStatus) DeepCopy() *CalicoNodeAgentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoNodeBFDSession) DeepCopyInto(out *CalicoNodeBFDSession) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeBFDSession.
+func (in *CalicoNodeBFDSession) DeepCopy() *CalicoNodeBFDSession {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoNodeBFDSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoNodeBFDStatus) DeepCopyInto(out *CalicoNodeBFDStatus) {
+	*out = *in
+	if in.SessionsV4 != nil {
+		in, out := &in.SessionsV4, &out.SessionsV4
+		*out = make([]CalicoNodeBFDSession, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SessionsV6 != nil {
+		in, out := &in.SessionsV6, &out.SessionsV6
+		*out = make([]CalicoNodeBFDSession, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoNodeBFDStatus.
+func (in *CalicoNodeBFDStatus) DeepCopy() *CalicoNodeBFDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoNodeBFDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CalicoNodeBGPRouteStatus) DeepCopyInto(out *CalicoNodeBGPRouteStatus) {
 	*out = *in
@@ -852,6 +1014,7 @@ func (in *CalicoNodeStatusStatus) DeepCopyInto(out *CalicoNodeStatusStatus) {
 	out.Agent = in.Agent
 	in.BGP.DeepCopyInto(&out.BGP)
 	in.Routes.DeepCopyInto(&out.Routes)
+	in.BFD.DeepCopyInto(&out.BFD)
 	return
 }
 
@@ -1058,6 +1221,11 @@ func (in *EntityRule) DeepCopyInto(out *EntityRule) {
 		*out = new(ServiceAccountMatch)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1648,6 +1816,50 @@ func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FlowLogsIPFIXCollector != nil {
+		in, out := &in.FlowLogsIPFIXCollector, &out.FlowLogsIPFIXCollector
+		*out = new(string)
+		**out = **in
+	}
+	if in.FlowLogsIPFIXObservationDomainID != nil {
+		in, out := &in.FlowLogsIPFIXObservationDomainID, &out.FlowLogsIPFIXObservationDomainID
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.FlowLogsIPFIXEnterpriseNumber != nil {
+		in, out := &in.FlowLogsIPFIXEnterpriseNumber, &out.FlowLogsIPFIXEnterpriseNumber
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.FlowLogsIPFIXTemplateRefreshInterval != nil {
+		in, out := &in.FlowLogsIPFIXTemplateRefreshInterval, &out.FlowLogsIPFIXTemplateRefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DNSPolicyMode != nil {
+		in, out := &in.DNSPolicyMode, &out.DNSPolicyMode
+		*out = new(DNSPolicyMode)
+		**out = **in
+	}
+	if in.DNSPolicyNfqueueID != nil {
+		in, out := &in.DNSPolicyNfqueueID, &out.DNSPolicyNfqueueID
+		*out = new(int)
+		**out = **in
+	}
+	if in.DNSPolicyNfqueueMaxHoldDuration != nil {
+		in, out := &in.DNSPolicyNfqueueMaxHoldDuration, &out.DNSPolicyNfqueueMaxHoldDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DNSTrustedServers != nil {
+		in, out := &in.DNSTrustedServers, &out.DNSTrustedServers
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
 	if in.RouteTableRanges != nil {
 		in, out := &in.RouteTableRanges, &out.RouteTableRanges
 		*out = new(RouteTableRanges)
@@ -1717,6 +1929,11 @@ func (in *FelixConfigurationSpec) DeepCopyInto(out *FelixConfigurationSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.WireguardKeyRotationInterval != nil {
+		in, out := &in.WireguardKeyRotationInterval, &out.WireguardKeyRotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.AWSSrcDstCheck != nil {
 		in, out := &in.AWSSrcDstCheck, &out.AWSSrcDstCheck
 		*out = new(AWSSrcDstCheckOption)
@@ -1760,6 +1977,22 @@ func (in *FelixConfigurationSpec) DeepCopy() *FelixConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCMethodMatch) DeepCopyInto(out *GRPCMethodMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCMethodMatch.
+func (in *GRPCMethodMatch) DeepCopy() *GRPCMethodMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCMethodMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalNetworkPolicy) DeepCopyInto(out *GlobalNetworkPolicy) {
 	*out = *in
@@ -1852,6 +2085,21 @@ func (in *GlobalNetworkPolicySpec) DeepCopyInto(out *GlobalNetworkPolicySpec) {
 		*out = make([]PolicyPerformanceHint, len(*in))
 		copy(*out, *in)
 	}
+	if in.ActiveFrom != nil {
+		in, out := &in.ActiveFrom, &out.ActiveFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveUntil != nil {
+		in, out := &in.ActiveUntil, &out.ActiveUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveSchedule != nil {
+		in, out := &in.ActiveSchedule, &out.ActiveSchedule
+		*out = make([]PolicyScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1946,6 +2194,27 @@ func (in *GlobalNetworkSetSpec) DeepCopy() *GlobalNetworkSetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderMatch) DeepCopyInto(out *HTTPHeaderMatch) {
+	*out = *in
+	if in.Present != nil {
+		in, out := &in.Present, &out.Present
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderMatch.
+func (in *HTTPHeaderMatch) DeepCopy() *HTTPHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPMatch) DeepCopyInto(out *HTTPMatch) {
 	*out = *in
@@ -1959,6 +2228,23 @@ func (in *HTTPMatch) DeepCopyInto(out *HTTPMatch) {
 		*out = make([]HTTPPath, len(*in))
 		copy(*out, *in)
 	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeaderMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GRPCMethods != nil {
+		in, out := &in.GRPCMethods, &out.GRPCMethods
+		*out = make([]GRPCMethodMatch, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2274,6 +2560,123 @@ func (in *IPPoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigration) DeepCopyInto(out *IPPoolMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigration.
+func (in *IPPoolMigration) DeepCopy() *IPPoolMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPoolMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigrationList) DeepCopyInto(out *IPPoolMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IPPoolMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigrationList.
+func (in *IPPoolMigrationList) DeepCopy() *IPPoolMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPPoolMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigrationSpec) DeepCopyInto(out *IPPoolMigrationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigrationSpec.
+func (in *IPPoolMigrationSpec) DeepCopy() *IPPoolMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolMigrationStatus) DeepCopyInto(out *IPPoolMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolMigrationStatus.
+func (in *IPPoolMigrationStatus) DeepCopy() *IPPoolMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPPoolQuota) DeepCopyInto(out *IPPoolQuota) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolQuota.
+func (in *IPPoolQuota) DeepCopy() *IPPoolQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(IPPoolQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 	*out = *in
@@ -2292,6 +2695,11 @@ func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 		*out = new(AssignmentMode)
 		**out = **in
 	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]IPPoolQuota, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2632,6 +3040,21 @@ func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
 		*out = make([]PolicyPerformanceHint, len(*in))
 		copy(*out, *in)
 	}
+	if in.ActiveFrom != nil {
+		in, out := &in.ActiveFrom, &out.ActiveFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveUntil != nil {
+		in, out := &in.ActiveUntil, &out.ActiveUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveSchedule != nil {
+		in, out := &in.ActiveSchedule, &out.ActiveSchedule
+		*out = make([]PolicyScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2744,6 +3167,11 @@ func (in *NodeControllerConfig) DeepCopyInto(out *NodeControllerConfig) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.IPAMDefragPeriod != nil {
+		in, out := &in.IPAMDefragPeriod, &out.IPAMDefragPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -2778,6 +3206,27 @@ func (in *PolicyControllerConfig) DeepCopy() *PolicyControllerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyScheduleWindow) DeepCopyInto(out *PolicyScheduleWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyScheduleWindow.
+func (in *PolicyScheduleWindow) DeepCopy() *PolicyScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrefixAdvertisement) DeepCopyInto(out *PrefixAdvertisement) {
 	*out = *in
@@ -2912,6 +3361,144 @@ func (in *ProtoPort) DeepCopy() *ProtoPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSPolicy) DeepCopyInto(out *QoSPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicy.
+func (in *QoSPolicy) DeepCopy() *QoSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QoSPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSPolicyControls) DeepCopyInto(out *QoSPolicyControls) {
+	*out = *in
+	if in.IngressBandwidth != nil {
+		in, out := &in.IngressBandwidth, &out.IngressBandwidth
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EgressBandwidth != nil {
+		in, out := &in.EgressBandwidth, &out.EgressBandwidth
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IngressBurst != nil {
+		in, out := &in.IngressBurst, &out.IngressBurst
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EgressBurst != nil {
+		in, out := &in.EgressBurst, &out.EgressBurst
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IngressPacketRate != nil {
+		in, out := &in.IngressPacketRate, &out.IngressPacketRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EgressPacketRate != nil {
+		in, out := &in.EgressPacketRate, &out.EgressPacketRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IngressMaxConnections != nil {
+		in, out := &in.IngressMaxConnections, &out.IngressMaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EgressMaxConnections != nil {
+		in, out := &in.EgressMaxConnections, &out.EgressMaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyControls.
+func (in *QoSPolicyControls) DeepCopy() *QoSPolicyControls {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSPolicyControls)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSPolicyList) DeepCopyInto(out *QoSPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QoSPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyList.
+func (in *QoSPolicyList) DeepCopy() *QoSPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QoSPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSPolicySpec) DeepCopyInto(out *QoSPolicySpec) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = new(float64)
+		**out = **in
+	}
+	in.Controls.DeepCopyInto(&out.Controls)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicySpec.
+func (in *QoSPolicySpec) DeepCopy() *QoSPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTableIDRange) DeepCopyInto(out *RouteTableIDRange) {
 	*out = *in
@@ -2999,6 +3586,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 		*out = new(HTTPMatch)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DSCP != nil {
+		in, out := &in.DSCP, &out.DSCP
+		*out = new(numorstring.DSCP)
+		**out = **in
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(RuleMetadata)
@@ -62,6 +62,10 @@ func (c *FakeProjectcalicoV3) IPPools() v3.IPPoolInterface {
 	return newFakeIPPools(c)
 }
 
+func (c *FakeProjectcalicoV3) IPPoolMigrations() v3.IPPoolMigrationInterface {
+	return newFakeIPPoolMigrations(c)
+}
+
 func (c *FakeProjectcalicoV3) IPReservations() v3.IPReservationInterface {
 	return newFakeIPReservations(c)
 }
@@ -82,6 +86,10 @@ func (c *FakeProjectcalicoV3) Profiles() v3.ProfileInterface {
 	return newFakeProfiles(c)
 }
 
+func (c *FakeProjectcalicoV3) QoSPolicies() v3.QoSPolicyInterface {
+	return newFakeQoSPolicies(c)
+}
+
 func (c *FakeProjectcalicoV3) StagedGlobalNetworkPolicies() v3.StagedGlobalNetworkPolicyInterface {
 	return newFakeStagedGlobalNetworkPolicies(c)
 }
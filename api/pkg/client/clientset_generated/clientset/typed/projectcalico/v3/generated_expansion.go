@@ -28,6 +28,8 @@ type IPAMConfigurationExpansion interface{}
 
 type IPPoolExpansion interface{}
 
+type IPPoolMigrationExpansion interface{}
+
 type IPReservationExpansion interface{}
 
 type KubeControllersConfigurationExpansion interface{}
@@ -38,6 +40,8 @@ type NetworkSetExpansion interface{}
 
 type ProfileExpansion interface{}
 
+type QoSPolicyExpansion interface{}
+
 type StagedGlobalNetworkPolicyExpansion interface{}
 
 type StagedKubernetesNetworkPolicyExpansion interface{}
@@ -26,11 +26,13 @@ type ProjectcalicoV3Interface interface {
 	HostEndpointsGetter
 	IPAMConfigurationsGetter
 	IPPoolsGetter
+	IPPoolMigrationsGetter
 	IPReservationsGetter
 	KubeControllersConfigurationsGetter
 	NetworkPoliciesGetter
 	NetworkSetsGetter
 	ProfilesGetter
+	QoSPoliciesGetter
 	StagedGlobalNetworkPoliciesGetter
 	StagedKubernetesNetworkPoliciesGetter
 	StagedNetworkPoliciesGetter
@@ -90,6 +92,10 @@ func (c *ProjectcalicoV3Client) IPPools() IPPoolInterface {
 	return newIPPools(c)
 }
 
+func (c *ProjectcalicoV3Client) IPPoolMigrations() IPPoolMigrationInterface {
+	return newIPPoolMigrations(c)
+}
+
 func (c *ProjectcalicoV3Client) IPReservations() IPReservationInterface {
 	return newIPReservations(c)
 }
@@ -110,6 +116,10 @@ func (c *ProjectcalicoV3Client) Profiles() ProfileInterface {
 	return newProfiles(c)
 }
 
+func (c *ProjectcalicoV3Client) QoSPolicies() QoSPolicyInterface {
+	return newQoSPolicies(c)
+}
+
 func (c *ProjectcalicoV3Client) StagedGlobalNetworkPolicies() StagedGlobalNetworkPolicyInterface {
 	return newStagedGlobalNetworkPolicies(c)
 }
@@ -63,6 +63,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().IPAMConfigurations().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("ippools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().IPPools().Informer()}, nil
+	case v3.SchemeGroupVersion.WithResource("ippoolmigrations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().IPPoolMigrations().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("ipreservations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().IPReservations().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("kubecontrollersconfigurations"):
@@ -73,6 +75,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().NetworkSets().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("profiles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().Profiles().Informer()}, nil
+	case v3.SchemeGroupVersion.WithResource("qospolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().QoSPolicies().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("stagedglobalnetworkpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Projectcalico().V3().StagedGlobalNetworkPolicies().Informer()}, nil
 	case v3.SchemeGroupVersion.WithResource("stagedkubernetesnetworkpolicies"):
@@ -34,6 +34,8 @@ type Interface interface {
 	IPAMConfigurations() IPAMConfigurationInformer
 	// IPPools returns a IPPoolInformer.
 	IPPools() IPPoolInformer
+	// IPPoolMigrations returns a IPPoolMigrationInformer.
+	IPPoolMigrations() IPPoolMigrationInformer
 	// IPReservations returns a IPReservationInformer.
 	IPReservations() IPReservationInformer
 	// KubeControllersConfigurations returns a KubeControllersConfigurationInformer.
@@ -44,6 +46,8 @@ type Interface interface {
 	NetworkSets() NetworkSetInformer
 	// Profiles returns a ProfileInformer.
 	Profiles() ProfileInformer
+	// QoSPolicies returns a QoSPolicyInformer.
+	QoSPolicies() QoSPolicyInformer
 	// StagedGlobalNetworkPolicies returns a StagedGlobalNetworkPolicyInformer.
 	StagedGlobalNetworkPolicies() StagedGlobalNetworkPolicyInformer
 	// StagedKubernetesNetworkPolicies returns a StagedKubernetesNetworkPolicyInformer.
@@ -125,6 +129,11 @@ func (v *version) IPPools() IPPoolInformer {
 	return &iPPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IPPoolMigrations returns a IPPoolMigrationInformer.
+func (v *version) IPPoolMigrations() IPPoolMigrationInformer {
+	return &iPPoolMigrationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPReservations returns a IPReservationInformer.
 func (v *version) IPReservations() IPReservationInformer {
 	return &iPReservationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -150,6 +159,11 @@ func (v *version) Profiles() ProfileInformer {
 	return &profileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// QoSPolicies returns a QoSPolicyInformer.
+func (v *version) QoSPolicies() QoSPolicyInformer {
+	return &qoSPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // StagedGlobalNetworkPolicies returns a StagedGlobalNetworkPolicyInformer.
 func (v *version) StagedGlobalNetworkPolicies() StagedGlobalNetworkPolicyInformer {
 	return &stagedGlobalNetworkPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
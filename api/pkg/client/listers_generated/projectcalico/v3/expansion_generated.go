@@ -52,6 +52,10 @@ type IPAMConfigurationListerExpansion interface{}
 // IPPoolLister.
 type IPPoolListerExpansion interface{}
 
+// IPPoolMigrationListerExpansion allows custom methods to be added to
+// IPPoolMigrationLister.
+type IPPoolMigrationListerExpansion interface{}
+
 // IPReservationListerExpansion allows custom methods to be added to
 // IPReservationLister.
 type IPReservationListerExpansion interface{}
@@ -80,6 +84,10 @@ type NetworkSetNamespaceListerExpansion interface{}
 // ProfileLister.
 type ProfileListerExpansion interface{}
 
+// QoSPolicyListerExpansion allows custom methods to be added to
+// QoSPolicyLister.
+type QoSPolicyListerExpansion interface{}
+
 // StagedGlobalNetworkPolicyListerExpansion allows custom methods to be added to
 // StagedGlobalNetworkPolicyLister.
 type StagedGlobalNetworkPolicyListerExpansion interface{}
@@ -29,6 +29,7 @@ func init() {
 	asNumberType := reflect.TypeOf(numorstring.ASNumber(0))
 	protocolType := reflect.TypeOf(numorstring.Protocol{})
 	portType := reflect.TypeOf(numorstring.Port{})
+	dscpType := reflect.TypeOf(numorstring.DSCP{})
 
 	// Perform tests of JSON unmarshaling of the various field types.
 	DescribeTable("NumOrStringJSONUnmarshaling",
@@ -89,6 +90,11 @@ func init() {
 		Entry("should accept 0 protocol as string", "\"0\"", protocolType, numorstring.ProtocolFromInt(0)),
 		Entry("should accept 0 protocol as string", "\"255\"", protocolType, numorstring.ProtocolFromInt(255)),
 		Entry("should accept 256 protocol as string", "\"256\"", protocolType, numorstring.ProtocolFromString("256")),
+
+		// DSCP tests.
+		Entry("should accept 46 DSCP as int", "46", dscpType, numorstring.DSCPFromInt(46)),
+		Entry("should accept 46 DSCP as string", "\"46\"", dscpType, numorstring.DSCPFromInt(46)),
+		Entry("should accept EF DSCP as string", "\"EF\"", dscpType, numorstring.DSCPFromString("EF")),
 		Entry("should reject bad protocol string", "\"25", protocolType, nil),
 	)
 
@@ -121,6 +127,10 @@ func init() {
 		// Protocol tests.
 		Entry("should marshal protocol of 0", numorstring.ProtocolFromInt(0), "0"),
 		Entry("should marshal protocol of udp", numorstring.ProtocolFromString("UDP"), "\"UDP\""),
+
+		// DSCP tests.
+		Entry("should marshal DSCP of 46", numorstring.DSCPFromInt(46), "46"),
+		Entry("should marshal DSCP of AF41", numorstring.DSCPFromString("af41"), "\"AF41\""),
 	)
 
 	// Perform tests of Stringer interface various field types.
@@ -141,6 +151,10 @@ func init() {
 		// Protocol tests.
 		Entry("should stringify protocol of 0", numorstring.ProtocolFromInt(0), "0"),
 		Entry("should stringify protocol of udp", numorstring.ProtocolFromString("UDP"), "UDP"),
+
+		// DSCP tests.
+		Entry("should stringify DSCP of 46", numorstring.DSCPFromInt(46), "46"),
+		Entry("should stringify DSCP of EF", numorstring.DSCPFromString("ef"), "EF"),
 	)
 
 	// Perform tests of Protocols supporting ports.
@@ -191,6 +205,28 @@ func init() {
 		Entry("protocol udp -> UDP", numorstring.ProtocolFromInt(2), numorstring.ProtocolFromInt(2)),
 		Entry("protocol tcp -> TCP", numorstring.ProtocolFromString("TCP"), numorstring.ProtocolFromStringV1("TCP")),
 	)
+
+	// Perform tests of DSCP NumValue method.
+	DescribeTable("NumOrStringDSCP NumValue",
+		func(dscp numorstring.DSCP, expected int) {
+			num, err := dscp.NumValue()
+			if expected < 0 {
+				Expect(err).To(HaveOccurred(), "expected DSCP to be invalid")
+			} else {
+				Expect(err).NotTo(HaveOccurred(), "expected DSCP to be valid")
+				Expect(num).To(Equal(uint8(expected)), "expected DSCP value to match")
+			}
+		},
+		Entry("DSCP 0", numorstring.DSCPFromInt(0), 0),
+		Entry("DSCP 63", numorstring.DSCPFromInt(63), 63),
+		Entry("DSCP 64 is out of range", numorstring.DSCPFromInt(64), -1),
+		Entry("DSCP \"10\"", numorstring.DSCPFromString("10"), 10),
+		Entry("DSCP EF", numorstring.DSCPFromString("EF"), 46),
+		Entry("DSCP af41", numorstring.DSCPFromString("af41"), 34),
+		Entry("DSCP CS3", numorstring.DSCPFromString("CS3"), 24),
+		Entry("DSCP DF", numorstring.DSCPFromString("DF"), 0),
+		Entry("unknown DSCP class", numorstring.DSCPFromString("AF44"), -1),
+	)
 }
 
 func portFromRange(minPort, maxPort uint16) numorstring.Port {
@@ -19,12 +19,14 @@ import (
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.AutoHostEndpointConfig":             schema_pkg_apis_projectcalico_v3_AutoHostEndpointConfig(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPBFD":                             schema_pkg_apis_projectcalico_v3_BGPBFD(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPConfiguration":                   schema_pkg_apis_projectcalico_v3_BGPConfiguration(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPConfigurationList":               schema_pkg_apis_projectcalico_v3_BGPConfigurationList(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPConfigurationSpec":               schema_pkg_apis_projectcalico_v3_BGPConfigurationSpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPDaemonStatus":                    schema_pkg_apis_projectcalico_v3_BGPDaemonStatus(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilter":                          schema_pkg_apis_projectcalico_v3_BGPFilter(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterList":                      schema_pkg_apis_projectcalico_v3_BGPFilterList(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterOperation":                 schema_pkg_apis_projectcalico_v3_BGPFilterOperation(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterPrefixLengthV4":            schema_pkg_apis_projectcalico_v3_BGPFilterPrefixLengthV4(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterPrefixLengthV6":            schema_pkg_apis_projectcalico_v3_BGPFilterPrefixLengthV6(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterRuleV4":                    schema_pkg_apis_projectcalico_v3_BGPFilterRuleV4(ref),
@@ -39,6 +41,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BlockAffinityList":                  schema_pkg_apis_projectcalico_v3_BlockAffinityList(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BlockAffinitySpec":                  schema_pkg_apis_projectcalico_v3_BlockAffinitySpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeAgentStatus":              schema_pkg_apis_projectcalico_v3_CalicoNodeAgentStatus(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBFDSession":               schema_pkg_apis_projectcalico_v3_CalicoNodeBFDSession(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBFDStatus":                schema_pkg_apis_projectcalico_v3_CalicoNodeBFDStatus(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBGPRouteStatus":           schema_pkg_apis_projectcalico_v3_CalicoNodeBGPRouteStatus(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBGPStatus":                schema_pkg_apis_projectcalico_v3_CalicoNodeBGPStatus(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodePeer":                     schema_pkg_apis_projectcalico_v3_CalicoNodePeer(ref),
@@ -58,12 +62,14 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.FelixConfiguration":                 schema_pkg_apis_projectcalico_v3_FelixConfiguration(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.FelixConfigurationList":             schema_pkg_apis_projectcalico_v3_FelixConfigurationList(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.FelixConfigurationSpec":             schema_pkg_apis_projectcalico_v3_FelixConfigurationSpec(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GRPCMethodMatch":                    schema_pkg_apis_projectcalico_v3_GRPCMethodMatch(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GlobalNetworkPolicy":                schema_pkg_apis_projectcalico_v3_GlobalNetworkPolicy(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GlobalNetworkPolicyList":            schema_pkg_apis_projectcalico_v3_GlobalNetworkPolicyList(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GlobalNetworkPolicySpec":            schema_pkg_apis_projectcalico_v3_GlobalNetworkPolicySpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GlobalNetworkSet":                   schema_pkg_apis_projectcalico_v3_GlobalNetworkSet(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GlobalNetworkSetList":               schema_pkg_apis_projectcalico_v3_GlobalNetworkSetList(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GlobalNetworkSetSpec":               schema_pkg_apis_projectcalico_v3_GlobalNetworkSetSpec(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPHeaderMatch":                    schema_pkg_apis_projectcalico_v3_HTTPHeaderMatch(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPMatch":                          schema_pkg_apis_projectcalico_v3_HTTPMatch(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPPath":                           schema_pkg_apis_projectcalico_v3_HTTPPath(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.HealthTimeoutOverride":              schema_pkg_apis_projectcalico_v3_HealthTimeoutOverride(ref),
@@ -77,6 +83,11 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPIPConfiguration":                  schema_pkg_apis_projectcalico_v3_IPIPConfiguration(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPool":                             schema_pkg_apis_projectcalico_v3_IPPool(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolList":                         schema_pkg_apis_projectcalico_v3_IPPoolList(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigration":                    schema_pkg_apis_projectcalico_v3_IPPoolMigration(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigrationList":                schema_pkg_apis_projectcalico_v3_IPPoolMigrationList(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigrationSpec":                schema_pkg_apis_projectcalico_v3_IPPoolMigrationSpec(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigrationStatus":              schema_pkg_apis_projectcalico_v3_IPPoolMigrationStatus(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolQuota":                        schema_pkg_apis_projectcalico_v3_IPPoolQuota(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolSpec":                         schema_pkg_apis_projectcalico_v3_IPPoolSpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPReservation":                      schema_pkg_apis_projectcalico_v3_IPReservation(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPReservationList":                  schema_pkg_apis_projectcalico_v3_IPReservationList(ref),
@@ -95,11 +106,16 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.NetworkSetSpec":                     schema_pkg_apis_projectcalico_v3_NetworkSetSpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.NodeControllerConfig":               schema_pkg_apis_projectcalico_v3_NodeControllerConfig(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.PolicyControllerConfig":             schema_pkg_apis_projectcalico_v3_PolicyControllerConfig(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.PolicyScheduleWindow":               schema_pkg_apis_projectcalico_v3_PolicyScheduleWindow(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.PrefixAdvertisement":                schema_pkg_apis_projectcalico_v3_PrefixAdvertisement(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.Profile":                            schema_pkg_apis_projectcalico_v3_Profile(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.ProfileList":                        schema_pkg_apis_projectcalico_v3_ProfileList(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.ProfileSpec":                        schema_pkg_apis_projectcalico_v3_ProfileSpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.ProtoPort":                          schema_pkg_apis_projectcalico_v3_ProtoPort(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicy":                          schema_pkg_apis_projectcalico_v3_QoSPolicy(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicyControls":                  schema_pkg_apis_projectcalico_v3_QoSPolicyControls(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicyList":                      schema_pkg_apis_projectcalico_v3_QoSPolicyList(ref),
+		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicySpec":                      schema_pkg_apis_projectcalico_v3_QoSPolicySpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.RouteTableIDRange":                  schema_pkg_apis_projectcalico_v3_RouteTableIDRange(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.RouteTableRange":                    schema_pkg_apis_projectcalico_v3_RouteTableRange(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.Rule":                               schema_pkg_apis_projectcalico_v3_Rule(ref),
@@ -124,6 +140,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.TierList":                           schema_pkg_apis_projectcalico_v3_TierList(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.TierSpec":                           schema_pkg_apis_projectcalico_v3_TierSpec(ref),
 		"github.com/projectcalico/api/pkg/apis/projectcalico/v3.WorkloadEndpointControllerConfig":   schema_pkg_apis_projectcalico_v3_WorkloadEndpointControllerConfig(ref),
+		"github.com/projectcalico/api/pkg/lib/numorstring.DSCP":                                     schema_api_pkg_lib_numorstring_DSCP(ref),
 		"github.com/projectcalico/api/pkg/lib/numorstring.Port":                                     schema_api_pkg_lib_numorstring_Port(ref),
 		"github.com/projectcalico/api/pkg/lib/numorstring.Protocol":                                 schema_api_pkg_lib_numorstring_Protocol(ref),
 		"github.com/projectcalico/api/pkg/lib/numorstring.Uint8OrString":                            schema_api_pkg_lib_numorstring_Uint8OrString(ref),
@@ -481,6 +498,47 @@ func schema_pkg_apis_projectcalico_v3_AutoHostEndpointConfig(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_BGPBFD(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BGPBFD contains the Bidirectional Forwarding Detection settings for a set of BGP sessions. BIRD uses one set of BFD timers for all the BFD sessions on a node, so where the peerings of a node ask for different timers, the smallest value of each timer is used.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"enabled": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enabled sets whether BFD is used to detect the failure of the BGP sessions. When a BFD session goes down, BIRD closes the corresponding BGP session. [Default: false]",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"minRxInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinRxInterval is the minimum interval between received BFD control packets that this node supports. [Default: 10ms]",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"minTxInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinTxInterval is the minimum interval at which this node sends BFD control packets while the session is up. [Default: 100ms]",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"multiplier": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Multiplier is the number of consecutive BFD control packets that can be missed before the session is declared down. [Default: 5]",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_BGPConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -687,6 +745,12 @@ func schema_pkg_apis_projectcalico_v3_BGPConfigurationSpec(ref common.ReferenceC
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"nodeMeshBFD": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BFD settings for node-to-node mesh peerings.  When enabled, Bidirectional Forwarding Detection is used to detect the failure of a mesh peer. This field can only be set on the default BGPConfiguration instance and requires that NodeMesh is enabled",
+							Ref:         ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPBFD"),
+						},
+					},
 					"bindMode": {
 						SchemaProps: spec.SchemaProps{
 							Description: "BindMode indicates whether to listen for BGP connections on all addresses (None) or only on the node's canonical IP address Node.Spec.BGP.IPvXAddress (NodeIP). Default behaviour is to listen for BGP connections on all addresses.",
@@ -727,7 +791,7 @@ func schema_pkg_apis_projectcalico_v3_BGPConfigurationSpec(ref common.ReferenceC
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPPassword", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.Community", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.PrefixAdvertisement", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ServiceClusterIPBlock", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ServiceExternalIPBlock", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ServiceLoadBalancerIPBlock", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPBFD", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPPassword", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.Community", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.PrefixAdvertisement", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ServiceClusterIPBlock", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ServiceExternalIPBlock", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ServiceLoadBalancerIPBlock", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -868,6 +932,62 @@ func schema_pkg_apis_projectcalico_v3_BGPFilterList(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_BGPFilterOperation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BGPFilterOperation is a modification made to a route matched by a BGPFilter rule.  Exactly one field must be set.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"addCommunity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AddCommunity adds a standard (`aa:nn`) or large (`aa:nn:mm`) community to the route.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"removeCommunity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemoveCommunity removes a standard (`aa:nn`) or large (`aa:nn:mm`) community from the route.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"setMED": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SetMED sets the multi-exit discriminator of the route.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"setLocalPreference": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SetLocalPreference sets the local preference of the route.  It only has an effect on imported routes and on routes exported to iBGP peers.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"prependASPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrependASPath prepends the given AS numbers to the AS path of the route, so that the first AS number in the list becomes the first in the path.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_BGPFilterPrefixLengthV4(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -952,6 +1072,50 @@ func schema_pkg_apis_projectcalico_v3_BGPFilterRuleV4(ref common.ReferenceCallba
 							Format: "",
 						},
 					},
+					"communities": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Communities, if set, matches routes that carry at least one of the given communities. For standard communities use `aa:nn` format, where `aa` and `nn` are 16 bit numbers. For large communities use `aa:nn:mm` format, where `aa`, `nn` and `mm` are 32 bit numbers.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"asPathPrefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ASPathPrefix, if set, matches routes whose AS path starts with the given sequence of AS numbers.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
+									},
+								},
+							},
+						},
+					},
+					"operations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operations is an ordered list of modifications that are made to matching routes before the action is applied.  Operations cannot be used with the Reject action.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterOperation"),
+									},
+								},
+							},
+						},
+					},
 					"action": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
@@ -964,7 +1128,7 @@ func schema_pkg_apis_projectcalico_v3_BGPFilterRuleV4(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterPrefixLengthV4"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterOperation", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterPrefixLengthV4"},
 	}
 }
 
@@ -1004,6 +1168,50 @@ func schema_pkg_apis_projectcalico_v3_BGPFilterRuleV6(ref common.ReferenceCallba
 							Format: "",
 						},
 					},
+					"communities": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Communities, if set, matches routes that carry at least one of the given communities. For standard communities use `aa:nn` format, where `aa` and `nn` are 16 bit numbers. For large communities use `aa:nn:mm` format, where `aa`, `nn` and `mm` are 32 bit numbers.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"asPathPrefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ASPathPrefix, if set, matches routes whose AS path starts with the given sequence of AS numbers.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int64",
+									},
+								},
+							},
+						},
+					},
+					"operations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operations is an ordered list of modifications that are made to matching routes before the action is applied.  Operations cannot be used with the Reject action.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterOperation"),
+									},
+								},
+							},
+						},
+					},
 					"action": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
@@ -1016,7 +1224,7 @@ func schema_pkg_apis_projectcalico_v3_BGPFilterRuleV6(ref common.ReferenceCallba
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterPrefixLengthV6"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterOperation", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPFilterPrefixLengthV6"},
 	}
 }
 
@@ -1312,11 +1520,17 @@ func schema_pkg_apis_projectcalico_v3_BGPPeerSpec(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"bfd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BFD configures Bidirectional Forwarding Detection for the peerings generated by this BGPPeer resource, so that the failure of a peer is detected in well under a second rather than when the BGP hold timer expires.",
+							Ref:         ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPBFD"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPPassword", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPBFD", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.BGPPassword", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -1554,6 +1768,104 @@ func schema_pkg_apis_projectcalico_v3_CalicoNodeAgentStatus(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_CalicoNodeBFDSession(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CalicoNodeBFDSession contains the status of a BFD session on the node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"peerIP": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IP address of the peer whose session we are reporting.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"interface": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interface that the session runs over.  Empty for multihop sessions.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State is the BFD session state.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"since": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Since the state last changed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interval is the interval at which BFD control packets are sent.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is the time without a received BFD control packet after which the session is declared down.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_CalicoNodeBFDStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CalicoNodeBFDStatus defines the observed state of BFD sessions on the node.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sessionsV4": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SessionsV4 represents IPv4 BFD sessions on the node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBFDSession"),
+									},
+								},
+							},
+						},
+					},
+					"sessionsV6": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SessionsV6 represents IPv6 BFD sessions on the node.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBFDSession"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBFDSession"},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_CalicoNodeBGPRouteStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1962,11 +2274,18 @@ func schema_pkg_apis_projectcalico_v3_CalicoNodeStatusStatus(ref common.Referenc
 							Ref:         ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBGPRouteStatus"),
 						},
 					},
+					"bfd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BFD holds the status of the BFD sessions on the node.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBFDStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeAgentStatus", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBGPRouteStatus", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBGPStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeAgentStatus", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBFDStatus", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBGPRouteStatus", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.CalicoNodeBGPStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -2315,6 +2634,21 @@ func schema_pkg_apis_projectcalico_v3_EntityRule(ref common.ReferenceCallback) c
 							Ref:         ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.ServiceAccountMatch"),
 						},
 					},
+					"domains": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Domains is an optional field, valid only on the destination of egress rules, that restricts the rule to traffic to IP addresses that the domain names resolve to. Felix learns the addresses by snooping the DNS responses that workloads receive over UDP from the FelixConfiguration DNSTrustedServers; names resolved over TCP, or through other servers, are not learned. A leading \"*.\" matches any subdomain, so \"*.example.com\" matches \"api.example.com\" but not \"example.com\".\n\nDomains cannot be specified on the same rule as Selector, NotSelector, NamespaceSelector, Nets, NotNets or Services.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -3424,7 +3758,69 @@ func schema_pkg_apis_projectcalico_v3_FelixConfigurationSpec(ref common.Referenc
 							Format:      "",
 						},
 					},
-					"bpfProfiling": {
+					"flowLogsIPFIXCollector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FlowLogsIPFIXCollector is the address, in host:port form, of an IPFIX collector to which Felix should export flow records over UDP. IPFIX records are not aggregated, each describes a single connection. [Default: unset - IPFIX export is disabled]",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"flowLogsIPFIXObservationDomainID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FlowLogsIPFIXObservationDomainID is the observation domain ID included in the IPFIX messages that Felix sends. Collectors use it to distinguish between exporters. [Default: 0]",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"flowLogsIPFIXEnterpriseNumber": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FlowLogsIPFIXEnterpriseNumber is the IANA Private Enterprise Number under which Felix exports Calico-specific information elements, such as endpoint names, namespaces and policies, in IPFIX records. When zero, only standard information elements are exported. [Default: 0]",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"flowLogsIPFIXTemplateRefreshInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FlowLogsIPFIXTemplateRefreshInterval is the interval at which Felix resends its IPFIX templates, so that collectors that restart can decode its records. [Default: 10m]",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"dnsPolicyMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSPolicyMode controls whether Felix snoops on the DNS responses sent to local workloads in order to learn the IPs of the domains used in egress policy rules. Only responses from the DNSTrustedServers that answer a query the workload sent are used, and only DNS over UDP is snooped; answers received over TCP are not learned. With NoDelay, responses are released immediately, so a workload may try to connect before the learned IPs are programmed. With DelayDNSResponse, responses that contain new information are held until the dataplane has been updated, or until DNSPolicyNfqueueMaxHoldDuration has passed. Snooping is not supported in BPF mode. [Default: Disabled]",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsPolicyNfqueueID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSPolicyNfqueueID is the NFQUEUE number that Felix uses to snoop on DNS responses. [Default: 100]",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"dnsPolicyNfqueueMaxHoldDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSPolicyNfqueueMaxHoldDuration is the longest time that Felix holds a DNS response while waiting for the dataplane to be updated, when DNSPolicyMode is DelayDNSResponse. [Default: 3s]",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"dnsTrustedServers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DNSTrustedServers is the list of DNS servers (IPs or CIDRs) whose responses Felix snoops on when DNSPolicyMode is enabled. A server is matched on the address that the workload sent its query to, before any DNAT, so a Kubernetes DNS service can be trusted by listing its cluster IP. [Default: none]",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"bpfProfiling": {
 						SchemaProps: spec.SchemaProps{
 							Description: "BPFProfiling controls profiling of BPF programs. At the monent, it can be Disabled or Enabled. [Default: Disabled]",
 							Type:        []string{"string"},
@@ -3548,6 +3944,12 @@ func schema_pkg_apis_projectcalico_v3_FelixConfigurationSpec(ref common.Referenc
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"wireguardKeyRotationInterval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WireguardKeyRotationInterval controls how often Felix rotates the node's Wireguard key pair. When it is time to rotate, Felix generates a new key pair and publishes the new public key alongside the current one so that peers can prepare for the switch; after a short grace period it moves the Wireguard device over to the new key. Set 0 to disable. [Default: 0]",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 					"awsSrcDstCheck": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AWSSrcDstCheck controls whether Felix will try to change the \"source/dest check\" setting on the EC2 instance on which it is running. A value of \"Disable\" will try to disable the source/dest check. Disabling the check allows for sending workload traffic without encapsulation within the same AWS subnet. [Default: DoNothing]",
@@ -3619,6 +4021,35 @@ func schema_pkg_apis_projectcalico_v3_FelixConfigurationSpec(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_GRPCMethodMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GRPCMethodMatch specifies a gRPC service, and optionally a method of that service, to match.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"service": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Service is the fully qualified name of the gRPC service, e.g. \"helloworld.Greeter\".",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"method": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Method is an optional method name. If omitted, all methods of the service are matched.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"service"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_GlobalNetworkPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3828,11 +4259,37 @@ func schema_pkg_apis_projectcalico_v3_GlobalNetworkPolicySpec(ref common.Referen
 							},
 						},
 					},
+					"activeFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveFrom is an optional time before which the policy is not enforced: until then, Calico behaves as if the policy did not exist.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"activeUntil": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveUntil is an optional time from which the policy is no longer enforced.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"activeSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveSchedule optionally restricts the policy to recurring windows of time, such as a weekly maintenance window.  If set, the policy is only enforced during one of the windows (and between ActiveFrom and ActiveUntil, if they are set).",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.PolicyScheduleWindow"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.Rule"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.PolicyScheduleWindow", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.Rule", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -3953,11 +4410,54 @@ func schema_pkg_apis_projectcalico_v3_GlobalNetworkSetSpec(ref common.ReferenceC
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_HTTPHeaderMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HTTPHeaderMatch specifies an HTTP request header to match. Name is required and at most one of Exact, Regex or Present may be specified. If none are specified, the rule matches any request that has the header.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the header to match. Header names are matched case-insensitively.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"exact": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Exact matches requests whose header value is exactly equal to the given value.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"regex": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Regex matches requests whose header value matches the given RE2 regular expression. The whole value must match.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"present": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Present, if true, matches requests that have the header, with any value. If false, it matches requests that do not have the header.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_HTTPMatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "HTTPMatch is an optional field that apply only to HTTP requests The Methods and Path fields are joined with AND",
+				Description: "HTTPMatch is an optional field that apply only to HTTP requests The Methods, Paths, Headers, Hosts and GRPCMethods fields are joined with AND",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"methods": {
@@ -3989,11 +4489,54 @@ func schema_pkg_apis_projectcalico_v3_HTTPMatch(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Headers is an optional field that restricts the rule to apply to HTTP requests whose headers match all of the listed header matches. Multiple headers are AND'd together. e.g: - {name: x-version, exact: v2} - {name: authorization, present: true}",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPHeaderMatch"),
+									},
+								},
+							},
+						},
+					},
+					"hosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hosts is an optional field that restricts the rule to apply to HTTP requests for one of the listed hosts, as given by the :authority pseudo-header (or the Host header for HTTP/1.1). Hosts are matched case-insensitively. A host may start with \"*.\" to match any subdomain. If a host does not specify a port, it matches requests for any port. Multiple hosts are OR'd together.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"grpcMethods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GRPCMethods is an optional field that restricts the rule to apply to gRPC requests for one of the listed services or methods. Multiple methods are OR'd together.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.GRPCMethodMatch"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPPath"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.GRPCMethodMatch", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPHeaderMatch", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPPath"},
 	}
 }
 
@@ -4472,6 +5015,231 @@ func schema_pkg_apis_projectcalico_v3_IPPoolList(ref common.ReferenceCallback) c
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_IPPoolMigration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IPPoolMigration moves the pods using addresses from one IP pool to another.  The Calico kube-controllers disable the old pool, then evict the pods using its addresses in batches, respecting PodDisruptionBudgets, so that their controllers recreate them with addresses from the new pool.  Once no addresses in the old pool are assigned, including tunnel addresses and those assigned outside of Kubernetes, the pool and its blocks are deleted.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigrationSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigrationStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigrationSpec", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigrationStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_IPPoolMigrationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IPPoolMigrationList contains a list of IPPoolMigration resources.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigration"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolMigration", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_IPPoolMigrationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IPPoolMigrationSpec contains the specification for an IPPoolMigration resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fromPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FromPool is the name of the IP pool to move pods out of.  The pool is disabled when the migration starts, and deleted when it completes.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"toPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ToPool is the name of the IP pool that the pods should get their new addresses from.  It must be enabled, and select the nodes that the old pool selects.  The migration doesn't start while any other enabled pool assigns addresses to pods on those nodes.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"batchSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BatchSize is the maximum number of pods that are being moved at any one time. [Default: 10]",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"fromPool", "toPool"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_IPPoolMigrationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IPPoolMigrationStatus reports the progress of an IPPoolMigration.  It is updated by the Calico kube-controllers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the stage that the migration has reached: Pending, Migrating, Complete or Failed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message describes the current state of the migration, for example why it has failed or what it is waiting for.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"totalPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TotalPods is the number of pods that were using addresses from the old pool when the migration started.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"remainingPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemainingPods is the number of pods that are still using addresses from the old pool.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"unmanagedPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UnmanagedPods is the number of remaining pods that aren't owned by a controller.  They would not be recreated if they were evicted, so they must be deleted manually.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is when the migration started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is when the old pool was deleted.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_IPPoolQuota(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IPPoolQuota limits the number of addresses from an IP pool that may be assigned to the pods in each of a set of namespaces.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector selects the namespaces that the quota applies to, based on the namespaces' labels.  The limit applies to each selected namespace separately; for example, \"all()\" limits every namespace and \"projectcalico.org/name == 'dev'\" limits only the \"dev\" namespace.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxAddresses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxAddresses is the maximum number of addresses from the pool that may be assigned to the pods in each selected namespace.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"namespaceSelector", "maxAddresses"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_IPPoolSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4564,19 +5332,33 @@ func schema_pkg_apis_projectcalico_v3_IPPoolSpec(ref common.ReferenceCallback) c
 							},
 						},
 					},
-					"assignmentMode": {
-						SchemaProps: spec.SchemaProps{
-							Description: "Determines the mode how IP addresses should be assigned from this pool",
-							Type:        []string{"string"},
-							Format:      "",
-						},
-					},
+					"assignmentMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Determines the mode how IP addresses should be assigned from this pool",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"quotas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quotas limit the number of addresses from this pool that Calico IPAM will assign to the pods in each namespace.  If more than one quota selects a namespace, the lowest limit applies.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolQuota"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"cidr"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPIPConfiguration"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPIPConfiguration", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.IPPoolQuota"},
 	}
 }
 
@@ -5108,11 +5890,37 @@ func schema_pkg_apis_projectcalico_v3_NetworkPolicySpec(ref common.ReferenceCall
 							},
 						},
 					},
+					"activeFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveFrom is an optional time before which the policy is not enforced: until then, Calico behaves as if the policy did not exist.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"activeUntil": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveUntil is an optional time from which the policy is no longer enforced.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"activeSchedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ActiveSchedule optionally restricts the policy to recurring windows of time, such as a weekly maintenance window.  If set, the policy is only enforced during one of the windows (and between ActiveFrom and ActiveUntil, if they are set).",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.PolicyScheduleWindow"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.Rule"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.PolicyScheduleWindow", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.Rule", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -5265,6 +6073,12 @@ func schema_pkg_apis_projectcalico_v3_NodeControllerConfig(ref common.ReferenceC
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"ipamDefragPeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IPAMDefragPeriod is the period at which the controller drains sparsely-used IPAM blocks and releases the affinity of blocks that have drained, so that their addresses can be used by other nodes. Set to 0 to disable IPAM block defragmentation. [Default: 0]",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
 			},
 		},
@@ -5294,6 +6108,58 @@ func schema_pkg_apis_projectcalico_v3_PolicyControllerConfig(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_PolicyScheduleWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PolicyScheduleWindow is a recurring window of time during which a policy is enforced.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"days": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Days are the days of the week on which the window starts, for example \"Saturday\".  If omitted, the window starts every day.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time of day at which the window starts, in 24-hour \"HH:MM\" format.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"endTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EndTime is the time of day at which the window ends, in 24-hour \"HH:MM\" format.  If it is not after StartTime, the window ends on the following day.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the IANA name of the time zone that StartTime and EndTime are in, for example \"Europe/London\". [Default: UTC]",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"startTime", "endTime"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_PrefixAdvertisement(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5510,6 +6376,216 @@ func schema_pkg_apis_projectcalico_v3_ProtoPort(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_projectcalico_v3_QoSPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QoSPolicy applies bandwidth, packet rate and connection limits to the workloads that it selects, as an alternative to setting the qos.projectcalico.org annotations on each pod.  When more than one QoSPolicy selects a workload, only the one that is first in order is applied.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicySpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicySpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_QoSPolicyControls(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QoSPolicyControls are the QoS limits that a QoSPolicy may set.  Unset fields are not limited by the QoS policy.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ingressBandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IngressBandwidth is the maximum bandwidth, in bits per second, of traffic to the workload.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"egressBandwidth": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EgressBandwidth is the maximum bandwidth, in bits per second, of traffic from the workload.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"ingressBurst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IngressBurst is the burst size, in bits, of traffic to the workload.  It may only be set along with IngressBandwidth, and must be at least as large.  [Default: 4Gi]",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"egressBurst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EgressBurst is the burst size, in bits, of traffic from the workload.  It may only be set along with EgressBandwidth, and must be at least as large.  [Default: 4Gi]",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"ingressPacketRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IngressPacketRate is the maximum rate, in packets per second, of traffic to the workload.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"egressPacketRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EgressPacketRate is the maximum rate, in packets per second, of traffic from the workload.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"ingressMaxConnections": {
+						SchemaProps: spec.SchemaProps{
+							Description: "IngressMaxConnections is the maximum number of connections to the workload.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"egressMaxConnections": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EgressMaxConnections is the maximum number of connections from the workload.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_QoSPolicyList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QoSPolicyList contains a list of QoSPolicy resources.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicy"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicy", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_projectcalico_v3_QoSPolicySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "QoSPolicySpec contains the specification for a QoSPolicy resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"order": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Order is an optional field that specifies the order in which the QoS policy is considered. When more than one QoS policy selects a workload, the one with the lowest order is applied, and QoS policies with the same order are ordered by name.  QoS policies without an order come after all of those with one.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector is an expression used to pick out the namespaces of the workloads that the QoS policy applies to.  If empty, workloads in any namespace may be selected.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is an expression used to pick out the workloads that the QoS policy applies to. If empty, all workloads in the selected namespaces are selected.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"controls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Controls are the limits applied to the selected workloads.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicyControls"),
+						},
+					},
+					"podAnnotationOverride": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PodAnnotationOverride controls whether the QoS annotations of a selected pod may override the limits set by this QoS policy: - Allow: the pod annotations take precedence over the QoS policy. - StricterOnly: a pod annotation takes precedence only if it sets a lower limit. - Deny: the pod annotations are ignored for the limits that the QoS policy sets. Pod annotations always apply to the limits that the QoS policy doesn't set. [Default: Allow]",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"controls"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.QoSPolicyControls"},
+	}
+}
+
 func schema_pkg_apis_projectcalico_v3_RouteTableIDRange(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5629,6 +6705,12 @@ func schema_pkg_apis_projectcalico_v3_Rule(ref common.ReferenceCallback) common.
 							Ref:         ref("github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPMatch"),
 						},
 					},
+					"dscp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DSCP is the Differentiated Services Code Point that a SetDSCP rule sets on the packets that it matches, either as a number between 0 and 63, or as the name of a standard class, such as \"EF\", \"AF41\" or \"CS3\".  Required if, and only if, the Action is SetDSCP.\n\nSetDSCP rules may only be used in the egress rules of policies that are not DoNotTrack.  They are evaluated against every packet that the endpoint sends on the connections that it opens, independently of the rules that allow or deny traffic: the first SetDSCP rule, in policy order, that matches a packet sets its DSCP.  Replies to connections that the endpoint accepted are not marked.  If the packet is encapsulated (VXLAN or IPIP) on its way to another node, the DSCP is copied to the outer header.",
+							Ref:         ref("github.com/projectcalico/api/pkg/lib/numorstring.DSCP"),
+						},
+					},
 					"metadata": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Metadata contains additional information for this rule",
@@ -5640,7 +6722,7 @@ func schema_pkg_apis_projectcalico_v3_Rule(ref common.ReferenceCallback) common.
 			},
 		},
 		Dependencies: []string{
-			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.EntityRule", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPMatch", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ICMPFields", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.RuleMetadata", "github.com/projectcalico/api/pkg/lib/numorstring.Protocol"},
+			"github.com/projectcalico/api/pkg/apis/projectcalico/v3.EntityRule", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.HTTPMatch", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.ICMPFields", "github.com/projectcalico/api/pkg/apis/projectcalico/v3.RuleMetadata", "github.com/projectcalico/api/pkg/lib/numorstring.DSCP", "github.com/projectcalico/api/pkg/lib/numorstring.Protocol"},
 	}
 }
 
@@ -6597,6 +7679,17 @@ func schema_pkg_apis_projectcalico_v3_WorkloadEndpointControllerConfig(ref commo
 	}
 }
 
+func schema_api_pkg_lib_numorstring_DSCP(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type:   numorstring.DSCP{}.OpenAPISchemaType(),
+				Format: numorstring.DSCP{}.OpenAPISchemaFormat(),
+			},
+		},
+	}
+}
+
 func schema_api_pkg_lib_numorstring_Port(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
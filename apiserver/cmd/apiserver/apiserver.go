@@ -21,6 +21,7 @@ package main
 import (
 	"os"
 	"runtime"
+	_ "time/tzdata" // Embed the time zone database, used for policy schedules, since images may not have one.
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apiserver/pkg/features"
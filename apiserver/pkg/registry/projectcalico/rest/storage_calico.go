@@ -36,11 +36,13 @@ import (
 	calicohostendpoint "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/hostendpoint"
 	calicoipamconfig "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ipamconfig"
 	calicoippool "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ippool"
+	calicoippoolmigration "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ippoolmigration"
 	calicoipreservation "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/ipreservation"
 	calicokubecontrollersconfig "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/kubecontrollersconfig"
 	calicopolicy "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/networkpolicy"
 	caliconetworkset "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/networkset"
 	calicoprofile "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/profile"
+	calicoqospolicy "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/qospolicy"
 	"github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/server"
 	calicostagedgpolicy "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/stagedglobalnetworkpolicy"
 	calicostagedk8spolicy "github.com/projectcalico/calico/apiserver/pkg/registry/projectcalico/stagedkubernetesnetworkpolicy"
@@ -285,6 +287,50 @@ func (p RESTStorageProvider) NewV3Storage(
 		[]string{},
 	)
 
+	ipPoolMigrationRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("ippoolmigrations"), nil)
+	if err != nil {
+		return nil, err
+	}
+	ipPoolMigrationSetOpts := server.NewOptions(
+		etcd.Options{
+			RESTOptions:   ipPoolMigrationRESTOptions,
+			Capacity:      10,
+			ObjectType:    calicoippoolmigration.EmptyObject(),
+			ScopeStrategy: calicoippoolmigration.NewStrategy(scheme),
+			NewListFunc:   calicoippoolmigration.NewList,
+			GetAttrsFunc:  calicoippoolmigration.GetAttrs,
+			Trigger:       nil,
+		},
+		calicostorage.Options{
+			RESTOptions: ipPoolMigrationRESTOptions,
+		},
+		p.StorageType,
+		authorizer,
+		[]string{},
+	)
+
+	qosPolicyRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("qospolicies"), nil)
+	if err != nil {
+		return nil, err
+	}
+	qosPolicySetOpts := server.NewOptions(
+		etcd.Options{
+			RESTOptions:   qosPolicyRESTOptions,
+			Capacity:      10,
+			ObjectType:    calicoqospolicy.EmptyObject(),
+			ScopeStrategy: calicoqospolicy.NewStrategy(scheme),
+			NewListFunc:   calicoqospolicy.NewList,
+			GetAttrsFunc:  calicoqospolicy.GetAttrs,
+			Trigger:       nil,
+		},
+		calicostorage.Options{
+			RESTOptions: qosPolicyRESTOptions,
+		},
+		p.StorageType,
+		authorizer,
+		[]string{},
+	)
+
 	ipReservationRESTOptions, err := restOptionsGetter.GetRESTOptions(calico.Resource("ipreservations"), nil)
 	if err != nil {
 		return nil, err
@@ -538,6 +584,8 @@ func (p RESTStorageProvider) NewV3Storage(
 	storage["networksets"] = rESTInPeace(caliconetworkset.NewREST(scheme, *networksetOpts))
 	storage["hostendpoints"] = rESTInPeace(calicohostendpoint.NewREST(scheme, *hostEndpointOpts))
 	storage["ippools"] = rESTInPeace(calicoippool.NewREST(scheme, *ipPoolSetOpts))
+	storage["ippoolmigrations"] = rESTInPeace(calicoippoolmigration.NewREST(scheme, *ipPoolMigrationSetOpts))
+	storage["qospolicies"] = rESTInPeace(calicoqospolicy.NewREST(scheme, *qosPolicySetOpts))
 	storage["ipreservations"] = rESTInPeace(calicoipreservation.NewREST(scheme, *ipReservationSetOpts))
 	storage["bgpconfigurations"] = rESTInPeace(calicobgpconfiguration.NewREST(scheme, *bgpConfigurationOpts))
 	storage["bgppeers"] = rESTInPeace(calicobgppeer.NewREST(scheme, *bgpPeerOpts))
@@ -83,6 +83,14 @@ func convertToAAPI(libcalicoObject runtime.Object) (res runtime.Object) {
 		aapi := &v3.IPPool{}
 		IPPoolConverter{}.convertToAAPI(obj, aapi)
 		return aapi
+	case *v3.IPPoolMigration:
+		aapi := &v3.IPPoolMigration{}
+		IPPoolMigrationConverter{}.convertToAAPI(obj, aapi)
+		return aapi
+	case *v3.QoSPolicy:
+		aapi := &v3.QoSPolicy{}
+		QoSPolicyConverter{}.convertToAAPI(obj, aapi)
+		return aapi
 	case *v3.IPReservation:
 		aapi := &v3.IPReservation{}
 		IPReservationConverter{}.convertToAAPI(obj, aapi)
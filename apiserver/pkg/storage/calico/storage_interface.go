@@ -45,6 +45,10 @@ func NewStorage(opts Options) (registry.DryRunnableStorage, factory.DestroyFunc)
 		return NewHostEndpointStorage(opts)
 	case "projectcalico.org/ippools":
 		return NewIPPoolStorage(opts)
+	case "projectcalico.org/ippoolmigrations":
+		return NewIPPoolMigrationStorage(opts)
+	case "projectcalico.org/qospolicies":
+		return NewQoSPolicyStorage(opts)
 	case "projectcalico.org/ipreservations":
 		return NewIPReservationStorage(opts)
 	case "projectcalico.org/bgpconfigurations":
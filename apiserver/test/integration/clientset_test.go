@@ -1163,6 +1163,78 @@ func testIPReservationClient(client calicoclient.Interface, name string) error {
 	return nil
 }
 
+// TestIPPoolMigrationClient exercises the IPPoolMigration client.
+func TestIPPoolMigrationClient(t *testing.T) {
+	const name = "test-ippoolmigration"
+	rootTestFunc := func() func(t *testing.T) {
+		return func(t *testing.T) {
+			client, shutdownServer := getFreshApiserverAndClient(t, func() runtime.Object {
+				return &v3.IPPoolMigration{}
+			})
+			defer shutdownServer()
+			if err := testIPPoolMigrationClient(client, name); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if !t.Run(name, rootTestFunc()) {
+		t.Errorf("test-ippoolmigration test failed")
+	}
+}
+
+func testIPPoolMigrationClient(client calicoclient.Interface, name string) error {
+	migrationClient := client.ProjectcalicoV3().IPPoolMigrations()
+	migration := &v3.IPPoolMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v3.IPPoolMigrationSpec{
+			FromPool: "old-pool",
+			ToPool:   "new-pool",
+		},
+	}
+	ctx := context.Background()
+
+	// start from scratch
+	migrations, err := migrationClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing ippoolmigrations (%s)", err)
+	}
+	if migrations.Items == nil {
+		return fmt.Errorf("items field should not be set to nil")
+	}
+
+	migrationServer, err := migrationClient.Create(ctx, migration, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error creating the ippoolmigration '%v' (%v)", migration, err)
+	}
+	if name != migrationServer.Name {
+		return fmt.Errorf("didn't get the same ippoolmigration back from the server \n%+v\n%+v", migration, migrationServer)
+	}
+
+	// The status is stored along with the spec.
+	migrationServer.Status.Phase = v3.IPPoolMigrationPhaseMigrating
+	migrationServer.Status.RemainingPods = 3
+	_, err = migrationClient.Update(ctx, migrationServer, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("error updating the ippoolmigration '%v' (%v)", migrationServer, err)
+	}
+
+	migrationServer, err = migrationClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting ippoolmigration %s (%s)", name, err)
+	}
+	if migrationServer.Status.Phase != v3.IPPoolMigrationPhaseMigrating || migrationServer.Status.RemainingPods != 3 {
+		return fmt.Errorf("didn't get the updated ippoolmigration status back from the server \n%+v", migrationServer.Status)
+	}
+
+	err = migrationClient.Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("ippoolmigration should be deleted (%s)", err)
+	}
+
+	return nil
+}
+
 // TestHostEndpointClient exercises the HostEndpoint client.
 func TestHostEndpointClient(t *testing.T) {
 	const name = "test-hostendpoint"
@@ -2265,19 +2337,19 @@ func testBGPFilterClient(client calicoclient.Interface, name string) error {
 	}
 
 	for i := 0; i < size; i++ {
-		if bgpFilterNew.Spec.ExportV4[i] != bgpFilter.Spec.ExportV4[i] {
+		if !reflect.DeepEqual(bgpFilterNew.Spec.ExportV4[i], bgpFilter.Spec.ExportV4[i]) {
 			return fmt.Errorf("didn't get the correct object back from the server. Incorrect ExportV4: \n%+v\n%+v",
 				bgpFilter.Spec.ExportV4, bgpFilterNew.Spec.ExportV4)
 		}
-		if bgpFilterNew.Spec.ImportV4[i] != bgpFilter.Spec.ImportV4[i] {
+		if !reflect.DeepEqual(bgpFilterNew.Spec.ImportV4[i], bgpFilter.Spec.ImportV4[i]) {
 			return fmt.Errorf("didn't get the correct object back from the server. Incorrect ImportV4: \n%+v\n%+v",
 				bgpFilter.Spec.ImportV4, bgpFilterNew.Spec.ImportV4)
 		}
-		if bgpFilterNew.Spec.ExportV6[i] != bgpFilter.Spec.ExportV6[i] {
+		if !reflect.DeepEqual(bgpFilterNew.Spec.ExportV6[i], bgpFilter.Spec.ExportV6[i]) {
 			return fmt.Errorf("didn't get the correct object back from the server. Incorrect ExportV6: \n%+v\n%+v",
 				bgpFilter.Spec.ExportV6, bgpFilterNew.Spec.ExportV6)
 		}
-		if bgpFilterNew.Spec.ImportV6[i] != bgpFilter.Spec.ImportV6[i] {
+		if !reflect.DeepEqual(bgpFilterNew.Spec.ImportV6[i], bgpFilter.Spec.ImportV6[i]) {
 			return fmt.Errorf("didn't get the correct object back from the server. Incorrect ImportV6: \n%+v\n%+v",
 				bgpFilter.Spec.ImportV6, bgpFilterNew.Spec.ImportV6)
 		}
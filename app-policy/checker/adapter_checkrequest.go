@@ -89,6 +89,26 @@ func (a *CheckRequestToFlowAdapter) GetHttpPath() *string {
 	return &path
 }
 
+func (a *CheckRequestToFlowAdapter) GetHttpHost() *string {
+	if a.flow == nil || a.flow.GetAttributes().GetRequest().GetHttp() == nil {
+		return nil
+	}
+	host := a.flow.GetAttributes().GetRequest().GetHttp().GetHost()
+	return &host
+}
+
+func (a *CheckRequestToFlowAdapter) GetHttpHeaders() map[string]string {
+	if a.flow == nil || a.flow.GetAttributes().GetRequest().GetHttp() == nil {
+		return nil
+	}
+	headers := a.flow.GetAttributes().GetRequest().GetHttp().GetHeaders()
+	if headers == nil {
+		// The request is HTTP, so it has no headers rather than unknown headers.
+		return map[string]string{}
+	}
+	return headers
+}
+
 func (a *CheckRequestToFlowAdapter) GetSourcePrincipal() *string {
 	if a.flow == nil {
 		return nil
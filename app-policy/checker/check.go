@@ -237,6 +237,10 @@ func checkProfile(profile *proto.Profile, dir flxrules.RuleDir, req *requestCach
 // checkRules checks the rules against the request and returns the action to take.
 func checkRules(rules []*proto.Rule, req *requestCache, policyNamespace string) (action Action, index int) {
 	for i, r := range rules {
+		if strings.ToLower(r.Action) == "setdscp" {
+			// DSCP rules don't affect whether the request is allowed.
+			continue
+		}
 		if match(policyNamespace, r, req) {
 			log.Debugf("checkRules: Rule matched %v", r)
 			a := actionFromString(r.Action)
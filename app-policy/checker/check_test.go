@@ -370,6 +370,34 @@ func TestCheckPolicyRules(t *testing.T) {
 	Expect(idx).To(Equal(2))
 }
 
+// SetDSCP rules don't affect the verdict, so processing should continue past them.
+func TestCheckPolicySetDSCPRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := &proto.Policy{OutboundRules: []*proto.Rule{
+		{
+			Action: "setdscp",
+			Dscp:   46,
+		},
+		{
+			Action: "allow",
+		},
+	}}
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/sue",
+		},
+	}}
+	flow := NewCheckRequestToFlowAdapter(req)
+	reqCache := NewRequestCache(policystore.NewPolicyStore(), flow)
+	st, idx := checkPolicy(policy, rules.RuleDirEgress, reqCache)
+	Expect(st).To(Equal(ALLOW))
+	Expect(idx).To(Equal(1))
+}
+
 // If tiers have no ingress policies, we should not get NO_MATCH.
 func TestCheckNoIngressPolicyRulesInTier(t *testing.T) {
 	RegisterTestingT(t)
@@ -1020,6 +1048,8 @@ type MockFlow struct {
 	Protocol        int
 	HttpMethod      *string
 	HttpPath        *string
+	HttpHost        *string
+	HttpHeaders     map[string]string
 	SourcePrincipal *string
 	DestPrincipal   *string
 	SourceLabels    map[string]string
@@ -1054,6 +1084,14 @@ func (m *MockFlow) GetHttpPath() *string {
 	return m.HttpPath
 }
 
+func (m *MockFlow) GetHttpHost() *string {
+	return m.HttpHost
+}
+
+func (m *MockFlow) GetHttpHeaders() map[string]string {
+	return m.HttpHeaders
+}
+
 func (m *MockFlow) GetSourcePrincipal() *string {
 	return m.SourcePrincipal
 }
@@ -17,7 +17,9 @@ package checker
 import (
 	"fmt"
 	"net"
+	"regexp"
 	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 
@@ -32,6 +34,9 @@ var protocolMapL4 = map[int32]string{
 	17: "udp",
 }
 
+// regexCache caches compiled header match regular expressions, keyed on the pattern from the rule.
+var regexCache sync.Map
+
 type namespaceMatch struct {
 	Names    []string
 	Selector string
@@ -60,6 +65,8 @@ type L4Flow interface {
 type L7Flow interface {
 	GetHttpMethod() *string
 	GetHttpPath() *string
+	GetHttpHost() *string
+	GetHttpHeaders() map[string]string
 	GetSourcePrincipal() *string
 	GetDestPrincipal() *string
 	GetSourceLabels() map[string]string
@@ -86,6 +93,7 @@ func match(policyNamespace string, rule *proto.Rule, req *requestCache) bool {
 			"DestPort":   req.GetDestPort(),
 			"HttpMethod": req.GetHttpMethod(),
 			"HttpPath":   req.GetHttpPath(),
+			"HttpHost":   req.GetHttpHost(),
 		}).Debug("Checking rule on request")
 	}
 	return matchSource(policyNamespace, rule, req) &&
@@ -158,7 +166,7 @@ func computeNamespaceMatch(
 // Rule matches, false otherwise.
 func matchRequest(rule *proto.Rule, req *requestCache) bool {
 	log.WithField("request", req).Debug("Matching request.")
-	return matchHTTP(rule.GetHttpMatch(), req.GetHttpMethod(), req.GetHttpPath())
+	return matchHTTP(rule.GetHttpMatch(), req)
 }
 
 // matchServiceAccounts checks if the service account part of the Rule matches the request. It
@@ -253,7 +261,7 @@ func matchNamespace(nsMatch *namespaceMatch, ns *namespace) bool {
 
 // matchHTTP checks if the HTTP part of the Rule matches the request. It returns true if the Rule
 // matches, false otherwise.
-func matchHTTP(rule *proto.HTTPMatch, httpMethod, httpPath *string) bool {
+func matchHTTP(rule *proto.HTTPMatch, req L7Flow) bool {
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.WithFields(log.Fields{
 			"rule": rule,
@@ -264,7 +272,11 @@ func matchHTTP(rule *proto.HTTPMatch, httpMethod, httpPath *string) bool {
 		return true
 	}
 
-	return matchHTTPMethods(rule.GetMethods(), httpMethod) && matchHTTPPaths(rule.GetPaths(), httpPath)
+	return matchHTTPMethods(rule.GetMethods(), req.GetHttpMethod()) &&
+		matchHTTPPaths(rule.GetPaths(), req.GetHttpPath()) &&
+		matchHTTPHeaders(rule.GetHeaders(), req.GetHttpHeaders()) &&
+		matchHTTPHosts(rule.GetHosts(), req.GetHttpHost()) &&
+		matchGRPCMethods(rule.GetGrpcMethods(), req.GetHttpHeaders(), req.GetHttpPath())
 }
 
 // matchHTTPMethods checks if the HTTP methods match. It returns true if the methods match, false
@@ -345,6 +357,154 @@ func matchHTTPPaths(paths []*proto.HTTPMatch_PathMatch, reqPath *string) bool {
 	return false
 }
 
+// matchHTTPHeaders checks if the HTTP headers match. All of the header matches must match. It
+// returns true if the headers match, false otherwise.
+func matchHTTPHeaders(headers []*proto.HTTPMatch_HeaderMatch, reqHeaders map[string]string) bool {
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.WithFields(log.Fields{
+			"headers":    headers,
+			"reqHeaders": reqHeaders,
+		}).Debug("Matching HTTP Headers")
+	}
+	if len(headers) == 0 {
+		log.Debug("Rule has 0 HTTP Headers, matched.")
+		return true
+	}
+	if reqHeaders == nil {
+		log.Debug("Request has nil HTTP Headers.")
+		return true
+	}
+
+	for _, headerMatch := range headers {
+		// Envoy passes header names in lower case.
+		value, present := reqHeaders[strings.ToLower(headerMatch.GetName())]
+		switch headerMatch.GetHeaderMatch().(type) {
+		case *proto.HTTPMatch_HeaderMatch_Exact:
+			if !present || value != headerMatch.GetExact() {
+				log.Debugf("HTTP Header %s exact not matched.", headerMatch.GetName())
+				return false
+			}
+		case *proto.HTTPMatch_HeaderMatch_Regex:
+			if !present || !matchRegex(headerMatch.GetRegex(), value) {
+				log.Debugf("HTTP Header %s regex not matched.", headerMatch.GetName())
+				return false
+			}
+		case *proto.HTTPMatch_HeaderMatch_Present:
+			if present != headerMatch.GetPresent() {
+				log.Debugf("HTTP Header %s presence not matched.", headerMatch.GetName())
+				return false
+			}
+		default:
+			if !present {
+				log.Debugf("HTTP Header %s not present.", headerMatch.GetName())
+				return false
+			}
+		}
+	}
+	log.Debug("HTTP Headers matched.")
+	return true
+}
+
+// matchRegex checks if the whole of value matches the regular expression pattern.
+func matchRegex(pattern, value string) bool {
+	re, ok := regexCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			// The validator rejects invalid regular expressions, so this should never happen.
+			log.WithError(err).WithField("regex", pattern).Error("Invalid HTTP Header regex, not matched.")
+			return false
+		}
+		re, _ = regexCache.LoadOrStore(pattern, compiled)
+	}
+	return re.(*regexp.Regexp).MatchString(value)
+}
+
+// matchHTTPHosts checks if the HTTP host (authority) matches. It returns true if the hosts match,
+// false otherwise.
+func matchHTTPHosts(hosts []string, reqHost *string) bool {
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.WithFields(log.Fields{
+			"hosts":   hosts,
+			"reqHost": reqHost,
+		}).Debug("Matching HTTP Hosts")
+	}
+	if len(hosts) == 0 {
+		log.Debug("Rule has 0 HTTP Hosts, matched.")
+		return true
+	}
+	if reqHost == nil {
+		log.Debug("Request has nil HTTP Host.")
+		return true
+	}
+
+	reqName, reqPort := splitHostPort(strings.ToLower(*reqHost))
+	for _, host := range hosts {
+		name, port := splitHostPort(strings.ToLower(host))
+		// A host without a port matches requests to any port.
+		if port != "" && port != reqPort {
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(name, "*"); ok {
+			if strings.HasSuffix(reqName, suffix) && len(reqName) > len(suffix) {
+				log.Debugf("HTTP Host wildcard %s matched.", host)
+				return true
+			}
+		} else if name == reqName {
+			log.Debug("HTTP Host matched.")
+			return true
+		}
+	}
+	log.Debug("HTTP Host not matched.")
+	return false
+}
+
+// splitHostPort splits an authority into its host and (possibly empty) port.
+func splitHostPort(authority string) (string, string) {
+	if host, port, err := net.SplitHostPort(authority); err == nil {
+		return host, port
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(authority, "["), "]"), ""
+}
+
+// matchGRPCMethods checks if the request is a gRPC call to one of the given services or methods. It
+// returns true if the methods match, false otherwise.
+func matchGRPCMethods(methods []*proto.HTTPMatch_GRPCMethodMatch, reqHeaders map[string]string, reqPath *string) bool {
+	if log.IsLevelEnabled(log.DebugLevel) {
+		log.WithFields(log.Fields{
+			"methods": methods,
+			"reqPath": reqPath,
+		}).Debug("Matching gRPC Methods")
+	}
+	if len(methods) == 0 {
+		log.Debug("Rule has 0 gRPC Methods, matched.")
+		return true
+	}
+	if reqHeaders != nil && !strings.HasPrefix(reqHeaders["content-type"], "application/grpc") {
+		log.Debug("Request is not gRPC, gRPC Method not matched.")
+		return false
+	}
+	if reqPath == nil {
+		log.Debug("Request has nil HTTP Path.")
+		return true
+	}
+
+	// gRPC requests are sent to /<package>.<service>/<method>.
+	service, method, ok := strings.Cut(strings.TrimPrefix(*reqPath, "/"), "/")
+	if !ok {
+		log.Debug("HTTP Path is not a gRPC method, gRPC Method not matched.")
+		return false
+	}
+	for _, m := range methods {
+		if m.GetService() == service && (m.GetMethod() == "" || m.GetMethod() == method) {
+			log.Debug("gRPC Method matched.")
+			return true
+		}
+	}
+	log.Debug("gRPC Method not matched.")
+	return false
+}
+
 // matchSrcIPSets checks if the source IP is within the IP sets and not in the not IP sets. It
 // returns true if the IP sets match, false otherwise.
 func matchSrcIPSets(r *proto.Rule, req *requestCache) bool {
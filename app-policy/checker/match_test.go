@@ -142,11 +142,158 @@ func TestMatchHTTPPaths(t *testing.T) {
 	}
 }
 
+// HTTP Headers clause with empty list will match any headers. All header matches must match.
+func TestMatchHTTPHeaders(t *testing.T) {
+	exact := func(name, value string) *proto.HTTPMatch_HeaderMatch {
+		return &proto.HTTPMatch_HeaderMatch{Name: name, HeaderMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: value}}
+	}
+	regex := func(name, value string) *proto.HTTPMatch_HeaderMatch {
+		return &proto.HTTPMatch_HeaderMatch{Name: name, HeaderMatch: &proto.HTTPMatch_HeaderMatch_Regex{Regex: value}}
+	}
+	present := func(name string, value bool) *proto.HTTPMatch_HeaderMatch {
+		return &proto.HTTPMatch_HeaderMatch{Name: name, HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: value}}
+	}
+	reqHeaders := map[string]string{"x-version": "v2", "x-user": "admin-42"}
+
+	testCases := []struct {
+		title   string
+		headers []*proto.HTTPMatch_HeaderMatch
+		result  bool
+	}{
+		{"empty", nil, true},
+		{"exact", []*proto.HTTPMatch_HeaderMatch{exact("x-version", "v2")}, true},
+		{"exact case-insensitive name", []*proto.HTTPMatch_HeaderMatch{exact("X-Version", "v2")}, true},
+		{"exact fail", []*proto.HTTPMatch_HeaderMatch{exact("x-version", "v1")}, false},
+		{"exact missing", []*proto.HTTPMatch_HeaderMatch{exact("x-missing", "v2")}, false},
+		{"regex", []*proto.HTTPMatch_HeaderMatch{regex("x-user", "admin-[0-9]+")}, true},
+		{"regex must match whole value", []*proto.HTTPMatch_HeaderMatch{regex("x-user", "admin")}, false},
+		{"regex missing", []*proto.HTTPMatch_HeaderMatch{regex("x-missing", ".*")}, false},
+		{"regex invalid", []*proto.HTTPMatch_HeaderMatch{regex("x-user", "admin-(")}, false},
+		{"present", []*proto.HTTPMatch_HeaderMatch{present("x-user", true)}, true},
+		{"present fail", []*proto.HTTPMatch_HeaderMatch{present("x-missing", true)}, false},
+		{"absent", []*proto.HTTPMatch_HeaderMatch{present("x-missing", false)}, true},
+		{"absent fail", []*proto.HTTPMatch_HeaderMatch{present("x-user", false)}, false},
+		{"name only", []*proto.HTTPMatch_HeaderMatch{{Name: "x-user"}}, true},
+		{"name only fail", []*proto.HTTPMatch_HeaderMatch{{Name: "x-missing"}}, false},
+		{"multiple", []*proto.HTTPMatch_HeaderMatch{exact("x-version", "v2"), regex("x-user", "admin-.*")}, true},
+		{"multiple fail", []*proto.HTTPMatch_HeaderMatch{exact("x-version", "v2"), exact("x-user", "bob")}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(matchHTTPHeaders(tc.headers, reqHeaders)).To(Equal(tc.result))
+		})
+	}
+
+	t.Run("unknown headers", func(t *testing.T) {
+		RegisterTestingT(t)
+		Expect(matchHTTPHeaders([]*proto.HTTPMatch_HeaderMatch{exact("x-version", "v2")}, nil)).To(BeTrue())
+	})
+}
+
+// HTTP Hosts clause with empty list will match any host.
+func TestMatchHTTPHosts(t *testing.T) {
+	testCases := []struct {
+		title   string
+		hosts   []string
+		reqHost string
+		result  bool
+	}{
+		{"empty", nil, "example.com", true},
+		{"exact", []string{"example.com"}, "example.com", true},
+		{"case-insensitive", []string{"Example.COM"}, "example.com", true},
+		{"exact fail", []string{"example.com"}, "example.org", false},
+		{"any port", []string{"example.com"}, "example.com:8080", true},
+		{"port", []string{"example.com:8080"}, "example.com:8080", true},
+		{"port fail", []string{"example.com:8080"}, "example.com:9090", false},
+		{"port missing from request", []string{"example.com:8080"}, "example.com", false},
+		{"wildcard", []string{"*.example.com"}, "api.example.com", true},
+		{"wildcard nested", []string{"*.example.com"}, "v1.api.example.com:443", true},
+		{"wildcard doesn't match parent", []string{"*.example.com"}, "example.com", false},
+		{"ipv6", []string{"[fd00::1]"}, "[fd00::1]:80", true},
+		{"multiple", []string{"example.org", "example.com"}, "example.com", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(matchHTTPHosts(tc.hosts, &tc.reqHost)).To(Equal(tc.result))
+		})
+	}
+}
+
+// gRPC Methods clause with empty list will match any request.
+func TestMatchGRPCMethods(t *testing.T) {
+	grpcHeaders := map[string]string{"content-type": "application/grpc+proto"}
+	httpHeaders := map[string]string{"content-type": "application/json"}
+	greeter := &proto.HTTPMatch_GRPCMethodMatch{Service: "helloworld.Greeter"}
+	sayHello := &proto.HTTPMatch_GRPCMethodMatch{Service: "helloworld.Greeter", Method: "SayHello"}
+
+	testCases := []struct {
+		title   string
+		methods []*proto.HTTPMatch_GRPCMethodMatch
+		headers map[string]string
+		reqPath string
+		result  bool
+	}{
+		{"empty", nil, httpHeaders, "/foo", true},
+		{"service", []*proto.HTTPMatch_GRPCMethodMatch{greeter}, grpcHeaders, "/helloworld.Greeter/SayGoodbye", true},
+		{"method", []*proto.HTTPMatch_GRPCMethodMatch{sayHello}, grpcHeaders, "/helloworld.Greeter/SayHello", true},
+		{"method fail", []*proto.HTTPMatch_GRPCMethodMatch{sayHello}, grpcHeaders, "/helloworld.Greeter/SayGoodbye", false},
+		{"service fail", []*proto.HTTPMatch_GRPCMethodMatch{greeter}, grpcHeaders, "/helloworld.Farewell/SayGoodbye", false},
+		{"not gRPC", []*proto.HTTPMatch_GRPCMethodMatch{greeter}, httpHeaders, "/helloworld.Greeter/SayHello", false},
+		{"not a method path", []*proto.HTTPMatch_GRPCMethodMatch{greeter}, grpcHeaders, "/helloworld.Greeter", false},
+		{"unknown headers", []*proto.HTTPMatch_GRPCMethodMatch{greeter}, nil, "/helloworld.Greeter/SayHello", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(matchGRPCMethods(tc.methods, tc.headers, &tc.reqPath)).To(Equal(tc.result))
+		})
+	}
+}
+
 // An omitted HTTP Match clause always matches.
 func TestMatchHTTPNil(t *testing.T) {
 	RegisterTestingT(t)
 
-	Expect(matchHTTP(nil, nil, nil)).To(BeTrue())
+	Expect(matchHTTP(nil, &MockFlow{})).To(BeTrue())
+}
+
+// All HTTP subclauses must match for the HTTP clause to match.
+func TestMatchHTTP(t *testing.T) {
+	RegisterTestingT(t)
+
+	method, path, host := "POST", "/helloworld.Greeter/SayHello", "greeter.example.com"
+	flow := &MockFlow{
+		HttpMethod:  &method,
+		HttpPath:    &path,
+		HttpHost:    &host,
+		HttpHeaders: map[string]string{"content-type": "application/grpc", "x-version": "v2"},
+	}
+	rule := &proto.HTTPMatch{
+		Methods:     []string{"POST"},
+		Paths:       []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/helloworld."}}},
+		Headers:     []*proto.HTTPMatch_HeaderMatch{{Name: "x-version", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "v2"}}},
+		Hosts:       []string{"*.example.com"},
+		GrpcMethods: []*proto.HTTPMatch_GRPCMethodMatch{{Service: "helloworld.Greeter", Method: "SayHello"}},
+	}
+	Expect(matchHTTP(rule, flow)).To(BeTrue())
+
+	rule.Hosts = []string{"other.example.com"}
+	Expect(matchHTTP(rule, flow)).To(BeFalse())
+	rule.Hosts = nil
+	Expect(matchHTTP(rule, flow)).To(BeTrue())
+
+	rule.Headers[0].HeaderMatch = &proto.HTTPMatch_HeaderMatch_Exact{Exact: "v1"}
+	Expect(matchHTTP(rule, flow)).To(BeFalse())
+	rule.Headers = nil
+	Expect(matchHTTP(rule, flow)).To(BeTrue())
+
+	rule.GrpcMethods[0].Method = "SayGoodbye"
+	Expect(matchHTTP(rule, flow)).To(BeFalse())
 }
 
 // Test HTTPPaths panic on invalid data.
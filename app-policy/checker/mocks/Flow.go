@@ -75,6 +75,38 @@ func (_m *Flow) GetDestPrincipal() *string {
 	return r0
 }
 
+// GetHttpHeaders provides a mock function with given fields:
+func (_m *Flow) GetHttpHeaders() map[string]string {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// GetHttpHost provides a mock function with given fields:
+func (_m *Flow) GetHttpHost() *string {
+	ret := _m.Called()
+
+	var r0 *string
+	if rf, ok := ret.Get(0).(func() *string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*string)
+		}
+	}
+
+	return r0
+}
+
 // GetHttpMethod provides a mock function with given fields:
 func (_m *Flow) GetHttpMethod() *string {
 	ret := _m.Called()
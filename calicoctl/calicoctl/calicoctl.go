@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	_ "time/tzdata" // Embed the time zone database, used for policy schedules, since images may not have one.
 
 	"github.com/docopt/docopt-go"
 	log "github.com/sirupsen/logrus"
@@ -43,6 +44,7 @@ func main() {
     label        Add or update labels of resources.
     convert      Convert config files between different API versions.
     ipam         IP address management.
+    policy       Policy tools.
     node         Calico node management.
     version      Display the version of this binary.
     datastore    Calico datastore management.
@@ -130,6 +132,8 @@ Description:
 			err = commands.Node(args)
 		case "ipam":
 			err = commands.IPAM(args)
+		case "policy":
+			err = commands.Policy(args)
 		case "cluster":
 			err = commands.Cluster(args)
 		case "datastore":
@@ -63,6 +63,7 @@ var allV3Resources []string = []string{
 	"felixconfigurations",
 	"ipreservations",
 	"bgpfilters",
+	"qospolicies",
 }
 
 var resourceDisplayMap map[string]string = map[string]string{
@@ -82,6 +83,7 @@ var resourceDisplayMap map[string]string = map[string]string{
 	"nodes":                           "Nodes",
 	"ipreservations":                  "IPReservations",
 	"bgpfilters":                      "BGPFilters",
+	"qospolicies":                     "QoSPolicies",
 	"tiers":                           "Tiers",
 }
 
@@ -83,6 +83,8 @@ var _ = Describe("Etcd to KDD Migration Export handling", func() {
 		allPlurals.Discard("clusterinformations")
 		// Not supported in KDD (OpenStack only).
 		allPlurals.Discard("caliconodestatuses")
+		// Migrations act on the pods in the cluster they were created in.
+		allPlurals.Discard("ippoolmigrations")
 		// Handled by IPAM migration code.
 		allPlurals.Discard("ipamconfigs")
 		allPlurals.Discard("blockaffinities")
@@ -228,6 +228,16 @@ func (c *MockIPAMClient) IPReservations() client.IPReservationInterface {
 	return nil
 }
 
+func (c *MockIPAMClient) IPPoolMigrations() client.IPPoolMigrationInterface {
+	// DO NOTHING
+	return nil
+}
+
+func (c *MockIPAMClient) QoSPolicies() client.QoSPolicyInterface {
+	// DO NOTHING
+	return nil
+}
+
 func (c *MockIPAMClient) Profiles() client.ProfileInterface {
 	// DO NOTHING
 	return nil
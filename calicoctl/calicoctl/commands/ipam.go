@@ -38,6 +38,10 @@ func IPAM(args []string) error {
     split            Split the IP pool specified by the CIDR into
                      the specified number of smaller IPPools.
     configure        Configure IPAM
+    defrag           Release sparsely-used IPAM blocks back to
+                     their IP pools.
+    migrate-pool     Move pods from one IP pool to another, and
+                     delete the old pool.
 
 Options:
   -h --help      Show this screen.
@@ -78,6 +82,10 @@ Description:
 		return ipam.Configure(args)
 	case "split":
 		return ipam.Split(args)
+	case "defrag":
+		return ipam.Defrag(args)
+	case "migrate-pool":
+		return ipam.MigratePool(args)
 	default:
 		fmt.Println(doc)
 	}
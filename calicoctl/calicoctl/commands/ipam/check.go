@@ -435,6 +435,10 @@ func (c *IPAMChecker) checkIPAM(ctx context.Context) error {
 				goodHandles++
 				continue
 			}
+			if ipam.IsQuotaHandle(handleID) {
+				// Quota handles count a namespace's addresses rather than owning any.
+				continue
+			}
 			if c.showAllIPs {
 				fmt.Printf("  %s doesn't have any active IPs.\n", handleID)
 			}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	docopt "github.com/docopt/docopt-go"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/clientmgr"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/common"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/constants"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// Defrag implements the "calicoctl ipam defrag" command, which drains sparsely-used IPAM blocks and
+// releases their affinity once they are empty.
+func Defrag(args []string) error {
+	doc := constants.DatastoreIntro + `Usage:
+  <BINARY_NAME> ipam defrag [--pool=<POOL>...] [--threshold=<PERCENT>] [--dry-run] [--cancel] [--config=<CONFIG>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+     --pool=<POOL>             Name or CIDR of an IP pool to defragment.  May be
+                               specified multiple times.  Defaults to all pools.
+     --threshold=<PERCENT>     Drain blocks with this percentage of their addresses
+                               in use, or fewer.
+                               [default: ` + strconv.Itoa(ipam.DefaultDefragThreshold) + `]
+     --dry-run                 Report the blocks that would be drained and released
+                               without changing them.
+     --cancel                  Stop draining blocks, so that addresses can be
+                               assigned from them again.
+  -c --config=<CONFIG>         Path to the file containing connection configuration in
+                               YAML or JSON format.
+                               [default: ` + constants.DefaultConfigPath + `]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The ipam defrag command returns the address space held by sparsely-used
+  blocks to the IP pools, so that it can be used by other nodes.
+
+  Blocks that are affine to a node and have few addresses in use are marked as
+  draining: no new addresses are assigned from them, and their affinity is
+  released once the workloads using their addresses have been removed.  A block
+  is only drained if the node's other blocks have room for its addresses, and
+  every node keeps at least one block.
+
+  Each run drains more blocks and releases those that have drained, so run the
+  command periodically, or enable the ipamDefragPeriod option of the node
+  controller in kube-controllers to do this automatically.
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	err = common.CheckVersionMismatch(parsedArgs["--config"], parsedArgs["--allow-version-mismatch"])
+	if err != nil {
+		return err
+	}
+
+	threshold, err := strconv.Atoi(parsedArgs["--threshold"].(string))
+	if err != nil || threshold <= 0 || threshold > 100 {
+		return fmt.Errorf("Invalid threshold. Use a percentage between 1 and 100")
+	}
+
+	ctx := context.Background()
+
+	// Create a new backend client from env vars.
+	cf := parsedArgs["--config"].(string)
+	client, err := clientmgr.NewClient(cf)
+	if err != nil {
+		return err
+	}
+
+	defragArgs := ipam.DefragArgs{
+		Pools:     parsedArgs["--pool"].([]string),
+		Threshold: threshold,
+		DryRun:    parsedArgs["--dry-run"].(bool),
+		Cancel:    parsedArgs["--cancel"].(bool),
+	}
+	return defragBlocks(ctx, client.IPAM(), defragArgs)
+}
+
+func defragBlocks(ctx context.Context, ipamClient ipam.Interface, args ipam.DefragArgs) error {
+	res, err := ipamClient.DefragBlocks(ctx, args)
+	if err != nil {
+		return fmt.Errorf("Error: %v", err)
+	}
+
+	prefix := ""
+	if args.DryRun {
+		prefix = "Would have: "
+	}
+	if args.Cancel {
+		fmt.Printf("%sStopped draining %d block(s)%s\n", prefix, len(res.Restored), blockList(res.Restored))
+		return nil
+	}
+	fmt.Printf("%sStarted draining %d block(s)%s\n", prefix, len(res.Drained), blockList(res.Drained))
+	fmt.Printf("%sReleased %d drained block(s)%s\n", prefix, len(res.Released), blockList(res.Released))
+	fmt.Printf("%d block(s) still draining%s\n", len(res.Draining), blockList(res.Draining))
+	if args.DryRun {
+		return nil
+	}
+
+	// Report the capacity that remains to be reclaimed.
+	usage, err := ipamClient.GetUtilization(ctx, ipam.GetUtilizationArgs{Pools: args.Pools, DefragThreshold: args.Threshold})
+	if err != nil {
+		return fmt.Errorf("Error: %v", err)
+	}
+	fmt.Println()
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"IP POOL", "CIDR", "DRAINING BLOCKS", "IPS RECLAIMABLE"})
+	for _, poolUse := range usage {
+		draining := 0
+		for _, blockUse := range poolUse.Blocks {
+			if blockUse.Draining {
+				draining++
+			}
+		}
+		table.Append([]string{
+			poolUse.Name,
+			poolUse.CIDR.String(),
+			fmt.Sprint(draining),
+			fmt.Sprint(poolUse.Reclaimable),
+		})
+	}
+	table.Render()
+
+	return nil
+}
+
+func blockList(blocks []cnet.IPNet) string {
+	if len(blocks) == 0 {
+		return ""
+	}
+	var cidrs []string
+	for _, b := range blocks {
+		cidrs = append(cidrs, b.String())
+	}
+	return ": " + strings.Join(cidrs, ", ")
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	docopt "github.com/docopt/docopt-go"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/clientmgr"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/common"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/constants"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// MigratePool implements the "calicoctl ipam migrate-pool" command, which creates an IPPoolMigration to
+// move the pods using one IP pool to another.
+func MigratePool(args []string) error {
+	doc := constants.DatastoreIntro + `Usage:
+  <BINARY_NAME> ipam migrate-pool --from=<POOL> --to=<POOL> [--batch-size=<NUM>] [--config=<CONFIG>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+     --from=<POOL>             Name or CIDR of the IP pool to move pods out of.
+     --to=<POOL>               Name or CIDR of the IP pool to move pods to.
+     --batch-size=<NUM>        Maximum number of pods to move at once.
+                               [default: 10]
+  -c --config=<CONFIG>         Path to the file containing connection configuration in
+                               YAML or JSON format.
+                               [default: ` + constants.DefaultConfigPath + `]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The ipam migrate-pool command moves the pods using addresses from one IP pool
+  to another, and then deletes the old pool.
+
+  The migration is carried out by the node controller in calico-kube-controllers.
+  It disables the old pool, so that no new addresses are assigned from it, and
+  then evicts the pods using its addresses in batches, so that their controllers
+  recreate them with addresses from the new pool.  Evictions respect the pods'
+  PodDisruptionBudgets.  Pods that aren't owned by a controller, such as bare or
+  static pods, must be deleted by hand.  Once no addresses in the old pool are
+  assigned, it is deleted, which releases its IPAM blocks.  That includes tunnel
+  addresses, which move to another pool by themselves, and addresses assigned
+  outside of Kubernetes, which must be released by hand.
+
+  The new pool must be enabled, and select the nodes that the old pool selects.
+  The migration doesn't start while any other enabled pool assigns addresses to
+  pods on those nodes, so that the pods are only moved to the new pool.  Pods
+  whose annotations name other IP pools get addresses from those pools instead.
+
+  The command returns once the migration has been created.  Use
+  '<BINARY_NAME> get ippoolmigrations -o wide' to follow its progress.
+
+Examples:
+  # Move pods from the 192.168.0.0/16 pool to the 10.10.0.0/16 pool
+  <BINARY_NAME> ipam migrate-pool --from=192.168.0.0/16 --to=10.10.0.0/16
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	err = common.CheckVersionMismatch(parsedArgs["--config"], parsedArgs["--allow-version-mismatch"])
+	if err != nil {
+		return err
+	}
+
+	batchSize, err := strconv.Atoi(parsedArgs["--batch-size"].(string))
+	if err != nil || batchSize <= 0 {
+		return fmt.Errorf("Invalid batch size. Use a positive number of pods")
+	}
+
+	ctx := context.Background()
+
+	// Create a new backend client from env vars.
+	cf := parsedArgs["--config"].(string)
+	client, err := clientmgr.NewClient(cf)
+	if err != nil {
+		return err
+	}
+
+	from, err := findPool(ctx, client, parsedArgs["--from"].(string))
+	if err != nil {
+		return err
+	}
+	to, err := findPool(ctx, client, parsedArgs["--to"].(string))
+	if err != nil {
+		return err
+	}
+	if from.Name == to.Name {
+		return fmt.Errorf("The IP pools to migrate from and to must be different")
+	}
+	if to.Spec.Disabled {
+		return fmt.Errorf("IP pool %s is disabled. Enable it before migrating pods to it", to.Name)
+	}
+	_, fromCIDR, _ := cnet.ParseCIDR(from.Spec.CIDR)
+	_, toCIDR, _ := cnet.ParseCIDR(to.Spec.CIDR)
+	if fromCIDR != nil && toCIDR != nil && fromCIDR.Version() != toCIDR.Version() {
+		return fmt.Errorf("IP pools %s and %s are not the same IP version", from.Name, to.Name)
+	}
+
+	m := apiv3.NewIPPoolMigration()
+	m.Name = migrationName(from.Name)
+	m.Spec = apiv3.IPPoolMigrationSpec{
+		FromPool:  from.Name,
+		ToPool:    to.Name,
+		BatchSize: batchSize,
+	}
+	if _, err := client.IPPoolMigrations().Create(ctx, m, options.SetOptions{}); err != nil {
+		return fmt.Errorf("Error creating IP pool migration: %v", err)
+	}
+
+	fmt.Printf("Created IP pool migration %s to move pods from IP pool %s (%s) to %s (%s).\n",
+		m.Name, from.Name, from.Spec.CIDR, to.Name, to.Spec.CIDR)
+	fmt.Printf("Use '%s get ippoolmigration %s -o wide' to follow its progress.\n", name, m.Name)
+	return nil
+}
+
+// findPool returns the IP pool with the given name or CIDR.
+func findPool(ctx context.Context, c client.Interface, nameOrCIDR string) (*apiv3.IPPool, error) {
+	pools, err := c.IPPools().List(ctx, options.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list IP pools: %v", err)
+	}
+	for _, pool := range pools.Items {
+		if pool.Name == nameOrCIDR || pool.Spec.CIDR == nameOrCIDR {
+			return &pool, nil
+		}
+	}
+	return nil, fmt.Errorf("Unable to find IP pool %s", nameOrCIDR)
+}
+
+// migrationName returns the name of the migration out of the given pool.  A pool is deleted once its
+// pods have been migrated, so there is only one migration per pool.
+func migrationName(poolName string) string {
+	name := "migrate-" + poolName
+	// Max K8s resource name is 253 characters.
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return name
+}
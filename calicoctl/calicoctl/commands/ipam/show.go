@@ -213,6 +213,36 @@ func showBlockUtilization(ctx context.Context, ipamClient ipam.Interface, showBl
 	return nil
 }
 
+func showQuotaUtilization(ctx context.Context, ipamClient ipam.Interface) error {
+	usage, err := ipamClient.GetUtilization(ctx, ipam.GetUtilizationArgs{})
+	if err != nil {
+		return err
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"IP POOL", "CIDR", "NAMESPACE", "IPS IN USE", "IPS QUOTA"})
+	for _, poolUse := range usage {
+		for _, quotaUse := range poolUse.Quotas {
+			table.Append([]string{
+				poolUse.Name,
+				poolUse.CIDR.String(),
+				quotaUse.Namespace,
+				fmt.Sprintf("%d (%.f%%)", quotaUse.InUse, percentOf(quotaUse.InUse, quotaUse.Limit)),
+				fmt.Sprint(quotaUse.Limit),
+			})
+		}
+	}
+	table.Render()
+
+	return nil
+}
+
+func percentOf(n, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return 100 * float64(n) / float64(total)
+}
+
 func showConfiguration(ctx context.Context, ipamClient ipam.Interface) error {
 	ipamConfig, err := ipamClient.GetIPAMConfig(ctx)
 	if err != nil {
@@ -244,13 +274,15 @@ func showConfiguration(ctx context.Context, ipamClient ipam.Interface) error {
 // IPAM takes keyword with an IP address then calls the subcommands.
 func Show(args []string) error {
 	doc := constants.DatastoreIntro + `Usage:
-  <BINARY_NAME> ipam show [--ip=<IP> | --show-blocks | --show-borrowed | --show-configuration] [--config=<CONFIG>] [--allow-version-mismatch]
+  <BINARY_NAME> ipam show [--ip=<IP> | --show-blocks | --show-borrowed | --show-quotas | --show-configuration] [--config=<CONFIG>] [--allow-version-mismatch]
 
 Options:
   -h --help                    Show this screen.
      --ip=<IP>                 Report whether this specific IP address is in use.
      --show-blocks             Show detailed information for IP blocks as well as pools.
      --show-borrowed           Show detailed information for "borrowed" IP addresses.
+     --show-quotas             Show each namespace's use of the IP pools that limit it
+                               with a quota.
      --show-configuration      Show current Calico IPAM configuration.
   -c --config=<CONFIG>         Path to the file containing connection configuration in
                                YAML or JSON format.
@@ -299,6 +331,7 @@ Description:
 	passedIP := parsedArgs["--ip"]
 	showBlocks := parsedArgs["--show-blocks"].(bool)
 	showBorrowed := parsedArgs["--show-borrowed"].(bool)
+	showQuotas := parsedArgs["--show-quotas"].(bool)
 	configuration := parsedArgs["--show-configuration"].(bool)
 
 	if passedIP != nil {
@@ -307,6 +340,8 @@ Description:
 		return showBlockUtilization(ctx, ipamClient, true)
 	} else if showBorrowed {
 		return showBorrowedDetails(ctx, ippoolClient, bc)
+	} else if showQuotas {
+		return showQuotaUtilization(ctx, ipamClient)
 	} else if configuration {
 		return showConfiguration(ctx, ipamClient)
 	}
@@ -0,0 +1,398 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	googleproto "google.golang.org/protobuf/proto"
+
+	"github.com/projectcalico/calico/app-policy/checker"
+	"github.com/projectcalico/calico/app-policy/policystore"
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/config"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/rules"
+	"github.com/projectcalico/calico/felix/types"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// endpoint is one end of the flow under test.
+type endpoint struct {
+	// Name is the namespace/name of the workload, or the IP address if the flow end is not a
+	// workload.
+	Name string
+	// IPs are the addresses of the workload, or the single IP address that was given.
+	IPs []net.IP
+	// Key is the key of the workload endpoint, or nil if the IP address doesn't belong to a
+	// workload; in that case there's no Calico policy on that end of the flow.
+	Key *model.WorkloadEndpointKey
+}
+
+// resolveEndpoint finds the workload endpoint for the given pod ("namespace/name", or just
+// "name" for a pod in the default namespace) or IP address.
+func resolveEndpoint(updates []bapi.Update, spec string) (*endpoint, error) {
+	ip := net.ParseIP(spec)
+	name := spec
+	if ip == nil && !strings.Contains(name, "/") {
+		name = "default/" + name
+	}
+
+	var matches []model.WorkloadEndpointKey
+	wepIPs := map[model.WorkloadEndpointKey][]net.IP{}
+	for _, u := range updates {
+		key, ok := u.Key.(model.WorkloadEndpointKey)
+		if !ok {
+			continue
+		}
+		wep, ok := u.Value.(*model.WorkloadEndpoint)
+		if !ok || wep == nil {
+			continue
+		}
+		var ips []net.IP
+		for _, n := range wep.IPv4Nets {
+			ips = append(ips, n.IP)
+		}
+		for _, n := range wep.IPv6Nets {
+			ips = append(ips, n.IP)
+		}
+		wepIPs[key] = ips
+		if ip != nil {
+			for _, wepIP := range ips {
+				if wepIP.Equal(ip) {
+					matches = append(matches, key)
+				}
+			}
+		} else if key.WorkloadID == name {
+			matches = append(matches, key)
+		}
+	}
+
+	if len(matches) == 0 {
+		if ip == nil {
+			return nil, fmt.Errorf("no workload endpoint found for pod %q", name)
+		}
+		return &endpoint{Name: ip.String(), IPs: []net.IP{ip}}, nil
+	}
+
+	// A pod with several interfaces has several endpoints; choose one consistently.
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].EndpointID < matches[j].EndpointID
+	})
+	key := matches[0]
+	ep := &endpoint{Name: key.WorkloadID, IPs: wepIPs[key], Key: &key}
+	if ip != nil {
+		ep.IPs = []net.IP{ip}
+	}
+	return ep, nil
+}
+
+// selectFlowIPs picks the source and destination addresses for the flow, making sure that they
+// are of the same IP version.  IPv4 is preferred unless an IPv6 address was given explicitly.
+func selectFlowIPs(src, dst *endpoint) (net.IP, net.IP, error) {
+	wantV6 := (len(src.IPs) == 1 && src.IPs[0].To4() == nil) || (len(dst.IPs) == 1 && dst.IPs[0].To4() == nil)
+	pick := func(ep *endpoint) net.IP {
+		for _, ip := range ep.IPs {
+			if (ip.To4() == nil) == wantV6 {
+				return ip
+			}
+		}
+		return nil
+	}
+	srcIP, dstIP := pick(src), pick(dst)
+	if srcIP == nil || dstIP == nil {
+		return nil, nil, fmt.Errorf("%s and %s have no addresses of the same IP version", src.Name, dst.Name)
+	}
+	return srcIP, dstIP, nil
+}
+
+// testFlow is the flow under test.  It only has L4 attributes.
+type testFlow struct {
+	srcIP, dstIP     net.IP
+	srcPort, dstPort int
+	protocol         int
+}
+
+func (f *testFlow) GetSourceIP() net.IP                { return f.srcIP }
+func (f *testFlow) GetDestIP() net.IP                  { return f.dstIP }
+func (f *testFlow) GetSourcePort() int                 { return f.srcPort }
+func (f *testFlow) GetDestPort() int                   { return f.dstPort }
+func (f *testFlow) GetProtocol() int                   { return f.protocol }
+func (f *testFlow) GetHttpMethod() *string             { return nil }
+func (f *testFlow) GetHttpPath() *string               { return nil }
+func (f *testFlow) GetHttpHost() *string               { return nil }
+func (f *testFlow) GetHttpHeaders() map[string]string  { return nil }
+func (f *testFlow) GetSourcePrincipal() *string        { return nil }
+func (f *testFlow) GetDestPrincipal() *string          { return nil }
+func (f *testFlow) GetSourceLabels() map[string]string { return nil }
+func (f *testFlow) GetDestLabels() map[string]string   { return nil }
+
+// directionResult is the outcome of evaluating the policy for one end of the flow.
+type directionResult struct {
+	Direction rules.RuleDir
+	Endpoint  *endpoint
+	// Trace and Allowed are the outcome with the enforced policies.
+	Trace   []*calc.RuleID
+	Allowed bool
+	// PendingTrace and PendingAllowed are the outcome if the staged policies were enforced.
+	PendingTrace   []*calc.RuleID
+	PendingAllowed bool
+	// StagedDeletes are the staged policies that delete an enforced policy that applies to the
+	// endpoint.  They never appear in the pending trace.
+	StagedDeletes []string
+}
+
+// StagedPolicies returns the staged policies that would change the verdict if they were
+// enforced.
+func (r *directionResult) StagedPolicies() []string {
+	if r == nil || r.Allowed == r.PendingAllowed {
+		return nil
+	}
+	staged := append([]string(nil), r.StagedDeletes...)
+	for _, rid := range r.PendingTrace {
+		if strings.HasPrefix(rid.Name, model.PolicyNamePrefixStaged) {
+			staged = append(staged, policyDisplayName(rid))
+		}
+	}
+	return staged
+}
+
+// result is the outcome of evaluating the policy for a flow.  Egress (Ingress) is nil if the
+// source (destination) is not a workload.
+type result struct {
+	Flow        *testFlow
+	Source      *endpoint
+	Destination *endpoint
+	Egress      *directionResult
+	Ingress     *directionResult
+}
+
+// Allowed returns true if the flow is allowed by the enforced policies.
+func (r *result) Allowed() bool {
+	return (r.Egress == nil || r.Egress.Allowed) && (r.Ingress == nil || r.Ingress.Allowed)
+}
+
+// PendingAllowed returns true if the flow would be allowed if the staged policies were enforced.
+func (r *result) PendingAllowed() bool {
+	return (r.Egress == nil || r.Egress.PendingAllowed) && (r.Ingress == nil || r.Ingress.PendingAllowed)
+}
+
+// evaluate runs the calculation graph for the nodes hosting the source and destination
+// workloads, and evaluates the flow against the resulting policy.
+func evaluate(snap *snapshot, src, dst *endpoint, flow *testFlow) (*result, error) {
+	res := &result{Flow: flow, Source: src, Destination: dst}
+	stores := map[string]*policystore.PolicyStore{}
+	storeForNode := func(node string) *policystore.PolicyStore {
+		if stores[node] == nil {
+			stores[node] = policyStoreForNode(snap.updates, node)
+		}
+		return stores[node]
+	}
+
+	var err error
+	if src.Key != nil {
+		res.Egress, err = evaluateDirection(storeForNode(src.Key.Hostname), snap.stagedDeletes, src, rules.RuleDirEgress, flow)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dst.Key != nil {
+		res.Ingress, err = evaluateDirection(storeForNode(dst.Key.Hostname), snap.stagedDeletes, dst, rules.RuleDirIngress, flow)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func evaluateDirection(
+	store *policystore.PolicyStore,
+	stagedDeletes map[string]bool,
+	ep *endpoint,
+	dir rules.RuleDir,
+	flow *testFlow,
+) (*directionResult, error) {
+	wep := store.Endpoints[types.WorkloadEndpointID{
+		OrchestratorId: ep.Key.OrchestratorID,
+		WorkloadId:     ep.Key.WorkloadID,
+		EndpointId:     ep.Key.EndpointID,
+	}]
+	if wep == nil {
+		return nil, fmt.Errorf("workload endpoint for %s not found on node %s", ep.Name, ep.Key.Hostname)
+	}
+
+	r := &directionResult{Direction: dir, Endpoint: ep}
+	r.Trace = checker.Evaluate(dir, store, withoutStagedPolicies(wep), flow)
+	r.Allowed = traceAllows(r.Trace)
+	pending, deleted := withStagedPolicies(wep, stagedDeletes)
+	r.PendingTrace = checker.Evaluate(dir, store, pending, flow)
+	r.PendingAllowed = traceAllows(r.PendingTrace)
+	for _, name := range deleted {
+		r.StagedDeletes = append(r.StagedDeletes, stagedPolicyName(name))
+	}
+	return r, nil
+}
+
+// traceAllows returns true if the final rule in the trace allows the flow.  An empty trace means
+// that the endpoint has no policies or profiles that apply, so the flow is denied.
+func traceAllows(trace []*calc.RuleID) bool {
+	return len(trace) > 0 && trace[len(trace)-1].Action == rules.RuleActionAllow
+}
+
+// withoutStagedPolicies returns a copy of the endpoint with the staged policies removed, which is
+// what the dataplane enforces.
+func withoutStagedPolicies(wep *proto.WorkloadEndpoint) *proto.WorkloadEndpoint {
+	return filterTierPolicies(wep, func(names []string) []string {
+		var out []string
+		for _, name := range names {
+			if !model.PolicyIsStaged(name) {
+				out = append(out, name)
+			}
+		}
+		return out
+	})
+}
+
+// withStagedPolicies returns a copy of the endpoint in which each staged policy replaces the
+// enforced policy of the same name, if there is one, and the enforced policies that have a staged
+// delete are removed.  It also returns the names of the removed policies, in the order that they
+// apply to the endpoint.
+func withStagedPolicies(wep *proto.WorkloadEndpoint, stagedDeletes map[string]bool) (*proto.WorkloadEndpoint, []string) {
+	var deleted []string
+	seen := map[string]bool{}
+	out := filterTierPolicies(wep, func(names []string) []string {
+		replaced := map[string]bool{}
+		for _, name := range names {
+			if enforced, staged := enforcedPolicyName(name); staged {
+				replaced[enforced] = true
+			}
+		}
+		var out []string
+		for _, name := range names {
+			if stagedDeletes[name] {
+				if !seen[name] {
+					seen[name] = true
+					deleted = append(deleted, name)
+				}
+				continue
+			}
+			if !replaced[name] {
+				out = append(out, name)
+			}
+		}
+		return out
+	})
+	return out, deleted
+}
+
+func filterTierPolicies(wep *proto.WorkloadEndpoint, filter func([]string) []string) *proto.WorkloadEndpoint {
+	out := googleproto.Clone(wep).(*proto.WorkloadEndpoint)
+	for _, tier := range out.Tiers {
+		tier.IngressPolicies = filter(tier.IngressPolicies)
+		tier.EgressPolicies = filter(tier.EgressPolicies)
+	}
+	return out
+}
+
+// enforcedPolicyName converts the name of a staged policy to the name of the policy that it would
+// replace.  The second return value is false if the policy is not staged.
+func enforcedPolicyName(name string) (string, bool) {
+	namespace := ""
+	if ns, n, ok := strings.Cut(name, "/"); ok {
+		namespace, name = ns+"/", n
+	}
+	enforced, staged := strings.CutPrefix(name, model.PolicyNamePrefixStaged)
+	if !staged {
+		return "", false
+	}
+	return namespace + enforced, true
+}
+
+// stagedPolicyName converts the name of an enforced policy to the name of the staged policy of
+// the same name.
+func stagedPolicyName(name string) string {
+	if ns, n, ok := strings.Cut(name, "/"); ok {
+		return ns + "/" + model.PolicyNamePrefixStaged + n
+	}
+	return model.PolicyNamePrefixStaged + name
+}
+
+// policyStoreForNode runs Felix's calculation graph for the given node and returns a policy store
+// populated with the resulting policies, profiles, IP sets and local endpoints.
+func policyStoreForNode(updates []bapi.Update, node string) *policystore.PolicyStore {
+	conf := config.New()
+	conf.FelixHostname = node
+
+	store := policystore.NewPolicyStore()
+	es := calc.NewEventSequencer(conf)
+	es.Callback = func(msg interface{}) {
+		if upd := toDataplane(msg); upd != nil {
+			store.ProcessUpdate("per-host-policies", upd, true)
+		}
+	}
+	cg := calc.NewCalculationGraph(es, nil, conf, func() {})
+	cg.OnUpdates(updates)
+	cg.OnStatusUpdated(bapi.InSync)
+	cg.Flush()
+	es.Flush()
+	return store
+}
+
+// toDataplane wraps the calculation graph output messages that the policy store uses in a
+// ToDataplane envelope.  Other messages are ignored.
+func toDataplane(msg interface{}) *proto.ToDataplane {
+	switch msg := msg.(type) {
+	case *proto.IPSetUpdate:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_IpsetUpdate{IpsetUpdate: msg}}
+	case *proto.IPSetDeltaUpdate:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_IpsetDeltaUpdate{IpsetDeltaUpdate: msg}}
+	case *proto.IPSetRemove:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_IpsetRemove{IpsetRemove: msg}}
+	case *proto.ActivePolicyUpdate:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_ActivePolicyUpdate{ActivePolicyUpdate: msg}}
+	case *proto.ActivePolicyRemove:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_ActivePolicyRemove{ActivePolicyRemove: msg}}
+	case *proto.ActiveProfileUpdate:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_ActiveProfileUpdate{ActiveProfileUpdate: msg}}
+	case *proto.ActiveProfileRemove:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_ActiveProfileRemove{ActiveProfileRemove: msg}}
+	case *proto.WorkloadEndpointUpdate:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_WorkloadEndpointUpdate{WorkloadEndpointUpdate: msg}}
+	case *proto.WorkloadEndpointRemove:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_WorkloadEndpointRemove{WorkloadEndpointRemove: msg}}
+	case *proto.ServiceAccountUpdate:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_ServiceAccountUpdate{ServiceAccountUpdate: msg}}
+	case *proto.ServiceAccountRemove:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_ServiceAccountRemove{ServiceAccountRemove: msg}}
+	case *proto.NamespaceUpdate:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_NamespaceUpdate{NamespaceUpdate: msg}}
+	case *proto.NamespaceRemove:
+		return &proto.ToDataplane{Payload: &proto.ToDataplane_NamespaceRemove{NamespaceRemove: msg}}
+	}
+	return nil
+}
+
+// policyDisplayName returns the name of the policy or profile in a trace entry, including its
+// namespace if it has one.
+func policyDisplayName(rid *calc.RuleID) string {
+	if rid.Namespace != "" {
+		return rid.Namespace + "/" + rid.Name
+	}
+	return rid.Name
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/rules"
+	"github.com/projectcalico/calico/libcalico-go/lib/logutils"
+)
+
+func init() {
+	logutils.ConfigureFormatter("test")
+}
+
+const testResources = `
+apiVersion: projectcalico.org/v3
+kind: Tier
+metadata:
+  name: security
+spec:
+  order: 100
+---
+apiVersion: projectcalico.org/v3
+kind: GlobalNetworkPolicy
+metadata:
+  name: security.pass-all
+spec:
+  tier: security
+  selector: all()
+  types: [Ingress, Egress]
+  ingress:
+  - action: Pass
+  egress:
+  - action: Pass
+---
+apiVersion: projectcalico.org/v3
+kind: GlobalNetworkPolicy
+metadata:
+  name: allow-egress
+spec:
+  selector: all()
+  types: [Egress]
+  egress:
+  - action: Allow
+---
+apiVersion: projectcalico.org/v3
+kind: NetworkPolicy
+metadata:
+  name: allow-frontend
+  namespace: default
+spec:
+  selector: app == 'backend'
+  types: [Ingress]
+  ingress:
+  - action: Allow
+    protocol: TCP
+    source:
+      selector: app == 'frontend'
+    destination:
+      ports: [8080]
+---
+apiVersion: projectcalico.org/v3
+kind: StagedNetworkPolicy
+metadata:
+  name: allow-frontend
+  namespace: default
+spec:
+  selector: app == 'backend'
+  types: [Ingress]
+  ingress:
+  - action: Deny
+    source:
+      selector: app == 'frontend'
+---
+apiVersion: projectcalico.org/v3
+kind: WorkloadEndpoint
+metadata:
+  name: node1-k8s-frontend-eth0
+  namespace: default
+  labels:
+    app: frontend
+    projectcalico.org/namespace: default
+    projectcalico.org/orchestrator: k8s
+spec:
+  orchestrator: k8s
+  node: node1
+  pod: frontend
+  endpoint: eth0
+  interfaceName: cali1
+  ipNetworks: [10.0.0.1/32]
+---
+apiVersion: projectcalico.org/v3
+kind: WorkloadEndpoint
+metadata:
+  name: node2-k8s-backend-eth0
+  namespace: default
+  labels:
+    app: backend
+    projectcalico.org/namespace: default
+    projectcalico.org/orchestrator: k8s
+spec:
+  orchestrator: k8s
+  node: node2
+  pod: backend
+  endpoint: eth0
+  interfaceName: cali2
+  ipNetworks: [10.0.0.2/32]
+`
+
+func loadTestSnapshot(t *testing.T, extraResources ...string) *snapshot {
+	fname := filepath.Join(t.TempDir(), "resources.yaml")
+	resources := strings.Join(append([]string{testResources}, extraResources...), "---\n")
+	Expect(os.WriteFile(fname, []byte(resources), 0o644)).To(Succeed())
+	snap, err := snapshotFromFiles(map[string]interface{}{"--filename": fname})
+	Expect(err).NotTo(HaveOccurred())
+	return snap
+}
+
+func evaluateTestFlow(t *testing.T, snap *snapshot, from, to string, port int) *result {
+	src, err := resolveEndpoint(snap.updates, from)
+	Expect(err).NotTo(HaveOccurred())
+	dst, err := resolveEndpoint(snap.updates, to)
+	Expect(err).NotTo(HaveOccurred())
+	srcIP, dstIP, err := selectFlowIPs(src, dst)
+	Expect(err).NotTo(HaveOccurred())
+	res, err := evaluate(snap, src, dst, &testFlow{
+		srcIP:    srcIP,
+		dstIP:    dstIP,
+		srcPort:  34567,
+		dstPort:  port,
+		protocol: 6,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return res
+}
+
+type traceEntry struct {
+	Tier, Name string
+	Index      int
+	Action     rules.RuleAction
+}
+
+func summariseTrace(trace []*calc.RuleID) []traceEntry {
+	var out []traceEntry
+	for _, rid := range trace {
+		out = append(out, traceEntry{rid.Tier, policyDisplayName(rid), rid.Index, rid.Action})
+	}
+	return out
+}
+
+func TestEvaluatePodToPod(t *testing.T) {
+	RegisterTestingT(t)
+	snap := loadTestSnapshot(t)
+
+	res := evaluateTestFlow(t, snap, "default/frontend", "backend", 8080)
+	Expect(res.Flow.srcIP.String()).To(Equal("10.0.0.1"))
+	Expect(res.Flow.dstIP.String()).To(Equal("10.0.0.2"))
+
+	Expect(res.Egress).NotTo(BeNil())
+	Expect(res.Egress.Endpoint.Key.Hostname).To(Equal("node1"))
+	Expect(summariseTrace(res.Egress.Trace)).To(Equal([]traceEntry{
+		{"security", "pass-all", 0, rules.RuleActionPass},
+		{"default", "allow-egress", 0, rules.RuleActionAllow},
+	}))
+	Expect(res.Egress.Allowed).To(BeTrue())
+	Expect(res.Egress.PendingAllowed).To(BeTrue())
+
+	Expect(res.Ingress).NotTo(BeNil())
+	Expect(res.Ingress.Endpoint.Key.Hostname).To(Equal("node2"))
+	Expect(summariseTrace(res.Ingress.Trace)).To(Equal([]traceEntry{
+		{"security", "pass-all", 0, rules.RuleActionPass},
+		{"default", "default/allow-frontend", 0, rules.RuleActionAllow},
+	}))
+	Expect(res.Ingress.Allowed).To(BeTrue())
+
+	// The staged policy replaces the enforced policy of the same name and denies the flow.
+	Expect(summariseTrace(res.Ingress.PendingTrace)).To(Equal([]traceEntry{
+		{"security", "pass-all", 0, rules.RuleActionPass},
+		{"default", "default/staged:allow-frontend", 0, rules.RuleActionDeny},
+	}))
+	Expect(res.Ingress.PendingAllowed).To(BeFalse())
+	Expect(res.Ingress.StagedPolicies()).To(Equal([]string{"default/staged:allow-frontend"}))
+	Expect(res.Egress.StagedPolicies()).To(BeEmpty())
+
+	Expect(res.Allowed()).To(BeTrue())
+	Expect(res.PendingAllowed()).To(BeFalse())
+
+	var out bytes.Buffer
+	printResult(&out, res)
+	Expect(out.String()).To(ContainSubstring("Flow: default/frontend (10.0.0.1) -> default/backend (10.0.0.2), TCP port 8080"))
+	Expect(out.String()).To(ContainSubstring("Verdict: Allow"))
+	Expect(out.String()).To(ContainSubstring("Verdict if staged policies were enforced: Deny"))
+	Expect(out.String()).To(ContainSubstring("default/staged:allow-frontend (Ingress)"))
+}
+
+func TestEvaluateStagedDelete(t *testing.T) {
+	RegisterTestingT(t)
+	snap := loadTestSnapshot(t, `
+apiVersion: projectcalico.org/v3
+kind: StagedGlobalNetworkPolicy
+metadata:
+  name: allow-egress
+spec:
+  stagedAction: Delete
+`)
+	Expect(snap.stagedDeletes).To(Equal(map[string]bool{"allow-egress": true}))
+
+	// Staging the delete of the only policy that allows egress denies the flow, without changing
+	// the enforced verdict.
+	res := evaluateTestFlow(t, snap, "default/frontend", "default/backend", 8080)
+	Expect(res.Egress.Allowed).To(BeTrue())
+	Expect(res.Egress.PendingAllowed).To(BeFalse())
+	for _, rid := range res.Egress.PendingTrace {
+		Expect(rid.Name).NotTo(Equal("allow-egress"))
+	}
+	Expect(res.Egress.StagedDeletes).To(Equal([]string{"staged:allow-egress"}))
+	Expect(res.Egress.StagedPolicies()).To(ContainElement("staged:allow-egress"))
+	Expect(res.Allowed()).To(BeTrue())
+	Expect(res.PendingAllowed()).To(BeFalse())
+
+	var out bytes.Buffer
+	printResult(&out, res)
+	Expect(out.String()).To(ContainSubstring("Verdict if staged policies were enforced: Deny"))
+	Expect(out.String()).To(ContainSubstring("staged:allow-egress (Egress)"))
+}
+
+func TestEvaluateTierDefaultDeny(t *testing.T) {
+	RegisterTestingT(t)
+	snap := loadTestSnapshot(t)
+
+	// The wrong port doesn't match the allow rule, so the default tier's default action applies.
+	res := evaluateTestFlow(t, snap, "default/frontend", "default/backend", 9090)
+	Expect(res.Ingress.Allowed).To(BeFalse())
+	trace := res.Ingress.Trace
+	Expect(trace[len(trace)-1].Tier).To(Equal("default"))
+	Expect(trace[len(trace)-1].Index).To(Equal(-1))
+	Expect(trace[len(trace)-1].Action).To(Equal(rules.RuleActionDeny))
+	Expect(res.Allowed()).To(BeFalse())
+	Expect(res.Ingress.StagedPolicies()).To(BeEmpty())
+}
+
+func TestEvaluateExternalIP(t *testing.T) {
+	RegisterTestingT(t)
+	snap := loadTestSnapshot(t)
+
+	res := evaluateTestFlow(t, snap, "10.0.0.1", "8.8.8.8", 53)
+	Expect(res.Source.Name).To(Equal("default/frontend"))
+	Expect(res.Destination.Key).To(BeNil())
+	Expect(res.Ingress).To(BeNil())
+	Expect(res.Egress.Allowed).To(BeTrue())
+	Expect(res.Allowed()).To(BeTrue())
+
+	var out bytes.Buffer
+	printResult(&out, res)
+	Expect(out.String()).To(ContainSubstring("Ingress to 8.8.8.8: not a Calico workload, no policy applied."))
+	Expect(out.String()).To(ContainSubstring("No staged policies would change the verdict."))
+}
+
+func TestResolveUnknownPod(t *testing.T) {
+	RegisterTestingT(t)
+	snap := loadTestSnapshot(t)
+
+	_, err := resolveEndpoint(snap.updates, "default/missing")
+	Expect(err).To(MatchError(`no workload endpoint found for pod "default/missing"`))
+}
+
+func TestSelectFlowIPsMismatchedVersions(t *testing.T) {
+	RegisterTestingT(t)
+	src := &endpoint{Name: "default/frontend", IPs: []net.IP{net.ParseIP("10.0.0.1")}}
+	dst := &endpoint{Name: "fd00::1", IPs: []net.IP{net.ParseIP("fd00::1")}}
+	_, _, err := selectFlowIPs(src, dst)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestParseFlowArgs(t *testing.T) {
+	RegisterTestingT(t)
+
+	protocol, srcPort, dstPort, err := parseFlowArgs(map[string]interface{}{
+		"--protocol": "udp", "--source-port": "0", "--port": "53",
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(protocol).To(Equal(17))
+	Expect(srcPort).To(Equal(0))
+	Expect(dstPort).To(Equal(53))
+
+	protocol, _, _, err = parseFlowArgs(map[string]interface{}{
+		"--protocol": "ICMP", "--source-port": "0",
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(protocol).To(Equal(1))
+
+	_, _, _, err = parseFlowArgs(map[string]interface{}{
+		"--protocol": "TCP", "--source-port": "0",
+	})
+	Expect(err).To(MatchError("--port is required for TCP"))
+
+	_, _, _, err = parseFlowArgs(map[string]interface{}{
+		"--protocol": "SCTP", "--source-port": "0", "--port": "80",
+	})
+	Expect(err).To(HaveOccurred())
+
+	_, _, _, err = parseFlowArgs(map[string]interface{}{
+		"--protocol": "TCP", "--source-port": "0", "--port": "70000",
+	})
+	Expect(err).To(HaveOccurred())
+}
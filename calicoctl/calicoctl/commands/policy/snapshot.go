@@ -0,0 +1,333 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/clientmgr"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/file"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/resourcemgr"
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/felixsyncer"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/syncersv1/updateprocessors"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/watchersyncer"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/names"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// syncTimeout is how long we wait for the Felix syncer to report that it is in sync.
+const syncTimeout = 60 * time.Second
+
+// snapshot is the datastore state that the flow is evaluated against.
+type snapshot struct {
+	// updates are exactly the updates that Felix would feed into its calculation graph.
+	updates []bapi.Update
+	// stagedDeletes are the names of the enforced policies that have a staged delete.  Felix
+	// isn't sent staged deletes, so they don't appear in the updates.
+	stagedDeletes map[string]bool
+}
+
+// snapshotFromDatastore runs the Felix syncer against the datastore until it is in sync, and
+// records the updates that it sent, along with the staged policies that delete an enforced
+// policy.
+func snapshotFromDatastore(cf string) (*snapshot, error) {
+	cfg, err := clientmgr.LoadClientConfig(cf)
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientmgr.NewClientFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the backend client.
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	bc := client.(accessor).Backend()
+
+	stagedDeletes, err := listStagedDeletes(client)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newSyncCollector()
+	syncer := felixsyncer.New(bc, cfg.Spec, c, true)
+	syncer.Start()
+	defer syncer.Stop()
+
+	select {
+	case <-c.inSync:
+	case <-time.After(syncTimeout):
+		return nil, fmt.Errorf("timed out waiting for the datastore to sync")
+	}
+
+	// Take the updates before the deferred Stop(), which sends deletions for everything.
+	c.lock.Lock()
+	updates := c.updates
+	c.updates = nil
+	c.lock.Unlock()
+	return &snapshot{updates: updates, stagedDeletes: stagedDeletes}, nil
+}
+
+// listStagedDeletes lists the staged policies in the datastore and returns the names of the
+// enforced policies that they delete.
+func listStagedDeletes(client clientv3.Interface) (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+
+	var resources []resourcemgr.ResourceObject
+	snps, err := client.StagedNetworkPolicies().List(ctx, options.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged network policies: %w", err)
+	}
+	for i := range snps.Items {
+		resources = append(resources, &snps.Items[i])
+	}
+	sgnps, err := client.StagedGlobalNetworkPolicies().List(ctx, options.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged global network policies: %w", err)
+	}
+	for i := range sgnps.Items {
+		resources = append(resources, &sgnps.Items[i])
+	}
+	sknps, err := client.StagedKubernetesNetworkPolicies().List(ctx, options.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged Kubernetes network policies: %w", err)
+	}
+	for i := range sknps.Items {
+		resources = append(resources, &sknps.Items[i])
+	}
+
+	return stagedDeletesFromResources(resources)
+}
+
+// stagedDeletesFromResources returns the names of the enforced policies that the staged policies
+// in the given resources delete.
+func stagedDeletesFromResources(resources []resourcemgr.ResourceObject) (map[string]bool, error) {
+	stagedDeletes := map[string]bool{}
+	for _, r := range resources {
+		name, err := stagedDeleteName(r)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" {
+			stagedDeletes[name] = true
+		}
+	}
+	return stagedDeletes, nil
+}
+
+// stagedDeleteName returns the v1 name of the enforced policy that the given resource deletes, or
+// "" if the resource isn't a staged delete.
+func stagedDeleteName(r resourcemgr.ResourceObject) (string, error) {
+	var (
+		action  apiv3.StagedAction
+		convert func(model.ResourceKey) (model.Key, error)
+	)
+	switch p := r.(type) {
+	case *apiv3.StagedNetworkPolicy:
+		action, convert = p.Spec.StagedAction, updateprocessors.ConvertStagedNetworkPolicyV3ToV1Key
+	case *apiv3.StagedGlobalNetworkPolicy:
+		action, convert = p.Spec.StagedAction, updateprocessors.ConvertStagedGlobalNetworkPolicyV3ToV1Key
+	case *apiv3.StagedKubernetesNetworkPolicy:
+		action, convert = p.Spec.StagedAction, updateprocessors.ConvertStagedKubernetesNetworkPolicyV3ToV1Key
+	default:
+		return "", nil
+	}
+	if action != apiv3.StagedActionDelete {
+		return "", nil
+	}
+	key, err := convert(model.ResourceKey{
+		Kind:      r.GetObjectKind().GroupVersionKind().Kind,
+		Name:      r.GetObjectMeta().GetName(),
+		Namespace: r.GetObjectMeta().GetNamespace(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to convert staged policy %q: %w", r.GetObjectMeta().GetName(), err)
+	}
+	enforced, _ := enforcedPolicyName(key.(model.PolicyKey).Name)
+	return enforced, nil
+}
+
+// syncCollector is a SyncerCallbacks implementation that records the updates that are
+// received before the syncer is in sync.
+type syncCollector struct {
+	lock       sync.Mutex
+	updates    []bapi.Update
+	inSync     chan struct{}
+	inSyncOnce sync.Once
+}
+
+func newSyncCollector() *syncCollector {
+	return &syncCollector{inSync: make(chan struct{})}
+}
+
+func (c *syncCollector) OnStatusUpdated(status bapi.SyncStatus) {
+	if status == bapi.InSync {
+		c.inSyncOnce.Do(func() {
+			close(c.inSync)
+		})
+	}
+}
+
+func (c *syncCollector) OnUpdates(updates []bapi.Update) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.updates = append(c.updates, updates...)
+}
+
+// snapshotFromFiles loads the resources in the given file (or directory of files) and converts
+// them to the updates that the Felix syncer would send for them.  If the files do not include
+// the default tier, it is added, as it always exists in a real datastore.
+func snapshotFromFiles(parsedArgs map[string]interface{}) (*snapshot, error) {
+	var resources []resourcemgr.ResourceObject
+	err := file.Iter(parsedArgs, func(modifiedArgs map[string]interface{}) error {
+		filename := modifiedArgs["--filename"].(string)
+		r, err := resourcemgr.CreateResourcesFromFile(filename)
+		if err != nil {
+			return err
+		}
+		flattened, err := flattenResources(r)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, flattened...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	updates, err := updatesFromResources(resources)
+	if err != nil {
+		return nil, err
+	}
+	stagedDeletes, err := stagedDeletesFromResources(resources)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{updates: updates, stagedDeletes: stagedDeletes}, nil
+}
+
+// updatesFromResources converts v3 resources to the v1 model updates that the Felix syncer
+// would send for them.  Resources that Felix doesn't use for policy are ignored.
+func updatesFromResources(resources []resourcemgr.ResourceObject) ([]bapi.Update, error) {
+	haveDefaultTier := false
+	for _, r := range resources {
+		if r.GetObjectKind().GroupVersionKind().Kind == apiv3.KindTier && r.GetObjectMeta().GetName() == names.DefaultTierName {
+			haveDefaultTier = true
+		}
+	}
+	if !haveDefaultTier {
+		tier := apiv3.NewTier()
+		tier.Name = names.DefaultTierName
+		order := apiv3.DefaultTierOrder
+		tier.Spec.Order = &order
+		resources = append(resources, tier)
+	}
+
+	var updates []bapi.Update
+	for _, r := range resources {
+		kind := r.GetObjectKind().GroupVersionKind().Kind
+		processor := updateProcessorForKind(kind)
+		if processor == nil {
+			log.WithField("kind", kind).Info("Ignoring resource that isn't used for policy evaluation")
+			continue
+		}
+		kvp := &model.KVPair{
+			Key: model.ResourceKey{
+				Kind:      kind,
+				Name:      r.GetObjectMeta().GetName(),
+				Namespace: r.GetObjectMeta().GetNamespace(),
+			},
+			Value: r,
+		}
+		converted, err := processor.Process(kvp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process %s %q: %w", kind, kvp.Key.(model.ResourceKey).Name, err)
+		}
+		for _, c := range converted {
+			if c.Value == nil {
+				continue
+			}
+			updates = append(updates, bapi.Update{KVPair: *c, UpdateType: bapi.UpdateTypeKVNew})
+		}
+	}
+	return updates, nil
+}
+
+// updateProcessorForKind returns the update processor that the Felix syncer uses for the given
+// resource kind, or nil if the kind is not relevant to policy evaluation.
+func updateProcessorForKind(kind string) watchersyncer.SyncerUpdateProcessor {
+	switch kind {
+	case apiv3.KindGlobalNetworkPolicy:
+		return updateprocessors.NewGlobalNetworkPolicyUpdateProcessor()
+	case apiv3.KindStagedGlobalNetworkPolicy:
+		return updateprocessors.NewStagedGlobalNetworkPolicyUpdateProcessor()
+	case apiv3.KindNetworkPolicy:
+		return updateprocessors.NewNetworkPolicyUpdateProcessor()
+	case apiv3.KindStagedNetworkPolicy:
+		return updateprocessors.NewStagedNetworkPolicyUpdateProcessor()
+	case apiv3.KindStagedKubernetesNetworkPolicy:
+		return updateprocessors.NewStagedKubernetesNetworkPolicyUpdateProcessor()
+	case apiv3.KindGlobalNetworkSet:
+		return updateprocessors.NewGlobalNetworkSetUpdateProcessor()
+	case apiv3.KindNetworkSet:
+		return updateprocessors.NewNetworkSetUpdateProcessor()
+	case apiv3.KindTier:
+		return updateprocessors.NewTierUpdateProcessor()
+	case apiv3.KindProfile:
+		return updateprocessors.NewProfileUpdateProcessor()
+	case apiv3.KindHostEndpoint:
+		return updateprocessors.NewHostEndpointUpdateProcessor()
+	case libapiv3.KindWorkloadEndpoint:
+		return updateprocessors.NewWorkloadEndpointUpdateProcessor()
+	}
+	return nil
+}
+
+// flattenResources converts the loaded resources, which may include resource lists, into a
+// single slice of resources.
+func flattenResources(loaded []runtime.Object) ([]resourcemgr.ResourceObject, error) {
+	var res []resourcemgr.ResourceObject
+	for _, obj := range loaded {
+		switch r := obj.(type) {
+		case resourcemgr.ResourceObject:
+			res = append(res, r)
+		case resourcemgr.ResourceListObject:
+			items, err := meta.ExtractList(r)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				res = append(res, item.(resourcemgr.ResourceObject))
+			}
+		}
+	}
+	return res, nil
+}
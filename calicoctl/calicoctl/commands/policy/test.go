@@ -0,0 +1,266 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	docopt "github.com/docopt/docopt-go"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/argutils"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/common"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/commands/constants"
+	"github.com/projectcalico/calico/calicoctl/calicoctl/util"
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+// The tier name that the policy checker uses for profile entries in its trace.
+const profileTier = "__PROFILE__"
+
+// protocolNumbers maps the protocols that the policy checker supports to their numbers.
+var protocolNumbers = map[string]int{
+	"icmp": 1,
+	"tcp":  6,
+	"udp":  17,
+}
+
+// Test evaluates the policy that applies to a flow between two endpoints.
+func Test(args []string) error {
+	doc := constants.DatastoreIntro + `Usage:
+  <BINARY_NAME> policy test --from=<SOURCE> --to=<DESTINATION> [--port=<PORT>] [--protocol=<PROTOCOL>] [--source-port=<PORT>] [--filename=<FILENAME>] [--recursive] [--config=<CONFIG>] [--allow-version-mismatch]
+
+Options:
+  -h --help                    Show this screen.
+     --from=<SOURCE>           The source of the flow; either a pod, specified as
+                               <namespace>/<name>, or an IP address.
+     --to=<DESTINATION>        The destination of the flow; either a pod, specified
+                               as <namespace>/<name>, or an IP address.
+     --port=<PORT>             The destination port.  Required for TCP and UDP.
+     --protocol=<PROTOCOL>     The protocol; one of TCP, UDP or ICMP.
+                               [default: TCP]
+     --source-port=<PORT>      The source port. [default: 0]
+  -f --filename=<FILENAME>     Load the policies, tiers, profiles and endpoints from
+                               this file (or directory of files) rather than from
+                               the datastore.
+  -R --recursive               Process the directory specified in -f or --filename
+                               recursively.
+  -c --config=<CONFIG>         Path to the file containing connection configuration in
+                               YAML or JSON format.
+                               [default: ` + constants.DefaultConfigPath + `]
+     --allow-version-mismatch  Allow client and cluster versions mismatch.
+
+Description:
+  The policy test command evaluates the policies that would apply to a flow from
+  the source to the destination, as Felix would calculate them, without needing
+  any live traffic.  It prints the verdict together with the tier, policy and
+  rule that each end of the flow matched.  Staged policies are evaluated as
+  though they were enforced, including staged deletes, which remove the enforced
+  policy of the same name; any that would change the verdict are listed.
+
+  Policy is only evaluated for the ends of the flow that are Calico workloads;
+  IP addresses that don't belong to a workload are treated as having no policy.
+  Pods without a namespace are assumed to be in the default namespace.
+`
+	// Replace all instances of BINARY_NAME with the name of the binary.
+	name, _ := util.NameAndDescription()
+	doc = strings.ReplaceAll(doc, "<BINARY_NAME>", name)
+
+	parsedArgs, err := docopt.ParseArgs(doc, args, "")
+	if err != nil {
+		return fmt.Errorf("Invalid option: 'calicoctl %s'. Use flag '--help' to read about a specific subcommand.", strings.Join(args, " "))
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	protocol, srcPort, dstPort, err := parseFlowArgs(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	var snap *snapshot
+	if parsedArgs["--filename"] != nil {
+		snap, err = snapshotFromFiles(parsedArgs)
+	} else {
+		err = common.CheckVersionMismatch(parsedArgs["--config"], parsedArgs["--allow-version-mismatch"])
+		if err != nil {
+			return err
+		}
+		snap, err = snapshotFromDatastore(parsedArgs["--config"].(string))
+	}
+	if err != nil {
+		return err
+	}
+
+	src, err := resolveEndpoint(snap.updates, parsedArgs["--from"].(string))
+	if err != nil {
+		return err
+	}
+	dst, err := resolveEndpoint(snap.updates, parsedArgs["--to"].(string))
+	if err != nil {
+		return err
+	}
+	srcIP, dstIP, err := selectFlowIPs(src, dst)
+	if err != nil {
+		return err
+	}
+
+	flow := &testFlow{
+		srcIP:    srcIP,
+		dstIP:    dstIP,
+		srcPort:  srcPort,
+		dstPort:  dstPort,
+		protocol: protocol,
+	}
+	res, err := evaluate(snap, src, dst, flow)
+	if err != nil {
+		return err
+	}
+	printResult(os.Stdout, res)
+	return nil
+}
+
+// parseFlowArgs parses and validates the protocol and port arguments.
+func parseFlowArgs(parsedArgs map[string]interface{}) (protocol, srcPort, dstPort int, err error) {
+	protoStr := strings.ToLower(argutils.ArgStringOrBlank(parsedArgs, "--protocol"))
+	protocol, ok := protocolNumbers[protoStr]
+	if !ok {
+		if n, err := strconv.Atoi(protoStr); err == nil {
+			for _, num := range protocolNumbers {
+				if num == n {
+					protocol, ok = n, true
+				}
+			}
+		}
+	}
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("unsupported protocol %q; must be one of TCP, UDP or ICMP", protoStr)
+	}
+
+	parsePort := func(arg string) (int, error) {
+		s := argutils.ArgStringOrBlank(parsedArgs, arg)
+		port, err := strconv.ParseUint(s, 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value for %s: %q", arg, s)
+		}
+		return int(port), nil
+	}
+	if srcPort, err = parsePort("--source-port"); err != nil {
+		return
+	}
+	if parsedArgs["--port"] != nil {
+		if dstPort, err = parsePort("--port"); err != nil {
+			return
+		}
+	} else if protocol != protocolNumbers["icmp"] {
+		err = fmt.Errorf("--port is required for %s", strings.ToUpper(protoStr))
+		return
+	}
+	return
+}
+
+// printResult prints the verdict for the flow, the rule trace for each end of the flow and the
+// staged policies that would change the verdict.
+func printResult(w io.Writer, res *result) {
+	f := res.Flow
+	fmt.Fprintf(w, "Flow: %s -> %s, %s\n\n",
+		endpointDescription(res.Source, f.srcIP.String()),
+		endpointDescription(res.Destination, f.dstIP.String()),
+		flowDescription(f))
+
+	printDirection(w, "Egress from", res.Source, res.Egress)
+	printDirection(w, "Ingress to", res.Destination, res.Ingress)
+
+	fmt.Fprintf(w, "Verdict: %s\n", verdictString(res.Allowed()))
+
+	if res.PendingAllowed() == res.Allowed() {
+		fmt.Fprintln(w, "No staged policies would change the verdict.")
+		return
+	}
+	fmt.Fprintf(w, "\nVerdict if staged policies were enforced: %s\n", verdictString(res.PendingAllowed()))
+	for _, dr := range []*directionResult{res.Egress, res.Ingress} {
+		for _, name := range dr.StagedPolicies() {
+			fmt.Fprintf(w, "  %s (%s)\n", name, dr.Direction)
+		}
+	}
+}
+
+func printDirection(w io.Writer, heading string, ep *endpoint, dr *directionResult) {
+	if dr == nil {
+		fmt.Fprintf(w, "%s %s: not a Calico workload, no policy applied.\n\n", heading, ep.Name)
+		return
+	}
+	fmt.Fprintf(w, "%s %s (node %s):\n", heading, ep.Name, ep.Key.Hostname)
+	if len(dr.Trace) == 0 {
+		fmt.Fprintln(w, "No policies or profiles apply; the flow is denied.")
+	} else {
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"TIER", "POLICY", "RULE", "ACTION"})
+		for _, rid := range dr.Trace {
+			table.Append(traceRow(rid))
+		}
+		table.Render()
+	}
+	fmt.Fprintln(w)
+}
+
+func traceRow(rid *calc.RuleID) []string {
+	tier := rid.Tier
+	name := policyDisplayName(rid)
+	rule := strconv.Itoa(rid.Index)
+	switch {
+	case tier == profileTier && name == profileTier:
+		// The endpoint has no profiles.
+		tier, name, rule = "", "(no profiles)", ""
+	case tier == profileTier:
+		tier = "(profile)"
+	case rid.Index < 0:
+		rule = "end of tier"
+	}
+	return []string{tier, name, rule, rid.Action.String()}
+}
+
+func endpointDescription(ep *endpoint, ip string) string {
+	if ep.Name == ip {
+		return ip
+	}
+	return fmt.Sprintf("%s (%s)", ep.Name, ip)
+}
+
+func flowDescription(f *testFlow) string {
+	for name, num := range protocolNumbers {
+		if num != f.protocol {
+			continue
+		}
+		if name == "icmp" {
+			return "ICMP"
+		}
+		return fmt.Sprintf("%s port %d", strings.ToUpper(name), f.dstPort)
+	}
+	return fmt.Sprintf("protocol %d", f.protocol)
+}
+
+func verdictString(allowed bool) string {
+	if allowed {
+		return rules.RuleActionAllow.String()
+	}
+	return rules.RuleActionDeny.String()
+}
@@ -182,6 +182,33 @@ func TestIPAM(t *testing.T) {
 		Expect(outLines).To(ContainElement(And(ContainSubstring("Block"), ContainSubstring("10.66"), ContainSubstring("8 (100%)"), ContainSubstring("0 (0%)"))))
 		Expect(outLines).To(ContainElement(And(ContainSubstring("IP Pool"), ContainSubstring("fd5f"), ContainSubstring("7 (0%)"))))
 
+		// ipam defrag doesn't drain the partly-used block, because the host's other block has no room
+		// for its addresses.
+		out = Calicoctl(kdd, "ipam", "defrag", "--pool=ipam-test-v4-b29", "--threshold=50")
+		Expect(out).To(ContainSubstring("Started draining 0 block(s)"))
+		Expect(out).To(ContainSubstring("IPS RECLAIMABLE"))
+
+		// ipam defrag with an invalid threshold.
+		out, err = CalicoctlMayFail(kdd, "ipam", "defrag", "--threshold=0")
+		Expect(err).To(HaveOccurred())
+		Expect(out).To(ContainSubstring("Invalid threshold"))
+
+		// ipam migrate-pool only migrates between pools of the same IP version.
+		out, err = CalicoctlMayFail(kdd, "ipam", "migrate-pool", "--from=10.65.0.0/16", "--to=ipam-test-v6")
+		Expect(err).To(HaveOccurred())
+		Expect(out).To(ContainSubstring("not the same IP version"))
+
+		// ipam migrate-pool creates a migration for kube-controllers to carry out.
+		out = Calicoctl(kdd, "ipam", "migrate-pool", "--from=10.65.0.0/16", "--to=ipam-test-v4-b29", "--batch-size=5")
+		Expect(out).To(ContainSubstring("Created IP pool migration migrate-ipam-test-v4"))
+		migration, err := client.IPPoolMigrations().Get(ctx, "migrate-ipam-test-v4", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(migration.Spec).To(Equal(v3.IPPoolMigrationSpec{FromPool: "ipam-test-v4", ToPool: "ipam-test-v4-b29", BatchSize: 5}))
+		out = Calicoctl(kdd, "get", "ippoolmigrations")
+		Expect(out).To(ContainSubstring("migrate-ipam-test-v4"))
+		_, err = client.IPPoolMigrations().Delete(ctx, "migrate-ipam-test-v4", options.DeleteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
 		// Clean up resources
 		cidrs := append(v4, v4More...)
 		cidrs = append(cidrs, v6...)
@@ -816,3 +816,16 @@ func ResolvePools(ctx context.Context, c client.Interface, pools []string, isv4
 	}
 	return result, nil
 }
+
+// CheckDatastoreReady returns a CNI error if the datastore cannot be reached or has not been marked as ready
+// in the default ClusterInformation. It is used to implement the STATUS verb.
+func CheckDatastoreReady(ctx context.Context, calicoClient client.Interface) error {
+	ci, err := calicoClient.ClusterInformation().Get(ctx, "default", options.GetOptions{})
+	if err != nil {
+		return cnitypes.NewError(types.ErrPluginNotAvailable, "error getting ClusterInformation", err.Error())
+	}
+	if ci.Spec.DatastoreReady == nil || !*ci.Spec.DatastoreReady {
+		return cnitypes.NewError(types.ErrPluginNotAvailable, "Calico is currently not ready to process requests", "")
+	}
+	return nil
+}
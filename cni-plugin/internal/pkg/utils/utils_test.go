@@ -15,13 +15,41 @@
 package utils_test
 
 import (
+	"context"
+	"fmt"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 
 	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
 )
 
+// fakeClusterInfoClient is a Calico client that only serves the default ClusterInformation.
+type fakeClusterInfoClient struct {
+	client.Interface
+	ci  *apiv3.ClusterInformation
+	err error
+}
+
+func (c *fakeClusterInfoClient) ClusterInformation() client.ClusterInformationInterface {
+	return &fakeClusterInfo{c: c}
+}
+
+type fakeClusterInfo struct {
+	client.ClusterInformationInterface
+	c *fakeClusterInfoClient
+}
+
+func (f *fakeClusterInfo) Get(ctx context.Context, name string, opts options.GetOptions) (*apiv3.ClusterInformation, error) {
+	return f.c.ci, f.c.err
+}
+
 var _ = Describe("utils", func() {
 	table.DescribeTable("Mesos Labels", func(raw, sanitized string) {
 		result := utils.SanitizeMesosLabel(raw)
@@ -36,3 +64,26 @@ var _ = Describe("utils", func() {
 			"some_val-with.lots*of^weird#characters", "some_val-with.lots-of-weird-characters"),
 	)
 })
+
+var _ = Describe("CheckDatastoreReady", func() {
+	ready := true
+	notReady := false
+
+	It("should succeed if the datastore is ready", func() {
+		c := &fakeClusterInfoClient{ci: &apiv3.ClusterInformation{Spec: apiv3.ClusterInformationSpec{DatastoreReady: &ready}}}
+		Expect(utils.CheckDatastoreReady(context.Background(), c)).To(Succeed())
+	})
+
+	table.DescribeTable("should fail with ErrPluginNotAvailable", func(c *fakeClusterInfoClient) {
+		err := utils.CheckDatastoreReady(context.Background(), c)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*cnitypes.Error).Code).To(Equal(types.ErrPluginNotAvailable))
+	},
+		table.Entry("datastore not ready",
+			&fakeClusterInfoClient{ci: &apiv3.ClusterInformation{Spec: apiv3.ClusterInformationSpec{DatastoreReady: &notReady}}}),
+		table.Entry("readiness not set",
+			&fakeClusterInfoClient{ci: &apiv3.ClusterInformation{}}),
+		table.Entry("datastore unreachable",
+			&fakeClusterInfoClient{err: fmt.Errorf("connection refused")}),
+	)
+})
@@ -45,6 +45,12 @@ type Dataplane interface {
 	CleanUpNamespace(args *skel.CmdArgs) error
 }
 
+// Checker is implemented by dataplanes that are able to verify the networking previously set up by
+// DoNetworking, for use by the CNI CHECK command.
+type Checker interface {
+	CheckNetworking(args *skel.CmdArgs, hostVethName string, result *cniv1.Result) error
+}
+
 func GetDataplane(conf types.NetConf, logger *logrus.Entry) (Dataplane, error) {
 	name, ok := conf.DataplaneOptions["type"]
 	if !ok {
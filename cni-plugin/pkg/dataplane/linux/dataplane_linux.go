@@ -426,6 +426,73 @@ func SetupRoutes(hostNlHandle *netlink.Handle, hostVeth netlink.Link, result *cn
 	return nil
 }
 
+// CheckNetworking verifies that the networking set up by DoNetworking is still in place: the host side veth
+// exists and is up, each IP in the result has a route via the host veth, and the container interface exists
+// in the container's netns with the expected addresses.
+func (d *linuxDataplane) CheckNetworking(args *skel.CmdArgs, hostVethName string, result *cniv1.Result) error {
+	hostNlHandle, err := netlink.NewHandle(syscall.NETLINK_ROUTE)
+	if err != nil {
+		return fmt.Errorf("failed to create host netlink handle: %v", err)
+	}
+	defer hostNlHandle.Close()
+
+	hostVeth, err := hostNlHandle.LinkByName(hostVethName)
+	if err != nil {
+		return fmt.Errorf("failed to lookup host veth %q: %v", hostVethName, err)
+	}
+	if hostVeth.Type() != "veth" {
+		return fmt.Errorf("host interface %q is of type %q, expected veth", hostVethName, hostVeth.Type())
+	}
+	if hostVeth.Attrs().Flags&net.FlagUp == 0 {
+		return fmt.Errorf("host veth %q is not up", hostVethName)
+	}
+
+	routes, err := netlinkutils.RouteListRetryEINTR(hostNlHandle, hostVeth, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("error listing routes: %v", err)
+	}
+	for _, ipAddr := range result.IPs {
+		found := false
+		for _, r := range routes {
+			if r.Dst != nil && r.Dst.IP.Equal(ipAddr.Address.IP) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("missing route to %s via host veth %q", ipAddr.Address.IP, hostVethName)
+		}
+	}
+
+	if args.Netns == "" {
+		d.logger.Debug("No netns provided, skipping container interface check")
+		return nil
+	}
+	return ns.WithNetNSPath(args.Netns, func(_ ns.NetNS) error {
+		contVeth, err := netlink.LinkByName(args.IfName)
+		if err != nil {
+			return fmt.Errorf("failed to lookup container interface %q: %v", args.IfName, err)
+		}
+		addrs, err := netlink.AddrList(contVeth, netlink.FAMILY_ALL)
+		if err != nil {
+			return fmt.Errorf("failed to list addresses on container interface %q: %v", args.IfName, err)
+		}
+		for _, ipAddr := range result.IPs {
+			found := false
+			for _, a := range addrs {
+				if a.IP.Equal(ipAddr.Address.IP) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("container interface %q is missing address %s", args.IfName, ipAddr.Address.IP)
+			}
+		}
+		return nil
+	})
+}
+
 // configureSysctls configures necessary sysctls required for the host side of the veth pair for IPv4 and/or IPv6.
 func (d *linuxDataplane) configureSysctls(hostVethName string, hasIPv4, hasIPv6 bool) error {
 	var err error
@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipamplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+)
+
+// gcGracePeriod is the minimum age of an allocation before GC will consider releasing it. This protects
+// allocations made by an ADD that is still in progress, which the runtime will not yet report as a valid attachment.
+const gcGracePeriod = 1 * time.Minute
+
+// timestampLayout is the format used for the timestamp attribute stored with each allocation.
+const timestampLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// cmdGC releases IPAM handles belonging to this network and node whose container IDs are not in the
+// runtime's list of valid attachments.
+func cmdGC(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+
+	// The valid attachments are not part of Calico's NetConf, so parse them separately.
+	gcConf := cnitypes.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &gcConf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+
+	utils.ConfigureLogging(conf)
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return err
+	}
+
+	type accessor interface {
+		Backend() bapi.Client
+	}
+	bc, ok := calicoClient.(accessor)
+	if !ok {
+		return fmt.Errorf("calico client does not provide backend access")
+	}
+
+	nodename := utils.DetermineNodename(conf)
+	valid := map[string]bool{}
+	for _, a := range gcConf.ValidAttachments {
+		valid[a.ContainerID] = true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	handles, err := staleHandles(ctx, bc.Backend(), conf.Name, nodename, valid, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(handles) == 0 {
+		logrus.Debug("No stale IPAM handles found")
+		return nil
+	}
+
+	// Serialize with other IPAM operations on this host, as we do for ADD and DEL.
+	unlock := acquireIPAMLockBestEffort(conf.IPAMLockFile)
+	defer unlock()
+
+	for _, handleID := range handles {
+		logger := logrus.WithField("HandleID", handleID)
+		logger.Info("Releasing stale IPAM handle")
+		if err := calicoClient.IPAM().ReleaseByHandle(ctx, handleID); err != nil {
+			if _, ok := err.(errors.ErrorResourceDoesNotExist); !ok {
+				logger.WithError(err).Error("Failed to release stale IPAM handle")
+				return err
+			}
+			logger.Debug("Handle was released concurrently. Ignoring")
+		}
+	}
+	return nil
+}
+
+// staleHandles returns the IDs of IPAM handles created by this network on the given node whose container IDs
+// are not valid. A handle is only returned if all of its allocations were made by this node and are older than
+// the GC grace period.
+//
+// So that the cost of GC doesn't grow with the size of the cluster, only the blocks affine to this node are
+// examined. This reads the handle, node and timestamp of each allocation directly from the block, rather than
+// making further requests per handle. IPs that this node borrowed from other nodes' blocks are not considered,
+// and are left to the cluster-wide IPAM garbage collector in kube-controllers.
+func staleHandles(ctx context.Context, bc bapi.Client, netName, nodename string, valid map[string]bool, now time.Time) ([]string, error) {
+	affinities, err := bc.List(ctx, model.BlockAffinityListOptions{Host: nodename, AffinityType: string(ipam.AffinityTypeHost)}, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list block affinities: %w", err)
+	}
+
+	// Handles allocated by the CNI plugin are of the form "<network>.<containerID>". See utils.GetHandleID.
+	prefix := netName + "."
+
+	// Track whether each candidate handle is safe to release. A handle is disqualified by any allocation that
+	// belongs to another node or is within the grace period.
+	candidates := map[string]bool{}
+	var order []string
+	for _, kv := range affinities.KVPairs {
+		cidr := kv.Key.(model.BlockAffinityKey).CIDR
+		blockKV, err := bc.Get(ctx, model.BlockKey{CIDR: cidr}, "")
+		if err != nil {
+			if _, ok := err.(errors.ErrorResourceDoesNotExist); ok {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get IPAM block %s: %w", cidr, err)
+		}
+		block := blockKV.Value.(*model.AllocationBlock)
+		for _, attrIdx := range block.Allocations {
+			if attrIdx == nil || *attrIdx >= len(block.Attributes) {
+				continue
+			}
+			attrs := block.Attributes[*attrIdx]
+			if attrs.AttrPrimary == nil {
+				continue
+			}
+			handleID := *attrs.AttrPrimary
+			containerID, ok := strings.CutPrefix(handleID, prefix)
+			if !ok || containerID == "" || valid[containerID] {
+				continue
+			}
+
+			eligible, seen := candidates[handleID]
+			if !seen {
+				order = append(order, handleID)
+				eligible = true
+			}
+			candidates[handleID] = eligible && allocationCollectable(handleID, attrs.AttrSecondary, nodename, now)
+		}
+	}
+
+	var stale []string
+	for _, handleID := range order {
+		if candidates[handleID] {
+			stale = append(stale, handleID)
+		}
+	}
+	return stale, nil
+}
+
+// allocationCollectable returns true if an allocation with the given attributes was made on the given node
+// and is older than the GC grace period. Allocations without a valid timestamp, such as those made by older
+// versions of the plugin, are never collected, since we can't tell whether they are still being set up.
+func allocationCollectable(handleID string, attrs map[string]string, nodename string, now time.Time) bool {
+	logger := logrus.WithField("HandleID", handleID)
+	if attrs[ipam.AttributeNode] != nodename {
+		logger.WithField("node", attrs[ipam.AttributeNode]).Debug("Handle belongs to another node, skipping")
+		return false
+	}
+	ts, err := time.Parse(timestampLayout, attrs[ipam.AttributeTimestamp])
+	if err != nil {
+		logger.WithError(err).Debug("Allocation has no valid timestamp, skipping")
+		return false
+	}
+	if now.Sub(ts) < gcGracePeriod {
+		logger.WithField("timestamp", ts).Debug("Allocation is within the GC grace period, skipping")
+		return false
+	}
+	return true
+}
+
+// cmdStatus reports whether the plugin is able to serve IPAM requests, by checking that the datastore
+// is reachable and ready.
+func cmdStatus(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+
+	utils.ConfigureLogging(conf)
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return cnitypes.NewError(types.ErrPluginNotAvailable, "failed to create calico client", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return utils.CheckDatastoreReady(ctx, calicoClient)
+}
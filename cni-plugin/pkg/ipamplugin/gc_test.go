@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipamplugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// fakeBackend serves block affinities and blocks from memory. Any other use of the backend panics.
+type fakeBackend struct {
+	bapi.Client
+
+	affinities []model.BlockAffinityKey
+	blocks     map[string]*model.AllocationBlock
+	listErr    error
+	getErr     error
+
+	listed []model.ListInterface
+	gets   int
+}
+
+func (f *fakeBackend) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
+	f.listed = append(f.listed, list)
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	opts := list.(model.BlockAffinityListOptions)
+	kvps := &model.KVPairList{}
+	for _, k := range f.affinities {
+		if opts.Host == "" || k.Host == opts.Host {
+			kvps.KVPairs = append(kvps.KVPairs, &model.KVPair{Key: k})
+		}
+	}
+	return kvps, nil
+}
+
+func (f *fakeBackend) Get(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
+	f.gets++
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	k := key.(model.BlockKey)
+	b, ok := f.blocks[k.CIDR.String()]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: key}
+	}
+	return &model.KVPair{Key: k, Value: b}, nil
+}
+
+// allocation describes an allocation to add to a fake block. A zero time leaves out the timestamp.
+type allocation struct {
+	handle string
+	node   string
+	time   time.Time
+}
+
+func (f *fakeBackend) addBlock(cidr, host string, allocs ...allocation) {
+	_, n, err := cnet.ParseCIDR(cidr)
+	Expect(err).NotTo(HaveOccurred())
+	f.affinities = append(f.affinities, model.BlockAffinityKey{CIDR: *n, Host: host, AffinityType: string(ipam.AffinityTypeHost)})
+
+	b := &model.AllocationBlock{CIDR: *n, Allocations: make([]*int, 4)}
+	for i, a := range allocs {
+		handle := a.handle
+		idx := len(b.Attributes)
+		attrs := map[string]string{ipam.AttributeNode: a.node}
+		if !a.time.IsZero() {
+			attrs[ipam.AttributeTimestamp] = a.time.Format(timestampLayout)
+		}
+		b.Attributes = append(b.Attributes, model.AllocationAttribute{
+			AttrPrimary:   &handle,
+			AttrSecondary: attrs,
+		})
+		b.Allocations[i] = &idx
+	}
+	if f.blocks == nil {
+		f.blocks = map[string]*model.AllocationBlock{}
+	}
+	f.blocks[n.String()] = b
+}
+
+var _ = Describe("IPAM GC", func() {
+	var (
+		be    *fakeBackend
+		now   time.Time
+		old   time.Time
+		valid map[string]bool
+	)
+
+	BeforeEach(func() {
+		be = &fakeBackend{}
+		now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		old = now.Add(-time.Hour)
+		valid = map[string]bool{"live": true}
+	})
+
+	staleHandlesForNode := func() ([]string, error) {
+		return staleHandles(context.Background(), be, "net", "node1", valid, now)
+	}
+
+	It("should return handles for containers that are no longer valid", func() {
+		be.addBlock("10.0.0.0/30", "node1",
+			allocation{handle: "net.live", node: "node1", time: old},
+			allocation{handle: "net.dead", node: "node1", time: old},
+		)
+		be.addBlock("fd00::/126", "node1",
+			allocation{handle: "net.dead", node: "node1", time: old},
+			allocation{handle: "net.dead2", node: "node1", time: old},
+		)
+
+		handles, err := staleHandlesForNode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles).To(Equal([]string{"net.dead", "net.dead2"}))
+	})
+
+	It("should only examine blocks affine to this node", func() {
+		be.addBlock("10.0.0.0/30", "node1", allocation{handle: "net.dead", node: "node1", time: old})
+		be.addBlock("10.0.0.4/30", "node2", allocation{handle: "net.other", node: "node2", time: old})
+
+		handles, err := staleHandlesForNode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles).To(Equal([]string{"net.dead"}))
+		Expect(be.listed).To(Equal([]model.ListInterface{
+			model.BlockAffinityListOptions{Host: "node1", AffinityType: string(ipam.AffinityTypeHost)},
+		}))
+		Expect(be.gets).To(Equal(1))
+	})
+
+	It("should skip handles that belong to other networks or aren't from the CNI plugin", func() {
+		be.addBlock("10.0.0.0/30", "node1",
+			allocation{handle: "othernet.dead", node: "node1", time: old},
+			allocation{handle: "ipip-tunnel-addr-node1", node: "node1", time: old},
+			allocation{handle: "net.", node: "node1", time: old},
+		)
+
+		handles, err := staleHandlesForNode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles).To(BeEmpty())
+	})
+
+	It("should skip handles with any allocation from another node", func() {
+		be.addBlock("10.0.0.0/30", "node1",
+			allocation{handle: "net.dead", node: "node1", time: old},
+			allocation{handle: "net.dead", node: "node2", time: old},
+		)
+
+		handles, err := staleHandlesForNode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles).To(BeEmpty())
+	})
+
+	It("should skip handles with any allocation within the grace period", func() {
+		be.addBlock("10.0.0.0/30", "node1",
+			allocation{handle: "net.new", node: "node1", time: now.Add(-gcGracePeriod / 2)},
+		)
+		be.addBlock("fd00::/126", "node1",
+			allocation{handle: "net.dead", node: "node1", time: old},
+			allocation{handle: "net.dead", node: "node1", time: now},
+		)
+
+		handles, err := staleHandlesForNode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles).To(BeEmpty())
+	})
+
+	It("should skip handles with any allocation without a valid timestamp", func() {
+		be.addBlock("10.0.0.0/30", "node1",
+			allocation{handle: "net.untimed", node: "node1"},
+			allocation{handle: "net.dead", node: "node1", time: old},
+		)
+		be.blocks["10.0.0.0/30"].Attributes[1].AttrSecondary[ipam.AttributeTimestamp] = "yesterday"
+
+		handles, err := staleHandlesForNode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles).To(BeEmpty())
+	})
+
+	It("should ignore blocks that were deleted after listing affinities", func() {
+		be.addBlock("10.0.0.0/30", "node1", allocation{handle: "net.dead", node: "node1", time: old})
+		_, n, _ := cnet.ParseCIDR("10.0.0.4/30")
+		be.affinities = append(be.affinities, model.BlockAffinityKey{CIDR: *n, Host: "node1"})
+
+		handles, err := staleHandlesForNode()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(handles).To(Equal([]string{"net.dead"}))
+	})
+
+	It("should return an error if affinities can't be listed", func() {
+		be.listErr = fmt.Errorf("datastore unavailable")
+
+		_, err := staleHandlesForNode()
+		Expect(err).To(MatchError(ContainSubstring("datastore unavailable")))
+	})
+
+	It("should return an error if a block can't be read", func() {
+		be.addBlock("10.0.0.0/30", "node1", allocation{handle: "net.dead", node: "node1", time: old})
+		be.getErr = fmt.Errorf("datastore unavailable")
+
+		_, err := staleHandlesForNode()
+		Expect(err).To(MatchError(ContainSubstring("datastore unavailable")))
+	})
+})
@@ -102,13 +102,15 @@ func Main(version string) {
 	}
 
 	funcs := skel.CNIFuncs{
-		Add:   cmdAdd,
-		Check: nil,
-		Del:   cmdDel,
+		Add:    cmdAdd,
+		Check:  nil,
+		Del:    cmdDel,
+		GC:     cmdGC,
+		Status: cmdStatus,
 	}
 
 	skel.PluginMainFuncs(funcs,
-		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0"),
+		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0", "1.1.0"),
 		"Calico CNI IPAM "+version)
 }
 
@@ -281,7 +283,11 @@ func cmdAdd(args *skel.CmdArgs) error {
 			v6ips = v6Assignments.IPs
 		}
 		logger.Infof("Calico CNI IPAM assigned addresses IPv4=%v IPv6=%v", v4ips, v6ips)
-		if err != nil {
+		if quotaErr, ok := err.(ipam.QuotaExceededError); ok {
+			// Report quota errors as transient; the pod can start once other pods in the namespace release their addresses.
+			return cnitypes.NewError(cnitypes.ErrTryAgainLater,
+				fmt.Sprintf("namespace %s has reached its IP address quota", quotaErr.Namespace), err.Error())
+		} else if err != nil {
 			return err
 		}
 
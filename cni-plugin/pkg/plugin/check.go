@@ -0,0 +1,233 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/skel"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	cniv1 "github.com/containernetworking/cni/pkg/types/100"
+	cniSpecVersion "github.com/containernetworking/cni/pkg/version"
+	"github.com/containernetworking/plugins/pkg/ipam"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/cni-plugin/internal/pkg/utils"
+	"github.com/projectcalico/calico/cni-plugin/pkg/dataplane"
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+// cmdCheck verifies that the networking for a container set up by a previous ADD is still in place. It checks that
+// the WorkloadEndpoint exists in the datastore and matches the previous result, and that the host side veth and
+// routes are still programmed.
+func cmdCheck(args *skel.CmdArgs) (err error) {
+	// Defer a panic recover, so that in case we panic we can still return
+	// a proper error to the runtime.
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("Calico CNI panicked during CHECK: %s\nStack trace:\n%s", e, string(debug.Stack()))
+			if err != nil {
+				msg = fmt.Sprintf("%s: error=%s", msg, err)
+			}
+			err = errors.New(msg)
+		}
+		if err != nil {
+			logrus.WithError(err).Error("Final result of CNI CHECK was an error.")
+		}
+	}()
+
+	conf := types.NetConf{}
+	if err = json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+
+	utils.ConfigureLogging(conf)
+
+	// The previous result isn't part of Calico's NetConf, so parse it separately.
+	prevConf := cnitypes.NetConf{}
+	if err = json.Unmarshal(args.StdinData, &prevConf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+	if err = cniSpecVersion.ParsePrevResult(&prevConf); err != nil {
+		return err
+	}
+	if prevConf.PrevResult == nil {
+		return errors.New("required prevResult missing")
+	}
+	var result *cniv1.Result
+	result, err = cniv1.NewResultFromResult(prevConf.PrevResult)
+	if err != nil {
+		return fmt.Errorf("failed to convert prevResult: %v", err)
+	}
+
+	nodename := utils.DetermineNodename(conf)
+
+	var epIDs *utils.WEPIdentifiers
+	epIDs, err = utils.GetIdentifiers(args, nodename)
+	if err != nil {
+		return
+	}
+	epIDs.WEPName, err = epIDs.CalculateWorkloadEndpointName(false)
+	if err != nil {
+		return fmt.Errorf("error constructing WorkloadEndpoint name: %s", err)
+	}
+	logger := logrus.WithFields(logrus.Fields{
+		"ContainerID":      epIDs.ContainerID,
+		"WorkloadEndpoint": epIDs.WEPName,
+	})
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return
+	}
+
+	ctx := context.Background()
+	var endpoint *libapi.WorkloadEndpoint
+	endpoint, err = calicoClient.WorkloadEndpoints().Get(ctx, epIDs.Namespace, epIDs.WEPName, options.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting WorkloadEndpoint %s: %v", epIDs.WEPName, err)
+	}
+	if err = checkEndpointMatchesResult(endpoint, args.ContainerID, result); err != nil {
+		return
+	}
+	logger.Debug("WorkloadEndpoint matches previous result")
+
+	var d dataplane.Dataplane
+	d, err = dataplane.GetDataplane(conf, logger)
+	if err != nil {
+		return
+	}
+	if checker, ok := d.(dataplane.Checker); ok {
+		if err = checker.CheckNetworking(args, endpoint.Spec.InterfaceName, result); err != nil {
+			return
+		}
+		logger.Debug("Dataplane matches previous result")
+	} else {
+		logger.Debug("Dataplane does not support CHECK, skipping dataplane verification")
+	}
+	return nil
+}
+
+// checkEndpointMatchesResult verifies that the given WorkloadEndpoint belongs to the given container and
+// owns the IPs from the previous result.
+func checkEndpointMatchesResult(endpoint *libapi.WorkloadEndpoint, containerID string, result *cniv1.Result) error {
+	if endpoint.Spec.ContainerID != "" && endpoint.Spec.ContainerID != containerID {
+		return fmt.Errorf("WorkloadEndpoint %s belongs to container %s, not %s",
+			endpoint.Name, endpoint.Spec.ContainerID, containerID)
+	}
+	if endpoint.Spec.InterfaceName == "" {
+		return fmt.Errorf("WorkloadEndpoint %s has no interface name", endpoint.Name)
+	}
+
+	for _, ipAddr := range result.IPs {
+		found := false
+		for _, n := range endpoint.Spec.IPNetworks {
+			ip, _, err := cnet.ParseCIDROrIP(n)
+			if err == nil && ip.Equal(ipAddr.Address.IP) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("WorkloadEndpoint %s does not contain IP %s", endpoint.Name, ipAddr.Address.IP)
+		}
+	}
+	return nil
+}
+
+// cmdStatus reports whether the plugin is ready to service ADD requests: calico/node must be running,
+// the datastore must be ready, any configured readiness gates must pass, and the IPAM plugin must be available.
+func cmdStatus(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+
+	utils.ConfigureLogging(conf)
+
+	nodeNameFile := "/var/lib/calico/nodename"
+	if conf.NodenameFile != "" {
+		nodeNameFile = conf.NodenameFile
+	}
+	if !conf.NodenameFileOptional {
+		if _, err := os.Stat(nodeNameFile); err != nil {
+			return cnitypes.NewError(types.ErrPluginNotAvailable,
+				"calico/node is not running", fmt.Sprintf("%s: check that the calico/node container is running and has mounted /var/lib/calico/", err))
+		}
+	}
+
+	calicoClient, err := utils.CreateClient(conf)
+	if err != nil {
+		return cnitypes.NewError(types.ErrPluginNotAvailable, "error creating calico client", err.Error())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testConnectionTimeout)
+	defer cancel()
+	if err := utils.CheckDatastoreReady(ctx, calicoClient); err != nil {
+		return err
+	}
+
+	// Readiness gates are typically used to wait for Felix to be ready before networking pods.
+	for _, endpoint := range conf.ReadinessGates {
+		if ready, err := isEndpointReady(endpoint, 5*time.Second); !ready {
+			details := ""
+			if err != nil {
+				details = err.Error()
+			}
+			return cnitypes.NewError(types.ErrPluginNotAvailable, fmt.Sprintf("readiness gate %s is not ready", endpoint), details)
+		}
+	}
+
+	// Finally, check that the IPAM plugin is ready.
+	if conf.IPAM.Type != "" {
+		if err := ipam.ExecStatus(conf.IPAM.Type, args.StdinData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cmdGC cleans up resources for attachments that the runtime no longer considers valid. Calico doesn't keep any
+// per-attachment state of its own outside of the WorkloadEndpoint, which is removed along with the pod, so this
+// delegates to the IPAM plugin to release any leaked IP addresses.
+func cmdGC(args *skel.CmdArgs) error {
+	conf := types.NetConf{}
+	if err := json.Unmarshal(args.StdinData, &conf); err != nil {
+		return fmt.Errorf("failed to load netconf: %v", err)
+	}
+
+	utils.ConfigureLogging(conf)
+
+	if conf.IPAM.Type == "" {
+		logrus.Debug("No IPAM plugin configured, nothing to garbage collect")
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+	if err := invoke.DelegateGC(ctx, conf.IPAM.Type, args.StdinData, nil); err != nil {
+		logrus.WithError(err).Error("Final result of CNI GC was an error.")
+		return err
+	}
+	return nil
+}
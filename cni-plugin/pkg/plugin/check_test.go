@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	cniv1 "github.com/containernetworking/cni/pkg/types/100"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/cni-plugin/pkg/types"
+	libapi "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+)
+
+var _ = Describe("CHECK", func() {
+	var (
+		endpoint *libapi.WorkloadEndpoint
+		result   *cniv1.Result
+	)
+
+	BeforeEach(func() {
+		endpoint = libapi.NewWorkloadEndpoint()
+		endpoint.Name = "node1-k8s-pod1-eth0"
+		endpoint.Spec.ContainerID = "abcd"
+		endpoint.Spec.InterfaceName = "cali12345"
+		endpoint.Spec.IPNetworks = []string{"10.0.0.1/32", "fd00::1/128"}
+
+		result = &cniv1.Result{
+			CNIVersion: "1.1.0",
+			IPs: []*cniv1.IPConfig{
+				{Address: net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(32, 32)}},
+				{Address: net.IPNet{IP: net.ParseIP("fd00::1"), Mask: net.CIDRMask(128, 128)}},
+			},
+		}
+	})
+
+	It("should pass for an endpoint that matches the previous result", func() {
+		Expect(checkEndpointMatchesResult(endpoint, "abcd", result)).To(Succeed())
+	})
+
+	It("should pass for an endpoint that doesn't record its container ID", func() {
+		endpoint.Spec.ContainerID = ""
+		Expect(checkEndpointMatchesResult(endpoint, "abcd", result)).To(Succeed())
+	})
+
+	It("should fail for an endpoint that belongs to another container", func() {
+		err := checkEndpointMatchesResult(endpoint, "efgh", result)
+		Expect(err).To(MatchError(ContainSubstring("belongs to container abcd")))
+	})
+
+	It("should fail for an endpoint without an interface", func() {
+		endpoint.Spec.InterfaceName = ""
+		err := checkEndpointMatchesResult(endpoint, "abcd", result)
+		Expect(err).To(MatchError(ContainSubstring("has no interface name")))
+	})
+
+	It("should fail for an endpoint that is missing an IP from the previous result", func() {
+		endpoint.Spec.IPNetworks = []string{"10.0.0.1/32"}
+		err := checkEndpointMatchesResult(endpoint, "abcd", result)
+		Expect(err).To(MatchError(ContainSubstring("does not contain IP fd00::1")))
+	})
+
+	It("should fail without a previous result", func() {
+		err := cmdCheck(&skel.CmdArgs{
+			ContainerID: "abcd",
+			StdinData:   []byte(`{"cniVersion": "1.1.0", "name": "net", "type": "calico"}`),
+		})
+		Expect(err).To(MatchError("required prevResult missing"))
+	})
+
+	It("should fail with invalid config", func() {
+		Expect(cmdCheck(&skel.CmdArgs{StdinData: []byte(`{`)})).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("STATUS", func() {
+	It("should report that the plugin isn't available if calico/node isn't running", func() {
+		conf := `{"cniVersion": "1.1.0", "name": "net", "type": "calico", "nodename_file": "/nonexistent/nodename"}`
+		err := cmdStatus(&skel.CmdArgs{StdinData: []byte(conf)})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*cnitypes.Error).Code).To(Equal(types.ErrPluginNotAvailable))
+		Expect(err.(*cnitypes.Error).Msg).To(Equal("calico/node is not running"))
+	})
+
+	It("should fail with invalid config", func() {
+		Expect(cmdStatus(&skel.CmdArgs{StdinData: []byte(`{`)})).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("GC", func() {
+	var cniPath, oldPath string
+
+	BeforeEach(func() {
+		var err error
+		cniPath, err = os.MkdirTemp("", "cni-gc-test")
+		Expect(err).NotTo(HaveOccurred())
+		oldPath = os.Getenv("CNI_PATH")
+		Expect(os.Setenv("CNI_PATH", cniPath)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		_ = os.Setenv("CNI_PATH", oldPath)
+		_ = os.RemoveAll(cniPath)
+	})
+
+	// writeIPAMPlugin installs a fake IPAM plugin with the given script body.
+	writeIPAMPlugin := func(body string) {
+		script := "#!/bin/sh\n" + body + "\n"
+		Expect(os.WriteFile(filepath.Join(cniPath, "fake-ipam"), []byte(script), 0o755)).To(Succeed())
+	}
+
+	gcArgs := func(ipamType string) *skel.CmdArgs {
+		conf := fmt.Sprintf(`{"cniVersion": "1.1.0", "name": "net", "type": "calico", "ipam": {"type": %q}}`, ipamType)
+		return &skel.CmdArgs{StdinData: []byte(conf)}
+	}
+
+	It("should succeed without an IPAM plugin", func() {
+		Expect(cmdGC(gcArgs(""))).To(Succeed())
+	})
+
+	It("should delegate to the IPAM plugin", func() {
+		marker := filepath.Join(cniPath, "called")
+		writeIPAMPlugin(fmt.Sprintf(`[ "$CNI_COMMAND" = GC ] && touch %s`, marker))
+		Expect(cmdGC(gcArgs("fake-ipam"))).To(Succeed())
+		Expect(marker).To(BeAnExistingFile())
+	})
+
+	It("should return an error from the IPAM plugin", func() {
+		writeIPAMPlugin(`echo '{"cniVersion": "1.1.0", "code": 11, "msg": "gc failed"}'; exit 1`)
+		Expect(cmdGC(gcArgs("fake-ipam"))).To(MatchError(ContainSubstring("gc failed")))
+	})
+
+	It("should fail if the IPAM plugin can't be found", func() {
+		Expect(cmdGC(gcArgs("missing-ipam"))).NotTo(Succeed())
+	})
+
+	It("should fail with invalid config", func() {
+		Expect(cmdGC(&skel.CmdArgs{StdinData: []byte(`{`)})).NotTo(Succeed())
+	})
+})
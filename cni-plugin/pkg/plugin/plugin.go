@@ -173,7 +173,7 @@ func cmdAdd(args *skel.CmdArgs) (err error) {
 		conf.CNIVersion = "0.2.0"
 	}
 
-	if version.Compare(conf.CNIVersion, "1.0.0", ">") {
+	if version.Compare(conf.CNIVersion, "1.1.0", ">") {
 		return fmt.Errorf("unsupported CNI version %s", conf.CNIVersion)
 	}
 
@@ -701,11 +701,6 @@ func cmdDel(args *skel.CmdArgs) (err error) {
 	return
 }
 
-func cmdDummyCheck(args *skel.CmdArgs) (err error) {
-	fmt.Println("OK")
-	return nil
-}
-
 func Main(version string) {
 	// Set up logging formatting.
 	logutils.ConfigureFormatter("cni-plugin")
@@ -764,11 +759,13 @@ func Main(version string) {
 	}
 
 	funcs := skel.CNIFuncs{
-		Add:   cmdAdd,
-		Del:   cmdDel,
-		Check: cmdDummyCheck,
+		Add:    cmdAdd,
+		Del:    cmdDel,
+		Check:  cmdCheck,
+		GC:     cmdGC,
+		Status: cmdStatus,
 	}
 	skel.PluginMainFuncs(funcs,
-		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0"),
+		cniSpecVersion.PluginSupports("0.1.0", "0.2.0", "0.3.0", "0.3.1", "0.4.0", "1.0.0", "1.1.0"),
 		"Calico CNI plugin "+version)
 }
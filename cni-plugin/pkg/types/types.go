@@ -20,6 +20,10 @@ import (
 	"github.com/containernetworking/cni/pkg/types"
 )
 
+// ErrPluginNotAvailable is the error code returned by the STATUS verb, as defined by version 1.1.0
+// of the CNI specification, when the plugin is not able to service ADD requests.
+const ErrPluginNotAvailable uint = 50
+
 // Policy is a struct to hold policy config (which currently happens to also contain some K8s config)
 type Policy struct {
 	PolicyType              string `json:"type"`
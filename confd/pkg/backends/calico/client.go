@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 	"github.com/projectcalico/api/pkg/lib/numorstring"
@@ -489,6 +490,33 @@ type bgpPeer struct {
 	Filters         []string             `json:"filters"`
 	PassiveMode     bool                 `json:"passive_mode"`
 	LocalBGPPeer    bool                 `json:"local_bgp_peer"`
+	BFD             *bgpBFD              `json:"bfd,omitempty"`
+}
+
+// bgpBFD holds the BFD settings for a peering, with the intervals in milliseconds.  Zero values
+// mean that the BIRD default is used.
+type bgpBFD struct {
+	MinRxInterval int64 `json:"min_rx_interval"`
+	MinTxInterval int64 `json:"min_tx_interval"`
+	Multiplier    int32 `json:"multiplier"`
+}
+
+// newBGPBFD converts the v3 BFD settings to their v1 form.  It returns nil if BFD isn't enabled.
+func newBGPBFD(bfd *apiv3.BGPBFD) *bgpBFD {
+	if bfd == nil || !bfd.Enabled {
+		return nil
+	}
+	b := &bgpBFD{}
+	if bfd.MinRxInterval != nil {
+		b.MinRxInterval = bfd.MinRxInterval.Duration.Round(time.Millisecond).Milliseconds()
+	}
+	if bfd.MinTxInterval != nil {
+		b.MinTxInterval = bfd.MinTxInterval.Duration.Round(time.Millisecond).Milliseconds()
+	}
+	if bfd.Multiplier != nil {
+		b.Multiplier = *bfd.Multiplier
+	}
+	return b
 }
 
 type bgpPrefix struct {
@@ -858,6 +886,7 @@ func (c *client) localBGPPeerDataAsBGPPeers(localBGPPeerData localBGPPeerData, v
 		peer.SourceAddr = "None"
 		peer.PassiveMode = true
 		peer.LocalBGPPeer = true
+		peer.BFD = newBGPBFD(v3Peer.Spec.BFD)
 		peers = append(peers, peer)
 	}
 	return
@@ -1178,6 +1207,7 @@ func (c *client) updateBGPConfigCache(resName string, v3res *apiv3.BGPConfigurat
 		c.getLogSeverityKVPair(v3res, model.GlobalBGPConfigKey{})
 		c.getNodeMeshRestartTimeKVPair(v3res, model.GlobalBGPConfigKey{})
 		c.getNodeMeshPasswordKVPair(v3res, model.GlobalBGPConfigKey{})
+		c.getNodeMeshBFDKVPair(v3res, model.GlobalBGPConfigKey{})
 		c.getIgnoredInterfacesKVPair(v3res, model.GlobalBGPConfigKey{})
 
 		// Cache the updated BGP configuration
@@ -1439,6 +1469,20 @@ func (c *client) getNodeMeshRestartTimeKVPair(v3res *apiv3.BGPConfiguration, key
 	}
 }
 
+func (c *client) getNodeMeshBFDKVPair(v3res *apiv3.BGPConfiguration, key interface{}) {
+	meshBFDKey := getBGPConfigKey("node_mesh_bfd", key)
+
+	if v3res != nil && v3res.Spec.NodeMeshBFD != nil && v3res.Spec.NodeMeshBFD.Enabled {
+		bfd, err := json.Marshal(newBGPBFD(v3res.Spec.NodeMeshBFD))
+		if err != nil {
+			log.Warningf("Error while marshalling node mesh BFD settings. %#v", err)
+		}
+		c.updateCache(api.UpdateTypeKVUpdated, getKVPair(meshBFDKey, string(bfd)))
+	} else {
+		c.updateCache(api.UpdateTypeKVDeleted, getKVPair(meshBFDKey))
+	}
+}
+
 func (c *client) getNodeMeshPasswordKVPair(v3res *apiv3.BGPConfiguration, key interface{}) {
 	meshPasswordKey := getBGPConfigKey("node_mesh_password", key)
 
@@ -1929,6 +1973,7 @@ func (c *client) setPeerConfigFieldsFromV3Resource(peers []*bgpPeer, v3res *apiv
 		if v3res.Spec.MaxRestartTime != nil {
 			peer.RestartTime = fmt.Sprintf("%v", int(math.Round(v3res.Spec.MaxRestartTime.Duration.Seconds())))
 		}
+		peer.BFD = newBGPBFD(v3res.Spec.BFD)
 	}
 }
 
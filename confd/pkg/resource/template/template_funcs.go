@@ -16,6 +16,7 @@ import (
 
 	"github.com/kelseyhightower/memkv"
 	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
 )
 
 func newFuncMap() map[string]interface{} {
@@ -42,6 +43,7 @@ func newFuncMap() map[string]interface{} {
 	m["hashToIPv4"] = hashToIPv4
 	m["bgpFilterFunctionName"] = BGPFilterFunctionName
 	m["bgpFilterBIRDFuncs"] = BGPFilterBIRDFuncs
+	m["bfdBIRDConfig"] = BFDBIRDConfig
 	return m
 }
 
@@ -60,6 +62,18 @@ func filterStatement(fields filterArgs) (string, error) {
 		return "", err
 	}
 
+	if len(fields.operations) > 0 {
+		var statements []string
+		for _, op := range fields.operations {
+			opStatement, err := filterOperation(op)
+			if err != nil {
+				return "", err
+			}
+			statements = append(statements, opStatement)
+		}
+		actionStatement = strings.Join(append(statements, actionStatement), " ")
+	}
+
 	var conditions []string
 	if fields.cidr != "" {
 		if fields.operator == "" {
@@ -88,6 +102,18 @@ func filterStatement(fields filterArgs) (string, error) {
 		conditions = append(conditions, ifaceCondition)
 	}
 
+	if len(fields.communities) > 0 {
+		communitiesCondition, err := filterMatchCommunities(fields.communities)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, communitiesCondition)
+	}
+
+	if len(fields.asPathPrefix) > 0 {
+		conditions = append(conditions, filterMatchASPathPrefix(fields.asPathPrefix))
+	}
+
 	conditionExpr := strings.Join(conditions, "&&")
 	if conditionExpr != "" {
 		return fmt.Sprintf("if (%s) then { %s }", conditionExpr, actionStatement), nil
@@ -173,6 +199,82 @@ func filterMatchInterface(iface string) (string, error) {
 	return fmt.Sprintf("((defined(ifname))&&(ifname ~ \"%s\"))", iface), nil
 }
 
+// birdCommunity converts a standard (aa:nn) or large (aa:nn:mm) community into a BIRD pair or
+// triple, and returns it along with the name of the route attribute that holds that kind of
+// community.
+// e.g. input of "65000:100" produces output of ("bgp_community", "(65000,100)")
+func birdCommunity(community string) (string, string, error) {
+	parts := strings.Split(community, ":")
+	for _, part := range parts {
+		if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+			return "", "", fmt.Errorf("unexpected community found in BGPFilter: %s", community)
+		}
+	}
+	value := fmt.Sprintf("(%s)", strings.Join(parts, ","))
+	switch len(parts) {
+	case 2:
+		return "bgp_community", value, nil
+	case 3:
+		return "bgp_large_community", value, nil
+	default:
+		return "", "", fmt.Errorf("unexpected community found in BGPFilter: %s", community)
+	}
+}
+
+func filterMatchCommunities(communities []string) (string, error) {
+	var conditions []string
+	for _, c := range communities {
+		attr, value, err := birdCommunity(c)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s ~ %s)", value, attr))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(conditions, "||")), nil
+}
+
+func filterMatchASPathPrefix(asPathPrefix []numorstring.ASNumber) string {
+	var asns []string
+	for _, asn := range asPathPrefix {
+		asns = append(asns, asn.String())
+	}
+	return fmt.Sprintf("(bgp_path ~ [= %s * =])", strings.Join(asns, " "))
+}
+
+// filterOperation produces the BIRD statements that make a single BGPFilter modification to a route.
+// e.g. input of {PrependASPath: [65001, 65002]} produces output of
+// "bgp_path.prepend(65002); bgp_path.prepend(65001);"
+func filterOperation(op v3.BGPFilterOperation) (string, error) {
+	switch {
+	case op.AddCommunity != "":
+		attr, value, err := birdCommunity(op.AddCommunity)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s.add(%s);", attr, value), nil
+	case op.RemoveCommunity != "":
+		attr, value, err := birdCommunity(op.RemoveCommunity)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s.delete(%s);", attr, value), nil
+	case op.SetMED != nil:
+		return fmt.Sprintf("bgp_med = %d;", *op.SetMED), nil
+	case op.SetLocalPreference != nil:
+		return fmt.Sprintf("bgp_local_pref = %d;", *op.SetLocalPreference), nil
+	case len(op.PrependASPath) > 0:
+		// Each prepend puts its AS number at the front of the path, so prepend in reverse
+		// order to leave the path starting with the AS numbers in the order given.
+		var statements []string
+		for i := len(op.PrependASPath) - 1; i >= 0; i-- {
+			statements = append(statements, fmt.Sprintf("bgp_path.prepend(%s);", op.PrependASPath[i]))
+		}
+		return strings.Join(statements, " "), nil
+	default:
+		return "", fmt.Errorf("empty operation found in BGPFilter")
+	}
+}
+
 // BGPFilterFunctionName returns a formatted name for use as a BIRD function, truncating and hashing if the provided
 // name would result in a function name longer than the max allowable length of 64 chars.
 // e.g. input of ("my-bgp-filter", "import", "4") would result in output of "'bgp_my-bpg-filter_importFilterV4'"
@@ -199,6 +301,9 @@ type filterArgs struct {
 	prefixLengthV6 *v3.BGPFilterPrefixLengthV6
 	source         v3.BGPFilterMatchSource
 	iface          string
+	communities    []string
+	asPathPrefix   []numorstring.ASNumber
+	operations     []v3.BGPFilterOperation
 	action         v3.BGPFilterAction
 }
 
@@ -306,6 +411,9 @@ func BGPFilterBIRDFuncs(pairs memkv.KVPairs, version int) ([]string, error) {
 						prefixLengthV4: importV4.PrefixLength,
 						source:         importV4.Source,
 						iface:          importV4.Interface,
+						communities:    importV4.Communities,
+						asPathPrefix:   importV4.ASPathPrefix,
+						operations:     importV4.Operations,
 						action:         importV4.Action,
 					})
 				}
@@ -317,6 +425,9 @@ func BGPFilterBIRDFuncs(pairs memkv.KVPairs, version int) ([]string, error) {
 						prefixLengthV6: importV6.PrefixLength,
 						source:         importV6.Source,
 						iface:          importV6.Interface,
+						communities:    importV6.Communities,
+						asPathPrefix:   importV6.ASPathPrefix,
+						operations:     importV6.Operations,
 						action:         importV6.Action,
 					})
 				}
@@ -353,6 +464,9 @@ func BGPFilterBIRDFuncs(pairs memkv.KVPairs, version int) ([]string, error) {
 						prefixLengthV4: exportV4.PrefixLength,
 						source:         exportV4.Source,
 						iface:          exportV4.Interface,
+						communities:    exportV4.Communities,
+						asPathPrefix:   exportV4.ASPathPrefix,
+						operations:     exportV4.Operations,
 						action:         exportV4.Action,
 					})
 				}
@@ -364,6 +478,9 @@ func BGPFilterBIRDFuncs(pairs memkv.KVPairs, version int) ([]string, error) {
 						prefixLengthV6: exportV6.PrefixLength,
 						source:         exportV6.Source,
 						iface:          exportV6.Interface,
+						communities:    exportV6.Communities,
+						asPathPrefix:   exportV6.ASPathPrefix,
+						operations:     exportV6.Operations,
 						action:         exportV6.Action,
 					})
 				}
@@ -389,6 +506,85 @@ func BGPFilterBIRDFuncs(pairs memkv.KVPairs, version int) ([]string, error) {
 	return lines, nil
 }
 
+// bfdSettings is the v1 form of the BFD settings for a peering, as written by the Calico
+// backend.  The intervals are in milliseconds and zero values mean the BIRD default.
+type bfdSettings struct {
+	MinRxInterval int64 `json:"min_rx_interval"`
+	MinTxInterval int64 `json:"min_tx_interval"`
+	Multiplier    int32 `json:"multiplier"`
+}
+
+// BFDBIRDConfig produces the BIRD BFD protocol, which BIRD needs in order to run the BFD
+// sessions of any peering, if BFD is enabled for the node-to-node mesh or any of the given BGP
+// peerings.  The peerings themselves only turn BFD on: BIRD 1.x takes the BFD timers per
+// interface, for single hop sessions, and once for all multihop sessions, rather than per
+// peering.  The sessions therefore share one set of timers, using for each timer the most
+// aggressive value that any of the peerings asks for.  Timers that no peering sets are left out
+// so that the BIRD defaults apply.  No lines are produced if BFD isn't enabled for any peering.
+func BFDBIRDConfig(meshBFD string, peerSets ...memkv.KVPairs) ([]string, error) {
+	var settings []bfdSettings
+	if meshBFD != "" {
+		var s bfdSettings
+		if err := json.Unmarshal([]byte(meshBFD), &s); err != nil {
+			return []string{}, fmt.Errorf("error unmarshalling JSON: %s", err)
+		}
+		settings = append(settings, s)
+	}
+	for _, peers := range peerSets {
+		for _, kvp := range peers {
+			var peer struct {
+				BFD *bfdSettings `json:"bfd"`
+			}
+			if err := json.Unmarshal([]byte(kvp.Value), &peer); err != nil {
+				return []string{}, fmt.Errorf("error unmarshalling JSON: %s", err)
+			}
+			if peer.BFD != nil {
+				settings = append(settings, *peer.BFD)
+			}
+		}
+	}
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	var timers bfdSettings
+	for _, s := range settings {
+		timers.MinRxInterval = minNonZero(timers.MinRxInterval, s.MinRxInterval)
+		timers.MinTxInterval = minNonZero(timers.MinTxInterval, s.MinTxInterval)
+		timers.Multiplier = minNonZero(timers.Multiplier, s.Multiplier)
+	}
+	var options []string
+	if timers.MinRxInterval != 0 {
+		options = append(options, fmt.Sprintf("min rx interval %d ms;", timers.MinRxInterval))
+	}
+	if timers.MinTxInterval != 0 {
+		options = append(options, fmt.Sprintf("min tx interval %d ms;", timers.MinTxInterval))
+	}
+	if timers.Multiplier != 0 {
+		options = append(options, fmt.Sprintf("multiplier %d;", timers.Multiplier))
+	}
+
+	lines := []string{"protocol bfd {"}
+	if len(options) > 0 {
+		for _, sessions := range []string{`interface "*" {`, "multihop {"} {
+			lines = append(lines, "  "+sessions)
+			for _, option := range options {
+				lines = append(lines, "    "+option)
+			}
+			lines = append(lines, "  };")
+		}
+	}
+	return append(lines, "}"), nil
+}
+
+// minNonZero returns the smaller of the two values, ignoring zero values.
+func minNonZero[T int32 | int64](a, b T) T {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
 // The maximum length of a k8s resource (253 bytes) is longer than the maximum length of BIRD symbols (64 chars).
 // This function provides a way to map the k8s resource name to a BIRD symbol name that accounts
 // for the length difference in a way that minimizes the chance of collisions
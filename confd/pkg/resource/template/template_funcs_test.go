@@ -7,6 +7,7 @@ import (
 
 	"github.com/kelseyhightower/memkv"
 	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
 )
 
 func Test_hashToIPv4_invalid_range(t *testing.T) {
@@ -185,6 +186,162 @@ func Test_BGPFilterBIRDFuncs(t *testing.T) {
 	}
 }
 
+func Test_BGPFilterBIRDFuncsAttributes(t *testing.T) {
+	med := uint32(50)
+	localPref := uint32(200)
+	testFilter := v3.BGPFilter{}
+	testFilter.Spec = v3.BGPFilterSpec{
+		ImportV4: []v3.BGPFilterRuleV4{
+			{Action: "Reject", Communities: []string{"65000:666"}},
+			{Action: "Accept", ASPathPrefix: []numorstring.ASNumber{65001, 65002}, Operations: []v3.BGPFilterOperation{
+				{SetLocalPreference: &localPref},
+				{RemoveCommunity: "65001:100:1"},
+			}},
+		},
+		ExportV6: []v3.BGPFilterRuleV6{
+			{Action: "Accept", MatchOperator: "In", CIDR: "9000:1::0/64", Communities: []string{"65000:100", "65000:100:1"}, Operations: []v3.BGPFilterOperation{
+				{AddCommunity: "65000:200"},
+				{SetMED: &med},
+				{PrependASPath: []numorstring.ASNumber{65000, 65003}},
+			}},
+			{Action: "Accept", Operations: []v3.BGPFilterOperation{{AddCommunity: "65000:300:1"}}},
+		},
+	}
+	expectedBIRDCfgStrV4 := []string{
+		"# v4 BGPFilter test-bgpfilter",
+		"function 'bgp_test-bgpfilter_importFilterV4'() {",
+		"  if ((((65000,666) ~ bgp_community))) then { reject; }",
+		"  if ((bgp_path ~ [= 65001 65002 * =])) then { bgp_local_pref = 200; bgp_large_community.delete((65001,100,1)); accept; }",
+		"}",
+	}
+	expectedBIRDCfgStrV6 := []string{
+		"# v6 BGPFilter test-bgpfilter",
+		"function 'bgp_test-bgpfilter_exportFilterV6'() {",
+		"  if ((net ~ 9000:1::0/64)&&(((65000,100) ~ bgp_community)||((65000,100,1) ~ bgp_large_community))) then " +
+			"{ bgp_community.add((65000,200)); bgp_med = 50; bgp_path.prepend(65003); bgp_path.prepend(65000); accept; }",
+		"  bgp_large_community.add((65000,300,1)); accept;",
+		"}",
+	}
+
+	jsonFilter, err := json.Marshal(testFilter)
+	if err != nil {
+		t.Errorf("Error formatting BGPFilter into JSON: %s", err)
+	}
+	kvps := []memkv.KVPair{
+		{Key: "test-bgpfilter", Value: string(jsonFilter)},
+	}
+
+	v4BIRDCfgResult, err := BGPFilterBIRDFuncs(kvps, 4)
+	if err != nil {
+		t.Errorf("Unexpected error while generating v4 BIRD BGPFilter functions: %s", err)
+	}
+	if !reflect.DeepEqual(v4BIRDCfgResult, expectedBIRDCfgStrV4) {
+		t.Errorf("Generated v4 BIRD config differs from expectation:\n Generated = %s,\n Expected = %s",
+			v4BIRDCfgResult, expectedBIRDCfgStrV4)
+	}
+
+	v6BIRDCfgResult, err := BGPFilterBIRDFuncs(kvps, 6)
+	if err != nil {
+		t.Errorf("Unexpected error while generating v6 BIRD BGPFilter functions: %s", err)
+	}
+	if !reflect.DeepEqual(v6BIRDCfgResult, expectedBIRDCfgStrV6) {
+		t.Errorf("Generated v6 BIRD config differs from expectation:\n Generated = %s,\n Expected = %s",
+			v6BIRDCfgResult, expectedBIRDCfgStrV6)
+	}
+
+	// Communities are written directly into the BIRD config, so malformed values must be rejected.
+	testFilter.Spec.ImportV4[0].Communities = []string{"65000:100); accept; ("}
+	jsonFilter, err = json.Marshal(testFilter)
+	if err != nil {
+		t.Errorf("Error formatting BGPFilter into JSON: %s", err)
+	}
+	kvps[0].Value = string(jsonFilter)
+	if _, err := BGPFilterBIRDFuncs(kvps, 4); err == nil {
+		t.Errorf("Expected an error for an invalid community")
+	}
+}
+
+func Test_BFDBIRDConfig(t *testing.T) {
+	globalPeers := memkv.KVPairs{
+		{Key: "/bgp/v1/global/peer_v4/10.1.1.1", Value: `{"ip":"10.1.1.1","bfd":{"min_rx_interval":300,"min_tx_interval":300,"multiplier":0}}`},
+		{Key: "/bgp/v1/global/peer_v4/10.1.1.2", Value: `{"ip":"10.1.1.2"}`},
+	}
+	nodePeers := memkv.KVPairs{
+		{Key: "/bgp/v1/host/node1/peer_v4/10.1.1.3", Value: `{"ip":"10.1.1.3","bfd":{"min_rx_interval":500,"min_tx_interval":100,"multiplier":3}}`},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		meshBFD  string
+		peers    []memkv.KVPairs
+		expected []string
+	}{
+		{
+			name:     "BFD not enabled",
+			peers:    []memkv.KVPairs{{globalPeers[1]}, {}},
+			expected: nil,
+		},
+		{
+			name:     "BIRD defaults",
+			meshBFD:  `{"min_rx_interval":0,"min_tx_interval":0,"multiplier":0}`,
+			expected: []string{"protocol bfd {", "}"},
+		},
+		{
+			name:  "explicit peers only",
+			peers: []memkv.KVPairs{globalPeers, {}},
+			expected: []string{
+				"protocol bfd {",
+				`  interface "*" {`,
+				"    min rx interval 300 ms;",
+				"    min tx interval 300 ms;",
+				"  };",
+				"  multihop {",
+				"    min rx interval 300 ms;",
+				"    min tx interval 300 ms;",
+				"  };",
+				"}",
+			},
+		},
+		{
+			name:    "most aggressive of the mesh and peer settings",
+			meshBFD: `{"min_rx_interval":0,"min_tx_interval":0,"multiplier":4}`,
+			peers:   []memkv.KVPairs{globalPeers, nodePeers},
+			expected: []string{
+				"protocol bfd {",
+				`  interface "*" {`,
+				"    min rx interval 300 ms;",
+				"    min tx interval 100 ms;",
+				"    multiplier 3;",
+				"  };",
+				"  multihop {",
+				"    min rx interval 300 ms;",
+				"    min tx interval 100 ms;",
+				"    multiplier 3;",
+				"  };",
+				"}",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, err := BFDBIRDConfig(tc.meshBFD, tc.peers...)
+			if err != nil {
+				t.Fatalf("Unexpected error while generating BIRD BFD config: %s", err)
+			}
+			if !reflect.DeepEqual(lines, tc.expected) {
+				t.Errorf("Generated BIRD BFD config differs from expectation:\n Generated = %q,\n Expected = %q",
+					lines, tc.expected)
+			}
+		})
+	}
+
+	if _, err := BFDBIRDConfig("", memkv.KVPairs{{Key: "/bgp/v1/global/peer_v4/10.1.1.3", Value: "not json"}}); err == nil {
+		t.Error("Expected an error for invalid peer JSON")
+	}
+	if _, err := BFDBIRDConfig("not json"); err == nil {
+		t.Error("Expected an error for invalid mesh JSON")
+	}
+}
+
 func Test_ValidateHashToIpv4Method(t *testing.T) {
 	expectedRouterId := "207.94.5.27"
 	nodeName := "Testrobin123"
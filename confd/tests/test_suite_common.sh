@@ -582,6 +582,7 @@ execute_tests_daemon() {
         run_individual_test 'mesh/static-routes-exclude-node'
         run_individual_test 'mesh/communities'
         run_individual_test 'mesh/restart-time'
+        run_individual_test 'mesh/bfd'
     done
 
     # Turn the node-mesh off.
@@ -597,6 +598,7 @@ execute_tests_daemon() {
         run_individual_test 'explicit_peering/route_reflector'
         run_individual_test 'explicit_peering/keepnexthop'
         run_individual_test 'explicit_peering/keepnexthop-global'
+        run_individual_test 'explicit_peering/bfd'
 	run_individual_test 'explicit_peering/local-as'
 	run_individual_test 'explicit_peering/local-as-global'
     done
@@ -634,8 +636,10 @@ execute_tests_oneshot() {
         run_individual_test_oneshot 'mesh/static-routes-exclude-node'
         run_individual_test_oneshot 'mesh/communities'
         run_individual_test_oneshot 'mesh/restart-time'
+        run_individual_test_oneshot 'mesh/bfd'
         run_individual_test_oneshot 'explicit_peering/keepnexthop'
         run_individual_test_oneshot 'explicit_peering/keepnexthop-global'
+        run_individual_test_oneshot 'explicit_peering/bfd'
         export CALICO_ROUTER_ID=10.10.10.10
         run_individual_test_oneshot 'mesh/static-routes-no-ipv4-address'
         export -n CALICO_ROUTER_ID
@@ -2966,6 +2970,132 @@ EOF
     fi
 }
 
+test_bgp_filter_match_attributes() {
+    # For KDD, run Typha and clean up the output directory.
+    if [ "$DATASTORE_TYPE" = kubernetes ]; then
+        start_typha
+        rm -f /etc/calico/confd/config/*
+    fi
+
+    # Run confd as a background process.
+    echo "Running confd as background process"
+    NODENAME=kube-master BGP_LOGSEVERITYSCREEN="debug" confd -confdir=/etc/calico/confd >$LOGPATH/logd1 2>&1 &
+    CONFD_PID=$!
+    echo "Running with PID " $CONFD_PID
+
+    # Turn the node-mesh off
+    turn_mesh_off
+
+    # Create 3 nodes and a BGPFilter then globally pair the nodes all using the same filter
+    $CALICOCTL apply -f - <<EOF
+kind: Node
+apiVersion: projectcalico.org/v3
+metadata:
+  name: kube-master
+  labels:
+    global-peer: yes
+spec:
+  bgp:
+    ipv4Address: 10.192.0.2/16
+    ipv6Address: "2001::102/64"
+---
+kind: Node
+apiVersion: projectcalico.org/v3
+metadata:
+  name: kube-node-1
+  labels:
+    global-peer: yes
+spec:
+  bgp:
+    ipv4Address: 10.192.0.3/16
+    ipv6Address: "2001::103/64"
+---
+kind: Node
+apiVersion: projectcalico.org/v3
+metadata:
+  name: kube-node-2
+  labels:
+    global-peer: yes
+spec:
+  bgp:
+    ipv4Address: 10.192.0.4/16
+    ipv6Address: "2001::104/64"
+---
+kind: BGPFilter
+apiVersion: projectcalico.org/v3
+metadata:
+  name: test-filter-match-attributes
+spec:
+  exportV4:
+    - action: Accept
+      matchOperator: In
+      cidr: 44.4.0.0/16
+      communities: ["65000:100"]
+      operations:
+        - addCommunity: "65000:200"
+        - setMED: 50
+        - prependASPath: [64512, 64512]
+    - action: Reject
+  importV4:
+    - action: Reject
+      communities: ["65000:666", "65000:666:1"]
+    - action: Accept
+      asPathPrefix: [65001, 65002]
+      operations:
+        - setLocalPreference: 200
+        - removeCommunity: "65001:100:1"
+  exportV6:
+    - action: Accept
+      matchOperator: In
+      cidr: 7000:1::0/64
+      communities: ["65000:100"]
+      operations:
+        - addCommunity: "65000:200"
+        - setMED: 50
+        - prependASPath: [64512, 64512]
+    - action: Reject
+  importV6:
+    - action: Reject
+      communities: ["65000:666", "65000:666:1"]
+    - action: Accept
+      asPathPrefix: [65001, 65002]
+      operations:
+        - setLocalPreference: 200
+        - removeCommunity: "65001:100:1"
+---
+kind: BGPPeer
+apiVersion: projectcalico.org/v3
+metadata:
+  name: test-global-peer-with-filter
+spec:
+  peerSelector: has(global-peer)
+  filters:
+    - test-filter-match-attributes
+EOF
+
+    test_confd_templates bgpfilter/match_attributes
+
+    # Kill confd.
+    kill -9 $CONFD_PID
+
+    # Turn the node-mesh back on.
+    turn_mesh_on
+
+    # Delete remaining resources.
+    $CALICOCTL delete bgpfilter test-filter-match-attributes
+    $CALICOCTL delete bgppeer test-global-peer-with-filter
+    if [ "$DATASTORE_TYPE" = etcdv3 ]; then
+      $CALICOCTL delete node kube-master
+      $CALICOCTL delete node kube-node-1
+      $CALICOCTL delete node kube-node-2
+    fi
+
+    # For KDD, kill Typha.
+    if [ "$DATASTORE_TYPE" = kubernetes ]; then
+        kill_typha
+    fi
+}
+
 test_bgp_filter_match_interface() {
     # For KDD, run Typha and clean up the output directory.
     if [ "$DATASTORE_TYPE" = kubernetes ]; then
@@ -4462,6 +4592,7 @@ test_bgp_filters() {
   test_bgp_filter_match_operators
   test_bgp_filter_match_source
   test_bgp_filter_match_interface
+  test_bgp_filter_match_attributes
   test_bgp_filter_import_only_explicit_peers
   test_bgp_filter_import_only_global_peers
   test_bgp_filter_export_only_explicit_peers
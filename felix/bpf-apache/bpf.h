@@ -63,6 +63,16 @@ union ip4_bpf_lpm_trie_key {
 	struct ip4key ip;
 };
 
+struct ip6key {
+	__u32 mask;
+	__u32 addr[4];
+};
+
+union ip6_bpf_lpm_trie_key {
+	struct bpf_lpm_trie_key lpm;
+	struct ip6key ip;
+};
+
 // helper functions
 CALI_BPF_INLINE void ip4val_to_lpm(
 	union ip4_bpf_lpm_trie_key *ret, __u32 mask, __u32 addr) {
@@ -70,6 +80,15 @@ CALI_BPF_INLINE void ip4val_to_lpm(
 	ret->ip.addr = addr;
 }
 
+CALI_BPF_INLINE void ip6val_to_lpm(
+	union ip6_bpf_lpm_trie_key *ret, __u32 mask, const __u32 *addr) {
+	ret->lpm.prefixlen = mask;
+	ret->ip.addr[0] = addr[0];
+	ret->ip.addr[1] = addr[1];
+	ret->ip.addr[2] = addr[2];
+	ret->ip.addr[3] = addr[3];
+}
+
 CALI_BPF_INLINE __u32 port_to_host(__u32 port) {
 	return be32_to_host(port) >> 16;
 }
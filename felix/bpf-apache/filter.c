@@ -1,4 +1,4 @@
-// Copyright (c) 2019-2024 Tigera, Inc. All rights reserved.
+// Copyright (c) 2019-2025 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,32 +16,33 @@
 #include <linux/bpf.h>
 #include <linux/if_ether.h>
 #include <linux/ip.h>
+#include <linux/ipv6.h>
 #include <linux/in.h>
 #include <linux/tcp.h>
 #include <linux/udp.h>
 #include "filter.h"
 
-CALI_BPF_INLINE static int extract_ports(__u32 len, struct iphdr * h,
-	struct protoport *dport)
+CALI_BPF_INLINE static int extract_ports(__u32 len, __u32 iphdr_len,
+	__u8 protocol, void * l4, struct protoport *dport)
 {
 	struct tcphdr * thdr;
 	struct udphdr * uhdr;
 
-	dport->proto = h->protocol;
+	dport->proto = protocol;
 
-	switch (h->protocol) {
+	switch (protocol) {
 		case IPPROTO_TCP:
 			// Re-check buffer space for TCP (has larger headers than UDP).
 			if (len <
-				sizeof(struct ethhdr) + sizeof(*h) + sizeof(struct tcphdr)) {
+				sizeof(struct ethhdr) + iphdr_len + sizeof(struct tcphdr)) {
 				return 1; // Or maybe drop the packet? It's broken anyways.
 			}
 
-			thdr = (void*)((__u64)(h) + sizeof(*h));
+			thdr = l4;
 			dport->port = port_to_host(thdr->dest);
 			break;
 		case IPPROTO_UDP:
-			uhdr = (void*)((__u64)(h) + sizeof(*h));
+			uhdr = l4;
 			dport->port = port_to_host(uhdr->dest);
 			break;
 		default:
@@ -52,35 +53,19 @@ CALI_BPF_INLINE static int extract_ports(__u32 len, struct iphdr * h,
 	return 1;
 }
 
-
-SEC("xdp")
-enum xdp_action prefilter(struct xdp_md* xdp)
+CALI_BPF_INLINE static enum xdp_action prefilter_v4(struct xdp_md* xdp)
 {
-	struct ethhdr * ehdr;
+	struct ethhdr * ehdr = (void*)(long)xdp->data;
 	struct iphdr  * ihdr;
 	struct protoport dport = {0,0};
 	union ip4_bpf_lpm_trie_key sip;
 
-	// You must be at least 'UDP header' tall to take this ride.
-	if (xdp->data + sizeof(*ehdr) + sizeof(*ihdr) + sizeof(struct udphdr)
-		> xdp->data_end) {
-		// Packet too small to contain ethernet, ip, and UDP headers. Drop.
-		return XDP_DROP;
-	}
-
-	// Make sure it's an IP packet
-	// NOTE that this is a straightforward implementation that
-	// does not handle e.g. V[X]LAN encapsulation.
-	ehdr = (void*)(long)xdp->data;
-	if (be16_to_host(ETH_P_IP) != ehdr->h_proto) {
-		return XDP_PASS;
-	}
-
 	// Parse l4 protocols and ports.
 	// NOTE that this is a straightforward implementation that
 	// does not handle e.g. IPIP encapsulation.
 	ihdr = (void*)((__u64)(ehdr) + sizeof(*ehdr));
-	if (extract_ports(xdp->data_end - xdp->data, ihdr, &dport)) {
+	if (extract_ports(xdp->data_end - xdp->data, sizeof(*ihdr), ihdr->protocol,
+			(void*)((__u64)(ihdr) + sizeof(*ihdr)), &dport)) {
 		// Check failsafe ports and XDP_PASS early
 		if (NULL != bpf_map_lookup_elem(&calico_failsafe_ports, &dport)) {
 			return XDP_PASS;
@@ -99,4 +84,68 @@ enum xdp_action prefilter(struct xdp_md* xdp)
 	return XDP_PASS;
 }
 
+CALI_BPF_INLINE static enum xdp_action prefilter_v6(struct xdp_md* xdp)
+{
+	struct ethhdr  * ehdr = (void*)(long)xdp->data;
+	struct ipv6hdr * ihdr;
+	struct protoport dport = {0,0};
+	union ip6_bpf_lpm_trie_key sip;
+
+	// The IPv6 header is larger than the IPv4 one, so re-check that
+	// there is room for the headers we look at.
+	if (xdp->data + sizeof(*ehdr) + sizeof(*ihdr) + sizeof(struct udphdr)
+		> xdp->data_end) {
+		// Packet too small to contain ethernet, ipv6, and UDP headers. Drop.
+		return XDP_DROP;
+	}
+
+	// Parse l4 protocols and ports.
+	// NOTE that extension headers are not parsed, so only packets where
+	// TCP or UDP directly follows the IPv6 header can match a failsafe
+	// port.
+	ihdr = (void*)((__u64)(ehdr) + sizeof(*ehdr));
+	if (extract_ports(xdp->data_end - xdp->data, sizeof(*ihdr), ihdr->nexthdr,
+			(void*)((__u64)(ihdr) + sizeof(*ihdr)), &dport)) {
+		// Check failsafe ports and XDP_PASS early
+		if (NULL != bpf_map_lookup_elem(&calico_failsafe_ports, &dport)) {
+			return XDP_PASS;
+		}
+	}
+
+	ip6val_to_lpm(&sip, 128, ihdr->saddr.in6_u.u6_addr32);
+
+	// Drop the packet if source IP matches a blocklist entry.
+	if (NULL != bpf_map_lookup_elem(&calico_prefilter_v6, &sip)) {
+		return XDP_DROP;
+	}
+
+	return XDP_PASS;
+}
+
+SEC("xdp")
+enum xdp_action prefilter(struct xdp_md* xdp)
+{
+	struct ethhdr * ehdr;
+
+	// You must be at least 'UDP header' tall to take this ride.
+	if (xdp->data + sizeof(*ehdr) + sizeof(struct iphdr) + sizeof(struct udphdr)
+		> xdp->data_end) {
+		// Packet too small to contain ethernet, ip, and UDP headers. Drop.
+		return XDP_DROP;
+	}
+
+	// Make sure it's an IP packet
+	// NOTE that this is a straightforward implementation that
+	// does not handle e.g. V[X]LAN encapsulation.
+	ehdr = (void*)(long)xdp->data;
+	if (be16_to_host(ETH_P_IP) == ehdr->h_proto) {
+		return prefilter_v4(xdp);
+	}
+	if (be16_to_host(ETH_P_IPV6) == ehdr->h_proto) {
+		return prefilter_v6(xdp);
+	}
+
+	return XDP_PASS;
+}
+
 char ____license[] __attribute__((section("license")))  = "Apache-2.0";
@@ -27,6 +27,14 @@ struct {
     __uint(map_flags, BPF_F_NO_PREALLOC);
 } calico_prefilter_v4 SEC(".maps");
 
+struct {
+    __uint(type, BPF_MAP_TYPE_LPM_TRIE);
+    __type(key, union ip6_bpf_lpm_trie_key);
+    __type(value, __u32);
+    __uint(max_entries, 10240);
+    __uint(map_flags, BPF_F_NO_PREALLOC);
+} calico_prefilter_v6 SEC(".maps");
+
 struct {
     __uint(type, BPF_MAP_TYPE_HASH);
     __type(key, struct protoport);
@@ -271,6 +271,49 @@ static CALI_BPF_INLINE void ip_dec_ttl(struct iphdr *ip)
 	ip->check = (__be16) (sum + (sum >> 16));
 }
 
+#ifdef IPVER6
+/* ip_dsfield returns the traffic class, which holds the DSCP and ECN bits, and
+ * spans the priority and the top of the flow label.
+ */
+static CALI_BPF_INLINE __u8 ip_dsfield(struct ipv6hdr *ip)
+{
+	return (ip->priority << 4) | (ip->flow_lbl[0] >> 4);
+}
+
+/* ip_set_dscp sets the DSCP bits of the traffic class and keeps the ECN bits. */
+static CALI_BPF_INLINE void ip_set_dscp(struct ipv6hdr *ip, __u8 dscp)
+{
+	__u8 tclass = ip_dsfield(ip);
+
+	tclass = (dscp << 2) | (tclass & 0x3);
+	ip->priority = tclass >> 4;
+	ip->flow_lbl[0] = (tclass << 4) | (ip->flow_lbl[0] & 0xf);
+}
+#else
+/* ip_dsfield returns the TOS, which holds the DSCP and ECN bits. */
+static CALI_BPF_INLINE __u8 ip_dsfield(struct iphdr *ip)
+{
+	return ip->tos;
+}
+
+/* ip_set_dscp sets the DSCP bits of the TOS and keeps the ECN bits. */
+static CALI_BPF_INLINE void ip_set_dscp(struct iphdr *ip, __u8 dscp)
+{
+	__u16 *word = (__u16 *)ip;
+	__u16 old = *word;
+
+	ip->tos = (dscp << 2) | (ip->tos & 0x3);
+
+	/* Only the first word of the header changed so, as per RFC-1624, we can
+	 * adjust the checksum inline without helpers.
+	 */
+	__u32 sum = (__u16)~ip->check + (__u16)~old + *word;
+	sum = (sum & 0xffff) + (sum >> 16);
+	sum = (sum & 0xffff) + (sum >> 16);
+	ip->check = (__be16)~sum;
+}
+#endif
+
 #ifdef IPVER6
 #define ip_ttl_exceeded(ip) (CALI_F_TO_HOST && !CALI_F_IPIP && (ip)->hop_limit <= 1)
 #else
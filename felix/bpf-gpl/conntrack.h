@@ -147,6 +147,7 @@ create:
 
 	ct_value_set_flags(&ct_value, ct_ctx->flags);
 	CALI_DEBUG("CT-ALL tracking entry flags 0x%x", ct_value_get_flags(&ct_value));
+	ct_value.dscp = ct_ctx->dscp;
 
 	ct_value.orig_sip = ct_ctx->orig_src;
 	ct_value.orig_sport = ct_ctx->orig_sport;
@@ -701,6 +702,7 @@ static CALI_BPF_INLINE struct calico_ct_result calico_ct_lookup(struct cali_tc_c
 	v->last_seen = now;
 
 	result.flags = ct_value_get_flags(v);
+	__u8 dscp = v->dscp;
 
 	// Return the if_index where the CT state was created.
 	if (v->a_to_b.opener) {
@@ -763,6 +765,7 @@ static CALI_BPF_INLINE struct calico_ct_result calico_ct_lookup(struct cali_tc_c
 		// flags are in the tracking entry
 		result.flags = ct_value_get_flags(tracking_v);
 		CALI_CT_DEBUG("result.flags 0x%x", result.flags);
+		dscp = tracking_v->dscp;
 
 		if (ct_ctx->proto == IPPROTO_ICMP_46) {
 			result.rc =	CALI_CT_ESTABLISHED_DNAT;
@@ -905,6 +908,12 @@ static CALI_BPF_INLINE struct calico_ct_result calico_ct_lookup(struct cali_tc_c
 		goto out_lookup_fail;
 	}
 
+	if ((result.flags & CALI_CT_FLAG_SET_DSCP) && src_to_dst->opener) {
+		CALI_CT_DEBUG("Setting DSCP %d", dscp);
+		ctx->state->dscp = dscp;
+		ctx->state->flags |= CALI_ST_SET_DSCP;
+	}
+
 	int ret_from_tun = CALI_F_FROM_HEP &&
 				!ip_void(ctx->state->tun_ip) &&
 				ct_result_rc(result.rc) == CALI_CT_ESTABLISHED_DNAT &&
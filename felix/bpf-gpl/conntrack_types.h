@@ -39,6 +39,7 @@ enum cali_ct_type {
 #define CALI_CT_FLAG_NP_REMOTE	0x1000 /* marks connections from local host to remote backend of a nodeport */
 #define CALI_CT_FLAG_NP_NO_DSR	0x2000 /* marks connections from a client which is excluded from DSR */
 #define CALI_CT_FLAG_SKIP_REDIR_PEER	0x4000 /* marks connections from a client which is excluded from redir */
+#define CALI_CT_FLAG_SET_DSCP	0x8000 /* marks connections whose opener's packets get the DSCP in the entry */
 
 struct calico_ct_leg {
 	__u64 bytes;
@@ -74,7 +75,8 @@ struct calico_ct_value {
 	// not to zero the padding bytes, which upsets the verifier.  Worse than
 	// that, debug logging often prevents such optimisation resulting in
 	// failures when debug logging is compiled out only :-).
-	__u8 pad0[5];
+	__u8 dscp;
+	__u8 pad0[4];
 	__u8 flags2;
 	union {
 		// CALI_CT_TYPE_NORMAL and CALI_CT_TYPE_NAT_REV.
@@ -151,7 +153,7 @@ struct ct_create_ctx {
 			* initial CT entry for the tunneled traffic. */
 	__u16 flags;
 	__u8 proto;
-	__u8 __pad;
+	__u8 dscp;
 	enum cali_ct_type type;
 	bool allow_return;
 };
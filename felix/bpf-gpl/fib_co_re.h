@@ -163,6 +163,17 @@ skip_redir_ifindex:
 			size = offsetof(struct bpf_tunnel_key, local_ipv4);
 #endif
 
+			/* The VXLAN device is flow-based, so it takes the TOS of the outer
+			 * header from the tunnel key rather than inheriting it from the inner
+			 * packet.  Copy it over so that the DSCP is kept.
+			 */
+			if (skb_refresh_validate_ptrs(ctx, 0)) {
+				deny_reason(ctx, CALI_REASON_SHORT);
+				CALI_DEBUG("Too short");
+				goto deny;
+			}
+			key.tunnel_tos = ip_dsfield(ip_hdr(ctx));
+
 			int err = bpf_skb_set_tunnel_key(ctx->skb, &key, size, flags);
 			CALI_DEBUG("bpf_skb_set_tunnel_key %d nh " IP_FMT, err, &dest_rt->next_hop);
 
@@ -205,6 +216,14 @@ skip_redir_ifindex:
 			size = offsetof(struct bpf_tunnel_key, local_ipv4);
 #endif
 
+			/* As above, keep the DSCP of the inner packet. */
+			if (skb_refresh_validate_ptrs(ctx, 0)) {
+				deny_reason(ctx, CALI_REASON_SHORT);
+				CALI_DEBUG("Too short");
+				goto deny;
+			}
+			key.tunnel_tos = ip_dsfield(ip_hdr(ctx));
+
 			int err = bpf_skb_set_tunnel_key(ctx->skb, &key, size, flags);
 			CALI_DEBUG("bpf_skb_set_tunnel_key %d nh " IP_FMT, err, &dest_rt->next_hop);
 		}
@@ -215,6 +215,23 @@ static CALI_BPF_INLINE int tc_state_fill_from_nexthdr(struct cali_tc_ctx *ctx, b
 		} else if (CALI_F_TO_HEP && !CALI_F_IPIP && !CALI_F_L3_DEV) {
 			if (rt_addr_is_remote_host(&ctx->state->ip_dst)) {
 				CALI_DEBUG("IPIP packet to known Calico host, allow.");
+#ifndef IPVER6
+				/* Unlike the VXLAN device, the IPIP device can't be set to inherit
+				 * the TOS of the inner packet, so copy its DSCP to the outer header.
+				 * Like the rest of our IPIP handling, this assumes that the outer
+				 * header has no options.
+				 */
+				if (skb_refresh_validate_ptrs(ctx, IP_SIZE)) {
+					deny_reason(ctx, CALI_REASON_SHORT);
+					CALI_DEBUG("Too short");
+					goto deny;
+				}
+				__u8 dscp = ip_dsfield((struct iphdr *)(ip_hdr(ctx) + 1)) >> 2;
+				if (dscp != ip_dsfield(ip_hdr(ctx)) >> 2) {
+					CALI_DEBUG("Copying DSCP %d to the IPIP header", dscp);
+					ip_set_dscp(ip_hdr(ctx), dscp);
+				}
+#endif
 				goto allow;
 			} else {
 				CALI_DEBUG("IPIP packet to unknown dest, drop.");
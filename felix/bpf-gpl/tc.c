@@ -1160,6 +1160,17 @@ static CALI_BPF_INLINE struct fwd post_nat(struct cali_tc_ctx *ctx,
 	}
 
 allow:
+	if (state->flags & CALI_ST_SET_DSCP) {
+		/* NAT may have invalidated the packet pointers. */
+		if (skb_refresh_validate_ptrs(ctx, 0)) {
+			deny_reason(ctx, CALI_REASON_SHORT);
+			CALI_DEBUG("Too short to set DSCP");
+			goto deny;
+		}
+		CALI_DEBUG("Setting DSCP %d", state->dscp);
+		ip_set_dscp(ip_hdr(ctx), state->dscp);
+	}
+
 	if (state->ct_result.flags & CALI_CT_FLAG_SVC_SELF) {
 		CALI_DEBUG("Loopback SNAT");
 		seen_mark |=  CALI_SKB_MARK_MASQ;
@@ -1372,6 +1383,10 @@ int calico_tc_skb_new_flow_entrypoint(struct __sk_buff *skb)
 	if (state->flags & CALI_ST_HOST_PSNAT) {
 		ct_ctx_nat->flags |= CALI_CT_FLAG_HOST_PSNAT;
 	}
+	if (state->flags & CALI_ST_SET_DSCP) {
+		ct_ctx_nat->flags |= CALI_CT_FLAG_SET_DSCP;
+		ct_ctx_nat->dscp = state->dscp;
+	}
 	/* Mark connections that were routed via bpfnatout, but had CT miss at
 	 * HEP. That is because of SNAT happened between bpfnatout and here.
 	 * Returning packets on such a connection must go back via natbpfout
@@ -69,7 +69,10 @@ struct cali_tc_state {
 		__u32 icmp_un;
 	};
 	__u16 ihl;
-	__u16 unused;
+	/* DSCP to set on the packet if CALI_ST_SET_DSCP is set; from a SetDSCP policy rule
+	 * or the conntrack entry. */
+	__u8 dscp;
+	__u8 unused;
 	/* Return code from the policy program CALI_POL_DENY/ALLOW etc. */
 	__s32 pol_rc;
 	/* Source port of the packet; updated on the CALI_CT_ESTABLISHED_SNAT path or when doing encap.
@@ -156,6 +159,9 @@ enum cali_state_flags {
 	CALI_ST_LOG_PACKET        = 0x400,
 	/* CALI_ST_SKIP_REDIR_PEER is set when the packet is destined to a local VM workload */
 	CALI_ST_SKIP_REDIR_PEER	  = 0x800,
+	/* CALI_ST_SET_DSCP is set by policy program if a SetDSCP rule was hit, or by conntrack
+	 * for later packets of such a connection; state->dscp holds the DSCP. */
+	CALI_ST_SET_DSCP	  = 0x1000,
 };
 
 struct fwd {
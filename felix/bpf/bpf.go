@@ -318,11 +318,8 @@ func (b *BPFLib) NewCIDRMap(ifName string, family IPFamily) (string, error) {
 	mapName := getCIDRMapName(ifName, family)
 	mapPath := filepath.Join(b.xdpDir, mapName)
 
-	if family == IPFamilyV6 {
-		return "", errors.New("IPv6 not supported")
-	}
-
-	keySize := 8
+	// The key is a 4-byte prefix length followed by the address.
+	keySize := 4 + family.Size()
 	valueSize := 4
 
 	return newMap(mapName,
@@ -602,12 +599,10 @@ func (b *BPFLib) IsValidMap(ifName string, family IPFamily) (bool, error) {
 		return false, err
 	}
 	switch family {
-	case IPFamilyV4:
-		if m.Type != "lpm_trie" || m.KeySize != 8 || m.ValueSize != 4 {
+	case IPFamilyV4, IPFamilyV6:
+		if m.Type != "lpm_trie" || m.KeySize != 4+family.Size() || m.ValueSize != 4 {
 			return false, nil
 		}
-	case IPFamilyV6:
-		return false, fmt.Errorf("IPv6 not implemented yet")
 	default:
 		return false, fmt.Errorf("unknown IP family %d", family)
 	}
@@ -966,9 +961,10 @@ func (b *BPFLib) loadXDPRaw(objPath, ifName string, mode XDPMode, mapArgs []stri
 }
 
 func (b *BPFLib) getMapArgs(ifName string) ([]string, error) {
-	// FIXME hardcoded ipv4, do we need both?
 	mapName := getCIDRMapName(ifName, IPFamilyV4)
 	mapPath := filepath.Join(b.xdpDir, mapName)
+	mapNameV6 := getCIDRMapName(ifName, IPFamilyV6)
+	mapPathV6 := filepath.Join(b.xdpDir, mapNameV6)
 
 	failsafeMapPath := filepath.Join(b.calicoDir, failsafeMapName)
 
@@ -989,6 +985,12 @@ func (b *BPFLib) getMapArgs(ifName string) ([]string, error) {
 		mapArgs = append(mapArgs, []string{"map", "name", n, "pinned", p}...)
 	}
 
+	// The IPv6 map is only pinned when IPv6 is enabled.  Without it, bpftool creates an
+	// empty map for the program, which then lets all IPv6 traffic through.
+	if _, err := os.Stat(mapPathV6); err == nil {
+		mapArgs = append(mapArgs, []string{"map", "name", "calico_prefilter_v6", "pinned", mapPathV6}...)
+	}
+
 	return mapArgs, nil
 }
 
@@ -1328,6 +1330,8 @@ func hexToFailsafe(hexString []string) (proto uint8, port uint16, err error) {
 //	C0, A8, 00, 00    IP address
 //
 // ]
+//
+// IPv6 CIDRs are encoded the same way, with a 16-byte IP address.
 func CidrToHex(cidr string) ([]string, error) {
 	cidrParts := strings.Split(cidr, "/")
 	if len(cidrParts) != 2 {
@@ -1345,24 +1349,27 @@ func CidrToHex(cidr string) ([]string, error) {
 		return nil, fmt.Errorf("invalid IP %q", rawIP)
 	}
 
-	ipv4 := ip.To4()
-	if ipv4 == nil {
-		return nil, fmt.Errorf("IP %q is not IPv4", ip)
+	maxMask := 128
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+		maxMask = 32
 	}
 
-	// Check bounds on the mask since the mask will be in CIDR notation and should range between 0 and 32
-	if mask > 32 || mask < 0 {
-		return nil, fmt.Errorf("mask %d should be between 0 and 32", mask)
+	// Check bounds on the mask since the mask will be in CIDR notation and should range between 0 and
+	// the length of the address.
+	if mask > maxMask || mask < 0 {
+		return nil, fmt.Errorf("mask %d should be between 0 and %d", mask, maxMask)
 	}
 
 	maskBytes := make([]byte, 4)
 	binary.LittleEndian.PutUint32(maskBytes, uint32(mask))
 
-	hexStr := fmt.Sprintf("%02x %02x %02x %02x %02x %02x %02x %02x",
-		maskBytes[0], maskBytes[1], maskBytes[2], maskBytes[3],
-		ipv4[0], ipv4[1], ipv4[2], ipv4[3])
+	hexStr := make([]string, 0, len(maskBytes)+len(ip))
+	for _, b := range append(maskBytes, ip...) {
+		hexStr = append(hexStr, fmt.Sprintf("%02x", b))
+	}
 
-	return strings.Split(hexStr, " "), nil
+	return hexStr, nil
 }
 
 // hexToIPNet takes the bpftool hex representation of a CIDR (see above) and
@@ -1430,7 +1437,6 @@ func MemberToIPMask(member string) (*net.IP, int, error) {
 	memberParts := strings.Split(member, "/")
 	switch len(memberParts) {
 	case 1:
-		mask = 32
 		rawIP = memberParts[0]
 	case 2:
 		var err error
@@ -1447,6 +1453,13 @@ func MemberToIPMask(member string) (*net.IP, int, error) {
 	if ip == nil {
 		return nil, -1, fmt.Errorf("invalid IP %q", rawIP)
 	}
+	if len(memberParts) == 1 {
+		// A single address.
+		mask = 128
+		if ip.To4() != nil {
+			mask = 32
+		}
+	}
 
 	return &ip, mask, nil
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2019-2022 Tigera, Inc. All rights reserved.
+// Copyright (c) 2019-2025 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -741,14 +741,109 @@ func TestMemberToIPMask(t *testing.T) {
 	if mask != expectedMask {
 		t.Fatalf("got wrong mask: mask=%v expectedMask=%q", mask, expectedMask)
 	}
+
+	member = "2001:db8::1"
+	expectedIP = net.ParseIP("2001:db8::1")
+	expectedMask = 128
+
+	ip, mask, err = MemberToIPMask(member)
+	if err != nil {
+		t.Fatalf("cannot convert member (%s) to ip and mask: %v", member, err)
+	}
+	if !ip.Equal(expectedIP) {
+		t.Fatalf("got wrong IP: ip=%v expectedIP=%q", ip, expectedIP)
+	}
+	if mask != expectedMask {
+		t.Fatalf("got wrong mask: mask=%v expectedMask=%q", mask, expectedMask)
+	}
 }
 
-func TestIPv6NotSupported(t *testing.T) {
-	t.Log("Creating an IPv6 CIDR map should fail for now")
-	_, err := bpfDP.NewCIDRMap("myiface2", IPFamilyV6)
-	if err == nil {
-		t.Fatalf("creating an IPv6 blocklist should have failed")
+func TestIPv6CIDRMap(t *testing.T) {
+	_, err := bpfDP.NewCIDRMap("myiface3", IPFamilyV6)
+	if err != nil {
+		t.Fatalf("cannot create IPv6 map: %v", err)
 	}
+
+	t.Log("A created IPv6 map should be valid")
+	v, err := bpfDP.IsValidMap("myiface3", IPFamilyV6)
+	if err != nil {
+		t.Fatalf("cannot check map validity: %v", err)
+	}
+	if !v {
+		t.Fatalf("map should have been valid")
+	}
+
+	t.Log("An IPv6 map should only be listed as an IPv6 map")
+	arr, err := bpfDP.ListCIDRMaps(IPFamilyV6)
+	if err != nil {
+		t.Fatalf("cannot list map: %v", err)
+	}
+	if !strSliceContains(arr, "myiface3") {
+		t.Fatalf("map list should contain myiface3: %v", arr)
+	}
+	arr, err = bpfDP.ListCIDRMaps(IPFamilyV4)
+	if err != nil {
+		t.Fatalf("cannot list map: %v", err)
+	}
+	if strSliceContains(arr, "myiface3") {
+		t.Fatalf("IPv4 map list should NOT contain myiface3: %v", arr)
+	}
+
+	ip := net.ParseIP("2001:db8::")
+	mask := 32
+	err = bpfDP.UpdateCIDRMap("myiface3", IPFamilyV6, ip, mask, 51)
+	if err != nil {
+		t.Fatalf("cannot update map: %v", err)
+	}
+
+	t.Log("Looking up an element of an IPv6 map should return the right value")
+	value, err := bpfDP.LookupCIDRMap("myiface3", IPFamilyV6, ip, mask)
+	if err != nil {
+		t.Fatalf("cannot lookup map: %v", err)
+	}
+	if value != 51 {
+		t.Fatalf("wrong value found in map: %d", value)
+	}
+
+	t.Log("Dumping an IPv6 map should return its entries")
+	contents, err := bpfDP.DumpCIDRMap("myiface3", IPFamilyV6)
+	if err != nil {
+		t.Fatalf("cannot dump map: %v", err)
+	}
+	_, cidr, _ := net.ParseCIDR("2001:db8::/32")
+	if len(contents) != 1 || contents[NewCIDRMapKey(cidr)] != 51 {
+		t.Fatalf("invalid contents of the map: %v", contents)
+	}
+
+	err = bpfDP.RemoveItemCIDRMap("myiface3", IPFamilyV6, ip, mask)
+	if err != nil {
+		t.Fatalf("cannot remove item from map: %v", err)
+	}
+	err = bpfDP.RemoveCIDRMap("myiface3", IPFamilyV6)
+	if err != nil {
+		t.Fatalf("cannot delete map: %v", err)
+	}
+}
+
+func TestCidrToHex(t *testing.T) {
+	RegisterTestingT(t)
+
+	hex, err := CidrToHex("192.168.0.0/16")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(hex).To(Equal([]string{"10", "00", "00", "00", "c0", "a8", "00", "00"}))
+
+	hex, err = CidrToHex("2001:db8::1/128")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(hex).To(Equal([]string{
+		"80", "00", "00", "00",
+		"20", "01", "0d", "b8", "00", "00", "00", "00",
+		"00", "00", "00", "00", "00", "00", "00", "01",
+	}))
+
+	_, err = CidrToHex("192.168.0.0/33")
+	Expect(err).To(HaveOccurred())
+	_, err = CidrToHex("2001:db8::/129")
+	Expect(err).To(HaveOccurred())
 }
 
 func TestVersionParse(t *testing.T) {
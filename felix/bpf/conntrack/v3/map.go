@@ -100,7 +100,8 @@ func NewKey(proto uint8, ipA net.IP, portA uint16, ipB net.IP, portB uint16) Key
 //  // not to zero the padding bytes, which upsets the verifier.  Worse than
 //  // that, debug logging often prevents such optimisation resulting in
 //  // failures when debug logging is compiled out only :-).
-//  __u8 pad0[5];
+//  __u8 dscp;     // 18
+//  __u8 pad0[4];
 //  __u8 flags2;
 //  union {
 //    // CALI_CT_TYPE_NORMAL and CALI_CT_TYPE_NAT_REV.
@@ -129,6 +130,7 @@ const (
 	VoLastSeen  int = 8
 	VoType      int = 16
 	VoFlags     int = 17
+	VoDSCP      int = 18
 	VoFlags2    int = 23
 	VoRevKey    int = 24
 	VoLegAB     int = 24
@@ -176,6 +178,11 @@ func (e Value) Flags() uint16 {
 	return uint16(e[VoFlags]) | (uint16(e[VoFlags2]) << 8)
 }
 
+// DSCP returns the DSCP that is set on the opener's packets, valid only if FlagSetDSCP is set.
+func (e Value) DSCP() uint8 {
+	return e[VoDSCP]
+}
+
 // OrigIP returns the original destination IP, valid only if Type() is TypeNormal or TypeNATReverse
 func (e Value) OrigIP() net.IP {
 	return e[VoOrigIP : VoOrigIP+4]
@@ -222,6 +229,7 @@ const (
 	FlagNPRemote    uint16 = (1 << 12)
 	FlagNoDSR       uint16 = (1 << 13)
 	FlagNoRedirPeer uint16 = (1 << 14)
+	FlagSetDSCP     uint16 = (1 << 15)
 )
 
 func (e Value) ReverseNATKey() KeyInterface {
@@ -503,6 +511,10 @@ func (e Value) String() string {
 		if flags&FlagNPRemote != 0 {
 			flagsStr += " no-dsr"
 		}
+
+		if flags&FlagSetDSCP != 0 {
+			flagsStr += fmt.Sprintf(" set-dscp(%d)", e.DSCP())
+		}
 	}
 
 	ret := fmt.Sprintf("Entry{Type:%d, LastSeen:%d, Flags:%s ",
@@ -89,7 +89,8 @@ func NewKeyV6(proto uint8, ipA net.IP, portA uint16, ipB net.IP, portB uint16) K
 //  // not to zero the padding bytes, which upsets the verifier.  Worse than
 //  // that, debug logging often prevents such optimisation resulting in
 //  // failures when debug logging is compiled out only :-).
-//  __u8 pad0[5];
+//  __u8 dscp;     // 18
+//  __u8 pad0[4];
 //  __u8 flags2;
 //  union {
 //    // CALI_CT_TYPE_NORMAL and CALI_CT_TYPE_NAT_REV.
@@ -118,6 +119,7 @@ const (
 	VoLastSeenV6  int = 8
 	VoTypeV6      int = 16
 	VoFlagsV6     int = 17
+	VoDSCPV6      int = 18
 	VoFlags2V6    int = 23
 	VoRevKeyV6    int = 24
 	VoLegABV6     int = 24
@@ -148,6 +150,11 @@ func (e ValueV6) Flags() uint16 {
 	return uint16(e[VoFlagsV6]) | (uint16(e[VoFlags2]) << 8)
 }
 
+// DSCP returns the DSCP that is set on the opener's packets, valid only if FlagSetDSCP is set.
+func (e ValueV6) DSCP() uint8 {
+	return e[VoDSCPV6]
+}
+
 // OrigIP returns the original destination IP, valid only if Type() is TypeNormal or TypeNATReverse
 func (e ValueV6) OrigIP() net.IP {
 	return e[VoOrigIPV6 : VoOrigIPV6+16]
@@ -350,6 +357,10 @@ func (e ValueV6) String() string {
 		if flags&FlagNPRemote != 0 {
 			flagsStr += " no-dsr"
 		}
+
+		if flags&FlagSetDSCP != 0 {
+			flagsStr += fmt.Sprintf(" set-dscp(%d)", e.DSCP())
+		}
 	}
 
 	ret := fmt.Sprintf("Entry{Type:%d, LastSeen:%d, Flags:%s ",
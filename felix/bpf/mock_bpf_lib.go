@@ -1,4 +1,4 @@
-// Copyright (c) 2019-2020 Tigera, Inc. All rights reserved.
+// Copyright (c) 2019-2025 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -58,9 +58,55 @@ type IPv4Mask struct {
 	Mask int
 }
 
+type IPv6Mask struct {
+	Ip   [16]byte
+	Mask int
+}
+
+// CIDRMap is a mock CIDR map.  IPv4 maps store their contents in M and IPv6 maps in M6.
 type CIDRMap struct {
 	Info CIDRMapInfo
 	M    map[IPv4Mask]uint32
+	M6   map[IPv6Mask]uint32
+}
+
+func (m CIDRMap) lookup(ip net.IP, mask int) (uint32, bool) {
+	if m.Info.Family == IPFamilyV6 {
+		v, ok := m.M6[toIPv6Mask(ip, mask)]
+		return v, ok
+	}
+	v, ok := m.M[toIPv4Mask(ip, mask)]
+	return v, ok
+}
+
+func (m CIDRMap) update(ip net.IP, mask int, refCount uint32) {
+	if m.Info.Family == IPFamilyV6 {
+		m.M6[toIPv6Mask(ip, mask)] = refCount
+		return
+	}
+	m.M[toIPv4Mask(ip, mask)] = refCount
+}
+
+func (m CIDRMap) remove(ip net.IP, mask int) {
+	if m.Info.Family == IPFamilyV6 {
+		delete(m.M6, toIPv6Mask(ip, mask))
+		return
+	}
+	delete(m.M, toIPv4Mask(ip, mask))
+}
+
+func toIPv4Mask(ip net.IP, mask int) IPv4Mask {
+	l := len(ip)
+	return IPv4Mask{
+		Ip:   [4]byte{ip[l-4], ip[l-3], ip[l-2], ip[l-1]},
+		Mask: mask,
+	}
+}
+
+func toIPv6Mask(ip net.IP, mask int) IPv6Mask {
+	ipm := IPv6Mask{Mask: mask}
+	copy(ipm.Ip[:], ip.To16())
+	return ipm
 }
 
 type FailsafeMap struct {
@@ -119,20 +165,23 @@ func (b *MockBPFLib) GetBPFCalicoDir() string {
 }
 
 func (b *MockBPFLib) NewCIDRMap(ifName string, family IPFamily) (string, error) {
-	if family != IPFamilyV4 {
-		return "", errors.New("only IPv4 is supported")
-	}
-
 	key := CIDRMapsKey{
 		IfName: ifName,
 		Family: family,
 	}
 
-	b.CIDRMaps[key] = NewMockCIDRMap(id)
+	switch family {
+	case IPFamilyV4:
+		b.CIDRMaps[key] = NewMockCIDRMap(id)
+	case IPFamilyV6:
+		b.CIDRMaps[key] = NewMockCIDRMapV6(id)
+	default:
+		return "", fmt.Errorf("unknown IP family %d", family)
+	}
 
 	id += 1
 
-	return fmt.Sprintf("/sys/fs/bpf/calico/xdp/%s_ipv4_v1_blacklist", ifName), nil
+	return fmt.Sprintf("/sys/fs/bpf/calico/xdp/%s_%s_v1_blacklist", ifName, family), nil
 }
 
 func (b *MockBPFLib) NewFailsafeMap() (string, error) {
@@ -164,6 +213,13 @@ func (b *MockBPFLib) DumpCIDRMap(ifName string, family IPFamily) (map[CIDRMapKey
 		}
 		ret[NewCIDRMapKey(&ipnet)] = v
 	}
+	for k, v := range m.M6 {
+		ipnet := net.IPNet{
+			IP:   net.IP(k.Ip[:]),
+			Mask: net.CIDRMask(k.Mask, 128),
+		}
+		ret[NewCIDRMapKey(&ipnet)] = v
+	}
 
 	return ret, nil
 }
@@ -276,7 +332,7 @@ func (b *MockBPFLib) IsValidMap(ifName string, family IPFamily) (bool, error) {
 	}
 
 	valid := m.Info.Type == "lpm_trie" &&
-		m.Info.KeySize == 8 &&
+		m.Info.KeySize == 4+family.Size() &&
 		m.Info.ValueSize == 4
 	return valid, nil
 }
@@ -285,6 +341,9 @@ func (b *MockBPFLib) ListCIDRMaps(family IPFamily) ([]string, error) {
 	var ret []string
 
 	for k := range b.CIDRMaps {
+		if k.Family != family {
+			continue
+		}
 		ret = append(ret, k.IfName)
 	}
 
@@ -300,7 +359,6 @@ func (b *MockBPFLib) LoadXDP(objPath, ifName string, mode XDPMode) error {
 
 	key := CIDRMapsKey{
 		IfName: ifName,
-		// TODO change this when we support ipv6
 		Family: IPFamilyV4,
 	}
 
@@ -311,6 +369,12 @@ func (b *MockBPFLib) LoadXDP(objPath, ifName string, mode XDPMode) error {
 
 	mapArgs = append(mapArgs, strconv.Itoa(cmap.Info.Id))
 
+	// As with the real library, the IPv6 map is optional.
+	key.Family = IPFamilyV6
+	if cmap, ok := b.CIDRMaps[key]; ok {
+		mapArgs = append(mapArgs, strconv.Itoa(cmap.Info.Id))
+	}
+
 	return b.loadXDPRaw(objPath, ifName, mode, mapArgs)
 }
 
@@ -329,13 +393,7 @@ func (b *MockBPFLib) LookupCIDRMap(ifName string, family IPFamily, ip net.IP, ma
 		return 0, fmt.Errorf("map %q not found", ifName)
 	}
 
-	l := len(ip)
-	ipm := IPv4Mask{
-		Ip:   [4]byte{ip[l-4], ip[l-3], ip[l-2], ip[l-1]},
-		Mask: mask,
-	}
-
-	refCount, ok := m.M[ipm]
+	refCount, ok := m.lookup(ip, mask)
 	if !ok {
 		return 0, errors.New("CIDR not found")
 	}
@@ -392,17 +450,11 @@ func (b *MockBPFLib) RemoveItemCIDRMap(ifName string, family IPFamily, ip net.IP
 		return fmt.Errorf("map %q not found", ifName)
 	}
 
-	l := len(ip)
-	ipm := IPv4Mask{
-		Ip:   [4]byte{ip[l-4], ip[l-3], ip[l-2], ip[l-1]},
-		Mask: mask,
-	}
-
-	if _, ok := info.M[ipm]; !ok {
+	if _, ok := info.lookup(ip, mask); !ok {
 		return errors.New("CIDR not found")
 	}
 
-	delete(info.M, ipm)
+	info.remove(ip, mask)
 
 	return nil
 }
@@ -448,12 +500,7 @@ func (b *MockBPFLib) UpdateCIDRMap(ifName string, family IPFamily, ip net.IP, ma
 		return fmt.Errorf("map %q not found", ifName)
 	}
 
-	l := len(ip)
-	ipm := IPv4Mask{
-		Ip:   [4]byte{ip[l-4], ip[l-3], ip[l-2], ip[l-1]},
-		Mask: mask,
-	}
-	m.M[ipm] = refCount
+	m.update(ip, mask, refCount)
 	return nil
 }
 
@@ -538,11 +585,27 @@ func NewMockCIDRMap(mapID int) CIDRMap {
 				KeySize:   8,
 				ValueSize: 4,
 			},
+			Family: IPFamilyV4,
 		},
 		M: make(map[IPv4Mask]uint32),
 	}
 }
 
+func NewMockCIDRMapV6(mapID int) CIDRMap {
+	return CIDRMap{
+		Info: CIDRMapInfo{
+			CommonMapInfo: CommonMapInfo{
+				Id:        mapID,
+				Type:      "lpm_trie",
+				KeySize:   20,
+				ValueSize: 4,
+			},
+			Family: IPFamilyV6,
+		},
+		M6: make(map[IPv6Mask]uint32),
+	}
+}
+
 func NewMockSockMap(mapID int) SockMap {
 	return SockMap{
 		Info: SockMapInfo{
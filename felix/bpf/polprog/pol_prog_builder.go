@@ -126,6 +126,7 @@ var (
 	stateOffPreNATIPDst    = FieldOffset{Offset: stateEventHdrSize + 32, Field: "state->pre_nat_ip_dst"}
 	_                      = stateOffPreNATIPDst
 	stateOffPostNATIPDst   = FieldOffset{Offset: stateEventHdrSize + 48, Field: "state->post_nat_ip_dst"}
+	stateOffDSCP           = FieldOffset{Offset: stateEventHdrSize + 82, Field: "state->dscp"}
 	stateOffPolResult      = FieldOffset{Offset: stateEventHdrSize + 84, Field: "state->pol_rc"}
 	stateOffSrcPort        = FieldOffset{Offset: stateEventHdrSize + 88, Field: "state->sport"}
 	stateOffDstPort        = FieldOffset{Offset: stateEventHdrSize + 90, Field: "state->dport"}
@@ -163,6 +164,7 @@ var (
 	FlagDestIsHost uint64 = 1 << 2
 	FlagSrcIsHost  uint64 = 1 << 3
 	FlagLogPacket  uint64 = 1 << 10
+	FlagSetDSCP    uint64 = 1 << 12
 )
 
 type Rule struct {
@@ -232,6 +234,15 @@ func (p *Builder) Instructions(rules Rules) ([]Insns, error) {
 	p.blocks = append(p.blocks, p.b)
 	p.writeProgramHeader()
 
+	if !p.xdp {
+		// DSCP rules only apply to egress traffic from a workload or from the host.
+		if rules.ForHostInterface {
+			p.writeDSCPRules(rules.HostNormalTiers, true)
+		} else {
+			p.writeDSCPRules(rules.Tiers, false)
+		}
+	}
+
 	if p.xdp {
 		// For an XDP program HostNormalTiers continues the untracked policy to enforce;
 		// other fields are unused.
@@ -515,6 +526,39 @@ func (p *Builder) writeTiers(tiers []Tier, destLeg matchLeg, allowLabel string)
 	}
 }
 
+// writeDSCPRules emits the SetDSCP rules of the given tiers ahead of the rest of the policy.  They
+// don't affect the verdict; the first matching rule records its DSCP in the state and the rest are
+// skipped.
+func (p *Builder) writeDSCPRules(tiers []Tier, hostOnly bool) {
+	var dscpRules []Rule
+	for _, tier := range tiers {
+		for _, pol := range tier.Policies {
+			for _, rule := range pol.Rules {
+				if strings.ToLower(rule.Action) == "setdscp" {
+					dscpRules = append(dscpRules, rule)
+				}
+			}
+		}
+	}
+	if len(dscpRules) == 0 {
+		return
+	}
+
+	p.b.AddComment("Start of DSCP rules")
+	if hostOnly {
+		// Normal host policy only applies to traffic to or from the host.
+		p.b.Load64(R1, R9, stateOffFlags)
+		p.b.AndImm64(R1, int32(FlagDestIsHost|FlagSrcIsHost))
+		p.b.JumpEqImm64(R1, 0, "dscp_done")
+	}
+	for _, rule := range dscpRules {
+		p.b.AddCommentF("Start of DSCP rule %s", rule)
+		p.writeRule(rule, "setdscp", legDest)
+	}
+	p.b.LabelNextInsn("dscp_done")
+	p.b.AddComment("End of DSCP rules")
+}
+
 func (p *Builder) writeProfiles(profiles []Policy, noProfileMatchID uint64, allowLabel string) {
 	log.Debugf("Start of profiles")
 	for idx, prof := range profiles {
@@ -538,6 +582,10 @@ func (p *Builder) writePolicyRules(policy Policy, actionLabels map[string]string
 		}
 		p.b.AddCommentF("Rule MatchID: %d", rule.MatchID)
 		action := strings.ToLower(rule.Action)
+		if action == "setdscp" {
+			// Handled up front by writeDSCPRules.
+			continue
+		}
 		p.writeRule(rule, actionLabels[action], destLeg)
 		log.Debugf("End of rule %d", ruleIdx)
 		p.b.AddCommentF("End of rule %s", rule.RuleId)
@@ -746,6 +794,13 @@ func (p *Builder) writeEndOfRule(rule Rule, actionLabel string) {
 		p.b.Load64(R1, R9, stateOffFlags)
 		p.b.OrImm64(R1, int32(FlagLogPacket))
 		p.b.Store64(R9, R1, stateOffFlags)
+	} else if actionLabel == "setdscp" {
+		p.b.MovImm32(R1, rule.Dscp)
+		p.b.Store8(R9, R1, stateOffDSCP)
+		p.b.Load64(R1, R9, stateOffFlags)
+		p.b.OrImm64(R1, int32(FlagSetDSCP))
+		p.b.Store64(R9, R1, stateOffFlags)
+		p.b.Jump("dscp_done")
 	} else {
 		// If all the match criteria are met, we fall through to the end of the rule
 		// so all that's left to do is to jump to the relevant action.
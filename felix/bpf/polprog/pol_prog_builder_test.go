@@ -142,6 +142,47 @@ func TestPolicyDump(t *testing.T) {
 	checkLabelsAndComments(&proto.Rule{NotIcmp: &proto.Rule_NotIcmpType{NotIcmpType: 10}}, "If ICMP type == 10, skip to next rule", "comment")
 }
 
+func TestDSCPRules(t *testing.T) {
+	RegisterTestingT(t)
+	alloc := idalloc.New()
+
+	dscpTiers := []Tier{{
+		Policies: []Policy{{
+			Rules: []Rule{
+				{Rule: &proto.Rule{Action: "SetDSCP", Dscp: 46, DstNet: []string{"10.0.0.0/8"}}},
+				{Rule: &proto.Rule{Action: "Allow"}},
+			},
+		}},
+	}}
+
+	pg := NewBuilder(alloc, 1, 2, 3, 4, WithAllowDenyJumps(666, 777), WithPolicyDebugEnabled())
+	insns, err := pg.Instructions(Rules{Tiers: dscpTiers})
+	Expect(err).NotTo(HaveOccurred())
+	labels, comments := aggregateCommentsAndLabels(&insns[0])
+	Expect(labels).To(ContainElement("dscp_done"))
+	Expect(comments).To(ContainElement("Start of DSCP rules"))
+
+	// On a host interface, only the host endpoint's normal policy can set the DSCP.
+	pg = NewBuilder(alloc, 1, 2, 3, 4, WithAllowDenyJumps(666, 777), WithPolicyDebugEnabled())
+	insns, err = pg.Instructions(Rules{ForHostInterface: true, Tiers: dscpTiers})
+	Expect(err).NotTo(HaveOccurred())
+	labels, _ = aggregateCommentsAndLabels(&insns[0])
+	Expect(labels).NotTo(ContainElement("dscp_done"))
+
+	pg = NewBuilder(alloc, 1, 2, 3, 4, WithAllowDenyJumps(666, 777), WithPolicyDebugEnabled())
+	insns, err = pg.Instructions(Rules{ForHostInterface: true, HostNormalTiers: dscpTiers})
+	Expect(err).NotTo(HaveOccurred())
+	labels, _ = aggregateCommentsAndLabels(&insns[0])
+	Expect(labels).To(ContainElement("dscp_done"))
+
+	// XDP programs don't support DSCP rules.
+	pg = NewBuilder(alloc, 1, 2, 3, 4, WithAllowDenyJumps(666, 777), WithPolicyDebugEnabled())
+	insns, err = pg.Instructions(Rules{ForXDP: true, ForHostInterface: true, HostNormalTiers: dscpTiers})
+	Expect(err).NotTo(HaveOccurred())
+	labels, _ = aggregateCommentsAndLabels(&insns[0])
+	Expect(labels).NotTo(ContainElement("dscp_done"))
+}
+
 func aggregateCommentsAndLabels(insns *asm.Insns) ([]string, []string) {
 	labels := []string{}
 	comments := []string{}
@@ -96,7 +96,8 @@ type State struct {
 	TunIP2              uint32
 	TunIP3              uint32
 	ihl                 uint16
-	_                   uint16
+	DSCP                uint8
+	_                   uint8
 	PolicyRC            PolicyResult
 	SrcPort             uint16
 	DstPort             uint16
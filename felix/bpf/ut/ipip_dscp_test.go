@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ut_test
+
+import (
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/bpf/routes"
+	"github.com/projectcalico/calico/felix/ip"
+)
+
+// ipipPacket returns an IPIP packet from this node to node 2 with the given TOS on the outer and
+// inner headers.
+func ipipPacket(outerTOS, innerTOS uint8) []byte {
+	outer := &layers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TOS:      outerTOS,
+		TTL:      64,
+		Flags:    layers.IPv4DontFragment,
+		SrcIP:    node1ip,
+		DstIP:    node2ip,
+		Protocol: layers.IPProtocolIPv4,
+	}
+	inner := *ipv4Default
+	inner.TOS = innerTOS
+	udp := &layers.UDP{SrcPort: 1234, DstPort: 5678}
+	_ = udp.SetNetworkLayerForChecksum(&inner)
+
+	pkt := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(pkt, gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true},
+		ethDefault, outer, &inner, udp, gopacket.Payload(payloadDefault))
+	Expect(err).NotTo(HaveOccurred())
+	return pkt.Bytes()
+}
+
+func TestIPIPCopiesDSCPToOuterHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	hostIP = node1ip
+	defer resetRTMap(rtMap)
+	err := rtMap.Update(
+		routes.NewKey(ip.CIDRFromIPNet(&node2CIDR).(ip.V4CIDR)).AsBytes(),
+		routes.NewValue(routes.FlagsRemoteHost).AsBytes(),
+	)
+	Expect(err).NotTo(HaveOccurred())
+
+	const ef = 46
+
+	runBpfTest(t, "calico_to_host_ep", nil, func(bpfrun bpfProgRunFn) {
+		// The DSCP of the inner packet is copied to the outer header, which keeps its own ECN
+		// bits.
+		res, err := bpfrun(ipipPacket(0x1, ef<<2|0x2))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Retval).To(Equal(resTC_ACT_UNSPEC))
+		Expect(res.dataOut).To(Equal(ipipPacket(ef<<2|0x1, ef<<2|0x2)))
+
+		// Packets without a DSCP are left alone.
+		pktBytes := ipipPacket(0, 0)
+		res, err = bpfrun(pktBytes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Retval).To(Equal(resTC_ACT_UNSPEC))
+		Expect(res.dataOut).To(Equal(pktBytes))
+	})
+}
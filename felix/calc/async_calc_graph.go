@@ -31,6 +31,10 @@ import (
 const (
 	tickInterval    = 10 * time.Millisecond
 	leakyBucketSize = 10
+
+	// policyScheduleInterval is how often policies with a schedule are checked to see whether
+	// they have started or stopped being enforced.
+	policyScheduleInterval = time.Second
 )
 
 var (
@@ -79,10 +83,11 @@ type AsyncCalcGraph struct {
 	syncStatusNow    api.SyncStatus
 	healthAggregator *health.HealthAggregator
 
-	flushTicks       <-chan time.Time
-	healthTicks      <-chan time.Time
-	flushLeakyBucket int
-	dirty            bool
+	flushTicks          <-chan time.Time
+	healthTicks         <-chan time.Time
+	policyScheduleTicks <-chan time.Time
+	flushLeakyBucket    int
+	dirty               bool
 
 	debugHangC <-chan time.Time
 }
@@ -184,6 +189,10 @@ func (acg *AsyncCalcGraph) loop() {
 			}
 		case <-acg.healthTicks:
 			acg.reportHealth()
+		case <-acg.policyScheduleTicks:
+			if acg.CalcGraph.OnPolicyScheduleTick() {
+				acg.dirty = true
+			}
 		case <-acg.debugHangC:
 			log.Warning("Debug hang simulation timer popped, hanging the calculation graph!!")
 			time.Sleep(1 * time.Hour)
@@ -256,5 +265,6 @@ func (acg *AsyncCalcGraph) Start() {
 	log.Info("Starting AsyncCalcGraph")
 	acg.flushTicks = time.NewTicker(tickInterval).C
 	acg.healthTicks = time.NewTicker(healthInterval).C
+	acg.policyScheduleTicks = time.NewTicker(policyScheduleInterval).C
 	go acg.loop()
 }
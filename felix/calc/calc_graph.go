@@ -145,6 +145,12 @@ func (g *CalcGraph) Flush() {
 	g.policyResolver.Flush()
 }
 
+// OnPolicyScheduleTick re-evaluates the policies that are only enforced on a schedule.  It
+// returns true if any of them have started or stopped being enforced, so a Flush is needed.
+func (g *CalcGraph) OnPolicyScheduleTick() bool {
+	return g.policyResolver.OnPolicyScheduleTick()
+}
+
 func NewCalculationGraph(
 	callbacks PipelineCallbacks,
 	cache *LookupsCache,
@@ -308,7 +314,12 @@ func NewCalculationGraph(
 	ruleScanner.OnIPSetActive = func(ipSet *IPSetData) {
 		log.WithField("ipSet", ipSet).Info("IPSet now active")
 		callbacks.OnIPSetAdded(ipSet.UniqueID(), ipSet.DataplaneProtocolType())
-		if ipSet.Service != "" {
+		if len(ipSet.Domains) > 0 {
+			// Domain IP sets have static membership; the dataplane resolves the domains to IPs.
+			for _, domain := range ipSet.Domains {
+				callbacks.OnIPSetMemberAdded(ipSet.UniqueID(), labelindex.IPSetMember{Domain: domain})
+			}
+		} else if ipSet.Service != "" {
 			serviceIndex.UpdateIPSet(ipSet.UniqueID(), ipSet.Service)
 		} else {
 			ipsetMemberIndex.UpdateIPSet(ipSet.UniqueID(), ipSet.Selector, ipSet.NamedPortProtocol, ipSet.NamedPort)
@@ -317,9 +328,12 @@ func NewCalculationGraph(
 	}
 	ruleScanner.OnIPSetInactive = func(ipSet *IPSetData) {
 		log.WithField("ipSet", ipSet).Info("IPSet now inactive")
-		if ipSet.Service != "" {
+		switch {
+		case len(ipSet.Domains) > 0:
+			// Domain IP sets aren't tracked by an index; removing the IP set removes its members.
+		case ipSet.Service != "":
 			serviceIndex.DeleteIPSet(ipSet.UniqueID())
-		} else {
+		default:
 			ipsetMemberIndex.DeleteIPSet(ipSet.UniqueID())
 		}
 		callbacks.OnIPSetRemoved(ipSet.UniqueID())
@@ -381,6 +395,11 @@ func NewCalculationGraph(
 	activeBGPPeerCalc.RegisterWith(localEndpointDispatcher, allUpdDispatcher)
 	activeBGPPeerCalc.OnEndpointBGPPeerDataUpdate = polResolver.OnEndpointBGPPeerDataUpdate
 
+	// Create and hook up the QoS policy calculator.
+	qosPolicyCalc := NewQoSPolicyCalculator()
+	qosPolicyCalc.RegisterWith(localEndpointDispatcher, allUpdDispatcher)
+	qosPolicyCalc.OnEndpointQoSPolicyUpdate = polResolver.OnEndpointQoSPolicyUpdate
+
 	// Register for host IP updates.
 	//
 	//        ...
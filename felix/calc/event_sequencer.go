@@ -733,6 +733,7 @@ func (buf *EventSequencer) flushHostWireguardUpdates() {
 				Hostname:          nodename,
 				PublicKey:         wg.PublicKey,
 				InterfaceIpv4Addr: ipv4Str,
+				NextPublicKey:     wg.NextPublicKey,
 			})
 			buf.sentWireguard.Add(nodename)
 		} else if buf.sentWireguard.Contains(nodename) {
@@ -752,6 +753,7 @@ func (buf *EventSequencer) flushHostWireguardUpdates() {
 				Hostname:          nodename,
 				PublicKeyV6:       wg.PublicKeyV6,
 				InterfaceIpv6Addr: ipv6Str,
+				NextPublicKeyV6:   wg.NextPublicKeyV6,
 			})
 			buf.sentWireguardV6.Add(nodename)
 		} else if buf.sentWireguardV6.Contains(nodename) {
@@ -827,6 +829,9 @@ func (buf *EventSequencer) flushAddedIPSets() {
 }
 
 func memberToProto(member labelindex.IPSetMember) string {
+	if member.Domain != "" {
+		return member.Domain
+	}
 	switch member.Protocol {
 	case labelindex.ProtocolNone:
 		return member.CIDR.String()
@@ -15,6 +15,8 @@
 package calc
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
@@ -34,11 +36,16 @@ var (
 		Name: "felix_active_local_policies",
 		Help: "Number of active policies on this host.",
 	})
+	gaugeNumInactiveScheduledPolicies = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "felix_inactive_scheduled_policies",
+		Help: "Number of policies that aren't enforced because they are outside their active schedule.",
+	})
 )
 
 func init() {
 	prometheus.MustRegister(gaugeNumActiveEndpoints)
 	prometheus.MustRegister(gaugeNumActivePolicies)
+	prometheus.MustRegister(gaugeNumInactiveScheduledPolicies)
 }
 
 // PolicyResolver marries up the active policies with local endpoints and
@@ -49,6 +56,13 @@ func init() {
 // The PolicyResolver doesn't figure out which policies are currently active, it
 // expects to be told via its OnPolicyMatch(Stopped) methods which policies match
 // which endpoints.  The ActiveRulesCalculator does that calculation.
+//
+// Policies may also be limited to a schedule, outside of which the PolicyResolver leaves them out
+// of the endpoints' tiers, as if they didn't exist.  Since no datastore update marks the start or
+// end of a scheduled window, OnPolicyScheduleTick must be called periodically to re-evaluate them.
+//
+// It also combines the QoS controls of the QoSPolicy, if any, that applies to each workload endpoint
+// with those from the endpoint's annotations, as reported by the QoSPolicyCalculator.
 type PolicyResolver struct {
 	policyIDToEndpointIDs multidict.Multidict[model.PolicyKey, model.EndpointKey]
 	endpointIDToPolicyIDs multidict.Multidict[model.EndpointKey, model.PolicyKey]
@@ -60,6 +74,10 @@ type PolicyResolver struct {
 	Callbacks             []PolicyResolverCallbacks
 	InSync                bool
 	endpointBGPPeerData   map[model.WorkloadEndpointKey]EndpointBGPPeer
+	endpointQoSPolicies   map[model.WorkloadEndpointKey]EndpointQoSPolicy
+	policySchedules       map[model.PolicyKey]*policySchedule
+	inactivePolicies      set.Set[model.PolicyKey]
+	now                   func() time.Time
 }
 
 type PolicyResolverCallbacks interface {
@@ -74,8 +92,12 @@ func NewPolicyResolver() *PolicyResolver {
 		endpoints:             make(map[model.Key]model.Endpoint),
 		dirtyEndpoints:        set.New[model.EndpointKey](),
 		endpointBGPPeerData:   map[model.WorkloadEndpointKey]EndpointBGPPeer{},
+		endpointQoSPolicies:   map[model.WorkloadEndpointKey]EndpointQoSPolicy{},
 		policySorter:          NewPolicySorter(),
 		Callbacks:             []PolicyResolverCallbacks{},
+		policySchedules:       map[model.PolicyKey]*policySchedule{},
+		inactivePolicies:      set.New[model.PolicyKey](),
+		now:                   time.Now,
 	}
 }
 
@@ -105,9 +127,11 @@ func (pr *PolicyResolver) OnUpdate(update api.Update) (filterOut bool) {
 		log.Debugf("Policy update: %v", key)
 		if update.Value == nil {
 			delete(pr.allPolicies, key)
+			pr.updatePolicySchedule(key, nil)
 		} else {
 			policy := update.Value.(*model.Policy)
 			pr.allPolicies[key] = ExtractPolicyMetadata(policy)
+			pr.updatePolicySchedule(key, newPolicySchedule(key, policy))
 		}
 		if !pr.policyIDToEndpointIDs.ContainsKey(key) {
 			return
@@ -125,6 +149,50 @@ func (pr *PolicyResolver) OnUpdate(update api.Update) (filterOut bool) {
 	return
 }
 
+// updatePolicySchedule records the schedule of a policy (nil if it is always enforced) and whether
+// it is currently active.
+func (pr *PolicyResolver) updatePolicySchedule(key model.PolicyKey, schedule *policySchedule) {
+	if schedule == nil {
+		delete(pr.policySchedules, key)
+	} else {
+		pr.policySchedules[key] = schedule
+	}
+	pr.refreshPolicyActive(key, schedule, pr.now())
+}
+
+// refreshPolicyActive updates whether the policy is inside its schedule at the given time, and
+// marks the endpoints that it applies to as dirty if that has changed.  It returns true if there
+// was a change.
+func (pr *PolicyResolver) refreshPolicyActive(key model.PolicyKey, schedule *policySchedule, now time.Time) bool {
+	active := schedule == nil || schedule.IsActive(now)
+	if active != pr.inactivePolicies.Contains(key) {
+		return false
+	}
+	if active {
+		log.WithField("policy", key).Info("Scheduled policy is now active")
+		pr.inactivePolicies.Discard(key)
+	} else {
+		log.WithField("policy", key).Info("Scheduled policy is now inactive")
+		pr.inactivePolicies.Add(key)
+	}
+	gaugeNumInactiveScheduledPolicies.Set(float64(pr.inactivePolicies.Len()))
+	pr.markEndpointsMatchingPolicyDirty(key)
+	return true
+}
+
+// OnPolicyScheduleTick re-evaluates the schedules of the policies that have them, so that they
+// start and stop being enforced on time.  It returns true if any of them have changed, in which
+// case a Flush is needed.
+func (pr *PolicyResolver) OnPolicyScheduleTick() (changed bool) {
+	now := pr.now()
+	for key, schedule := range pr.policySchedules {
+		if pr.refreshPolicyActive(key, schedule, now) {
+			changed = true
+		}
+	}
+	return
+}
+
 func (pr *PolicyResolver) OnDatamodelStatus(status api.SyncStatus) {
 	if status == api.InSync {
 		pr.InSync = true
@@ -205,6 +273,10 @@ func (pr *PolicyResolver) sendEndpointUpdate(endpointID model.EndpointKey) error
 		}
 		for _, polKV := range tier.OrderedPolicies {
 			log.Debugf("Checking if policy %v matches %v", polKV.Key, endpointID)
+			if pr.inactivePolicies.Contains(polKV.Key) {
+				log.Debugf("Policy %v is outside its schedule, skipping", polKV.Key)
+				continue
+			}
 			if pr.endpointIDToPolicyIDs.Contains(endpointID, polKV.Key) {
 				log.Debugf("Policy %v matches %v", polKV.Key, endpointID)
 				tierMatches = true
@@ -226,6 +298,9 @@ func (pr *PolicyResolver) sendEndpointUpdate(endpointID model.EndpointKey) error
 		if !data.Empty() {
 			peerData = &data
 		}
+		if qosPolicy, ok := pr.endpointQoSPolicies[key]; ok {
+			endpoint = qosPolicy.applyTo(endpoint.(*model.WorkloadEndpoint))
+		}
 	}
 
 	for _, cb := range pr.Callbacks {
@@ -242,3 +317,12 @@ func (pr *PolicyResolver) OnEndpointBGPPeerDataUpdate(key model.WorkloadEndpoint
 	}
 	pr.dirtyEndpoints.Add(key)
 }
+
+func (pr *PolicyResolver) OnEndpointQoSPolicyUpdate(key model.WorkloadEndpointKey, qosPolicy *EndpointQoSPolicy) {
+	if qosPolicy != nil {
+		pr.endpointQoSPolicies[key] = *qosPolicy
+	} else {
+		delete(pr.endpointQoSPolicies, key)
+	}
+	pr.dirtyEndpoints.Add(key)
+}
@@ -16,8 +16,10 @@ package calc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/projectcalico/calico/lib/std/uniquelabels"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
@@ -209,3 +211,81 @@ func TestPolicyResolver_OnPolicyMatchStopped(t *testing.T) {
 		t.Error("Incorrect update:", d)
 	}
 }
+
+func TestPolicyResolver_PolicySchedule(t *testing.T) {
+	pr, recorder := createPolicyResolver()
+	pr.OnDatamodelStatus(api.InSync)
+	now := time.Date(2025, 6, 7, 12, 0, 0, 0, time.UTC)
+	pr.now = func() time.Time { return now }
+
+	polKey := model.PolicyKey{
+		Tier: "default",
+		Name: "test-policy",
+	}
+	activeFrom := metav1.NewTime(now.Add(time.Minute))
+	activeUntil := metav1.NewTime(now.Add(time.Hour))
+	policy := &model.Policy{ActiveFrom: &activeFrom, ActiveUntil: &activeUntil}
+	pol := ExtractPolicyMetadata(policy)
+
+	endpointKey := model.WorkloadEndpointKey{
+		Hostname: "test-workload-ep",
+	}
+	wep := &model.WorkloadEndpoint{
+		Name: "we1",
+	}
+	pr.OnUpdate(api.Update{KVPair: model.KVPair{Key: endpointKey, Value: wep}})
+	pr.OnUpdate(api.Update{KVPair: model.KVPair{Key: polKey, Value: policy}})
+	pr.OnPolicyMatch(polKey, endpointKey)
+
+	expectTiers := func(expected []TierInfo) {
+		t.Helper()
+		pr.Flush()
+		if len(recorder.updates) != 1 {
+			t.Fatal("Expected one update after Flush:", recorder.updates)
+		}
+		if d := cmp.Diff(recorder.updates[0], policyResolverUpdate{
+			Key:      endpointKey,
+			Endpoint: wep,
+			Tiers:    expected,
+		},
+			cmp.AllowUnexported(PolKV{}),
+			cmp.Comparer(func(a, b uniquelabels.Map) bool { return a.Equals(b) }),
+		); d != "" {
+			t.Error("Incorrect update:", d)
+		}
+		recorder.updates = nil
+	}
+
+	// Before ActiveFrom, the policy is left out, as if it didn't exist.
+	expectTiers([]TierInfo{})
+	if pr.OnPolicyScheduleTick() {
+		t.Error("Expected no change before the policy becomes active")
+	}
+
+	now = activeFrom.Time
+	if !pr.OnPolicyScheduleTick() {
+		t.Error("Expected a change when the policy becomes active")
+	}
+	expectTiers([]TierInfo{{
+		Name:            "default",
+		Valid:           true,
+		OrderedPolicies: []PolKV{{Key: polKey, Value: &pol}},
+	}})
+
+	now = activeUntil.Time
+	if !pr.OnPolicyScheduleTick() {
+		t.Error("Expected a change when the policy expires")
+	}
+	expectTiers([]TierInfo{})
+
+	// Removing the schedule makes the policy active again.
+	pr.OnUpdate(api.Update{KVPair: model.KVPair{Key: polKey, Value: &model.Policy{}}})
+	if len(pr.policySchedules) != 0 {
+		t.Error("Expected schedule to be removed")
+	}
+	expectTiers([]TierInfo{{
+		Name:            "default",
+		Valid:           true,
+		OrderedPolicies: []PolKV{{Key: polKey, Value: &pol}},
+	}})
+}
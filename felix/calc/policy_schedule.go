@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+const policyScheduleTimeOfDayLayout = "15:04"
+
+// policySchedule is the parsed form of a policy's ActiveFrom, ActiveUntil and ActiveSchedule
+// fields, which limit when the policy is enforced.
+type policySchedule struct {
+	from  *time.Time
+	until *time.Time
+	// scheduled is true if the policy has an ActiveSchedule, in which case it is only enforced
+	// within one of the windows.  Windows that are invalid are dropped, so if all of them are
+	// invalid the policy is never enforced.
+	scheduled bool
+	windows   []policyScheduleWindow
+}
+
+// policyScheduleWindow is a recurring window of time, which starts at the same time of day on
+// each of its days.
+type policyScheduleWindow struct {
+	// days is a bitmap of the days of the week that the window starts on, indexed by
+	// time.Weekday.  Zero means that the window starts every day.
+	days uint8
+	// start and end are the offsets from midnight at which the window starts and ends.  If
+	// end isn't after start, the window ends the following day.
+	start, end time.Duration
+	loc        *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// newPolicySchedule parses the schedule of the given policy.  It returns nil if the policy is
+// always enforced.
+func newPolicySchedule(key model.PolicyKey, policy *model.Policy) *policySchedule {
+	if policy.ActiveFrom == nil && policy.ActiveUntil == nil && len(policy.ActiveSchedule) == 0 {
+		return nil
+	}
+	logCtx := log.WithField("policy", key)
+	s := &policySchedule{scheduled: len(policy.ActiveSchedule) > 0}
+	if policy.ActiveFrom != nil {
+		s.from = &policy.ActiveFrom.Time
+	}
+	if policy.ActiveUntil != nil {
+		s.until = &policy.ActiveUntil.Time
+	}
+	for _, w := range policy.ActiveSchedule {
+		start, err := time.Parse(policyScheduleTimeOfDayLayout, w.StartTime)
+		if err != nil {
+			logCtx.WithError(err).Warn("Ignoring policy schedule window with invalid start time")
+			continue
+		}
+		end, err := time.Parse(policyScheduleTimeOfDayLayout, w.EndTime)
+		if err != nil {
+			logCtx.WithError(err).Warn("Ignoring policy schedule window with invalid end time")
+			continue
+		}
+		window := policyScheduleWindow{
+			start: sinceMidnight(start),
+			end:   sinceMidnight(end),
+			loc:   time.UTC,
+		}
+		if w.TimeZone != "" {
+			loc, err := time.LoadLocation(w.TimeZone)
+			if err != nil {
+				logCtx.WithError(err).Warn("Ignoring policy schedule window with unknown time zone")
+				continue
+			}
+			window.loc = loc
+		}
+		for _, d := range w.Days {
+			day, ok := weekdays[d]
+			if !ok {
+				logCtx.WithField("day", d).Warn("Ignoring unknown day in policy schedule")
+				continue
+			}
+			window.days |= 1 << day
+		}
+		if len(w.Days) > 0 && window.days == 0 {
+			// Don't let a window with only unknown days start every day.
+			logCtx.Warn("Ignoring policy schedule window with no valid days")
+			continue
+		}
+		s.windows = append(s.windows, window)
+	}
+	return s
+}
+
+// IsActive returns true if the policy should be enforced at the given time.
+func (s *policySchedule) IsActive(now time.Time) bool {
+	if s.from != nil && now.Before(*s.from) {
+		return false
+	}
+	if s.until != nil && !now.Before(*s.until) {
+		return false
+	}
+	if !s.scheduled {
+		return true
+	}
+	for _, w := range s.windows {
+		if w.contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w policyScheduleWindow) contains(now time.Time) bool {
+	local := now.In(w.loc)
+	offset := sinceMidnight(local)
+	today := local.Weekday()
+	if w.end > w.start {
+		return w.startsOn(today) && offset >= w.start && offset < w.end
+	}
+	// The window runs past midnight, so we may be in the part of today's window after its
+	// start, or the part of yesterday's window before its end.
+	yesterday := (today + 6) % 7
+	return (w.startsOn(today) && offset >= w.start) || (w.startsOn(yesterday) && offset < w.end)
+}
+
+func (w policyScheduleWindow) startsOn(day time.Weekday) bool {
+	return w.days == 0 || w.days&(1<<day) != 0
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+}
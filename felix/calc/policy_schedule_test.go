@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"testing"
+	"time"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+func TestPolicySchedule_IsActive(t *testing.T) {
+	// 2025-06-07 is a Saturday.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, 6, day, hour, min, 0, 0, time.UTC)
+	}
+	from := metav1.NewTime(at(7, 12, 0))
+	until := metav1.NewTime(at(8, 12, 0))
+
+	for _, tc := range []struct {
+		name   string
+		policy model.Policy
+		now    time.Time
+		active bool
+	}{
+		{"before ActiveFrom", model.Policy{ActiveFrom: &from}, at(7, 11, 59), false},
+		{"at ActiveFrom", model.Policy{ActiveFrom: &from}, at(7, 12, 0), true},
+		{"before ActiveUntil", model.Policy{ActiveUntil: &until}, at(8, 11, 59), true},
+		{"at ActiveUntil", model.Policy{ActiveUntil: &until}, at(8, 12, 0), false},
+		{"between ActiveFrom and ActiveUntil", model.Policy{ActiveFrom: &from, ActiveUntil: &until}, at(8, 0, 0), true},
+		{
+			"inside a daily window",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{StartTime: "09:00", EndTime: "17:00"}}},
+			at(9, 9, 0), true,
+		},
+		{
+			"after a daily window",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{StartTime: "09:00", EndTime: "17:00"}}},
+			at(9, 17, 0), false,
+		},
+		{
+			"inside a window on one of its days",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{Days: []string{"Saturday"}, StartTime: "09:00", EndTime: "17:00"}}},
+			at(7, 10, 0), true,
+		},
+		{
+			"inside a window's hours on another day",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{Days: []string{"Saturday"}, StartTime: "09:00", EndTime: "17:00"}}},
+			at(8, 10, 0), false,
+		},
+		{
+			"after midnight in a window that started the day before",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{Days: []string{"Saturday"}, StartTime: "22:00", EndTime: "02:00"}}},
+			at(8, 1, 0), true,
+		},
+		{
+			"after midnight in a window that didn't start the day before",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{Days: []string{"Saturday"}, StartTime: "22:00", EndTime: "02:00"}}},
+			at(7, 1, 0), false,
+		},
+		{
+			"inside a window in another time zone",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{StartTime: "09:00", EndTime: "10:00", TimeZone: "Asia/Tokyo"}}},
+			at(7, 0, 30), true,
+		},
+		{
+			"inside a window in an unknown time zone",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{StartTime: "09:00", EndTime: "17:00", TimeZone: "Mars/Olympus_Mons"}}},
+			at(9, 10, 0), false,
+		},
+		{
+			"inside a window with only unknown days",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{{Days: []string{"Caturday"}, StartTime: "09:00", EndTime: "17:00"}}},
+			at(9, 10, 0), false,
+		},
+		{
+			"inside a valid window alongside an invalid one",
+			model.Policy{ActiveSchedule: []apiv3.PolicyScheduleWindow{
+				{StartTime: "9am", EndTime: "17:00"},
+				{StartTime: "09:00", EndTime: "17:00"},
+			}},
+			at(9, 10, 0), true,
+		},
+		{
+			"inside a window but after ActiveUntil",
+			model.Policy{ActiveUntil: &until, ActiveSchedule: []apiv3.PolicyScheduleWindow{{StartTime: "09:00", EndTime: "17:00"}}},
+			at(9, 10, 0), false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newPolicySchedule(model.PolicyKey{Name: "pol"}, &tc.policy)
+			if s == nil {
+				t.Fatal("Expected a schedule")
+			}
+			if active := s.IsActive(tc.now); active != tc.active {
+				t.Errorf("Expected IsActive(%v) to be %v", tc.now, tc.active)
+			}
+		})
+	}
+
+	if s := newPolicySchedule(model.PolicyKey{Name: "pol"}, &model.Policy{}); s != nil {
+		t.Error("Expected no schedule for a policy without one")
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/dispatcher"
+	"github.com/projectcalico/calico/felix/labelindex"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	sel "github.com/projectcalico/calico/libcalico-go/lib/selector"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector/parser"
+)
+
+// defaultQoSBurst is the burst applied along with a bandwidth limit that doesn't specify one.  It
+// matches the default for the qos.projectcalico.org burst annotations.
+const defaultQoSBurst = 4294967296
+
+// QoSPolicyCalculator matches QoSPolicies against local endpoints and determines which QoSPolicy
+// applies to each endpoint.  It calls the PolicyResolver to tell it to apply that QoSPolicy's
+// controls to the WorkloadEndpoint data that is passed to the dataplane.
+type QoSPolicyCalculator struct {
+	// All QoS policies.
+	allQoSPoliciesByName map[string]*v3.QoSPolicy
+
+	// Label index, matching QoSPolicies against local endpoints.
+	labelIndex *labelindex.InheritIndex
+
+	// Names of the QoSPolicies that match each endpoint.
+	policiesByWorkloadID map[model.WorkloadEndpointKey][]string
+
+	// The QoS policy that we last sent for each endpoint.
+	activeByWorkloadID map[model.WorkloadEndpointKey]*EndpointQoSPolicy
+
+	// Callbacks.
+	OnEndpointQoSPolicyUpdate func(id model.WorkloadEndpointKey, qosPolicy *EndpointQoSPolicy)
+}
+
+// EndpointQoSPolicy is the QoS policy that applies to an active local endpoint.
+type EndpointQoSPolicy struct {
+	// Name of the V3 QoSPolicy resource.
+	v3PolicyName string
+
+	controls              v3.QoSPolicyControls
+	podAnnotationOverride v3.QoSPodAnnotationOverride
+}
+
+func NewQoSPolicyCalculator() *QoSPolicyCalculator {
+	qpc := &QoSPolicyCalculator{
+		allQoSPoliciesByName: map[string]*v3.QoSPolicy{},
+		policiesByWorkloadID: map[model.WorkloadEndpointKey][]string{},
+		activeByWorkloadID:   map[model.WorkloadEndpointKey]*EndpointQoSPolicy{},
+	}
+	qpc.labelIndex = labelindex.NewInheritIndex(qpc.onPolicyEndpointMatchStarted, qpc.onPolicyEndpointMatchStopped)
+	return qpc
+}
+
+func (qpc *QoSPolicyCalculator) RegisterWith(localEndpointDispatcher, allUpdDispatcher *dispatcher.Dispatcher) {
+	// It needs local workload endpoints.
+	localEndpointDispatcher.Register(model.WorkloadEndpointKey{}, qpc.OnUpdate)
+	// It also needs Profiles, for the namespace labels, and QoSPolicies.
+	allUpdDispatcher.Register(model.ResourceKey{}, qpc.OnUpdate)
+}
+
+func (qpc *QoSPolicyCalculator) OnUpdate(update api.Update) (_ bool) {
+	switch id := update.Key.(type) {
+	case model.WorkloadEndpointKey:
+		// Delegate to the label index.  It will call us back when the match status changes.
+		qpc.labelIndex.OnUpdate(update)
+	case model.ResourceKey:
+		switch id.Kind {
+		case v3.KindQoSPolicy:
+			if update.Value != nil {
+				logrus.WithField("name", id.Name).Debug("Updating QoSPolicy")
+				qosPolicy := update.Value.(*v3.QoSPolicy)
+				qpc.allQoSPoliciesByName[id.Name] = qosPolicy
+				// May trigger callbacks to onPolicyEndpointMatchStarted/onPolicyEndpointMatchStopped.
+				qpc.labelIndex.UpdateSelector(id.Name, qosPolicySelector(qosPolicy))
+			} else {
+				logrus.WithField("name", id.Name).Debug("Deleting QoSPolicy")
+				delete(qpc.allQoSPoliciesByName, id.Name)
+				qpc.labelIndex.DeleteSelector(id.Name)
+			}
+			// The order or controls of the policy may have changed without a change to the
+			// endpoints that it matches, so recheck the endpoints that it applies to.
+			for workloadID, names := range qpc.policiesByWorkloadID {
+				for _, name := range names {
+					if name == id.Name {
+						qpc.recalculate(workloadID)
+						break
+					}
+				}
+			}
+		case v3.KindProfile:
+			qpc.labelIndex.OnUpdate(update)
+		default:
+			// Ignore other kinds of v3 resource.
+		}
+	default:
+		logrus.Infof("Ignoring unexpected update: %v %#v",
+			reflect.TypeOf(update.Key), update)
+	}
+
+	return
+}
+
+// qosPolicySelector returns the selector for the endpoints that a QoSPolicy applies to, combining
+// its workload and namespace selectors.  Namespace labels are inherited by endpoints from their
+// namespace's profile, with a prefix.
+func qosPolicySelector(qosPolicy *v3.QoSPolicy) *sel.Selector {
+	rawSelector := qosPolicy.Spec.Selector
+	if rawSelector == "" {
+		rawSelector = "all()"
+	}
+	if qosPolicy.Spec.NamespaceSelector != "" {
+		nsSelector, err := parser.Parse(qosPolicy.Spec.NamespaceSelector)
+		if err != nil {
+			logrus.WithError(err).Errorf("QoSPolicy had invalid namespace selector: %q.  Will ignore this QoSPolicy.",
+				qosPolicy.Spec.NamespaceSelector)
+			return sel.NoMatch
+		}
+		nsSelector.AcceptVisitor(parser.PrefixVisitor{Prefix: conversion.NamespaceLabelPrefix})
+		rawSelector = fmt.Sprintf("(%s) && (%s)", rawSelector, nsSelector.String())
+	}
+
+	selector, err := sel.Parse(rawSelector)
+	if err != nil {
+		logrus.WithError(err).Errorf("QoSPolicy had invalid selector: %q.  Will ignore this QoSPolicy.", rawSelector)
+		return sel.NoMatch
+	}
+	return selector
+}
+
+func (qpc *QoSPolicyCalculator) onPolicyEndpointMatchStarted(policyNameIface any, workloadIDIface any) {
+	policyName := policyNameIface.(string)
+	workloadID := workloadIDIface.(model.WorkloadEndpointKey)
+	qpc.policiesByWorkloadID[workloadID] = append(qpc.policiesByWorkloadID[workloadID], policyName)
+	qpc.recalculate(workloadID)
+}
+
+func (qpc *QoSPolicyCalculator) onPolicyEndpointMatchStopped(policyNameIface any, workloadIDIface any) {
+	policyName := policyNameIface.(string)
+	workloadID := workloadIDIface.(model.WorkloadEndpointKey)
+
+	policies := qpc.policiesByWorkloadID[workloadID][:0]
+	for _, name := range qpc.policiesByWorkloadID[workloadID] {
+		if name == policyName {
+			continue
+		}
+		policies = append(policies, name)
+	}
+	if len(policies) == 0 {
+		delete(qpc.policiesByWorkloadID, workloadID)
+	} else {
+		qpc.policiesByWorkloadID[workloadID] = policies
+	}
+	qpc.recalculate(workloadID)
+}
+
+// recalculate works out which QoSPolicy applies to the given endpoint, and sends an update if
+// that, or the content of that QoSPolicy, has changed.
+func (qpc *QoSPolicyCalculator) recalculate(workloadID model.WorkloadEndpointKey) {
+	var newActive *EndpointQoSPolicy
+	if name := qpc.calculateActivePolicy(workloadID); name != "" {
+		qosPolicy := qpc.allQoSPoliciesByName[name]
+		newActive = &EndpointQoSPolicy{
+			v3PolicyName:          name,
+			controls:              qosPolicy.Spec.Controls,
+			podAnnotationOverride: qosPolicy.Spec.PodAnnotationOverride,
+		}
+	}
+	if reflect.DeepEqual(qpc.activeByWorkloadID[workloadID], newActive) {
+		return
+	}
+	if newActive == nil {
+		delete(qpc.activeByWorkloadID, workloadID)
+	} else {
+		qpc.activeByWorkloadID[workloadID] = newActive
+	}
+	qpc.OnEndpointQoSPolicyUpdate(workloadID, newActive)
+}
+
+// calculateActivePolicy returns the name of the QoSPolicy that comes first in order out of those
+// that match the given endpoint, or "" if there are none.
+func (qpc *QoSPolicyCalculator) calculateActivePolicy(id model.WorkloadEndpointKey) string {
+	names := qpc.policiesByWorkloadID[id]
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Slice(names, func(i, j int) bool {
+		oi := qpc.allQoSPoliciesByName[names[i]].Spec.Order
+		oj := qpc.allQoSPoliciesByName[names[j]].Spec.Order
+		if (oi == nil) != (oj == nil) {
+			// Policies without an order come last.
+			return oj == nil
+		}
+		if oi != nil && *oi != *oj {
+			return *oi < *oj
+		}
+		return names[i] < names[j]
+	})
+	return names[0]
+}
+
+// applyTo returns a copy of the given endpoint with the QoS controls of the QoS policy combined
+// with those from the endpoint's annotations, according to the QoS policy's PodAnnotationOverride.
+func (e *EndpointQoSPolicy) applyTo(wep *model.WorkloadEndpoint) *model.WorkloadEndpoint {
+	var annotationControls model.QoSControls
+	if wep.QoSControls != nil {
+		annotationControls = *wep.QoSControls
+	}
+	c := &e.controls
+	a := &annotationControls
+	var merged model.QoSControls
+
+	merged.IngressBandwidth, merged.IngressBurst = e.mergeBandwidth(
+		c.IngressBandwidth, c.IngressBurst, a.IngressBandwidth, a.IngressBurst)
+	merged.EgressBandwidth, merged.EgressBurst = e.mergeBandwidth(
+		c.EgressBandwidth, c.EgressBurst, a.EgressBandwidth, a.EgressBurst)
+	merged.IngressPacketRate = e.mergeLimit(c.IngressPacketRate, a.IngressPacketRate)
+	merged.EgressPacketRate = e.mergeLimit(c.EgressPacketRate, a.EgressPacketRate)
+	merged.IngressMaxConnections = e.mergeLimit(c.IngressMaxConnections, a.IngressMaxConnections)
+	merged.EgressMaxConnections = e.mergeLimit(c.EgressMaxConnections, a.EgressMaxConnections)
+
+	wepCopy := *wep
+	if merged == (model.QoSControls{}) {
+		wepCopy.QoSControls = nil
+	} else {
+		wepCopy.QoSControls = &merged
+	}
+	return &wepCopy
+}
+
+// mergeLimit returns the limit to apply given the QoS policy's limit (nil if unset) and the
+// annotation's limit (0 if unset).
+func (e *EndpointQoSPolicy) mergeLimit(policyLimit *int64, annotationLimit int64) int64 {
+	if policyLimit == nil {
+		return annotationLimit
+	}
+	if annotationLimit == 0 {
+		return *policyLimit
+	}
+	switch e.podAnnotationOverride {
+	case v3.QoSPodAnnotationOverrideDeny:
+		return *policyLimit
+	case v3.QoSPodAnnotationOverrideStricterOnly:
+		return min(*policyLimit, annotationLimit)
+	default:
+		return annotationLimit
+	}
+}
+
+// mergeBandwidth is like mergeLimit but for a bandwidth and its burst, which are taken together
+// from the QoS policy or the annotations.
+func (e *EndpointQoSPolicy) mergeBandwidth(policyBandwidth, policyBurst *int64, annotationBandwidth, annotationBurst int64) (int64, int64) {
+	if policyBandwidth == nil {
+		return annotationBandwidth, annotationBurst
+	}
+	if e.mergeLimit(policyBandwidth, annotationBandwidth) != *policyBandwidth {
+		return annotationBandwidth, annotationBurst
+	}
+	if policyBurst == nil {
+		return *policyBandwidth, defaultQoSBurst
+	}
+	return *policyBandwidth, *policyBurst
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcalico/calico/lib/std/uniquelabels"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+var _ = Describe("QoSPolicyCalculator", func() {
+	var qpc *QoSPolicyCalculator
+	var result map[string]*EndpointQoSPolicy
+	var numUpdates int
+
+	addWorkload := func(name, namespace string, labels map[string]string) {
+		qpc.OnUpdate(api.Update{
+			KVPair: model.KVPair{
+				Key: model.WorkloadEndpointKey{Hostname: "my-host", WorkloadID: name},
+				Value: &model.WorkloadEndpoint{
+					Name:       name,
+					Labels:     uniquelabels.Make(labels),
+					ProfileIDs: []string{"kns." + namespace},
+				},
+			},
+		})
+	}
+	addNamespace := func(name string, labels map[string]string) {
+		qpc.OnUpdate(api.Update{
+			KVPair: model.KVPair{
+				Key: model.ResourceKey{Kind: v3.KindProfile, Name: "kns." + name},
+				Value: &v3.Profile{
+					ObjectMeta: metav1.ObjectMeta{Name: "kns." + name},
+					Spec:       v3.ProfileSpec{LabelsToApply: labels},
+				},
+			},
+		})
+	}
+	updatePolicy := func(name string, spec *v3.QoSPolicySpec) {
+		update := api.Update{KVPair: model.KVPair{Key: model.ResourceKey{Kind: v3.KindQoSPolicy, Name: name}}}
+		if spec != nil {
+			update.Value = &v3.QoSPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: *spec}
+		}
+		qpc.OnUpdate(update)
+	}
+	policyName := func(workload string) string {
+		if result[workload] == nil {
+			return ""
+		}
+		return result[workload].v3PolicyName
+	}
+
+	bandwidth := int64(1000000)
+	order1, order2 := 1.0, 2.0
+
+	BeforeEach(func() {
+		qpc = NewQoSPolicyCalculator()
+		result = map[string]*EndpointQoSPolicy{}
+		numUpdates = 0
+		qpc.OnEndpointQoSPolicyUpdate = func(id model.WorkloadEndpointKey, qosPolicy *EndpointQoSPolicy) {
+			numUpdates++
+			if qosPolicy != nil {
+				result[id.WorkloadID] = qosPolicy
+			} else {
+				delete(result, id.WorkloadID)
+			}
+		}
+
+		addNamespace("prod", map[string]string{"pcns.env": "prod"})
+		addNamespace("dev", map[string]string{"pcns.env": "dev"})
+		addWorkload("w-web-prod", "prod", map[string]string{"app": "web"})
+		addWorkload("w-web-dev", "dev", map[string]string{"app": "web"})
+		addWorkload("w-db-prod", "prod", map[string]string{"app": "db"})
+	})
+
+	It("should apply a QoS policy to the workloads that it selects", func() {
+		updatePolicy("web", &v3.QoSPolicySpec{
+			Selector: "app == 'web'",
+			Controls: v3.QoSPolicyControls{IngressBandwidth: &bandwidth},
+		})
+		Expect(policyName("w-web-prod")).To(Equal("web"))
+		Expect(policyName("w-web-dev")).To(Equal("web"))
+		Expect(policyName("w-db-prod")).To(Equal(""))
+	})
+
+	It("should apply a QoS policy with an empty selector to all workloads in the selected namespaces", func() {
+		updatePolicy("prod", &v3.QoSPolicySpec{
+			NamespaceSelector: "env == 'prod'",
+			Controls:          v3.QoSPolicyControls{IngressBandwidth: &bandwidth},
+		})
+		Expect(policyName("w-web-prod")).To(Equal("prod"))
+		Expect(policyName("w-web-dev")).To(Equal(""))
+		Expect(policyName("w-db-prod")).To(Equal("prod"))
+	})
+
+	It("should combine the selector and namespace selector", func() {
+		updatePolicy("web-prod", &v3.QoSPolicySpec{
+			Selector:          "app == 'web'",
+			NamespaceSelector: "env == 'prod'",
+			Controls:          v3.QoSPolicyControls{IngressBandwidth: &bandwidth},
+		})
+		Expect(result).To(HaveLen(1))
+		Expect(policyName("w-web-prod")).To(Equal("web-prod"))
+	})
+
+	It("should apply the QoS policy that is first in order", func() {
+		updatePolicy("a-no-order", &v3.QoSPolicySpec{Controls: v3.QoSPolicyControls{IngressBandwidth: &bandwidth}})
+		updatePolicy("b-order-2", &v3.QoSPolicySpec{Order: &order2, Controls: v3.QoSPolicyControls{IngressBandwidth: &bandwidth}})
+		Expect(policyName("w-web-prod")).To(Equal("b-order-2"))
+		updatePolicy("d-order-1", &v3.QoSPolicySpec{Order: &order1, Controls: v3.QoSPolicyControls{IngressBandwidth: &bandwidth}})
+		Expect(policyName("w-web-prod")).To(Equal("d-order-1"))
+		updatePolicy("c-order-1", &v3.QoSPolicySpec{Order: &order1, Controls: v3.QoSPolicyControls{IngressBandwidth: &bandwidth}})
+		Expect(policyName("w-web-prod")).To(Equal("c-order-1"))
+
+		// Removing policies falls back to the next in order.
+		updatePolicy("c-order-1", nil)
+		updatePolicy("d-order-1", nil)
+		Expect(policyName("w-web-prod")).To(Equal("b-order-2"))
+		updatePolicy("b-order-2", nil)
+		Expect(policyName("w-web-prod")).To(Equal("a-no-order"))
+		updatePolicy("a-no-order", nil)
+		Expect(result).To(BeEmpty())
+	})
+
+	It("should send an update when the controls of a QoS policy change", func() {
+		updatePolicy("web", &v3.QoSPolicySpec{
+			Selector: "app == 'web'",
+			Controls: v3.QoSPolicyControls{IngressBandwidth: &bandwidth},
+		})
+		Expect(numUpdates).To(Equal(2))
+
+		// An identical update is a no-op.
+		updatePolicy("web", &v3.QoSPolicySpec{
+			Selector: "app == 'web'",
+			Controls: v3.QoSPolicyControls{IngressBandwidth: &bandwidth},
+		})
+		Expect(numUpdates).To(Equal(2))
+
+		updatePolicy("web", &v3.QoSPolicySpec{
+			Selector: "app == 'web'",
+			Controls: v3.QoSPolicyControls{EgressBandwidth: &bandwidth},
+		})
+		Expect(numUpdates).To(Equal(4))
+		Expect(result["w-web-prod"].controls).To(Equal(v3.QoSPolicyControls{EgressBandwidth: &bandwidth}))
+	})
+
+	It("should update the QoS policy of workloads whose namespace labels change", func() {
+		updatePolicy("prod", &v3.QoSPolicySpec{
+			NamespaceSelector: "env == 'prod'",
+			Controls:          v3.QoSPolicyControls{IngressBandwidth: &bandwidth},
+		})
+		Expect(policyName("w-web-dev")).To(Equal(""))
+		addNamespace("dev", map[string]string{"pcns.env": "prod"})
+		Expect(policyName("w-web-dev")).To(Equal("prod"))
+	})
+})
+
+var _ = DescribeTable("EndpointQoSPolicy.applyTo",
+	func(override v3.QoSPodAnnotationOverride, policy v3.QoSPolicyControls, annotations, expected *model.QoSControls) {
+		wep := &model.WorkloadEndpoint{Name: "w", QoSControls: annotations}
+		qosPolicy := &EndpointQoSPolicy{v3PolicyName: "qos", controls: policy, podAnnotationOverride: override}
+		Expect(qosPolicy.applyTo(wep).QoSControls).To(Equal(expected))
+		// The original endpoint must not be modified.
+		Expect(wep.QoSControls).To(Equal(annotations))
+	},
+	Entry("policy only, with a default burst",
+		v3.QoSPodAnnotationOverrideAllow,
+		v3.QoSPolicyControls{IngressBandwidth: int64Ptr(1000000), EgressPacketRate: int64Ptr(100)},
+		nil,
+		&model.QoSControls{IngressBandwidth: 1000000, IngressBurst: defaultQoSBurst, EgressPacketRate: 100},
+	),
+	Entry("annotations for limits that the policy doesn't set",
+		v3.QoSPodAnnotationOverrideDeny,
+		v3.QoSPolicyControls{IngressBandwidth: int64Ptr(1000000), IngressBurst: int64Ptr(2000000)},
+		&model.QoSControls{EgressMaxConnections: 10},
+		&model.QoSControls{IngressBandwidth: 1000000, IngressBurst: 2000000, EgressMaxConnections: 10},
+	),
+	Entry("Allow, the default, lets annotations override the policy",
+		v3.QoSPodAnnotationOverride(""),
+		v3.QoSPolicyControls{IngressBandwidth: int64Ptr(1000000), IngressPacketRate: int64Ptr(100)},
+		&model.QoSControls{IngressBandwidth: 5000000, IngressBurst: 6000000, IngressPacketRate: 200},
+		&model.QoSControls{IngressBandwidth: 5000000, IngressBurst: 6000000, IngressPacketRate: 200},
+	),
+	Entry("StricterOnly lets annotations override the policy with lower limits",
+		v3.QoSPodAnnotationOverrideStricterOnly,
+		v3.QoSPolicyControls{IngressBandwidth: int64Ptr(1000000), IngressPacketRate: int64Ptr(100), EgressBandwidth: int64Ptr(1000000)},
+		&model.QoSControls{IngressBandwidth: 5000000, IngressBurst: 6000000, IngressPacketRate: 50, EgressBandwidth: 2000, EgressBurst: 3000},
+		&model.QoSControls{IngressBandwidth: 1000000, IngressBurst: defaultQoSBurst, IngressPacketRate: 50, EgressBandwidth: 2000, EgressBurst: 3000},
+	),
+	Entry("Deny ignores annotations for the limits that the policy sets",
+		v3.QoSPodAnnotationOverrideDeny,
+		v3.QoSPolicyControls{IngressBandwidth: int64Ptr(1000000), IngressPacketRate: int64Ptr(100)},
+		&model.QoSControls{IngressBandwidth: 2000, IngressBurst: 3000, IngressPacketRate: 50},
+		&model.QoSControls{IngressBandwidth: 1000000, IngressBurst: defaultQoSBurst, IngressPacketRate: 100},
+	),
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
@@ -164,6 +164,36 @@ func parsedRuleToProtoRule(in *ParsedRule) *proto.Rule {
 		if len(in.HTTPMatch.Methods) > 0 {
 			out.HttpMatch.Methods = in.HTTPMatch.Methods
 		}
+		for _, headerMatch := range in.HTTPMatch.Headers {
+			protoMatch := &proto.HTTPMatch_HeaderMatch{Name: headerMatch.Name}
+			if headerMatch.Exact != "" {
+				protoMatch.HeaderMatch = &proto.HTTPMatch_HeaderMatch_Exact{Exact: headerMatch.Exact}
+			} else if headerMatch.Regex != "" {
+				protoMatch.HeaderMatch = &proto.HTTPMatch_HeaderMatch_Regex{Regex: headerMatch.Regex}
+			} else if headerMatch.Present != nil {
+				protoMatch.HeaderMatch = &proto.HTTPMatch_HeaderMatch_Present{Present: *headerMatch.Present}
+			}
+			out.HttpMatch.Headers = append(out.HttpMatch.Headers, protoMatch)
+		}
+		if len(in.HTTPMatch.Hosts) > 0 {
+			out.HttpMatch.Hosts = in.HTTPMatch.Hosts
+		}
+		for _, grpcMatch := range in.HTTPMatch.GRPCMethods {
+			out.HttpMatch.GrpcMethods = append(out.HttpMatch.GrpcMethods, &proto.HTTPMatch_GRPCMethodMatch{
+				Service: grpcMatch.Service,
+				Method:  grpcMatch.Method,
+			})
+		}
+	}
+
+	if in.DSCP != nil {
+		dscp, err := in.DSCP.NumValue()
+		if err != nil {
+			// Should be prevented by validation.
+			log.WithError(err).WithField("dscp", in.DSCP).Warn("Ignoring invalid DSCP value")
+		} else {
+			out.Dscp = int32(dscp)
+		}
 	}
 
 	if in.Metadata != nil {
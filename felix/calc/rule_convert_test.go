@@ -37,7 +37,10 @@ var icmpCode12 = 12
 var icmpType11 = 11
 var icmpCode13 = 13
 var proto123 = numorstring.ProtocolFromInt(uint8(123))
+var dscpAF41 = numorstring.DSCPFromString("AF41")
+var dscp46 = numorstring.DSCPFromInt(46)
 var protoTCP = numorstring.ProtocolFromStringV1("tcp")
+var headerNotPresent = false
 
 var fullyLoadedParsedRule = ParsedRule{
 	Action:    "allow",
@@ -90,6 +93,13 @@ var fullyLoadedParsedRule = ParsedRule{
 	HTTPMatch: &model.HTTPMatch{Methods: []string{"GET", "POST"}, Paths: []v3.HTTPPath{
 		{Exact: "/foo"},
 		{Prefix: "/bar"},
+	}, Headers: []v3.HTTPHeaderMatch{
+		{Name: "x-version", Exact: "v2"},
+		{Name: "x-user", Regex: "admin-.*"},
+		{Name: "x-debug", Present: &headerNotPresent},
+		{Name: "authorization"},
+	}, Hosts: []string{"*.example.com"}, GRPCMethods: []v3.GRPCMethodMatch{
+		{Service: "helloworld.Greeter", Method: "SayHello"},
 	}},
 
 	Metadata: &model.RuleMetadata{Annotations: map[string]string{"key": "value"}},
@@ -157,6 +167,16 @@ var fullyLoadedProtoRule = &proto.Rule{
 	HttpMatch: &proto.HTTPMatch{Methods: []string{"GET", "POST"},
 		Paths: []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Exact{Exact: "/foo"}},
 			{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/bar"}},
+		},
+		Headers: []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-version", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "v2"}},
+			{Name: "x-user", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Regex{Regex: "admin-.*"}},
+			{Name: "x-debug", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: false}},
+			{Name: "authorization"},
+		},
+		Hosts: []string{"*.example.com"},
+		GrpcMethods: []*proto.HTTPMatch_GRPCMethodMatch{
+			{Service: "helloworld.Greeter", Method: "SayHello"},
 		}},
 
 	Metadata: &proto.RuleMetadata{Annotations: map[string]string{"key": "value"}},
@@ -219,6 +239,12 @@ var _ = DescribeTable("ParsedRulesToProtoRules",
 		&proto.Rule{
 			DstIpPortSetIds: []string{"ipPortSetID"},
 		}),
+	Entry("DSCP class name",
+		ParsedRule{Action: "setdscp", DSCP: &dscpAF41},
+		&proto.Rule{Action: "setdscp", Dscp: 34}),
+	Entry("DSCP number",
+		ParsedRule{Action: "setdscp", DSCP: &dscp46},
+		&proto.Rule{Action: "setdscp", Dscp: 46}),
 	Entry("fully-loaded rule",
 		fullyLoadedParsedRule,
 		fullyLoadedProtoRule),
@@ -16,6 +16,7 @@ package calc
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/projectcalico/api/pkg/lib/numorstring"
@@ -86,6 +87,9 @@ type IPSetData struct {
 	// Type of the ip set to represent for this service. This allows us to create service
 	// IP sets with and without port information.
 	ServiceIncludePorts bool
+	// Domains holds the normalised, sorted domain names that this IP set represents.  The
+	// dataplane populates domain IP sets with the IPs that the domains resolve to.
+	Domains []string
 	// cachedUID holds the calculated unique ID of this IP set, or "" if it hasn't been calculated
 	// yet.
 	cachedUID string
@@ -105,13 +109,19 @@ func (d *IPSetData) String() string {
 	if d.ServiceIncludePorts {
 		parts = append(parts, "serviceIncludePorts=true")
 	}
+	if len(d.Domains) > 0 {
+		parts = append(parts, fmt.Sprintf("domains:%v", d.Domains))
+	}
 	parts = append(parts, fmt.Sprintf("uniqueID:%q", d.UniqueID()))
 	return "IPSetData{" + strings.Join(parts, ", ") + "}"
 }
 
 func (d *IPSetData) UniqueID() string {
 	if d.cachedUID == "" {
-		if d.Service != "" {
+		if len(d.Domains) > 0 {
+			// Domain based IP set.
+			d.cachedUID = hash.MakeUniqueID("d", strings.Join(d.Domains, ","))
+		} else if d.Service != "" {
 			// Service based IP set.
 			if d.ServiceIncludePorts {
 				// Service IP set including its ports
@@ -137,6 +147,9 @@ func (d *IPSetData) UniqueID() string {
 // DataplaneProtocolType returns the dataplane driver protocol type of this IP set.
 // One of the proto.IPSetUpdate_IPSetType constants.
 func (d *IPSetData) DataplaneProtocolType() proto.IPSetUpdate_IPSetType {
+	if len(d.Domains) > 0 {
+		return proto.IPSetUpdate_DOMAIN
+	}
 	if d.NamedPortProtocol != labelindex.ProtocolNone {
 		return proto.IPSetUpdate_IP_AND_PORT
 	}
@@ -354,6 +367,9 @@ type ParsedRule struct {
 	// does not implement the match, but other dataplanes such as Dikastes do.
 	HTTPMatch *model.HTTPMatch
 
+	// DSCP is the value that a setdscp rule sets on matching packets.
+	DSCP *numorstring.DSCP
+
 	Metadata *model.RuleMetadata
 }
 
@@ -429,6 +445,13 @@ func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IP
 		srcSelIPSets = append(srcSelIPSets, &IPSetData{Service: svc, ServiceIncludePorts: false})
 	}
 
+	// Domains are rendered as a single IP set, which the dataplane fills in with the IPs that the
+	// domains resolve to.  Since the dataplane already ANDs together the destination IP sets, that
+	// gives the right semantics.
+	if len(rule.DstDomains) > 0 {
+		dstSelIPSets = append(dstSelIPSets, &IPSetData{Domains: normaliseDomains(rule.DstDomains)})
+	}
+
 	parsedRule = &ParsedRule{
 		Action: rule.Action,
 
@@ -481,6 +504,7 @@ func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IP
 		OriginalDstService:                rule.DstService,
 		OriginalDstServiceNamespace:       rule.DstServiceNamespace,
 		HTTPMatch:                         rule.HTTPMatch,
+		DSCP:                              rule.DSCP,
 
 		// Pass through metadata (used by iptables backend)
 		Metadata: rule.Metadata,
@@ -499,6 +523,18 @@ func ruleToParsedRule(rule *model.Rule) (parsedRule *ParsedRule, allIPSets []*IP
 	return
 }
 
+// normaliseDomains returns a sorted, de-duplicated copy of the given domains, converted to lower case and
+// with any trailing dots removed, so that equivalent rules share an IP set.
+func normaliseDomains(domains []string) []string {
+	unique := set.New[string]()
+	for _, d := range domains {
+		unique.Add(strings.TrimSuffix(strings.ToLower(d), "."))
+	}
+	normalised := unique.Slice()
+	sort.Strings(normalised)
+	return normalised
+}
+
 // Converts a list of named ports to a list of IPSets.
 func namedPortsToIPSets(namedPorts []string, positiveSelectors []*selector.Selector, proto labelindex.IPSetPortProtocol) []*IPSetData {
 	var ipSets []*IPSetData
@@ -162,6 +162,18 @@ var _ = DescribeTable("RuleScanner rule conversion should generate correct Parse
 			OriginalSrcServiceNamespace: "default",
 		}),
 
+	// Domains.
+	Entry("dest domains",
+		model.Rule{DstDomains: []string{"www.Example.com.", "*.example.org", "www.example.com"}},
+		ParsedRule{
+			DstIPSetIDs: []string{"d:gnfzci6XnJ5JQy4O8XogCH2j9RqphY7iZwxs4A"},
+		}),
+	Entry("dest domains with a selector",
+		model.Rule{DstSelector: sel1, DstDomains: []string{"*.example.org", "www.example.com"}},
+		ParsedRule{
+			DstIPSetIDs: []string{sel1ID, "d:gnfzci6XnJ5JQy4O8XogCH2j9RqphY7iZwxs4A"},
+		}),
+
 	// Selectors.
 	Entry("source selector", model.Rule{SrcSelector: sel1}, ParsedRule{SrcIPSetIDs: []string{sel1ID}}),
 	Entry("dest selector", model.Rule{DstSelector: sel1}, ParsedRule{DstIPSetIDs: []string{sel1ID}}),
@@ -237,6 +249,10 @@ var _ = Describe("ParsedRule", func() {
 				// as either IPPortIPSetIDs or IPSetIDs.
 				continue
 			}
+			if name == "DstDomains" {
+				// Domains are rendered on the ParsedRule as a domain IP set.
+				continue
+			}
 			if strings.HasSuffix(name, "Net") {
 				// Deprecated XXXNet fields.
 				continue
@@ -317,7 +333,7 @@ func (ur *scanUpdateRecorder) OnProfileInactive(key model.ProfileRulesKey) {
 }
 
 func (ur *scanUpdateRecorder) ipSetActive(ipSet *IPSetData) {
-	if ipSet.Service != "" {
+	if ipSet.Service != "" || len(ipSet.Domains) > 0 {
 		// Not a selector-based set.
 		return
 	}
@@ -325,7 +341,7 @@ func (ur *scanUpdateRecorder) ipSetActive(ipSet *IPSetData) {
 }
 
 func (ur *scanUpdateRecorder) ipSetInactive(ipSet *IPSetData) {
-	if ipSet.Service != "" {
+	if ipSet.Service != "" || len(ipSet.Domains) > 0 {
 		// Not a selector-based set.
 		return
 	}
@@ -15,6 +15,9 @@
 package main
 
 import (
+	"os"
+	_ "time/tzdata" // Embed the time zone database, used for policy schedules, since images may not have one.
+
 	docopt "github.com/docopt/docopt-go"
 	log "github.com/sirupsen/logrus"
 
@@ -26,10 +29,19 @@ const usage = `Felix, the Calico per-host daemon.
 
 Usage:
   calico-felix [options]
+  calico-felix replay [--isolated | --dump] <recording>
 
 Options:
   -c --config-file=<filename>  Config file to load [default: /etc/calico/felix.cfg].
   --version                    Print the version and exit.
+  --isolated                   Replay the recording in a new network namespace, leaving the
+                               host's dataplane untouched.  Not supported for recordings
+                               made in BPF mode.
+  --dump                       Print the contents of the recording instead of replaying it.
+
+Description:
+  The replay command programs the dataplane from a recording that was made by setting
+  DebugCalcGraphRecordingFile, using the configuration in the recording.
 `
 
 // main is the entry point to the calico-felix binary.
@@ -43,6 +55,18 @@ func main() {
 		println(usage)
 		log.Fatalf("Failed to parse usage, exiting: %v", err)
 	}
+	if replay, _ := arguments.Bool("replay"); replay {
+		filename := arguments["<recording>"].(string)
+		if dump, _ := arguments.Bool("--dump"); dump {
+			if err := daemon.DumpRecording(filename, os.Stdout); err != nil {
+				log.Fatalf("Failed to dump recording: %v", err)
+			}
+			return
+		}
+		isolated, _ := arguments.Bool("--isolated")
+		daemon.Replay(filename, isolated)
+		return
+	}
 	configFile := arguments["--config-file"].(string)
 
 	// Execute felix.
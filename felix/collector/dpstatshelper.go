@@ -21,6 +21,7 @@ import (
 	"github.com/projectcalico/calico/felix/calc"
 	"github.com/projectcalico/calico/felix/collector/flowlog"
 	"github.com/projectcalico/calico/felix/collector/goldmane"
+	"github.com/projectcalico/calico/felix/collector/ipfix"
 	"github.com/projectcalico/calico/felix/collector/local"
 	"github.com/projectcalico/calico/felix/collector/types"
 	"github.com/projectcalico/calico/felix/config"
@@ -32,6 +33,7 @@ const (
 	// Log dispatcher names
 	FlowLogsGoldmaneReporterName = "goldmane"
 	FlowLogsLocalReporterName    = "socket"
+	FlowLogsIPFIXReporterName    = "ipfix"
 )
 
 // New creates the required dataplane stats collector, reporters and aggregators.
@@ -82,6 +84,16 @@ func New(
 		dispatchers[FlowLogsLocalReporterName] = nd
 	}
 
+	if configParams.FlowLogsIPFIXCollector != "" {
+		log.Infof("Creating Flow Logs IPFIXReporter with address %v", configParams.FlowLogsIPFIXCollector)
+		dispatchers[FlowLogsIPFIXReporterName] = ipfix.NewReporter(
+			configParams.FlowLogsIPFIXCollector,
+			uint32(configParams.FlowLogsIPFIXObservationDomainID),
+			uint32(configParams.FlowLogsIPFIXEnterpriseNumber),
+			configParams.FlowLogsIPFIXTemplateRefreshInterval,
+		)
+	}
+
 	if len(dispatchers) > 0 {
 		log.Info("Creating Flow Logs Reporter")
 		cw := flowlog.NewReporter(dispatchers, configParams.FlowLogsFlushInterval, healthAggregator)
@@ -116,6 +128,18 @@ func configureFlowAggregation(configParams *config.Config, fr *flowlog.FlowLogRe
 		log.Info("Adding Flow Logs Aggregator (denied) for local socket")
 		fr.AddAggregator(gad, []string{FlowLogsLocalReporterName})
 	}
+	// Set up aggregator for IPFIX reporter. IPFIX records describe individual connections, so we don't
+	// aggregate across 5-tuples.
+	if configParams.FlowLogsIPFIXCollector != "" {
+		log.Info("Creating IPFIX Aggregator for allowed")
+		iaa := defaultFlowAggregator(rules.RuleActionAllow, configParams.FlowLogsCollectorDebugTrace).AggregateOver(flowlog.FlowDefault)
+		log.Info("Adding Flow Logs Aggregator (allowed) for IPFIX")
+		fr.AddAggregator(iaa, []string{FlowLogsIPFIXReporterName})
+		log.Info("Creating IPFIX Aggregator for denied")
+		iad := defaultFlowAggregator(rules.RuleActionDeny, configParams.FlowLogsCollectorDebugTrace).AggregateOver(flowlog.FlowDefault)
+		log.Info("Adding Flow Logs Aggregator (denied) for IPFIX")
+		fr.AddAggregator(iad, []string{FlowLogsIPFIXReporterName})
+	}
 }
 
 func defaultFlowAggregator(forAction rules.RuleAction, traceEnabled bool) *flowlog.Aggregator {
@@ -88,6 +88,12 @@ func NewAggregator() *Aggregator {
 	}
 }
 
+// AggregateOver sets the aggregation level used for new flow log entries.
+func (a *Aggregator) AggregateOver(ak AggregationKind) *Aggregator {
+	a.current = ak
+	return a
+}
+
 func (a *Aggregator) DisplayDebugTraceLogs(b bool) *Aggregator {
 	a.displayDebugTraceLogs = b
 	return a
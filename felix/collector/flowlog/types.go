@@ -118,7 +118,11 @@ func newFlowMetaWithPrefixNameAggregation(mu metric.Update, includeService bool)
 	return f, nil
 }
 
-func NewFlowMeta(mu metric.Update, _ AggregationKind, includeService bool) (FlowMeta, error) {
+func NewFlowMeta(mu metric.Update, kind AggregationKind, includeService bool) (FlowMeta, error) {
+	if kind == FlowDefault {
+		// Keep the full 5-tuple and endpoint names, for consumers that report individual connections.
+		return newFlowMeta(mu, includeService)
+	}
 	return newFlowMetaWithPrefixNameAggregation(mu, includeService)
 }
 
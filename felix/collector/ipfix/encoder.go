@@ -0,0 +1,356 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+)
+
+const (
+	// version is the IPFIX protocol version number, as defined in RFC 7011.
+	version = 10
+
+	messageHeaderLen = 16
+	setHeaderLen     = 4
+
+	templateSetID = 2
+
+	// Template IDs must be 256 or above. We use one template for each IP version, since IPFIX addresses
+	// are fixed size.
+	templateIDIPv4 = 256
+	templateIDIPv6 = 257
+
+	// reversePEN is the enterprise number used for reverse direction information elements in bidirectional
+	// flow records, as defined in RFC 5103.
+	reversePEN = 29305
+
+	enterpriseBit  = 0x8000
+	variableLength = 0xffff
+
+	// defaultMaxMessageSize keeps messages within a typical path MTU, so that they aren't fragmented.
+	defaultMaxMessageSize = 1400
+)
+
+// IANA assigned information element IDs.
+const (
+	ieOctetDeltaCount          = 1
+	iePacketDeltaCount         = 2
+	ieProtocolIdentifier       = 4
+	ieSourceTransportPort      = 7
+	ieSourceIPv4Address        = 8
+	ieDestinationTransportPort = 11
+	ieDestinationIPv4Address   = 12
+	ieSourceIPv6Address        = 27
+	ieDestinationIPv6Address   = 28
+	ieFlowDirection            = 61
+	ieForwardingStatus         = 89
+	ieFlowStartMilliseconds    = 152
+	ieFlowEndMilliseconds      = 153
+)
+
+// Calico-specific information element IDs, scoped to the configured enterprise number. All are
+// variable length strings.
+const (
+	ieCalicoSourceNamespace = iota + 1
+	ieCalicoSourceName
+	ieCalicoSourceType
+	ieCalicoDestinationNamespace
+	ieCalicoDestinationName
+	ieCalicoDestinationType
+	ieCalicoDestinationServiceNamespace
+	ieCalicoDestinationServiceName
+	ieCalicoDestinationServicePortName
+	ieCalicoEnforcedPolicies
+	ieCalicoPendingPolicies
+)
+
+// Values for the flowDirection information element.
+const (
+	flowDirectionIngress = 0
+	flowDirectionEgress  = 1
+)
+
+// Values for the forwardingStatus information element, as defined in RFC 7270.
+const (
+	forwardingStatusForwarded = 0x40
+	forwardingStatusDropped   = 0x80
+)
+
+type fieldSpec struct {
+	id         uint16
+	length     uint16
+	enterprise uint32
+}
+
+// record is a single flow, in a form that can be encoded as an IPFIX data record. The forward direction
+// is from the source to the destination of the flow.
+type record struct {
+	start, end       time.Time
+	src, dst         net.IP
+	srcPort, dstPort uint16
+	proto            uint8
+
+	octets, packets               uint64
+	reverseOctets, reversePackets uint64
+
+	direction        uint8
+	forwardingStatus uint8
+
+	srcNamespace, srcName, srcType string
+	dstNamespace, dstName, dstType string
+
+	dstServiceNamespace, dstServiceName, dstServicePortName string
+
+	enforcedPolicies, pendingPolicies string
+}
+
+// encoder encodes flow records as IPFIX messages for a single observation domain. It is not safe for
+// concurrent use.
+type encoder struct {
+	observationDomainID uint32
+	enterpriseNumber    uint32
+	maxMessageSize      int
+
+	// sequenceNumber is the total number of data records sent in previous messages, modulo 2^32.
+	sequenceNumber uint32
+}
+
+// newEncoder creates an encoder. Calico-specific information elements are only included in records when
+// enterpriseNumber is non-zero, since they must be scoped to a registered Private Enterprise Number.
+func newEncoder(observationDomainID, enterpriseNumber uint32, maxMessageSize int) *encoder {
+	return &encoder{
+		observationDomainID: observationDomainID,
+		enterpriseNumber:    enterpriseNumber,
+		maxMessageSize:      maxMessageSize,
+	}
+}
+
+func (e *encoder) fields(templateID uint16) []fieldSpec {
+	addrID, addrLen := uint16(ieSourceIPv4Address), uint16(net.IPv4len)
+	dstAddrID := uint16(ieDestinationIPv4Address)
+	if templateID == templateIDIPv6 {
+		addrID, addrLen = ieSourceIPv6Address, net.IPv6len
+		dstAddrID = ieDestinationIPv6Address
+	}
+
+	fields := []fieldSpec{
+		{id: ieFlowStartMilliseconds, length: 8},
+		{id: ieFlowEndMilliseconds, length: 8},
+		{id: addrID, length: addrLen},
+		{id: dstAddrID, length: addrLen},
+		{id: ieSourceTransportPort, length: 2},
+		{id: ieDestinationTransportPort, length: 2},
+		{id: ieProtocolIdentifier, length: 1},
+		{id: ieOctetDeltaCount, length: 8},
+		{id: iePacketDeltaCount, length: 8},
+		{id: ieOctetDeltaCount, length: 8, enterprise: reversePEN},
+		{id: iePacketDeltaCount, length: 8, enterprise: reversePEN},
+		{id: ieFlowDirection, length: 1},
+		{id: ieForwardingStatus, length: 1},
+	}
+	if e.enterpriseNumber != 0 {
+		for id := uint16(ieCalicoSourceNamespace); id <= ieCalicoPendingPolicies; id++ {
+			fields = append(fields, fieldSpec{id: id, length: variableLength, enterprise: e.enterpriseNumber})
+		}
+	}
+	return fields
+}
+
+// templateMessage returns a message containing the template set describing all of the records that the
+// encoder produces. Templates must be sent before any data records, and periodically thereafter since
+// UDP transport is unreliable.
+func (e *encoder) templateMessage(now time.Time) []byte {
+	msg := e.appendMessageHeader(nil, now)
+	setStart := len(msg)
+	msg = appendSetHeader(msg, templateSetID)
+	for _, id := range []uint16{templateIDIPv4, templateIDIPv6} {
+		fields := e.fields(id)
+		msg = binary.BigEndian.AppendUint16(msg, id)
+		msg = binary.BigEndian.AppendUint16(msg, uint16(len(fields)))
+		for _, f := range fields {
+			if f.enterprise != 0 {
+				msg = binary.BigEndian.AppendUint16(msg, f.id|enterpriseBit)
+				msg = binary.BigEndian.AppendUint16(msg, f.length)
+				msg = binary.BigEndian.AppendUint32(msg, f.enterprise)
+			} else {
+				msg = binary.BigEndian.AppendUint16(msg, f.id)
+				msg = binary.BigEndian.AppendUint16(msg, f.length)
+			}
+		}
+	}
+	finishSet(msg, setStart)
+	finishMessage(msg)
+	return msg
+}
+
+// dataMessages encodes the given records into as many messages as needed to keep each message within the
+// maximum message size. Consecutive records that use the same template share a data set.
+func (e *encoder) dataMessages(now time.Time, records []*record) [][]byte {
+	var msgs [][]byte
+	var msg []byte
+	var numRecords uint32
+	setStart, setID := 0, uint16(0)
+
+	flush := func() {
+		if msg == nil {
+			return
+		}
+		finishSet(msg, setStart)
+		finishMessage(msg)
+		msgs = append(msgs, msg)
+		e.sequenceNumber += numRecords
+		msg, numRecords = nil, 0
+	}
+
+	for _, r := range records {
+		id := uint16(templateIDIPv4)
+		if r.src.To4() == nil || r.dst.To4() == nil {
+			id = templateIDIPv6
+		}
+		encoded := e.appendRecord(nil, id, r)
+
+		// Start a new message if this record doesn't fit in the current one, allowing for a new set header.
+		if msg != nil && len(msg)+setHeaderLen+len(encoded) > e.maxMessageSize {
+			flush()
+		}
+		if msg == nil {
+			msg = e.appendMessageHeader(nil, now)
+			setID = 0
+		}
+		if id != setID {
+			if setID != 0 {
+				finishSet(msg, setStart)
+			}
+			setStart, setID = len(msg), id
+			msg = appendSetHeader(msg, id)
+		}
+		msg = append(msg, encoded...)
+		numRecords++
+	}
+	flush()
+	return msgs
+}
+
+func (e *encoder) appendRecord(b []byte, templateID uint16, r *record) []byte {
+	b = binary.BigEndian.AppendUint64(b, uint64(r.start.UnixMilli()))
+	b = binary.BigEndian.AppendUint64(b, uint64(r.end.UnixMilli()))
+	if templateID == templateIDIPv4 {
+		b = append(b, r.src.To4()...)
+		b = append(b, r.dst.To4()...)
+	} else {
+		b = append(b, r.src.To16()...)
+		b = append(b, r.dst.To16()...)
+	}
+	b = binary.BigEndian.AppendUint16(b, r.srcPort)
+	b = binary.BigEndian.AppendUint16(b, r.dstPort)
+	b = append(b, r.proto)
+	b = binary.BigEndian.AppendUint64(b, r.octets)
+	b = binary.BigEndian.AppendUint64(b, r.packets)
+	b = binary.BigEndian.AppendUint64(b, r.reverseOctets)
+	b = binary.BigEndian.AppendUint64(b, r.reversePackets)
+	b = append(b, r.direction, r.forwardingStatus)
+
+	if e.enterpriseNumber != 0 {
+		strs := []string{
+			r.srcNamespace, r.srcName, r.srcType,
+			r.dstNamespace, r.dstName, r.dstType,
+			r.dstServiceNamespace, r.dstServiceName, r.dstServicePortName,
+			r.enforcedPolicies, r.pendingPolicies,
+		}
+		// Make sure that the record fits in a message on its own.
+		truncateStrings(strs, e.maxMessageSize-messageHeaderLen-setHeaderLen-len(b))
+		for _, s := range strs {
+			b = appendString(b, s)
+		}
+	}
+	return b
+}
+
+func (e *encoder) appendMessageHeader(b []byte, now time.Time) []byte {
+	b = binary.BigEndian.AppendUint16(b, version)
+	// The length is filled in by finishMessage.
+	b = binary.BigEndian.AppendUint16(b, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(now.Unix()))
+	b = binary.BigEndian.AppendUint32(b, e.sequenceNumber)
+	b = binary.BigEndian.AppendUint32(b, e.observationDomainID)
+	return b
+}
+
+func appendSetHeader(b []byte, id uint16) []byte {
+	b = binary.BigEndian.AppendUint16(b, id)
+	// The length is filled in by finishSet.
+	return binary.BigEndian.AppendUint16(b, 0)
+}
+
+// truncateStrings shortens the longest of the given strings, in place, so that their total encoded length
+// is at most budget. All strings are cut to the same maximum length, the largest that fits, so the short
+// names and types are left intact and the long policy strings share what remains.
+func truncateStrings(strs []string, budget int) {
+	encodedLen := func(limit int) int {
+		total := 0
+		for _, s := range strs {
+			total += encodedStringLen(s[:min(len(s), limit)])
+		}
+		return total
+	}
+	longest := 0
+	for _, s := range strs {
+		longest = max(longest, len(s))
+	}
+	if encodedLen(longest) <= budget {
+		return
+	}
+	// Binary search for the largest limit that fits.
+	lo, hi := 0, longest
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if encodedLen(mid) <= budget {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	for i, s := range strs {
+		strs[i] = s[:min(len(s), lo)]
+	}
+}
+
+func encodedStringLen(s string) int {
+	if len(s) < 255 {
+		return 1 + len(s)
+	}
+	return 3 + len(s)
+}
+
+// appendString appends s using the variable length encoding from RFC 7011 section 7.
+func appendString(b []byte, s string) []byte {
+	if len(s) < 255 {
+		b = append(b, byte(len(s)))
+	} else {
+		b = append(b, 255)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	}
+	return append(b, s...)
+}
+
+func finishSet(msg []byte, setStart int) {
+	binary.BigEndian.PutUint16(msg[setStart+2:], uint16(len(msg)-setStart))
+}
+
+func finishMessage(msg []byte) {
+	binary.BigEndian.PutUint16(msg[2:], uint16(len(msg)))
+}
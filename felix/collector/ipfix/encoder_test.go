@@ -0,0 +1,284 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fieldKey struct {
+	enterprise uint32
+	id         uint16
+}
+
+// decodedMessage is the result of parsing an IPFIX message with a minimal collector implementation.
+type decodedMessage struct {
+	length              int
+	exportTime          uint32
+	sequenceNumber      uint32
+	observationDomainID uint32
+	records             []map[fieldKey][]byte
+}
+
+// decode parses msg, storing any templates in the given map and using them to decode data records.
+func decode(msg []byte, templates map[uint16][]fieldSpec) (*decodedMessage, error) {
+	if len(msg) < messageHeaderLen {
+		return nil, fmt.Errorf("short message")
+	}
+	if v := binary.BigEndian.Uint16(msg); v != version {
+		return nil, fmt.Errorf("bad version %d", v)
+	}
+	d := &decodedMessage{
+		length:              int(binary.BigEndian.Uint16(msg[2:])),
+		exportTime:          binary.BigEndian.Uint32(msg[4:]),
+		sequenceNumber:      binary.BigEndian.Uint32(msg[8:]),
+		observationDomainID: binary.BigEndian.Uint32(msg[12:]),
+	}
+	if d.length != len(msg) {
+		return nil, fmt.Errorf("length %d doesn't match message size %d", d.length, len(msg))
+	}
+
+	for b := msg[messageHeaderLen:]; len(b) > 0; {
+		setID := binary.BigEndian.Uint16(b)
+		setLen := int(binary.BigEndian.Uint16(b[2:]))
+		if setLen < setHeaderLen || setLen > len(b) {
+			return nil, fmt.Errorf("bad set length %d", setLen)
+		}
+		body := b[setHeaderLen:setLen]
+		b = b[setLen:]
+
+		if setID == templateSetID {
+			for len(body) > 0 {
+				id := binary.BigEndian.Uint16(body)
+				count := int(binary.BigEndian.Uint16(body[2:]))
+				body = body[4:]
+				var fields []fieldSpec
+				for range count {
+					f := fieldSpec{id: binary.BigEndian.Uint16(body), length: binary.BigEndian.Uint16(body[2:])}
+					body = body[4:]
+					if f.id&enterpriseBit != 0 {
+						f.id &^= enterpriseBit
+						f.enterprise = binary.BigEndian.Uint32(body)
+						body = body[4:]
+					}
+					fields = append(fields, f)
+				}
+				templates[id] = fields
+			}
+			continue
+		}
+
+		fields, ok := templates[setID]
+		if !ok {
+			return nil, fmt.Errorf("no template for set %d", setID)
+		}
+		for len(body) > 0 {
+			rec := map[fieldKey][]byte{}
+			for _, f := range fields {
+				l := int(f.length)
+				if f.length == variableLength {
+					l = int(body[0])
+					body = body[1:]
+					if l == 255 {
+						l = int(binary.BigEndian.Uint16(body))
+						body = body[2:]
+					}
+				}
+				rec[fieldKey{f.enterprise, f.id}] = body[:l]
+				body = body[l:]
+			}
+			d.records = append(d.records, rec)
+		}
+	}
+	return d, nil
+}
+
+func testRecord(src, dst string) *record {
+	return &record{
+		start:            time.UnixMilli(1000),
+		end:              time.UnixMilli(6000),
+		src:              net.ParseIP(src),
+		dst:              net.ParseIP(dst),
+		srcPort:          40000,
+		dstPort:          443,
+		proto:            6,
+		octets:           100,
+		packets:          2,
+		reverseOctets:    200,
+		reversePackets:   3,
+		direction:        flowDirectionEgress,
+		forwardingStatus: forwardingStatusForwarded,
+		srcNamespace:     "ns1",
+		srcName:          "client",
+		srcType:          "wep",
+		dstNamespace:     "ns2",
+		dstName:          "server",
+		dstType:          "wep",
+		enforcedPolicies: "0|default|ns2/default.allow|allow|0",
+	}
+}
+
+var _ = Describe("IPFIX encoder", func() {
+	const pen = 12345
+	var (
+		e         *encoder
+		templates map[uint16][]fieldSpec
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		e = newEncoder(7, pen, defaultMaxMessageSize)
+		templates = map[uint16][]fieldSpec{}
+		now = time.Unix(1700000000, 0)
+	})
+
+	It("should encode templates for both IP versions", func() {
+		d, err := decode(e.templateMessage(now), templates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.observationDomainID).To(Equal(uint32(7)))
+		Expect(d.exportTime).To(Equal(uint32(1700000000)))
+		Expect(d.records).To(BeEmpty())
+
+		Expect(templates).To(HaveKey(uint16(templateIDIPv4)))
+		Expect(templates).To(HaveKey(uint16(templateIDIPv6)))
+		Expect(templates[templateIDIPv4]).To(ContainElement(fieldSpec{id: ieSourceIPv4Address, length: 4}))
+		Expect(templates[templateIDIPv6]).To(ContainElement(fieldSpec{id: ieSourceIPv6Address, length: 16}))
+		Expect(templates[templateIDIPv4]).To(ContainElement(fieldSpec{id: ieOctetDeltaCount, length: 8, enterprise: reversePEN}))
+		Expect(templates[templateIDIPv4]).To(ContainElement(fieldSpec{id: ieCalicoEnforcedPolicies, length: variableLength, enterprise: pen}))
+	})
+
+	It("should omit Calico information elements without an enterprise number", func() {
+		e = newEncoder(7, 0, defaultMaxMessageSize)
+		_, err := decode(e.templateMessage(now), templates)
+		Expect(err).NotTo(HaveOccurred())
+		for _, f := range templates[templateIDIPv4] {
+			Expect(f.enterprise).NotTo(Equal(uint32(pen)))
+		}
+
+		d, err := decode(e.dataMessages(now, []*record{testRecord("10.0.0.1", "10.0.0.2")})[0], templates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.records).To(HaveLen(1))
+		Expect(d.records[0]).NotTo(HaveKey(fieldKey{pen, ieCalicoSourceName}))
+	})
+
+	It("should encode IPv4 and IPv6 records using the right templates", func() {
+		_, err := decode(e.templateMessage(now), templates)
+		Expect(err).NotTo(HaveOccurred())
+
+		msgs := e.dataMessages(now, []*record{
+			testRecord("10.0.0.1", "10.0.0.2"),
+			testRecord("fd00::1", "fd00::2"),
+		})
+		Expect(msgs).To(HaveLen(1))
+		d, err := decode(msgs[0], templates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.sequenceNumber).To(Equal(uint32(0)))
+		Expect(d.records).To(HaveLen(2))
+
+		v4 := d.records[0]
+		Expect(net.IP(v4[fieldKey{0, ieSourceIPv4Address}]).String()).To(Equal("10.0.0.1"))
+		Expect(net.IP(v4[fieldKey{0, ieDestinationIPv4Address}]).String()).To(Equal("10.0.0.2"))
+		Expect(binary.BigEndian.Uint16(v4[fieldKey{0, ieDestinationTransportPort}])).To(Equal(uint16(443)))
+		Expect(v4[fieldKey{0, ieProtocolIdentifier}]).To(Equal([]byte{6}))
+		Expect(binary.BigEndian.Uint64(v4[fieldKey{0, ieFlowStartMilliseconds}])).To(Equal(uint64(1000)))
+		Expect(binary.BigEndian.Uint64(v4[fieldKey{0, ieFlowEndMilliseconds}])).To(Equal(uint64(6000)))
+		Expect(binary.BigEndian.Uint64(v4[fieldKey{0, ieOctetDeltaCount}])).To(Equal(uint64(100)))
+		Expect(binary.BigEndian.Uint64(v4[fieldKey{reversePEN, ieOctetDeltaCount}])).To(Equal(uint64(200)))
+		Expect(binary.BigEndian.Uint64(v4[fieldKey{reversePEN, iePacketDeltaCount}])).To(Equal(uint64(3)))
+		Expect(v4[fieldKey{0, ieFlowDirection}]).To(Equal([]byte{flowDirectionEgress}))
+		Expect(v4[fieldKey{0, ieForwardingStatus}]).To(Equal([]byte{forwardingStatusForwarded}))
+		Expect(string(v4[fieldKey{pen, ieCalicoSourceName}])).To(Equal("client"))
+		Expect(string(v4[fieldKey{pen, ieCalicoDestinationNamespace}])).To(Equal("ns2"))
+		Expect(string(v4[fieldKey{pen, ieCalicoEnforcedPolicies}])).To(Equal("0|default|ns2/default.allow|allow|0"))
+		Expect(v4[fieldKey{pen, ieCalicoPendingPolicies}]).To(BeEmpty())
+
+		v6 := d.records[1]
+		Expect(net.IP(v6[fieldKey{0, ieSourceIPv6Address}]).String()).To(Equal("fd00::1"))
+		Expect(net.IP(v6[fieldKey{0, ieDestinationIPv6Address}]).String()).To(Equal("fd00::2"))
+
+		// The sequence number of the next message counts the records already sent.
+		d, err = decode(e.dataMessages(now, []*record{testRecord("10.0.0.1", "10.0.0.2")})[0], templates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.sequenceNumber).To(Equal(uint32(2)))
+	})
+
+	It("should encode long strings using the three byte length encoding", func() {
+		_, err := decode(e.templateMessage(now), templates)
+		Expect(err).NotTo(HaveOccurred())
+
+		r := testRecord("10.0.0.1", "10.0.0.2")
+		r.enforcedPolicies = strings.Repeat("p", 300)
+		r.pendingPolicies = strings.Repeat("q", 400)
+		msgs := e.dataMessages(now, []*record{r})
+		Expect(msgs).To(HaveLen(1))
+		d, err := decode(msgs[0], templates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(d.records[0][fieldKey{pen, ieCalicoEnforcedPolicies}])).To(Equal(r.enforcedPolicies))
+		Expect(string(d.records[0][fieldKey{pen, ieCalicoPendingPolicies}])).To(Equal(r.pendingPolicies))
+	})
+
+	It("should truncate the longest strings so that a record fits within the maximum message size", func() {
+		_, err := decode(e.templateMessage(now), templates)
+		Expect(err).NotTo(HaveOccurred())
+
+		r := testRecord("fd00::1", "fd00::2")
+		r.enforcedPolicies = strings.Repeat("p", 5000)
+		r.pendingPolicies = strings.Repeat("q", 60000)
+		msgs := e.dataMessages(now, []*record{r, testRecord("10.0.0.1", "10.0.0.2")})
+		Expect(msgs).To(HaveLen(2))
+		Expect(len(msgs[0])).To(Equal(defaultMaxMessageSize))
+
+		d, err := decode(msgs[0], templates)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.records).To(HaveLen(1))
+		rec := d.records[0]
+		Expect(string(rec[fieldKey{pen, ieCalicoSourceName}])).To(Equal("client"))
+		Expect(string(rec[fieldKey{pen, ieCalicoDestinationNamespace}])).To(Equal("ns2"))
+		enforced := string(rec[fieldKey{pen, ieCalicoEnforcedPolicies}])
+		pending := string(rec[fieldKey{pen, ieCalicoPendingPolicies}])
+		Expect(enforced).To(HavePrefix("ppp"))
+		Expect(pending).To(HavePrefix("qqq"))
+		Expect(len(enforced) + len(pending)).To(BeNumerically("<", defaultMaxMessageSize))
+	})
+
+	It("should split records across messages to respect the maximum message size", func() {
+		_, err := decode(e.templateMessage(now), templates)
+		Expect(err).NotTo(HaveOccurred())
+
+		var records []*record
+		for range 50 {
+			records = append(records, testRecord("10.0.0.1", "10.0.0.2"), testRecord("fd00::1", "fd00::2"))
+		}
+		msgs := e.dataMessages(now, records)
+		Expect(len(msgs)).To(BeNumerically(">", 1))
+
+		var total uint32
+		for _, msg := range msgs {
+			Expect(len(msg)).To(BeNumerically("<=", defaultMaxMessageSize))
+			d, err := decode(msg, templates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.sequenceNumber).To(Equal(total))
+			total += uint32(len(d.records))
+		}
+		Expect(total).To(Equal(uint32(100)))
+	})
+})
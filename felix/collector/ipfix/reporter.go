@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/collector/flowlog"
+	"github.com/projectcalico/calico/felix/collector/types/endpoint"
+)
+
+// IPFIXReporter is a types.Reporter that exports flow logs as IPFIX data records over UDP.
+type IPFIXReporter struct {
+	address                 string
+	templateRefreshInterval time.Duration
+
+	// lock protects the fields below, since Start is called from the flow log reporter's health loop
+	// independently of Report.
+	lock          sync.Mutex
+	conn          net.Conn
+	encoder       *encoder
+	lastTemplates time.Time
+
+	// Allow the time function to be mocked for test purposes.
+	timeNowFn func() time.Time
+}
+
+// NewReporter creates an IPFIXReporter that sends to the collector at the given host:port address. Calico-specific
+// information elements are only exported if enterpriseNumber is non-zero.
+func NewReporter(address string, observationDomainID, enterpriseNumber uint32, templateRefreshInterval time.Duration) *IPFIXReporter {
+	if enterpriseNumber == 0 {
+		logrus.Info("No IPFIX enterprise number configured, only exporting standard information elements " +
+			"(without endpoint names, namespaces or policies).")
+	}
+	return &IPFIXReporter{
+		address:                 address,
+		templateRefreshInterval: templateRefreshInterval,
+		encoder:                 newEncoder(observationDomainID, enterpriseNumber, defaultMaxMessageSize),
+		timeNowFn:               time.Now,
+	}
+}
+
+// Start resolves the collector address and sets up the UDP socket. It is safe to call repeatedly; once the
+// socket is set up, subsequent calls are no-ops until a send fails.
+func (r *IPFIXReporter) Start() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.conn != nil {
+		return nil
+	}
+
+	conn, err := net.Dial("udp", r.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to IPFIX collector %s: %w", r.address, err)
+	}
+	logrus.WithField("address", r.address).Info("Connected to IPFIX collector")
+	r.conn = conn
+	// Make sure that the collector gets our templates before any data records.
+	r.lastTemplates = time.Time{}
+	return nil
+}
+
+func (r *IPFIXReporter) Report(logSlice any) error {
+	switch logs := logSlice.(type) {
+	case []*flowlog.FlowLog:
+		if logrus.IsLevelEnabled(logrus.DebugLevel) {
+			logrus.WithField("num", len(logs)).Debug("Dispatching flow logs to IPFIX collector")
+		}
+		records := make([]*record, 0, len(logs))
+		for _, l := range logs {
+			records = append(records, convertFlowLog(l))
+		}
+		return r.send(records)
+	default:
+		logrus.Panic("Unexpected kind of log dispatcher")
+	}
+	return nil
+}
+
+func (r *IPFIXReporter) send(records []*record) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.conn == nil {
+		return fmt.Errorf("not connected to IPFIX collector %s", r.address)
+	}
+
+	now := r.timeNowFn()
+	var msgs [][]byte
+	if now.Sub(r.lastTemplates) >= r.templateRefreshInterval {
+		msgs = append(msgs, r.encoder.templateMessage(now))
+		r.lastTemplates = now
+	}
+	msgs = append(msgs, r.encoder.dataMessages(now, records)...)
+
+	for _, msg := range msgs {
+		if _, err := r.conn.Write(msg); err != nil {
+			// Close the socket so that the next call to Start re-resolves the collector address, which may
+			// have changed.
+			_ = r.conn.Close()
+			r.conn = nil
+			return fmt.Errorf("failed to send to IPFIX collector %s: %w", r.address, err)
+		}
+	}
+	return nil
+}
+
+// convertFlowLog converts a flow log into an IPFIX record. In and out counts in flow logs are from the point of
+// view of the reporting endpoint, whereas the record's forward direction is from the flow's source to its
+// destination.
+func convertFlowLog(fl *flowlog.FlowLog) *record {
+	r := &record{
+		start:   fl.StartTime,
+		end:     fl.EndTime,
+		src:     fl.Tuple.SourceNet(),
+		dst:     fl.Tuple.DestNet(),
+		srcPort: port(fl.Tuple.L4Src),
+		dstPort: port(fl.Tuple.L4Dst),
+		proto:   uint8(fl.Tuple.Proto),
+
+		srcNamespace: fl.SrcMeta.Namespace,
+		srcName:      endpointName(fl.SrcMeta),
+		srcType:      string(fl.SrcMeta.Type),
+		dstNamespace: fl.DstMeta.Namespace,
+		dstName:      endpointName(fl.DstMeta),
+		dstType:      string(fl.DstMeta.Type),
+
+		dstServiceNamespace: fl.DstService.Namespace,
+		dstServiceName:      fl.DstService.Name,
+		dstServicePortName:  fl.DstService.PortName,
+
+		enforcedPolicies: policies(fl.FlowEnforcedPolicySet),
+		pendingPolicies:  policies(fl.FlowPendingPolicySet),
+	}
+
+	if fl.Reporter == flowlog.ReporterSrc {
+		r.direction = flowDirectionEgress
+		r.octets, r.packets = uint64(fl.BytesOut), uint64(fl.PacketsOut)
+		r.reverseOctets, r.reversePackets = uint64(fl.BytesIn), uint64(fl.PacketsIn)
+	} else {
+		r.direction = flowDirectionIngress
+		r.octets, r.packets = uint64(fl.BytesIn), uint64(fl.PacketsIn)
+		r.reverseOctets, r.reversePackets = uint64(fl.BytesOut), uint64(fl.PacketsOut)
+	}
+
+	if fl.Action == flowlog.ActionDeny {
+		r.forwardingStatus = forwardingStatusDropped
+	} else {
+		r.forwardingStatus = forwardingStatusForwarded
+	}
+	return r
+}
+
+// endpointName returns the full name of the endpoint if it is known, falling back to the aggregated name.
+func endpointName(m endpoint.Metadata) string {
+	if m.Name != "" && m.Name != flowlog.FieldNotIncluded {
+		return m.Name
+	}
+	return m.AggregatedName
+}
+
+// policies returns the policies in the set as a sorted, comma separated list.
+func policies(s flowlog.FlowPolicySet) string {
+	ps := make([]string, 0, len(s))
+	for p := range s {
+		ps = append(ps, p)
+	}
+	sort.Strings(ps)
+	return strings.Join(ps, ",")
+}
+
+// port converts a flow log port, which may be unset (-1), into a transport port number.
+func port(p int) uint16 {
+	if p < 0 || p > 0xffff {
+		return 0
+	}
+	return uint16(p)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipfix
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/collector/flowlog"
+	"github.com/projectcalico/calico/felix/collector/types/endpoint"
+	"github.com/projectcalico/calico/felix/collector/types/tuple"
+)
+
+const testPEN = 54321
+
+func ipBytes(s string) [16]byte {
+	var b [16]byte
+	copy(b[:], net.ParseIP(s).To16())
+	return b
+}
+
+func testFlowLog(reporter flowlog.ReporterType, action flowlog.Action) *flowlog.FlowLog {
+	fl := &flowlog.FlowLog{
+		StartTime: time.Unix(100, 0),
+		EndTime:   time.Unix(110, 0),
+		FlowMeta: flowlog.FlowMeta{
+			Tuple: tuple.Make(ipBytes("10.0.0.1"), ipBytes("10.0.0.2"), 6, 40000, 80),
+			SrcMeta: endpoint.Metadata{
+				Type:           endpoint.Wep,
+				Namespace:      "client-ns",
+				Name:           "client-abcde",
+				AggregatedName: "client-*",
+			},
+			DstMeta: endpoint.Metadata{
+				Type:           endpoint.Wep,
+				Namespace:      "server-ns",
+				Name:           flowlog.FieldNotIncluded,
+				AggregatedName: "server-*",
+			},
+			DstService: flowlog.FlowService{Namespace: "server-ns", Name: "server", PortName: "http", PortNum: 80},
+			Action:     action,
+			Reporter:   reporter,
+		},
+		FlowEnforcedPolicySet: flowlog.FlowPolicySet{
+			"1|default|server-ns/default.b|allow|0": struct{}{},
+			"0|default|server-ns/default.a|pass|0":  struct{}{},
+		},
+	}
+	fl.BytesIn, fl.PacketsIn = 1000, 10
+	fl.BytesOut, fl.PacketsOut = 500, 5
+	return fl
+}
+
+var _ = Describe("IPFIXReporter", func() {
+	var (
+		listener  *net.UDPConn
+		reporter  *IPFIXReporter
+		templates map[uint16][]fieldSpec
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+		Expect(err).NotTo(HaveOccurred())
+		templates = map[uint16][]fieldSpec{}
+		now = time.Unix(1000, 0)
+
+		reporter = NewReporter(listener.LocalAddr().String(), 3, testPEN, time.Minute)
+		reporter.timeNowFn = func() time.Time { return now }
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	receive := func() *decodedMessage {
+		buf := make([]byte, 65535)
+		Expect(listener.SetReadDeadline(time.Now().Add(5 * time.Second))).To(Succeed())
+		n, err := listener.Read(buf)
+		Expect(err).NotTo(HaveOccurred())
+		d, err := decode(buf[:n], templates)
+		Expect(err).NotTo(HaveOccurred())
+		return d
+	}
+
+	It("should fail to report before it is started", func() {
+		Expect(reporter.Report([]*flowlog.FlowLog{testFlowLog(flowlog.ReporterSrc, flowlog.ActionAllow)})).To(HaveOccurred())
+	})
+
+	It("should send templates followed by data records", func() {
+		Expect(reporter.Start()).To(Succeed())
+		Expect(reporter.Report([]*flowlog.FlowLog{testFlowLog(flowlog.ReporterSrc, flowlog.ActionAllow)})).To(Succeed())
+
+		d := receive()
+		Expect(d.observationDomainID).To(Equal(uint32(3)))
+		Expect(d.records).To(BeEmpty())
+		Expect(templates).To(HaveLen(2))
+
+		d = receive()
+		Expect(d.records).To(HaveLen(1))
+		r := d.records[0]
+		Expect(net.IP(r[fieldKey{0, ieSourceIPv4Address}]).String()).To(Equal("10.0.0.1"))
+		Expect(net.IP(r[fieldKey{0, ieDestinationIPv4Address}]).String()).To(Equal("10.0.0.2"))
+		Expect(binary.BigEndian.Uint16(r[fieldKey{0, ieSourceTransportPort}])).To(Equal(uint16(40000)))
+		Expect(binary.BigEndian.Uint64(r[fieldKey{0, ieFlowStartMilliseconds}])).To(Equal(uint64(100000)))
+		Expect(binary.BigEndian.Uint64(r[fieldKey{0, ieFlowEndMilliseconds}])).To(Equal(uint64(110000)))
+		Expect(r[fieldKey{0, ieFlowDirection}]).To(Equal([]byte{flowDirectionEgress}))
+		Expect(r[fieldKey{0, ieForwardingStatus}]).To(Equal([]byte{forwardingStatusForwarded}))
+
+		// The source reported the flow, so its outbound counts are in the forward direction.
+		Expect(binary.BigEndian.Uint64(r[fieldKey{0, ieOctetDeltaCount}])).To(Equal(uint64(500)))
+		Expect(binary.BigEndian.Uint64(r[fieldKey{0, iePacketDeltaCount}])).To(Equal(uint64(5)))
+		Expect(binary.BigEndian.Uint64(r[fieldKey{reversePEN, ieOctetDeltaCount}])).To(Equal(uint64(1000)))
+		Expect(binary.BigEndian.Uint64(r[fieldKey{reversePEN, iePacketDeltaCount}])).To(Equal(uint64(10)))
+
+		Expect(string(r[fieldKey{testPEN, ieCalicoSourceNamespace}])).To(Equal("client-ns"))
+		Expect(string(r[fieldKey{testPEN, ieCalicoSourceName}])).To(Equal("client-abcde"))
+		Expect(string(r[fieldKey{testPEN, ieCalicoSourceType}])).To(Equal("wep"))
+		Expect(string(r[fieldKey{testPEN, ieCalicoDestinationName}])).To(Equal("server-*"))
+		Expect(string(r[fieldKey{testPEN, ieCalicoDestinationServiceName}])).To(Equal("server"))
+		Expect(string(r[fieldKey{testPEN, ieCalicoDestinationServicePortName}])).To(Equal("http"))
+		Expect(string(r[fieldKey{testPEN, ieCalicoEnforcedPolicies}])).To(Equal(
+			"0|default|server-ns/default.a|pass|0,1|default|server-ns/default.b|allow|0"))
+	})
+
+	It("should convert flows reported by the destination", func() {
+		Expect(reporter.Start()).To(Succeed())
+		Expect(reporter.Report([]*flowlog.FlowLog{testFlowLog(flowlog.ReporterDst, flowlog.ActionDeny)})).To(Succeed())
+		receive()
+
+		r := receive().records[0]
+		Expect(r[fieldKey{0, ieFlowDirection}]).To(Equal([]byte{flowDirectionIngress}))
+		Expect(r[fieldKey{0, ieForwardingStatus}]).To(Equal([]byte{forwardingStatusDropped}))
+		Expect(binary.BigEndian.Uint64(r[fieldKey{0, ieOctetDeltaCount}])).To(Equal(uint64(1000)))
+		Expect(binary.BigEndian.Uint64(r[fieldKey{reversePEN, ieOctetDeltaCount}])).To(Equal(uint64(500)))
+	})
+
+	It("should only resend templates after the refresh interval", func() {
+		Expect(reporter.Start()).To(Succeed())
+		fls := []*flowlog.FlowLog{testFlowLog(flowlog.ReporterSrc, flowlog.ActionAllow)}
+
+		Expect(reporter.Report(fls)).To(Succeed())
+		Expect(receive().records).To(BeEmpty())
+		Expect(receive().records).To(HaveLen(1))
+
+		now = now.Add(30 * time.Second)
+		Expect(reporter.Report(fls)).To(Succeed())
+		d := receive()
+		Expect(d.records).To(HaveLen(1))
+		Expect(d.sequenceNumber).To(Equal(uint32(1)))
+
+		now = now.Add(30 * time.Second)
+		Expect(reporter.Report(fls)).To(Succeed())
+		Expect(receive().records).To(BeEmpty())
+		Expect(receive().records).To(HaveLen(1))
+	})
+})
@@ -164,6 +164,13 @@ type Config struct {
 	UseInternalDataplaneDriver bool          `config:"bool;true"`
 	DataplaneDriver            string        `config:"file(must-exist,executable);calico-iptables-plugin;non-zero,die-on-fail,skip-default-validation"`
 	DataplaneWatchdogTimeout   time.Duration `config:"seconds;90"`
+	// DataplaneDriverSocket path of a Unix socket over which Felix exchanges messages with an external dataplane
+	// driver that runs as an independent process.  If set, Felix uses the socket instead of starting
+	// DataplaneDriver as a child process.  Only used if UseInternalDataplaneDriver is set to false.
+	DataplaneDriverSocket string `config:"file;;local"`
+	// DataplaneDriverSocketMode controls whether Felix connects to the DataplaneDriverSocket, which the driver
+	// listens on (Connect), or listens on it and waits for the driver to connect (Listen).
+	DataplaneDriverSocketMode string `config:"oneof(Connect,Listen);Connect;local"`
 
 	// Wireguard configuration
 	WireguardEnabled               bool          `config:"bool;false"`
@@ -178,6 +185,11 @@ type Config struct {
 	WireguardHostEncryptionEnabled bool          `config:"bool;false"`
 	WireguardPersistentKeepAlive   time.Duration `config:"seconds;0"`
 	WireguardThreadingEnabled      bool          `config:"bool;false"`
+	WireguardKeyRotationInterval   time.Duration `config:"seconds;0"`
+	// WireguardPresharedKeyFile path to a file containing a secret that is shared by all nodes in the cluster.  If
+	// specified, Felix derives a distinct Wireguard preshared key for each pair of nodes from the secret, adding a
+	// symmetric layer of encryption on top of the Wireguard key exchange.
+	WireguardPresharedKeyFile string `config:"file(must-exist);;local"`
 
 	// nftables configuration.
 	NFTablesMode string `config:"oneof(Enabled,Disabled);Disabled"`
@@ -287,6 +299,11 @@ type Config struct {
 	// TyphaURISAN URI SAN to use when authenticating to Typha over TLS. If any TLS parameters are specified then one of
 	// TyphaCN and TyphaURISAN must be set.
 	TyphaURISAN string `config:"string;;local"`
+	// TyphaNodeFiltering if true, asks Typha to only send full detail for workload endpoints and
+	// host-scoped configuration that belong to this node.  Remote workload endpoints are sent in a reduced
+	// form that contains only the fields needed to calculate IP set membership; remote workload endpoints
+	// that don't have any IPs yet, and updates that don't change the reduced form, are not sent at all.
+	TyphaNodeFiltering bool `config:"bool;false;local"`
 
 	Ipv6Support bool `config:"bool;true"`
 
@@ -374,6 +391,11 @@ type Config struct {
 
 	WorkloadSourceSpoofing string `config:"oneof(Disabled,Any);Disabled"`
 
+	DNSPolicyMode                   string        `config:"oneof(Disabled,NoDelay,DelayDNSResponse);Disabled"`
+	DNSPolicyNfqueueID              int           `config:"int(0:65535);100"`
+	DNSPolicyNfqueueMaxHoldDuration time.Duration `config:"seconds;3"`
+	DNSTrustedServers               []string      `config:"cidr-list;;"`
+
 	ReportingIntervalSecs time.Duration `config:"seconds;30"`
 	ReportingTTLSecs      time.Duration `config:"seconds;90"`
 
@@ -413,6 +435,11 @@ type Config struct {
 	FlowLogsLocalReporter        string        `config:"oneof(Enabled,Disabled);Disabled"`
 	FlowLogsPolicyEvaluationMode string        `config:"oneof(None,Continuous);Continuous"`
 
+	FlowLogsIPFIXCollector               string        `config:"authority;"`
+	FlowLogsIPFIXObservationDomainID     int           `config:"int(0:4294967295);0"`
+	FlowLogsIPFIXEnterpriseNumber        int           `config:"int(0:4294967295);0"`
+	FlowLogsIPFIXTemplateRefreshInterval time.Duration `config:"seconds;600"`
+
 	KubeNodePortRanges    []numorstring.Port `config:"portrange-list;30000:32767"`
 	NATPortRange          numorstring.Port   `config:"portrange;"`
 	NATOutgoingAddress    net.IP             `config:"ipv4;"`
@@ -435,6 +462,13 @@ type Config struct {
 	DebugSimulateDataplaneApplyDelay time.Duration `config:"seconds;0"`
 	DebugPanicAfter                  time.Duration `config:"seconds;0"`
 	DebugSimulateDataRace            bool          `config:"bool;false"`
+	// DebugCalcGraphRecordingFile path of a file to record the updates that feed Felix's calculation graph, and the
+	// messages that it sends to the dataplane driver, to.  The recording is gzip-compressed and is replaced each
+	// time that Felix starts.  It can be replayed with "calico-felix replay" to reproduce the dataplane state.
+	DebugCalcGraphRecordingFile string `config:"file;;local"`
+	// DebugCalcGraphRecordingMaxSizeMB is the size, in megabytes, at which Felix stops adding to the recording in
+	// DebugCalcGraphRecordingFile.  The recording remains usable up to that point.  Zero means no limit.
+	DebugCalcGraphRecordingMaxSizeMB int `config:"int;1024;local"`
 	// DebugHost is the host to bind the debug server port to.  Only used if DebugPort is non-zero.
 	DebugHost string `config:"host-address;localhost"`
 	// DebugPort is the port to bind the pprof debug server to or 0 to disable the debug port.
@@ -542,9 +576,14 @@ func (config *Config) FlowLogsLocalReporterEnabled() bool {
 	return config.FlowLogsLocalReporter == "Enabled"
 }
 
+func (config *Config) DNSPolicyEnabled() bool {
+	return config.DNSPolicyMode != "Disabled"
+}
+
 func (config *Config) FlowLogsEnabled() bool {
 	return config.FlowLogsGoldmaneServer != "" ||
-		config.FlowLogsLocalReporterEnabled()
+		config.FlowLogsLocalReporterEnabled() ||
+		config.FlowLogsIPFIXCollector != ""
 }
 
 func (config *Config) ProgramRoutesEnabled() bool {
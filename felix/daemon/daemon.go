@@ -41,6 +41,7 @@ import (
 	"github.com/projectcalico/calico/felix/logutils"
 	"github.com/projectcalico/calico/felix/policysync"
 	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/recording"
 	"github.com/projectcalico/calico/felix/statusrep"
 	"github.com/projectcalico/calico/felix/usagerep"
 	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
@@ -448,6 +449,27 @@ configRetry:
 		lookupsCache,
 	)
 
+	// If enabled, record the calculation graph's inputs and outputs so that they can be replayed
+	// offline.
+	var recorder *recording.Writer
+	if configParams.DebugCalcGraphRecordingFile != "" {
+		w, err := recording.Create(
+			configParams.DebugCalcGraphRecordingFile,
+			int64(configParams.DebugCalcGraphRecordingMaxSizeMB)*1024*1024,
+		)
+		if err != nil {
+			log.WithError(err).Error("Failed to create calculation graph recording; not recording.")
+		} else {
+			log.WithFields(log.Fields{
+				"file":      configParams.DebugCalcGraphRecordingFile,
+				"maxSizeMB": configParams.DebugCalcGraphRecordingMaxSizeMB,
+			}).Warn(
+				"Recording calculation graph updates; this may use a lot of disk space.")
+			recorder = w
+			dpDriver = recording.NewRecordingDriver(dpDriver, recorder)
+		}
+	}
+
 	// Defer reporting ready until we've started the dataplane driver.  This
 	// ensures that our overall readiness waits for the dataplane driver to
 	// report ready on its health report.
@@ -539,15 +561,7 @@ configRetry:
 			fmt.Sprintf("Revision: %s; Build date: %s",
 				buildinfo.GitRevision, buildinfo.BuildDate),
 			syncerToValidator,
-			&syncclient.Options{
-				ReadTimeout:  configParams.TyphaReadTimeout,
-				WriteTimeout: configParams.TyphaWriteTimeout,
-				KeyFile:      configParams.TyphaKeyFile,
-				CertFile:     configParams.TyphaCertFile,
-				CAFile:       configParams.TyphaCAFile,
-				ServerCN:     configParams.TyphaCN,
-				ServerURISAN: configParams.TyphaURISAN,
-			},
+			typhaOptions(configParams, "", 0),
 		)
 	} else {
 		// Use the syncer locally.
@@ -602,7 +616,32 @@ configRetry:
 		configParams.SetUseNodeResourceUpdates(supportsNodeResourceUpdates)
 
 		go func() {
-			typhaConnection.Finished.Wait()
+			for {
+				typhaConnection.Finished.Wait()
+				// Try to resume the session so that we only need to receive the updates that we missed.
+				// If that's not possible, restart so that we get a fresh snapshot.
+				cacheID, seqNo := typhaConnection.LastAppliedBreadcrumb()
+				if seqNo == 0 {
+					break
+				}
+				log.WithFields(log.Fields{
+					"cacheID": cacheID,
+					"seqNo":   seqNo,
+				}).Info("Connection to Typha failed, trying to resume session.")
+				typhaConnection = syncclient.New(
+					typhaDiscoverer,
+					buildinfo.Version,
+					configParams.FelixHostname,
+					fmt.Sprintf("Revision: %s; Build date: %s",
+						buildinfo.GitRevision, buildinfo.BuildDate),
+					syncerToValidator,
+					typhaOptions(configParams, cacheID, seqNo),
+				)
+				if err := typhaConnection.Start(context.Background()); err != nil {
+					log.WithError(err).Warn("Failed to reconnect to Typha.")
+					break
+				}
+			}
 			failureReportChan <- "Connection to Typha failed"
 		}()
 	}
@@ -673,7 +712,11 @@ configRetry:
 	// calculation graph.
 	validator := calc.NewValidationFilter(asyncCalcGraph, configParams)
 
-	go syncerToValidator.SendToSinkForever(validator)
+	var validatorCallbacks bapi.SyncerCallbacks = validator
+	if recorder != nil {
+		validatorCallbacks = recording.NewRecordingSyncerCallbacks(validator, recorder)
+	}
+	go syncerToValidator.SendToSinkForever(validatorCallbacks)
 	asyncCalcGraph.Start()
 	log.Infof("Started the processing graph")
 	var stopSignalChans []chan<- *sync.WaitGroup
@@ -1196,7 +1239,7 @@ func (fc *DataplaneConnector) handleProcessStatusUpdate(ctx context.Context, msg
 	}
 }
 
-func (fc *DataplaneConnector) reconcileWireguardStatUpdate(dpPubKey string, ipVersion proto.IPVersion) error {
+func (fc *DataplaneConnector) reconcileWireguardStatUpdate(dpPubKey, dpNextPubKey string, ipVersion proto.IPVersion) error {
 	// In case of a recoverable failure (ErrorResourceUpdateConflict), retry update 3 times.
 	for iter := 0; iter < 3; iter++ {
 		// Read node resource from datastore and compare it with the publicKey from dataplane.
@@ -1230,19 +1273,21 @@ func (fc *DataplaneConnector) reconcileWireguardStatUpdate(dpPubKey string, ipVe
 			return err
 		}
 
-		// Check if the public-key needs to be updated.
-		storedPublicKey := node.Status.WireguardPublicKey
+		// Check if the public-key (or the next public-key, during a key rotation) needs to be updated.
+		storedPublicKey, storedNextPublicKey := node.Status.WireguardPublicKey, node.Status.WireguardNextPublicKey
 		if ipVersion == proto.IPVersion_IPV6 {
-			storedPublicKey = node.Status.WireguardPublicKeyV6
+			storedPublicKey, storedNextPublicKey = node.Status.WireguardPublicKeyV6, node.Status.WireguardNextPublicKeyV6
 		} else if ipVersion != proto.IPVersion_IPV4 {
 			return fmt.Errorf("Unknown IP version: %d", ipVersion)
 		}
-		if storedPublicKey != dpPubKey {
+		if storedPublicKey != dpPubKey || storedNextPublicKey != dpNextPubKey {
 			updateCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 			if ipVersion == proto.IPVersion_IPV4 {
 				node.Status.WireguardPublicKey = dpPubKey
+				node.Status.WireguardNextPublicKey = dpNextPubKey
 			} else if ipVersion == proto.IPVersion_IPV6 {
 				node.Status.WireguardPublicKeyV6 = dpPubKey
+				node.Status.WireguardNextPublicKeyV6 = dpNextPubKey
 			}
 			_, err := fc.datastorev3.Nodes().Update(updateCtx, node, options.SetOptions{})
 			cancel()
@@ -1257,7 +1302,7 @@ func (fc *DataplaneConnector) reconcileWireguardStatUpdate(dpPubKey string, ipVe
 				log.WithError(err).Info("Failed updating node resource")
 				return err
 			}
-			log.Debugf("Updated IPv%d Wireguard public-key from %s to %s", ipVersion, storedPublicKey, dpPubKey)
+			log.Debugf("Updated IPv%d Wireguard public-key from %s to %s (next public-key %q)", ipVersion, storedPublicKey, dpPubKey, dpNextPubKey)
 		}
 		break
 	}
@@ -1282,7 +1327,7 @@ func (fc *DataplaneConnector) handleWireguardStatUpdateFromDataplane() {
 		}
 
 		// Try and reconcile the current wireguard status data.
-		err := fc.reconcileWireguardStatUpdate(current.PublicKey, current.IpVersion)
+		err := fc.reconcileWireguardStatUpdate(current.PublicKey, current.NextPublicKey, current.IpVersion)
 		if err == nil {
 			current = nil
 			retryC = nil
@@ -1449,3 +1494,22 @@ func createTyphaDiscoverer(configParams *config.Config, k8sClientSet kubernetes.
 	)
 	return typhaDiscoverer
 }
+
+// typhaOptions returns the options for a Typha client.  If resumeSeqNo is non-zero, the client asks Typha
+// to resume the previous session from that breadcrumb.  Only the Typha process that sent the breadcrumb
+// can resume the session; if the client reaches a different one, we fall back to a full resync.
+func typhaOptions(configParams *config.Config, resumeCacheID string, resumeSeqNo uint64) *syncclient.Options {
+	return &syncclient.Options{
+		ReadTimeout:  configParams.TyphaReadTimeout,
+		WriteTimeout: configParams.TyphaWriteTimeout,
+		KeyFile:      configParams.TyphaKeyFile,
+		CertFile:     configParams.TyphaCertFile,
+		CAFile:       configParams.TyphaCAFile,
+		ServerCN:     configParams.TyphaCN,
+		ServerURISAN: configParams.TyphaURISAN,
+
+		RequestNodeFiltering: configParams.TyphaNodeFiltering,
+		ResumeCacheID:        resumeCacheID,
+		ResumeSeqNo:          resumeSeqNo,
+	}
+}
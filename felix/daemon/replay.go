@@ -0,0 +1,226 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/config"
+	dp "github.com/projectcalico/calico/felix/dataplane"
+	"github.com/projectcalico/calico/felix/logutils"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/recording"
+	"github.com/projectcalico/calico/libcalico-go/lib/health"
+)
+
+// Replay replays a recording made with DebugCalcGraphRecordingFile into Felix's internal dataplane
+// driver, reproducing the dataplane state that Felix programmed when the recording was made.
+// Felix's configuration is taken from the recording.  If isolated is true, the replay runs in a new
+// network namespace so that the host's network interfaces, routes, iptables/nftables rules and IP
+// sets are left untouched.  BPF programs and maps are pinned in the BPF filesystem, which a network
+// namespace doesn't isolate, so recordings made in BPF mode can't be replayed in isolation.
+//
+// Once the whole recording has been replayed, Replay waits for a signal before exiting so that the
+// resulting dataplane state can be inspected.
+func Replay(filename string, isolated bool) {
+	logutils.ConfigureEarlyLogging()
+
+	if isolated {
+		configParams, err := recordedConfig(filename)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to load Felix's configuration from the recording.")
+		}
+		if err := checkIsolatedReplay(configParams); err != nil {
+			log.WithError(err).Fatal("Unable to replay recording in isolation.")
+		}
+		log.Info("Replaying recording in a new network namespace.")
+		if err := runIsolated("replay", filename); err != nil {
+			log.WithError(err).Fatal("Isolated replay failed.")
+		}
+		return
+	}
+
+	r, err := recording.Open(filename)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to open recording.")
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	var driver dp.DataplaneDriver
+	var pending []interface{}
+	numMsgs := 0
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.WithError(err).Fatal("Failed to read recording.")
+		}
+		if rec.Type != recording.RecordTypeToDataplane {
+			continue
+		}
+
+		msgs := []interface{}{rec.ToDataplane}
+		if driver == nil {
+			// We can't start the dataplane driver until we have its configuration.
+			configUpdate, ok := rec.ToDataplane.(*proto.ConfigUpdate)
+			if !ok {
+				pending = append(pending, rec.ToDataplane)
+				continue
+			}
+			driver = startReplayDriver(configUpdate)
+			msgs = append(msgs, pending...)
+			pending = nil
+		}
+		for _, msg := range msgs {
+			if err := driver.SendMessage(msg); err != nil {
+				log.WithError(err).Fatal("Failed to send message to dataplane driver.")
+			}
+			numMsgs++
+		}
+	}
+	if driver == nil {
+		log.Fatal("Recording doesn't include Felix's configuration; unable to replay it.")
+	}
+
+	log.WithField("numMessages", numMsgs).Info(
+		"Finished replaying recording.  The dataplane state remains in place until Felix exits; " +
+			"send SIGINT or SIGTERM to exit.")
+	signalC := make(chan os.Signal, 1)
+	signal.Notify(signalC, syscall.SIGINT, syscall.SIGTERM)
+	<-signalC
+}
+
+// startReplayDriver starts the internal dataplane driver with the configuration from the recording.
+func startReplayDriver(configUpdate *proto.ConfigUpdate) dp.DataplaneDriver {
+	configParams, err := replayConfig(configUpdate)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load Felix's configuration from the recording.")
+	}
+	logutils.ConfigureLogging(configParams)
+
+	driver, _ := dp.StartDataplaneDriver(
+		configParams.Copy(),
+		health.NewHealthAggregator(),
+		nil,
+		func() {
+			// The dataplane asks for a restart when it detects that the host has changed in a way that
+			// needs new configuration.  That's expected when replaying on a different host.
+			log.Warn("Dataplane driver requested a restart; ignoring during replay.")
+		},
+		func(err error) {
+			log.WithError(err).Fatal("Dataplane driver reported a fatal error.")
+		},
+		nil,
+		nil,
+	)
+
+	go func() {
+		for {
+			msg, err := driver.RecvMessage()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to read from dataplane driver.")
+			}
+			if _, ok := msg.(*proto.DataplaneInSync); ok {
+				log.Info("Dataplane driver has programmed the dataplane.")
+			}
+			log.WithField("msg", msg).Debug("Message from dataplane driver.")
+		}
+	}()
+	return driver
+}
+
+// recordedConfig returns the configuration from the first ConfigUpdate in the recording, adjusted
+// for replaying.
+func recordedConfig(filename string) (*config.Config, error) {
+	r, err := recording.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return nil, errors.New("recording doesn't include Felix's configuration")
+		} else if err != nil {
+			return nil, err
+		}
+		if configUpdate, ok := rec.ToDataplane.(*proto.ConfigUpdate); ok {
+			return replayConfig(configUpdate)
+		}
+	}
+}
+
+// checkIsolatedReplay returns an error if replaying with the given configuration would modify the
+// host's dataplane, even in a new network namespace.
+func checkIsolatedReplay(configParams *config.Config) error {
+	if configParams.BPFEnabled {
+		return errors.New("the recording was made in BPF mode; BPF maps are pinned in /sys/fs/bpf, " +
+			"which is shared with the host, so replay it without --isolated on a test host instead")
+	}
+	return nil
+}
+
+// replayConfig returns Felix's configuration as it was when the recording was made, adjusted so
+// that it is suitable for replaying.
+func replayConfig(configUpdate *proto.ConfigUpdate) (*config.Config, error) {
+	configParams := config.New()
+	if _, err := configParams.UpdateFromConfigUpdate(configUpdate); err != nil {
+		return nil, err
+	}
+	// The replay always uses the internal dataplane driver, even if the recording was made with an
+	// external one.
+	if _, err := configParams.UpdateFrom(map[string]string{
+		"UseInternalDataplaneDriver": "true",
+	}, config.InternalOverride); err != nil {
+		return nil, err
+	}
+	return configParams, nil
+}
+
+// DumpRecording prints the records in the given recording, one per line.
+func DumpRecording(filename string, out io.Writer) error {
+	r, err := recording.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(out, rec); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/config"
+	"github.com/projectcalico/calico/felix/proto"
+)
+
+var _ = Describe("Isolated replay", func() {
+	recordedConfig := func(rawConfig map[string]string) *config.Config {
+		configParams, err := replayConfig(&proto.ConfigUpdate{
+			SourceToRawConfig: map[uint32]*proto.RawConfig{
+				uint32(config.EnvironmentVariable): {Source: config.EnvironmentVariable.String(), Config: rawConfig},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return configParams
+	}
+
+	It("should allow recordings made in iptables mode", func() {
+		Expect(checkIsolatedReplay(recordedConfig(map[string]string{}))).To(Succeed())
+	})
+
+	It("should refuse recordings made in BPF mode", func() {
+		configParams := recordedConfig(map[string]string{"BPFEnabled": "true"})
+		Expect(configParams.BPFEnabled).To(BeTrue())
+		Expect(checkIsolatedReplay(configParams)).To(MatchError(ContainSubstring("BPF mode")))
+	})
+})
@@ -1,4 +1,4 @@
-// Copyright (c) 2019-2021 Tigera, Inc. All rights reserved.
+// Copyright (c) 2019-2025 Tigera, Inc. All rights reserved.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -27,6 +27,13 @@ type Callbacks struct {
 	RemoveHostEndpointV4     *RemoveHostEndpointFuncs
 	UpdateWorkloadEndpointV4 *UpdateWorkloadEndpointFuncs
 	RemoveWorkloadEndpointV4 *RemoveWorkloadEndpointFuncs
+	AddInterfaceV6           *AddInterfaceFuncs
+	RemoveInterfaceV6        *RemoveInterfaceFuncs
+	UpdateInterfaceV6        *UpdateInterfaceFuncs
+	UpdateHostEndpointV6     *UpdateHostEndpointFuncs
+	RemoveHostEndpointV6     *RemoveHostEndpointFuncs
+	UpdateWorkloadEndpointV6 *UpdateWorkloadEndpointFuncs
+	RemoveWorkloadEndpointV6 *RemoveWorkloadEndpointFuncs
 }
 
 func NewCallbacks() *Callbacks {
@@ -38,6 +45,13 @@ func NewCallbacks() *Callbacks {
 		RemoveHostEndpointV4:     &RemoveHostEndpointFuncs{},
 		UpdateWorkloadEndpointV4: &UpdateWorkloadEndpointFuncs{},
 		RemoveWorkloadEndpointV4: &RemoveWorkloadEndpointFuncs{},
+		AddInterfaceV6:           &AddInterfaceFuncs{},
+		RemoveInterfaceV6:        &RemoveInterfaceFuncs{},
+		UpdateInterfaceV6:        &UpdateInterfaceFuncs{},
+		UpdateHostEndpointV6:     &UpdateHostEndpointFuncs{},
+		RemoveHostEndpointV6:     &RemoveHostEndpointFuncs{},
+		UpdateWorkloadEndpointV6: &UpdateWorkloadEndpointFuncs{},
+		RemoveWorkloadEndpointV6: &RemoveWorkloadEndpointFuncs{},
 	}
 }
 
@@ -17,9 +17,11 @@
 package dataplane
 
 import (
+	"bytes"
 	"context"
 	"math/bits"
 	"net"
+	"os"
 	"os/exec"
 	"runtime/debug"
 	"strings"
@@ -190,6 +192,21 @@ func StartDataplaneDriver(
 			log.WithError(err).Warning("Unable to assign table index for IPv6 wireguard")
 		}
 
+		// Load the cluster secret that the per-peer Wireguard preshared keys are derived from.
+		var wireguardPresharedKeySecret []byte
+		if configParams.WireguardPresharedKeyFile != "" {
+			secret, err := os.ReadFile(configParams.WireguardPresharedKeyFile)
+			if err != nil {
+				log.WithError(err).WithField("file", configParams.WireguardPresharedKeyFile).Panic(
+					"Failed to read Wireguard preshared key file")
+			}
+			wireguardPresharedKeySecret = bytes.TrimSpace(secret)
+			if len(wireguardPresharedKeySecret) == 0 {
+				log.WithField("file", configParams.WireguardPresharedKeyFile).Panic(
+					"Wireguard preshared key file is empty")
+			}
+		}
+
 		// Extract node labels from the hosts such they could be referenced later
 		// e.g. Topology Aware Hints.
 		felixHostname := configParams.FelixHostname
@@ -222,6 +239,11 @@ func StartDataplaneDriver(
 				NFTables:              configParams.NFTablesMode == "Enabled",
 				WorkloadIfacePrefixes: configParams.InterfacePrefixes(),
 
+				// DNS snooping is only supported by the iptables and nftables dataplanes.
+				DNSPolicyNfqueueEnabled: configParams.DNSPolicyEnabled() && !configParams.BPFEnabled,
+				DNSPolicyNfqueueID:      uint16(configParams.DNSPolicyNfqueueID),
+				DNSTrustedServers:       configParams.DNSTrustedServers,
+
 				IPSetConfigV4: ipsets.NewIPVersionConfig(
 					ipsets.IPFamilyV4,
 					rules.IPSetNamePrefix,
@@ -311,6 +333,8 @@ func StartDataplaneDriver(
 				PersistentKeepAlive: configParams.WireguardPersistentKeepAlive,
 				ThreadedNAPI:        configParams.WireguardThreadingEnabled,
 				RouteSyncDisabled:   configParams.RouteSyncDisabled,
+				KeyRotationInterval: configParams.WireguardKeyRotationInterval,
+				PresharedKeySecret:  wireguardPresharedKeySecret,
 			},
 			IPIPMTU:                        configParams.IpInIpMtu,
 			VXLANMTU:                       configParams.VXLANMTU,
@@ -403,6 +427,9 @@ func StartDataplaneDriver(
 			BPFProfiling:                       configParams.BPFProfiling,
 			ServiceLoopPrevention:              configParams.ServiceLoopPrevention,
 
+			DNSPolicyDelayDNSResponse:       configParams.DNSPolicyMode == "DelayDNSResponse",
+			DNSPolicyNfqueueMaxHoldDuration: configParams.DNSPolicyNfqueueMaxHoldDuration,
+
 			KubeClientSet: k8sClientSet,
 
 			FeatureDetectOverrides: configParams.FeatureDetectOverride,
@@ -416,6 +443,12 @@ func StartDataplaneDriver(
 			FlowLogsEnabled:    configParams.FlowLogsEnabled(),
 		}
 
+		if configParams.DNSPolicyEnabled() && configParams.BPFEnabled {
+			log.Warn("DNSPolicyMode is not supported in BPF mode; domain-based policy rules will not match.")
+		} else if configParams.DNSPolicyEnabled() && len(configParams.DNSTrustedServers) == 0 {
+			log.Warn("DNSPolicyMode is enabled but DNSTrustedServers is empty; domain-based policy rules will not match.")
+		}
+
 		if configParams.BPFExternalServiceMode == "dsr" {
 			dpConfig.BPFNodePortDSREnabled = true
 			dpConfig.BPFDSROptoutCIDRs = configParams.BPFDSROptoutCIDRs
@@ -433,6 +466,18 @@ func StartDataplaneDriver(
 		}
 
 		return intDP, nil
+	} else if configParams.DataplaneDriverSocket != "" {
+		log.WithFields(log.Fields{
+			"socket": configParams.DataplaneDriverSocket,
+			"mode":   configParams.DataplaneDriverSocketMode,
+		}).Info("Using external dataplane driver over Unix socket.")
+
+		conn, err := extdataplane.StartSocketDataplaneDriver(
+			configParams.DataplaneDriverSocket, configParams.DataplaneDriverSocketMode)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to set up connection to external dataplane driver.")
+		}
+		return conn, nil
 	} else {
 		log.WithField("driver", configParams.DataplaneDriver).Info(
 			"Using external dataplane driver.")
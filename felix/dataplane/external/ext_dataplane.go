@@ -12,8 +12,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// extdataplane implements the connection to an external dataplane driver, connected via
-// a pair of pipes.
+// extdataplane implements the connection to an external dataplane driver, connected either via
+// a pair of pipes to a child process or via a Unix socket to an independent process.
 package extdataplane
 
 import (
@@ -92,15 +92,21 @@ type extDataplaneConn struct {
 }
 
 func (c *extDataplaneConn) RecvMessage() (msg interface{}, err error) {
+	return readMessage(c.fromDataplane)
+}
+
+// readMessage reads a single length-prefixed FromDataplane message from the given reader and
+// returns its unwrapped payload.
+func readMessage(r io.Reader) (msg interface{}, err error) {
 	buf := make([]byte, 8)
-	_, err = io.ReadFull(c.fromDataplane, buf)
+	_, err = io.ReadFull(r, buf)
 	if err != nil {
 		return
 	}
 	length := binary.LittleEndian.Uint64(buf)
 
 	data := make([]byte, length)
-	_, err = io.ReadFull(c.fromDataplane, data)
+	_, err = io.ReadFull(r, data)
 	if err != nil {
 		return
 	}
@@ -134,13 +140,20 @@ func (c *extDataplaneConn) RecvMessage() (msg interface{}, err error) {
 }
 
 func (fc *extDataplaneConn) SendMessage(msg interface{}) error {
-	log.Debugf("Writing msg (%v) to felix: %#v", fc.nextSeqNumber, msg)
+	err := writeMessage(fc.toDataplane, msg, fc.nextSeqNumber)
+	fc.nextSeqNumber += 1
+	return err
+}
+
+// writeMessage wraps the given message in a ToDataplane envelope and writes it, length-prefixed,
+// to the given writer.
+func writeMessage(w io.Writer, msg interface{}, seqNo uint64) error {
+	log.Debugf("Writing msg (%v) to felix: %#v", seqNo, msg)
 
-	envelope, err := WrapPayloadWithEnvelope(msg, fc.nextSeqNumber)
+	envelope, err := WrapPayloadWithEnvelope(msg, seqNo)
 	if err != nil {
 		log.WithError(err).Panic("Cannot wrap message to dataplane")
 	}
-	fc.nextSeqNumber += 1
 
 	data, err := pb.Marshal(envelope)
 
@@ -155,7 +168,7 @@ func (fc *extDataplaneConn) SendMessage(msg interface{}) error {
 	messageBuf.Write(lengthBytes)
 	messageBuf.Write(data)
 	for {
-		_, err := messageBuf.WriteTo(fc.toDataplane)
+		_, err := messageBuf.WriteTo(w)
 		if err == io.ErrShortWrite {
 			log.Warn("Short write to dataplane driver; buffer full?")
 			continue
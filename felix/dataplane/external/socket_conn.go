@@ -0,0 +1,262 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extdataplane
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SocketModeConnect means that Felix connects to a socket that the driver listens on.
+	SocketModeConnect = "Connect"
+	// SocketModeListen means that Felix listens on the socket and the driver connects to it.
+	SocketModeListen = "Listen"
+)
+
+var (
+	// reconnectInterval is how long we wait between attempts to connect to the driver's socket.
+	reconnectInterval = time.Second
+	// writeTimeout is how long we wait for the driver to accept each message before giving up on
+	// the connection.  Without it, a driver that stops reading would block Felix's main loop.
+	writeTimeout = 10 * time.Second
+	// minAcceptBackoff and maxAcceptBackoff bound how long we wait before retrying after
+	// failing to accept a connection from the driver.
+	minAcceptBackoff = 10 * time.Millisecond
+	maxAcceptBackoff = time.Second
+)
+
+var errStopped = errors.New("dataplane driver connection stopped")
+
+// StartSocketDataplaneDriver returns a connection to an external dataplane driver that runs as an
+// independent process and exchanges messages with Felix over the Unix socket at the given path.
+// The messages are framed in the same way as for a driver that Felix starts as a child process.
+//
+// The driver may disconnect and reconnect (for example, because it restarted) at any time.  Felix
+// tracks the state that it has sent and replays it in full to each new connection, before sending
+// any further updates.  While no driver is connected, updates are only recorded.
+func StartSocketDataplaneDriver(path, mode string) (*socketDataplaneConn, error) {
+	c := &socketDataplaneConn{
+		path:  path,
+		state: newDataplaneState(),
+	}
+	c.cond = sync.NewCond(&c.lock)
+
+	switch mode {
+	case SocketModeConnect:
+		go c.loopConnecting()
+	case SocketModeListen:
+		// Remove any socket left behind by a previous instance of Felix.
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale dataplane driver socket: %w", err)
+		}
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on dataplane driver socket: %w", err)
+		}
+		c.listener = l
+		go c.loopAccepting()
+	default:
+		return nil, fmt.Errorf("unknown dataplane driver socket mode %q", mode)
+	}
+	return c, nil
+}
+
+type socketDataplaneConn struct {
+	path     string
+	listener net.Listener
+
+	// lock protects the fields below.  It is held while writing to the connection so that
+	// replays and updates are never interleaved.
+	lock sync.Mutex
+	// cond is signalled whenever conn changes or the connection is stopped.
+	cond          *sync.Cond
+	conn          net.Conn
+	state         *dataplaneState
+	nextSeqNumber uint64
+	stopped       bool
+}
+
+// SendMessage records the message in the state to replay on reconnection and, if a driver is
+// connected, sends it.  Failing to send, including timing out because the driver isn't reading,
+// is not an error: the connection is dropped and the message will be included in the replay when
+// the driver reconnects.
+func (c *socketDataplaneConn) SendMessage(msg interface{}) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state.Update(msg)
+	if c.conn == nil {
+		log.Debug("Dataplane driver not connected; message will be sent when it connects.")
+		return nil
+	}
+	if err := c.writeLocked(c.conn, msg); err != nil {
+		log.WithError(err).Warn("Failed to write to dataplane driver; waiting for it to reconnect.")
+		c.dropLocked(c.conn)
+	}
+	return nil
+}
+
+// RecvMessage returns the next message from the driver, blocking across reconnections.  It only
+// returns an error if the connection has been stopped.
+func (c *socketDataplaneConn) RecvMessage() (msg interface{}, err error) {
+	for {
+		conn := c.waitForConn()
+		if conn == nil {
+			return nil, errStopped
+		}
+		msg, err := readMessage(conn)
+		if err == nil {
+			return msg, nil
+		}
+		log.WithError(err).Warn("Lost connection to dataplane driver; waiting for it to reconnect.")
+		c.lock.Lock()
+		c.dropLocked(conn)
+		c.lock.Unlock()
+	}
+}
+
+// Stop closes the connection and stops connecting or listening.
+func (c *socketDataplaneConn) Stop() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stopped = true
+	if c.listener != nil {
+		_ = c.listener.Close()
+	}
+	if c.conn != nil {
+		c.dropLocked(c.conn)
+	}
+	c.cond.Broadcast()
+}
+
+func (c *socketDataplaneConn) loopConnecting() {
+	logCxt := log.WithField("path", c.path)
+	for {
+		c.lock.Lock()
+		for c.conn != nil && !c.stopped {
+			c.cond.Wait()
+		}
+		stopped := c.stopped
+		c.lock.Unlock()
+		if stopped {
+			return
+		}
+
+		conn, err := net.Dial("unix", c.path)
+		if err != nil {
+			logCxt.WithError(err).Debug("Failed to connect to dataplane driver; will retry.")
+			time.Sleep(reconnectInterval)
+			continue
+		}
+		logCxt.Info("Connected to dataplane driver.")
+		c.attach(conn)
+	}
+}
+
+func (c *socketDataplaneConn) loopAccepting() {
+	logCxt := log.WithField("path", c.path)
+	backoff := minAcceptBackoff
+	for {
+		conn, err := c.listener.Accept()
+		if err != nil {
+			c.lock.Lock()
+			stopped := c.stopped
+			c.lock.Unlock()
+			if stopped {
+				return
+			}
+			// Errors such as running out of file descriptors are usually transient, so keep
+			// listening rather than leaving Felix without a dataplane.
+			logCxt.WithError(err).WithField("retryIn", backoff).Error(
+				"Failed to accept connection from dataplane driver; will retry.")
+			time.Sleep(backoff)
+			backoff = min(backoff*2, maxAcceptBackoff)
+			continue
+		}
+		backoff = minAcceptBackoff
+		logCxt.Info("Dataplane driver connected.")
+		c.attach(conn)
+	}
+}
+
+// attach makes the given connection the current one, replacing any existing connection, and
+// replays the current state to it.
+func (c *socketDataplaneConn) attach(conn net.Conn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.stopped {
+		_ = conn.Close()
+		return
+	}
+	if c.conn != nil {
+		log.Info("Replacing existing connection to dataplane driver.")
+		c.dropLocked(c.conn)
+	}
+
+	// Make the connection available to RecvMessage before replaying; the driver may send status
+	// reports while it processes the replay and it mustn't block doing so.
+	c.conn = conn
+	c.nextSeqNumber = 0
+	c.cond.Broadcast()
+
+	err := c.state.Replay(func(msg interface{}) error {
+		return c.writeLocked(conn, msg)
+	})
+	if err != nil {
+		log.WithError(err).Warn("Failed to replay state to dataplane driver; waiting for it to reconnect.")
+		c.dropLocked(conn)
+		return
+	}
+	log.Info("Replayed current state to dataplane driver.")
+}
+
+func (c *socketDataplaneConn) writeLocked(conn net.Conn, msg interface{}) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	err := writeMessage(conn, msg, c.nextSeqNumber)
+	c.nextSeqNumber += 1
+	return err
+}
+
+// dropLocked closes the given connection and, if it is the current one, clears it so that we
+// wait for a new one.
+func (c *socketDataplaneConn) dropLocked(conn net.Conn) {
+	_ = conn.Close()
+	if c.conn == conn {
+		c.conn = nil
+		c.cond.Broadcast()
+	}
+}
+
+// waitForConn waits until a driver is connected and returns its connection, or returns nil if
+// the connection has been stopped.
+func (c *socketDataplaneConn) waitForConn() net.Conn {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for c.conn == nil && !c.stopped {
+		c.cond.Wait()
+	}
+	return c.conn
+}
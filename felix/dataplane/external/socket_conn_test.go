@@ -0,0 +1,257 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extdataplane
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	pb "google.golang.org/protobuf/proto"
+
+	"github.com/projectcalico/calico/felix/proto"
+)
+
+func init() {
+	reconnectInterval = 10 * time.Millisecond
+	writeTimeout = 200 * time.Millisecond
+	maxAcceptBackoff = 20 * time.Millisecond
+}
+
+// fakeDriver is the driver's end of a socket connection.
+type fakeDriver struct {
+	conn net.Conn
+}
+
+// recv reads the next message that Felix sent to the driver.
+func (d *fakeDriver) recv() *proto.ToDataplane {
+	Expect(d.conn.SetReadDeadline(time.Now().Add(5 * time.Second))).To(Succeed())
+	buf := make([]byte, 8)
+	_, err := io.ReadFull(d.conn, buf)
+	Expect(err).NotTo(HaveOccurred())
+	data := make([]byte, binary.LittleEndian.Uint64(buf))
+	_, err = io.ReadFull(d.conn, data)
+	Expect(err).NotTo(HaveOccurred())
+	var envelope proto.ToDataplane
+	Expect(pb.Unmarshal(data, &envelope)).To(Succeed())
+	return &envelope
+}
+
+func (d *fakeDriver) send(msg *proto.FromDataplane) {
+	data, err := pb.Marshal(msg)
+	Expect(err).NotTo(HaveOccurred())
+	buf := binary.LittleEndian.AppendUint64(nil, uint64(len(data)))
+	_, err = d.conn.Write(append(buf, data...))
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func startFelixSide(t *testing.T, path, mode string) *socketDataplaneConn {
+	c, err := StartSocketDataplaneDriver(path, mode)
+	Expect(err).NotTo(HaveOccurred())
+	t.Cleanup(c.Stop)
+	return c
+}
+
+func TestSocketConnectModeReplaysOnReconnect(t *testing.T) {
+	RegisterTestingT(t)
+
+	path := filepath.Join(t.TempDir(), "driver.sock")
+	l, err := net.Listen("unix", path)
+	Expect(err).NotTo(HaveOccurred())
+
+	// Felix sends its initial state before the driver has accepted the connection.
+	c := startFelixSide(t, path, SocketModeConnect)
+	Expect(c.SendMessage(&proto.IPSetUpdate{Id: "s1", Members: []string{"10.0.0.1"}})).To(Succeed())
+	Expect(c.SendMessage(&proto.InSync{})).To(Succeed())
+
+	conn, err := l.Accept()
+	Expect(err).NotTo(HaveOccurred())
+	d := &fakeDriver{conn: conn}
+	msg := d.recv()
+	Expect(msg.SequenceNumber).To(Equal(uint64(0)))
+	Expect(msg.GetIpsetUpdate().GetMembers()).To(Equal([]string{"10.0.0.1"}))
+	Expect(d.recv().GetInSync()).NotTo(BeNil())
+
+	// Subsequent updates are sent as they happen.
+	Expect(c.SendMessage(&proto.IPSetDeltaUpdate{Id: "s1", AddedMembers: []string{"10.0.0.2"}})).To(Succeed())
+	msg = d.recv()
+	Expect(msg.SequenceNumber).To(Equal(uint64(2)))
+	Expect(msg.GetIpsetDeltaUpdate().GetAddedMembers()).To(Equal([]string{"10.0.0.2"}))
+
+	// Status reports from the driver are passed through.
+	d.send(&proto.FromDataplane{Payload: &proto.FromDataplane_ProcessStatusUpdate{
+		ProcessStatusUpdate: &proto.ProcessStatusUpdate{IsoTimestamp: "now"},
+	}})
+	recvd, err := c.RecvMessage()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(recvd.(*proto.ProcessStatusUpdate).IsoTimestamp).To(Equal("now"))
+
+	// Simulate the driver restarting.  RecvMessage blocks across the restart rather than failing.
+	recvDone := make(chan interface{})
+	go func() {
+		msg, _ := c.RecvMessage()
+		recvDone <- msg
+	}()
+	Expect(conn.Close()).To(Succeed())
+	Expect(l.Close()).To(Succeed())
+	Eventually(func() bool {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return c.conn == nil
+	}, "5s").Should(BeTrue())
+	Expect(c.SendMessage(&proto.ActivePolicyUpdate{Id: &proto.PolicyID{Tier: "default", Name: "pol"}})).To(Succeed())
+	Consistently(recvDone, "50ms").ShouldNot(Receive())
+
+	l, err = net.Listen("unix", path)
+	Expect(err).NotTo(HaveOccurred())
+	defer l.Close()
+	conn, err = l.Accept()
+	Expect(err).NotTo(HaveOccurred())
+	d = &fakeDriver{conn: conn}
+	defer conn.Close()
+
+	// The new connection gets the full current state, including the update that was made while
+	// the driver was disconnected, and the IP set delta folded into the IP set.
+	msg = d.recv()
+	Expect(msg.SequenceNumber).To(Equal(uint64(0)))
+	Expect(msg.GetIpsetUpdate().GetMembers()).To(ConsistOf("10.0.0.1", "10.0.0.2"))
+	Expect(d.recv().GetActivePolicyUpdate().GetId().GetName()).To(Equal("pol"))
+	Expect(d.recv().GetInSync()).NotTo(BeNil())
+
+	d.send(&proto.FromDataplane{Payload: &proto.FromDataplane_ProcessStatusUpdate{
+		ProcessStatusUpdate: &proto.ProcessStatusUpdate{IsoTimestamp: "later"},
+	}})
+	var recvd2 interface{}
+	Eventually(recvDone, "5s").Should(Receive(&recvd2))
+	Expect(recvd2.(*proto.ProcessStatusUpdate).IsoTimestamp).To(Equal("later"))
+}
+
+func TestSocketListenMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	path := filepath.Join(t.TempDir(), "felix.sock")
+	c := startFelixSide(t, path, SocketModeListen)
+	Expect(c.SendMessage(&proto.ConfigUpdate{Config: map[string]string{"foo": "bar"}})).To(Succeed())
+
+	conn, err := net.Dial("unix", path)
+	Expect(err).NotTo(HaveOccurred())
+	d := &fakeDriver{conn: conn}
+	Expect(d.recv().GetConfigUpdate().GetConfig()).To(Equal(map[string]string{"foo": "bar"}))
+
+	// A second connection replaces the first.
+	conn2, err := net.Dial("unix", path)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn2.Close()
+	d2 := &fakeDriver{conn: conn2}
+	Expect(d2.recv().GetConfigUpdate()).NotTo(BeNil())
+	Expect(c.SendMessage(&proto.Encapsulation{VxlanEnabled: true})).To(Succeed())
+	Expect(d2.recv().GetEncapsulation().GetVxlanEnabled()).To(BeTrue())
+
+	_, err = io.ReadFull(conn, make([]byte, 1))
+	Expect(err).To(HaveOccurred())
+}
+
+func TestSocketDropsDriverThatStopsReading(t *testing.T) {
+	RegisterTestingT(t)
+
+	path := filepath.Join(t.TempDir(), "felix.sock")
+	c := startFelixSide(t, path, SocketModeListen)
+	conn, err := net.Dial("unix", path)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+	Eventually(func() net.Conn {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		return c.conn
+	}, "5s").ShouldNot(BeNil())
+
+	// The driver never reads, so a message larger than the socket buffer can't be written.
+	// SendMessage must give up rather than blocking forever.
+	members := make([]string, 100000)
+	for i := range members {
+		members[i] = "10.0.0.1"
+	}
+	start := time.Now()
+	Expect(c.SendMessage(&proto.IPSetUpdate{Id: "s1", Members: members})).To(Succeed())
+	Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	c.lock.Lock()
+	Expect(c.conn).To(BeNil())
+	c.lock.Unlock()
+}
+
+// flakyListener fails the first few calls to Accept.
+type flakyListener struct {
+	net.Listener
+	failures atomic.Int32
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.failures.Add(-1) >= 0 {
+		return nil, errors.New("too many open files")
+	}
+	return l.Listener.Accept()
+}
+
+func TestSocketRetriesAfterAcceptError(t *testing.T) {
+	RegisterTestingT(t)
+
+	path := filepath.Join(t.TempDir(), "felix.sock")
+	l, err := net.Listen("unix", path)
+	Expect(err).NotTo(HaveOccurred())
+	fl := &flakyListener{Listener: l}
+	fl.failures.Store(3)
+	c := &socketDataplaneConn{path: path, listener: fl, state: newDataplaneState()}
+	c.cond = sync.NewCond(&c.lock)
+	t.Cleanup(c.Stop)
+	go c.loopAccepting()
+	Expect(c.SendMessage(&proto.ConfigUpdate{Config: map[string]string{"foo": "bar"}})).To(Succeed())
+
+	conn, err := net.Dial("unix", path)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+	d := &fakeDriver{conn: conn}
+	Expect(d.recv().GetConfigUpdate().GetConfig()).To(Equal(map[string]string{"foo": "bar"}))
+	Expect(fl.failures.Load()).To(BeNumerically("<", 0))
+}
+
+func TestSocketStop(t *testing.T) {
+	RegisterTestingT(t)
+
+	path := filepath.Join(t.TempDir(), "felix.sock")
+	c, err := StartSocketDataplaneDriver(path, SocketModeListen)
+	Expect(err).NotTo(HaveOccurred())
+
+	errC := make(chan error)
+	go func() {
+		_, err := c.RecvMessage()
+		errC <- err
+	}()
+	c.Stop()
+	Eventually(errC, "5s").Should(Receive(Equal(errStopped)))
+}
+
+func TestSocketBadMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := StartSocketDataplaneDriver(filepath.Join(t.TempDir(), "felix.sock"), "Sideways")
+	Expect(err).To(MatchError(`unknown dataplane driver socket mode "Sideways"`))
+}
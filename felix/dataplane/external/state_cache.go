@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extdataplane
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/types"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// stateKind identifies a class of dataplane state.  The kinds are declared in the order in which
+// they are replayed to a newly-connected driver.  That mirrors the order in which the calculation
+// graph's EventSequencer flushes updates so that, for example, IP sets are sent before the policies
+// that reference them.
+type stateKind int
+
+const (
+	kindConfig stateKind = iota
+	kindIPSet
+	kindPolicy
+	kindProfile
+	kindHostEndpoint
+	kindWorkloadEndpoint
+	kindServiceAccount
+	kindNamespace
+	kindVTEP
+	kindRoute
+	kindWireguardEndpoint
+	kindWireguardEndpointV6
+	kindHostMetadata
+	kindHostMetadataV6
+	kindHostMetadataV4V6
+	kindIPAMPool
+	kindEncapsulation
+	kindGlobalBGPConfig
+	kindService
+
+	numStateKinds
+)
+
+// singletonKey is the key used for kinds of state that only have one instance.
+type singletonKey struct{}
+
+// serviceKey identifies a Service; ServiceUpdate has no ID message.
+type serviceKey struct {
+	namespace, name string
+}
+
+// dataplaneState tracks the net effect of the messages that Felix has sent to the dataplane
+// driver so that it can be replayed in full to a driver that (re)connects.  Each update message
+// replaces the previous update for the same object and each remove message deletes it.  IP set
+// delta updates are folded into the membership of the IP set that they apply to.
+type dataplaneState struct {
+	updates      [numStateKinds]map[any]any
+	ipSetMembers map[string]set.Set[string]
+	inSync       bool
+}
+
+func newDataplaneState() *dataplaneState {
+	s := &dataplaneState{
+		ipSetMembers: map[string]set.Set[string]{},
+	}
+	for i := range s.updates {
+		s.updates[i] = map[any]any{}
+	}
+	return s
+}
+
+// Update records the effect of the given message, which must be one that can be sent to the
+// dataplane driver.
+func (s *dataplaneState) Update(msg interface{}) {
+	switch msg := msg.(type) {
+	case *proto.InSync:
+		s.inSync = true
+	case *proto.ConfigUpdate:
+		s.set(kindConfig, singletonKey{}, msg)
+
+	case *proto.IPSetUpdate:
+		s.set(kindIPSet, msg.Id, msg)
+		s.ipSetMembers[msg.Id] = set.FromArray(msg.Members)
+	case *proto.IPSetDeltaUpdate:
+		members, ok := s.ipSetMembers[msg.Id]
+		if !ok {
+			log.WithField("id", msg.Id).Warn("Delta update for unknown IP set; ignoring.")
+			return
+		}
+		for _, m := range msg.RemovedMembers {
+			members.Discard(m)
+		}
+		members.AddAll(msg.AddedMembers)
+	case *proto.IPSetRemove:
+		s.remove(kindIPSet, msg.Id)
+		delete(s.ipSetMembers, msg.Id)
+
+	case *proto.ActivePolicyUpdate:
+		s.set(kindPolicy, types.ProtoToPolicyID(msg.Id), msg)
+	case *proto.ActivePolicyRemove:
+		s.remove(kindPolicy, types.ProtoToPolicyID(msg.Id))
+	case *proto.ActiveProfileUpdate:
+		s.set(kindProfile, types.ProtoToProfileID(msg.Id), msg)
+	case *proto.ActiveProfileRemove:
+		s.remove(kindProfile, types.ProtoToProfileID(msg.Id))
+	case *proto.HostEndpointUpdate:
+		s.set(kindHostEndpoint, types.ProtoToHostEndpointID(msg.Id), msg)
+	case *proto.HostEndpointRemove:
+		s.remove(kindHostEndpoint, types.ProtoToHostEndpointID(msg.Id))
+	case *proto.WorkloadEndpointUpdate:
+		s.set(kindWorkloadEndpoint, types.ProtoToWorkloadEndpointID(msg.Id), msg)
+	case *proto.WorkloadEndpointRemove:
+		s.remove(kindWorkloadEndpoint, types.ProtoToWorkloadEndpointID(msg.Id))
+	case *proto.ServiceAccountUpdate:
+		s.set(kindServiceAccount, types.ProtoToServiceAccountID(msg.Id), msg)
+	case *proto.ServiceAccountRemove:
+		s.remove(kindServiceAccount, types.ProtoToServiceAccountID(msg.Id))
+	case *proto.NamespaceUpdate:
+		s.set(kindNamespace, types.ProtoToNamespaceID(msg.Id), msg)
+	case *proto.NamespaceRemove:
+		s.remove(kindNamespace, types.ProtoToNamespaceID(msg.Id))
+
+	case *proto.VXLANTunnelEndpointUpdate:
+		s.set(kindVTEP, msg.Node, msg)
+	case *proto.VXLANTunnelEndpointRemove:
+		s.remove(kindVTEP, msg.Node)
+	case *proto.RouteUpdate:
+		s.set(kindRoute, msg.Dst, msg)
+	case *proto.RouteRemove:
+		s.remove(kindRoute, msg.Dst)
+	case *proto.WireguardEndpointUpdate:
+		s.set(kindWireguardEndpoint, msg.Hostname, msg)
+	case *proto.WireguardEndpointRemove:
+		s.remove(kindWireguardEndpoint, msg.Hostname)
+	case *proto.WireguardEndpointV6Update:
+		s.set(kindWireguardEndpointV6, msg.Hostname, msg)
+	case *proto.WireguardEndpointV6Remove:
+		s.remove(kindWireguardEndpointV6, msg.Hostname)
+
+	case *proto.HostMetadataUpdate:
+		s.set(kindHostMetadata, msg.Hostname, msg)
+	case *proto.HostMetadataRemove:
+		s.remove(kindHostMetadata, msg.Hostname)
+	case *proto.HostMetadataV6Update:
+		s.set(kindHostMetadataV6, msg.Hostname, msg)
+	case *proto.HostMetadataV6Remove:
+		s.remove(kindHostMetadataV6, msg.Hostname)
+	case *proto.HostMetadataV4V6Update:
+		s.set(kindHostMetadataV4V6, msg.Hostname, msg)
+	case *proto.HostMetadataV4V6Remove:
+		s.remove(kindHostMetadataV4V6, msg.Hostname)
+	case *proto.IPAMPoolUpdate:
+		s.set(kindIPAMPool, msg.Id, msg)
+	case *proto.IPAMPoolRemove:
+		s.remove(kindIPAMPool, msg.Id)
+	case *proto.Encapsulation:
+		s.set(kindEncapsulation, singletonKey{}, msg)
+	case *proto.GlobalBGPConfigUpdate:
+		s.set(kindGlobalBGPConfig, singletonKey{}, msg)
+	case *proto.ServiceUpdate:
+		s.set(kindService, serviceKey{msg.Namespace, msg.Name}, msg)
+	case *proto.ServiceRemove:
+		s.remove(kindService, serviceKey{msg.Namespace, msg.Name})
+
+	default:
+		log.WithField("msg", msg).Warnf("Not tracking state for unknown message type %T", msg)
+	}
+}
+
+func (s *dataplaneState) set(kind stateKind, key, msg any) {
+	s.updates[kind][key] = msg
+}
+
+func (s *dataplaneState) remove(kind stateKind, key any) {
+	delete(s.updates[kind], key)
+}
+
+// Replay calls the given function with a sequence of messages that recreates the current state
+// from scratch, followed by an InSync message if Felix has already reported that it is in sync.
+// It stops at the first error returned by the function.
+func (s *dataplaneState) Replay(f func(msg interface{}) error) error {
+	for kind := range s.updates {
+		for key, msg := range s.updates[kind] {
+			if stateKind(kind) == kindIPSet {
+				// Send the current membership, which may differ from the original update.
+				u := msg.(*proto.IPSetUpdate)
+				msg = &proto.IPSetUpdate{
+					Id:      u.Id,
+					Type:    u.Type,
+					Members: s.ipSetMembers[key.(string)].Slice(),
+				}
+			}
+			if err := f(msg); err != nil {
+				return err
+			}
+		}
+	}
+	if s.inSync {
+		return f(&proto.InSync{})
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extdataplane
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/proto"
+)
+
+func replayAll(s *dataplaneState) []interface{} {
+	var msgs []interface{}
+	Expect(s.Replay(func(msg interface{}) error {
+		msgs = append(msgs, msg)
+		return nil
+	})).To(Succeed())
+	return msgs
+}
+
+func TestDataplaneStateReplayOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := newDataplaneState()
+	wep := &proto.WorkloadEndpointUpdate{
+		Id: &proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "default/pod", EndpointId: "eth0"},
+	}
+	pol := &proto.ActivePolicyUpdate{Id: &proto.PolicyID{Tier: "default", Name: "pol"}}
+	route := &proto.RouteUpdate{Dst: "10.0.0.0/26"}
+	vtep := &proto.VXLANTunnelEndpointUpdate{Node: "node1"}
+	cfg := &proto.ConfigUpdate{Config: map[string]string{"foo": "bar"}}
+
+	// Send the updates in the opposite order to the one in which they must be replayed.
+	s.Update(route)
+	s.Update(vtep)
+	s.Update(wep)
+	s.Update(pol)
+	s.Update(&proto.IPSetUpdate{Id: "s1", Members: []string{"10.0.0.1"}})
+	s.Update(cfg)
+
+	Expect(replayAll(s)).To(Equal([]interface{}{
+		cfg,
+		&proto.IPSetUpdate{Id: "s1", Members: []string{"10.0.0.1"}},
+		pol,
+		wep,
+		vtep,
+		route,
+	}))
+
+	// InSync is only replayed once it has been sent, and always comes last.
+	s.Update(&proto.InSync{})
+	msgs := replayAll(s)
+	Expect(msgs).To(HaveLen(7))
+	Expect(msgs[6]).To(Equal(&proto.InSync{}))
+}
+
+func TestDataplaneStateUpdatesAndRemoves(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := newDataplaneState()
+	s.Update(&proto.ActiveProfileUpdate{Id: &proto.ProfileID{Name: "prof"}, Profile: &proto.Profile{}})
+	s.Update(&proto.ActiveProfileUpdate{Id: &proto.ProfileID{Name: "prof"}})
+	s.Update(&proto.ServiceUpdate{Namespace: "ns", Name: "svc", Type: "ClusterIP"})
+	s.Update(&proto.ServiceUpdate{Namespace: "ns", Name: "svc2"})
+	s.Update(&proto.ServiceRemove{Namespace: "ns", Name: "svc2"})
+	s.Update(&proto.HostMetadataUpdate{Hostname: "node1", Ipv4Addr: "192.168.0.1"})
+	s.Update(&proto.HostMetadataRemove{Hostname: "node1"})
+
+	Expect(replayAll(s)).To(Equal([]interface{}{
+		&proto.ActiveProfileUpdate{Id: &proto.ProfileID{Name: "prof"}},
+		&proto.ServiceUpdate{Namespace: "ns", Name: "svc", Type: "ClusterIP"},
+	}))
+}
+
+func TestDataplaneStateIPSetDeltas(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := newDataplaneState()
+	orig := &proto.IPSetUpdate{
+		Id:      "s1",
+		Type:    proto.IPSetUpdate_IP_AND_PORT,
+		Members: []string{"10.0.0.1,tcp:80", "10.0.0.2,tcp:80"},
+	}
+	s.Update(orig)
+	s.Update(&proto.IPSetDeltaUpdate{
+		Id:             "s1",
+		AddedMembers:   []string{"10.0.0.3,tcp:80"},
+		RemovedMembers: []string{"10.0.0.1,tcp:80"},
+	})
+	// A delta for an IP set that we don't know about is ignored.
+	s.Update(&proto.IPSetDeltaUpdate{Id: "s2", AddedMembers: []string{"10.0.0.4"}})
+
+	msgs := replayAll(s)
+	Expect(msgs).To(HaveLen(1))
+	u := msgs[0].(*proto.IPSetUpdate)
+	Expect(u.Id).To(Equal("s1"))
+	Expect(u.Type).To(Equal(proto.IPSetUpdate_IP_AND_PORT))
+	Expect(u.Members).To(ConsistOf("10.0.0.2,tcp:80", "10.0.0.3,tcp:80"))
+
+	// The original message is not modified.
+	Expect(orig.Members).To(Equal([]string{"10.0.0.1,tcp:80", "10.0.0.2,tcp:80"}))
+
+	s.Update(&proto.IPSetRemove{Id: "s1"})
+	Expect(replayAll(s)).To(BeEmpty())
+}
+
+func TestDataplaneStateReplayStopsOnError(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := newDataplaneState()
+	s.Update(&proto.ConfigUpdate{})
+	s.Update(&proto.Encapsulation{IpipEnabled: true})
+	s.Update(&proto.InSync{})
+
+	calls := 0
+	err := s.Replay(func(msg interface{}) error {
+		calls++
+		return errors.New("broken pipe")
+	})
+	Expect(err).To(MatchError("broken pipe"))
+	Expect(calls).To(Equal(1))
+}
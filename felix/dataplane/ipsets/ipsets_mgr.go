@@ -17,6 +17,7 @@ package ipsets
 import (
 	log "github.com/sirupsen/logrus"
 
+	"github.com/projectcalico/calico/felix/dnssnoop"
 	"github.com/projectcalico/calico/felix/ipsets"
 	"github.com/projectcalico/calico/felix/proto"
 	"github.com/projectcalico/calico/libcalico-go/lib/set"
@@ -38,6 +39,11 @@ type IPSetsDataplane interface {
 
 type UpdateListener = ipsets.UpdateListener
 
+// DomainStore is the source of domain name to IP mappings for domain sets.
+type DomainStore interface {
+	IPsForDomain(domain string) []string
+}
+
 // Except for domain IP sets, IPSetsManager simply passes through IP set updates from the datastore
 // to the ipsets.IPSets dataplane layer.  For domain IP sets - which hereafter we'll just call
 // "domain sets" - IPSetsManager handles the resolution from domain names to expiring IPs.
@@ -45,12 +51,20 @@ type IPSetsManager struct {
 	dataplanes []IPSetsDataplane
 	maxSize    int
 	lg         *log.Entry
+
+	domainStore DomainStore
+	// domainSets maps from domain set ID to the domains in the set.
+	domainSets map[string]set.Set[string]
+	// dirtyDomainSets holds the IDs of domain sets whose IPs need to be recalculated.
+	dirtyDomainSets set.Set[string]
 }
 
 func NewIPSetsManager(name string, ipsets_ IPSetsDataplane, maxIPSetSize int) *IPSetsManager {
 	m := &IPSetsManager{
-		maxSize: maxIPSetSize,
-		lg:      log.WithField("name", name),
+		maxSize:         maxIPSetSize,
+		lg:              log.WithField("name", name),
+		domainSets:      map[string]set.Set[string]{},
+		dirtyDomainSets: set.New[string](),
 	}
 
 	if ipsets_ != nil {
@@ -64,6 +78,40 @@ func (m *IPSetsManager) AddDataplane(dp IPSetsDataplane) {
 	m.dataplanes = append(m.dataplanes, dp)
 }
 
+// SetDomainStore sets the store used to resolve the domains in domain sets.  Without a store,
+// domain sets are programmed with no members.
+func (m *IPSetsManager) SetDomainStore(store DomainStore) {
+	m.domainStore = store
+}
+
+// OnDomainChange marks the domain sets that match any of the given (normalised) names as needing
+// recalculation.
+func (m *IPSetsManager) OnDomainChange(names set.Set[string]) {
+	for setID, domains := range m.domainSets {
+		if m.dirtyDomainSets.Contains(setID) {
+			continue
+		}
+		if domainsMatchAny(domains, names) {
+			m.lg.WithField("ipSetId", setID).Debug("Domain set needs recalculation")
+			m.dirtyDomainSets.Add(setID)
+		}
+	}
+}
+
+func domainsMatchAny(domains set.Set[string], names set.Set[string]) bool {
+	for _, domain := range domains.Slice() {
+		if names.Contains(domain) {
+			return true
+		}
+		for _, name := range names.Slice() {
+			if dnssnoop.DomainMatches(domain, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (m *IPSetsManager) GetIPSetType(setID string) (typ ipsets.IPSetType, err error) {
 	for _, dp := range m.dataplanes {
 		typ, err = dp.GetTypeOf(setID)
@@ -89,6 +137,16 @@ func (m *IPSetsManager) OnUpdate(msg interface{}) {
 	// IP set-related messages, these are extremely common.
 	case *proto.IPSetDeltaUpdate:
 		m.lg.WithField("ipSetId", msg.Id).Debug("IP set delta update")
+		if domains, ok := m.domainSets[msg.Id]; ok {
+			for _, d := range msg.RemovedMembers {
+				domains.Discard(dnssnoop.NormaliseDomain(d))
+			}
+			for _, d := range msg.AddedMembers {
+				domains.Add(dnssnoop.NormaliseDomain(d))
+			}
+			m.dirtyDomainSets.Add(msg.Id)
+			return
+		}
 		for _, dp := range m.dataplanes {
 			dp.AddMembers(msg.Id, msg.AddedMembers)
 			dp.RemoveMembers(msg.Id, msg.RemovedMembers)
@@ -103,6 +161,17 @@ func (m *IPSetsManager) OnUpdate(msg interface{}) {
 			setType = ipsets.IPSetTypeHashNet
 		case proto.IPSetUpdate_IP_AND_PORT:
 			setType = ipsets.IPSetTypeHashIPPort
+		case proto.IPSetUpdate_DOMAIN:
+			// Domain sets are programmed as IP sets containing the IPs that the domains
+			// currently resolve to.
+			domains := set.New[string]()
+			for _, d := range msg.Members {
+				domains.Add(dnssnoop.NormaliseDomain(d))
+			}
+			m.domainSets[msg.Id] = domains
+			m.dirtyDomainSets.Discard(msg.Id)
+			m.programDomainSet(msg.Id)
+			return
 		default:
 			m.lg.WithField("type", msg.Type).Panic("Unknown IP set type")
 		}
@@ -116,13 +185,41 @@ func (m *IPSetsManager) OnUpdate(msg interface{}) {
 		}
 	case *proto.IPSetRemove:
 		m.lg.WithField("ipSetId", msg.Id).Debug("IP set remove")
+		delete(m.domainSets, msg.Id)
+		m.dirtyDomainSets.Discard(msg.Id)
 		for _, dp := range m.dataplanes {
 			dp.RemoveIPSet(msg.Id)
 		}
 	}
 }
 
+func (m *IPSetsManager) programDomainSet(setID string) {
+	var ips []string
+	if m.domainStore != nil {
+		ipSet := set.New[string]()
+		for _, domain := range m.domainSets[setID].Slice() {
+			ipSet.AddAll(m.domainStore.IPsForDomain(domain))
+		}
+		ips = ipSet.Slice()
+	} else {
+		m.lg.WithField("ipSetId", setID).Debug("No domain store, domain set will be empty")
+	}
+	metadata := ipsets.IPSetMetadata{
+		Type:    ipsets.IPSetTypeHashIP,
+		SetID:   setID,
+		MaxSize: m.maxSize,
+	}
+	// The dataplanes filter out IPs of the wrong family.
+	for _, dp := range m.dataplanes {
+		dp.AddOrReplaceIPSet(metadata, ips)
+	}
+}
+
 func (m *IPSetsManager) CompleteDeferredWork() error {
-	// Nothing to do, we don't defer any work.
+	// Only domain sets defer work, so that a burst of DNS changes results in one recalculation.
+	for _, setID := range m.dirtyDomainSets.Slice() {
+		m.programDomainSet(setID)
+	}
+	m.dirtyDomainSets.Clear()
 	return nil
 }
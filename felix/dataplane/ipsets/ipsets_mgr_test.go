@@ -15,6 +15,8 @@
 package ipsets
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -144,4 +146,89 @@ var _ = Describe("IP Sets manager", func() {
 	for _, testCase := range ipsetsMgrTestCases {
 		IPsetsMgrTest1(testCase.ipsetID, testCase.ipsetType, testCase.ipsetMembers)
 	}
+
+	Describe("with a domain store", func() {
+		var store *mockDomainStore
+
+		BeforeEach(func() {
+			store = &mockDomainStore{ips: map[string][]string{
+				"example.com":   {"10.0.0.1"},
+				"a.example.org": {"10.0.0.2"},
+				"b.example.org": {"10.0.0.3"},
+			}}
+			ipsetsMgr.SetDomainStore(store)
+			ipsetsMgr.OnUpdate(&proto.IPSetUpdate{
+				Id:      "d:1",
+				Members: []string{"Example.com.", "*.example.org"},
+				Type:    proto.IPSetUpdate_DOMAIN,
+			})
+			err := ipsetsMgr.CompleteDeferredWork()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should program the domain set with the resolved IPs", func() {
+			Expect(ipSets.Members["d:1"]).To(Equal(set.From("10.0.0.1", "10.0.0.2", "10.0.0.3")))
+		})
+
+		It("should recalculate the set when a matching domain changes", func() {
+			store.ips["c.example.org"] = []string{"10.0.0.4"}
+			ipsetsMgr.OnDomainChange(set.From("c.example.org"))
+			Expect(ipSets.Members["d:1"]).To(Equal(set.From("10.0.0.1", "10.0.0.2", "10.0.0.3")))
+			err := ipsetsMgr.CompleteDeferredWork()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipSets.Members["d:1"]).To(Equal(set.From("10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4")))
+		})
+
+		It("should ignore changes to other domains", func() {
+			ipSets.AddOrReplaceCalled = false
+			ipsetsMgr.OnDomainChange(set.From("example.net"))
+			err := ipsetsMgr.CompleteDeferredWork()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipSets.AddOrReplaceCalled).To(BeFalse())
+		})
+
+		It("should handle delta updates to the domains", func() {
+			ipsetsMgr.OnUpdate(&proto.IPSetDeltaUpdate{
+				Id:             "d:1",
+				RemovedMembers: []string{"*.example.org"},
+			})
+			err := ipsetsMgr.CompleteDeferredWork()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipSets.Members["d:1"]).To(Equal(set.From("10.0.0.1")))
+		})
+
+		It("should remove the domain set", func() {
+			ipsetsMgr.OnUpdate(&proto.IPSetRemove{Id: "d:1"})
+			ipsetsMgr.OnDomainChange(set.From("example.com"))
+			err := ipsetsMgr.CompleteDeferredWork()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipSets.Members["d:1"]).To(BeNil())
+		})
+	})
+
+	It("should program an empty domain set without a domain store", func() {
+		ipsetsMgr.OnUpdate(&proto.IPSetUpdate{
+			Id:      "d:1",
+			Members: []string{"example.com"},
+			Type:    proto.IPSetUpdate_DOMAIN,
+		})
+		Expect(ipSets.Members["d:1"]).To(Equal(set.New[string]()))
+	})
 })
+
+type mockDomainStore struct {
+	ips map[string][]string
+}
+
+func (s *mockDomainStore) IPsForDomain(domain string) []string {
+	if suffix, ok := strings.CutPrefix(domain, "*"); ok {
+		var ips []string
+		for name, nameIPs := range s.ips {
+			if strings.HasSuffix(name, suffix) {
+				ips = append(ips, nameIPs...)
+			}
+		}
+		return ips
+	}
+	return s.ips[domain]
+}
@@ -4278,7 +4278,7 @@ func (m *bpfEndpointManager) ruleMatchID(
 		a = rules.RuleActionPass
 	case "deny":
 		a = rules.RuleActionDeny
-	case "log":
+	case "log", "setdscp":
 		// If we get it here, we dont know what to do about that, 0 means
 		// invalid, but does not break anything.
 		return 0
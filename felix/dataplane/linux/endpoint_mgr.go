@@ -67,13 +67,13 @@ func newEndpointManagerCallbacks(callbacks *common.Callbacks, ipVersion uint8) e
 		}
 	} else {
 		return endpointManagerCallbacks{
-			addInterface:           &common.AddInterfaceFuncs{},
-			removeInterface:        &common.RemoveInterfaceFuncs{},
-			updateInterface:        &common.UpdateInterfaceFuncs{},
-			updateHostEndpoint:     &common.UpdateHostEndpointFuncs{},
-			removeHostEndpoint:     &common.RemoveHostEndpointFuncs{},
-			updateWorkloadEndpoint: &common.UpdateWorkloadEndpointFuncs{},
-			removeWorkloadEndpoint: &common.RemoveWorkloadEndpointFuncs{},
+			addInterface:           callbacks.AddInterfaceV6,
+			removeInterface:        callbacks.RemoveInterfaceV6,
+			updateInterface:        callbacks.UpdateInterfaceV6,
+			updateHostEndpoint:     callbacks.UpdateHostEndpointV6,
+			removeHostEndpoint:     callbacks.RemoveHostEndpointV6,
+			updateWorkloadEndpoint: callbacks.UpdateWorkloadEndpointV6,
+			removeWorkloadEndpoint: callbacks.RemoveWorkloadEndpointV6,
 		}
 	}
 }
@@ -159,6 +159,15 @@ type endpointManager struct {
 	activePolicySelectors map[types.PolicyID]string
 	policyChainRefCounts  map[string]int // Chain name to count.
 
+	// dscpPolicyIDs contains the active policies that have DSCP rules.
+	dscpPolicyIDs set.Set[types.PolicyID]
+	// DSCP chains that we've programmed in the mangle table, for workloads and host endpoints,
+	// along with their dispatch chains.
+	activeWlIDToDSCPChain        map[types.WorkloadEndpointID]*generictables.Chain
+	activeWlDSCPDispatchChains   map[string]*generictables.Chain
+	activeHostIfaceToDSCPChain   map[string]*generictables.Chain
+	activeHostDSCPDispatchChains map[string]*generictables.Chain
+
 	// Workload endpoints that would be locally active but are 'shadowed' by other endpoints
 	// with the same interface name.
 	shadowedWlEndpoints map[types.WorkloadEndpointID]*proto.WorkloadEndpoint
@@ -337,6 +346,12 @@ func newEndpointManagerWithShims(
 		activePolicySelectors: map[types.PolicyID]string{},
 		policyChainRefCounts:  map[string]int{},
 
+		dscpPolicyIDs:                set.New[types.PolicyID](),
+		activeWlIDToDSCPChain:        map[types.WorkloadEndpointID]*generictables.Chain{},
+		activeWlDSCPDispatchChains:   map[string]*generictables.Chain{},
+		activeHostIfaceToDSCPChain:   map[string]*generictables.Chain{},
+		activeHostDSCPDispatchChains: map[string]*generictables.Chain{},
+
 		shadowedWlEndpoints: map[types.WorkloadEndpointID]*proto.WorkloadEndpoint{},
 
 		wlIfaceNamesToReconfigure: set.New[string](),
@@ -415,8 +430,22 @@ func (m *endpointManager) OnUpdate(protoBufMsg interface{}) {
 		}
 		m.hostEndpointsDirty = true
 	case *proto.ActivePolicyUpdate:
-		newSel := msg.Policy.OriginalSelector
 		id := types.ProtoToPolicyID(msg.GetId())
+		if hasDSCP := rules.PolicyHasDSCPRules(msg.Policy); hasDSCP != m.dscpPolicyIDs.Contains(id) {
+			// Endpoints only get DSCP chains if some of their policies have DSCP rules, so
+			// mark any endpoints using this policy for update.
+			log.WithFields(log.Fields{
+				"id":      id,
+				"hasDSCP": hasDSCP,
+			}).Debug("Active policy DSCP rules added/removed.")
+			if hasDSCP {
+				m.dscpPolicyIDs.Add(id)
+			} else {
+				m.dscpPolicyIDs.Discard(id)
+			}
+			m.dirtyPolicyIDs.Add(id)
+		}
+		newSel := msg.Policy.OriginalSelector
 		if oldSel, ok := m.activePolicySelectors[id]; ok && oldSel == newSel {
 			// No change that we care about.
 			return
@@ -438,6 +467,7 @@ func (m *endpointManager) OnUpdate(protoBufMsg interface{}) {
 		// so we no longer need to track it at all.
 		id := types.ProtoToPolicyID(msg.GetId())
 		m.dirtyPolicyIDs.Discard(id)
+		m.dscpPolicyIDs.Discard(id)
 		delete(m.activePolicySelectors, id)
 	case *proto.GlobalBGPConfigUpdate:
 		log.Debug("GlobalBGPConfig updated.")
@@ -702,6 +732,10 @@ func (m *endpointManager) resolveWorkloadEndpoints() {
 		m.callbacks.InvokeRemoveWorkload(oldWorkload)
 		m.filterTable.RemoveChains(m.activeWlIDToChains[id])
 		delete(m.activeWlIDToChains, id)
+		if dscpChain := m.activeWlIDToDSCPChain[id]; dscpChain != nil {
+			m.mangleTable.RemoveChainByName(dscpChain.Name)
+			delete(m.activeWlIDToDSCPChain, id)
+		}
 		if oldWorkload != nil {
 			m.epMarkMapper.ReleaseEndpointMark(oldWorkload.Name)
 			// Remove any routes from the routing table.  The RouteTable will remove any
@@ -890,6 +924,14 @@ func (m *endpointManager) resolveWorkloadEndpoints() {
 		// Rewrite the dispatch chains if they've changed.
 		newDispatchChains := m.ruleRenderer.WorkloadDispatchChains(m.activeWlEndpoints)
 		m.updateDispatchChains(m.activeWlDispatchChains, newDispatchChains, m.filterTable)
+
+		// Similarly for the DSCP dispatch chains in the mangle table.
+		var dscpIfaceNames []string
+		for id := range m.activeWlIDToDSCPChain {
+			dscpIfaceNames = append(dscpIfaceNames, m.activeWlEndpoints[id].Name)
+		}
+		newDSCPDispatchChains := m.ruleRenderer.WorkloadDSCPDispatchChains(dscpIfaceNames)
+		m.updateDispatchChains(m.activeWlDSCPDispatchChains, newDSCPDispatchChains, m.mangleTable)
 		m.needToCheckDispatchChains = false
 
 		// Set flag to update endpoint mark chains.
@@ -941,6 +983,17 @@ func (m *endpointManager) updateWorkloadEndpointChains(
 	)
 	m.filterTable.UpdateChains(chains)
 	m.activeWlIDToChains[id] = chains
+
+	dscpChain := m.ruleRenderer.WorkloadEndpointToDSCPChain(workload.Name, tierGroups, m.dscpPolicyIDs)
+	if oldChain := m.activeWlIDToDSCPChain[id]; oldChain != nil && (dscpChain == nil || oldChain.Name != dscpChain.Name) {
+		m.mangleTable.RemoveChainByName(oldChain.Name)
+	}
+	if dscpChain != nil {
+		m.mangleTable.UpdateChain(dscpChain)
+		m.activeWlIDToDSCPChain[id] = dscpChain
+	} else {
+		delete(m.activeWlIDToDSCPChain, id)
+	}
 }
 
 type tierGroupFilter int
@@ -1199,6 +1252,7 @@ func (m *endpointManager) updateHostEndpoints() {
 		// Build iptables chains for normal and apply-on-forward host endpoint policy.
 		newHostIfaceFiltChains := map[string][]*generictables.Chain{}
 		newHostIfaceMangleEgressChains := map[string][]*generictables.Chain{}
+		newHostIfaceDSCPChains := map[string]*generictables.Chain{}
 		for ifaceName, id := range newIfaceNameToHostEpID {
 			log.WithField("id", id).Info("Updating host endpoint normal policy chains.")
 			hostEp := m.rawHostEndpoints[id]
@@ -1233,6 +1287,19 @@ func (m *endpointManager) updateHostEndpoints() {
 			}
 			newHostIfaceMangleEgressChains[ifaceName] = mangleChains
 			delete(m.activeHostIfaceToMangleEgressChains, ifaceName)
+
+			dscpChain := m.ruleRenderer.HostEndpointToDSCPChain(
+				ifaceName,
+				normalTierGroups,
+				m.dscpPolicyIDs,
+			)
+			if dscpChain != nil {
+				if !reflect.DeepEqual(dscpChain, m.activeHostIfaceToDSCPChain[ifaceName]) {
+					m.mangleTable.UpdateChain(dscpChain)
+				}
+				newHostIfaceDSCPChains[ifaceName] = dscpChain
+				delete(m.activeHostIfaceToDSCPChain, ifaceName)
+			}
 		}
 
 		// Build iptables chains for pre-DNAT host endpoint policy.
@@ -1272,12 +1339,18 @@ func (m *endpointManager) updateHostEndpoints() {
 				"Host interface no longer protected, deleting its preDNAT chains.")
 			m.mangleTable.RemoveChains(chains)
 		}
+		for ifaceName, chain := range m.activeHostIfaceToDSCPChain {
+			log.WithField("ifaceName", ifaceName).Info(
+				"Host interface no longer has DSCP policy, deleting its DSCP chain.")
+			m.mangleTable.RemoveChainByName(chain.Name)
+		}
 
 		m.callbacks.InvokeInterfaceCallbacks(m.activeIfaceNameToHostEpID, newIfaceNameToHostEpID)
 
 		m.activeHostIfaceToFiltChains = newHostIfaceFiltChains
 		m.activeHostIfaceToMangleEgressChains = newHostIfaceMangleEgressChains
 		m.activeHostIfaceToMangleIngressChains = newHostIfaceMangleIngressChains
+		m.activeHostIfaceToDSCPChain = newHostIfaceDSCPChains
 	}
 
 	// Build iptables chains for untracked host endpoint policy.
@@ -1386,6 +1459,19 @@ func (m *endpointManager) updateHostEndpoints() {
 	newMangleDispatchChains := append(newMangleIngressDispatchChains, newMangleEgressDispatchChains...)
 	m.updateDispatchChains(m.activeHostMangleDispatchChains, newMangleDispatchChains, m.mangleTable)
 
+	// Rewrite the DSCP dispatch chains if they've changed.
+	var dscpIfaceNames []string
+	defaultIfaceName = ""
+	for ifaceName := range m.activeHostIfaceToDSCPChain {
+		if ifaceName == allInterfaces {
+			defaultIfaceName = allInterfaces
+			continue
+		}
+		dscpIfaceNames = append(dscpIfaceNames, ifaceName)
+	}
+	newDSCPDispatchChains := m.ruleRenderer.HostDSCPDispatchChains(dscpIfaceNames, defaultIfaceName)
+	m.updateDispatchChains(m.activeHostDSCPDispatchChains, newDSCPDispatchChains, m.mangleTable)
+
 	log.Debug("Done resolving host endpoints.")
 }
 
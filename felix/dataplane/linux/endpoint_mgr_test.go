@@ -135,6 +135,17 @@ var toHostDispatchEmpty = []*generictables.Chain{
 	},
 }
 
+var dscpDispatchEmpty = []*generictables.Chain{
+	{
+		Name:  "cali-from-wl-dscp",
+		Rules: []generictables.Rule{},
+	},
+	{
+		Name:  "cali-to-hep-dscp",
+		Rules: []generictables.Rule{},
+	},
+}
+
 var wlEPID1 = proto.WorkloadEndpointID{
 	OrchestratorId: "k8s",
 	WorkloadId:     "pod-11",
@@ -992,6 +1003,7 @@ func endpointManagerTests(ipVersion uint8, flowlogs bool) func() {
 				mangleTable.checkChains([][]*generictables.Chain{
 					preDNATChainsForIfaces(ipVersion, names, epMgr.epMarkMapper, flowlogs),
 					mangleEgressChainsForIfaces(ipVersion, names, epMgr.epMarkMapper, flowlogs),
+					dscpDispatchEmpty,
 				})
 			}
 		}
@@ -1013,6 +1025,7 @@ func endpointManagerTests(ipVersion uint8, flowlogs bool) func() {
 				mangleTable.checkChains([][]*generictables.Chain{
 					fromHostDispatchEmpty,
 					toHostDispatchEmpty,
+					dscpDispatchEmpty,
 				})
 			}
 		}
@@ -1658,6 +1671,7 @@ func endpointManagerTests(ipVersion uint8, flowlogs bool) func() {
 				mangleTable.checkChains([][]*generictables.Chain{
 					fromHostDispatchEmpty,
 					toHostDispatchEmpty,
+					dscpDispatchEmpty,
 				})
 			}
 		}
@@ -1697,6 +1711,74 @@ func endpointManagerTests(ipVersion uint8, flowlogs bool) func() {
 
 					It("should have expected chains", expectWlChainsFor(ipVersion, flowlogs, "cali12345-ab_policy1"))
 
+					Context("with DSCP rules in the policy", func() {
+						JustBeforeEach(func() {
+							epMgr.OnUpdate(&proto.ActivePolicyUpdate{
+								Id: &proto.PolicyID{Tier: "default", Name: "policy1"},
+								Policy: &proto.Policy{
+									OutboundRules: []*proto.Rule{{Action: "setdscp", Dscp: 46}},
+								},
+							})
+							applyUpdates(epMgr)
+						})
+
+						It("should program the DSCP chains", func() {
+							mangleTable.checkChains([][]*generictables.Chain{
+								fromHostDispatchEmpty,
+								toHostDispatchEmpty,
+								{
+									{
+										Name: "cali-from-wl-dscp",
+										Rules: []generictables.Rule{{
+											Match:  iptables.Match().InInterface("cali12345-ab"),
+											Action: iptables.GotoAction{Target: "cali-dfw-cali12345-ab"},
+										}},
+									},
+									{
+										Name:  "cali-to-hep-dscp",
+										Rules: []generictables.Rule{},
+									},
+									{
+										Name: "cali-dfw-cali12345-ab",
+										Rules: []generictables.Rule{
+											{
+												Match:  iptables.Match(),
+												Action: iptables.ClearMarkAction{Mark: 0x10},
+											},
+											{
+												Match:  iptables.Match(),
+												Action: iptables.JumpAction{Target: "cali-pd-default/policy1"},
+											},
+										},
+									},
+								},
+							})
+						})
+
+						Context("with the DSCP rules removed", func() {
+							JustBeforeEach(func() {
+								epMgr.OnUpdate(&proto.ActivePolicyUpdate{
+									Id:     &proto.PolicyID{Tier: "default", Name: "policy1"},
+									Policy: &proto.Policy{},
+								})
+								applyUpdates(epMgr)
+							})
+
+							It("should remove the DSCP chains", expectWlChainsFor(ipVersion, flowlogs, "cali12345-ab_policy1"))
+						})
+
+						Context("with the endpoint removed", func() {
+							JustBeforeEach(func() {
+								epMgr.OnUpdate(&proto.WorkloadEndpointRemove{
+									Id: &wlEPID1,
+								})
+								applyUpdates(epMgr)
+							})
+
+							It("should have empty dispatch chains", expectEmptyChains(ipVersion))
+						})
+					})
+
 					Context("with another endpoint with the same interface name and earlier workload ID, and no policy", func() {
 						JustBeforeEach(func() {
 							epMgr.OnUpdate(&proto.WorkloadEndpointUpdate{
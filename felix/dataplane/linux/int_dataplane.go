@@ -62,6 +62,7 @@ import (
 	"github.com/projectcalico/calico/felix/dataplane/common"
 	dpsets "github.com/projectcalico/calico/felix/dataplane/ipsets"
 	"github.com/projectcalico/calico/felix/dataplane/linux/dataplanedefs"
+	"github.com/projectcalico/calico/felix/dnssnoop"
 	"github.com/projectcalico/calico/felix/environment"
 	"github.com/projectcalico/calico/felix/generictables"
 	"github.com/projectcalico/calico/felix/idalloc"
@@ -262,6 +263,10 @@ type Config struct {
 
 	ServiceLoopPrevention string
 
+	// DNS policy.  The NFQUEUE rules are controlled by RulesConfig.DNSPolicyNfqueueEnabled.
+	DNSPolicyDelayDNSResponse       bool
+	DNSPolicyNfqueueMaxHoldDuration time.Duration
+
 	LookPathOverride func(file string) (string, error)
 
 	KubeClientSet *kubernetes.Clientset
@@ -380,6 +385,15 @@ type InternalDataplane struct {
 
 	applyThrottle *throttle.Throttle
 
+	// domainInfoStore holds the domain name to IP mappings learned by snooping DNS responses; nil
+	// if DNS policy is disabled.
+	domainInfoStore *dnssnoop.DomainInfoStore
+	// domainIPSetsManagers are the managers that resolve domain sets using domainInfoStore.
+	domainIPSetsManagers []*dpsets.IPSetsManager
+	// pendingDomainGen is the domainInfoStore generation that will be in the dataplane after the
+	// next successful apply.
+	pendingDomainGen uint64
+
 	config Config
 
 	debugHangC <-chan time.Time
@@ -388,6 +402,8 @@ type InternalDataplane struct {
 	sockmapState      *sockmapState
 	endpointsSourceV4 endpointsSource
 	ipsetsSourceV4    ipsetsSource
+	endpointsSourceV6 endpointsSource
+	ipsetsSourceV6    ipsetsSource
 	callbacks         *common.Callbacks
 
 	loopSummarizer *logutils.Summarizer
@@ -706,7 +722,7 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 			log.WithError(err).Warn("Can't enable XDP acceleration.")
 			config.XDPEnabled = false
 		} else if !config.BPFEnabled {
-			st, err := NewXDPState(config.XDPAllowGeneric)
+			st, err := NewXDPState(config.XDPAllowGeneric, config.IPv6Enabled)
 			if err != nil {
 				log.WithError(err).Warn("Can't enable XDP acceleration.")
 			} else {
@@ -722,7 +738,7 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 
 	// TODO Support cleaning up non-BPF XDP state from a previous Felix run, when BPF mode has just been enabled.
 	if !config.BPFEnabled && dp.xdpState == nil {
-		xdpState, err := NewXDPState(config.XDPAllowGeneric)
+		xdpState, err := NewXDPState(config.XDPAllowGeneric, config.IPv6Enabled)
 		if err == nil {
 			if err := xdpState.WipeXDP(); err != nil {
 				log.WithError(err).Warn("Failed to cleanup preexisting XDP state")
@@ -765,6 +781,14 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 	ipsetsManager := dpsets.NewIPSetsManager("ipv4", ipSetsV4, config.MaxIPSetSize)
 	ipsetsManagerV6 := dpsets.NewIPSetsManager("ipv6", nil, config.MaxIPSetSize)
 
+	if config.RulesConfig.DNSPolicyNfqueueEnabled {
+		dp.domainInfoStore = dnssnoop.NewDomainInfoStore()
+		dp.domainIPSetsManagers = []*dpsets.IPSetsManager{ipsetsManager, ipsetsManagerV6}
+		for _, m := range dp.domainIPSetsManagers {
+			m.SetDomainStore(dp.domainInfoStore)
+		}
+	}
+
 	var mangleTableV6, natTableV6, rawTableV6, filterTableV6 generictables.Table
 	var nftablesV6RootTable *nftables.NftablesTable
 
@@ -1091,11 +1115,11 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 	// Add a manager for IPv4 wireguard configuration. This is added irrespective of whether wireguard is actually enabled
 	// because it may need to tidy up some of the routing rules when disabled.
 	cryptoRouteTableWireguard := wireguard.New(config.Hostname, &config.Wireguard, 4, config.NetlinkTimeout,
-		config.DeviceRouteProtocol, func(publicKey wgtypes.Key) error {
-			if publicKey == zeroKey {
-				dp.fromDataplane <- &proto.WireguardStatusUpdate{PublicKey: "", IpVersion: 4}
-			} else {
-				dp.fromDataplane <- &proto.WireguardStatusUpdate{PublicKey: publicKey.String(), IpVersion: 4}
+		config.DeviceRouteProtocol, func(publicKey, nextPublicKey wgtypes.Key) error {
+			dp.fromDataplane <- &proto.WireguardStatusUpdate{
+				PublicKey:     wireguardKeyString(publicKey),
+				NextPublicKey: wireguardKeyString(nextPublicKey),
+				IpVersion:     4,
 			}
 			return nil
 		},
@@ -1197,6 +1221,7 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 		ipsetsManagerV6.AddDataplane(ipSetsV6)
 		dp.RegisterManager(ipsetsManagerV6)
 		if !config.BPFEnabled {
+			dp.ipsetsSourceV6 = ipsetsManagerV6
 			dp.RegisterManager(newHostIPManager(
 				config.RulesConfig.WorkloadIfacePrefixes,
 				rules.IPSetIDThisHostIPs,
@@ -1215,7 +1240,7 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 		linkAddrsManagerV6 := linkaddrs.New(6, config.RulesConfig.WorkloadIfacePrefixes, featureDetector, config.NetlinkTimeout)
 		dp.linkAddrsManagers = append(dp.linkAddrsManagers, linkAddrsManagerV6)
 
-		dp.RegisterManager(newEndpointManager(
+		epManagerV6 := newEndpointManager(
 			rawTableV6,
 			mangleTableV6,
 			filterTableV6,
@@ -1234,7 +1259,9 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 			config.FloatingIPsEnabled,
 			config.RulesConfig.NFTables,
 			linkAddrsManagerV6,
-		))
+		)
+		dp.RegisterManager(epManagerV6)
+		dp.endpointsSourceV6 = epManagerV6
 		dp.RegisterManager(newFloatingIPManager(natTableV6, ruleRenderer, 6, config.FloatingIPsEnabled))
 		dp.RegisterManager(newMasqManager(ipSetsV6, natTableV6, ruleRenderer, config.MaxIPSetSize, 6))
 		dp.RegisterManager(newServiceLoopManager(filterTableV6, ruleRenderer, 6))
@@ -1242,11 +1269,11 @@ func NewIntDataplaneDriver(config Config) *InternalDataplane {
 		// Add a manager for IPv6 wireguard configuration. This is added irrespective of whether wireguard is actually enabled
 		// because it may need to tidy up some of the routing rules when disabled.
 		cryptoRouteTableWireguardV6 := wireguard.New(config.Hostname, &config.Wireguard, 6, config.NetlinkTimeout,
-			config.DeviceRouteProtocol, func(publicKey wgtypes.Key) error {
-				if publicKey == zeroKey {
-					dp.fromDataplane <- &proto.WireguardStatusUpdate{PublicKey: "", IpVersion: 6}
-				} else {
-					dp.fromDataplane <- &proto.WireguardStatusUpdate{PublicKey: publicKey.String(), IpVersion: 6}
+			config.DeviceRouteProtocol, func(publicKey, nextPublicKey wgtypes.Key) error {
+				dp.fromDataplane <- &proto.WireguardStatusUpdate{
+					PublicKey:     wireguardKeyString(publicKey),
+					NextPublicKey: wireguardKeyString(nextPublicKey),
+					IpVersion:     6,
 				}
 				return nil
 			},
@@ -1574,6 +1601,12 @@ type ManagerWithRouteRules interface {
 	GetRouteRules() []routeRules
 }
 
+// reschedulingSyncer is implemented by route table syncers that need Apply to be called again after a delay, even if
+// nothing else has changed.
+type reschedulingSyncer interface {
+	RescheduleAfter() time.Duration
+}
+
 type routeRules interface {
 	SetRule(rule *routerule.Rule)
 	RemoveRule(rule *routerule.Rule)
@@ -1619,6 +1652,21 @@ func (d *InternalDataplane) Start() {
 	// Do our start-of-day configuration.
 	d.doStaticDataplaneConfig()
 
+	if d.domainInfoStore != nil {
+		d.domainInfoStore.Start()
+		snooper := dnssnoop.NewSnooper(
+			d.domainInfoStore,
+			int(d.config.RulesConfig.DNSPolicyNfqueueID),
+			d.config.DNSPolicyDelayDNSResponse,
+			d.config.DNSPolicyNfqueueMaxHoldDuration,
+		)
+		if err := snooper.Start(); err != nil {
+			// The NFQUEUE rules bypass the queue when nobody is listening so DNS keeps
+			// working, but domain-based rules won't match anything new.
+			log.WithError(err).Error("Failed to start DNS snooping; domain-based policy rules will not be updated.")
+		}
+	}
+
 	// Then, start the worker threads.
 	go d.loopUpdatingDataplane()
 	go d.loopReportingStatus()
@@ -2000,7 +2048,7 @@ func (d *InternalDataplane) setUpIptablesNormal() {
 		}})
 
 		// Include rules which should be appended to the filter table forward chain.
-		t.AppendRules("FORWARD", d.ruleRenderer.StaticFilterForwardAppendRules())
+		t.AppendRules("FORWARD", d.ruleRenderer.StaticFilterForwardAppendRules(t.IPVersion()))
 	}
 	for _, t := range d.natTables {
 		t.UpdateChains(d.ruleRenderer.StaticNATTableChains(t.IPVersion()))
@@ -2043,6 +2091,14 @@ func (d *InternalDataplane) setUpIptablesNormal() {
 			Match:  d.newMatch(),
 			Action: d.actions.Jump(rules.ChainManglePostrouting),
 		}})
+		t.InsertOrAppendRules("FORWARD", []generictables.Rule{{
+			Match:  d.newMatch(),
+			Action: d.actions.Jump(rules.ChainMangleForward),
+		}})
+		t.InsertOrAppendRules("OUTPUT", []generictables.Rule{{
+			Match:  d.newMatch(),
+			Action: d.actions.Jump(rules.ChainMangleOutput),
+		}})
 	}
 	if d.xdpState != nil {
 		if err := d.setXDPFailsafePorts(); err != nil {
@@ -2134,6 +2190,11 @@ func (d *InternalDataplane) loopUpdatingDataplane() {
 	throttleC := jitter.NewTicker(100*time.Millisecond, 10*time.Millisecond).Channel()
 	beingThrottled := false
 
+	var domainUpdatesC <-chan struct{}
+	if d.domainInfoStore != nil {
+		domainUpdatesC = d.domainInfoStore.UpdatesReadyC()
+	}
+
 	for {
 		select {
 		case msg := <-d.toDataplane:
@@ -2158,6 +2219,8 @@ func (d *InternalDataplane) loopUpdatingDataplane() {
 			log.Debug("Refreshing XDP")
 			d.forceXDPRefresh = true
 			d.dataplaneNeedsSync = true
+		case <-domainUpdatesC:
+			d.onDomainInfoChange()
 		case <-d.reschedC:
 			log.Debug("Reschedule kick received")
 			d.dataplaneNeedsSync = true
@@ -2199,6 +2262,10 @@ func (d *InternalDataplane) loopUpdatingDataplane() {
 					d.sendDataplaneInSyncOnce.Do(func() {
 						d.fromDataplane <- &proto.DataplaneInSync{}
 					})
+					if d.domainInfoStore != nil {
+						// Release any DNS responses that were waiting for this update.
+						d.domainInfoStore.MarkApplied(d.pendingDomainGen)
+					}
 				}
 
 				d.loopSummarizer.EndOfIteration(applyTime)
@@ -2416,6 +2483,17 @@ func (d *InternalDataplane) recordMsgStat(msg interface{}) {
 	countMessages.WithLabelValues(typeName).Inc()
 }
 
+// onDomainInfoChange passes newly-learned (or expired) DNS information to the IP sets managers.
+func (d *InternalDataplane) onDomainInfoChange() {
+	names, gen := d.domainInfoStore.TakeChanges()
+	log.WithFields(log.Fields{"numNames": names.Len(), "gen": gen}).Debug("DNS information changed")
+	for _, m := range d.domainIPSetsManagers {
+		m.OnDomainChange(names)
+	}
+	d.pendingDomainGen = gen
+	d.dataplaneNeedsSync = true
+}
+
 func (d *InternalDataplane) apply() {
 	// Update sequencing is important here because iptables rules have dependencies on ipsets.
 	// Creating a rule that references an unknown IP set fails, as does deleting an IP set that
@@ -2459,7 +2537,7 @@ func (d *InternalDataplane) apply() {
 		}
 
 		var applyXDPError error
-		d.xdpState.ProcessPendingDiffState(d.endpointsSourceV4)
+		d.xdpState.ProcessPendingDiffState(d.endpointsSourceV4, d.endpointsSourceV6)
 		if err := d.applyXDPActions(); err != nil {
 			applyXDPError = err
 		} else {
@@ -2620,6 +2698,15 @@ func (d *InternalDataplane) apply() {
 	// Wait for the route updates to finish.
 	routesWG.Wait()
 
+	// Some route table syncers need to be polled, for example WireGuard while a peer is rotating its key.
+	for _, r := range d.routeTableSyncers() {
+		if rs, ok := r.(reschedulingSyncer); ok {
+			if after := rs.RescheduleAfter(); after != 0 && (reschedDelay == 0 || after < reschedDelay) {
+				reschedDelay = after
+			}
+		}
+	}
+
 	// Wait for the rule updates to finish.
 	rulesWG.Wait()
 
@@ -2659,11 +2746,11 @@ func (d *InternalDataplane) apply() {
 func (d *InternalDataplane) applyXDPActions() error {
 	var err error = nil
 	for i := 0; i < 10; i++ {
-		err = d.xdpState.ResyncIfNeeded(d.ipsetsSourceV4)
+		err = d.xdpState.ResyncIfNeeded(d.ipsetsSourceV4, d.ipsetsSourceV6)
 		if err != nil {
 			return err
 		}
-		if err = d.xdpState.ApplyBPFActions(d.ipsetsSourceV4); err == nil {
+		if err = d.xdpState.ApplyBPFActions(d.ipsetsSourceV4, d.ipsetsSourceV6); err == nil {
 			return nil
 		} else {
 			log.WithError(err).Info("Applying XDP BPF actions did not succeed, will retry with resync...")
@@ -105,6 +105,11 @@ func (m *policyManager) OnUpdate(msg interface{}) {
 		m.rawTable.UpdateChains(chains)
 		m.mangleTable.UpdateChains(chains)
 		m.filterTable.UpdateChains(chains)
+		if !m.rawEgressOnly && !rules.PolicyHasDSCPRules(msg.Policy) {
+			// The DSCP chain is only rendered if the policy has DSCP rules; clean up any
+			// previous version of it.
+			m.removeChainFromAllTables(rules.PolicyChainName(rules.PolicyDSCPPfx, &id, m.nftablesEnabled))
+		}
 	case *proto.ActivePolicyRemove:
 		log.WithField("id", msg.Id).Debug("Removing policy chains")
 		id := types.ProtoToPolicyID(msg.GetId())
@@ -136,13 +141,17 @@ func (m *policyManager) cleanUpPolicy(id *types.PolicyID) {
 	}
 	inName := rules.PolicyChainName(rules.PolicyInboundPfx, id, m.nftablesEnabled)
 	outName := rules.PolicyChainName(rules.PolicyOutboundPfx, id, m.nftablesEnabled)
+	dscpName := rules.PolicyChainName(rules.PolicyDSCPPfx, id, m.nftablesEnabled)
 	// As above, we need to clean up in all the tables.
-	m.filterTable.RemoveChainByName(inName)
-	m.filterTable.RemoveChainByName(outName)
-	m.mangleTable.RemoveChainByName(inName)
-	m.mangleTable.RemoveChainByName(outName)
-	m.rawTable.RemoveChainByName(inName)
-	m.rawTable.RemoveChainByName(outName)
+	m.removeChainFromAllTables(inName)
+	m.removeChainFromAllTables(outName)
+	m.removeChainFromAllTables(dscpName)
+}
+
+func (m *policyManager) removeChainFromAllTables(name string) {
+	m.filterTable.RemoveChainByName(name)
+	m.mangleTable.RemoveChainByName(name)
+	m.rawTable.RemoveChainByName(name)
 }
 
 func (m *policyManager) updateNeededIPSets(id *types.PolicyID, neededIPSets set.Set[string]) {
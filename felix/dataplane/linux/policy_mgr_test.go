@@ -93,6 +93,62 @@ func policyManagerTests(ipVersion uint8, flowlogs bool) func() {
 			})
 		})
 
+		Describe("after a policy update with DSCP rules", func() {
+			BeforeEach(func() {
+				policyMgr.OnUpdate(&proto.ActivePolicyUpdate{
+					Id: &proto.PolicyID{Name: "pol1", Tier: "tier1"},
+					Policy: &proto.Policy{
+						OutboundRules: []*proto.Rule{
+							{Action: "setdscp", Dscp: 46},
+							{Action: "allow"},
+						},
+					},
+				})
+				err := policyMgr.CompleteDeferredWork()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should install the DSCP chain", func() {
+				mangleTable.checkChains([][]*generictables.Chain{{
+					{Name: "cali-pi-tier1/pol1"},
+					{Name: "cali-po-tier1/pol1"},
+					{Name: "cali-pd-tier1/pol1"},
+				}})
+			})
+
+			Describe("after the DSCP rules are removed", func() {
+				BeforeEach(func() {
+					policyMgr.OnUpdate(&proto.ActivePolicyUpdate{
+						Id: &proto.PolicyID{Name: "pol1", Tier: "tier1"},
+						Policy: &proto.Policy{
+							OutboundRules: []*proto.Rule{
+								{Action: "allow"},
+							},
+						},
+					})
+				})
+
+				It("should remove the DSCP chain", func() {
+					mangleTable.checkChains([][]*generictables.Chain{{
+						{Name: "cali-pi-tier1/pol1"},
+						{Name: "cali-po-tier1/pol1"},
+					}})
+				})
+			})
+
+			Describe("after a policy remove", func() {
+				BeforeEach(func() {
+					policyMgr.OnUpdate(&proto.ActivePolicyRemove{
+						Id: &proto.PolicyID{Name: "pol1", Tier: "tier1"},
+					})
+				})
+
+				It("should remove all the chains", func() {
+					mangleTable.checkChains([][]*generictables.Chain{})
+				})
+			})
+		})
+
 		Describe("after an untracked policy update", func() {
 			BeforeEach(func() {
 				policyMgr.OnUpdate(&proto.ActivePolicyUpdate{
@@ -417,10 +473,16 @@ func (r *mockPolRenderer) PolicyToIptablesChains(
 ) []*generictables.Chain {
 	inName := rules.PolicyChainName(rules.PolicyInboundPfx, policyID, false)
 	outName := rules.PolicyChainName(rules.PolicyOutboundPfx, policyID, false)
-	return []*generictables.Chain{
+	chains := []*generictables.Chain{
 		{Name: inName},
 		{Name: outName},
 	}
+	if rules.PolicyHasDSCPRules(policy) {
+		chains = append(chains, &generictables.Chain{
+			Name: rules.PolicyChainName(rules.PolicyDSCPPfx, policyID, false),
+		})
+	}
+	return chains
 }
 
 func (r *mockPolRenderer) ProfileToIptablesChains(
@@ -40,6 +40,10 @@ import (
 	"github.com/projectcalico/calico/felix/vxlanfdb"
 )
 
+// vxlanTOSInherit is the TOS setting that makes the kernel copy the TOS of the inner packet to the
+// outer header of VXLAN packets.
+const vxlanTOSInherit = 1
+
 type vxlanManager struct {
 	// Our dependencies.
 	hostname        string
@@ -618,6 +622,9 @@ func (m *vxlanManager) configureVXLANDevice(
 	vxlan := &netlink.Vxlan{
 		LinkAttrs: la,
 		Port:      m.vxlanPort,
+		// Copy the DSCP of the inner packet, for example, from a SetDSCP policy rule, to the
+		// outer header.
+		TOS: vxlanTOSInherit,
 	}
 
 	if m.dpConfig.BPFEnabled && bpfutils.BTFEnabled {
@@ -804,6 +811,10 @@ func vxlanLinksIncompat(l1, l2 netlink.Link) string {
 		return fmt.Sprintf("gbp: %v vs %v", v1.GBP, v2.GBP)
 	}
 
+	if v1.TOS != v2.TOS {
+		return fmt.Sprintf("tos: %v vs %v", v1.TOS, v2.TOS)
+	}
+
 	if len(v1.Attrs().HardwareAddr) > 0 && len(v2.Attrs().HardwareAddr) > 0 && !bytes.Equal(v1.Attrs().HardwareAddr, v2.Attrs().HardwareAddr) {
 		return fmt.Sprintf("vtep mac addr: %v vs %v", v1.Attrs().HardwareAddr, v2.Attrs().HardwareAddr)
 	}
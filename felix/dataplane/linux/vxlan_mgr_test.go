@@ -37,6 +37,7 @@ import (
 type mockVXLANDataplane struct {
 	links     []netlink.Link
 	ipVersion uint8
+	added     []netlink.Link
 }
 
 func (m *mockVXLANDataplane) LinkByName(name string) (netlink.Link, error) {
@@ -101,7 +102,8 @@ func (m *mockVXLANDataplane) LinkList() ([]netlink.Link, error) {
 	return m.links, nil
 }
 
-func (m *mockVXLANDataplane) LinkAdd(netlink.Link) error {
+func (m *mockVXLANDataplane) LinkAdd(link netlink.Link) error {
+	m.added = append(m.added, link)
 	return nil
 }
 
@@ -124,6 +126,7 @@ func (t *mockVXLANFDB) SetVTEPs(targets []vxlanfdb.VTEP) {
 
 var _ = Describe("VXLANManager", func() {
 	var manager, managerV6 *vxlanManager
+	var dataplane, dataplaneV6 *mockVXLANDataplane
 	var rt *mockRouteTable
 	var fdb *mockVXLANFDB
 
@@ -137,6 +140,14 @@ var _ = Describe("VXLANManager", func() {
 		la := netlink.NewLinkAttrs()
 		la.Name = "eth0"
 		opRecorder := logutils.NewSummarizer("test")
+		dataplane = &mockVXLANDataplane{
+			links:     []netlink.Link{&mockLink{attrs: la}},
+			ipVersion: 4,
+		}
+		dataplaneV6 = &mockVXLANDataplane{
+			links:     []netlink.Link{&mockLink{attrs: la}},
+			ipVersion: 6,
+		}
 		manager = newVXLANManagerWithShims(
 			dpsets.NewMockIPSets(),
 			rt,
@@ -152,10 +163,7 @@ var _ = Describe("VXLANManager", func() {
 				},
 			},
 			opRecorder,
-			&mockVXLANDataplane{
-				links:     []netlink.Link{&mockLink{attrs: la}},
-				ipVersion: 4,
-			},
+			dataplane,
 			4,
 			4444,
 		)
@@ -175,10 +183,7 @@ var _ = Describe("VXLANManager", func() {
 				},
 			},
 			opRecorder,
-			&mockVXLANDataplane{
-				links:     []netlink.Link{&mockLink{attrs: la}},
-				ipVersion: 6,
-			},
+			dataplaneV6,
 			6,
 			6666,
 		)
@@ -272,6 +277,38 @@ var _ = Describe("VXLANManager", func() {
 		Expect(fdb.setVTEPsCalls).To(Equal(1))
 	})
 
+	It("should recreate a VXLAN device that doesn't copy the inner DSCP to the outer header", func() {
+		manager.OnUpdate(&proto.VXLANTunnelEndpointUpdate{
+			Node:           "node1",
+			Mac:            "00:0a:74:9d:68:16",
+			Ipv4Addr:       "10.0.0.0",
+			ParentDeviceIp: "172.0.0.2",
+		})
+		manager.OnParentNameUpdate("eth0")
+
+		Expect(manager.configureVXLANDevice(50, manager.getLocalVTEP(), false)).To(Succeed())
+		Expect(dataplane.added).To(HaveLen(1))
+		Expect(dataplane.added[0].(*netlink.Vxlan).TOS).To(Equal(vxlanTOSInherit))
+
+		// The TOS is checked along with the rest of the configuration.
+		Expect(vxlanLinksIncompat(&netlink.Vxlan{TOS: vxlanTOSInherit}, &netlink.Vxlan{})).To(Equal("tos: 1 vs 0"))
+		Expect(vxlanLinksIncompat(&netlink.Vxlan{TOS: vxlanTOSInherit}, &netlink.Vxlan{TOS: vxlanTOSInherit})).To(BeEmpty())
+	})
+
+	It("IPv6: should recreate a VXLAN device that doesn't copy the inner DSCP to the outer header", func() {
+		managerV6.OnUpdate(&proto.VXLANTunnelEndpointUpdate{
+			Node:             "node1",
+			MacV6:            "00:0a:74:9d:68:16",
+			Ipv6Addr:         "fd00:10:244::",
+			ParentDeviceIpv6: "fc00:10:96::2",
+		})
+		managerV6.OnParentNameUpdate("eth0")
+
+		Expect(managerV6.configureVXLANDevice(50, managerV6.getLocalVTEP(), false)).To(Succeed())
+		Expect(dataplaneV6.added).To(HaveLen(1))
+		Expect(dataplaneV6.added[0].(*netlink.Vxlan).TOS).To(Equal(vxlanTOSInherit))
+	})
+
 	It("successfully adds a IPv6 route to the parent interface", func() {
 		managerV6.OnUpdate(&proto.VXLANTunnelEndpointUpdate{
 			Node:             "node1",
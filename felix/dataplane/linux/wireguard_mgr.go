@@ -158,6 +158,7 @@ func (m *wireguardManager) OnUpdate(protoBufMsg interface{}) {
 			}
 		}
 		m.wireguardRouteTable.EndpointWireguardUpdate(msg.Hostname, key, ifaceAddr)
+		m.wireguardRouteTable.EndpointWireguardNextKeyUpdate(msg.Hostname, m.parseNextKey(msg.Hostname, msg.NextPublicKey))
 	case *proto.WireguardEndpointRemove:
 		logCtx.WithField("msg", msg).Debug("WireguardEndpointRemove update")
 		if m.ipVersion != 4 {
@@ -187,6 +188,7 @@ func (m *wireguardManager) OnUpdate(protoBufMsg interface{}) {
 			}
 		}
 		m.wireguardRouteTable.EndpointWireguardUpdate(msg.Hostname, key, ifaceAddr)
+		m.wireguardRouteTable.EndpointWireguardNextKeyUpdate(msg.Hostname, m.parseNextKey(msg.Hostname, msg.NextPublicKeyV6))
 	case *proto.WireguardEndpointV6Remove:
 		logCtx.WithField("msg", msg).Debug("WireguardEndpointV6Remove update")
 		if m.ipVersion != 6 {
@@ -197,6 +199,29 @@ func (m *wireguardManager) OnUpdate(protoBufMsg interface{}) {
 	}
 }
 
+// parseNextKey parses the next public key of a node, which is only set while the node is rotating its key. A key that
+// fails to parse is treated as not set, the node is still reachable using its current key.
+func (m *wireguardManager) parseNextKey(hostname, nextPublicKey string) wgtypes.Key {
+	if nextPublicKey == "" {
+		return zeroKey
+	}
+	key, err := wgtypes.ParseKey(nextPublicKey)
+	if err != nil {
+		log.WithError(err).WithField("ipVersion", m.ipVersion).Errorf(
+			"error parsing next wireguard public key %s for node %s", nextPublicKey, hostname)
+		return zeroKey
+	}
+	return key
+}
+
+// wireguardKeyString returns the string form of a wireguard key for a status update, or "" for the zero key.
+func wireguardKeyString(key wgtypes.Key) string {
+	if key == zeroKey {
+		return ""
+	}
+	return key.String()
+}
+
 func (m *wireguardManager) CompleteDeferredWork() error {
 	// Dataplane programming is handled through the routetable interface.
 	return nil
@@ -1,4 +1,4 @@
-// Copyright (c) 2019-2021 Tigera, Inc. All rights reserved.
+// Copyright (c) 2019-2025 Tigera, Inc. All rights reserved.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -70,13 +70,17 @@ import (
 // where the internal dataplane tells each manager to complete its
 // deferred work.
 //
-// XDP state contains an IP state which is a representation of an XDP
-// state for a specific IP family. Currently it only contains such a
-// thing for IPv4. Among other data the IP state has a field called
-// system state which is a view of the information from the data store
-// that is relevant to XDP. That is: network interface names, host
-// endpoints, policies, and ipset IDs. Note the lack of ipset contents
-// - this is to preserve memory. Such a form of a system state
+// XDP state contains an IP state for each IP family, which is a
+// representation of an XDP state for that IP family. There is always
+// one for IPv4, and there is one for IPv6 if IPv6 is enabled. Each
+// network interface has a single XDP program, which uses a BPF map
+// for each IP family, so an IP state installs the program and creates
+// its (possibly empty) map if any IP family needs it. Among other
+// data the IP state has a field called system state which is a view
+// of the information from the data store that is relevant to XDP.
+// That is: network interface names, host endpoints, policies, and
+// ipset IDs. Note the lack of ipset contents - this is to preserve
+// memory. Such a form of a system state
 // requires us to perform updates of the XDP state in two steps:
 // processing pending diff state together with applying BPF actions,
 // and processing member updates.
@@ -99,21 +103,24 @@ import (
 
 type xdpState struct {
 	ipV4State *xdpIPState
+	ipV6State *xdpIPState
 	common    xdpStateCommon
 }
 
-func NewXDPState(allowGenericXDP bool) (*xdpState, error) {
+func NewXDPState(allowGenericXDP, ipV6Enabled bool) (*xdpState, error) {
 	lib, err := bpf.NewBPFLib("/usr/lib/calico/bpf/")
 	if err != nil {
 		return nil, err
 	}
-	return NewXDPStateWithBPFLibrary(lib, allowGenericXDP), nil
+	return NewXDPStateWithBPFLibrary(lib, allowGenericXDP, ipV6Enabled), nil
 }
 
-func NewXDPStateWithBPFLibrary(library bpf.BPFDataplane, allowGenericXDP bool) *xdpState {
+func NewXDPStateWithBPFLibrary(library bpf.BPFDataplane, allowGenericXDP, ipV6Enabled bool) *xdpState {
 	log.Debug("Created new xdpState.")
+	ipV4State, ipV6State := newXDPIPStates(ipV6Enabled)
 	return &xdpState{
-		ipV4State: newXDPIPState(4),
+		ipV4State: ipV4State,
+		ipV6State: ipV6State,
 		common: xdpStateCommon{
 			programTag: "",
 			needResync: true,
@@ -123,6 +130,29 @@ func NewXDPStateWithBPFLibrary(library bpf.BPFDataplane, allowGenericXDP bool) *
 	}
 }
 
+// newXDPIPStates creates the IP states for IPv4 and, if enabled, IPv6.  The XDP program on an
+// interface is shared by both IP families, so each IP state is told about the other one.
+func newXDPIPStates(ipV6Enabled bool) (*xdpIPState, *xdpIPState) {
+	ipV4State := newXDPIPState(4)
+	if !ipV6Enabled {
+		return ipV4State, nil
+	}
+	ipV6State := newXDPIPState(6)
+	ipV4State.peers = []*xdpIPState{ipV6State}
+	ipV6State.peers = []*xdpIPState{ipV4State}
+	return ipV4State, ipV6State
+}
+
+func (x *xdpState) ipStates() []*xdpIPState {
+	var states []*xdpIPState
+	for _, s := range []*xdpIPState{x.ipV4State, x.ipV6State} {
+		if s != nil {
+			states = append(states, s)
+		}
+	}
+	return states
+}
+
 func membersToSet(members []string) set.Set[string] {
 	membersSet := set.New[string]()
 	for _, m := range members {
@@ -137,22 +167,32 @@ func (x *xdpState) OnUpdate(protoBufMsg interface{}) {
 	switch msg := protoBufMsg.(type) {
 	case *proto.IPSetDeltaUpdate:
 		log.WithField("ipSetId", msg.Id).Debug("IP set delta update")
-		x.ipV4State.addMembersIPSet(msg.Id, membersToSet(msg.AddedMembers))
-		x.ipV4State.removeMembersIPSet(msg.Id, membersToSet(msg.RemovedMembers))
+		for _, s := range x.ipStates() {
+			s.addMembersIPSet(msg.Id, membersToSet(msg.AddedMembers))
+			s.removeMembersIPSet(msg.Id, membersToSet(msg.RemovedMembers))
+		}
 	case *proto.IPSetUpdate:
 		log.WithField("ipSetId", msg.Id).Debug("IP set update")
-		x.ipV4State.replaceIPSet(msg.Id, membersToSet(msg.Members))
+		for _, s := range x.ipStates() {
+			s.replaceIPSet(msg.Id, membersToSet(msg.Members))
+		}
 	case *proto.IPSetRemove:
 		log.WithField("ipSetId", msg.Id).Debug("IP set remove")
-		x.ipV4State.removeIPSet(msg.Id)
+		for _, s := range x.ipStates() {
+			s.removeIPSet(msg.Id)
+		}
 	case *proto.ActivePolicyUpdate:
 		id := types.ProtoToPolicyID(msg.GetId())
 		log.WithField("id", msg.Id).Debug("Updating policy chains")
-		x.ipV4State.updatePolicy(id, msg.Policy)
+		for _, s := range x.ipStates() {
+			s.updatePolicy(id, msg.Policy)
+		}
 	case *proto.ActivePolicyRemove:
 		id := types.ProtoToPolicyID(msg.GetId())
 		log.WithField("id", msg.Id).Debug("Removing policy chains")
-		x.ipV4State.removePolicy(id)
+		for _, s := range x.ipStates() {
+			s.removePolicy(id)
+		}
 	}
 }
 
@@ -171,14 +211,24 @@ func (x *xdpState) PopulateCallbacks(cbs *common.Callbacks) {
 		}
 		x.ipV4State.cbIDs = append(x.ipV4State.cbIDs, cbIDs...)
 	}
+	if x.ipV6State != nil {
+		cbIDs := []*common.CbID{
+			cbs.AddInterfaceV6.Append(x.ipV6State.addInterface),
+			cbs.RemoveInterfaceV6.Append(x.ipV6State.removeInterface),
+			cbs.UpdateInterfaceV6.Append(x.ipV6State.updateInterface),
+			cbs.UpdateHostEndpointV6.Append(x.ipV6State.updateHostEndpoint),
+			cbs.RemoveHostEndpointV6.Append(x.ipV6State.removeHostEndpoint),
+		}
+		x.ipV6State.cbIDs = append(x.ipV6State.cbIDs, cbIDs...)
+	}
 }
 
 func (x *xdpState) DepopulateCallbacks(cbs *common.Callbacks) {
-	if x.ipV4State != nil {
-		for _, id := range x.ipV4State.cbIDs {
+	for _, s := range x.ipStates() {
+		for _, id := range s.cbIDs {
 			cbs.Drop(id)
 		}
-		x.ipV4State.cbIDs = nil
+		s.cbIDs = nil
 	}
 }
 
@@ -186,13 +236,31 @@ func (x *xdpState) QueueResync() {
 	x.common.needResync = true
 }
 
-func (x *xdpState) ProcessPendingDiffState(epSourceV4 endpointsSource) {
+// ProcessPendingDiffState processes the pending diff state of both IP families.  The source
+// for IPv6 is ignored if IPv6 is disabled.
+func (x *xdpState) ProcessPendingDiffState(epSourceV4, epSourceV6 endpointsSource) {
+	// An interface needs the XDP program if either IP family needs it, so the new state of
+	// both IP families has to be known before deciding which programs and maps to install or
+	// remove.
+	changedIfaces := set.New[string]()
+	var changesV4, changesV6 *xdpStateChanges
 	if x.ipV4State != nil {
-		x.ipV4State.processPendingDiffState(epSourceV4)
+		changesV4 = x.ipV4State.updateNewCurrentState(epSourceV4)
+		changedIfaces.AddSet(changesV4.ifaces)
+	}
+	if x.ipV6State != nil {
+		changesV6 = x.ipV6State.updateNewCurrentState(epSourceV6)
+		changedIfaces.AddSet(changesV6.ifaces)
+	}
+	if x.ipV4State != nil {
+		x.ipV4State.updateBPFActions(changedIfaces, changesV4.changeInMaps)
+	}
+	if x.ipV6State != nil {
+		x.ipV6State.updateBPFActions(changedIfaces, changesV6.changeInMaps)
 	}
 }
 
-func (x *xdpState) ResyncIfNeeded(ipsSourceV4 ipsetsSource) error {
+func (x *xdpState) ResyncIfNeeded(ipsSourceV4, ipsSourceV6 ipsetsSource) error {
 	var err error
 	if !x.common.needResync {
 		return nil
@@ -204,7 +272,7 @@ func (x *xdpState) ResyncIfNeeded(ipsSourceV4 ipsetsSource) error {
 			log.Info("Retrying after an XDP update failure...")
 		}
 		log.Debug("Resyncing XDP state with dataplane.")
-		err = x.tryResync(newConvertingIPSetsSource(ipsSourceV4))
+		err = x.tryResync(newConvertingIPSetsSource(ipsSourceV4), newConvertingIPSetsSource(ipsSourceV6))
 		if err == nil {
 			success = true
 			break
@@ -217,24 +285,48 @@ func (x *xdpState) ResyncIfNeeded(ipsSourceV4 ipsetsSource) error {
 	return nil
 }
 
-func (x *xdpState) ApplyBPFActions(ipsSource ipsetsSource) error {
-	if x.ipV4State != nil {
-		memberCacheV4 := newXDPMemberCache(x.ipV4State.getBpfIPFamily(), x.common.bpfLib)
-		err := x.ipV4State.bpfActions.apply(memberCacheV4, x.ipV4State.ipsetIDsToMembers, newConvertingIPSetsSource(ipsSource), x.common.xdpModes)
-		x.ipV4State.bpfActions = newXDPBPFActions()
-		if err != nil {
-			log.WithError(err).Info("Applying BPF actions did not succeed. Queueing XDP resync.")
-			x.QueueResync()
+// ApplyBPFActions applies the BPF actions of both IP families.  The XDP programs are shared by
+// both IP families, so they are removed before, and installed after, the maps of either IP
+// family are updated.
+func (x *xdpState) ApplyBPFActions(ipsSourceV4, ipsSourceV6 ipsetsSource) error {
+	programActions := newXDPBPFActions()
+	for _, s := range x.ipStates() {
+		programActions.UninstallXDP.AddSet(s.bpfActions.UninstallXDP)
+		programActions.InstallXDP.AddSet(s.bpfActions.InstallXDP)
+	}
+	err := func() error {
+		if err := programActions.uninstallXDP(x.common.bpfLib); err != nil {
 			return err
 		}
+		if x.ipV4State != nil {
+			memberCacheV4 := newXDPMemberCache(x.ipV4State.getBpfIPFamily(), x.common.bpfLib)
+			if err := x.ipV4State.bpfActions.applyMapActions(memberCacheV4, x.ipV4State.ipsetIDsToMembers, newConvertingIPSetsSource(ipsSourceV4)); err != nil {
+				return err
+			}
+		}
+		if x.ipV6State != nil {
+			memberCacheV6 := newXDPMemberCache(x.ipV6State.getBpfIPFamily(), x.common.bpfLib)
+			if err := x.ipV6State.bpfActions.applyMapActions(memberCacheV6, x.ipV6State.ipsetIDsToMembers, newConvertingIPSetsSource(ipsSourceV6)); err != nil {
+				return err
+			}
+		}
+		return programActions.installXDP(x.common.bpfLib, x.common.xdpModes)
+	}()
+	for _, s := range x.ipStates() {
+		s.bpfActions = newXDPBPFActions()
+	}
+	if err != nil {
+		log.WithError(err).Info("Applying BPF actions did not succeed. Queueing XDP resync.")
+		x.QueueResync()
+		return err
 	}
 	return nil
 }
 
 func (x *xdpState) ProcessMemberUpdates() error {
-	if x.ipV4State != nil {
-		memberCacheV4 := newXDPMemberCache(x.ipV4State.getBpfIPFamily(), x.common.bpfLib)
-		err := x.ipV4State.processMemberUpdates(memberCacheV4)
+	for _, s := range x.ipStates() {
+		memberCache := newXDPMemberCache(s.getBpfIPFamily(), x.common.bpfLib)
+		err := s.processMemberUpdates(memberCache)
 		if err != nil {
 			log.WithError(err).Info("Processing member updates did not succeed. Queueing XDP resync.")
 			x.QueueResync()
@@ -245,42 +337,44 @@ func (x *xdpState) ProcessMemberUpdates() error {
 }
 
 func (x *xdpState) DropPendingDiffState() {
-	if x.ipV4State != nil {
-		x.ipV4State.pendingDiffState = newXDPPendingDiffState()
+	for _, s := range x.ipStates() {
+		s.pendingDiffState = newXDPPendingDiffState()
 	}
 }
 
 func (x *xdpState) UpdateState() {
-	if x.ipV4State != nil {
-		x.ipV4State.currentState, x.ipV4State.newCurrentState = x.ipV4State.newCurrentState, nil
-		x.ipV4State.cleanupCache()
+	for _, s := range x.ipStates() {
+		s.currentState, s.newCurrentState = s.newCurrentState, nil
+		s.cleanupCache()
 	}
 }
 
 // WipeXDP clears any previously set XDP state, returning an error if synchronization fails.
 func (x *xdpState) WipeXDP() error {
-	savedIPV4State := x.ipV4State
-	x.ipV4State = newXDPIPState(4)
-	x.ipV4State.newCurrentState = newXDPSystemState()
+	savedIPV4State, savedIPV6State := x.ipV4State, x.ipV6State
+	x.ipV4State, x.ipV6State = newXDPIPStates(savedIPV6State != nil)
+	for _, s := range x.ipStates() {
+		s.newCurrentState = newXDPSystemState()
+	}
 	defer func() {
-		x.ipV4State = savedIPV4State
+		x.ipV4State, x.ipV6State = savedIPV4State, savedIPV6State
 	}()
 	// Nil source, we are not going to use it anyway,
 	// because we are about to drop everything, and when
 	// we only drop stuff, the code does not call
 	// ipsetsSource functions at all.
 	ipsSource := &nilIPSetsSource{}
-	if err := x.tryResync(ipsSource); err != nil {
+	if err := x.tryResync(ipsSource, ipsSource); err != nil {
 		return err
 	}
-	if err := x.ApplyBPFActions(ipsSource); err != nil {
+	if err := x.ApplyBPFActions(ipsSource, ipsSource); err != nil {
 		return err
 	}
 	x.QueueResync()
 	return nil
 }
 
-func (x *xdpState) tryResync(ipsSourceV4 ipsetsSource) error {
+func (x *xdpState) tryResync(ipsSourceV4, ipsSourceV6 ipsetsSource) error {
 	if x.common.programTag == "" {
 		tag, err := x.common.bpfLib.GetXDPObjTagAuto()
 		if err != nil {
@@ -293,6 +387,34 @@ func (x *xdpState) tryResync(ipsSourceV4 ipsetsSource) error {
 			return err
 		}
 	}
+	if x.ipV6State != nil {
+		if err := x.ipV6State.tryResync(&x.common, ipsSourceV6); err != nil {
+			return err
+		}
+	} else if err := x.removeStaleIPv6Maps(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// removeStaleIPv6Maps removes the IPv6 maps left behind by a previous Felix with IPv6 enabled.
+// A program that is still using one of them is replaced, so that it stops dropping IPv6 traffic.
+func (x *xdpState) removeStaleIPv6Maps() error {
+	ifaces, err := x.common.bpfLib.ListCIDRMaps(bpf.IPFamilyV6)
+	if err != nil {
+		return err
+	}
+	for _, iface := range ifaces {
+		log.WithField("iface", iface).Info("Removing stale IPv6 BPF blocklist map.")
+		if err := x.common.bpfLib.RemoveCIDRMap(iface, bpf.IPFamilyV6); err != nil {
+			return err
+		}
+		ba := x.ipV4State.bpfActions
+		if x.ipV4State.programNeeded(iface, true) && !ba.InstallXDP.Contains(iface) {
+			ba.UninstallXDP.Add(iface)
+			ba.InstallXDP.Add(iface)
+		}
+	}
 	return nil
 }
 
@@ -305,7 +427,10 @@ type xdpIPState struct {
 	newCurrentState   *xdpSystemState
 	bpfActions        *xdpBPFActions
 	cbIDs             []*common.CbID
-	logCxt            *log.Entry
+	// peers are the IP states of the other IP families.  They share the XDP program on each
+	// interface with this one.
+	peers  []*xdpIPState
+	logCxt *log.Entry
 }
 
 type ipsetIDsToMembers struct {
@@ -445,8 +570,11 @@ func newXDPIPState(ipFamily int) *xdpIPState {
 }
 
 func (s *xdpIPState) getBpfIPFamily() bpf.IPFamily {
-	if s.ipFamily == 4 {
+	switch s.ipFamily {
+	case 4:
 		return bpf.IPFamilyV4
+	case 6:
+		return bpf.IPFamilyV6
 	}
 
 	s.logCxt.WithField("ipFamily", s.ipFamily).Panic("Invalid ip family.")
@@ -454,6 +582,39 @@ func (s *xdpIPState) getBpfIPFamily() bpf.IPFamily {
 	return bpf.IPFamilyUnknown
 }
 
+// programNeeded returns true if any IP family needs the XDP program on the interface, in the
+// new state if newState is set, or in the current state otherwise.  Each IP family has a map on
+// every interface with the program, even if that map is empty.
+func (s *xdpIPState) programNeeded(iface string, newState bool) bool {
+	for _, st := range append([]*xdpIPState{s}, s.peers...) {
+		sys := st.currentState
+		if newState && st.newCurrentState != nil {
+			sys = st.newCurrentState
+		}
+		if data, ok := sys.IfaceNameToData[iface]; ok && data.NeedsXDP() {
+			return true
+		}
+	}
+	return false
+}
+
+// filterMembers returns the members that belong to the IP family of the state.  IP set updates
+// contain the members of both IP families.
+func (s *xdpIPState) filterMembers(members set.Set[string]) set.Set[string] {
+	filtered := set.New[string]()
+	members.Iter(func(member string) error {
+		if isIPv6Member(member) == (s.ipFamily == 6) {
+			filtered.Add(member)
+		}
+		return nil
+	})
+	return filtered
+}
+
+func isIPv6Member(member string) bool {
+	return strings.Contains(member, ":")
+}
+
 // newXDPResyncState creates the xdpResyncState object, returning an error on failure.
 func (s *xdpIPState) newXDPResyncState(bpfLib bpf.BPFDataplane, ipsSource ipsetsSource, programTag string, xdpModes []bpf.XDPMode) (*xdpResyncState, error) {
 	xdpIfaces, err := bpfLib.GetXDPIfaces()
@@ -643,12 +804,7 @@ func (s *xdpIPState) tryResync(common *xdpStateCommon, ipsSource ipsetsSource) e
 func (s *xdpIPState) fixupXDPProgramAndMapConsistency(resyncState *xdpResyncState) {
 	ifaces := s.getIfaces(resyncState, giNS|giWX|giIX|giUX|giWM|giCM|giRM)
 	ifaces.Iter(func(iface string) error {
-		shouldHaveXDP := func() bool {
-			if data, ok := s.newCurrentState.IfaceNameToData[iface]; ok {
-				return data.NeedsXDP()
-			}
-			return false
-		}()
+		shouldHaveXDP := s.programNeeded(iface, true)
 		hasXDP, hasBogusXDP := func() (bool, bool) {
 			if progInfo, ok := resyncState.ifacesWithProgs[iface]; ok {
 				return true, progInfo.bogus
@@ -935,9 +1091,15 @@ func (s *xdpIPState) getIfaces(resyncState *xdpResyncState, flags IfaceFlags) se
 		return nil
 	}
 	if flags&giNS == giNS {
-		for iface, data := range s.newCurrentState.IfaceNameToData {
-			if data.NeedsXDP() {
-				ifaces.Add(iface)
+		for _, st := range append([]*xdpIPState{s}, s.peers...) {
+			newState := st.newCurrentState
+			if newState == nil {
+				newState = st.currentState
+			}
+			for iface, data := range newState.IfaceNameToData {
+				if data.NeedsXDP() {
+					ifaces.Add(iface)
+				}
 			}
 		}
 	}
@@ -1039,7 +1201,31 @@ func (s *xdpIPState) processMemberUpdates(memberCache *xdpMemberCache) error {
 // interface is only processed in the part of the code that handles
 // updates of the host endpoint and it is skipped in the code that
 // handles policy updates.
+//
+// The XDP program on an interface is shared with the other IP
+// families, so the processing is done in two steps: the first one
+// computes the new desired state, and the second one, which needs the
+// new desired state of the other IP families, generates the actions.
+// This function does both, for when there are no other IP families.
 func (s *xdpIPState) processPendingDiffState(epSource endpointsSource) {
+	changes := s.updateNewCurrentState(epSource)
+	s.updateBPFActions(changes.ifaces, changes.changeInMaps)
+}
+
+// xdpStateChanges describes the changes between the current state and
+// the new desired state of an IP family.
+type xdpStateChanges struct {
+	// interfaces whose data has changed
+	ifaces set.Set[string]
+	// keys are interface names, values are maps with keys being
+	// set IDs, and values being ref count delta (can be less or
+	// greater than zero)
+	changeInMaps map[string]map[string]int
+}
+
+// updateNewCurrentState generates the new desired state from the
+// current state and the pending diff state.
+func (s *xdpIPState) updateNewCurrentState(epSource endpointsSource) *xdpStateChanges {
 	cs := s.currentState
 	s.newCurrentState = cs.Copy()
 
@@ -1047,14 +1233,10 @@ func (s *xdpIPState) processPendingDiffState(epSource endpointsSource) {
 	s.logCxt.WithField("cs", cs).Debug("Processing pending diff state.")
 
 	pds := s.pendingDiffState
-	ba := s.bpfActions
 	rawHep := epSource.GetRawHostEndpoints()
 
 	processedIfaces := set.New[string]()
 
-	// keys are interface names, values are maps with keys being
-	// set IDs, and values being ref count delta (can be less or
-	// greater than zero)
 	changeInMaps := make(map[string]map[string]int)
 
 	// CHANGES IN INTERFACES
@@ -1073,15 +1255,6 @@ func (s *xdpIPState) processPendingDiffState(epSource endpointsSource) {
 	pds.IfaceNamesToDrop.Iter(func(ifName string) error {
 		s.logCxt.WithField("iface", ifName).Debug("Iface is gone.")
 
-		dropXDP := false
-		if data, ok := cs.IfaceNameToData[ifName]; ok {
-			dropXDP = data.NeedsXDP()
-		}
-		if dropXDP {
-			ba.UninstallXDP.Add(ifName)
-			ba.RemoveMap.Add(ifName)
-		}
-
 		delete(newCs.IfaceNameToData, ifName)
 		processedIfaces.Add(ifName)
 
@@ -1231,11 +1404,25 @@ func (s *xdpIPState) processPendingDiffState(epSource endpointsSource) {
 		}
 	}
 
-	ifacesWithUpdatedPolicies.Iter(func(ifaceName string) error {
-		oldData := cs.IfaceNameToData[ifaceName]
-		newData := newCs.IfaceNameToData[ifaceName]
-		oldNeedsXDP := oldData.NeedsXDP()
-		newNeedsXDP := newData.NeedsXDP()
+	processedIfaces.AddSet(ifacesWithUpdatedPolicies)
+
+	s.logCxt.WithField("newCS", newCs).Debug("Finished processing pending diff state.")
+
+	return &xdpStateChanges{
+		ifaces:       processedIfaces,
+		changeInMaps: changeInMaps,
+	}
+}
+
+// updateBPFActions generates the actions that will get the current
+// state into the new desired state.  ifaces are the interfaces whose
+// data has changed in any IP family.
+func (s *xdpIPState) updateBPFActions(ifaces set.Set[string], changeInMaps map[string]map[string]int) {
+	ba := s.bpfActions
+
+	ifaces.Iter(func(ifaceName string) error {
+		oldNeedsXDP := s.programNeeded(ifaceName, false)
+		newNeedsXDP := s.programNeeded(ifaceName, true)
 		if oldNeedsXDP && !newNeedsXDP {
 			ba.UninstallXDP.Add(ifaceName)
 			ba.RemoveMap.Add(ifaceName)
@@ -1268,10 +1455,7 @@ func (s *xdpIPState) processPendingDiffState(epSource endpointsSource) {
 		}
 	}
 
-	s.logCxt.WithFields(log.Fields{
-		"newCS":      newCs,
-		"bpfActions": *ba,
-	}).Debug("Finished processing pending diff state.")
+	s.logCxt.WithField("bpfActions", *ba).Debug("Finished generating BPF actions.")
 }
 
 func dumpSetToString(s set.Set[string]) string {
@@ -1334,15 +1518,6 @@ func (s *xdpIPState) processHostEndpointChange(ifaceName string, oldData *xdpIfa
 		PoliciesToSetIDs: policiesToSetIDs,
 	}
 	s.newCurrentState.IfaceNameToData[ifaceName] = newData
-	oldNeedsXDP := oldData.NeedsXDP()
-	newNeedsXDP := newData.NeedsXDP()
-	if oldNeedsXDP && !newNeedsXDP {
-		s.bpfActions.UninstallXDP.Add(ifaceName)
-		s.bpfActions.RemoveMap.Add(ifaceName)
-	} else if !oldNeedsXDP && newNeedsXDP {
-		s.bpfActions.InstallXDP.Add(ifaceName)
-		s.bpfActions.CreateMap.Add(ifaceName)
-	}
 	m, ok := changeInMaps[ifaceName]
 	if !ok {
 		m = make(map[string]int)
@@ -1417,7 +1592,7 @@ func (s *xdpIPState) updatePolicy(policyID types.PolicyID, policy *proto.Policy)
 		"policy":   policy,
 	}).Debug("updatePolicy callback called.")
 	s.pendingDiffState.PoliciesToRemove.Discard(policyID)
-	if xdpRules, ok := xdpRulesFromProtoRules(policy.InboundRules, policy.OutboundRules); ok {
+	if xdpRules, ok := xdpRulesFromProtoRules(policy.InboundRules, policy.OutboundRules, s.ipFamily); ok {
 		s.logCxt.WithField("policyID", policyID).Debug("Policy can be optimized.")
 		s.pendingDiffState.PoliciesToUpdate[policyID] = &xdpRules
 	} else {
@@ -1432,7 +1607,7 @@ func (s *xdpIPState) removePolicy(policyID types.PolicyID) {
 	s.pendingDiffState.PoliciesToRemove.Add(policyID)
 }
 
-func xdpRulesFromProtoRules(inboundRules, outboundRules []*proto.Rule) (xdpRules, bool) {
+func xdpRulesFromProtoRules(inboundRules, outboundRules []*proto.Rule, ipFamily int) (xdpRules, bool) {
 	xdpRules := xdpRules{}
 	isValid := len(inboundRules) > 0 &&
 		// TODO: Maybe we should take all the initial rules
@@ -1440,7 +1615,7 @@ func xdpRulesFromProtoRules(inboundRules, outboundRules []*proto.Rule) (xdpRules
 		// has 4 inbound rules with actions "deny", "deny",
 		// "allow" and "deny, respectively, we would take
 		// first two rules into account.
-		isValidRuleForXDP(inboundRules[0])
+		isValidRuleForXDP(inboundRules[0], ipFamily)
 	if isValid {
 		xdpRules.Rules = []xdpRule{
 			{
@@ -1451,16 +1626,17 @@ func xdpRulesFromProtoRules(inboundRules, outboundRules []*proto.Rule) (xdpRules
 	return xdpRules, isValid
 }
 
-func isValidRuleForXDP(rule *proto.Rule) bool {
+func isValidRuleForXDP(rule *proto.Rule, ipFamily int) bool {
+	ipVersion := proto.IPVersion_IPV4
+	if ipFamily == 6 {
+		ipVersion = proto.IPVersion_IPV6
+	}
 	return rule != nil &&
 		rule.Action == "deny" &&
-		// accept ipv4 traffic (or any, which matches ipv4
-		// too)
-		//
-		// TODO: drop the ip version check when we add support
-		// for ipv6
+		// accept traffic of the IP family (or any, which
+		// matches it too)
 		(rule.IpVersion == proto.IPVersion_ANY ||
-			rule.IpVersion == proto.IPVersion_IPV4) &&
+			rule.IpVersion == ipVersion) &&
 		// accept only rules that don't specify a protocol,
 		// which means blocking all the traffic
 		rule.Protocol == nil &&
@@ -1502,7 +1678,7 @@ func (s *xdpIPState) removeMembersIPSet(setID string, members set.Set[string]) {
 		"setID":   setID,
 		"members": members,
 	}).Debug("removeMembersIPSet callback called.")
-	s.ipsetIDsToMembers.RemoveMembers(setID, members)
+	s.ipsetIDsToMembers.RemoveMembers(setID, s.filterMembers(members))
 }
 
 func (s *xdpIPState) addMembersIPSet(setID string, members set.Set[string]) {
@@ -1510,7 +1686,7 @@ func (s *xdpIPState) addMembersIPSet(setID string, members set.Set[string]) {
 		"setID":   setID,
 		"members": members,
 	}).Debug("addMembersIPSet callback called.")
-	s.ipsetIDsToMembers.AddMembers(setID, members)
+	s.ipsetIDsToMembers.AddMembers(setID, s.filterMembers(members))
 }
 
 func (s *xdpIPState) replaceIPSet(setID string, members set.Set[string]) {
@@ -1518,7 +1694,7 @@ func (s *xdpIPState) replaceIPSet(setID string, members set.Set[string]) {
 		"setID":   setID,
 		"members": members,
 	}).Debug("ReplaceIPSet callback called.")
-	s.ipsetIDsToMembers.Replace(setID, members)
+	s.ipsetIDsToMembers.Replace(setID, s.filterMembers(members))
 }
 
 func (s *xdpIPState) removeIPSet(setID string) {
@@ -1791,20 +1967,29 @@ func newXDPBPFActions() *xdpBPFActions {
 // removes whole ipsets into/from the BPF maps, adds and removes
 // certain members to/from BPF maps.
 func (a *xdpBPFActions) apply(memberCache *xdpMemberCache, ipsetIDsToMembers *ipsetIDsToMembers, ipsSource ipsetsSource, xdpModes []bpf.XDPMode) error {
+	if err := a.uninstallXDP(memberCache.bpfLib); err != nil {
+		return err
+	}
+	if err := a.applyMapActions(memberCache, ipsetIDsToMembers, ipsSource); err != nil {
+		return err
+	}
+	return a.installXDP(memberCache.bpfLib, xdpModes)
+}
+
+// uninstallXDP uninstalls the XDP programs.
+func (a *xdpBPFActions) uninstallXDP(bpfLib bpf.BPFDataplane) error {
 	var opErr error
-	logCxt := log.WithField("family", memberCache.GetFamily().String())
 
 	// used for dropping programs, to handle the case when generic
 	// xdp is currently disabled and we need to drop a program
 	// installed in generic mode by previous felix instance which
 	// had generic xdp enabled.
 	allXDPModes := getXDPModes(true)
-	logCxt.Debug("Processing BPF actions.")
 	a.UninstallXDP.Iter(func(iface string) error {
 		var removeErrs []error
-		logCxt.WithField("iface", iface).Debug("Removing XDP programs.")
+		log.WithField("iface", iface).Debug("Removing XDP programs.")
 		for _, mode := range allXDPModes {
-			if err := memberCache.bpfLib.RemoveXDP(iface, mode); err != nil {
+			if err := bpfLib.RemoveXDP(iface, mode); err != nil {
 				removeErrs = append(removeErrs, err)
 			}
 			// Note: keep trying to remove remaining possible modes, even if that one
@@ -1820,9 +2005,15 @@ func (a *xdpBPFActions) apply(memberCache *xdpMemberCache, ipsetIDsToMembers *ip
 		}
 		return nil
 	})
-	if opErr != nil {
-		return opErr
-	}
+	return opErr
+}
+
+// applyMapActions creates and removes the BPF maps of an IP family,
+// and updates their contents.
+func (a *xdpBPFActions) applyMapActions(memberCache *xdpMemberCache, ipsetIDsToMembers *ipsetIDsToMembers, ipsSource ipsetsSource) error {
+	var opErr error
+	logCxt := log.WithField("family", memberCache.GetFamily().String())
+	logCxt.Debug("Processing BPF actions.")
 
 	a.RemoveMap.Iter(func(iface string) error {
 		logCxt.WithField("iface", iface).Debug("Removing BPF blocklist map.")
@@ -1909,14 +2100,23 @@ func (a *xdpBPFActions) apply(memberCache *xdpMemberCache, ipsetIDsToMembers *ip
 		}
 	}
 
+	logCxt.Debug("Finished processing BPF actions.")
+
+	return nil
+}
+
+// installXDP installs the XDP programs.  The maps they use need to
+// exist already.
+func (a *xdpBPFActions) installXDP(bpfLib bpf.BPFDataplane, xdpModes []bpf.XDPMode) error {
+	var opErr error
 	a.InstallXDP.Iter(func(iface string) error {
-		logCxt.WithField("iface", iface).Debug("Loading XDP program.")
+		log.WithField("iface", iface).Debug("Loading XDP program.")
 		var loadErrs []error
 		for _, mode := range xdpModes {
-			if err := memberCache.bpfLib.LoadXDPAuto(iface, mode); err != nil {
+			if err := bpfLib.LoadXDPAuto(iface, mode); err != nil {
 				loadErrs = append(loadErrs, err)
 			} else {
-				logCxt.WithFields(log.Fields{
+				log.WithFields(log.Fields{
 					"iface": iface,
 					"mode":  mode,
 				}).Debug("Loading XDP program succeeded.")
@@ -1930,12 +2130,7 @@ func (a *xdpBPFActions) apply(memberCache *xdpMemberCache, ipsetIDsToMembers *ip
 		}
 		return nil
 	})
-	if opErr != nil {
-		return opErr
-	}
-	logCxt.Debug("Finished processing BPF actions.")
-
-	return nil
+	return opErr
 }
 
 func getXDPModes(allowGenericXDP bool) []bpf.XDPMode {
@@ -2004,7 +2199,11 @@ func convertMembersToMasked(members set.Set[string], setType ipsets.IPSetType) s
 	case ipsets.IPSetTypeHashIP:
 		newMembers := set.New[string]()
 		members.Iter(func(member string) error {
-			newMembers.Add(member + "/32")
+			if isIPv6Member(member) {
+				newMembers.Add(member + "/128")
+			} else {
+				newMembers.Add(member + "/32")
+			}
 			return nil
 		})
 		return newMembers
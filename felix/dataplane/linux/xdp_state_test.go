@@ -1,4 +1,4 @@
-// Copyright (c) 2020 Tigera, Inc. All rights reserved.
+// Copyright (c) 2020-2025 Tigera, Inc. All rights reserved.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -122,6 +122,7 @@ var knownProtoRuleFields = set.From(
 	"HttpMatch",
 	"Metadata",
 	"DstIpPortSetIds",
+	"Dscp",
 )
 
 func testAllProtoRuleFieldsAreKnown() {
@@ -446,7 +447,7 @@ var _ = Describe("XDP state", func() {
 
 			DescribeTable("",
 				func(s testStruct) {
-					state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), true)
+					state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), true, false)
 					ipState := state.ipV4State
 					cs := ipState.currentState
 					expectedNcs := newXDPSystemState()
@@ -1734,7 +1735,7 @@ var _ = Describe("XDP state", func() {
 			DescribeTable("resync",
 				func(s testStruct) {
 					lib, programTag := bpfStateToBpfLib(s.bpfState)
-					state := NewXDPStateWithBPFLibrary(lib, false)
+					state := NewXDPStateWithBPFLibrary(lib, false, false)
 					state.common.programTag = programTag
 					ipState := state.ipV4State
 					ipState.newCurrentState = newXDPSystemState()
@@ -2195,7 +2196,7 @@ var _ = Describe("XDP state", func() {
 					family := bpf.IPFamilyV4
 					lib := stateToBPFDataplane(bpfState, family)
 					memberCache := newXDPMemberCache(family, lib)
-					state := NewXDPStateWithBPFLibrary(lib, true)
+					state := NewXDPStateWithBPFLibrary(lib, true, false)
 					ipState := state.ipV4State
 					ipState.newCurrentState = newXDPSystemState()
 					testStateToRealState(s.newCurrentState, nil, ipState.newCurrentState)
@@ -2410,7 +2411,7 @@ var _ = Describe("XDP state", func() {
 					},
 				},
 			}
-			state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), true)
+			state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), true, false)
 			ipState := state.ipV4State
 			testStateToRealState(testState, nil, ipState.currentState)
 			cache := ipState.ipsetIDsToMembers
@@ -2465,7 +2466,7 @@ var _ = Describe("XDP state", func() {
 
 			DescribeTable("",
 				func(s testStruct) {
-					state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), false)
+					state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), false, false)
 					state.ipV4State.bpfActions.InstallXDP.AddAll(s.install)
 					state.ipV4State.bpfActions.UninstallXDP.AddAll(s.uninstall)
 					state.ipV4State.bpfActions.CreateMap.AddAll(s.create)
@@ -2710,7 +2711,7 @@ var _ = Describe("XDP state", func() {
 
 			DescribeTable("",
 				func(s testStruct) {
-					state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), true)
+					state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), true, false)
 					state.ipV4State.newCurrentState = newXDPSystemState()
 					ipsetsSrc := &nilIPSetsSource{}
 					resyncState, err := state.ipV4State.newXDPResyncState(state.common.bpfLib, ipsetsSrc, state.common.programTag, state.common.xdpModes)
@@ -2797,5 +2798,139 @@ var _ = Describe("XDP state", func() {
 				}),
 			)
 		})
+
+		Describe("IPv6", func() {
+			It("should only optimize rules that match the IP family", func() {
+				Expect(isValidRuleForXDP(customRule(nil, "deny", 0, "ipset"), 4)).To(BeTrue())
+				Expect(isValidRuleForXDP(customRule(nil, "deny", 4, "ipset"), 4)).To(BeTrue())
+				Expect(isValidRuleForXDP(customRule(nil, "deny", 6, "ipset"), 4)).To(BeFalse())
+				Expect(isValidRuleForXDP(customRule(nil, "deny", 0, "ipset"), 6)).To(BeTrue())
+				Expect(isValidRuleForXDP(customRule(nil, "deny", 6, "ipset"), 6)).To(BeTrue())
+				Expect(isValidRuleForXDP(customRule(nil, "deny", 4, "ipset"), 6)).To(BeFalse())
+			})
+
+			It("should only track the members of the IP family", func() {
+				state := NewXDPStateWithBPFLibrary(bpf.NewMockBPFLib("../../bpf-apache/bin"), true, true)
+				state.ipV4State.ipsetIDsToMembers.SetCache("ipset", set.New[string]())
+				state.ipV6State.ipsetIDsToMembers.SetCache("ipset", set.New[string]())
+				state.OnUpdate(&proto.IPSetUpdate{
+					Id:      "ipset",
+					Members: []string{"1.2.3.4", "10.0.0.0/8", "2001:db8::1", "2001:db8:1::/48"},
+				})
+				Expect(state.ipV4State.ipsetIDsToMembers.pendingReplaces["ipset"]).To(Equal(set.From("1.2.3.4", "10.0.0.0/8")))
+				Expect(state.ipV6State.ipsetIDsToMembers.pendingReplaces["ipset"]).To(Equal(set.From("2001:db8::1", "2001:db8:1::/48")))
+			})
+
+			It("should convert IPv6 addresses to CIDRs", func() {
+				members := convertMembersToMasked(set.From("1.2.3.4", "2001:db8::1"), ipsets.IPSetTypeHashIP)
+				Expect(members).To(Equal(set.From("1.2.3.4/32", "2001:db8::1/128")))
+			})
+
+			It("should share the XDP program between the IP families", func() {
+				lib := bpf.NewMockBPFLib("../../bpf-apache/bin")
+				_, err := lib.NewFailsafeMap()
+				Expect(err).NotTo(HaveOccurred())
+				state := NewXDPStateWithBPFLibrary(lib, true, true)
+				epSrc := &mockEndpointsSource{rawHep: map[types.HostEndpointID]*proto.HostEndpoint{
+					{EndpointId: "ep"}: {
+						Name: "default.ep",
+						UntrackedTiers: []*proto.TierInfo{
+							{
+								Name:            "default",
+								IngressPolicies: []string{"policy"},
+							},
+						},
+					},
+				}}
+				ipsetsSrcV6 := &mockIPSetsSource{ipsetsMap: map[string]mockIPSetValue{
+					"ipset": {members: set.From("2001:db8::1"), ipsetType: ipsets.IPSetTypeHashIP},
+				}}
+				update := func() {
+					state.ProcessPendingDiffState(epSrc, epSrc)
+					Expect(state.ResyncIfNeeded(&nilIPSetsSource{}, ipsetsSrcV6)).To(Succeed())
+					Expect(state.ApplyBPFActions(&nilIPSetsSource{}, ipsetsSrcV6)).To(Succeed())
+					Expect(state.ProcessMemberUpdates()).To(Succeed())
+					state.DropPendingDiffState()
+					state.UpdateState()
+				}
+				updatePolicy := func(rule *proto.Rule) {
+					state.OnUpdate(&proto.ActivePolicyUpdate{
+						Id:     &proto.PolicyID{Tier: "default", Name: "policy"},
+						Policy: &proto.Policy{InboundRules: []*proto.Rule{rule}},
+					})
+				}
+
+				By("adding an interface with an IPv6 policy")
+				updatePolicy(customRule(nil, "deny", 6, "ipset"))
+				state.ipV4State.addInterface("iface", types.HostEndpointID{EndpointId: "ep"})
+				state.ipV6State.addInterface("iface", types.HostEndpointID{EndpointId: "ep"})
+				update()
+				Expect(bpfDataplaneDump(lib, bpf.IPFamilyV4)).To(Equal(map[string]map[string]uint32{
+					"iface": {},
+				}))
+				Expect(bpfDataplaneDump(lib, bpf.IPFamilyV6)).To(Equal(map[string]map[string]uint32{
+					"iface": {"2001:db8::1/128": 1},
+				}))
+				mapIDV4, err := lib.GetCIDRMapID("iface", bpf.IPFamilyV4)
+				Expect(err).NotTo(HaveOccurred())
+				mapIDV6, err := lib.GetCIDRMapID("iface", bpf.IPFamilyV6)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lib.GetMapsFromXDP("iface")).To(ConsistOf(lib.FailsafeMap.Info.Id, mapIDV4, mapIDV6))
+
+				By("adding members of both IP families to the IP set")
+				state.OnUpdate(&proto.IPSetDeltaUpdate{
+					Id:           "ipset",
+					AddedMembers: []string{"10.0.0.1", "2001:db8::2"},
+				})
+				update()
+				Expect(bpfDataplaneDump(lib, bpf.IPFamilyV4)).To(Equal(map[string]map[string]uint32{
+					"iface": {},
+				}))
+				Expect(bpfDataplaneDump(lib, bpf.IPFamilyV6)).To(Equal(map[string]map[string]uint32{
+					"iface": {"2001:db8::1/128": 1, "2001:db8::2/128": 1},
+				}))
+
+				By("making the policy unoptimizable")
+				updatePolicy(allowRule("ipset"))
+				update()
+				Expect(lib.XDPProgs).To(BeEmpty())
+				Expect(lib.CIDRMaps).To(BeEmpty())
+			})
+
+			It("should remove stale IPv6 maps when IPv6 is disabled", func() {
+				lib := bpf.NewMockBPFLib("../../bpf-apache/bin")
+				_, err := lib.NewFailsafeMap()
+				Expect(err).NotTo(HaveOccurred())
+				for _, family := range []bpf.IPFamily{bpf.IPFamilyV4, bpf.IPFamilyV6} {
+					_, err = lib.NewCIDRMap("iface", family)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(lib.LoadXDPAuto("iface", bpf.XDPDriver)).To(Succeed())
+
+				state := NewXDPStateWithBPFLibrary(lib, true, false)
+				state.ipV4State.newCurrentState = newXDPSystemState()
+				testStateToRealState(map[string]testIfaceData{
+					"iface": {
+						epID: "ep",
+						policiesToSets: map[string][]string{
+							"policy": {"ipset"},
+						},
+					},
+				}, nil, state.ipV4State.newCurrentState)
+				ipsetsSrc := &mockIPSetsSource{ipsetsMap: map[string]mockIPSetValue{
+					"ipset": {members: set.From("1.2.3.4"), ipsetType: ipsets.IPSetTypeHashIP},
+				}}
+				Expect(state.ResyncIfNeeded(ipsetsSrc, nil)).To(Succeed())
+				Expect(state.ApplyBPFActions(ipsetsSrc, nil)).To(Succeed())
+
+				Expect(lib.ListCIDRMaps(bpf.IPFamilyV6)).To(BeEmpty())
+				Expect(bpfDataplaneDump(lib, bpf.IPFamilyV4)).To(Equal(map[string]map[string]uint32{
+					"iface": {"1.2.3.4/32": 1},
+				}))
+				mapIDV4, err := lib.GetCIDRMapID("iface", bpf.IPFamilyV4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lib.GetMapsFromXDP("iface")).To(ConsistOf(lib.FailsafeMap.Info.Id, mapIDV4))
+			})
+		})
 	})
 })
@@ -353,7 +353,7 @@ func (s *PolicySets) protoRuleToHnsRules(policyId string, pRule *proto.Rule, isI
 		aclPolicy.Action = hns.Block
 	case "next-tier", "pass":
 		aclPolicy.Action = ActionPass
-	case "log":
+	case "log", "setdscp":
 		logCxt.WithField("action", ruleCopy.Action).Info("This rule action is not supported, rule will be skipped")
 		return nil, ErrNotSupported
 	default:
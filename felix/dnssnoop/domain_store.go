@@ -0,0 +1,433 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dnssnoop learns the IPs of domain names by snooping on the DNS responses that are sent
+// to local workloads.  The learned mappings are used to populate the IP sets for domain-based
+// policy rules.  Only DNS over UDP is snooped; the dataplane only passes us queries to, and
+// responses from, the trusted DNS servers.
+package dnssnoop
+
+import (
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+const (
+	expiryInterval = time.Second
+
+	// queryTimeout is how long we wait for the response to a DNS query.  Resolvers retry well
+	// within this time, and a retry with the same ID replaces the pending query.
+	queryTimeout = 10 * time.Second
+	// maxPendingQueries limits the memory used to track outstanding queries.  Further queries are
+	// not recorded, so their responses are not snooped.
+	maxPendingQueries = 10000
+)
+
+// DomainInfoStore records the A, AAAA and CNAME records seen in DNS responses, along with their
+// expiry times (as calculated from the TTLs).  It is safe for concurrent use; DNS responses are
+// fed in from the snooper goroutine and the dataplane goroutine reads out the changes.
+type DomainInfoStore struct {
+	lock sync.Mutex
+	// now is a shim for time.Now, replaced in tests.
+	now func() time.Time
+
+	// mappings maps from (lower case, no trailing dot) name to the data that we've learned for it.
+	mappings map[string]*nameData
+	// revCNAMEs maps from a name to the names that have a CNAME record pointing at it.
+	revCNAMEs map[string]set.Set[string]
+	// pendingQueries holds the queries that workloads have sent and that haven't been answered
+	// yet.  We only learn from responses that answer one of them.
+	pendingQueries map[queryKey]pendingQuery
+
+	// changedNames holds the names whose values have changed since the last TakeChanges.
+	changedNames set.Set[string]
+	// changeGen is incremented for each batch of changes.  The dataplane reports the generation
+	// that it has programmed via MarkApplied, which allows DNS responses to be held until the
+	// dataplane is ready for the connections that follow them.
+	changeGen  uint64
+	appliedGen uint64
+	// appliedC is closed (and replaced) each time appliedGen increases.
+	appliedC chan struct{}
+
+	updatesReady chan struct{}
+}
+
+type nameData struct {
+	// ips and cnames map from value to the time that the value expires.
+	ips    map[string]time.Time
+	cnames map[string]time.Time
+}
+
+func (d *nameData) empty() bool {
+	return len(d.ips) == 0 && len(d.cnames) == 0
+}
+
+// queryKey identifies an outstanding DNS query by the addresses and ports of the workload and the
+// server, and the DNS message ID.
+type queryKey struct {
+	client, server netip.AddrPort
+	id             uint16
+}
+
+type pendingQuery struct {
+	question dnsmessage.Question
+	expiry   time.Time
+}
+
+func (q pendingQuery) matches(question dnsmessage.Question) bool {
+	return q.question.Type == question.Type &&
+		q.question.Class == question.Class &&
+		strings.EqualFold(q.question.Name.String(), question.Name.String())
+}
+
+func NewDomainInfoStore() *DomainInfoStore {
+	return &DomainInfoStore{
+		now:            time.Now,
+		mappings:       map[string]*nameData{},
+		revCNAMEs:      map[string]set.Set[string]{},
+		pendingQueries: map[queryKey]pendingQuery{},
+		changedNames:   set.New[string](),
+		appliedC:       make(chan struct{}),
+		updatesReady:   make(chan struct{}, 1),
+	}
+}
+
+// Start starts a background goroutine that removes expired records.
+func (s *DomainInfoStore) Start() {
+	go s.loopExpiring()
+}
+
+func (s *DomainInfoStore) loopExpiring() {
+	ticker := time.NewTicker(expiryInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.expire()
+	}
+}
+
+// UpdatesReadyC returns a channel that receives a value when there are changes waiting to be
+// collected with TakeChanges.
+func (s *DomainInfoStore) UpdatesReadyC() <-chan struct{} {
+	return s.updatesReady
+}
+
+// ProcessDNSMessage handles a DNS message that was sent from src to dst.  Queries are recorded;
+// responses are only used if they answer a recorded query, i.e. they come from the address and port
+// that the query was sent to and have the same ID and question.  For a response, it returns the
+// change generation that must be applied before the response can be released, and whether the
+// response changed anything.
+func (s *DomainInfoStore) ProcessDNSMessage(src, dst netip.AddrPort, msg []byte) (gen uint64, changed bool) {
+	var p dnsmessage.Parser
+	hdr, err := p.Start(msg)
+	if err != nil {
+		log.WithError(err).Debug("Failed to parse DNS message.")
+		return 0, false
+	}
+	question, err := p.Question()
+	if err != nil {
+		log.WithError(err).Debug("Failed to parse DNS question.")
+		return 0, false
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	if !hdr.Response {
+		s.recordQueryLockHeld(queryKey{client: src, server: dst, id: hdr.ID}, question, now)
+		return 0, false
+	}
+	key := queryKey{client: dst, server: src, id: hdr.ID}
+	query, ok := s.pendingQueries[key]
+	if !ok || !query.matches(question) || !query.expiry.After(now) {
+		log.WithFields(log.Fields{"server": src, "client": dst, "id": hdr.ID}).Debug(
+			"Ignoring DNS response that doesn't match a query.")
+		return 0, false
+	}
+	delete(s.pendingQueries, key)
+	if hdr.RCode != dnsmessage.RCodeSuccess {
+		return 0, false
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		log.WithError(err).Debug("Failed to parse DNS questions.")
+		return 0, false
+	}
+
+	for {
+		ah, err := p.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			log.WithError(err).Debug("Failed to parse DNS answer.")
+			break
+		}
+		name := NormaliseDomain(ah.Name.String())
+		expiry := now.Add(time.Duration(ah.TTL) * time.Second)
+		switch ah.Type {
+		case dnsmessage.TypeA:
+			r, err := p.AResource()
+			if err != nil {
+				log.WithError(err).Debug("Failed to parse A record.")
+				return s.finishBatchLockHeld(changed)
+			}
+			changed = s.storeLockHeld(name, net.IP(r.A[:]).String(), false, expiry) || changed
+		case dnsmessage.TypeAAAA:
+			r, err := p.AAAAResource()
+			if err != nil {
+				log.WithError(err).Debug("Failed to parse AAAA record.")
+				return s.finishBatchLockHeld(changed)
+			}
+			changed = s.storeLockHeld(name, net.IP(r.AAAA[:]).String(), false, expiry) || changed
+		case dnsmessage.TypeCNAME:
+			r, err := p.CNAMEResource()
+			if err != nil {
+				log.WithError(err).Debug("Failed to parse CNAME record.")
+				return s.finishBatchLockHeld(changed)
+			}
+			changed = s.storeLockHeld(name, NormaliseDomain(r.CNAME.String()), true, expiry) || changed
+		default:
+			if err := p.SkipAnswer(); err != nil {
+				log.WithError(err).Debug("Failed to skip DNS answer.")
+				return s.finishBatchLockHeld(changed)
+			}
+		}
+	}
+	return s.finishBatchLockHeld(changed)
+}
+
+func (s *DomainInfoStore) recordQueryLockHeld(key queryKey, question dnsmessage.Question, now time.Time) {
+	if _, ok := s.pendingQueries[key]; !ok && len(s.pendingQueries) >= maxPendingQueries {
+		log.WithField("client", key.client).Debug("Too many pending DNS queries; not recording query.")
+		return
+	}
+	s.pendingQueries[key] = pendingQuery{question: question, expiry: now.Add(queryTimeout)}
+}
+
+func (s *DomainInfoStore) finishBatchLockHeld(changed bool) (uint64, bool) {
+	if !changed {
+		return 0, false
+	}
+	s.changeGen++
+	select {
+	case s.updatesReady <- struct{}{}:
+	default:
+		// Already a signal pending.
+	}
+	return s.changeGen, true
+}
+
+// storeLockHeld records a single value for the name, returning true if the value is new.  Values
+// that we already know about just have their expiry extended.
+func (s *DomainInfoStore) storeLockHeld(name, value string, isCNAME bool, expiry time.Time) bool {
+	data := s.mappings[name]
+	if data == nil {
+		data = &nameData{ips: map[string]time.Time{}, cnames: map[string]time.Time{}}
+		s.mappings[name] = data
+	}
+	values := data.ips
+	if isCNAME {
+		values = data.cnames
+	}
+	oldExpiry, known := values[value]
+	if known {
+		if expiry.After(oldExpiry) {
+			values[value] = expiry
+		}
+		return false
+	}
+	log.WithFields(log.Fields{"name": name, "value": value, "cname": isCNAME}).Debug("Learned new DNS mapping")
+	values[value] = expiry
+	if isCNAME {
+		if s.revCNAMEs[value] == nil {
+			s.revCNAMEs[value] = set.New[string]()
+		}
+		s.revCNAMEs[value].Add(name)
+	}
+	s.changedNames.Add(name)
+	return true
+}
+
+func (s *DomainInfoStore) expire() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	for key, query := range s.pendingQueries {
+		if !query.expiry.After(now) {
+			delete(s.pendingQueries, key)
+		}
+	}
+
+	changed := false
+	for name, data := range s.mappings {
+		for ip, expiry := range data.ips {
+			if !expiry.After(now) {
+				delete(data.ips, ip)
+				s.changedNames.Add(name)
+				changed = true
+			}
+		}
+		for cname, expiry := range data.cnames {
+			if !expiry.After(now) {
+				delete(data.cnames, cname)
+				if rev := s.revCNAMEs[cname]; rev != nil {
+					rev.Discard(name)
+					if rev.Len() == 0 {
+						delete(s.revCNAMEs, cname)
+					}
+				}
+				s.changedNames.Add(name)
+				changed = true
+			}
+		}
+		if data.empty() {
+			delete(s.mappings, name)
+		}
+	}
+	if changed {
+		log.Debug("Some DNS mappings expired.")
+	}
+	s.finishBatchLockHeld(changed)
+}
+
+// TakeChanges returns the names whose IPs may have changed since the last call, along with the
+// generation that the changes correspond to.  Names that reach a changed name via CNAME records
+// are included.
+func (s *DomainInfoStore) TakeChanges() (set.Set[string], uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	changed := set.New[string]()
+	var addWithAliases func(name string)
+	addWithAliases = func(name string) {
+		if changed.Contains(name) {
+			return
+		}
+		changed.Add(name)
+		if rev := s.revCNAMEs[name]; rev != nil {
+			for _, alias := range rev.Slice() {
+				addWithAliases(alias)
+			}
+		}
+	}
+	for _, name := range s.changedNames.Slice() {
+		addWithAliases(name)
+	}
+	s.changedNames = set.New[string]()
+	return changed, s.changeGen
+}
+
+// IPsForDomain returns the unexpired IPs for the given domain, following CNAME records.  A domain
+// with a leading "*." matches all subdomains of the rest of the name.
+func (s *DomainInfoStore) IPsForDomain(domain string) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	domain = NormaliseDomain(domain)
+	ips := set.New[string]()
+	visited := set.New[string]()
+	if strings.HasPrefix(domain, "*.") {
+		for name := range s.mappings {
+			if DomainMatches(domain, name) {
+				s.collectIPsLockHeld(name, now, visited, ips)
+			}
+		}
+	} else {
+		s.collectIPsLockHeld(domain, now, visited, ips)
+	}
+	result := ips.Slice()
+	sort.Strings(result)
+	return result
+}
+
+func (s *DomainInfoStore) collectIPsLockHeld(name string, now time.Time, visited, ips set.Set[string]) {
+	if visited.Contains(name) {
+		return
+	}
+	visited.Add(name)
+	data := s.mappings[name]
+	if data == nil {
+		return
+	}
+	for ip, expiry := range data.ips {
+		if expiry.After(now) {
+			ips.Add(ip)
+		}
+	}
+	for cname, expiry := range data.cnames {
+		if expiry.After(now) {
+			s.collectIPsLockHeld(cname, now, visited, ips)
+		}
+	}
+}
+
+// MarkApplied records that the dataplane has been programmed with the changes up to gen.
+func (s *DomainInfoStore) MarkApplied(gen uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if gen <= s.appliedGen {
+		return
+	}
+	s.appliedGen = gen
+	close(s.appliedC)
+	s.appliedC = make(chan struct{})
+}
+
+// WaitForApplied waits until the dataplane has been programmed with the changes up to gen, or until
+// the timeout expires.  It returns true if the changes were applied.
+func (s *DomainInfoStore) WaitForApplied(gen uint64, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		s.lock.Lock()
+		if s.appliedGen >= gen {
+			s.lock.Unlock()
+			return true
+		}
+		appliedC := s.appliedC
+		s.lock.Unlock()
+
+		select {
+		case <-appliedC:
+		case <-timer.C:
+			return false
+		}
+	}
+}
+
+// NormaliseDomain converts a domain name to the form used as a key in the store: lower case,
+// without a trailing dot.
+func NormaliseDomain(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// DomainMatches returns true if the (normalised) name matches the given (normalised) domain, which
+// may have a leading "*." to match any subdomain.
+func DomainMatches(domain, name string) bool {
+	if suffix, ok := strings.CutPrefix(domain, "*"); ok {
+		return strings.HasSuffix(name, suffix) && len(name) > len(suffix)
+	}
+	return domain == name
+}
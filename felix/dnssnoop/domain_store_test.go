@@ -0,0 +1,297 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssnoop
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type testRecord struct {
+	name  string
+	ttl   uint32
+	a     string
+	aaaa  string
+	cname string
+}
+
+var (
+	testClient = netip.MustParseAddrPort("10.65.0.2:40000")
+	testServer = netip.MustParseAddrPort("10.96.0.10:53")
+)
+
+func dnsQuery(t *testing.T, id uint16, name string) []byte {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	addQuestion(t, &b, name)
+	msg, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func addQuestion(t *testing.T, b *dnsmessage.Builder, name string) {
+	if err := b.StartQuestions(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(name),
+		Type:  dnsmessage.TypeA,
+		Class: dnsmessage.ClassINET,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func dnsResponse(t *testing.T, id uint16, records ...testRecord) []byte {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, Response: true, RCode: dnsmessage.RCodeSuccess})
+	addQuestion(t, &b, records[0].name)
+	if err := b.StartAnswers(); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		hdr := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(r.name), Class: dnsmessage.ClassINET, TTL: r.ttl}
+		var err error
+		switch {
+		case r.a != "":
+			var a [4]byte
+			copy(a[:], parseIP(t, r.a).To4())
+			err = b.AResource(hdr, dnsmessage.AResource{A: a})
+		case r.aaaa != "":
+			var aaaa [16]byte
+			copy(aaaa[:], parseIP(t, r.aaaa).To16())
+			err = b.AAAAResource(hdr, dnsmessage.AAAAResource{AAAA: aaaa})
+		default:
+			err = b.CNAMEResource(hdr, dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(r.cname)})
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	msg, err := b.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// exchange feeds the store a query for the first record's name from testClient to testServer,
+// followed by the server's response containing the records.
+func exchange(t *testing.T, s *DomainInfoStore, records ...testRecord) (uint64, bool) {
+	s.ProcessDNSMessage(testClient, testServer, dnsQuery(t, 1234, records[0].name))
+	return s.ProcessDNSMessage(testServer, testClient, dnsResponse(t, 1234, records...))
+}
+
+func newTestStore() (*DomainInfoStore, *time.Time) {
+	s := NewDomainInfoStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestDomainInfoStore_ARecords(t *testing.T) {
+	RegisterTestingT(t)
+	s, _ := newTestStore()
+
+	gen, changed := exchange(t, s,
+		testRecord{name: "Example.COM.", ttl: 30, a: "10.0.0.1"},
+		testRecord{name: "example.com.", ttl: 30, aaaa: "fd00::1"},
+	)
+	Expect(changed).To(BeTrue())
+	Expect(gen).To(Equal(uint64(1)))
+	Expect(s.UpdatesReadyC()).To(Receive())
+	Expect(s.IPsForDomain("example.com")).To(Equal([]string{"10.0.0.1", "fd00::1"}))
+
+	changes, takenGen := s.TakeChanges()
+	Expect(changes.Slice()).To(ConsistOf("example.com"))
+	Expect(takenGen).To(Equal(uint64(1)))
+
+	// Seeing the same records again only refreshes the expiry.
+	_, changed = exchange(t, s, testRecord{name: "example.com.", ttl: 60, a: "10.0.0.1"})
+	Expect(changed).To(BeFalse())
+	Expect(s.UpdatesReadyC()).NotTo(Receive())
+}
+
+func TestDomainInfoStore_IgnoresQueriesAndErrors(t *testing.T) {
+	RegisterTestingT(t)
+	s, _ := newTestStore()
+
+	query := dnsQuery(t, 1, "example.com.")
+	_, changed := s.ProcessDNSMessage(testClient, testServer, query)
+	Expect(changed).To(BeFalse())
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 1, Response: true, RCode: dnsmessage.RCodeNameError})
+	addQuestion(t, &b, "example.com.")
+	msg, err := b.Finish()
+	Expect(err).NotTo(HaveOccurred())
+	_, changed = s.ProcessDNSMessage(testServer, testClient, msg)
+	Expect(changed).To(BeFalse())
+	Expect(s.pendingQueries).To(BeEmpty())
+
+	_, changed = s.ProcessDNSMessage(testServer, testClient, []byte{1, 2, 3})
+	Expect(changed).To(BeFalse())
+}
+
+func TestDomainInfoStore_OnlyLearnsAnswersToQueries(t *testing.T) {
+	RegisterTestingT(t)
+	s, now := newTestStore()
+	record := testRecord{name: "example.com.", ttl: 30, a: "10.0.0.1"}
+
+	// Unsolicited response.
+	_, changed := s.ProcessDNSMessage(testServer, testClient, dnsResponse(t, 1, record))
+	Expect(changed).To(BeFalse())
+
+	s.ProcessDNSMessage(testClient, testServer, dnsQuery(t, 1, "example.com."))
+
+	// Wrong ID, question, server or client port.
+	_, changed = s.ProcessDNSMessage(testServer, testClient, dnsResponse(t, 2, record))
+	Expect(changed).To(BeFalse())
+	_, changed = s.ProcessDNSMessage(testServer, testClient, dnsResponse(t, 1,
+		testRecord{name: "example.net.", ttl: 30, a: "10.0.0.1"}))
+	Expect(changed).To(BeFalse())
+	_, changed = s.ProcessDNSMessage(netip.MustParseAddrPort("10.96.0.11:53"), testClient, dnsResponse(t, 1, record))
+	Expect(changed).To(BeFalse())
+	_, changed = s.ProcessDNSMessage(testServer, netip.MustParseAddrPort("10.65.0.2:40001"), dnsResponse(t, 1, record))
+	Expect(changed).To(BeFalse())
+	Expect(s.IPsForDomain("example.com")).To(BeEmpty())
+
+	// The real response, with the question's case changed by the server.
+	_, changed = s.ProcessDNSMessage(testServer, testClient, dnsResponse(t, 1,
+		testRecord{name: "EXAMPLE.com.", ttl: 30, a: "10.0.0.1"}))
+	Expect(changed).To(BeTrue())
+	Expect(s.IPsForDomain("example.com")).To(Equal([]string{"10.0.0.1"}))
+
+	// A second response to the same query is ignored.
+	_, changed = s.ProcessDNSMessage(testServer, testClient, dnsResponse(t, 1,
+		testRecord{name: "example.com.", ttl: 30, a: "10.0.0.2"}))
+	Expect(changed).To(BeFalse())
+
+	// As is a response that arrives after the query has timed out.
+	s.ProcessDNSMessage(testClient, testServer, dnsQuery(t, 3, "example.com."))
+	*now = now.Add(queryTimeout)
+	_, changed = s.ProcessDNSMessage(testServer, testClient, dnsResponse(t, 3,
+		testRecord{name: "example.com.", ttl: 30, a: "10.0.0.3"}))
+	Expect(changed).To(BeFalse())
+	Expect(s.IPsForDomain("example.com")).To(Equal([]string{"10.0.0.1"}))
+}
+
+func TestDomainInfoStore_PendingQueryLimit(t *testing.T) {
+	RegisterTestingT(t)
+	s, now := newTestStore()
+
+	for i := 0; i < maxPendingQueries+1; i++ {
+		s.ProcessDNSMessage(testClient, testServer, dnsQuery(t, uint16(i), "example.com."))
+	}
+	Expect(s.pendingQueries).To(HaveLen(maxPendingQueries))
+
+	*now = now.Add(queryTimeout)
+	s.expire()
+	Expect(s.pendingQueries).To(BeEmpty())
+}
+
+func TestDomainInfoStore_CNAMEs(t *testing.T) {
+	RegisterTestingT(t)
+	s, _ := newTestStore()
+
+	exchange(t, s,
+		testRecord{name: "www.example.com.", ttl: 30, cname: "cdn.example.net."},
+		testRecord{name: "cdn.example.net.", ttl: 30, a: "10.0.0.2"},
+	)
+	Expect(s.IPsForDomain("www.example.com")).To(Equal([]string{"10.0.0.2"}))
+	s.TakeChanges()
+
+	// A new IP for the CNAME target should be reported as a change to the alias too.
+	exchange(t, s, testRecord{name: "cdn.example.net.", ttl: 30, a: "10.0.0.3"})
+	changes, _ := s.TakeChanges()
+	Expect(changes.Slice()).To(ConsistOf("cdn.example.net", "www.example.com"))
+	Expect(s.IPsForDomain("www.example.com")).To(Equal([]string{"10.0.0.2", "10.0.0.3"}))
+}
+
+func TestDomainInfoStore_CNAMELoop(t *testing.T) {
+	RegisterTestingT(t)
+	s, _ := newTestStore()
+
+	exchange(t, s,
+		testRecord{name: "a.example.com.", ttl: 30, cname: "b.example.com."},
+		testRecord{name: "b.example.com.", ttl: 30, cname: "a.example.com."},
+	)
+	Expect(s.IPsForDomain("a.example.com")).To(BeEmpty())
+	changes, _ := s.TakeChanges()
+	Expect(changes.Slice()).To(ConsistOf("a.example.com", "b.example.com"))
+}
+
+func TestDomainInfoStore_Wildcards(t *testing.T) {
+	RegisterTestingT(t)
+	s, _ := newTestStore()
+
+	exchange(t, s, testRecord{name: "example.com.", ttl: 30, a: "10.0.0.1"})
+	exchange(t, s, testRecord{name: "a.example.com.", ttl: 30, a: "10.0.0.2"})
+	exchange(t, s, testRecord{name: "b.c.example.com.", ttl: 30, a: "10.0.0.3"})
+	exchange(t, s, testRecord{name: "notexample.com.", ttl: 30, a: "10.0.0.4"})
+
+	Expect(s.IPsForDomain("*.example.com")).To(Equal([]string{"10.0.0.2", "10.0.0.3"}))
+	Expect(DomainMatches("*.example.com", "example.com")).To(BeFalse())
+	Expect(DomainMatches("*.example.com", "a.example.com")).To(BeTrue())
+	Expect(DomainMatches("example.com", "example.com")).To(BeTrue())
+}
+
+func TestDomainInfoStore_Expiry(t *testing.T) {
+	RegisterTestingT(t)
+	s, now := newTestStore()
+
+	exchange(t, s,
+		testRecord{name: "example.com.", ttl: 10, a: "10.0.0.1"},
+		testRecord{name: "example.com.", ttl: 60, a: "10.0.0.2"},
+	)
+	s.TakeChanges()
+	<-s.UpdatesReadyC()
+
+	*now = now.Add(11 * time.Second)
+	// Expired IPs are ignored even before they're cleaned up.
+	Expect(s.IPsForDomain("example.com")).To(Equal([]string{"10.0.0.2"}))
+
+	s.expire()
+	Expect(s.UpdatesReadyC()).To(Receive())
+	changes, gen := s.TakeChanges()
+	Expect(changes.Slice()).To(ConsistOf("example.com"))
+	Expect(gen).To(Equal(uint64(2)))
+
+	*now = now.Add(60 * time.Second)
+	s.expire()
+	Expect(s.IPsForDomain("example.com")).To(BeEmpty())
+	Expect(s.mappings).To(BeEmpty())
+}
+
+func TestDomainInfoStore_WaitForApplied(t *testing.T) {
+	RegisterTestingT(t)
+	s, _ := newTestStore()
+
+	gen, _ := exchange(t, s, testRecord{name: "example.com.", ttl: 10, a: "10.0.0.1"})
+	Expect(s.WaitForApplied(gen, 10*time.Millisecond)).To(BeFalse())
+
+	done := make(chan bool)
+	go func() {
+		done <- s.WaitForApplied(gen, 10*time.Second)
+	}()
+	s.MarkApplied(gen)
+	Eventually(done).Should(Receive(BeTrue()))
+
+	// Already applied.
+	Expect(s.WaitForApplied(gen, 0)).To(BeTrue())
+}
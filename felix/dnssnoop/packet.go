@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssnoop
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/projectcalico/calico/felix/nfnetlink/pkt"
+)
+
+const protoUDP = 17
+
+// udpPacket holds the addresses and payload of a UDP packet.
+type udpPacket struct {
+	src, dst netip.AddrPort
+	payload  []byte
+}
+
+// parseUDPPacket parses a raw IPv4 or IPv6 UDP packet, as passed to us by NFQUEUE.  IPv6 extension
+// headers are not supported; DNS messages don't use them in practice.
+func parseUDPPacket(b []byte) (udpPacket, error) {
+	if len(b) == 0 {
+		return udpPacket{}, errors.New("empty packet")
+	}
+	var offset int
+	var src, dst netip.Addr
+	switch b[0] >> 4 {
+	case 4:
+		if len(b) < pkt.SizeofIPv4Header {
+			return udpPacket{}, errors.New("short IPv4 header")
+		}
+		hdr := pkt.ParseIPv4Header(b)
+		if hdr.Protocol != protoUDP {
+			return udpPacket{}, fmt.Errorf("unexpected IP protocol %d", hdr.Protocol)
+		}
+		offset = int(hdr.IHL)
+		src, _ = netip.AddrFromSlice(hdr.Saddr.To4())
+		dst, _ = netip.AddrFromSlice(hdr.Daddr.To4())
+	case 6:
+		if len(b) < pkt.IPv6HeaderLen {
+			return udpPacket{}, errors.New("short IPv6 header")
+		}
+		hdr := pkt.ParseIPv6Header(b)
+		if hdr.NextHeader != protoUDP {
+			return udpPacket{}, fmt.Errorf("unexpected IPv6 next header %d", hdr.NextHeader)
+		}
+		offset = pkt.IPv6HeaderLen
+		src, _ = netip.AddrFromSlice(hdr.Saddr.To16())
+		dst, _ = netip.AddrFromSlice(hdr.Daddr.To16())
+	default:
+		return udpPacket{}, fmt.Errorf("unknown IP version %d", b[0]>>4)
+	}
+	if len(b) < offset+pkt.SizeofUDPHeader {
+		return udpPacket{}, errors.New("short UDP header")
+	}
+	udp := pkt.ParseUDPHeader(b[offset:])
+	return udpPacket{
+		src:     netip.AddrPortFrom(src, udp.Source),
+		dst:     netip.AddrPortFrom(dst, udp.Dest),
+		payload: b[offset+pkt.SizeofUDPHeader:],
+	}, nil
+}
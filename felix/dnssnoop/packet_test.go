@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssnoop
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func parseIP(t *testing.T, s string) net.IP {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		t.Fatalf("bad IP %q", s)
+	}
+	return ip
+}
+
+func TestParseUDPPacket(t *testing.T) {
+	RegisterTestingT(t)
+	udp := []byte{0, 53, 0x30, 0x39, 0, 11, 0, 0, 'd', 'n', 's'}
+
+	v4 := append([]byte{0x45, 0, 0, 31, 0, 0, 0, 0, 64, protoUDP, 0, 0, 10, 0, 0, 1, 10, 0, 0, 2}, udp...)
+	p, err := parseUDPPacket(v4)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(p.src).To(Equal(netip.MustParseAddrPort("10.0.0.1:53")))
+	Expect(p.dst).To(Equal(netip.MustParseAddrPort("10.0.0.2:12345")))
+	Expect(p.payload).To(Equal([]byte("dns")))
+
+	// IPv4 with options.
+	v4Opts := append([]byte{0x46, 0, 0, 35, 0, 0, 0, 0, 64, protoUDP, 0, 0, 10, 0, 0, 1, 10, 0, 0, 2, 1, 1, 1, 0}, udp...)
+	p, err = parseUDPPacket(v4Opts)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(p.dst).To(Equal(netip.MustParseAddrPort("10.0.0.2:12345")))
+	Expect(p.payload).To(Equal([]byte("dns")))
+
+	v6 := make([]byte, 40)
+	v6[0] = 0x60
+	v6[6] = protoUDP
+	v6[23] = 1
+	v6[39] = 2
+	p, err = parseUDPPacket(append(v6, udp...))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(p.src).To(Equal(netip.MustParseAddrPort("[::1]:53")))
+	Expect(p.dst).To(Equal(netip.MustParseAddrPort("[::2]:12345")))
+	Expect(p.payload).To(Equal([]byte("dns")))
+
+	tcp := append([]byte{}, v4...)
+	tcp[9] = 6
+	_, err = parseUDPPacket(tcp)
+	Expect(err).To(HaveOccurred())
+
+	_, err = parseUDPPacket(v4[:24])
+	Expect(err).To(HaveOccurred())
+	_, err = parseUDPPacket(nil)
+	Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnssnoop
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+)
+
+const (
+	// maxQueuedPackets is the number of DNS packets that the kernel will hold for us before it
+	// starts to accept them without snooping.
+	maxQueuedPackets = 1000
+)
+
+// Snooper reads DNS queries and responses from an NFQUEUE, feeds them to the DomainInfoStore and
+// then releases them.  If delayResponses is set, responses that teach us something new are held until the
+// dataplane has been updated (or until maxHold expires) so that the workload's subsequent
+// connection is allowed by the domain IP sets.
+type Snooper struct {
+	store          *DomainInfoStore
+	queueNum       int
+	delayResponses bool
+	maxHold        time.Duration
+}
+
+func NewSnooper(store *DomainInfoStore, queueNum int, delayResponses bool, maxHold time.Duration) *Snooper {
+	return &Snooper{
+		store:          store,
+		queueNum:       queueNum,
+		delayResponses: delayResponses,
+		maxHold:        maxHold,
+	}
+}
+
+// Start subscribes to the NFQUEUE and starts a background goroutine to process the packets.
+func (s *Snooper) Start() error {
+	ch := make(chan nfnetlink.NfqueuePacket, maxQueuedPackets)
+	q, err := nfnetlink.NfqueueSubscribe(s.queueNum, maxQueuedPackets, ch, make(chan struct{}))
+	if err != nil {
+		return err
+	}
+	go s.loopProcessingPackets(q, ch)
+	return nil
+}
+
+func (s *Snooper) loopProcessingPackets(q *nfnetlink.Nfqueue, ch <-chan nfnetlink.NfqueuePacket) {
+	logCtx := log.WithField("queueNum", s.queueNum)
+	for p := range ch {
+		gen, changed := uint64(0), false
+		if udp, err := parseUDPPacket(p.Payload); err != nil {
+			logCtx.WithError(err).Debug("Ignoring unexpected packet.")
+		} else {
+			gen, changed = s.store.ProcessDNSMessage(udp.src, udp.dst, udp.payload)
+		}
+
+		if changed && s.delayResponses {
+			go func(id uint32) {
+				if !s.store.WaitForApplied(gen, s.maxHold) {
+					logCtx.Debug("Timed out waiting for dataplane to be updated; releasing DNS response.")
+				}
+				s.accept(q, id)
+			}(p.ID)
+			continue
+		}
+		s.accept(q, p.ID)
+	}
+	logCtx.Warn("NFQUEUE closed; no longer snooping DNS responses.")
+}
+
+func (s *Snooper) accept(q *nfnetlink.Nfqueue, id uint32) {
+	if err := q.SetVerdict(id, nfnl.NF_ACCEPT); err != nil {
+		log.WithError(err).WithField("queueNum", s.queueNum).Warn("Failed to release DNS packet.")
+	}
+}
@@ -33,8 +33,10 @@ type ActionFactory interface {
 	SetConnmark(mark, mask uint32) Action
 	Reject(with RejectWith) Action
 	Nflog(group uint16, prefix string, size int) Action
+	Nfqueue(queueNum uint16) Action
 	LimitPacketRate(rate int64, mark uint32) Action
 	LimitNumConnections(num int64, rejectWith RejectWith) Action
+	SetDSCP(dscp uint8) Action
 }
 
 type RejectWith string
@@ -37,10 +37,13 @@ type MatchCriteria interface {
 	NotDestAddrType(addrType AddrType) MatchCriteria
 	ConntrackState(stateNames string) MatchCriteria
 	NotConntrackState(stateNames string) MatchCriteria
+	ConntrackOrigDest(net string) MatchCriteria
+	ConntrackOriginalDirection() MatchCriteria
 	Protocol(name string) MatchCriteria
 	NotProtocol(name string) MatchCriteria
 	ProtocolNum(num uint8) MatchCriteria
 	NotProtocolNum(num uint8) MatchCriteria
+	IPIPInnerDSCP(min, max uint8) MatchCriteria
 	SourceNet(net string) MatchCriteria
 	NotSourceNet(net string) MatchCriteria
 	DestNet(net string) MatchCriteria
@@ -105,6 +105,12 @@ func (s *actionFactory) Nflog(group uint16, prefix string, size int) generictabl
 	}
 }
 
+func (s *actionFactory) Nfqueue(queueNum uint16) generictables.Action {
+	return NfqueueAction{
+		QueueNum: queueNum,
+	}
+}
+
 func (a *actionFactory) LimitPacketRate(rate int64, mark uint32) generictables.Action {
 	return LimitPacketRateAction{
 		Rate: rate,
@@ -119,6 +125,10 @@ func (a *actionFactory) LimitNumConnections(num int64, rejectWith generictables.
 	}
 }
 
+func (a *actionFactory) SetDSCP(dscp uint8) generictables.Action {
+	return SetDSCPAction{DSCP: dscp}
+}
+
 type Referrer interface {
 	ReferencedChain() string
 }
@@ -256,6 +266,21 @@ func (n NflogAction) String() string {
 	return fmt.Sprintf("Nflog:g=%d,p=%s", n.Group, n.Prefix)
 }
 
+// NfqueueAction passes the packet to userspace via NFQUEUE.  If nothing is listening on the queue,
+// the packet is accepted.
+type NfqueueAction struct {
+	QueueNum    uint16
+	TypeNfqueue struct{}
+}
+
+func (n NfqueueAction) ToFragment(features *environment.Features) string {
+	return fmt.Sprintf("--jump NFQUEUE --queue-num %d --queue-bypass", n.QueueNum)
+}
+
+func (n NfqueueAction) String() string {
+	return fmt.Sprintf("Nfqueue:%d", n.QueueNum)
+}
+
 type DNATAction struct {
 	DestAddr string
 	DestPort uint16
@@ -461,3 +486,16 @@ func (a LimitNumConnectionsAction) ToFragment(features *environment.Features) st
 func (a LimitNumConnectionsAction) String() string {
 	return fmt.Sprintf("LimitNumConnectionsAction:%d, rejectWith:%s", a.Num, a.RejectWith)
 }
+
+type SetDSCPAction struct {
+	DSCP        uint8
+	TypeSetDSCP struct{}
+}
+
+func (a SetDSCPAction) ToFragment(features *environment.Features) string {
+	return fmt.Sprintf("--jump DSCP --set-dscp %#x", a.DSCP)
+}
+
+func (a SetDSCPAction) String() string {
+	return fmt.Sprintf("SetDSCP:%d", a.DSCP)
+}
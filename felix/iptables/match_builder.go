@@ -173,6 +173,14 @@ func (m matchCriteria) NotConntrackState(stateNames string) generictables.MatchC
 	return append(m, fmt.Sprintf("-m conntrack ! --ctstate %s", stateNames))
 }
 
+func (m matchCriteria) ConntrackOrigDest(net string) generictables.MatchCriteria {
+	return append(m, fmt.Sprintf("-m conntrack --ctorigdst %s", net))
+}
+
+func (m matchCriteria) ConntrackOriginalDirection() generictables.MatchCriteria {
+	return append(m, "-m conntrack --ctdir ORIGINAL")
+}
+
 func (m matchCriteria) Protocol(name string) generictables.MatchCriteria {
 	return append(m, fmt.Sprintf("-p %s", name))
 }
@@ -189,6 +197,17 @@ func (m matchCriteria) NotProtocolNum(num uint8) generictables.MatchCriteria {
 	return append(m, fmt.Sprintf("! -p %d", num))
 }
 
+// IPIPInnerDSCP matches IPIP packets where the DSCP of the inner packet is between min and max.  The
+// outer header is assumed to have no options, as is the case for the packets that the kernel
+// encapsulates.
+func (m matchCriteria) IPIPInnerDSCP(min, max uint8) generictables.MatchCriteria {
+	// Take the TOS, which is the second byte of the inner header, and drop the ECN bits.
+	if min == max {
+		return append(m, fmt.Sprintf("-m u32 --u32 \"20>>18&0x3F=%d\"", min))
+	}
+	return append(m, fmt.Sprintf("-m u32 --u32 \"20>>18&0x3F=%d:%d\"", min, max))
+}
+
 func (m matchCriteria) SourceNet(net string) generictables.MatchCriteria {
 	return append(m, fmt.Sprintf("--source %s", net))
 }
@@ -59,6 +59,8 @@ var _ = DescribeTable("MatchBuilder",
 	Entry("NotMarkMatchesWithMask", Match().NotMarkMatchesWithMask(0x400a, 0xf00f), "-m mark ! --mark 0x400a/0xf00f"),
 	// Conntrack.
 	Entry("ConntrackState", Match().ConntrackState("INVALID"), "-m conntrack --ctstate INVALID"),
+	Entry("ConntrackOrigDest", Match().ConntrackOrigDest("10.96.0.10/32"), "-m conntrack --ctorigdst 10.96.0.10/32"),
+	Entry("ConntrackOriginalDirection", Match().ConntrackOriginalDirection(), "-m conntrack --ctdir ORIGINAL"),
 	// Interfaces.
 	Entry("InInterface", Match().InInterface("tap1234abcd"), "--in-interface tap1234abcd"),
 	Entry("OutInterface", Match().OutInterface("tap1234abcd"), "--out-interface tap1234abcd"),
@@ -73,6 +75,8 @@ var _ = DescribeTable("MatchBuilder",
 	Entry("NotProtocol", Match().NotProtocol("tcp"), "! -p tcp"),
 	Entry("ProtocolNum", Match().ProtocolNum(123), "-p 123"),
 	Entry("NotProtocolNum", Match().NotProtocolNum(123), "! -p 123"),
+	Entry("IPIPInnerDSCP", Match().IPIPInnerDSCP(46, 46), `-m u32 --u32 "20>>18&0x3F=46"`),
+	Entry("IPIPInnerDSCP range", Match().IPIPInnerDSCP(1, 63), `-m u32 --u32 "20>>18&0x3F=1:63"`),
 	// CIDRs.
 	Entry("SourceNet", Match().SourceNet("10.0.0.4"), "--source 10.0.0.4"),
 	Entry("NotSourceNet", Match().NotSourceNet("10.0.0.4"), "! --source 10.0.0.4"),
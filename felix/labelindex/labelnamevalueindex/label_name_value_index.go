@@ -57,6 +57,10 @@ func New[ItemID comparable, Item Labeled](nameOfTrackedItems string) *LabelNameV
 type values[ItemID comparable] struct {
 	m     map[uniquestr.Handle]set.Set[ItemID]
 	count int
+
+	// parsed holds each value in m, parsed once for checking against
+	// selector value predicates such as "labelName > 3".
+	parsed map[uniquestr.Handle]*parser.LabelValue
 }
 
 // Add an item to the index.  Note: its labels will be captured at this
@@ -77,7 +81,8 @@ func (idx *LabelNameValueIndex[ItemID, Item]) Add(id ItemID, item Item) {
 		vals, ok := idx.labelNameToValueToIDs[k]
 		if !ok {
 			vals = values[ItemID]{
-				m: map[uniquestr.Handle]set.Set[ItemID]{},
+				m:      map[uniquestr.Handle]set.Set[ItemID]{},
+				parsed: map[uniquestr.Handle]*parser.LabelValue{},
 			}
 			idx.labelNameToValueToIDs[k] = vals
 		}
@@ -85,6 +90,7 @@ func (idx *LabelNameValueIndex[ItemID, Item]) Add(id ItemID, item Item) {
 		if setOfIDs == nil {
 			setOfIDs = set.New[ItemID]()
 			vals.m[v] = setOfIDs
+			vals.parsed[v] = parser.ParseLabelValue(v)
 		}
 		setOfIDs.Add(id)
 		vals.count++
@@ -103,6 +109,7 @@ func (idx *LabelNameValueIndex[ItemID, Item]) Remove(id ItemID) {
 		setOfIDs.Discard(id)
 		if setOfIDs.Len() == 0 {
 			delete(vals.m, v)
+			delete(vals.parsed, v)
 			if len(vals.m) == 0 {
 				delete(idx.labelNameToValueToIDs, k)
 				continue
@@ -124,7 +131,7 @@ func (idx *LabelNameValueIndex[ItemID, Item]) StrategyFor(labelName uniquestr.Ha
 		return FullScanStrategy[ItemID, Item]{allItems: idx.allItems}
 	}
 
-	if r.MustHaveOneOfValues == nil {
+	if r.MustHaveOneOfValues == nil && len(r.ValuePredicates) == 0 {
 		// A selector such as "has(labelName)", which matches the label but
 		// not any particular value.
 		if vals, ok := idx.labelNameToValueToIDs[labelName]; !ok {
@@ -141,10 +148,23 @@ func (idx *LabelNameValueIndex[ItemID, Item]) StrategyFor(labelName uniquestr.Ha
 	// match objects that we're tracking.
 	var filteredMustHaves []uniquestr.Handle
 	var idSets []set.Set[ItemID]
-	for _, v := range r.MustHaveOneOfValues {
-		if idsSet := idx.labelNameToValueToIDs[labelName].m[v]; idsSet != nil {
-			filteredMustHaves = append(filteredMustHaves, v)
-			idSets = append(idSets, idsSet)
+	if r.MustHaveOneOfValues == nil {
+		// A selector such as "labelName matches 'regex'" or "labelName > 3",
+		// which can't be expanded to a set of values.  Check each value of
+		// the label that we're tracking against the predicates instead.
+		vals := idx.labelNameToValueToIDs[labelName]
+		for v, idsSet := range vals.m {
+			if r.MatchesValuePredicates(vals.parsed[v]) {
+				filteredMustHaves = append(filteredMustHaves, v)
+				idSets = append(idSets, idsSet)
+			}
+		}
+	} else {
+		for _, v := range r.MustHaveOneOfValues {
+			if idsSet := idx.labelNameToValueToIDs[labelName].m[v]; idsSet != nil {
+				filteredMustHaves = append(filteredMustHaves, v)
+				idSets = append(idSets, idsSet)
+			}
 		}
 	}
 
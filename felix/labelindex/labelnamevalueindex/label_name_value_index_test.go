@@ -140,6 +140,61 @@ func TestLabelValueIndexStrategies(t *testing.T) {
 	Expect(scan(strat)).To(ConsistOf())
 	Expect(strat.EstimatedItemsToScan()).To(Equal(0))
 	Expect(strat.Name()).To(Equal("no-match"))
+
+	t.Log("Label name and value predicate (single)")
+	strat = idx.StrategyFor(aHandle, restrictionFor("a matches '1$'"))
+	Expect(strat).To(BeAssignableToTypeOf(LabelNameSingleValueStrategy[string]{}))
+	Expect(scan(strat)).To(ConsistOf("a1", "c1"))
+	Expect(strat.EstimatedItemsToScan()).To(Equal(2))
+
+	t.Log("Label name and value predicate (multi)")
+	strat = idx.StrategyFor(aHandle, restrictionFor("a matches '^a[23]$'"))
+	Expect(strat).To(BeAssignableToTypeOf(LabelNameMultiValueStrategy[string]{}))
+	Expect(scan(strat)).To(ConsistOf("a2", "c2", "a3", "c3"))
+	Expect(strat.EstimatedItemsToScan()).To(Equal(4))
+
+	t.Log("Label name and value predicate (no match)")
+	strat = idx.StrategyFor(aHandle, restrictionFor("a >= 2"))
+	Expect(strat).To(BeAssignableToTypeOf(NoMatchStrategy[string]{}))
+	Expect(scan(strat)).To(BeEmpty())
+}
+
+func TestLabelValueIndexComparisonStrategies(t *testing.T) {
+	RegisterTestingT(t)
+	idx := New[string, labels]("item")
+
+	idx.Add("t1", labels{"tier": "1"})
+	idx.Add("t2", labels{"tier": "2"})
+	idx.Add("t3", labels{"tier": "3"})
+	idx.Add("t3b", labels{"tier": "3"})
+	idx.Add("t10", labels{"tier": "10"})
+	idx.Add("v1", labels{"version": "v1.2.0"})
+	idx.Add("v2", labels{"version": "v2.0.1"})
+
+	tierHandle := uniquestr.Make("tier")
+	strat := idx.StrategyFor(tierHandle, restrictionFor("tier >= 3"))
+	Expect(strat).To(BeAssignableToTypeOf(LabelNameMultiValueStrategy[string]{}))
+	Expect(scan(strat)).To(ConsistOf("t3", "t3b", "t10"))
+	Expect(strat.EstimatedItemsToScan()).To(Equal(3))
+
+	strat = idx.StrategyFor(tierHandle, restrictionFor("tier > 1 && tier < 3"))
+	Expect(strat).To(BeAssignableToTypeOf(LabelNameSingleValueStrategy[string]{}))
+	Expect(scan(strat)).To(ConsistOf("t2"))
+
+	strat = idx.StrategyFor(uniquestr.Make("version"), restrictionFor("version < 2"))
+	Expect(strat).To(BeAssignableToTypeOf(LabelNameSingleValueStrategy[string]{}))
+	Expect(scan(strat)).To(ConsistOf("v1"))
+}
+
+func restrictionFor(sel string) parser.LabelRestriction {
+	s, err := parser.Parse(sel)
+	Expect(err).NotTo(HaveOccurred())
+	lrs := s.LabelRestrictions()
+	Expect(lrs).To(HaveLen(1))
+	for _, r := range lrs {
+		return r
+	}
+	return parser.LabelRestriction{}
 }
 
 func handleSlice(ss ...string) []uniquestr.Handle {
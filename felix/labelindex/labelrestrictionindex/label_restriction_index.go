@@ -120,6 +120,23 @@ func (s *LabelRestrictionIndex[SelID]) AddSelector(id SelID, selector *selector.
 				}
 				values.Add(v, id)
 			}
+		} else if res.MustBePresent && len(res.ValuePredicates) > 0 {
+			// Selector requires that this label's value satisfies some
+			// predicates, such as a regex, that we check during the scan.
+			if debug {
+				logrus.WithFields(logrus.Fields{
+					"selector":   selector.String(),
+					"label":      labelName.Value(),
+					"predicates": res.ValuePredicates,
+				}).Debug("Optimising selector on value predicates.")
+			}
+			optimized = true
+			values, ok := s.labelToValueToIDs[labelName]
+			if !ok {
+				values = &valuesSubIndex[SelID]{}
+				s.labelToValueToIDs[labelName] = values
+			}
+			values.AddPredicates(id, res)
 		} else if res.MustBePresent {
 			// Selector requires that this label is present, add it to the
 			// wildcards.
@@ -170,6 +187,13 @@ func (s *LabelRestrictionIndex[SelID]) DeleteSelector(id SelID) {
 					delete(s.labelToValueToIDs, labelName)
 				}
 			}
+		} else if res.MustBePresent && len(res.ValuePredicates) > 0 {
+			optimized = true
+			values := s.labelToValueToIDs[labelName]
+			values.RemovePredicates(id)
+			if values.Empty() {
+				delete(s.labelToValueToIDs, labelName)
+			}
 		} else if res.MustBePresent {
 			optimized = true
 			values := s.labelToValueToIDs[labelName]
@@ -213,6 +237,9 @@ func scoreLabelRestriction(lr parser.LabelRestriction) int {
 	if lr.MustBePresent {
 		score += 10
 	}
+	if len(lr.ValuePredicates) > 0 {
+		score += 50
+	}
 	if lr.MustHaveOneOfValues != nil {
 		s := 10000 - len(lr.MustHaveOneOfValues)
 		if s < 100 {
@@ -250,6 +277,16 @@ func (s *LabelRestrictionIndex[SelID]) IterPotentialMatches(item Labeled, f func
 		if ids := values.selsMatchingSpecificValues[v]; ids != nil {
 			ids.Iter(emit)
 		}
+		if values.selsMatchingPredicates == nil {
+			continue
+		}
+		// Parse the value once for all the selectors' predicates.
+		parsed := parser.ParseLabelValue(v)
+		for id, res := range values.selsMatchingPredicates {
+			if res.MatchesValuePredicates(parsed) {
+				f(id, s.selectorsByID[id])
+			}
+		}
 	}
 
 	// Finally, emit the unoptimized selectors.
@@ -266,10 +303,12 @@ func (s *LabelRestrictionIndex[SelID]) updateGauges() {
 }
 
 // valuesSubIndex keeps track of the selectors that match a particular
-// label, either matching particular values or a wildcard (such as
+// label, either matching particular values, values that satisfy some
+// predicates (such as "labelName matches 'regex'") or a wildcard (such as
 // "has(labelName)").
 type valuesSubIndex[SelID comparable] struct {
 	selsMatchingSpecificValues map[uniquestr.Handle]set.Set[SelID]
+	selsMatchingPredicates     map[SelID]parser.LabelRestriction
 	selsMatchingWildcard       set.Set[SelID]
 }
 
@@ -319,6 +358,21 @@ func (t *valuesSubIndex[SelID]) RemoveWildcard(id SelID) {
 	}
 }
 
+func (t *valuesSubIndex[SelID]) AddPredicates(id SelID, res parser.LabelRestriction) {
+	if t.selsMatchingPredicates == nil {
+		t.selsMatchingPredicates = map[SelID]parser.LabelRestriction{}
+	}
+	t.selsMatchingPredicates[id] = res
+}
+
+func (t *valuesSubIndex[SelID]) RemovePredicates(id SelID) {
+	delete(t.selsMatchingPredicates, id)
+	if len(t.selsMatchingPredicates) == 0 {
+		// For symmetry with AddPredicates, we clean up the map when no longer in use.
+		t.selsMatchingPredicates = nil
+	}
+}
+
 func (t *valuesSubIndex[SelID]) Empty() bool {
-	return len(t.selsMatchingSpecificValues) == 0 && t.selsMatchingWildcard == nil
+	return len(t.selsMatchingSpecificValues) == 0 && t.selsMatchingPredicates == nil && t.selsMatchingWildcard == nil
 }
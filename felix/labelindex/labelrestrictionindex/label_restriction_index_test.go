@@ -140,6 +140,43 @@ func TestLabelRestrictionIndex(t *testing.T) {
 	Expect(idx.labelToValueToIDs).To(BeEmpty())
 }
 
+func TestLabelRestrictionIndexValuePredicates(t *testing.T) {
+	RegisterTestingT(t)
+
+	var optGauge, unoptGauge dummyGauge
+	idx := New[string](WithGauges[string](&optGauge, &unoptGauge))
+
+	idx.AddSelector("hasTier", mustParseSelector("has(tier)"))
+	idx.AddSelector("tierGe3", mustParseSelector("tier >= 3"))
+	idx.AddSelector("tierLt3", mustParseSelector("tier < 3 && has(tier)"))
+	idx.AddSelector("appRegex", mustParseSelector("app matches '^web-' && has(tier)"))
+	Expect(optGauge).To(BeNumerically("==", 4))
+	Expect(unoptGauge).To(BeNumerically("==", 0))
+
+	potentialMatches := func(labels map[string]string) []string {
+		var out []string
+		idx.IterPotentialMatches(labeledAdapter(labels), func(s string, _ *selector.Selector) {
+			Expect(out).NotTo(ContainElement(s), "IterPotentialMatches produced duplicate: "+s)
+			out = append(out, s)
+		})
+		return out
+	}
+
+	Expect(potentialMatches(map[string]string{"tier": "5"})).To(ConsistOf("hasTier", "tierGe3"))
+	Expect(potentialMatches(map[string]string{"tier": "1"})).To(ConsistOf("hasTier", "tierLt3"))
+	Expect(potentialMatches(map[string]string{"tier": "high"})).To(ConsistOf("hasTier"))
+	Expect(potentialMatches(map[string]string{"app": "web-1"})).To(ConsistOf("appRegex"))
+	Expect(potentialMatches(map[string]string{"app": "db-1"})).To(BeEmpty())
+
+	idx.DeleteSelector("tierGe3")
+	Expect(potentialMatches(map[string]string{"tier": "5"})).To(ConsistOf("hasTier"))
+	idx.DeleteSelector("tierLt3")
+	idx.DeleteSelector("appRegex")
+	idx.DeleteSelector("hasTier")
+	Expect(optGauge).To(BeNumerically("==", 0))
+	Expect(idx.labelToValueToIDs).To(BeEmpty())
+}
+
 type labeledAdapter map[string]string
 
 func (l labeledAdapter) AllOwnAndParentLabelHandles() iter.Seq2[uniquestr.Handle, uniquestr.Handle] {
@@ -210,6 +247,20 @@ func TestFindMostRestrictedLabel(t *testing.T) {
 	})).To(Equal("a"),
 		"findMostRestrictedLabel should prefer impossible selector (present and absent)")
 
+	aGe3 := mustParseSelector("a >= 3").LabelRestrictions()[uniquestr.Make("a")]
+	Expect(mostRestricted(map[string]parser.LabelRestriction{
+		"a": aGe3,
+		"b": {MustBePresent: true},
+		"c": {},
+	})).To(Equal("a"),
+		"findMostRestrictedLabel should prefer 'predicate' labels over 'present' labels")
+	Expect(mostRestricted(map[string]parser.LabelRestriction{
+		"a": aGe3,
+		"b": {MustBePresent: true, MustHaveOneOfValues: stringSliceToHandle([]string{"B1", "B2"})},
+		"c": {},
+	})).To(Equal("b"),
+		"findMostRestrictedLabel should prefer 'value' labels over 'predicate' labels")
+
 	var manyVals []uniquestr.Handle
 	for i := 0; i < 15000; i++ {
 		manyVals = append(manyVals, uniquestr.Make(fmt.Sprint(i)))
@@ -167,9 +167,15 @@ type IPSetMember struct {
 	CIDR       ip.CIDR
 	Protocol   IPSetPortProtocol
 	PortNumber uint16
+	// Domain is set, instead of the fields above, for members of domain IP sets.  The dataplane
+	// resolves the domain to IPs.
+	Domain string
 }
 
 func (m IPSetMember) String() string {
+	if m.Domain != "" {
+		return fmt.Sprintf("labelindex.IPSetMember(domain:%s)", m.Domain)
+	}
 	return fmt.Sprintf("labelindex.IPSetMember(%s:%s:%d)", m.CIDR, m.Protocol, m.PortNumber)
 }
 
@@ -221,6 +221,9 @@ func (d *MockWireguard) ConfigureDevice(name string, cfg wgtypes.Config) error {
 			if peerCfg.PersistentKeepaliveInterval != nil {
 				peer.PersistentKeepaliveInterval = *peerCfg.PersistentKeepaliveInterval
 			}
+			if peerCfg.PresharedKey != nil {
+				peer.PresharedKey = *peerCfg.PresharedKey
+			}
 
 			// Construct the set of allowed IPs and then transfer to the slice for storage. We sort these so our tests
 			// can be deterministic.
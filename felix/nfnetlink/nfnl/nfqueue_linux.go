@@ -0,0 +1,205 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nfnl
+
+import (
+	"encoding/binary"
+)
+
+// Message Types
+const (
+	NFQNL_MSG_PACKET = iota
+	NFQNL_MSG_VERDICT
+	NFQNL_MSG_CONFIG
+	NFQNL_MSG_VERDICT_BATCH
+
+	NFQNL_MSG_MAX
+)
+
+// Attributes
+const (
+	NFQA_UNSPEC = iota
+	NFQA_PACKET_HDR
+	NFQA_VERDICT_HDR
+	NFQA_MARK
+	NFQA_TIMESTAMP
+	NFQA_IFINDEX_INDEV // 5
+	NFQA_IFINDEX_OUTDEV
+	NFQA_IFINDEX_PHYSINDEV
+	NFQA_IFINDEX_PHYSOUTDEV
+	NFQA_HWADDR
+	NFQA_PAYLOAD // 10
+	NFQA_CT
+	NFQA_CT_INFO
+	NFQA_CAP_LEN
+	NFQA_SKB_INFO
+	NFQA_EXP // 15
+	NFQA_UID
+	NFQA_GID
+	NFQA_SECCTX
+	NFQA_VLAN
+	NFQA_L2HDR // 20
+	NFQA_PRIORITY
+	NFQA_CGROUP_CLASSID
+
+	__NFQA_MAX
+)
+const NFQA_MAX = __NFQA_MAX - 1
+
+// Config Commands
+const (
+	NFQNL_CFG_CMD_NONE = iota
+	NFQNL_CFG_CMD_BIND
+	NFQNL_CFG_CMD_UNBIND
+	NFQNL_CFG_CMD_PF_BIND
+	NFQNL_CFG_CMD_PF_UNBIND
+)
+
+// Attribute Configuration
+const (
+	NFQA_CFG_UNSPEC = iota
+	NFQA_CFG_CMD
+	NFQA_CFG_PARAMS
+	NFQA_CFG_QUEUE_MAXLEN
+	NFQA_CFG_MASK
+	NFQA_CFG_FLAGS
+	__NFQA_CFG_MAX
+)
+const NFQA_CFG_MAX = __NFQA_CFG_MAX - 1
+
+const (
+	NFQNL_COPY_NONE   = 0x00
+	NFQNL_COPY_META   = 0x01
+	NFQNL_COPY_PACKET = 0x02
+)
+
+const (
+	NFQA_CFG_F_FAIL_OPEN = 0x0001
+	NFQA_CFG_F_CONNTRACK = 0x0002
+	NFQA_CFG_F_GSO       = 0x0004
+	NFQA_CFG_F_UID_GID   = 0x0008
+	NFQA_CFG_F_SECCTX    = 0x0010
+)
+
+// Verdicts
+const (
+	NF_DROP = iota
+	NF_ACCEPT
+	NF_STOLEN
+	NF_QUEUE
+	NF_REPEAT
+	NF_STOP
+)
+
+const (
+	SizeofNfqueueMsgPktHdr       = 0x7
+	SizeofNfqueueMsgConfigCmd    = 0x4
+	SizeofNfqueueMsgConfigParams = 0x5
+	SizeofNfqueueMsgVerdictHdr   = 0x8
+)
+
+// The nfqueue structures are packed and use network byte order, so, unlike their NFLOG
+// counterparts, we serialise them field by field.
+
+type NfqueueMsgPktHdr struct {
+	PacketID   uint32
+	HwProtocol uint16
+	Hook       uint8
+}
+
+func DeserializeNfqueueMsgPktHdr(b []byte) *NfqueueMsgPktHdr {
+	return &NfqueueMsgPktHdr{
+		PacketID:   binary.BigEndian.Uint32(b[0:4]),
+		HwProtocol: binary.BigEndian.Uint16(b[4:6]),
+		Hook:       b[6],
+	}
+}
+
+type NfqueueMsgConfigCmd struct {
+	command uint8
+	pf      uint16
+}
+
+func NewNfqueueMsgConfigCmd(command int, pf int) *NfqueueMsgConfigCmd {
+	return &NfqueueMsgConfigCmd{
+		command: uint8(command),
+		pf:      uint16(pf),
+	}
+}
+
+func (msg *NfqueueMsgConfigCmd) Len() int {
+	return SizeofNfqueueMsgConfigCmd
+}
+
+func (msg *NfqueueMsgConfigCmd) Serialize() []byte {
+	b := make([]byte, SizeofNfqueueMsgConfigCmd)
+	b[0] = msg.command
+	binary.BigEndian.PutUint16(b[2:4], msg.pf)
+	return b
+}
+
+type NfqueueMsgConfigParams struct {
+	copyRange uint32
+	copyMode  uint8
+}
+
+func NewNfqueueMsgConfigParams(copyRange int, copyMode int) *NfqueueMsgConfigParams {
+	return &NfqueueMsgConfigParams{
+		copyRange: uint32(copyRange),
+		copyMode:  uint8(copyMode),
+	}
+}
+
+func (msg *NfqueueMsgConfigParams) Len() int {
+	return SizeofNfqueueMsgConfigParams
+}
+
+func (msg *NfqueueMsgConfigParams) Serialize() []byte {
+	b := make([]byte, SizeofNfqueueMsgConfigParams)
+	binary.BigEndian.PutUint32(b[0:4], msg.copyRange)
+	b[4] = msg.copyMode
+	return b
+}
+
+type NfqueueMsgVerdictHdr struct {
+	verdict  uint32
+	packetID uint32
+}
+
+func NewNfqueueMsgVerdictHdr(verdict int, packetID uint32) *NfqueueMsgVerdictHdr {
+	return &NfqueueMsgVerdictHdr{
+		verdict:  uint32(verdict),
+		packetID: packetID,
+	}
+}
+
+func (msg *NfqueueMsgVerdictHdr) Len() int {
+	return SizeofNfqueueMsgVerdictHdr
+}
+
+func (msg *NfqueueMsgVerdictHdr) Serialize() []byte {
+	b := make([]byte, SizeofNfqueueMsgVerdictHdr)
+	binary.BigEndian.PutUint32(b[0:4], msg.verdict)
+	binary.BigEndian.PutUint32(b[4:8], msg.packetID)
+	return b
+}
+
+// BE32 returns the big endian encoding of v, for use as a 32-bit netlink attribute value.
+func BE32(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
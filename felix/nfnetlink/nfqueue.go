@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nfnetlink
+
+// NfqueuePacket is a packet that the kernel has passed to userspace via NFQUEUE.  The kernel holds
+// on to the packet until a verdict is set for its ID.
+type NfqueuePacket struct {
+	ID         uint32
+	HwProtocol int
+	Payload    []byte
+}
@@ -0,0 +1,182 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nfnetlink
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink/nl"
+
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+)
+
+// Nfqueue is a subscription to an NFQUEUE queue.  Every packet received from the queue must be
+// given a verdict with SetVerdict, otherwise the kernel holds on to it until the queue is closed.
+type Nfqueue struct {
+	queueNum int
+	sock     *nl.NetlinkSocket
+}
+
+// NfqueueSubscribe binds to the given queue and starts a goroutine that sends the queued packets to
+// ch.  The queue is configured to fail open, so the kernel accepts packets rather than dropping them
+// if more than maxLen packets are waiting for a verdict.  Closing done unbinds from the queue.
+func NfqueueSubscribe(queueNum int, maxLen int, ch chan<- NfqueuePacket, done <-chan struct{}) (*Nfqueue, error) {
+	sock, err := nl.Subscribe(syscall.NETLINK_NETFILTER)
+	if err != nil {
+		return nil, err
+	}
+	q := &Nfqueue{queueNum: queueNum, sock: sock}
+
+	// Bind our socket to the queue.
+	cmd := nfnl.NewNfqueueMsgConfigCmd(nfnl.NFQNL_CFG_CMD_BIND, syscall.AF_UNSPEC)
+	if err := q.sendConfig(nl.NewRtAttr(nfnl.NFQA_CFG_CMD, cmd.Serialize())); err != nil {
+		sock.Close()
+		return nil, fmt.Errorf("failed to bind to NFQUEUE %d: %w", queueNum, err)
+	}
+
+	// Copy whole packets; DNS responses are small.
+	params := nfnl.NewNfqueueMsgConfigParams(0xFFFF, nfnl.NFQNL_COPY_PACKET)
+	if err := q.sendConfig(nl.NewRtAttr(nfnl.NFQA_CFG_PARAMS, params.Serialize())); err != nil {
+		sock.Close()
+		return nil, err
+	}
+	if err := q.sendConfig(nl.NewRtAttr(nfnl.NFQA_CFG_QUEUE_MAXLEN, nfnl.BE32(uint32(maxLen)))); err != nil {
+		sock.Close()
+		return nil, err
+	}
+	if err := q.sendConfig(
+		nl.NewRtAttr(nfnl.NFQA_CFG_FLAGS, nfnl.BE32(nfnl.NFQA_CFG_F_FAIL_OPEN)),
+		nl.NewRtAttr(nfnl.NFQA_CFG_MASK, nfnl.BE32(nfnl.NFQA_CFG_F_FAIL_OPEN)),
+	); err != nil {
+		sock.Close()
+		return nil, err
+	}
+
+	go func() {
+		<-done
+		cmd := nfnl.NewNfqueueMsgConfigCmd(nfnl.NFQNL_CFG_CMD_UNBIND, syscall.AF_UNSPEC)
+		if err := q.sendConfig(nl.NewRtAttr(nfnl.NFQA_CFG_CMD, cmd.Serialize())); err != nil {
+			log.WithError(err).WithField("queueNum", queueNum).Warn("Failed to unbind from NFQUEUE")
+		}
+		sock.Close()
+	}()
+
+	go q.loopReceiving(ch, done)
+
+	return q, nil
+}
+
+func (q *Nfqueue) sendConfig(attrs ...*nl.RtAttr) error {
+	req := nl.NewNetlinkRequest(nfnl.NFNL_SUBSYS_QUEUE<<8|nfnl.NFQNL_MSG_CONFIG, syscall.NLM_F_REQUEST)
+	req.AddData(nfnl.NewNfGenMsg(syscall.AF_UNSPEC, nfnl.NFNETLINK_V0, q.queueNum))
+	for _, a := range attrs {
+		req.AddData(a)
+	}
+	return q.sock.Send(req)
+}
+
+// SetVerdict tells the kernel what to do with the packet with the given ID; one of the nfnl.NF_...
+// verdicts.  It is safe to call concurrently with the receive loop.
+func (q *Nfqueue) SetVerdict(id uint32, verdict int) error {
+	req := nl.NewNetlinkRequest(nfnl.NFNL_SUBSYS_QUEUE<<8|nfnl.NFQNL_MSG_VERDICT, syscall.NLM_F_REQUEST)
+	req.AddData(nfnl.NewNfGenMsg(syscall.AF_UNSPEC, nfnl.NFNETLINK_V0, q.queueNum))
+	req.AddData(nl.NewRtAttr(nfnl.NFQA_VERDICT_HDR, nfnl.NewNfqueueMsgVerdictHdr(verdict, id).Serialize()))
+	return q.sock.Send(req)
+}
+
+func (q *Nfqueue) loopReceiving(ch chan<- NfqueuePacket, done <-chan struct{}) {
+	defer close(ch)
+	logCtx := log.WithField("queueNum", q.queueNum)
+	for {
+		msgs, _, err := q.sock.Receive()
+		if err != nil {
+			select {
+			case <-done:
+				logCtx.Info("NFQUEUE closed.")
+				return
+			default:
+			}
+			var errno syscall.Errno
+			if errors.As(err, &errno) && (errno == syscall.ENOBUFS || errno.Temporary()) {
+				// The queue is configured to fail open, so packets that we didn't receive
+				// have been accepted.
+				logCtx.WithError(err).Warn("Error receiving from NFQUEUE, some packets not seen.")
+				continue
+			}
+			logCtx.WithError(err).Error("Failed to receive from NFQUEUE.")
+			return
+		}
+		for _, m := range msgs {
+			if m.Header.Type == syscall.NLMSG_ERROR {
+				native := binary.LittleEndian
+				errno := int32(native.Uint32(m.Data[0:4]))
+				logCtx.Warnf("NLMSG_ERROR: %v", syscall.Errno(-errno))
+				continue
+			}
+			if m.Header.Type != nfnl.NFNL_SUBSYS_QUEUE<<8|nfnl.NFQNL_MSG_PACKET {
+				continue
+			}
+			pkt, err := parseNfqueuePacket(m.Data[nfnl.SizeofNfGenMsg:])
+			if err != nil {
+				logCtx.WithError(err).Warn("Error parsing NFQUEUE packet.")
+				continue
+			}
+			select {
+			case ch <- pkt:
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+func parseNfqueuePacket(m []byte) (NfqueuePacket, error) {
+	var pkt NfqueuePacket
+	var attrs [nfnl.NFQA_MAX]nfnl.NetlinkNetfilterAttr
+	n, err := nfnl.ParseNetfilterAttr(m, attrs[:])
+	if err != nil {
+		return pkt, err
+	}
+
+	seenHdr := false
+	for idx := 0; idx < n; idx++ {
+		attr := attrs[idx]
+		switch int(attr.Attr.Type) & nfnl.NLA_TYPE_MASK {
+		case nfnl.NFQA_PACKET_HDR:
+			if len(attr.Value) < nfnl.SizeofNfqueueMsgPktHdr {
+				return pkt, fmt.Errorf("short packet header (%d bytes)", len(attr.Value))
+			}
+			hdr := nfnl.DeserializeNfqueueMsgPktHdr(attr.Value)
+			pkt.ID = hdr.PacketID
+			pkt.HwProtocol = int(hdr.HwProtocol)
+			seenHdr = true
+		case nfnl.NFQA_PAYLOAD:
+			pkt.Payload = attr.Value
+		default:
+			// Skip attributes we don't need.
+		}
+	}
+	if !seenHdr {
+		return pkt, errors.New("packet has no header")
+	}
+	return pkt, nil
+}
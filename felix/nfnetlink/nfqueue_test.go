@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nfnetlink
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseNfqueuePacket", func() {
+	// Packet header (ID 258, IPv4, hook 3), followed by mark and payload attributes.
+	data := [...]byte{
+		11, 0, 1, 0, 0, 0, 1, 2, 8, 0, 3, 0,
+		8, 0, 3, 0, 0, 0, 0, 16,
+		7, 0, 10, 0, 'd', 'n', 's', 0,
+	}
+
+	It("should parse the packet ID and payload", func() {
+		pkt, err := parseNfqueuePacket(data[:])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pkt.ID).To(Equal(uint32(258)))
+		Expect(pkt.HwProtocol).To(Equal(0x800))
+		Expect(pkt.Payload).To(Equal([]byte("dns")))
+	})
+
+	It("should reject a packet without a header", func() {
+		_, err := parseNfqueuePacket(data[12:])
+		Expect(err).To(HaveOccurred())
+	})
+})
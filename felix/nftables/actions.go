@@ -118,6 +118,12 @@ func (a *actionSet) Nflog(group uint16, prefix string, size int) generictables.A
 	}
 }
 
+func (a *actionSet) Nfqueue(queueNum uint16) generictables.Action {
+	return NfqueueAction{
+		QueueNum: queueNum,
+	}
+}
+
 func (a *actionSet) LimitPacketRate(rate int64, mark uint32) generictables.Action {
 	return LimitPacketRateAction{
 		Rate: rate,
@@ -132,6 +138,10 @@ func (a *actionSet) LimitNumConnections(num int64, rejectWith generictables.Reje
 	}
 }
 
+func (a *actionSet) SetDSCP(dscp uint8) generictables.Action {
+	return SetDSCPAction{DSCP: dscp}
+}
+
 func escapeLogPrefix(prefix string) string {
 	return fmt.Sprintf("\"%s\"", prefix)
 }
@@ -455,6 +465,21 @@ func (n NflogAction) String() string {
 	return fmt.Sprintf("Nflog:g=%d,p=%s", n.Group, n.Prefix)
 }
 
+// NfqueueAction passes the packet to userspace via NFQUEUE.  If nothing is listening on the queue,
+// the packet is accepted.
+type NfqueueAction struct {
+	QueueNum    uint16
+	TypeNfqueue struct{}
+}
+
+func (n NfqueueAction) ToFragment(features *environment.Features) string {
+	return fmt.Sprintf("queue flags bypass to %d", n.QueueNum)
+}
+
+func (n NfqueueAction) String() string {
+	return fmt.Sprintf("Nfqueue:%d", n.QueueNum)
+}
+
 type LimitPacketRateAction struct {
 	Rate int64
 	// Mark is not used on nftables mode
@@ -495,3 +520,17 @@ func (a LimitNumConnectionsAction) ToFragment(features *environment.Features) st
 func (a LimitNumConnectionsAction) String() string {
 	return fmt.Sprintf("LimitNumConnectionsAction:%d, rejectWith:%s", a.Num, a.RejectWith)
 }
+
+type SetDSCPAction struct {
+	DSCP        uint8
+	TypeSetDSCP struct{}
+}
+
+func (a SetDSCPAction) ToFragment(features *environment.Features) string {
+	// The IP version placeholder is filled in by the renderer, based on the table's family.
+	return fmt.Sprintf("<IPV> dscp set %d", a.DSCP)
+}
+
+func (a SetDSCPAction) String() string {
+	return fmt.Sprintf("SetDSCP:%d", a.DSCP)
+}
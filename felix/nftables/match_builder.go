@@ -315,6 +315,16 @@ func (m nftMatch) NotConntrackState(stateNames string) generictables.MatchCriter
 	return m
 }
 
+func (m nftMatch) ConntrackOrigDest(net string) generictables.MatchCriteria {
+	m.clauses = append(m.clauses, fmt.Sprintf("ct original <IPV> daddr %s", net))
+	return m
+}
+
+func (m nftMatch) ConntrackOriginalDirection() generictables.MatchCriteria {
+	m.clauses = append(m.clauses, "ct direction original")
+	return m
+}
+
 func (m nftMatch) Protocol(name string) generictables.MatchCriteria {
 	if m.proto != "" {
 		logrus.WithField("protocol", m.proto).Fatal("Protocol already set")
@@ -355,6 +365,19 @@ func (m nftMatch) NotProtocolNum(num uint8) generictables.MatchCriteria {
 	return m
 }
 
+// IPIPInnerDSCP matches IPIP packets where the DSCP of the inner packet is between min and max.  The
+// outer header is assumed to have no options, as is the case for the packets that the kernel
+// encapsulates.
+func (m nftMatch) IPIPInnerDSCP(min, max uint8) generictables.MatchCriteria {
+	// The DSCP is the top 6 bits of the TOS, which is the second byte of the inner header.
+	if min == max {
+		m.clauses = append(m.clauses, fmt.Sprintf("@nh,168,6 %d", min))
+	} else {
+		m.clauses = append(m.clauses, fmt.Sprintf("@nh,168,6 %d-%d", min, max))
+	}
+	return m
+}
+
 func (m nftMatch) SourceNet(net string) generictables.MatchCriteria {
 	m.clauses = append(m.clauses, fmt.Sprintf("<IPV> saddr %s", net))
 	return m
@@ -62,6 +62,8 @@ var _ = DescribeTable("MatchBuilder",
 
 	// Conntrack.
 	Entry("ConntrackState", Match().ConntrackState("INVALID"), "ct state invalid"),
+	Entry("ConntrackOrigDest", Match().ConntrackOrigDest("10.96.0.10/32"), "ct original ip daddr 10.96.0.10/32"),
+	Entry("ConntrackOriginalDirection", Match().ConntrackOriginalDirection(), "ct direction original"),
 
 	// Interfaces.
 	Entry("InInterface", Match().InInterface("tap1234abcd"), "iifname tap1234abcd"),
@@ -80,6 +82,8 @@ var _ = DescribeTable("MatchBuilder",
 	Entry("ProtocolNum", Match().ProtocolNum(123), "meta l4proto 123"),
 	Entry("NotProtocolNum", Match().NotProtocolNum(123), "meta l4proto != 123"),
 	Entry("ProtocolNum IPIP", Match().ProtocolNum(4), "meta l4proto 4"),
+	Entry("IPIPInnerDSCP", Match().IPIPInnerDSCP(46, 46), "@nh,168,6 46"),
+	Entry("IPIPInnerDSCP range", Match().IPIPInnerDSCP(1, 63), "@nh,168,6 1-63"),
 
 	// CIDRs.
 	Entry("SourceNet", Match().SourceNet("10.0.0.4"), "ip saddr 10.0.0.4"),
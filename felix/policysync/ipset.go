@@ -25,6 +25,16 @@ import (
 type ipSetInfo struct {
 	ipsets.IPSetMetadata
 	members set.Set[ipsets.IPSetMember]
+	// isDomainSet is true for domain IP sets, whose members are domain names rather than IPs.
+	isDomainSet bool
+}
+
+// domainMember is a member of a domain IP set.  Only the dataplane can resolve domains to IPs so
+// the domain names are passed through to clients unchanged.
+type domainMember string
+
+func (d domainMember) String() string {
+	return string(d)
 }
 
 func newIPSet(update *proto.IPSetUpdate) *ipSetInfo {
@@ -37,6 +47,8 @@ func newIPSet(update *proto.IPSetUpdate) *ipSetInfo {
 		s.Type = ipsets.IPSetTypeHashIPPort
 	case proto.IPSetUpdate_NET:
 		s.Type = ipsets.IPSetTypeHashNet
+	case proto.IPSetUpdate_DOMAIN:
+		s.isDomainSet = true
 	default:
 		log.WithField("IPSetType", update.GetType()).Panic("unknown IPSetType")
 	}
@@ -52,19 +64,26 @@ func newIPSet(update *proto.IPSetUpdate) *ipSetInfo {
 func (s *ipSetInfo) replaceMembers(update *proto.IPSetUpdate) {
 	s.members = set.New[ipsets.IPSetMember]()
 	for _, ms := range update.GetMembers() {
-		s.members.Add(ipsets.CanonicaliseMember(s.Type, ms))
+		s.members.Add(s.canonicaliseMember(ms))
 	}
 }
 
 func (s *ipSetInfo) deltaUpdate(update *proto.IPSetDeltaUpdate) {
 	for _, ms := range update.GetAddedMembers() {
-		s.members.Add(ipsets.CanonicaliseMember(s.Type, ms))
+		s.members.Add(s.canonicaliseMember(ms))
 	}
 	for _, ms := range update.GetRemovedMembers() {
-		s.members.Discard(ipsets.CanonicaliseMember(s.Type, ms))
+		s.members.Discard(s.canonicaliseMember(ms))
 	}
 }
 
+func (s *ipSetInfo) canonicaliseMember(member string) ipsets.IPSetMember {
+	if s.isDomainSet {
+		return domainMember(member)
+	}
+	return ipsets.CanonicaliseMember(s.Type, member)
+}
+
 func (s *ipSetInfo) getIPSetUpdate() *proto.IPSetUpdate {
 	u := &proto.IPSetUpdate{Id: s.SetID, Type: s.getProtoType()}
 	s.members.Iter(func(item ipsets.IPSetMember) error {
@@ -75,6 +94,9 @@ func (s *ipSetInfo) getIPSetUpdate() *proto.IPSetUpdate {
 }
 
 func (s *ipSetInfo) getProtoType() proto.IPSetUpdate_IPSetType {
+	if s.isDomainSet {
+		return proto.IPSetUpdate_DOMAIN
+	}
 	switch s.Type {
 	case ipsets.IPSetTypeHashIP:
 		return proto.IPSetUpdate_IP
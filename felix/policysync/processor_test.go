@@ -455,6 +455,32 @@ var _ = Describe("Processor", func() {
 					close(done)
 				})
 
+				It("should pass through domain IP sets when endpoint newly refs profile update", func(done Done) {
+					domainSetUpd := &proto.IPSetUpdate{
+						Id:      "domainset",
+						Type:    proto.IPSetUpdate_DOMAIN,
+						Members: []string{"*.example.com"},
+					}
+					updates <- domainSetUpd
+					updates <- &proto.IPSetDeltaUpdate{Id: "domainset", AddedMembers: []string{"www.example.org"}}
+					proUpd2 := &proto.ActiveProfileUpdate{
+						Id: proUpd.Id,
+						Profile: &proto.Profile{OutboundRules: []*proto.Rule{
+							{
+								Action:      "allow",
+								DstIpSetIds: []string{"domainset"},
+							},
+						}},
+					}
+					updates <- proUpd2
+					g := <-refdOutput
+					Expect(g.GetIpsetUpdate().GetId()).To(Equal("domainset"))
+					Expect(g.GetIpsetUpdate().GetType()).To(Equal(proto.IPSetUpdate_DOMAIN))
+					Expect(g.GetIpsetUpdate().GetMembers()).To(ConsistOf("*.example.com", "www.example.org"))
+
+					close(done)
+				})
+
 				It("should send IPSetUpdate when endpoint newly refs wep update", func(done Done) {
 					wepUpd := &proto.WorkloadEndpointUpdate{
 						Id:       types.WorkloadEndpointIDToProto(unrefdId),
@@ -287,6 +287,7 @@ const (
 	IPSetUpdate_IP          IPSetUpdate_IPSetType = 0 // Each member is an IP address in dotted-decimal or IPv6 format.
 	IPSetUpdate_IP_AND_PORT IPSetUpdate_IPSetType = 1 // Each member is "<IP>,(tcp|udp):port".
 	IPSetUpdate_NET         IPSetUpdate_IPSetType = 2 // Each member is a CIDR in dotted-decimal or IPv6 format.
+	IPSetUpdate_DOMAIN      IPSetUpdate_IPSetType = 3 // Each member is a domain name, optionally with a leading "*." wildcard.
 )
 
 // Enum value maps for IPSetUpdate_IPSetType.
@@ -295,11 +296,13 @@ var (
 		0: "IP",
 		1: "IP_AND_PORT",
 		2: "NET",
+		3: "DOMAIN",
 	}
 	IPSetUpdate_IPSetType_value = map[string]int32{
 		"IP":          0,
 		"IP_AND_PORT": 1,
 		"NET":         2,
+		"DOMAIN":      3,
 	}
 )
 
@@ -2228,8 +2231,10 @@ type Rule struct {
 	SrcServiceAccountMatch *ServiceAccountMatch `protobuf:"bytes,120,opt,name=src_service_account_match,json=srcServiceAccountMatch,proto3" json:"src_service_account_match,omitempty"`
 	DstServiceAccountMatch *ServiceAccountMatch `protobuf:"bytes,121,opt,name=dst_service_account_match,json=dstServiceAccountMatch,proto3" json:"dst_service_account_match,omitempty"`
 	// Pass through of the v3 datamodel HTTP match criteria.
-	HttpMatch *HTTPMatch    `protobuf:"bytes,122,opt,name=http_match,json=httpMatch,proto3" json:"http_match,omitempty"`
-	Metadata  *RuleMetadata `protobuf:"bytes,123,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	HttpMatch *HTTPMatch `protobuf:"bytes,122,opt,name=http_match,json=httpMatch,proto3" json:"http_match,omitempty"`
+	// For "setdscp" rules, the DSCP value to set on matching packets.
+	Dscp     int32         `protobuf:"varint,134,opt,name=dscp,proto3" json:"dscp,omitempty"`
+	Metadata *RuleMetadata `protobuf:"bytes,123,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	// An opaque ID/hash for the rule.
 	RuleId        string `protobuf:"bytes,201,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -2554,6 +2559,13 @@ func (x *Rule) GetHttpMatch() *HTTPMatch {
 	return nil
 }
 
+func (x *Rule) GetDscp() int32 {
+	if x != nil {
+		return x.Dscp
+	}
+	return 0
+}
+
 func (x *Rule) GetMetadata() *RuleMetadata {
 	if x != nil {
 		return x.Metadata
@@ -2656,9 +2668,12 @@ func (x *ServiceAccountMatch) GetNames() []string {
 }
 
 type HTTPMatch struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Methods       []string               `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
-	Paths         []*HTTPMatch_PathMatch `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Methods       []string                     `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	Paths         []*HTTPMatch_PathMatch       `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	Headers       []*HTTPMatch_HeaderMatch     `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	Hosts         []string                     `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	GrpcMethods   []*HTTPMatch_GRPCMethodMatch `protobuf:"bytes,5,rep,name=grpc_methods,json=grpcMethods,proto3" json:"grpc_methods,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2707,6 +2722,27 @@ func (x *HTTPMatch) GetPaths() []*HTTPMatch_PathMatch {
 	return nil
 }
 
+func (x *HTTPMatch) GetHeaders() []*HTTPMatch_HeaderMatch {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HTTPMatch) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *HTTPMatch) GetGrpcMethods() []*HTTPMatch_GRPCMethodMatch {
+	if x != nil {
+		return x.GrpcMethods
+	}
+	return nil
+}
+
 type RuleMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Annotations   map[string]string      `protobuf:"bytes,1,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -4047,7 +4083,9 @@ type WireguardStatusUpdate struct {
 	// Wireguard public-key set on the interface.
 	PublicKey string `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
 	// The IP version of this update
-	IpVersion     IPVersion `protobuf:"varint,2,opt,name=ip_version,json=ipVersion,proto3,enum=felix.IPVersion" json:"ip_version,omitempty"`
+	IpVersion IPVersion `protobuf:"varint,2,opt,name=ip_version,json=ipVersion,proto3,enum=felix.IPVersion" json:"ip_version,omitempty"`
+	// The public-key that the interface is about to switch to, if a key rotation is in progress.
+	NextPublicKey string `protobuf:"bytes,3,opt,name=next_public_key,json=nextPublicKey,proto3" json:"next_public_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -4096,6 +4134,13 @@ func (x *WireguardStatusUpdate) GetIpVersion() IPVersion {
 	return IPVersion_ANY
 }
 
+func (x *WireguardStatusUpdate) GetNextPublicKey() string {
+	if x != nil {
+		return x.NextPublicKey
+	}
+	return ""
+}
+
 type DataplaneInSync struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -5701,8 +5746,10 @@ type WireguardEndpointUpdate struct {
 	PublicKey string `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
 	// The IP address of the IPv4 wireguard interface.
 	InterfaceIpv4Addr string `protobuf:"bytes,3,opt,name=interface_ipv4_addr,json=interfaceIpv4Addr,proto3" json:"interface_ipv4_addr,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// The public key that IPv4 wireguard on this endpoint is about to switch to, if a key rotation is in progress.
+	NextPublicKey string `protobuf:"bytes,4,opt,name=next_public_key,json=nextPublicKey,proto3" json:"next_public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WireguardEndpointUpdate) Reset() {
@@ -5756,6 +5803,13 @@ func (x *WireguardEndpointUpdate) GetInterfaceIpv4Addr() string {
 	return ""
 }
 
+func (x *WireguardEndpointUpdate) GetNextPublicKey() string {
+	if x != nil {
+		return x.NextPublicKey
+	}
+	return ""
+}
+
 type WireguardEndpointRemove struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the IPv4 wireguard host.
@@ -5809,8 +5863,10 @@ type WireguardEndpointV6Update struct {
 	PublicKeyV6 string `protobuf:"bytes,2,opt,name=public_key_v6,json=publicKeyV6,proto3" json:"public_key_v6,omitempty"`
 	// The IP address of the IPv6 wireguard interface.
 	InterfaceIpv6Addr string `protobuf:"bytes,3,opt,name=interface_ipv6_addr,json=interfaceIpv6Addr,proto3" json:"interface_ipv6_addr,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// The public key that IPv6 wireguard on this endpoint is about to switch to, if a key rotation is in progress.
+	NextPublicKeyV6 string `protobuf:"bytes,4,opt,name=next_public_key_v6,json=nextPublicKeyV6,proto3" json:"next_public_key_v6,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *WireguardEndpointV6Update) Reset() {
@@ -5864,6 +5920,13 @@ func (x *WireguardEndpointV6Update) GetInterfaceIpv6Addr() string {
 	return ""
 }
 
+func (x *WireguardEndpointV6Update) GetNextPublicKeyV6() string {
+	if x != nil {
+		return x.NextPublicKeyV6
+	}
+	return ""
+}
+
 type WireguardEndpointV6Remove struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the IPv6 wireguard host.
@@ -6271,6 +6334,164 @@ func (*HTTPMatch_PathMatch_Exact) isHTTPMatch_PathMatch_PathMatch() {}
 
 func (*HTTPMatch_PathMatch_Prefix) isHTTPMatch_PathMatch_PathMatch() {}
 
+type HTTPMatch_HeaderMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are valid to be assigned to HeaderMatch:
+	//
+	//	*HTTPMatch_HeaderMatch_Exact
+	//	*HTTPMatch_HeaderMatch_Regex
+	//	*HTTPMatch_HeaderMatch_Present
+	HeaderMatch   isHTTPMatch_HeaderMatch_HeaderMatch `protobuf_oneof:"header_match"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HTTPMatch_HeaderMatch) Reset() {
+	*x = HTTPMatch_HeaderMatch{}
+	mi := &file_felixbackend_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPMatch_HeaderMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPMatch_HeaderMatch) ProtoMessage() {}
+
+func (x *HTTPMatch_HeaderMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_felixbackend_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPMatch_HeaderMatch.ProtoReflect.Descriptor instead.
+func (*HTTPMatch_HeaderMatch) Descriptor() ([]byte, []int) {
+	return file_felixbackend_proto_rawDescGZIP(), []int{19, 1}
+}
+
+func (x *HTTPMatch_HeaderMatch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HTTPMatch_HeaderMatch) GetHeaderMatch() isHTTPMatch_HeaderMatch_HeaderMatch {
+	if x != nil {
+		return x.HeaderMatch
+	}
+	return nil
+}
+
+func (x *HTTPMatch_HeaderMatch) GetExact() string {
+	if x != nil {
+		if x, ok := x.HeaderMatch.(*HTTPMatch_HeaderMatch_Exact); ok {
+			return x.Exact
+		}
+	}
+	return ""
+}
+
+func (x *HTTPMatch_HeaderMatch) GetRegex() string {
+	if x != nil {
+		if x, ok := x.HeaderMatch.(*HTTPMatch_HeaderMatch_Regex); ok {
+			return x.Regex
+		}
+	}
+	return ""
+}
+
+func (x *HTTPMatch_HeaderMatch) GetPresent() bool {
+	if x != nil {
+		if x, ok := x.HeaderMatch.(*HTTPMatch_HeaderMatch_Present); ok {
+			return x.Present
+		}
+	}
+	return false
+}
+
+type isHTTPMatch_HeaderMatch_HeaderMatch interface {
+	isHTTPMatch_HeaderMatch_HeaderMatch()
+}
+
+type HTTPMatch_HeaderMatch_Exact struct {
+	Exact string `protobuf:"bytes,2,opt,name=exact,proto3,oneof"`
+}
+
+type HTTPMatch_HeaderMatch_Regex struct {
+	Regex string `protobuf:"bytes,3,opt,name=regex,proto3,oneof"`
+}
+
+type HTTPMatch_HeaderMatch_Present struct {
+	Present bool `protobuf:"varint,4,opt,name=present,proto3,oneof"`
+}
+
+func (*HTTPMatch_HeaderMatch_Exact) isHTTPMatch_HeaderMatch_HeaderMatch() {}
+
+func (*HTTPMatch_HeaderMatch_Regex) isHTTPMatch_HeaderMatch_HeaderMatch() {}
+
+func (*HTTPMatch_HeaderMatch_Present) isHTTPMatch_HeaderMatch_HeaderMatch() {}
+
+type HTTPMatch_GRPCMethodMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HTTPMatch_GRPCMethodMatch) Reset() {
+	*x = HTTPMatch_GRPCMethodMatch{}
+	mi := &file_felixbackend_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPMatch_GRPCMethodMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPMatch_GRPCMethodMatch) ProtoMessage() {}
+
+func (x *HTTPMatch_GRPCMethodMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_felixbackend_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPMatch_GRPCMethodMatch.ProtoReflect.Descriptor instead.
+func (*HTTPMatch_GRPCMethodMatch) Descriptor() ([]byte, []int) {
+	return file_felixbackend_proto_rawDescGZIP(), []int{19, 2}
+}
+
+func (x *HTTPMatch_GRPCMethodMatch) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *HTTPMatch_GRPCMethodMatch) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
 var File_felixbackend_proto protoreflect.FileDescriptor
 
 const file_felixbackend_proto_rawDesc = "" +
@@ -6347,15 +6568,17 @@ const file_felixbackend_proto_rawDesc = "" +
 	"\vConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\b\n" +
-	"\x06InSync\"\x98\x01\n" +
+	"\x06InSync\"\xa4\x01\n" +
 	"\vIPSetUpdate\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\amembers\x18\x02 \x03(\tR\amembers\x120\n" +
-	"\x04type\x18\x03 \x01(\x0e2\x1c.felix.IPSetUpdate.IPSetTypeR\x04type\"-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1c.felix.IPSetUpdate.IPSetTypeR\x04type\"9\n" +
 	"\tIPSetType\x12\x06\n" +
 	"\x02IP\x10\x00\x12\x0f\n" +
 	"\vIP_AND_PORT\x10\x01\x12\a\n" +
-	"\x03NET\x10\x02\"p\n" +
+	"\x03NET\x10\x02\x12\n" +
+	"\n" +
+	"\x06DOMAIN\x10\x03\"p\n" +
 	"\x10IPSetDeltaUpdate\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\radded_members\x18\x02 \x03(\tR\faddedMembers\x12'\n" +
@@ -6386,7 +6609,7 @@ const file_felixbackend_proto_rawDesc = "" +
 	"\x0eoutbound_rules\x18\x02 \x03(\v2\v.felix.RuleR\routboundRules\x12\x1c\n" +
 	"\tuntracked\x18\x03 \x01(\bR\tuntracked\x12\x19\n" +
 	"\bpre_dnat\x18\x04 \x01(\bR\apreDnat\x12+\n" +
-	"\x11original_selector\x18\x06 \x01(\tR\x10originalSelector\"\xaa\x10\n" +
+	"\x11original_selector\x18\x06 \x01(\tR\x10originalSelector\"\xbf\x10\n" +
 	"\x04Rule\x12\x16\n" +
 	"\x06action\x18\x01 \x01(\tR\x06action\x12/\n" +
 	"\n" +
@@ -6428,7 +6651,8 @@ const file_felixbackend_proto_rawDesc = "" +
 	"\x19src_service_account_match\x18x \x01(\v2\x1a.felix.ServiceAccountMatchR\x16srcServiceAccountMatch\x12U\n" +
 	"\x19dst_service_account_match\x18y \x01(\v2\x1a.felix.ServiceAccountMatchR\x16dstServiceAccountMatch\x12/\n" +
 	"\n" +
-	"http_match\x18z \x01(\v2\x10.felix.HTTPMatchR\thttpMatch\x12/\n" +
+	"http_match\x18z \x01(\v2\x10.felix.HTTPMatchR\thttpMatch\x12\x13\n" +
+	"\x04dscp\x18\x86\x01 \x01(\x05R\x04dscp\x12/\n" +
 	"\bmetadata\x18{ \x01(\v2\x13.felix.RuleMetadataR\bmetadata\x12\x18\n" +
 	"\arule_id\x18\xc9\x01 \x01(\tR\x06ruleIdB\x06\n" +
 	"\x04icmpB\n" +
@@ -6437,15 +6661,27 @@ const file_felixbackend_proto_rawDesc = "" +
 	"log_prefix\"G\n" +
 	"\x13ServiceAccountMatch\x12\x1a\n" +
 	"\bselector\x18\x01 \x01(\tR\bselector\x12\x14\n" +
-	"\x05names\x18\x02 \x03(\tR\x05names\"\xa4\x01\n" +
+	"\x05names\x18\x02 \x03(\tR\x05names\"\xfb\x03\n" +
 	"\tHTTPMatch\x12\x18\n" +
 	"\amethods\x18\x01 \x03(\tR\amethods\x120\n" +
-	"\x05paths\x18\x02 \x03(\v2\x1a.felix.HTTPMatch.PathMatchR\x05paths\x1aK\n" +
+	"\x05paths\x18\x02 \x03(\v2\x1a.felix.HTTPMatch.PathMatchR\x05paths\x126\n" +
+	"\aheaders\x18\x03 \x03(\v2\x1c.felix.HTTPMatch.HeaderMatchR\aheaders\x12\x14\n" +
+	"\x05hosts\x18\x04 \x03(\tR\x05hosts\x12C\n" +
+	"\fgrpc_methods\x18\x05 \x03(\v2 .felix.HTTPMatch.GRPCMethodMatchR\vgrpcMethods\x1aK\n" +
 	"\tPathMatch\x12\x16\n" +
 	"\x05exact\x18\x01 \x01(\tH\x00R\x05exact\x12\x18\n" +
 	"\x06prefix\x18\x02 \x01(\tH\x00R\x06prefixB\f\n" +
 	"\n" +
-	"path_match\"\x96\x01\n" +
+	"path_match\x1a}\n" +
+	"\vHeaderMatch\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x05exact\x18\x02 \x01(\tH\x00R\x05exact\x12\x16\n" +
+	"\x05regex\x18\x03 \x01(\tH\x00R\x05regex\x12\x1a\n" +
+	"\apresent\x18\x04 \x01(\bH\x00R\apresentB\x0e\n" +
+	"\fheader_match\x1aC\n" +
+	"\x0fGRPCMethodMatch\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\"\x96\x01\n" +
 	"\fRuleMetadata\x12F\n" +
 	"\vannotations\x18\x01 \x03(\v2$.felix.RuleMetadata.AnnotationsEntryR\vannotations\x1a>\n" +
 	"\x10AnnotationsEntry\x12\x10\n" +
@@ -6544,12 +6780,13 @@ const file_felixbackend_proto_rawDesc = "" +
 	"\x06status\x18\x02 \x01(\v2\x15.felix.EndpointStatusR\x06status\x123\n" +
 	"\bendpoint\x18\x03 \x01(\v2\x17.felix.WorkloadEndpointR\bendpoint\"I\n" +
 	"\x1cWorkloadEndpointStatusRemove\x12)\n" +
-	"\x02id\x18\x01 \x01(\v2\x19.felix.WorkloadEndpointIDR\x02id\"g\n" +
+	"\x02id\x18\x01 \x01(\v2\x19.felix.WorkloadEndpointIDR\x02id\"\x8f\x01\n" +
 	"\x15WireguardStatusUpdate\x12\x1d\n" +
 	"\n" +
 	"public_key\x18\x01 \x01(\tR\tpublicKey\x12/\n" +
 	"\n" +
-	"ip_version\x18\x02 \x01(\x0e2\x10.felix.IPVersionR\tipVersion\"\x11\n" +
+	"ip_version\x18\x02 \x01(\x0e2\x10.felix.IPVersionR\tipVersion\x12&\n" +
+	"\x0fnext_public_key\x18\x03 \x01(\tR\rnextPublicKey\"\x11\n" +
 	"\x0fDataplaneInSync\"\x88\x02\n" +
 	"\x16HostMetadataV4V6Update\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x1b\n" +
@@ -6685,18 +6922,20 @@ const file_felixbackend_proto_rawDesc = "" +
 	"\tDirection\x12\v\n" +
 	"\aINBOUND\x10\x00\x12\f\n" +
 	"\bOUTBOUND\x10\x01B\x04\n" +
-	"\x02id\"\x84\x01\n" +
+	"\x02id\"\xac\x01\n" +
 	"\x17WireguardEndpointUpdate\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x1d\n" +
 	"\n" +
 	"public_key\x18\x02 \x01(\tR\tpublicKey\x12.\n" +
-	"\x13interface_ipv4_addr\x18\x03 \x01(\tR\x11interfaceIpv4Addr\"5\n" +
+	"\x13interface_ipv4_addr\x18\x03 \x01(\tR\x11interfaceIpv4Addr\x12&\n" +
+	"\x0fnext_public_key\x18\x04 \x01(\tR\rnextPublicKey\"5\n" +
 	"\x17WireguardEndpointRemove\x12\x1a\n" +
-	"\bhostname\x18\x01 \x01(\tR\bhostname\"\x8b\x01\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\"\xb8\x01\n" +
 	"\x19WireguardEndpointV6Update\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\"\n" +
 	"\rpublic_key_v6\x18\x02 \x01(\tR\vpublicKeyV6\x12.\n" +
-	"\x13interface_ipv6_addr\x18\x03 \x01(\tR\x11interfaceIpv6Addr\"7\n" +
+	"\x13interface_ipv6_addr\x18\x03 \x01(\tR\x11interfaceIpv6Addr\x12+\n" +
+	"\x12next_public_key_v6\x18\x04 \x01(\tR\x0fnextPublicKeyV6\"7\n" +
 	"\x19WireguardEndpointV6Remove\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\"\xbf\x02\n" +
 	"\x15GlobalBGPConfigUpdate\x122\n" +
@@ -6765,7 +7004,7 @@ func file_felixbackend_proto_rawDescGZIP() []byte {
 }
 
 var file_felixbackend_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
-var file_felixbackend_proto_msgTypes = make([]protoimpl.MessageInfo, 86)
+var file_felixbackend_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
 var file_felixbackend_proto_goTypes = []any{
 	(IPVersion)(0),                       // 0: felix.IPVersion
 	(WorkloadType)(0),                    // 1: felix.WorkloadType
@@ -6858,11 +7097,13 @@ var file_felixbackend_proto_goTypes = []any{
 	nil,                                  // 88: felix.ConfigUpdate.SourceToRawConfigEntry
 	nil,                                  // 89: felix.RawConfig.ConfigEntry
 	(*HTTPMatch_PathMatch)(nil),          // 90: felix.HTTPMatch.PathMatch
-	nil,                                  // 91: felix.RuleMetadata.AnnotationsEntry
-	nil,                                  // 92: felix.WorkloadEndpoint.AnnotationsEntry
-	nil,                                  // 93: felix.HostMetadataV4V6Update.LabelsEntry
-	nil,                                  // 94: felix.ServiceAccountUpdate.LabelsEntry
-	nil,                                  // 95: felix.NamespaceUpdate.LabelsEntry
+	(*HTTPMatch_HeaderMatch)(nil),        // 91: felix.HTTPMatch.HeaderMatch
+	(*HTTPMatch_GRPCMethodMatch)(nil),    // 92: felix.HTTPMatch.GRPCMethodMatch
+	nil,                                  // 93: felix.RuleMetadata.AnnotationsEntry
+	nil,                                  // 94: felix.WorkloadEndpoint.AnnotationsEntry
+	nil,                                  // 95: felix.HostMetadataV4V6Update.LabelsEntry
+	nil,                                  // 96: felix.ServiceAccountUpdate.LabelsEntry
+	nil,                                  // 97: felix.NamespaceUpdate.LabelsEntry
 }
 var file_felixbackend_proto_depIdxs = []int32{
 	15,  // 0: felix.ToDataplane.in_sync:type_name -> felix.InSync
@@ -6937,65 +7178,67 @@ var file_felixbackend_proto_depIdxs = []int32{
 	29,  // 69: felix.Rule.http_match:type_name -> felix.HTTPMatch
 	30,  // 70: felix.Rule.metadata:type_name -> felix.RuleMetadata
 	90,  // 71: felix.HTTPMatch.paths:type_name -> felix.HTTPMatch.PathMatch
-	91,  // 72: felix.RuleMetadata.annotations:type_name -> felix.RuleMetadata.AnnotationsEntry
-	34,  // 73: felix.WorkloadEndpointUpdate.id:type_name -> felix.WorkloadEndpointID
-	36,  // 74: felix.WorkloadEndpointUpdate.endpoint:type_name -> felix.WorkloadEndpoint
-	44,  // 75: felix.WorkloadEndpoint.tiers:type_name -> felix.TierInfo
-	45,  // 76: felix.WorkloadEndpoint.ipv4_nat:type_name -> felix.NatInfo
-	45,  // 77: felix.WorkloadEndpoint.ipv6_nat:type_name -> felix.NatInfo
-	92,  // 78: felix.WorkloadEndpoint.annotations:type_name -> felix.WorkloadEndpoint.AnnotationsEntry
-	37,  // 79: felix.WorkloadEndpoint.qos_controls:type_name -> felix.QoSControls
-	38,  // 80: felix.WorkloadEndpoint.local_bgp_peer:type_name -> felix.LocalBGPPeer
-	1,   // 81: felix.WorkloadEndpoint.type:type_name -> felix.WorkloadType
-	34,  // 82: felix.WorkloadEndpointRemove.id:type_name -> felix.WorkloadEndpointID
-	40,  // 83: felix.HostEndpointUpdate.id:type_name -> felix.HostEndpointID
-	42,  // 84: felix.HostEndpointUpdate.endpoint:type_name -> felix.HostEndpoint
-	44,  // 85: felix.HostEndpoint.tiers:type_name -> felix.TierInfo
-	44,  // 86: felix.HostEndpoint.untracked_tiers:type_name -> felix.TierInfo
-	44,  // 87: felix.HostEndpoint.pre_dnat_tiers:type_name -> felix.TierInfo
-	44,  // 88: felix.HostEndpoint.forward_tiers:type_name -> felix.TierInfo
-	40,  // 89: felix.HostEndpointRemove.id:type_name -> felix.HostEndpointID
-	40,  // 90: felix.HostEndpointStatusUpdate.id:type_name -> felix.HostEndpointID
-	48,  // 91: felix.HostEndpointStatusUpdate.status:type_name -> felix.EndpointStatus
-	40,  // 92: felix.HostEndpointStatusRemove.id:type_name -> felix.HostEndpointID
-	34,  // 93: felix.WorkloadEndpointStatusUpdate.id:type_name -> felix.WorkloadEndpointID
-	48,  // 94: felix.WorkloadEndpointStatusUpdate.status:type_name -> felix.EndpointStatus
-	36,  // 95: felix.WorkloadEndpointStatusUpdate.endpoint:type_name -> felix.WorkloadEndpoint
-	34,  // 96: felix.WorkloadEndpointStatusRemove.id:type_name -> felix.WorkloadEndpointID
-	0,   // 97: felix.WireguardStatusUpdate.ip_version:type_name -> felix.IPVersion
-	93,  // 98: felix.HostMetadataV4V6Update.labels:type_name -> felix.HostMetadataV4V6Update.LabelsEntry
-	62,  // 99: felix.IPAMPoolUpdate.pool:type_name -> felix.IPAMPool
-	66,  // 100: felix.ServiceAccountUpdate.id:type_name -> felix.ServiceAccountID
-	94,  // 101: felix.ServiceAccountUpdate.labels:type_name -> felix.ServiceAccountUpdate.LabelsEntry
-	66,  // 102: felix.ServiceAccountRemove.id:type_name -> felix.ServiceAccountID
-	69,  // 103: felix.NamespaceUpdate.id:type_name -> felix.NamespaceID
-	95,  // 104: felix.NamespaceUpdate.labels:type_name -> felix.NamespaceUpdate.LabelsEntry
-	69,  // 105: felix.NamespaceRemove.id:type_name -> felix.NamespaceID
-	2,   // 106: felix.RouteUpdate.types:type_name -> felix.RouteType
-	3,   // 107: felix.RouteUpdate.ip_pool_type:type_name -> felix.IPPoolType
-	70,  // 108: felix.RouteUpdate.tunnel_type:type_name -> felix.TunnelType
-	32,  // 109: felix.DataplaneStats.protocol:type_name -> felix.Protocol
-	77,  // 110: felix.DataplaneStats.stats:type_name -> felix.Statistic
-	78,  // 111: felix.DataplaneStats.rules:type_name -> felix.RuleTrace
-	4,   // 112: felix.DataplaneStats.action:type_name -> felix.Action
-	6,   // 113: felix.Statistic.direction:type_name -> felix.Statistic.Direction
-	7,   // 114: felix.Statistic.relativity:type_name -> felix.Statistic.Relativity
-	8,   // 115: felix.Statistic.kind:type_name -> felix.Statistic.Kind
-	4,   // 116: felix.Statistic.action:type_name -> felix.Action
-	25,  // 117: felix.RuleTrace.policy:type_name -> felix.PolicyID
-	21,  // 118: felix.RuleTrace.profile:type_name -> felix.ProfileID
-	9,   // 119: felix.RuleTrace.direction:type_name -> felix.RuleTrace.Direction
-	84,  // 120: felix.ServiceUpdate.ports:type_name -> felix.ServicePort
-	14,  // 121: felix.ConfigUpdate.SourceToRawConfigEntry.value:type_name -> felix.RawConfig
-	10,  // 122: felix.PolicySync.Sync:input_type -> felix.SyncRequest
-	76,  // 123: felix.PolicySync.Report:input_type -> felix.DataplaneStats
-	11,  // 124: felix.PolicySync.Sync:output_type -> felix.ToDataplane
-	75,  // 125: felix.PolicySync.Report:output_type -> felix.ReportResult
-	124, // [124:126] is the sub-list for method output_type
-	122, // [122:124] is the sub-list for method input_type
-	122, // [122:122] is the sub-list for extension type_name
-	122, // [122:122] is the sub-list for extension extendee
-	0,   // [0:122] is the sub-list for field type_name
+	91,  // 72: felix.HTTPMatch.headers:type_name -> felix.HTTPMatch.HeaderMatch
+	92,  // 73: felix.HTTPMatch.grpc_methods:type_name -> felix.HTTPMatch.GRPCMethodMatch
+	93,  // 74: felix.RuleMetadata.annotations:type_name -> felix.RuleMetadata.AnnotationsEntry
+	34,  // 75: felix.WorkloadEndpointUpdate.id:type_name -> felix.WorkloadEndpointID
+	36,  // 76: felix.WorkloadEndpointUpdate.endpoint:type_name -> felix.WorkloadEndpoint
+	44,  // 77: felix.WorkloadEndpoint.tiers:type_name -> felix.TierInfo
+	45,  // 78: felix.WorkloadEndpoint.ipv4_nat:type_name -> felix.NatInfo
+	45,  // 79: felix.WorkloadEndpoint.ipv6_nat:type_name -> felix.NatInfo
+	94,  // 80: felix.WorkloadEndpoint.annotations:type_name -> felix.WorkloadEndpoint.AnnotationsEntry
+	37,  // 81: felix.WorkloadEndpoint.qos_controls:type_name -> felix.QoSControls
+	38,  // 82: felix.WorkloadEndpoint.local_bgp_peer:type_name -> felix.LocalBGPPeer
+	1,   // 83: felix.WorkloadEndpoint.type:type_name -> felix.WorkloadType
+	34,  // 84: felix.WorkloadEndpointRemove.id:type_name -> felix.WorkloadEndpointID
+	40,  // 85: felix.HostEndpointUpdate.id:type_name -> felix.HostEndpointID
+	42,  // 86: felix.HostEndpointUpdate.endpoint:type_name -> felix.HostEndpoint
+	44,  // 87: felix.HostEndpoint.tiers:type_name -> felix.TierInfo
+	44,  // 88: felix.HostEndpoint.untracked_tiers:type_name -> felix.TierInfo
+	44,  // 89: felix.HostEndpoint.pre_dnat_tiers:type_name -> felix.TierInfo
+	44,  // 90: felix.HostEndpoint.forward_tiers:type_name -> felix.TierInfo
+	40,  // 91: felix.HostEndpointRemove.id:type_name -> felix.HostEndpointID
+	40,  // 92: felix.HostEndpointStatusUpdate.id:type_name -> felix.HostEndpointID
+	48,  // 93: felix.HostEndpointStatusUpdate.status:type_name -> felix.EndpointStatus
+	40,  // 94: felix.HostEndpointStatusRemove.id:type_name -> felix.HostEndpointID
+	34,  // 95: felix.WorkloadEndpointStatusUpdate.id:type_name -> felix.WorkloadEndpointID
+	48,  // 96: felix.WorkloadEndpointStatusUpdate.status:type_name -> felix.EndpointStatus
+	36,  // 97: felix.WorkloadEndpointStatusUpdate.endpoint:type_name -> felix.WorkloadEndpoint
+	34,  // 98: felix.WorkloadEndpointStatusRemove.id:type_name -> felix.WorkloadEndpointID
+	0,   // 99: felix.WireguardStatusUpdate.ip_version:type_name -> felix.IPVersion
+	95,  // 100: felix.HostMetadataV4V6Update.labels:type_name -> felix.HostMetadataV4V6Update.LabelsEntry
+	62,  // 101: felix.IPAMPoolUpdate.pool:type_name -> felix.IPAMPool
+	66,  // 102: felix.ServiceAccountUpdate.id:type_name -> felix.ServiceAccountID
+	96,  // 103: felix.ServiceAccountUpdate.labels:type_name -> felix.ServiceAccountUpdate.LabelsEntry
+	66,  // 104: felix.ServiceAccountRemove.id:type_name -> felix.ServiceAccountID
+	69,  // 105: felix.NamespaceUpdate.id:type_name -> felix.NamespaceID
+	97,  // 106: felix.NamespaceUpdate.labels:type_name -> felix.NamespaceUpdate.LabelsEntry
+	69,  // 107: felix.NamespaceRemove.id:type_name -> felix.NamespaceID
+	2,   // 108: felix.RouteUpdate.types:type_name -> felix.RouteType
+	3,   // 109: felix.RouteUpdate.ip_pool_type:type_name -> felix.IPPoolType
+	70,  // 110: felix.RouteUpdate.tunnel_type:type_name -> felix.TunnelType
+	32,  // 111: felix.DataplaneStats.protocol:type_name -> felix.Protocol
+	77,  // 112: felix.DataplaneStats.stats:type_name -> felix.Statistic
+	78,  // 113: felix.DataplaneStats.rules:type_name -> felix.RuleTrace
+	4,   // 114: felix.DataplaneStats.action:type_name -> felix.Action
+	6,   // 115: felix.Statistic.direction:type_name -> felix.Statistic.Direction
+	7,   // 116: felix.Statistic.relativity:type_name -> felix.Statistic.Relativity
+	8,   // 117: felix.Statistic.kind:type_name -> felix.Statistic.Kind
+	4,   // 118: felix.Statistic.action:type_name -> felix.Action
+	25,  // 119: felix.RuleTrace.policy:type_name -> felix.PolicyID
+	21,  // 120: felix.RuleTrace.profile:type_name -> felix.ProfileID
+	9,   // 121: felix.RuleTrace.direction:type_name -> felix.RuleTrace.Direction
+	84,  // 122: felix.ServiceUpdate.ports:type_name -> felix.ServicePort
+	14,  // 123: felix.ConfigUpdate.SourceToRawConfigEntry.value:type_name -> felix.RawConfig
+	10,  // 124: felix.PolicySync.Sync:input_type -> felix.SyncRequest
+	76,  // 125: felix.PolicySync.Report:input_type -> felix.DataplaneStats
+	11,  // 126: felix.PolicySync.Sync:output_type -> felix.ToDataplane
+	75,  // 127: felix.PolicySync.Report:output_type -> felix.ReportResult
+	126, // [126:128] is the sub-list for method output_type
+	124, // [124:126] is the sub-list for method input_type
+	124, // [124:124] is the sub-list for extension type_name
+	124, // [124:124] is the sub-list for extension extendee
+	0,   // [0:124] is the sub-list for field type_name
 }
 
 func init() { file_felixbackend_proto_init() }
@@ -7070,13 +7313,18 @@ func file_felixbackend_proto_init() {
 		(*HTTPMatch_PathMatch_Exact)(nil),
 		(*HTTPMatch_PathMatch_Prefix)(nil),
 	}
+	file_felixbackend_proto_msgTypes[81].OneofWrappers = []any{
+		(*HTTPMatch_HeaderMatch_Exact)(nil),
+		(*HTTPMatch_HeaderMatch_Regex)(nil),
+		(*HTTPMatch_HeaderMatch_Present)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_felixbackend_proto_rawDesc), len(file_felixbackend_proto_rawDesc)),
 			NumEnums:      10,
-			NumMessages:   86,
+			NumMessages:   88,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+)
+
+// dataplaneDriver matches the dataplane package's DataplaneDriver interface.
+type dataplaneDriver interface {
+	SendMessage(msg interface{}) error
+	RecvMessage() (msg interface{}, err error)
+}
+
+// RecordingDriver wraps a dataplane driver, recording the messages that are sent to it.  A failure
+// to record is logged but doesn't affect the driver.
+type RecordingDriver struct {
+	dataplaneDriver
+	w *Writer
+}
+
+func NewRecordingDriver(driver dataplaneDriver, w *Writer) *RecordingDriver {
+	return &RecordingDriver{dataplaneDriver: driver, w: w}
+}
+
+func (d *RecordingDriver) SendMessage(msg interface{}) error {
+	d.w.logError(d.w.WriteToDataplane(msg))
+	return d.dataplaneDriver.SendMessage(msg)
+}
+
+// RecordingSyncerCallbacks wraps a set of syncer callbacks, recording the updates that are passed
+// to them.
+type RecordingSyncerCallbacks struct {
+	api.SyncerCallbacks
+	w *Writer
+}
+
+func NewRecordingSyncerCallbacks(callbacks api.SyncerCallbacks, w *Writer) *RecordingSyncerCallbacks {
+	return &RecordingSyncerCallbacks{SyncerCallbacks: callbacks, w: w}
+}
+
+func (c *RecordingSyncerCallbacks) OnStatusUpdated(status api.SyncStatus) {
+	c.w.logError(c.w.WriteSyncerStatus(status))
+	c.SyncerCallbacks.OnStatusUpdated(status)
+}
+
+func (c *RecordingSyncerCallbacks) OnUpdates(updates []api.Update) {
+	c.w.logError(c.w.WriteSyncerUpdates(updates))
+	c.SyncerCallbacks.OnUpdates(updates)
+}
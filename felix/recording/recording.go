@@ -0,0 +1,422 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recording implements recordings of the updates that flow into and out of Felix's
+// calculation graph: the datastore updates from the syncer and the messages that the calculation
+// graph sends to the dataplane driver.  A recording can be replayed into a dataplane driver to
+// reproduce the dataplane state that Felix programmed, without access to the original cluster.
+//
+// A recording is a gzip-compressed stream that starts with a fixed header followed by a sequence
+// of records.  Each record has a one-byte type, an 8-byte little-endian timestamp (in nanoseconds
+// since the epoch), an 8-byte little-endian length and then the data.  Dataplane messages are
+// encoded as ToDataplane protobufs, in the same way as for an external dataplane driver; syncer
+// updates and statuses are encoded as JSON.
+package recording
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	pb "google.golang.org/protobuf/proto"
+
+	extdataplane "github.com/projectcalico/calico/felix/dataplane/external"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+const header = "calico-felix-recording-v1\n"
+
+// flushInterval is the maximum time that records are buffered before being flushed to the file.
+// Felix doesn't close the recording when it exits so this bounds how much is lost.
+const flushInterval = time.Second
+
+type RecordType uint8
+
+const (
+	RecordTypeToDataplane RecordType = iota + 1
+	RecordTypeSyncerUpdate
+	RecordTypeSyncerStatus
+)
+
+func (t RecordType) String() string {
+	switch t {
+	case RecordTypeToDataplane:
+		return "to-dataplane"
+	case RecordTypeSyncerUpdate:
+		return "syncer-update"
+	case RecordTypeSyncerStatus:
+		return "syncer-status"
+	default:
+		return fmt.Sprintf("Unknown<%d>", uint8(t))
+	}
+}
+
+// Record is a single entry in a recording.
+type Record struct {
+	Type      RecordType
+	Timestamp time.Time
+
+	// ToDataplane is the message that was sent to the dataplane driver, for RecordTypeToDataplane.
+	ToDataplane interface{}
+	// SyncerUpdate is the update from the syncer, for RecordTypeSyncerUpdate.
+	SyncerUpdate *SyncerUpdate
+	// SyncerStatus is the status reported by the syncer, for RecordTypeSyncerStatus.
+	SyncerStatus string
+}
+
+func (r *Record) String() string {
+	var detail string
+	switch r.Type {
+	case RecordTypeToDataplane:
+		detail = fmt.Sprintf("%T %v", r.ToDataplane, r.ToDataplane)
+	case RecordTypeSyncerUpdate:
+		u := r.SyncerUpdate
+		detail = fmt.Sprintf("%s %s rev=%q %s", u.UpdateType, u.Key, u.Revision, u.Value)
+	case RecordTypeSyncerStatus:
+		detail = r.SyncerStatus
+	}
+	return fmt.Sprintf("%s %s %s", r.Timestamp.Format(time.RFC3339Nano), r.Type, detail)
+}
+
+// SyncerUpdate is the recorded form of an update from the syncer.  The key is recorded as its
+// default datastore path and the value in its datastore encoding.
+type SyncerUpdate struct {
+	Key        string `json:"key"`
+	UpdateType string `json:"type"`
+	Revision   string `json:"revision,omitempty"`
+	Value      string `json:"value,omitempty"`
+}
+
+var updateTypeNames = map[api.UpdateType]string{
+	api.UpdateTypeKVUnknown: "unknown",
+	api.UpdateTypeKVNew:     "new",
+	api.UpdateTypeKVUpdated: "updated",
+	api.UpdateTypeKVDeleted: "deleted",
+}
+
+func syncerUpdateFromAPI(u api.Update) *SyncerUpdate {
+	su := &SyncerUpdate{
+		UpdateType: updateTypeNames[u.UpdateType],
+		Revision:   u.Revision,
+	}
+	if path, err := model.KeyToDefaultPath(u.Key); err == nil {
+		su.Key = path
+	} else {
+		su.Key = fmt.Sprint(u.Key)
+	}
+	if u.Value != nil {
+		if data, err := model.SerializeValue(&u.KVPair); err == nil {
+			su.Value = string(data)
+		} else {
+			su.Value = fmt.Sprint(u.Value)
+		}
+	}
+	return su
+}
+
+// ErrMaxSizeReached is the sticky error once a recording has reached its maximum size.  The
+// recording is complete up to that point and can still be replayed.
+var ErrMaxSizeReached = errors.New("recording reached its maximum size")
+
+// Writer writes a recording.  It is safe for concurrent use.  Write errors are sticky: after the
+// first error, further records are discarded and the error is returned.
+type Writer struct {
+	lock      sync.Mutex
+	file      io.Closer
+	out       *countingWriter
+	buf       *bufio.Writer
+	gz        *gzip.Writer
+	maxSize   int64
+	lastFlush time.Time
+	seqNo     uint64
+	err       error
+	logOnce   sync.Once
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Create creates (or truncates) the given file and returns a Writer that records to it.  See
+// NewWriter for the meaning of maxSize.
+func Create(filename string, maxSize int64) (*Writer, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	w, err := NewWriter(f, maxSize)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	w.file = f
+	return w, nil
+}
+
+// NewWriter returns a Writer that records to the given writer.  Once roughly maxSize bytes have
+// been written, the recording is finished and further records are discarded.  If maxSize is zero,
+// the recording's size is unlimited.
+func NewWriter(out io.Writer, maxSize int64) (*Writer, error) {
+	w := &Writer{
+		out:       &countingWriter{w: out},
+		maxSize:   maxSize,
+		lastFlush: time.Now(),
+	}
+	w.buf = bufio.NewWriter(w.out)
+	w.gz = gzip.NewWriter(w.buf)
+	if _, err := w.gz.Write([]byte(header)); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// WriteToDataplane records a message that was sent to the dataplane driver.  Messages that can't
+// be sent to an external dataplane driver are not recorded.
+func (w *Writer) WriteToDataplane(msg interface{}) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	envelope, err := extdataplane.WrapPayloadWithEnvelope(msg, w.seqNo)
+	if err != nil {
+		log.WithError(err).Debug("Not recording internal message.")
+		return nil
+	}
+	data, err := pb.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	w.seqNo++
+	return w.writeLocked(RecordTypeToDataplane, data)
+}
+
+// WriteSyncerUpdates records a batch of updates from the syncer.
+func (w *Writer) WriteSyncerUpdates(updates []api.Update) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, u := range updates {
+		data, err := json.Marshal(syncerUpdateFromAPI(u))
+		if err != nil {
+			return err
+		}
+		if err := w.writeLocked(RecordTypeSyncerUpdate, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteSyncerStatus records a status update from the syncer.
+func (w *Writer) WriteSyncerStatus(status api.SyncStatus) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.writeLocked(RecordTypeSyncerStatus, []byte(status.String()))
+}
+
+func (w *Writer) writeLocked(t RecordType, data []byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	now := time.Now()
+	hdr := make([]byte, 17)
+	hdr[0] = byte(t)
+	binary.LittleEndian.PutUint64(hdr[1:], uint64(now.UnixNano()))
+	binary.LittleEndian.PutUint64(hdr[9:], uint64(len(data)))
+	if _, w.err = w.gz.Write(hdr); w.err != nil {
+		return w.err
+	}
+	if _, w.err = w.gz.Write(data); w.err != nil {
+		return w.err
+	}
+	if now.Sub(w.lastFlush) >= flushInterval {
+		w.lastFlush = now
+		w.err = w.flushLocked()
+	}
+	if w.err == nil && w.maxSize > 0 && w.out.n >= w.maxSize {
+		if w.err = w.finishLocked(); w.err == nil {
+			w.err = ErrMaxSizeReached
+		}
+	}
+	return w.err
+}
+
+// finishLocked writes the end of the gzip stream and flushes it.
+func (w *Writer) finishLocked() error {
+	if err := w.gz.Close(); err != nil {
+		return err
+	}
+	return w.buf.Flush()
+}
+
+func (w *Writer) flushLocked() error {
+	if err := w.gz.Flush(); err != nil {
+		return err
+	}
+	return w.buf.Flush()
+}
+
+// logError logs the given error, if any.  Since errors are sticky, only the first is logged.
+func (w *Writer) logError(err error) {
+	if err == nil {
+		return
+	}
+	w.logOnce.Do(func() {
+		if errors.Is(err, ErrMaxSizeReached) {
+			log.WithField("maxSize", w.maxSize).Warn(
+				"Calculation graph recording reached its maximum size; recording stopped.")
+			return
+		}
+		log.WithError(err).Error("Failed to write to calculation graph recording; recording stopped.")
+	})
+}
+
+// Close finishes the recording and closes the underlying file, if the Writer was created with
+// Create.
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	err := w.err
+	if err == nil {
+		err = w.finishLocked()
+		w.err = errors.New("recording closed")
+	} else if errors.Is(err, ErrMaxSizeReached) {
+		// The recording was already finished when it reached its maximum size.
+		err = nil
+		w.err = errors.New("recording closed")
+	}
+	if w.file != nil {
+		if cErr := w.file.Close(); err == nil {
+			err = cErr
+		}
+	}
+	return err
+}
+
+// Reader reads a recording.
+type Reader struct {
+	file io.Closer
+	gz   *gzip.Reader
+}
+
+// Open opens the given recording file.
+func Open(filename string) (*Reader, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	r, err := NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	r.file = f
+	return r, nil
+}
+
+// NewReader returns a Reader for the recording in the given reader.  It checks the recording's
+// header.
+func NewReader(in io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("not a Felix recording: %w", err)
+	}
+	buf := make([]byte, len(header))
+	if _, err := io.ReadFull(gz, buf); err != nil || string(buf) != header {
+		return nil, errors.New("not a Felix recording: bad header")
+	}
+	return &Reader{gz: gz}, nil
+}
+
+// Next returns the next record in the recording or io.EOF at the end of the recording.  A
+// recording that was cut short, for example because Felix exited while recording, ends at the
+// last complete record.
+func (r *Reader) Next() (*Record, error) {
+	hdr := make([]byte, 17)
+	if _, err := io.ReadFull(r.gz, hdr); err != nil {
+		return nil, r.endOfRecording(err)
+	}
+	rec := &Record{
+		Type:      RecordType(hdr[0]),
+		Timestamp: time.Unix(0, int64(binary.LittleEndian.Uint64(hdr[1:]))),
+	}
+	data := make([]byte, binary.LittleEndian.Uint64(hdr[9:]))
+	if _, err := io.ReadFull(r.gz, data); err != nil {
+		return nil, r.endOfRecording(err)
+	}
+
+	switch rec.Type {
+	case RecordTypeToDataplane:
+		var envelope proto.ToDataplane
+		if err := pb.Unmarshal(data, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to parse dataplane message: %w", err)
+		}
+		rec.ToDataplane = UnwrapPayload(&envelope)
+	case RecordTypeSyncerUpdate:
+		rec.SyncerUpdate = &SyncerUpdate{}
+		if err := json.Unmarshal(data, rec.SyncerUpdate); err != nil {
+			return nil, fmt.Errorf("failed to parse syncer update: %w", err)
+		}
+	case RecordTypeSyncerStatus:
+		rec.SyncerStatus = string(data)
+	default:
+		return nil, fmt.Errorf("unknown record type %d", rec.Type)
+	}
+	return rec, nil
+}
+
+func (r *Reader) endOfRecording(err error) error {
+	if err == io.ErrUnexpectedEOF {
+		log.Warn("Recording ends with an incomplete record; ignoring it.")
+		return io.EOF
+	}
+	return err
+}
+
+// Close closes the underlying file, if the Reader was created with Open.
+func (r *Reader) Close() error {
+	if r.file != nil {
+		return r.file.Close()
+	}
+	return nil
+}
+
+// UnwrapPayload returns the message in the given ToDataplane envelope, or nil if it is empty.
+func UnwrapPayload(envelope *proto.ToDataplane) interface{} {
+	m := envelope.ProtoReflect()
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("payload"))
+	if fd == nil {
+		return nil
+	}
+	return m.Get(fd).Message().Interface()
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recording
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	googleproto "google.golang.org/protobuf/proto"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+func readAll(r *Reader) []*Record {
+	var recs []*Record
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return recs
+		}
+		Expect(err).NotTo(HaveOccurred())
+		recs = append(recs, rec)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	RegisterTestingT(t)
+
+	filename := filepath.Join(t.TempDir(), "recording.gz")
+	w, err := Create(filename, 0)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(w.WriteSyncerStatus(api.ResyncInProgress)).To(Succeed())
+	Expect(w.WriteSyncerUpdates([]api.Update{
+		{
+			KVPair: model.KVPair{
+				Key:      model.GlobalConfigKey{Name: "LogSeverityScreen"},
+				Value:    "Debug",
+				Revision: "1234",
+			},
+			UpdateType: api.UpdateTypeKVNew,
+		},
+		{
+			KVPair:     model.KVPair{Key: model.HostIPKey{Hostname: "node1"}},
+			UpdateType: api.UpdateTypeKVDeleted,
+		},
+	})).To(Succeed())
+	Expect(w.WriteToDataplane(&proto.ConfigUpdate{Config: map[string]string{"LogSeverityScreen": "Debug"}})).To(Succeed())
+	Expect(w.WriteToDataplane(&proto.IPSetUpdate{Id: "s1", Members: []string{"10.0.0.1"}})).To(Succeed())
+	Expect(w.WriteToDataplane(&proto.InSync{})).To(Succeed())
+	Expect(w.Close()).To(Succeed())
+
+	r, err := Open(filename)
+	Expect(err).NotTo(HaveOccurred())
+	defer r.Close()
+	recs := readAll(r)
+	Expect(recs).To(HaveLen(6))
+
+	Expect(recs[0].Type).To(Equal(RecordTypeSyncerStatus))
+	Expect(recs[0].SyncerStatus).To(Equal("resync"))
+	Expect(recs[1].SyncerUpdate).To(Equal(&SyncerUpdate{
+		Key:        "/calico/v1/config/LogSeverityScreen",
+		UpdateType: "new",
+		Revision:   "1234",
+		Value:      "Debug",
+	}))
+	Expect(recs[2].SyncerUpdate).To(Equal(&SyncerUpdate{
+		Key:        "/calico/v1/host/node1/bird_ip",
+		UpdateType: "deleted",
+	}))
+	Expect(recs[3].Type).To(Equal(RecordTypeToDataplane))
+	Expect(googleproto.Equal(recs[3].ToDataplane.(*proto.ConfigUpdate),
+		&proto.ConfigUpdate{Config: map[string]string{"LogSeverityScreen": "Debug"}})).To(BeTrue())
+	Expect(recs[4].ToDataplane.(*proto.IPSetUpdate).Members).To(Equal([]string{"10.0.0.1"}))
+	Expect(recs[5].ToDataplane).To(BeAssignableToTypeOf(&proto.InSync{}))
+
+	for i := 1; i < len(recs); i++ {
+		Expect(recs[i].Timestamp).NotTo(BeTemporally("<", recs[i-1].Timestamp))
+	}
+	Expect(recs[4].String()).To(ContainSubstring("to-dataplane *proto.IPSetUpdate"))
+}
+
+func TestInternalMessagesNotRecorded(t *testing.T) {
+	RegisterTestingT(t)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 0)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(w.WriteToDataplane(&calc.DatastoreNotReady{})).To(Succeed())
+	Expect(w.Close()).To(Succeed())
+
+	r, err := NewReader(&buf)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(readAll(r)).To(BeEmpty())
+}
+
+func TestMaxSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 64*1024)
+	Expect(err).NotTo(HaveOccurred())
+
+	// Write records that don't compress well until the recording is full.
+	rand := mathrand.New(mathrand.NewSource(1))
+	written := 0
+	for {
+		members := make([]string, 100)
+		for i := range members {
+			members[i] = fmt.Sprintf("10.%d.%d.%d", rand.Intn(256), rand.Intn(256), rand.Intn(256))
+		}
+		err := w.WriteToDataplane(&proto.IPSetUpdate{Id: "s1", Members: members})
+		if err != nil {
+			Expect(err).To(Equal(ErrMaxSizeReached))
+			break
+		}
+		written++
+		Expect(written).To(BeNumerically("<", 1000), "recording never reached its maximum size")
+	}
+	Expect(buf.Len()).To(BeNumerically("<", 128*1024))
+
+	// Further records are discarded, and the recording is complete up to the limit.
+	Expect(w.WriteToDataplane(&proto.InSync{})).To(Equal(ErrMaxSizeReached))
+	size := buf.Len()
+	Expect(w.Close()).To(Succeed())
+	Expect(buf.Len()).To(Equal(size))
+
+	r, err := NewReader(&buf)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(readAll(r)).To(HaveLen(written + 1))
+}
+
+func TestTruncatedRecording(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Simulate Felix exiting without closing the recording: the data is flushed but the gzip
+	// stream isn't terminated.
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 0)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(w.WriteToDataplane(&proto.InSync{})).To(Succeed())
+	Expect(w.WriteToDataplane(&proto.IPSetRemove{Id: "s1"})).To(Succeed())
+	w.lock.Lock()
+	Expect(w.flushLocked()).To(Succeed())
+	w.lock.Unlock()
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	Expect(err).NotTo(HaveOccurred())
+	recs := readAll(r)
+	Expect(recs).To(HaveLen(2))
+	Expect(recs[1].ToDataplane.(*proto.IPSetRemove).Id).To(Equal("s1"))
+
+	// Chop the final record in half.
+	r, err = NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()-10]))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(len(readAll(r))).To(BeNumerically("<", 2))
+}
+
+func TestBadHeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewReader(bytes.NewReader([]byte("plain text")))
+	Expect(err).To(HaveOccurred())
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write([]byte("some-other-format\n"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(gz.Close()).To(Succeed())
+	_, err = NewReader(&buf)
+	Expect(err).To(MatchError("not a Felix recording: bad header"))
+}
+
+type fakeDriver struct {
+	sent []interface{}
+}
+
+func (d *fakeDriver) SendMessage(msg interface{}) error {
+	d.sent = append(d.sent, msg)
+	return nil
+}
+
+func (d *fakeDriver) RecvMessage() (interface{}, error) {
+	return nil, nil
+}
+
+type fakeCallbacks struct {
+	updates  []api.Update
+	statuses []api.SyncStatus
+}
+
+func (c *fakeCallbacks) OnStatusUpdated(status api.SyncStatus) {
+	c.statuses = append(c.statuses, status)
+}
+
+func (c *fakeCallbacks) OnUpdates(updates []api.Update) {
+	c.updates = append(c.updates, updates...)
+}
+
+func TestRecordingWrappers(t *testing.T) {
+	RegisterTestingT(t)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 0)
+	Expect(err).NotTo(HaveOccurred())
+
+	driver := &fakeDriver{}
+	rd := NewRecordingDriver(driver, w)
+	callbacks := &fakeCallbacks{}
+	rc := NewRecordingSyncerCallbacks(callbacks, w)
+
+	update := api.Update{
+		KVPair:     model.KVPair{Key: model.ReadyFlagKey{}, Value: true},
+		UpdateType: api.UpdateTypeKVNew,
+	}
+	rc.OnUpdates([]api.Update{update})
+	rc.OnStatusUpdated(api.InSync)
+	inSync := &proto.InSync{}
+	Expect(rd.SendMessage(inSync)).To(Succeed())
+	Expect(w.Close()).To(Succeed())
+
+	// The wrapped driver and callbacks still get everything.
+	Expect(driver.sent).To(Equal([]interface{}{inSync}))
+	Expect(callbacks.updates).To(Equal([]api.Update{update}))
+	Expect(callbacks.statuses).To(Equal([]api.SyncStatus{api.InSync}))
+
+	r, err := NewReader(&buf)
+	Expect(err).NotTo(HaveOccurred())
+	recs := readAll(r)
+	Expect(recs).To(HaveLen(3))
+	Expect(recs[0].SyncerUpdate.Key).To(Equal("/calico/v1/Ready"))
+	Expect(recs[0].SyncerUpdate.Value).To(Equal("true"))
+	Expect(recs[1].SyncerStatus).To(Equal("in-sync"))
+	Expect(recs[2].Type).To(Equal(RecordTypeToDataplane))
+
+	// Writing after the recording is closed fails but doesn't affect the driver.
+	Expect(rd.SendMessage(&proto.InSync{})).To(Succeed())
+	Expect(driver.sent).To(HaveLen(2))
+}
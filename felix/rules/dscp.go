@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/generictables"
+	"github.com/projectcalico/calico/felix/types"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// DSCP marking is implemented in the mangle table.  Unlike the rest of policy, which is only
+// evaluated for the first packet of a connection, the DSCP has to be set on every packet that the
+// endpoint sends on the connections that it opened, so:
+//
+//   - Each policy with DSCP rules gets a cali-pd-<policy> chain, which holds only its DSCP rules.
+//     A matching rule sets the DSCP and the pass mark, and then returns.
+//   - Each endpoint that has such policies gets a per-endpoint DSCP chain, which jumps to the
+//     DSCP chains of its egress policies in order and returns once the pass mark is set.  Hence,
+//     the first matching DSCP rule wins.
+//   - The mangle FORWARD chain dispatches traffic from workloads to the workload DSCP chains and
+//     the mangle OUTPUT chain dispatches traffic from the host to the host endpoint DSCP chains.
+//     Both only dispatch packets in the original direction of their connection, which matches
+//     the BPF dataplane, where the DSCP is recorded on the conntrack entry of the flow that the
+//     endpoint opened and only set on the opener's packets.
+//
+// If the packet is then encapsulated, the DSCP is copied to the outer header: the VXLAN devices
+// inherit the TOS of the inner packet and the cali-ipip-dscp chain copies it for IPIP.
+
+// WorkloadEndpointToDSCPChain renders the mangle chain that applies the DSCP rules of the
+// workload's egress policies.  Returns nil if none of the policies have DSCP rules.
+func (r *DefaultRuleRenderer) WorkloadEndpointToDSCPChain(
+	ifaceName string,
+	tiers []TierPolicyGroups,
+	dscpPolicies set.Set[types.PolicyID],
+) *generictables.Chain {
+	return r.endpointDSCPChain(WorkloadFromEndpointDSCPPfx, ifaceName, tiers, dscpPolicies)
+}
+
+// HostEndpointToDSCPChain renders the mangle chain that applies the DSCP rules of the host
+// endpoint's (normal) egress policies.  Returns nil if none of the policies have DSCP rules.
+func (r *DefaultRuleRenderer) HostEndpointToDSCPChain(
+	ifaceName string,
+	tiers []TierPolicyGroups,
+	dscpPolicies set.Set[types.PolicyID],
+) *generictables.Chain {
+	return r.endpointDSCPChain(HostToEndpointDSCPPfx, ifaceName, tiers, dscpPolicies)
+}
+
+func (r *DefaultRuleRenderer) endpointDSCPChain(
+	endpointPrefix string,
+	ifaceName string,
+	tiers []TierPolicyGroups,
+	dscpPolicies set.Set[types.PolicyID],
+) *generictables.Chain {
+	var rules []generictables.Rule
+	for _, tier := range tiers {
+		for _, group := range tier.EgressPolicies {
+			for _, polName := range group.PolicyNames {
+				polID := types.PolicyID{Tier: group.Tier, Name: polName}
+				if model.PolicyIsStaged(polName) || !dscpPolicies.Contains(polID) {
+					continue
+				}
+				if len(rules) > 0 {
+					rules = append(rules, generictables.Rule{
+						Match:   r.NewMatch().MarkSingleBitSet(r.MarkPass),
+						Action:  r.Return(),
+						Comment: []string{"Return if DSCP set"},
+					})
+				}
+				rules = append(rules, generictables.Rule{
+					Match:  r.NewMatch(),
+					Action: r.Jump(PolicyChainName(PolicyDSCPPfx, &polID, r.NFTables)),
+				})
+			}
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	logrus.WithField("ifaceName", ifaceName).Debug("Rendering endpoint DSCP chain.")
+
+	// The policy DSCP chains use the pass mark to signal that they set the DSCP.
+	rules = append([]generictables.Rule{{
+		Match:  r.NewMatch(),
+		Action: r.ClearMark(r.MarkPass),
+	}}, rules...)
+
+	return &generictables.Chain{
+		Name:  EndpointChainName(endpointPrefix, ifaceName, r.maxNameLength),
+		Rules: rules,
+	}
+}
+
+// WorkloadDSCPDispatchChains renders the chains that dispatch traffic from workloads to the
+// workload DSCP chains of the given interfaces.
+func (r *DefaultRuleRenderer) WorkloadDSCPDispatchChains(ifaceNames []string) []*generictables.Chain {
+	return r.interfaceNameDispatchChains(
+		ifaceNames,
+		WorkloadFromEndpointDSCPPfx,
+		"",
+		ChainDispatchFromWorkloadDSCP,
+		"",
+		nil,
+		nil,
+	)
+}
+
+// HostDSCPDispatchChains renders the chains that dispatch traffic from the host to the host
+// endpoint DSCP chains of the given interfaces.  If defaultIfaceName is non-empty, traffic to
+// other interfaces, apart from local workloads, is sent to that interface's chain.
+func (r *DefaultRuleRenderer) HostDSCPDispatchChains(ifaceNames []string, defaultIfaceName string) []*generictables.Chain {
+	var toEndRules []generictables.Rule
+	if defaultIfaceName != "" {
+		for _, prefix := range r.WorkloadIfacePrefixes {
+			toEndRules = append(toEndRules, generictables.Rule{
+				Match:   r.NewMatch().OutInterface(prefix + r.wildcard),
+				Action:  r.Return(),
+				Comment: []string{"Skip egress WHEP DSCP for traffic to local workload"},
+			})
+		}
+		toEndRules = append(toEndRules, generictables.Rule{
+			Match:  r.NewMatch(),
+			Action: r.GoTo(EndpointChainName(HostToEndpointDSCPPfx, defaultIfaceName, r.maxNameLength)),
+		})
+	}
+	return r.interfaceNameDispatchChains(
+		ifaceNames,
+		"",
+		HostToEndpointDSCPPfx,
+		"",
+		ChainDispatchToHostEndpointDSCP,
+		nil,
+		toEndRules,
+	)
+}
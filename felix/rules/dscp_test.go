@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/generictables"
+	"github.com/projectcalico/calico/felix/ipsets"
+	"github.com/projectcalico/calico/felix/iptables"
+	. "github.com/projectcalico/calico/felix/rules"
+	"github.com/projectcalico/calico/felix/types"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+var _ = Describe("DSCP chains", func() {
+	rrConfigNormal := Config{
+		IPIPEnabled:           true,
+		IPSetConfigV4:         ipsets.NewIPVersionConfig(ipsets.IPFamilyV4, "cali", nil, nil),
+		IPSetConfigV6:         ipsets.NewIPVersionConfig(ipsets.IPFamilyV6, "cali", nil, nil),
+		MarkAccept:            0x8,
+		MarkPass:              0x10,
+		MarkScratch0:          0x20,
+		MarkScratch1:          0x40,
+		MarkDrop:              0x80,
+		MarkEndpoint:          0xff00,
+		WorkloadIfacePrefixes: []string{"cali", "tap"},
+	}
+
+	var renderer RuleRenderer
+	BeforeEach(func() {
+		renderer = NewRenderer(rrConfigNormal)
+	})
+
+	tiers := []TierPolicyGroups{
+		{
+			Name: "tier1",
+			IngressPolicies: []*PolicyGroup{{
+				Tier:        "tier1",
+				Direction:   PolicyDirectionInbound,
+				PolicyNames: []string{"in"},
+			}},
+			EgressPolicies: []*PolicyGroup{{
+				Tier:        "tier1",
+				Direction:   PolicyDirectionOutbound,
+				PolicyNames: []string{"a", "staged:b", "c"},
+			}},
+		},
+		{
+			Name: "default",
+			EgressPolicies: []*PolicyGroup{{
+				Tier:        "default",
+				Direction:   PolicyDirectionOutbound,
+				PolicyNames: []string{"d"},
+			}},
+		},
+	}
+
+	It("should jump to the DSCP chains of egress policies with DSCP rules, in order", func() {
+		dscpPolicies := set.From(
+			types.PolicyID{Tier: "tier1", Name: "in"},
+			types.PolicyID{Tier: "tier1", Name: "staged:b"},
+			types.PolicyID{Tier: "tier1", Name: "c"},
+			types.PolicyID{Tier: "default", Name: "d"},
+		)
+		Expect(renderer.WorkloadEndpointToDSCPChain("cali1234", tiers, dscpPolicies)).To(Equal(&generictables.Chain{
+			Name: "cali-dfw-cali1234",
+			Rules: []generictables.Rule{
+				{
+					Match:  iptables.Match(),
+					Action: iptables.ClearMarkAction{Mark: 0x10},
+				},
+				{
+					Match:  iptables.Match(),
+					Action: iptables.JumpAction{Target: "cali-pd-tier1/c"},
+				},
+				{
+					Match:   iptables.Match().MarkSingleBitSet(0x10),
+					Action:  iptables.ReturnAction{},
+					Comment: []string{"Return if DSCP set"},
+				},
+				{
+					Match:  iptables.Match(),
+					Action: iptables.JumpAction{Target: "cali-pd-default/d"},
+				},
+			},
+		}))
+		Expect(renderer.HostEndpointToDSCPChain("eth0", tiers, dscpPolicies).Name).To(Equal("cali-dth-eth0"))
+	})
+
+	It("should return nil if no egress policies have DSCP rules", func() {
+		dscpPolicies := set.From(types.PolicyID{Tier: "tier1", Name: "in"})
+		Expect(renderer.WorkloadEndpointToDSCPChain("cali1234", tiers, dscpPolicies)).To(BeNil())
+		Expect(renderer.HostEndpointToDSCPChain("eth0", nil, dscpPolicies)).To(BeNil())
+	})
+
+	It("should render workload DSCP dispatch chains", func() {
+		Expect(renderer.WorkloadDSCPDispatchChains([]string{"cali1234"})).To(Equal([]*generictables.Chain{{
+			Name: "cali-from-wl-dscp",
+			Rules: []generictables.Rule{{
+				Match:  iptables.Match().InInterface("cali1234"),
+				Action: iptables.GotoAction{Target: "cali-dfw-cali1234"},
+			}},
+		}}))
+		Expect(renderer.WorkloadDSCPDispatchChains(nil)).To(Equal([]*generictables.Chain{{
+			Name:  "cali-from-wl-dscp",
+			Rules: []generictables.Rule{},
+		}}))
+	})
+
+	It("should render host endpoint DSCP dispatch chains with a default interface", func() {
+		Expect(renderer.HostDSCPDispatchChains([]string{"eth0"}, "*")).To(Equal([]*generictables.Chain{{
+			Name: "cali-to-hep-dscp",
+			Rules: []generictables.Rule{
+				{
+					Match:  iptables.Match().OutInterface("eth0"),
+					Action: iptables.GotoAction{Target: "cali-dth-eth0"},
+				},
+				{
+					Match:   iptables.Match().OutInterface("cali+"),
+					Action:  iptables.ReturnAction{},
+					Comment: []string{"Skip egress WHEP DSCP for traffic to local workload"},
+				},
+				{
+					Match:   iptables.Match().OutInterface("tap+"),
+					Action:  iptables.ReturnAction{},
+					Comment: []string{"Skip egress WHEP DSCP for traffic to local workload"},
+				},
+				{
+					Match:  iptables.Match(),
+					Action: iptables.GotoAction{Target: "cali-dth-*"},
+				},
+			},
+		}}))
+	})
+})
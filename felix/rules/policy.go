@@ -62,7 +62,42 @@ func (r *DefaultRuleRenderer) PolicyToIptablesChains(policyID *types.PolicyID, p
 			fmt.Sprintf("Policy %s egress", policyID.Name),
 		),
 	}
-	return []*generictables.Chain{&inbound, &outbound}
+	chains := []*generictables.Chain{&inbound, &outbound}
+	if PolicyHasDSCPRules(policy) {
+		// DSCP rules get their own chain, which is only referenced from the mangle table.
+		// They are applied to every packet, not just to the first packet of a connection
+		// like the normal policy chains.
+		chains = append(chains, &generictables.Chain{
+			Name:  PolicyChainName(PolicyDSCPPfx, policyID, r.NFTables),
+			Rules: r.policyDSCPRules(policy.OutboundRules, ipVersion, policyID.Name),
+		})
+	}
+	return chains
+}
+
+// PolicyHasDSCPRules returns true if the policy has any egress rules that set the DSCP.
+func PolicyHasDSCPRules(policy *proto.Policy) bool {
+	for _, r := range policy.OutboundRules {
+		if r.Action == "setdscp" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DefaultRuleRenderer) policyDSCPRules(protoRules []*proto.Rule, ipVersion uint8, name string) []generictables.Rule {
+	var rules []generictables.Rule
+	for ii, protoRule := range protoRules {
+		if protoRule.Action != "setdscp" {
+			continue
+		}
+		rules = append(rules, r.ProtoRuleToIptablesRules(protoRule, ipVersion, RuleOwnerTypePolicy, RuleDirEgress, ii, name, false)...)
+	}
+	if len(rules) == 0 {
+		rules = append(rules, generictables.Rule{})
+	}
+	rules[0].Comment = append(rules[0].Comment, fmt.Sprintf("Policy %s DSCP", name))
+	return rules
 }
 
 func (r *DefaultRuleRenderer) ProfileToIptablesChains(profileID *types.ProfileID, profile *proto.Profile, ipVersion uint8) (inbound, outbound *generictables.Chain) {
@@ -103,6 +138,11 @@ func (r *DefaultRuleRenderer) ProtoRulesToIptablesRules(
 ) []generictables.Rule {
 	var rules []generictables.Rule
 	for ii, protoRule := range protoRules {
+		if protoRule.Action == "setdscp" {
+			// DSCP rules don't affect the verdict; they're rendered into a separate chain
+			// by PolicyToIptablesChains.
+			continue
+		}
 		// TODO (Matt): Need rule hash when that's cleaned up.
 		rules = append(rules, r.ProtoRuleToIptablesRules(protoRule, ipVersion, owner, dir, ii, name, untracked)...)
 	}
@@ -655,6 +695,14 @@ func (r *DefaultRuleRenderer) CombineMatchAndActionsForProtoRule(
 			Match:  r.NewMatch(),
 			Action: r.IptablesFilterDenyAction(),
 		})
+	case "setdscp":
+		// Set the DSCP and then return to the endpoint's DSCP chain, which stops looking
+		// for further DSCP rules once it sees the pass mark.
+		mark = r.MarkPass
+		rules = append(rules,
+			generictables.Rule{Match: r.NewMatch(), Action: r.SetDSCP(uint8(pRule.Dscp))},
+			generictables.Rule{Match: r.NewMatch(), Action: r.Return()},
+		)
 	case "log":
 		// Handled above.
 	default:
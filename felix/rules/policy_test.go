@@ -2359,3 +2359,103 @@ var _ = Describe("rule metadata tests", func() {
 		))
 	})
 })
+
+var _ = Describe("DSCP rule tests", func() {
+	rrConfigNormal := Config{
+		IPIPEnabled:       true,
+		IPIPTunnelAddress: nil,
+		IPSetConfigV4:     ipsets.NewIPVersionConfig(ipsets.IPFamilyV4, "cali", nil, nil),
+		IPSetConfigV6:     ipsets.NewIPVersionConfig(ipsets.IPFamilyV6, "cali", nil, nil),
+		MarkAccept:        0x80,
+		MarkPass:          0x100,
+		MarkScratch0:      0x200,
+		MarkScratch1:      0x400,
+		MarkDrop:          0x800,
+		MarkEndpoint:      0xff000,
+		LogPrefix:         "calico-packet",
+	}
+	policyID := &types.PolicyID{Tier: "default", Name: "default.foo"}
+
+	It("should render DSCP rules into their own chain", func() {
+		renderer := NewRenderer(rrConfigNormal)
+		chains := renderer.PolicyToIptablesChains(
+			policyID,
+			&proto.Policy{
+				OutboundRules: []*proto.Rule{
+					{Action: "setdscp", Dscp: 46, DstNet: []string{"10.0.0.0/8"}},
+					{Action: "allow"},
+				},
+			},
+			4,
+		)
+		Expect(chains).To(Equal([]*generictables.Chain{
+			{
+				Name: "cali-pi-default/default.foo",
+				Rules: []generictables.Rule{
+					{Comment: []string{"Policy default.foo ingress"}},
+				},
+			},
+			{
+				Name: "cali-po-default/default.foo",
+				Rules: []generictables.Rule{
+					{
+						Match:   iptables.Match(),
+						Action:  iptables.SetMarkAction{Mark: 0x80},
+						Comment: []string{"Policy default.foo egress"},
+					},
+				},
+			},
+			{
+				Name: "cali-pd-default/default.foo",
+				Rules: []generictables.Rule{
+					{
+						Match:   iptables.Match().DestNet("10.0.0.0/8"),
+						Action:  iptables.SetMarkAction{Mark: 0x100},
+						Comment: []string{"Policy default.foo DSCP"},
+					},
+					{
+						Match:  iptables.Match().MarkSingleBitSet(0x100),
+						Action: iptables.SetDSCPAction{DSCP: 46},
+					},
+					{
+						Match:  iptables.Match().MarkSingleBitSet(0x100),
+						Action: iptables.ReturnAction{},
+					},
+				},
+			},
+		}))
+	})
+
+	It("should render an empty DSCP chain if no DSCP rules apply to the IP version", func() {
+		renderer := NewRenderer(rrConfigNormal)
+		chains := renderer.PolicyToIptablesChains(
+			policyID,
+			&proto.Policy{
+				OutboundRules: []*proto.Rule{
+					{Action: "setdscp", Dscp: 10, IpVersion: proto.IPVersion_IPV4},
+				},
+			},
+			6,
+		)
+		Expect(chains).To(HaveLen(3))
+		Expect(chains[1].Rules).To(Equal([]generictables.Rule{
+			{Comment: []string{"Policy default.foo egress"}},
+		}))
+		Expect(chains[2]).To(Equal(&generictables.Chain{
+			Name: "cali-pd-default/default.foo",
+			Rules: []generictables.Rule{
+				{Comment: []string{"Policy default.foo DSCP"}},
+			},
+		}))
+	})
+
+	It("should not render a DSCP chain for a policy without DSCP rules", func() {
+		renderer := NewRenderer(rrConfigNormal)
+		policy := &proto.Policy{
+			InboundRules:  []*proto.Rule{{Action: "allow"}},
+			OutboundRules: []*proto.Rule{{Action: "deny"}},
+		}
+		Expect(PolicyHasDSCPRules(policy)).To(BeFalse())
+		Expect(renderer.PolicyToIptablesChains(policyID, policy, 4)).To(HaveLen(2))
+	})
+})
@@ -29,6 +29,7 @@ import (
 	"github.com/projectcalico/calico/felix/nftables"
 	"github.com/projectcalico/calico/felix/proto"
 	"github.com/projectcalico/calico/felix/types"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
 )
 
 const (
@@ -60,6 +61,8 @@ const (
 
 	ChainManglePrerouting  = ChainNamePrefix + "PREROUTING"
 	ChainManglePostrouting = ChainNamePrefix + "POSTROUTING"
+	ChainMangleForward     = ChainNamePrefix + "FORWARD"
+	ChainMangleOutput      = ChainNamePrefix + "OUTPUT"
 
 	IPSetIDNATOutgoingAllPools  = "all-ipam-pools"
 	IPSetIDNATOutgoingMasqPools = "masq-ipam-pools"
@@ -77,6 +80,7 @@ const (
 	PolicyOutboundPfx  PolicyChainNamePrefix  = ChainNamePrefix + "po-"
 	ProfileInboundPfx  ProfileChainNamePrefix = ChainNamePrefix + "pri-"
 	ProfileOutboundPfx ProfileChainNamePrefix = ChainNamePrefix + "pro-"
+	PolicyDSCPPfx      PolicyChainNamePrefix  = ChainNamePrefix + "pd-"
 
 	PolicyGroupInboundPrefix  string = ChainNamePrefix + "gi-"
 	PolicyGroupOutboundPrefix string = ChainNamePrefix + "go-"
@@ -94,6 +98,9 @@ const (
 	ChainDispatchFromHostEndPointForward = ChainNamePrefix + "from-hep-forward"
 	ChainDispatchSetEndPointMark         = ChainNamePrefix + "set-endpoint-mark"
 	ChainDispatchFromEndPointMark        = ChainNamePrefix + "from-endpoint-mark"
+	ChainDispatchFromWorkloadDSCP        = ChainNamePrefix + "from-wl-dscp"
+	ChainDispatchToHostEndpointDSCP      = ChainNamePrefix + "to-hep-dscp"
+	ChainIPIPDSCP                        = ChainNamePrefix + "ipip-dscp"
 
 	ChainForwardCheck        = ChainNamePrefix + "forward-check"
 	ChainForwardEndpointMark = ChainNamePrefix + "forward-endpoint-mark"
@@ -113,6 +120,9 @@ const (
 	HostToEndpointForwardPfx   = ChainNamePrefix + "thfw-"
 	HostFromEndpointForwardPfx = ChainNamePrefix + "fhfw-"
 
+	WorkloadFromEndpointDSCPPfx = ChainNamePrefix + "dfw-"
+	HostToEndpointDSCPPfx       = ChainNamePrefix + "dth-"
+
 	RPFChain = ChainNamePrefix + "rpf"
 
 	RuleHashPrefix = "cali:"
@@ -264,7 +274,7 @@ type RuleRenderer interface {
 	StaticRawTableChains(ipVersion uint8) []*generictables.Chain
 	StaticBPFModeRawChains(ipVersion uint8, wgEncryptHost, disableConntrack bool) []*generictables.Chain
 	StaticMangleTableChains(ipVersion uint8) []*generictables.Chain
-	StaticFilterForwardAppendRules() []generictables.Rule
+	StaticFilterForwardAppendRules(ipVersion uint8) []generictables.Rule
 
 	DispatchMappings(map[types.WorkloadEndpointID]*proto.WorkloadEndpoint) (map[string][]string, map[string][]string)
 	WorkloadDispatchChains(map[types.WorkloadEndpointID]*proto.WorkloadEndpoint) []*generictables.Chain
@@ -289,6 +299,8 @@ type RuleRenderer interface {
 	HostDispatchChains(map[string]types.HostEndpointID, string, bool) []*generictables.Chain
 	FromHostDispatchChains(map[string]types.HostEndpointID, string) []*generictables.Chain
 	ToHostDispatchChains(map[string]types.HostEndpointID, string) []*generictables.Chain
+	WorkloadDSCPDispatchChains(ifaceNames []string) []*generictables.Chain
+	HostDSCPDispatchChains(ifaceNames []string, defaultIfaceName string) []*generictables.Chain
 	HostEndpointToFilterChains(
 		ifaceName string,
 		tiers []TierPolicyGroups,
@@ -313,6 +325,16 @@ type RuleRenderer interface {
 		ifaceName string,
 		preDNATTiers []TierPolicyGroups,
 	) []*generictables.Chain
+	WorkloadEndpointToDSCPChain(
+		ifaceName string,
+		tiers []TierPolicyGroups,
+		dscpPolicies set.Set[types.PolicyID],
+	) *generictables.Chain
+	HostEndpointToDSCPChain(
+		ifaceName string,
+		tiers []TierPolicyGroups,
+		dscpPolicies set.Set[types.PolicyID],
+	) *generictables.Chain
 
 	PolicyToIptablesChains(policyID *types.PolicyID, policy *proto.Policy, ipVersion uint8) []*generictables.Chain
 	ProfileToIptablesChains(profileID *types.ProfileID, policy *proto.Profile, ipVersion uint8) (inbound, outbound *generictables.Chain)
@@ -441,6 +463,14 @@ type Config struct {
 
 	NFTables        bool
 	FlowLogsEnabled bool
+
+	// DNSPolicyNfqueueEnabled causes DNS queries from local workloads to the DNSTrustedServers, and
+	// the responses to them, to be sent to NFQUEUE DNSPolicyNfqueueID so that Felix can learn the
+	// IPs of domains used in policy.  Servers are matched on the conntrack original destination so
+	// that a DNS service's cluster IP can be listed.
+	DNSPolicyNfqueueEnabled bool
+	DNSPolicyNfqueueID      uint16
+	DNSTrustedServers       []string
 }
 
 var unusedBitsInBPFMode = map[string]bool{
@@ -17,6 +17,7 @@ package rules
 import (
 	"fmt"
 
+	"github.com/projectcalico/api/pkg/lib/numorstring"
 	log "github.com/sirupsen/logrus"
 
 	tcdefs "github.com/projectcalico/calico/felix/bpf/tc/defs"
@@ -454,10 +455,16 @@ func (r *DefaultRuleRenderer) filterWorkloadToHostChain(ipVersion uint8) *generi
 		Action: r.Jump(ChainFromWorkloadDispatch),
 	})
 
-	// If the dispatch chain accepts the packet, it returns to us here.  Apply the configured
-	// action.  Note: we may have done work above to allow the packet and then end up dropping
-	// it here.  We can't optimize that away because there may be other rules (such as log
-	// rules in the policy).
+	// If the dispatch chain accepts the packet, it returns to us here.  DNS queries to
+	// host-networked servers are only snooped if we're about to accept them; the NFQUEUE verdict
+	// would skip the rest of the INPUT chain.
+	if r.EndpointToHostAction == "ACCEPT" {
+		rules = append(rules, r.dnsQueryNfqueueRules(ipVersion, false)...)
+	}
+
+	// Apply the configured action.  Note: we may have done work above to allow the packet and
+	// then end up dropping it here.  We can't optimize that away because there may be other rules
+	// (such as log rules in the policy).
 	for _, action := range r.inputAcceptActions {
 		rules = append(rules, generictables.Rule{
 			Action:  action,
@@ -671,9 +678,15 @@ func (r *DefaultRuleRenderer) StaticFilterForwardChains() []*generictables.Chain
 
 // StaticFilterForwardAppendRules returns rules which should be statically appended to the end of the filter
 // table's forward chain.
-func (r *DefaultRuleRenderer) StaticFilterForwardAppendRules() []generictables.Rule {
-	return []generictables.Rule{
-		{
+func (r *DefaultRuleRenderer) StaticFilterForwardAppendRules(ipVersion uint8) []generictables.Rule {
+	var rules []generictables.Rule
+
+	// Policy accepted this DNS query or response; hand it to Felix to snoop before it is accepted.
+	rules = append(rules, r.dnsQueryNfqueueRules(ipVersion, true)...)
+	rules = append(rules, r.dnsResponseNfqueueRules(ipVersion, true)...)
+
+	rules = append(rules,
+		generictables.Rule{
 			Match:   r.NewMatch().MarkSingleBitSet(r.MarkAccept),
 			Action:  r.filterAllowAction,
 			Comment: []string{"Policy explicitly accepted packet."},
@@ -681,10 +694,85 @@ func (r *DefaultRuleRenderer) StaticFilterForwardAppendRules() []generictables.R
 
 		// Set IptablesMarkAccept bit here, to indicate to our mangle-POSTROUTING chain that this is
 		// forwarded traffic and should not be subject to normal host endpoint policy.
-		{
+		generictables.Rule{
 			Action: r.SetMark(r.MarkAccept),
 		},
+	)
+	return rules
+}
+
+// dnsResponseNfqueueRules returns rules that send DNS responses heading to local workloads to
+// Felix's NFQUEUE, if DNS policy is enabled.  Only responses on connections to one of the
+// DNSTrustedServers are queued; the conntrack original destination is the address that the workload
+// sent its query to, before any DNAT.  The NFQUEUE action is terminal; the queue is configured with
+// bypass so that packets are accepted if Felix isn't listening.
+func (r *DefaultRuleRenderer) dnsResponseNfqueueRules(ipVersion uint8, onlyAccepted bool) []generictables.Rule {
+	if !r.DNSPolicyNfqueueEnabled {
+		return nil
+	}
+	var rules []generictables.Rule
+	for _, server := range r.dnsTrustedServers(ipVersion) {
+		for _, prefix := range r.WorkloadIfacePrefixes {
+			match := r.NewMatch()
+			if onlyAccepted {
+				match = match.MarkSingleBitSet(r.MarkAccept)
+			}
+			rules = append(rules, generictables.Rule{
+				Match: match.
+					Protocol("udp").
+					SourcePorts(53).
+					ConntrackOrigDest(server).
+					OutInterface(prefix + r.wildcard),
+				Action:  r.Nfqueue(r.DNSPolicyNfqueueID),
+				Comment: []string{"Snoop DNS responses to workloads."},
+			})
+		}
+	}
+	return rules
+}
+
+// dnsQueryNfqueueRules returns rules that send DNS queries from local workloads to the
+// DNSTrustedServers to Felix's NFQUEUE, so that the snooper only learns from responses that answer
+// a query that the workload actually sent.
+func (r *DefaultRuleRenderer) dnsQueryNfqueueRules(ipVersion uint8, onlyAccepted bool) []generictables.Rule {
+	if !r.DNSPolicyNfqueueEnabled {
+		return nil
+	}
+	var rules []generictables.Rule
+	for _, server := range r.dnsTrustedServers(ipVersion) {
+		for _, prefix := range r.WorkloadIfacePrefixes {
+			match := r.NewMatch()
+			if onlyAccepted {
+				match = match.MarkSingleBitSet(r.MarkAccept)
+			}
+			rules = append(rules, generictables.Rule{
+				Match: match.
+					Protocol("udp").
+					DestPorts(53).
+					ConntrackOrigDest(server).
+					InInterface(prefix + r.wildcard),
+				Action:  r.Nfqueue(r.DNSPolicyNfqueueID),
+				Comment: []string{"Snoop DNS queries from workloads."},
+			})
+		}
+	}
+	return rules
+}
+
+// dnsTrustedServers returns the DNSTrustedServers of the given IP version.
+func (r *DefaultRuleRenderer) dnsTrustedServers(ipVersion uint8) []string {
+	var servers []string
+	for _, server := range r.DNSTrustedServers {
+		ip, ipNet, err := cnet.ParseCIDROrIP(server)
+		if err != nil {
+			log.WithError(err).WithField("server", server).Error("Failed to parse trusted DNS server. Skipping it")
+			continue
+		}
+		if ip.Version() == int(ipVersion) {
+			servers = append(servers, ipNet.String())
+		}
 	}
+	return servers
 }
 
 func (r *DefaultRuleRenderer) StaticFilterOutputChains(ipVersion uint8) []*generictables.Chain {
@@ -723,6 +811,9 @@ func (r *DefaultRuleRenderer) filterOutputChain(ipVersion uint8) *generictables.
 		)
 	}
 
+	// DNS responses from host-networked DNS servers to workloads.
+	rules = append(rules, r.dnsResponseNfqueueRules(ipVersion, false)...)
+
 	// We don't currently police host -> endpoint according to the endpoint's ingress policy.
 	// That decision is based on pragmatism; it's generally very useful to be able to contact
 	// any local workload from the host and policing the traffic doesn't really protect
@@ -999,11 +1090,73 @@ func (r *DefaultRuleRenderer) StaticMangleTableChains(ipVersion uint8) []*generi
 		r.failsafeOutChain("mangle", ipVersion),
 		r.StaticManglePreroutingChain(ipVersion),
 		r.StaticManglePostroutingChain(ipVersion),
+		r.StaticMangleForwardChain(),
+		r.StaticMangleOutputChain(),
 	)
+	if ipVersion == 4 && r.IPIPEnabled {
+		chains = append(chains, r.StaticMangleIPIPDSCPChain())
+	}
 
 	return chains
 }
 
+// StaticMangleIPIPDSCPChain copies the DSCP of the inner packet to the outer header of IPIP
+// packets.  Unlike the VXLAN devices, the IPIP device is the kernel's fallback tunnel device, which
+// can't be set to inherit the TOS of the inner packet.
+func (r *DefaultRuleRenderer) StaticMangleIPIPDSCPChain() *generictables.Chain {
+	var rules []generictables.Rule
+	for dscp := uint8(1); dscp <= numorstring.MaxDSCP; dscp++ {
+		rules = append(rules, generictables.Rule{
+			Match:  r.NewMatch().IPIPInnerDSCP(dscp, dscp),
+			Action: r.SetDSCP(dscp),
+		})
+	}
+	return &generictables.Chain{
+		Name:  ChainIPIPDSCP,
+		Rules: rules,
+	}
+}
+
+// StaticMangleForwardChain applies the DSCP rules of workload egress policy to traffic that is
+// forwarded from local workloads, in the direction of the connections that they opened.
+func (r *DefaultRuleRenderer) StaticMangleForwardChain() *generictables.Chain {
+	var rules []generictables.Rule
+	for _, prefix := range r.WorkloadIfacePrefixes {
+		rules = append(rules, generictables.Rule{
+			Match:  r.NewMatch().InInterface(prefix + r.wildcard).ConntrackOriginalDirection(),
+			Action: r.Jump(ChainDispatchFromWorkloadDSCP),
+		})
+	}
+	// The DSCP chains use the pass mark to record that they have set the DSCP; don't leak it
+	// into the later tables.
+	rules = append(rules, generictables.Rule{
+		Match:  r.NewMatch(),
+		Action: r.ClearMark(r.MarkPass),
+	})
+	return &generictables.Chain{
+		Name:  ChainMangleForward,
+		Rules: rules,
+	}
+}
+
+// StaticMangleOutputChain applies the DSCP rules of host endpoint egress policy to traffic that is
+// sent by the host, in the direction of the connections that it opened.
+func (r *DefaultRuleRenderer) StaticMangleOutputChain() *generictables.Chain {
+	return &generictables.Chain{
+		Name: ChainMangleOutput,
+		Rules: []generictables.Rule{
+			{
+				Match:  r.NewMatch().ConntrackOriginalDirection(),
+				Action: r.Jump(ChainDispatchToHostEndpointDSCP),
+			},
+			{
+				Match:  r.NewMatch(),
+				Action: r.ClearMark(r.MarkPass),
+			},
+		},
+	}
+}
+
 func (r *DefaultRuleRenderer) StaticManglePreroutingChain(ipVersion uint8) *generictables.Chain {
 	rules := []generictables.Rule{}
 
@@ -1060,6 +1213,16 @@ func (r *DefaultRuleRenderer) StaticManglePreroutingChain(ipVersion uint8) *gene
 func (r *DefaultRuleRenderer) StaticManglePostroutingChain(ipVersion uint8) *generictables.Chain {
 	rules := []generictables.Rule{}
 
+	// IPIP packets that the host has encapsulated pass through here.  Copy the DSCP of the
+	// inner packet, if it has one, to the outer header.  This has to come first because the
+	// outer packet keeps the mark of the inner packet.
+	if ipVersion == 4 && r.IPIPEnabled {
+		rules = append(rules, generictables.Rule{
+			Match:  r.NewMatch().ProtocolNum(ProtoIPIP).IPIPInnerDSCP(1, numorstring.MaxDSCP),
+			Action: r.Jump(ChainIPIPDSCP),
+		})
+	}
+
 	// Note, we use RETURN as the Allow action in this chain, rather than ACCEPT because the
 	// mangle table is typically used, if at all, for packet manipulations that might need to
 	// apply to our allowed traffic.
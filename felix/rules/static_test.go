@@ -41,13 +41,19 @@ var _ = Describe("Static", func() {
 
 	checkManglePostrouting := func(ipVersion uint8, ipvs bool) {
 		It("should generate expected cali-POSTROUTING chain in the mangle table", func() {
-			expRules := []generictables.Rule{
-				// Accept already accepted.
-				{
-					Match:  Match().MarkSingleBitSet(0x10),
-					Action: ReturnAction{},
-				},
+			var expRules []generictables.Rule
+			if ipVersion == 4 && conf.IPIPEnabled {
+				// Copy the inner DSCP of IPIP packets to the outer header.
+				expRules = append(expRules, generictables.Rule{
+					Match:  Match().ProtocolNum(4).IPIPInnerDSCP(1, 63),
+					Action: JumpAction{Target: "cali-ipip-dscp"},
+				})
 			}
+			expRules = append(expRules, generictables.Rule{
+				// Accept already accepted.
+				Match:  Match().MarkSingleBitSet(0x10),
+				Action: ReturnAction{},
+			})
 			if ipvs {
 				// Accept IPVS-forwarded traffic.
 				expRules = append(expRules, generictables.Rule{
@@ -622,6 +628,36 @@ var _ = Describe("Static", func() {
 					},
 				}))
 			})
+			It("should return expected mangle FORWARD chain", func() {
+				Expect(findChain(rr.StaticMangleTableChains(4), "cali-FORWARD")).To(Equal(&generictables.Chain{
+					Name: "cali-FORWARD",
+					Rules: []generictables.Rule{
+						{
+							Match:  Match().InInterface("cali+").ConntrackOriginalDirection(),
+							Action: JumpAction{Target: ChainDispatchFromWorkloadDSCP},
+						},
+						{
+							Match:  Match(),
+							Action: ClearMarkAction{Mark: 0x20},
+						},
+					},
+				}))
+			})
+			It("should return expected mangle OUTPUT chain", func() {
+				Expect(findChain(rr.StaticMangleTableChains(6), "cali-OUTPUT")).To(Equal(&generictables.Chain{
+					Name: "cali-OUTPUT",
+					Rules: []generictables.Rule{
+						{
+							Match:  Match().ConntrackOriginalDirection(),
+							Action: JumpAction{Target: ChainDispatchToHostEndpointDSCP},
+						},
+						{
+							Match:  Match(),
+							Action: ClearMarkAction{Mark: 0x20},
+						},
+					},
+				}))
+			})
 
 			It("IPv4: should include the expected workload-to-host chain in the filter chains", func() {
 				Expect(findChain(rr.StaticFilterTableChains(4), "cali-wl-to-host")).To(Equal(&generictables.Chain{
@@ -710,6 +746,23 @@ var _ = Describe("Static", func() {
 
 			checkManglePostrouting(4, kubeIPVSEnabled)
 
+			It("IPv4: should copy the inner DSCP of IPIP packets to the outer header", func() {
+				chain := findChain(rr.StaticMangleTableChains(4), "cali-ipip-dscp")
+				Expect(chain).NotTo(BeNil())
+				Expect(chain.Rules).To(HaveLen(63))
+				Expect(chain.Rules[0]).To(Equal(generictables.Rule{
+					Match:  Match().IPIPInnerDSCP(1, 1),
+					Action: SetDSCPAction{DSCP: 1},
+				}))
+				Expect(chain.Rules[62]).To(Equal(generictables.Rule{
+					Match:  Match().IPIPInnerDSCP(63, 63),
+					Action: SetDSCPAction{DSCP: 63},
+				}))
+			})
+			It("IPv6: should not have an IPIP DSCP chain", func() {
+				Expect(findChain(rr.StaticMangleTableChains(6), "cali-ipip-dscp")).To(BeNil())
+			})
+
 			expInputChainIPIPV4IPVS := &generictables.Chain{
 				Name: "cali-INPUT",
 				Rules: []generictables.Rule{
@@ -1673,6 +1726,129 @@ var _ = Describe("Static", func() {
 		}
 	})
 
+	Describe("with DNS policy enabled", func() {
+		BeforeEach(func() {
+			conf = Config{
+				WorkloadIfacePrefixes:   []string{"cali", "tap"},
+				IPSetConfigV4:           ipsets.NewIPVersionConfig(ipsets.IPFamilyV4, "cali", nil, nil),
+				IPSetConfigV6:           ipsets.NewIPVersionConfig(ipsets.IPFamilyV6, "cali", nil, nil),
+				MarkAccept:              0x10,
+				MarkPass:                0x20,
+				MarkScratch0:            0x40,
+				MarkScratch1:            0x80,
+				MarkDrop:                0x200,
+				MarkEndpoint:            0xff000,
+				MarkNonCaliEndpoint:     0x1000,
+				FilterAllowAction:       "ACCEPT",
+				MangleAllowAction:       "ACCEPT",
+				DNSPolicyNfqueueEnabled: true,
+				DNSPolicyNfqueueID:      101,
+				DNSTrustedServers:       []string{"10.96.0.10/32", "fd00:96::10/128"},
+			}
+		})
+
+		It("should queue accepted DNS queries and responses for trusted servers in the forward append rules", func() {
+			Expect(rr.StaticFilterForwardAppendRules(4)).To(Equal([]generictables.Rule{
+				{
+					Match:   Match().MarkSingleBitSet(0x10).Protocol("udp").DestPorts(53).ConntrackOrigDest("10.96.0.10/32").InInterface("cali+"),
+					Action:  NfqueueAction{QueueNum: 101},
+					Comment: []string{"Snoop DNS queries from workloads."},
+				},
+				{
+					Match:   Match().MarkSingleBitSet(0x10).Protocol("udp").DestPorts(53).ConntrackOrigDest("10.96.0.10/32").InInterface("tap+"),
+					Action:  NfqueueAction{QueueNum: 101},
+					Comment: []string{"Snoop DNS queries from workloads."},
+				},
+				{
+					Match:   Match().MarkSingleBitSet(0x10).Protocol("udp").SourcePorts(53).ConntrackOrigDest("10.96.0.10/32").OutInterface("cali+"),
+					Action:  NfqueueAction{QueueNum: 101},
+					Comment: []string{"Snoop DNS responses to workloads."},
+				},
+				{
+					Match:   Match().MarkSingleBitSet(0x10).Protocol("udp").SourcePorts(53).ConntrackOrigDest("10.96.0.10/32").OutInterface("tap+"),
+					Action:  NfqueueAction{QueueNum: 101},
+					Comment: []string{"Snoop DNS responses to workloads."},
+				},
+				{
+					Match:   Match().MarkSingleBitSet(0x10),
+					Action:  AcceptAction{},
+					Comment: []string{"Policy explicitly accepted packet."},
+				},
+				{Action: SetMarkAction{Mark: 0x10}},
+			}))
+		})
+
+		It("should queue DNS responses from the host to workloads in the output chain", func() {
+			Expect(findChain(rr.StaticFilterTableChains(4), "cali-OUTPUT")).To(Equal(&generictables.Chain{
+				Name: "cali-OUTPUT",
+				Rules: []generictables.Rule{
+					// Untracked packets already matched in raw table.
+					{
+						Match:  Match().MarkSingleBitSet(0x10),
+						Action: AcceptAction{},
+					},
+
+					// DNS responses to workloads.
+					{
+						Match:   Match().Protocol("udp").SourcePorts(53).ConntrackOrigDest("10.96.0.10/32").OutInterface("cali+"),
+						Action:  NfqueueAction{QueueNum: 101},
+						Comment: []string{"Snoop DNS responses to workloads."},
+					},
+					{
+						Match:   Match().Protocol("udp").SourcePorts(53).ConntrackOrigDest("10.96.0.10/32").OutInterface("tap+"),
+						Action:  NfqueueAction{QueueNum: 101},
+						Comment: []string{"Snoop DNS responses to workloads."},
+					},
+
+					// To workload traffic.
+					{Match: Match().OutInterface("cali+"), Action: ReturnAction{}},
+					{Match: Match().OutInterface("tap+"), Action: ReturnAction{}},
+
+					// Non-workload traffic, send to host chains.
+					{Action: ClearMarkAction{Mark: 0xf0}},
+					{
+						Match:  Match().NotConntrackState("DNAT"),
+						Action: JumpAction{Target: ChainDispatchToHostEndpoint},
+					},
+					{
+						Match:   Match().MarkSingleBitSet(0x10),
+						Action:  AcceptAction{},
+						Comment: []string{"Host endpoint policy accepted packet."},
+					},
+				},
+			}))
+		})
+
+		It("should only queue DNS packets for trusted servers of the right IP version", func() {
+			rules := rr.StaticFilterForwardAppendRules(6)
+			Expect(rules).To(HaveLen(6))
+			Expect(rules[0].Match).To(Equal(Match().MarkSingleBitSet(0x10).Protocol("udp").DestPorts(53).ConntrackOrigDest("fd00:96::10/128").InInterface("cali+")))
+		})
+
+		It("should queue DNS queries to host-networked servers only if workload to host traffic is accepted", func() {
+			queryRule := generictables.Rule{
+				Match:   Match().Protocol("udp").DestPorts(53).ConntrackOrigDest("10.96.0.10/32").InInterface("cali+"),
+				Action:  NfqueueAction{QueueNum: 101},
+				Comment: []string{"Snoop DNS queries from workloads."},
+			}
+			Expect(findChain(rr.StaticFilterTableChains(4), ChainWorkloadToHost).Rules).NotTo(ContainElement(queryRule))
+
+			conf.EndpointToHostAction = "ACCEPT"
+			rr = NewRenderer(conf).(*DefaultRuleRenderer)
+			Expect(findChain(rr.StaticFilterTableChains(4), ChainWorkloadToHost).Rules).To(ContainElement(queryRule))
+		})
+
+		It("should not queue DNS packets when there are no trusted servers", func() {
+			rr.DNSTrustedServers = nil
+			Expect(rr.StaticFilterForwardAppendRules(4)).To(HaveLen(2))
+		})
+
+		It("should not queue DNS packets when disabled", func() {
+			rr.DNSPolicyNfqueueEnabled = false
+			Expect(rr.StaticFilterForwardAppendRules(4)).To(HaveLen(2))
+		})
+	})
+
 	Describe("with WireGuard enabled", func() {
 		type testConf struct {
 			IPVersion  uint8
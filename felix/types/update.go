@@ -33,6 +33,8 @@ type WireguardEndpointUpdate struct {
 	PublicKey string
 	// The IP address of the IPv4 wireguard interface.
 	InterfaceIpv4Addr string
+	// The public key that IPv4 wireguard on this endpoint is about to switch to, if a key rotation is in progress.
+	NextPublicKey string
 }
 
 type WireguardEndpointV6Update struct {
@@ -42,6 +44,8 @@ type WireguardEndpointV6Update struct {
 	PublicKeyV6 string
 	// The IP address of the IPv6 wireguard interface.
 	InterfaceIpv6Addr string
+	// The public key that IPv6 wireguard on this endpoint is about to switch to, if a key rotation is in progress.
+	NextPublicKeyV6 string
 }
 
 type RouteUpdate struct {
@@ -74,6 +78,7 @@ func ProtoToWireguardEndpointUpdate(msg *proto.WireguardEndpointUpdate) Wireguar
 		Hostname:          msg.Hostname,
 		PublicKey:         msg.PublicKey,
 		InterfaceIpv4Addr: msg.InterfaceIpv4Addr,
+		NextPublicKey:     msg.NextPublicKey,
 	}
 }
 
@@ -82,6 +87,7 @@ func ProtoToWireguardEndpointV6Update(msg *proto.WireguardEndpointV6Update) Wire
 		Hostname:          msg.Hostname,
 		PublicKeyV6:       msg.PublicKeyV6,
 		InterfaceIpv6Addr: msg.InterfaceIpv6Addr,
+		NextPublicKeyV6:   msg.NextPublicKeyV6,
 	}
 }
 
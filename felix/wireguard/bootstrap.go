@@ -601,13 +601,16 @@ func removeWireguardPublicKey(
 		}
 
 		// if there is any config mismatch, wipe the datastore's publickey (forces peers to send unencrypted traffic)
-		if ipVersion == 4 && thisNode.Status.WireguardPublicKey != "" || ipVersion == 6 && thisNode.Status.WireguardPublicKeyV6 != "" {
+		if ipVersion == 4 && (thisNode.Status.WireguardPublicKey != "" || thisNode.Status.WireguardNextPublicKey != "") ||
+			ipVersion == 6 && (thisNode.Status.WireguardPublicKeyV6 != "" || thisNode.Status.WireguardNextPublicKeyV6 != "") {
 			logCtx.Info("Wireguard key set on node - removing")
 			switch ipVersion {
 			case 4:
 				thisNode.Status.WireguardPublicKey = ""
+				thisNode.Status.WireguardNextPublicKey = ""
 			case 6:
 				thisNode.Status.WireguardPublicKeyV6 = ""
+				thisNode.Status.WireguardNextPublicKeyV6 = ""
 			}
 			cxt, cancel = context.WithTimeout(context.Background(), bootstrapK8sClientTimeout)
 			_, err = calicoClient.Nodes().Update(cxt, thisNode, options.SetOptions{})
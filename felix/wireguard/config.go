@@ -34,4 +34,9 @@ type Config struct {
 	PersistentKeepAlive time.Duration
 	RouteSyncDisabled   bool
 	ThreadedNAPI        bool
+
+	// Key rotation and preshared key configuration. A zero KeyRotationInterval disables key rotation; an empty
+	// PresharedKeySecret disables preshared keys.
+	KeyRotationInterval time.Duration
+	PresharedKeySecret  []byte
 }
@@ -17,6 +17,9 @@
 package wireguard
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"net"
@@ -54,6 +57,17 @@ const (
 	ipv4PrefixLen       = 32
 	ipv6PrefixLen       = 128
 	allSrcValidMarkPath = "/proc/sys/net/ipv4/conf/all/src_valid_mark"
+
+	// When rotating keys, the time between publishing our next public key and switching the device over to it. This
+	// gives peers time to add the next key before we start using it.
+	keyRotationGracePeriod = 2 * time.Minute
+
+	// While a peer's next key is programmed, how often we check whether the peer has started using it.
+	nextKeyHandshakePollInterval = time.Second
+
+	// Context string mixed into the preshared key derivation so that keys derived from the cluster secret for
+	// wireguard can't collide with keys derived from it for any other purpose.
+	presharedKeyContext = "calico-wireguard-psk"
 )
 
 var (
@@ -70,9 +84,17 @@ var (
 type nodeData struct {
 	endpointAddr          ip.Addr
 	publicKey             wgtypes.Key
+	nextPublicKey         wgtypes.Key
 	cidrs                 set.Set[ip.CIDR]
 	programmedInWireguard bool
 	routingToWireguard    bool
+
+	// The next public key that is programmed in wireguard as an additional peer, or the zero key.
+	programmedNextPublicKey wgtypes.Key
+
+	// Whether the peer has started using its next key, which we know once it has completed a handshake with the next
+	// key peer. From then on the peer's allowed IPs are programmed on the next key peer rather than the current one.
+	nextKeyActive bool
 }
 
 func newNodeData() *nodeData {
@@ -82,12 +104,32 @@ func newNodeData() *nodeData {
 }
 
 func (n *nodeData) allowedCidrsForWireguard() []net.IPNet {
-	cidrs := make([]net.IPNet, 0, n.cidrs.Len())
-	n.cidrs.Iter(func(item ip.CIDR) error {
-		cidrs = append(cidrs, item.ToIPNet())
+	return cidrsToIPNets(n.cidrs)
+}
+
+// currentKeyAllowedCidrs and nextKeyAllowedCidrs return the CIDRs to program on the current and next keys of a peer.
+// The CIDRs move over to the next key once the peer has started using it.
+func (n *nodeData) currentKeyAllowedCidrs() set.Set[ip.CIDR] {
+	if n.nextKeyActive {
+		return set.New[ip.CIDR]()
+	}
+	return n.cidrs
+}
+
+func (n *nodeData) nextKeyAllowedCidrs() set.Set[ip.CIDR] {
+	if !n.nextKeyActive {
+		return set.New[ip.CIDR]()
+	}
+	return n.cidrs
+}
+
+func cidrsToIPNets(cidrs set.Set[ip.CIDR]) []net.IPNet {
+	ipNets := make([]net.IPNet, 0, cidrs.Len())
+	cidrs.Iter(func(item ip.CIDR) error {
+		ipNets = append(ipNets, item.ToIPNet())
 		return nil
 	})
-	return cidrs
+	return ipNets
 }
 
 type nodeUpdateData struct {
@@ -96,9 +138,10 @@ type nodeUpdateData struct {
 	cidrsDeleted set.Set[ip.CIDR]
 
 	// Only used for peers.
-	deleted      bool
-	endpointAddr *ip.Addr
-	publicKey    *wgtypes.Key
+	deleted       bool
+	endpointAddr  *ip.Addr
+	publicKey     *wgtypes.Key
+	nextPublicKey *wgtypes.Key
 }
 
 func newNodeUpdateData() *nodeUpdateData {
@@ -136,6 +179,15 @@ type Wireguard struct {
 	ourPublicKeyAgreesWithDataplaneMsg bool
 	ourHostAddr                        ip.Addr
 
+	// Key rotation state. The device key is the public key last read from the wireguard device, and keyUpdatedAt is
+	// when it changed. While a rotation is in progress, nextPrivateKey holds the key we will switch to and
+	// nextKeyPublishedAt is when its public key was published (zero until then).
+	devicePublicKey    wgtypes.Key
+	keyUpdatedAt       time.Time
+	nextPrivateKey     *wgtypes.Key
+	nextKeyPublishedAt time.Time
+	switchToNextKey    bool
+
 	// Local route information. This contains the complete set of local routes: workloads, tunnels, hosts (for host
 	// encryption). This is always updated directly from the various update methods.
 	localIPs          set.Set[ip.Addr]
@@ -160,8 +212,9 @@ type Wireguard struct {
 	routetable *routetable.ClassView
 	routerule  *routerule.RouteRules
 
-	// Callback function used to notify of public key updates for the local nodeData
-	statusCallback func(publicKey wgtypes.Key) error
+	// Callback function used to notify of public key updates for the local nodeData. The next public key is only set
+	// while a key rotation is in progress.
+	statusCallback func(publicKey, nextPublicKey wgtypes.Key) error
 	opRecorder     logutils.OpRecorder
 
 	// The write proc sys function.
@@ -177,7 +230,7 @@ func New(
 	ipVersion uint8,
 	netlinkTimeout time.Duration,
 	deviceRouteProtocol netlink.RouteProtocol,
-	statusCallback func(publicKey wgtypes.Key) error,
+	statusCallback func(publicKey, nextPublicKey wgtypes.Key) error,
 	opRecorder logutils.OpRecorder,
 	featureDetector environment.FeatureDetectorIface,
 ) *Wireguard {
@@ -211,7 +264,7 @@ func NewWithShims(
 	netlinkTimeout time.Duration,
 	timeShim timeshim.Interface,
 	deviceRouteProtocol netlink.RouteProtocol,
-	statusCallback func(publicKey wgtypes.Key) error,
+	statusCallback func(publicKey, nextPublicKey wgtypes.Key) error,
 	writeProcSys func(path, value string) error,
 	opRecorder logutils.OpRecorder,
 	featureDetector environment.FeatureDetectorIface,
@@ -589,6 +642,36 @@ func (w *Wireguard) EndpointWireguardUpdate(name string, publicKey wgtypes.Key,
 	w.setNodeUpdate(name, update)
 }
 
+// EndpointWireguardNextKeyUpdate is called with the public key that a node is about to switch to while it is rotating
+// its key, or with the zero key when it is not rotating. The next key is programmed as an additional peer so that the
+// node can complete a handshake with us as soon as it switches keys.
+func (w *Wireguard) EndpointWireguardNextKeyUpdate(name string, nextPublicKey wgtypes.Key) {
+	logCtx := w.logCtx.WithFields(log.Fields{"node": name, "nextPublicKey": nextPublicKey})
+	logCtx.Debug("EndpointWireguardNextKeyUpdate")
+	if !w.Enabled() {
+		logCtx.Debug("Not enabled - ignoring")
+		return
+	}
+	if name == w.hostname {
+		// We track our own next key locally.
+		logCtx.Debug("Ignoring local next key")
+		return
+	}
+
+	existing, ok := w.nodes[name]
+	if ok && existing.nextPublicKey == nextPublicKey || !ok && nextPublicKey == zeroKey {
+		logCtx.Debug("Next public key unchanged from programmed")
+		if update := w.nodeUpdates[name]; update != nil {
+			update.nextPublicKey = nil
+		}
+		return
+	}
+	logCtx.Debug("Storing updated next public key")
+	update := w.getOrInitNodeUpdateData(name)
+	update.nextPublicKey = &nextPublicKey
+	w.setNodeUpdate(name, update)
+}
+
 // EndpointWireguardRemove is called when the wireguard configuration for an endpoint (a node) is removed. This
 // controls the local wireguard interface address and public key, and the peer public keys.
 func (w *Wireguard) EndpointWireguardRemove(name string) {
@@ -611,9 +694,10 @@ func (w *Wireguard) EndpointWireguardRemove(name string) {
 		return
 	}
 
-	// Create update to remove the public key.
+	// Create update to remove the public keys.
 	update := w.getOrInitNodeUpdateData(name)
 	update.publicKey = &zeroKey
+	update.nextPublicKey = &zeroKey
 	w.setNodeUpdate(name, update)
 }
 
@@ -645,14 +729,24 @@ func (w *Wireguard) Apply() (err error) {
 	defer func() {
 		// If we need to send the key then send on the callback method.
 		if !w.ourPublicKeyAgreesWithDataplaneMsg && w.ourPublicKey != nil {
-			w.logCtx.WithField("ourPublicKey", *w.ourPublicKey).Info("Public key out of sync or updated")
-			if errKey := w.statusCallback(*w.ourPublicKey); errKey != nil {
+			nextPublicKey := zeroKey
+			if w.nextPrivateKey != nil {
+				nextPublicKey = w.nextPrivateKey.PublicKey()
+			}
+			w.logCtx.WithFields(log.Fields{
+				"ourPublicKey":  *w.ourPublicKey,
+				"nextPublicKey": nextPublicKey,
+			}).Info("Public key out of sync or updated")
+			if errKey := w.statusCallback(*w.ourPublicKey, nextPublicKey); errKey != nil {
 				err = errKey
 				return
 			}
 
 			// We have sent the key status update.
 			w.ourPublicKeyAgreesWithDataplaneMsg = true
+			if w.nextPrivateKey != nil && w.nextKeyPublishedAt.IsZero() {
+				w.nextKeyPublishedAt = w.time.Now()
+			}
 		}
 	}()
 
@@ -686,6 +780,9 @@ func (w *Wireguard) Apply() (err error) {
 
 	// --- Wireguard is enabled ---
 
+	// Progress any key rotation. This may flag wireguard as not in-sync so that the resync switches the device key.
+	w.maybeRotateKey()
+
 	// Process local CIDR updates. This may result in node deltas for the local node.
 	if w.localCIDRsUpdated {
 		w.nodeUpdates[w.hostname] = w.getLocalNodeCIDRUpdates()
@@ -719,7 +816,8 @@ func (w *Wireguard) Apply() (err error) {
 				}
 
 				// Delete any nodes from the cache that no longer have any wireguard or routing configuration.
-				if node.endpointAddr == nil && node.cidrs.Len() == 0 && node.publicKey == zeroKey {
+				if node.endpointAddr == nil && node.cidrs.Len() == 0 && node.publicKey == zeroKey &&
+					node.nextPublicKey == zeroKey {
 					w.logCtx.WithField("node", name).Debug("Delete node configuration")
 					delete(w.nodes, name)
 				}
@@ -771,6 +869,15 @@ func (w *Wireguard) Apply() (err error) {
 		return ErrUpdateFailed
 	}
 
+	// Check whether any peer that is rotating its key has started using its next key. This may flag wireguard as not
+	// in-sync so that the resync moves the peer's allowed IPs over to the next key.
+	if err := w.updateNextKeyActive(wireguardClient); err != nil {
+		w.logCtx.WithError(err).Info("Failed to query wireguard peers")
+		w.closeWireguardClient()
+		w.inSyncWireguard = false
+		return ErrUpdateFailed
+	}
+
 	// The following can be done in parallel:
 	// - Update the link address
 	// - Update the routetable
@@ -836,6 +943,7 @@ func (w *Wireguard) Apply() (err error) {
 				w.ourPublicKey = &publicKey
 				w.ourPublicKeyAgreesWithDataplaneMsg = false
 			}
+			w.trackDeviceKey(publicKey)
 		}
 		w.inSyncWireguard = true
 	}()
@@ -1056,6 +1164,7 @@ func (w *Wireguard) updateCacheFromNodeUpdates() (conflictingKeys set.Set[wgtype
 
 			// Update the node public key and the key to node mapping.
 			node.publicKey = *update.publicKey
+			w.resetNextKeyActive(node)
 			if node.publicKey != zeroKey {
 				if nodenames := w.publicKeyToNodeNames[node.publicKey]; nodenames == nil {
 					w.logCtx.Debug("Public key not associated with a node")
@@ -1069,6 +1178,13 @@ func (w *Wireguard) updateCacheFromNodeUpdates() (conflictingKeys set.Set[wgtype
 			updated = true
 		}
 
+		if update.nextPublicKey != nil && *update.nextPublicKey != node.nextPublicKey {
+			logCtx.WithField("nextPublicKey", *update.nextPublicKey).Debug("Store next public key")
+			node.nextPublicKey = *update.nextPublicKey
+			w.resetNextKeyActive(node)
+			updated = true
+		}
+
 		update.cidrsDeleted.Iter(func(cidr ip.CIDR) error {
 			logCtx.WithField("cidr", cidr).Debug("Discarding CIDR")
 			node.cidrs.Discard(cidr)
@@ -1199,6 +1315,9 @@ func (w *Wireguard) constructWireguardDeltaFromNodeUpdates(conflictingKeys set.S
 					PersistentKeepaliveInterval: &w.config.PersistentKeepAlive,
 				}
 				updatePeer := false
+				if !peer.programmedInWireguard {
+					wgpeer.PresharedKey = w.presharedKey(w.devicePublicKey, peer.publicKey)
+				}
 				if !peer.programmedInWireguard || update.cidrsDeleted.Len() > 0 {
 					logCtx.Debug("Peer not programmed or CIDRs were deleted - need to replace full set of CIDRs")
 					wgpeer.ReplaceAllowedIPs = true
@@ -1232,6 +1351,9 @@ func (w *Wireguard) constructWireguardDeltaFromNodeUpdates(conflictingKeys set.S
 					PublicKey: peer.publicKey,
 				})
 			}
+
+			// Add, update or remove the peer's next key, if it is rotating its key.
+			wireguardUpdate.Peers = append(wireguardUpdate.Peers, w.nextPeerDelta(name, peer, update)...)
 		}
 
 		// Finally loop through any conflicting public keys and check each of the nodes is now handled correctly.
@@ -1262,6 +1384,7 @@ func (w *Wireguard) constructWireguardDeltaFromNodeUpdates(conflictingKeys set.S
 					nodeLogCtx.Debug("Not programmed in wireguard, needs to be added now")
 					wireguardUpdate.Peers = append(wireguardUpdate.Peers, wgtypes.PeerConfig{
 						PublicKey:                   peer.publicKey,
+						PresharedKey:                w.presharedKey(w.devicePublicKey, peer.publicKey),
 						Endpoint:                    w.endpointUDPAddr(peer.endpointAddr.AsNetIP()),
 						AllowedIPs:                  peer.allowedCidrsForWireguard(),
 						PersistentKeepaliveInterval: &w.config.PersistentKeepAlive,
@@ -1308,7 +1431,15 @@ func (w *Wireguard) constructWireguardDeltaForResync(wireguardClient netlinkshim
 	}
 
 	publicKey := device.PublicKey
-	if device.PrivateKey == zeroKey || device.PublicKey == zeroKey {
+	if w.switchToNextKey && w.nextPrivateKey != nil {
+		// We are rotating our key and the grace period has passed, switch the device over to the next key.
+		w.logCtx.Info("Switch to the next private/public key pair")
+		if device.PrivateKey != *w.nextPrivateKey {
+			wireguardUpdate.PrivateKey = w.nextPrivateKey
+			wireguardUpdateRequired = true
+		}
+		publicKey = w.nextPrivateKey.PublicKey()
+	} else if device.PrivateKey == zeroKey || device.PublicKey == zeroKey {
 		// One of the private or public key is not set. Generate a new private key and return the corresponding
 		// public key.
 		w.logCtx.Info("Generate new private/public key pair")
@@ -1327,6 +1458,14 @@ func (w *Wireguard) constructWireguardDeltaForResync(wireguardClient netlinkshim
 	// Track which keys we have processed.
 	processedKeys := set.New[wgtypes.Key]()
 
+	// Determine the next keys of peers that are rotating their keys. These are programmed as additional peers.
+	nextKeyToNodeName := map[wgtypes.Key]string{}
+	for name, node := range w.nodes {
+		if key := w.nextPeerKey(name, node); key != zeroKey {
+			nextKeyToNodeName[key] = name
+		}
+	}
+
 	// Handle nodes that are configured
 	for peerIdx := range device.Peers {
 		key := device.Peers[peerIdx].PublicKey
@@ -1337,7 +1476,21 @@ func (w *Wireguard) constructWireguardDeltaForResync(wireguardClient netlinkshim
 		processedKeys.Add(key)
 
 		logCtx := w.logCtx.WithFields(log.Fields{"publicKey": key, "node": node})
-		if node == nil {
+		if name, ok := nextKeyToNodeName[key]; ok && node == nil {
+			// This is the next key of a peer that is rotating its key. It only has allowed IPs once the peer has
+			// started using it.
+			peer := device.Peers[peerIdx]
+			next := w.nodes[name]
+			expectedPresharedKey := w.presharedKey(publicKey, key)
+			if !allowedIPsMatch(peer.AllowedIPs, next.nextKeyAllowedCidrs()) ||
+				!endpointMatches(peer.Endpoint, next.endpointAddr.AsNetIP(), w.ListeningPort()) ||
+				!presharedKeyMatches(peer.PresharedKey, expectedPresharedKey) {
+				logCtx.WithField("node", name).Info("Next key of peer needs updating")
+				wireguardUpdate.Peers = append(wireguardUpdate.Peers, w.nextPeerConfig(next, key, expectedPresharedKey))
+				wireguardUpdateRequired = true
+			}
+			continue
+		} else if node == nil {
 			logCtx.Info("Peer key is not expected or is associated with multiple nodes")
 			wireguardUpdate.Peers = append(wireguardUpdate.Peers, wgtypes.PeerConfig{
 				PublicKey: key,
@@ -1351,15 +1504,17 @@ func (w *Wireguard) constructWireguardDeltaForResync(wireguardClient netlinkshim
 		configuredAddr := device.Peers[peerIdx].Endpoint
 		replaceCidrs := false
 
-		// Need to check programmed CIDRs against expected to see if any need deleting.
+		// Need to check programmed CIDRs against expected to see if any need deleting. If the peer has started using
+		// its next key then the allowed IPs belong to the next key peer instead.
 		logCtx.Debug("Check programmed CIDRs for required deletions")
-		expectedAllowedCidrs := node.allowedCidrsForWireguard()
+		expectedCidrs := node.currentKeyAllowedCidrs()
+		expectedAllowedCidrs := cidrsToIPNets(expectedCidrs)
 		configuredCidrsAsSet := set.New[ip.CIDR]()
 		var allowedCidrsForUpdateMsg []net.IPNet
 		for _, netCidr := range configuredCidrs {
 			cidr := ip.CIDRFromIPNet(&netCidr)
 			configuredCidrsAsSet.Add(cidr)
-			if !node.cidrs.Contains(cidr) {
+			if !expectedCidrs.Contains(cidr) {
 				// Need to delete an entry, so just replace.
 				logCtx.WithField("cidr", cidr).Info("Unexpected CIDR configured - replace full set of CIDRs")
 				replaceCidrs = true
@@ -1379,11 +1534,13 @@ func (w *Wireguard) constructWireguardDeltaForResync(wireguardClient netlinkshim
 			}
 		}
 
-		// If the CIDRs need replacing or the endpoint address needs updating then update the entry.
+		// If the CIDRs need replacing, or the endpoint address or preshared key needs updating then update the entry.
 		expectedEndpointIP := node.endpointAddr.AsNetIP()
 		replaceEndpointAddr := expectedEndpointIP != nil &&
-			(configuredAddr == nil || configuredAddr.Port != w.ListeningPort() || !configuredAddr.IP.Equal(expectedEndpointIP))
-		if replaceEndpointAddr || allowedCidrsForUpdateMsg != nil {
+			!endpointMatches(configuredAddr, expectedEndpointIP, w.ListeningPort())
+		expectedPresharedKey := w.presharedKey(publicKey, key)
+		replacePresharedKey := !presharedKeyMatches(device.Peers[peerIdx].PresharedKey, expectedPresharedKey)
+		if replaceEndpointAddr || replacePresharedKey || replaceCidrs || allowedCidrsForUpdateMsg != nil {
 			peer := wgtypes.PeerConfig{
 				PublicKey:                   key,
 				UpdateOnly:                  true,
@@ -1396,6 +1553,14 @@ func (w *Wireguard) constructWireguardDeltaForResync(wireguardClient netlinkshim
 				logCtx.Info("Endpoint address needs updating")
 				peer.Endpoint = w.endpointUDPAddr(expectedEndpointIP)
 			}
+			if replacePresharedKey {
+				// Setting the zero key removes the preshared key.
+				logCtx.Info("Preshared key needs updating")
+				peer.PresharedKey = &zeroKey
+				if expectedPresharedKey != nil {
+					peer.PresharedKey = expectedPresharedKey
+				}
+			}
 
 			wireguardUpdate.Peers = append(wireguardUpdate.Peers, peer)
 			wireguardUpdateRequired = true
@@ -1417,13 +1582,31 @@ func (w *Wireguard) constructWireguardDeltaForResync(wireguardClient netlinkshim
 		logCtx.WithField("endpointAddr", node.endpointAddr).Info("Add peer to wireguard")
 		wireguardUpdate.Peers = append(wireguardUpdate.Peers, wgtypes.PeerConfig{
 			PublicKey:                   node.publicKey,
+			PresharedKey:                w.presharedKey(publicKey, node.publicKey),
 			Endpoint:                    w.endpointUDPAddr(node.endpointAddr.AsNetIP()),
-			AllowedIPs:                  node.allowedCidrsForWireguard(),
+			AllowedIPs:                  cidrsToIPNets(node.currentKeyAllowedCidrs()),
 			PersistentKeepaliveInterval: &w.config.PersistentKeepAlive,
 		})
 		wireguardUpdateRequired = true
 	}
 
+	// Handle next keys that are not configured, and record the next keys that will be programmed once this update is
+	// applied.
+	for key, name := range nextKeyToNodeName {
+		if processedKeys.Contains(key) {
+			continue
+		}
+		w.logCtx.WithFields(log.Fields{"nextPublicKey": key, "node": name}).Info("Add next key of peer to wireguard")
+		wireguardUpdate.Peers = append(wireguardUpdate.Peers, w.nextPeerConfig(w.nodes[name], key, w.presharedKey(publicKey, key)))
+		wireguardUpdateRequired = true
+	}
+	for name, node := range w.nodes {
+		node.programmedNextPublicKey = w.nextPeerKey(name, node)
+		if node.programmedNextPublicKey == zeroKey {
+			node.nextKeyActive = false
+		}
+	}
+
 	if wireguardUpdateRequired {
 		return publicKey, &wireguardUpdate, nil
 	}
@@ -1713,6 +1896,229 @@ func (w *Wireguard) shouldProgramWireguardPeer(name string, node *nodeData) bool
 	return true
 }
 
+// nextPeerKey returns the next public key of a peer that is rotating its key, if it should be programmed in wireguard
+// as an additional peer; otherwise it returns the zero key. The next key is only programmed if the peer itself is
+// programmed, and the key is not claimed as the current key of any node.
+func (w *Wireguard) nextPeerKey(name string, node *nodeData) wgtypes.Key {
+	if node.nextPublicKey == zeroKey || node.nextPublicKey == node.publicKey || !w.shouldProgramWireguardPeer(name, node) {
+		return zeroKey
+	}
+	if w.publicKeyToNodeNames[node.nextPublicKey] != nil || node.nextPublicKey == w.devicePublicKey {
+		w.logCtx.WithField("node", name).Warn("Next public key of peer is claimed by another node - not programming it")
+		return zeroKey
+	}
+	return node.nextPublicKey
+}
+
+// nextPeerDelta returns the wireguard peer updates needed to bring the programmed next key of a peer in line with the
+// expected next key, and records the expected key as programmed.
+//
+// When the peer switches over and publishes its next key as its current key, the programmed next key becomes the
+// current key and the allowed IPs move over to it in the same way as for any other key change. Since the peer entry
+// already exists, any session that the peer established with the new key is kept.
+func (w *Wireguard) nextPeerDelta(name string, node *nodeData, update *nodeUpdateData) (peers []wgtypes.PeerConfig) {
+	expected := w.nextPeerKey(name, node)
+	programmed := node.programmedNextPublicKey
+	if programmed != zeroKey && programmed != expected && programmed != node.publicKey {
+		w.logCtx.WithFields(log.Fields{"node": name, "nextPublicKey": programmed}).Debug("Removing next key of peer")
+		peers = append(peers, wgtypes.PeerConfig{
+			PublicKey: programmed,
+			Remove:    true,
+		})
+	}
+	if expected != zeroKey && (expected != programmed || update.endpointAddr != nil) {
+		w.logCtx.WithFields(log.Fields{"node": name, "nextPublicKey": expected}).Debug("Adding or updating next key of peer")
+		peers = append(peers, w.nextPeerConfig(node, expected, w.presharedKey(w.devicePublicKey, expected)))
+	}
+	node.programmedNextPublicKey = expected
+	if expected == zeroKey {
+		node.nextKeyActive = false
+	}
+	return peers
+}
+
+// nextPeerConfig returns the wireguard configuration for the next key of a peer. This has the same endpoint as the
+// peer but, until the peer starts using the key, no allowed IPs, so we never route to it; it is only there so that the
+// peer's handshake succeeds as soon as it starts using the key. For the same reason it has no persistent keepalive.
+func (w *Wireguard) nextPeerConfig(node *nodeData, nextPublicKey wgtypes.Key, presharedKey *wgtypes.Key) wgtypes.PeerConfig {
+	return wgtypes.PeerConfig{
+		PublicKey:         nextPublicKey,
+		PresharedKey:      presharedKey,
+		Endpoint:          w.endpointUDPAddr(node.endpointAddr.AsNetIP()),
+		ReplaceAllowedIPs: true,
+		AllowedIPs:        cidrsToIPNets(node.nextKeyAllowedCidrs()),
+	}
+}
+
+// updateNextKeyActive checks whether any peer that is rotating its key has started using its next key. Once the peer
+// switches, it can only be reached using its next key, and traffic from it arrives on the next key peer, so the allowed
+// IPs must move over. We know that the peer has switched once it has completed a handshake with the next key peer;
+// we never initiate one ourselves since we don't route to it. Moving the allowed IPs, and any other update to a peer
+// in this state, is handled by a resync.
+func (w *Wireguard) updateNextKeyActive(wireguardClient netlinkshim.Wireguard) error {
+	var handshakes map[wgtypes.Key]time.Time
+	for name, node := range w.nodes {
+		if node.nextKeyActive {
+			if _, ok := w.nodeUpdates[name]; ok {
+				w.inSyncWireguard = false
+			}
+			continue
+		}
+		if node.programmedNextPublicKey == zeroKey || node.programmedNextPublicKey != w.nextPeerKey(name, node) {
+			// Either there is no next key programmed, or it is about to be removed or become the current key.
+			continue
+		}
+		if handshakes == nil {
+			device, err := wireguardClient.DeviceByName(w.interfaceName)
+			if err != nil {
+				return err
+			}
+			handshakes = map[wgtypes.Key]time.Time{}
+			for _, peer := range device.Peers {
+				handshakes[peer.PublicKey] = peer.LastHandshakeTime
+			}
+		}
+		if !handshakes[node.programmedNextPublicKey].IsZero() {
+			w.logCtx.WithFields(log.Fields{"node": name, "nextPublicKey": node.programmedNextPublicKey}).Info(
+				"Peer has started using its next key, moving its allowed IPs over")
+			node.nextKeyActive = true
+			w.inSyncWireguard = false
+		}
+	}
+	return nil
+}
+
+// resetNextKeyActive is called when either of the keys of a peer changes. If the peer had started using its next key
+// then its allowed IPs are programmed on the next key peer, and moving them back is handled by a resync.
+func (w *Wireguard) resetNextKeyActive(node *nodeData) {
+	if node.nextKeyActive {
+		node.nextKeyActive = false
+		w.inSyncWireguard = false
+	}
+}
+
+// RescheduleAfter returns how long to wait before Apply should be called again even if nothing else changes, or zero if
+// there is no need. While a peer's next key is programmed, we poll for the peer's first handshake using it.
+func (w *Wireguard) RescheduleAfter() time.Duration {
+	for _, node := range w.nodes {
+		if node.programmedNextPublicKey != zeroKey && !node.nextKeyActive {
+			return nextKeyHandshakePollInterval
+		}
+	}
+	return 0
+}
+
+// presharedKey returns the preshared key to use between us and the peer with the given public keys, or nil if
+// preshared keys are not configured.
+func (w *Wireguard) presharedKey(ourPublicKey, peerPublicKey wgtypes.Key) *wgtypes.Key {
+	if len(w.config.PresharedKeySecret) == 0 {
+		return nil
+	}
+	key := derivePresharedKey(w.config.PresharedKeySecret, ourPublicKey, peerPublicKey)
+	return &key
+}
+
+// derivePresharedKey derives a preshared key for a pair of peers from the cluster secret. The public keys are sorted
+// before they are hashed so that both peers derive the same key, and since the keys are included the preshared key
+// changes whenever either peer rotates its key.
+func derivePresharedKey(secret []byte, publicKeyA, publicKeyB wgtypes.Key) wgtypes.Key {
+	if bytes.Compare(publicKeyA[:], publicKeyB[:]) > 0 {
+		publicKeyA, publicKeyB = publicKeyB, publicKeyA
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(presharedKeyContext))
+	mac.Write(publicKeyA[:])
+	mac.Write(publicKeyB[:])
+
+	var key wgtypes.Key
+	copy(key[:], mac.Sum(nil))
+	return key
+}
+
+// presharedKeyMatches returns true if the preshared key programmed for a peer matches the expected one (where nil means
+// no preshared key).
+func presharedKeyMatches(programmed wgtypes.Key, expected *wgtypes.Key) bool {
+	if expected == nil {
+		return programmed == zeroKey
+	}
+	return programmed == *expected
+}
+
+// allowedIPsMatch returns true if the allowed IPs programmed for a peer match the expected set of CIDRs.
+func allowedIPsMatch(programmed []net.IPNet, expected set.Set[ip.CIDR]) bool {
+	if len(programmed) != expected.Len() {
+		return false
+	}
+	for _, ipNet := range programmed {
+		if !expected.Contains(ip.CIDRFromIPNet(&ipNet)) {
+			return false
+		}
+	}
+	return true
+}
+
+// endpointMatches returns true if the endpoint programmed for a peer matches the expected IP and port.
+func endpointMatches(programmed *net.UDPAddr, expectedIP net.IP, expectedPort int) bool {
+	if expectedIP == nil {
+		return programmed == nil
+	}
+	return programmed != nil && programmed.Port == expectedPort && programmed.IP.Equal(expectedIP)
+}
+
+// maybeRotateKey progresses our key rotation, if key rotation is enabled. A rotation happens in two phases:
+//   - Once the current key is older than the rotation interval, generate the next key pair and publish the next public
+//     key alongside our current one. Peers add the next key as an additional peer when they see it.
+//   - Once the next key has been published for the grace period, switch the device over to it. The switch is made by a
+//     resync, after which the new public key is published as our current key.
+func (w *Wireguard) maybeRotateKey() {
+	if w.config.KeyRotationInterval <= 0 || w.keyUpdatedAt.IsZero() || w.switchToNextKey {
+		return
+	}
+
+	if w.nextPrivateKey == nil {
+		if w.time.Since(w.keyUpdatedAt) < w.config.KeyRotationInterval {
+			return
+		}
+		w.logCtx.Info("Key rotation interval has passed, generating the next private/public key pair")
+		key, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			w.logCtx.WithError(err).Error("error generating next private-key")
+			return
+		}
+		w.nextPrivateKey = &key
+		w.nextKeyPublishedAt = time.Time{}
+		w.ourPublicKeyAgreesWithDataplaneMsg = false
+		return
+	}
+
+	if !w.nextKeyPublishedAt.IsZero() && w.time.Since(w.nextKeyPublishedAt) >= keyRotationGracePeriod {
+		w.logCtx.Info("Key rotation grace period has passed, switching to the next key")
+		w.switchToNextKey = true
+		w.inSyncWireguard = false
+	}
+}
+
+// trackDeviceKey is called with the public key of the device after each successful resync. If the key has changed
+// (because we switched to our next key, or the key was regenerated) then any rotation in progress is finished and the
+// rotation interval restarts.
+func (w *Wireguard) trackDeviceKey(publicKey wgtypes.Key) {
+	if publicKey == w.devicePublicKey {
+		return
+	}
+	w.logCtx.WithField("publicKey", publicKey).Debug("Device public key updated")
+	w.devicePublicKey = publicKey
+	if w.config.KeyRotationInterval <= 0 {
+		return
+	}
+	w.keyUpdatedAt = w.time.Now()
+	if w.nextPrivateKey != nil {
+		w.nextPrivateKey = nil
+		w.nextKeyPublishedAt = time.Time{}
+		w.ourPublicKeyAgreesWithDataplaneMsg = false
+	}
+	w.switchToNextKey = false
+}
+
 // getWireguardClient returns a wireguard client for managing wireguard devices.
 func (w *Wireguard) getWireguardClient() (netlinkshim.Wireguard, error) {
 	if w.cachedWireguardClient == nil {
@@ -15,6 +15,9 @@
 package wireguard_test
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"net"
@@ -172,6 +175,7 @@ type mockCallbacks struct {
 	numStatusCallbacks int
 	statusErr          error
 	statusKey          wgtypes.Key
+	statusNextKey      wgtypes.Key
 
 	numProcSysCallbacks int
 	procSysPath         string
@@ -179,13 +183,14 @@ type mockCallbacks struct {
 	procSysErr          error
 }
 
-func (m *mockCallbacks) status(publicKey wgtypes.Key) error {
-	log.Debugf("Status update with public key: %s", publicKey)
+func (m *mockCallbacks) status(publicKey, nextPublicKey wgtypes.Key) error {
+	log.Debugf("Status update with public key: %s, next public key: %s", publicKey, nextPublicKey)
 	m.numStatusCallbacks++
 	if m.statusErr != nil {
 		return m.statusErr
 	}
 	m.statusKey = publicKey
+	m.statusNextKey = nextPublicKey
 
 	log.Debugf("Num callbacks: %d", m.numStatusCallbacks)
 	return nil
@@ -3742,3 +3747,410 @@ var _ = Describe("Wireguard (with no table index)", func() {
 		Expect(func() { wgFn(true, 7) }).To(Panic())
 	})
 })
+
+// expectedPresharedKey independently derives the preshared key that both peers should program.
+func expectedPresharedKey(secret []byte, publicKeyA, publicKeyB wgtypes.Key) wgtypes.Key {
+	if bytes.Compare(publicKeyA[:], publicKeyB[:]) > 0 {
+		publicKeyA, publicKeyB = publicKeyB, publicKeyA
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("calico-wireguard-psk"))
+	mac.Write(publicKeyA[:])
+	mac.Write(publicKeyB[:])
+	var key wgtypes.Key
+	copy(key[:], mac.Sum(nil))
+	return key
+}
+
+var _ = Describe("Wireguard (with key rotation and preshared keys)", func() {
+	var wgDataplane, rtDataplane, rrDataplane *mocknetlink.MockNetlinkDataplane
+	var t *mocktime.MockTime
+	var s *mockCallbacks
+	var wg *Wireguard
+	var link *mocknetlink.MockLink
+	var key_peer1, key_peer2 wgtypes.Key
+	secret := []byte("cluster-secret")
+
+	BeforeEach(func() {
+		wgDataplane = mocknetlink.New()
+		rtDataplane = mocknetlink.New()
+		rrDataplane = mocknetlink.New()
+		s = &mockCallbacks{}
+		t = mocktime.New()
+		t.SetAutoIncrement(11 * time.Second)
+
+		wg = NewWithShims(
+			hostname,
+			&Config{
+				Enabled:             true,
+				ListeningPort:       listeningPort,
+				FirewallMark:        int(firewallMark),
+				RoutingRulePriority: rulePriority,
+				RoutingTableIndex:   tableIndex,
+				InterfaceName:       ifaceName,
+				MTU:                 mtu,
+				KeyRotationInterval: time.Hour,
+				PresharedKeySecret:  secret,
+			},
+			4,
+			rtDataplane.NewMockNetlink,
+			rrDataplane.NewMockNetlink,
+			wgDataplane.NewMockNetlink,
+			wgDataplane.NewMockWireguard,
+			10*time.Second,
+			t,
+			FelixRouteProtocol,
+			s.status,
+			s.writeProcSys,
+			logutils.NewSummarizer("test loop"),
+			&environment.FakeFeatureDetector{
+				Features: environment.Features{
+					KernelSideRouteFiltering: true,
+				},
+			},
+		)
+
+		Expect(wg.Apply()).To(Equal(ErrWaitingForLink))
+		wgDataplane.SetIface(ifaceName, true, true)
+		rtDataplane.AddIface(101, ifaceName, true, true)
+		wg.OnIfaceStateChanged(ifaceName, 101, ifacemonitor.StateUp)
+		Expect(wg.Apply()).NotTo(HaveOccurred())
+		Expect(s.numStatusCallbacks).To(Equal(1))
+		Expect(s.statusNextKey).To(Equal(zeroKey))
+
+		wg.EndpointWireguardUpdate(hostname, s.statusKey, nil)
+		key_peer1 = mustGeneratePrivateKey().PublicKey()
+		wg.EndpointWireguardUpdate(peer1, key_peer1, nil)
+		wg.EndpointUpdate(peer1, ipv4_peer1)
+		wg.RouteUpdate(peer1, cidr_1)
+		key_peer2 = mustGeneratePrivateKey().PublicKey()
+		wg.EndpointWireguardUpdate(peer2, key_peer2, nil)
+		wg.EndpointUpdate(peer2, ipv4_peer2)
+		wg.RouteUpdate(peer2, cidr_2)
+		Expect(wg.Apply()).NotTo(HaveOccurred())
+		link = wgDataplane.NameToLink[ifaceName]
+		Expect(link).ToNot(BeNil())
+	})
+
+	It("should program the derived preshared key for each peer", func() {
+		Expect(link.WireguardPeers).To(HaveLen(2))
+		ourKey := link.WireguardPrivateKey.PublicKey()
+		psk1 := expectedPresharedKey(secret, ourKey, key_peer1)
+		Expect(psk1).To(Equal(expectedPresharedKey(secret, key_peer1, ourKey)))
+		Expect(link.WireguardPeers[key_peer1].PresharedKey).To(Equal(psk1))
+		Expect(link.WireguardPeers[key_peer2].PresharedKey).To(Equal(expectedPresharedKey(secret, ourKey, key_peer2)))
+		Expect(link.WireguardPeers[key_peer1].PresharedKey).NotTo(Equal(link.WireguardPeers[key_peer2].PresharedKey))
+	})
+
+	It("should fix an incorrect preshared key on resync", func() {
+		peer := link.WireguardPeers[key_peer1]
+		peer.PresharedKey = zeroKey
+		link.WireguardPeers[key_peer1] = peer
+		wg.QueueResync()
+		Expect(wg.Apply()).NotTo(HaveOccurred())
+		ourKey := link.WireguardPrivateKey.PublicKey()
+		Expect(link.WireguardPeers[key_peer1].PresharedKey).To(Equal(expectedPresharedKey(secret, ourKey, key_peer1)))
+	})
+
+	Describe("a peer publishes its next key", func() {
+		var nextKey_peer1 wgtypes.Key
+
+		BeforeEach(func() {
+			nextKey_peer1 = mustGeneratePrivateKey().PublicKey()
+			wg.EndpointWireguardNextKeyUpdate(peer1, nextKey_peer1)
+			Expect(wg.Apply()).NotTo(HaveOccurred())
+		})
+
+		It("should program the next key as an additional peer with no allowed IPs", func() {
+			Expect(link.WireguardPeers).To(HaveLen(3))
+			Expect(link.WireguardPeers[key_peer1].AllowedIPs).To(ConsistOf(cidr_1.ToIPNet()))
+			ourKey := link.WireguardPrivateKey.PublicKey()
+			Expect(link.WireguardPeers[nextKey_peer1]).To(Equal(wgtypes.Peer{
+				PublicKey:    nextKey_peer1,
+				PresharedKey: expectedPresharedKey(secret, ourKey, nextKey_peer1),
+				Endpoint: &net.UDPAddr{
+					IP:   ipv4_peer1.AsNetIP(),
+					Port: listeningPort,
+				},
+			}))
+		})
+
+		It("should retain the next key peer over a resync", func() {
+			wg.QueueResync()
+			Expect(wg.Apply()).NotTo(HaveOccurred())
+			Expect(link.WireguardPeers).To(HaveLen(3))
+			Expect(link.WireguardPeers).To(HaveKey(nextKey_peer1))
+			Expect(link.WireguardPeers[nextKey_peer1].AllowedIPs).To(BeEmpty())
+		})
+
+		It("should move the allowed IPs over when the peer switches to its next key", func() {
+			wg.EndpointWireguardUpdate(peer1, nextKey_peer1, nil)
+			wg.EndpointWireguardNextKeyUpdate(peer1, zeroKey)
+			Expect(wg.Apply()).NotTo(HaveOccurred())
+			Expect(link.WireguardPeers).To(HaveLen(2))
+			Expect(link.WireguardPeers).NotTo(HaveKey(key_peer1))
+			Expect(link.WireguardPeers[nextKey_peer1].AllowedIPs).To(ConsistOf(cidr_1.ToIPNet()))
+		})
+
+		It("should remove the next key peer if the peer abandons the rotation", func() {
+			wg.EndpointWireguardNextKeyUpdate(peer1, zeroKey)
+			Expect(wg.Apply()).NotTo(HaveOccurred())
+			Expect(link.WireguardPeers).To(HaveLen(2))
+			Expect(link.WireguardPeers).NotTo(HaveKey(nextKey_peer1))
+		})
+	})
+
+	It("should not rotate the key before the rotation interval", func() {
+		key := link.WireguardPrivateKey
+		t.IncrementTime(30 * time.Minute)
+		Expect(wg.Apply()).NotTo(HaveOccurred())
+		Expect(s.statusNextKey).To(Equal(zeroKey))
+		Expect(link.WireguardPrivateKey).To(Equal(key))
+	})
+
+	Describe("the rotation interval passes", func() {
+		var oldKey wgtypes.Key
+
+		BeforeEach(func() {
+			oldKey = link.WireguardPrivateKey
+			t.IncrementTime(time.Hour)
+			Expect(wg.Apply()).NotTo(HaveOccurred())
+		})
+
+		It("should publish the next key but keep using the current key", func() {
+			Expect(s.statusKey).To(Equal(oldKey.PublicKey()))
+			Expect(s.statusNextKey).NotTo(Equal(zeroKey))
+			Expect(s.statusNextKey).NotTo(Equal(s.statusKey))
+			Expect(link.WireguardPrivateKey).To(Equal(oldKey))
+
+			// Still within the grace period.
+			Expect(wg.Apply()).NotTo(HaveOccurred())
+			Expect(link.WireguardPrivateKey).To(Equal(oldKey))
+		})
+
+		It("should switch to the next key after the grace period", func() {
+			nextKey := s.statusNextKey
+			t.IncrementTime(2 * time.Minute)
+			Expect(wg.Apply()).NotTo(HaveOccurred())
+			Expect(link.WireguardPrivateKey.PublicKey()).To(Equal(nextKey))
+			Expect(s.statusKey).To(Equal(nextKey))
+			Expect(s.statusNextKey).To(Equal(zeroKey))
+
+			// The preshared keys are derived from the new key.
+			Expect(link.WireguardPeers).To(HaveLen(2))
+			Expect(link.WireguardPeers[key_peer1].PresharedKey).To(Equal(expectedPresharedKey(secret, nextKey, key_peer1)))
+			Expect(link.WireguardPeers[key_peer1].AllowedIPs).To(ConsistOf(cidr_1.ToIPNet()))
+			Expect(link.WireguardPeers[key_peer2].PresharedKey).To(Equal(expectedPresharedKey(secret, nextKey, key_peer2)))
+		})
+	})
+
+	It("should not switch to the next key until it has been published", func() {
+		oldKey := link.WireguardPrivateKey
+		s.statusErr = errors.New("failed to publish")
+		t.IncrementTime(time.Hour)
+		Expect(wg.Apply()).To(HaveOccurred())
+		t.IncrementTime(time.Hour)
+		Expect(wg.Apply()).To(HaveOccurred())
+		Expect(link.WireguardPrivateKey).To(Equal(oldKey))
+
+		// Once published, the grace period starts.
+		s.statusErr = nil
+		Expect(wg.Apply()).NotTo(HaveOccurred())
+		Expect(s.statusNextKey).NotTo(Equal(zeroKey))
+		Expect(link.WireguardPrivateKey).To(Equal(oldKey))
+		t.IncrementTime(2 * time.Minute)
+		Expect(wg.Apply()).NotTo(HaveOccurred())
+		Expect(link.WireguardPrivateKey.PublicKey()).To(Equal(s.statusKey))
+		Expect(s.statusNextKey).To(Equal(zeroKey))
+	})
+})
+
+// rotationTestNode is one of a pair of nodes, each with its own wireguard dataplane, used to check that traffic keeps
+// flowing while one of them rotates its key.
+type rotationTestNode struct {
+	name        string
+	addr        ip.Addr
+	cidr        ip.CIDR
+	wg          *Wireguard
+	wgDataplane *mocknetlink.MockNetlinkDataplane
+	status      *mockCallbacks
+}
+
+func newRotationTestNode(name string, addr ip.Addr, cidr ip.CIDR, t *mocktime.MockTime, rotationInterval time.Duration) *rotationTestNode {
+	n := &rotationTestNode{
+		name:        name,
+		addr:        addr,
+		cidr:        cidr,
+		wgDataplane: mocknetlink.New(),
+		status:      &mockCallbacks{},
+	}
+	rtDataplane := mocknetlink.New()
+	rrDataplane := mocknetlink.New()
+	n.wg = NewWithShims(
+		name,
+		&Config{
+			Enabled:             true,
+			ListeningPort:       listeningPort,
+			FirewallMark:        int(firewallMark),
+			RoutingRulePriority: rulePriority,
+			RoutingTableIndex:   tableIndex,
+			InterfaceName:       ifaceName,
+			MTU:                 mtu,
+			KeyRotationInterval: rotationInterval,
+		},
+		4,
+		rtDataplane.NewMockNetlink,
+		rrDataplane.NewMockNetlink,
+		n.wgDataplane.NewMockNetlink,
+		n.wgDataplane.NewMockWireguard,
+		10*time.Second,
+		t,
+		FelixRouteProtocol,
+		n.status.status,
+		n.status.writeProcSys,
+		logutils.NewSummarizer("test loop"),
+		&environment.FakeFeatureDetector{
+			Features: environment.Features{
+				KernelSideRouteFiltering: true,
+			},
+		},
+	)
+	Expect(n.wg.Apply()).To(Equal(ErrWaitingForLink))
+	n.wgDataplane.SetIface(ifaceName, true, true)
+	rtDataplane.AddIface(101, ifaceName, true, true)
+	n.wg.OnIfaceStateChanged(ifaceName, 101, ifacemonitor.StateUp)
+	Expect(n.wg.Apply()).NotTo(HaveOccurred())
+	return n
+}
+
+func (n *rotationTestNode) link() *mocknetlink.MockLink {
+	return n.wgDataplane.NameToLink[ifaceName]
+}
+
+// learn passes the published keys of the other node to this node, as the datastore would.
+func (n *rotationTestNode) learn(other *rotationTestNode) {
+	n.wg.EndpointWireguardUpdate(other.name, other.status.statusKey, nil)
+	n.wg.EndpointWireguardNextKeyUpdate(other.name, other.status.statusNextKey)
+}
+
+// send returns true if a packet from this node's CIDR to the other node's CIDR gets through. We route the packet to the
+// peer whose allowed IPs include the destination, and the handshake only succeeds if that is the other node's current
+// key and the other node has a peer for our current key. The other node then only accepts the packet if that peer's
+// allowed IPs include the source. A successful handshake is recorded on the other node's peer.
+func (n *rotationTestNode) send(other *rotationTestNode) bool {
+	var via *wgtypes.Peer
+	for _, peer := range n.link().WireguardPeers {
+		for _, allowed := range peer.AllowedIPs {
+			if ip.CIDRFromIPNet(&allowed) == other.cidr {
+				via = &peer
+			}
+		}
+	}
+	if via == nil || via.PublicKey != other.link().WireguardPublicKey {
+		return false
+	}
+	ourKey := n.link().WireguardPublicKey
+	peer, ok := other.link().WireguardPeers[ourKey]
+	if !ok {
+		return false
+	}
+	peer.LastHandshakeTime = time.Now()
+	other.link().WireguardPeers[ourKey] = peer
+	for _, allowed := range peer.AllowedIPs {
+		if ip.CIDRFromIPNet(&allowed) == n.cidr {
+			return true
+		}
+	}
+	return false
+}
+
+var _ = Describe("Wireguard (rotating a key while traffic flows)", func() {
+	var t *mocktime.MockTime
+	var a, b *rotationTestNode
+
+	BeforeEach(func() {
+		t = mocktime.New()
+		a = newRotationTestNode(hostname, ipv4_host, cidr_1, t, time.Hour)
+		b = newRotationTestNode(peer1, ipv4_peer1, cidr_2, t, 0)
+		for _, n := range []*rotationTestNode{a, b} {
+			n.wg.EndpointWireguardUpdate(n.name, n.status.statusKey, nil)
+		}
+		a.learn(b)
+		a.wg.EndpointUpdate(b.name, b.addr)
+		a.wg.RouteUpdate(b.name, b.cidr)
+		b.learn(a)
+		b.wg.EndpointUpdate(a.name, a.addr)
+		b.wg.RouteUpdate(a.name, a.cidr)
+		Expect(a.wg.Apply()).NotTo(HaveOccurred())
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		Expect(a.send(b)).To(BeTrue())
+		Expect(b.send(a)).To(BeTrue())
+	})
+
+	It("should keep traffic flowing in both directions", func() {
+		oldKey := a.status.statusKey
+
+		By("publishing the next key")
+		t.IncrementTime(time.Hour)
+		Expect(a.wg.Apply()).NotTo(HaveOccurred())
+		Expect(a.status.statusNextKey).NotTo(Equal(zeroKey))
+		nextKey := a.status.statusNextKey
+		b.learn(a)
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		Expect(b.link().WireguardPeers).To(HaveKey(nextKey))
+		Expect(b.wg.RescheduleAfter()).To(Equal(time.Second))
+		Expect(a.send(b)).To(BeTrue())
+		Expect(b.send(a)).To(BeTrue())
+
+		By("switching to the next key before the peer has heard that we switched")
+		t.IncrementTime(2 * time.Minute)
+		Expect(a.wg.Apply()).NotTo(HaveOccurred())
+		Expect(a.link().WireguardPublicKey).To(Equal(nextKey))
+
+		// The handshake succeeds, but the peer drops the traffic until it has moved the allowed IPs over.
+		Expect(a.send(b)).To(BeFalse())
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		Expect(b.link().WireguardPeers[nextKey].AllowedIPs).To(ConsistOf(a.cidr.ToIPNet()))
+		Expect(b.link().WireguardPeers[oldKey].AllowedIPs).To(BeEmpty())
+		Expect(b.wg.RescheduleAfter()).To(BeZero())
+		Expect(a.send(b)).To(BeTrue())
+		Expect(b.send(a)).To(BeTrue())
+
+		// A resync leaves the allowed IPs on the next key.
+		b.wg.QueueResync()
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		Expect(a.send(b)).To(BeTrue())
+		Expect(b.send(a)).To(BeTrue())
+
+		By("publishing the next key as the current key")
+		Expect(a.status.statusKey).To(Equal(nextKey))
+		Expect(a.status.statusNextKey).To(Equal(zeroKey))
+		b.learn(a)
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		Expect(b.link().WireguardPeers).To(HaveLen(1))
+		Expect(b.link().WireguardPeers[nextKey].AllowedIPs).To(ConsistOf(a.cidr.ToIPNet()))
+		Expect(a.send(b)).To(BeTrue())
+		Expect(b.send(a)).To(BeTrue())
+	})
+
+	It("should move the allowed IPs back if the peer abandons the rotation after switching", func() {
+		t.IncrementTime(time.Hour)
+		Expect(a.wg.Apply()).NotTo(HaveOccurred())
+		nextKey := a.status.statusNextKey
+		b.learn(a)
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		t.IncrementTime(2 * time.Minute)
+		Expect(a.wg.Apply()).NotTo(HaveOccurred())
+		a.send(b)
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		Expect(b.link().WireguardPeers[nextKey].AllowedIPs).To(ConsistOf(a.cidr.ToIPNet()))
+
+		b.wg.EndpointWireguardNextKeyUpdate(a.name, zeroKey)
+		Expect(b.wg.Apply()).NotTo(HaveOccurred())
+		Expect(b.link().WireguardPeers).NotTo(HaveKey(nextKey))
+		Expect(b.link().WireguardPeers).To(HaveLen(1))
+		for _, peer := range b.link().WireguardPeers {
+			Expect(peer.AllowedIPs).To(ConsistOf(a.cidr.ToIPNet()))
+		}
+	})
+})
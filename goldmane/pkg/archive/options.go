@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"time"
+)
+
+type Option func(*SegmentStore)
+
+// WithMaxAge sets the maximum age of segments to retain. Segments whose time range ended longer ago
+// than this are removed. A zero value disables age-based retention.
+func WithMaxAge(d time.Duration) Option {
+	return func(s *SegmentStore) {
+		s.maxAge = d
+	}
+}
+
+// WithMaxSize sets the maximum total size in bytes of segments to retain. When exceeded, the oldest
+// segments are removed first. A zero value disables size-based retention.
+func WithMaxSize(bytes int64) Option {
+	return func(s *SegmentStore) {
+		s.maxSize = bytes
+	}
+}
+
+// WithNowFunc allows overriding the current time, used in tests.
+func WithNowFunc(f func() time.Time) Option {
+	return func(s *SegmentStore) {
+		s.nowFunc = f
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	"github.com/projectcalico/calico/goldmane/pkg/types"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+const (
+	// segmentSuffix is the file extension used for segment files.
+	segmentSuffix = ".seg"
+
+	// tmpSuffix is the file extension used for segment files that are still being written.
+	tmpSuffix = ".tmp"
+)
+
+// segment describes a single segment file on disk. Each segment holds the contents of a single
+// aggregation bucket, and is named after the time range that the bucket covers.
+type segment struct {
+	start int64
+	end   int64
+	size  int64
+	path  string
+}
+
+// SegmentStore is an implementation of storage.Archive that persists each bucket of flows as a
+// segment file within a directory. Segments contain length-delimited proto.Flow messages, and
+// are pruned based on their age and the total size of the directory.
+type SegmentStore struct {
+	sync.Mutex
+
+	dir     string
+	maxAge  time.Duration
+	maxSize int64
+	nowFunc func() time.Time
+
+	// segments is the set of segments currently on disk, sorted by start time.
+	segments []segment
+}
+
+// NewSegmentStore returns a SegmentStore that persists segments within the given directory, creating it if needed.
+// Any segments already present in the directory are loaded so that they can be served.
+func NewSegmentStore(dir string, opts ...Option) (*SegmentStore, error) {
+	s := &SegmentStore{
+		dir:     dir,
+		nowFunc: time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create flow storage directory: %w", err)
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"dir":      dir,
+		"segments": len(s.segments),
+		"maxAge":   s.maxAge,
+		"maxSize":  s.maxSize,
+	}).Info("Initialized flow segment store")
+	return s, nil
+}
+
+// load populates the set of known segments from the files in the store's directory.
+func (s *SegmentStore) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read flow storage directory: %w", err)
+	}
+
+	s.segments = nil
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(s.dir, e.Name())
+		if strings.HasSuffix(e.Name(), tmpSuffix) {
+			// Left over from an interrupted write - clean it up.
+			logrus.WithField("path", path).Info("Removing incomplete segment")
+			_ = os.Remove(path)
+			continue
+		}
+		start, end, ok := parseSegmentName(e.Name())
+		if !ok {
+			logrus.WithField("path", path).Debug("Ignoring unrecognized file in flow storage directory")
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		s.segments = append(s.segments, segment{start: start, end: end, size: info.Size(), path: path})
+	}
+	sort.Slice(s.segments, func(i, j int) bool {
+		return s.segments[i].start < s.segments[j].start
+	})
+	return nil
+}
+
+// Write persists the given flows as the segment covering [start, end), replacing any existing segment for
+// the same time range, and then applies retention limits.
+func (s *SegmentStore) Write(start, end int64, flows []*types.Flow) error {
+	s.Lock()
+	defer s.Unlock()
+
+	path := filepath.Join(s.dir, segmentName(start, end))
+	size, err := writeSegment(path, flows)
+	if err != nil {
+		return err
+	}
+
+	seg := segment{start: start, end: end, size: size, path: path}
+	idx := sort.Search(len(s.segments), func(i int) bool {
+		return s.segments[i].start >= start
+	})
+	if idx < len(s.segments) && s.segments[idx].start == start {
+		s.segments[idx] = seg
+	} else {
+		s.segments = append(s.segments[:idx], append([]segment{seg}, s.segments[idx:]...)...)
+	}
+
+	s.prune()
+	return nil
+}
+
+// Read calls fn for each flow in segments that start within [startGte, startLt).
+func (s *SegmentStore) Read(startGte, startLt int64, fn func(*types.Flow) error) error {
+	s.Lock()
+	segments := make([]segment, 0, len(s.segments))
+	for _, seg := range s.segments {
+		if (startGte == 0 || seg.start >= startGte) && (startLt == 0 || seg.start < startLt) {
+			segments = append(segments, seg)
+		}
+	}
+	s.Unlock()
+
+	for _, seg := range segments {
+		if err := readSegment(seg, fn); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// The segment was pruned after we took our copy of the list.
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// prune removes segments that exceed the configured retention limits, oldest first.
+// Must be called with the lock held.
+func (s *SegmentStore) prune() {
+	var total int64
+	for _, seg := range s.segments {
+		total += seg.size
+	}
+
+	cutoff := int64(0)
+	if s.maxAge > 0 {
+		cutoff = s.nowFunc().Add(-s.maxAge).Unix()
+	}
+
+	for len(s.segments) > 0 {
+		oldest := s.segments[0]
+		expired := cutoff != 0 && oldest.end <= cutoff
+		oversized := s.maxSize > 0 && total > s.maxSize
+		if !expired && !oversized {
+			break
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"path":      oldest.path,
+			"expired":   expired,
+			"oversized": oversized,
+		}).Debug("Removing segment due to retention limits")
+		if err := os.Remove(oldest.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.WithError(err).WithField("path", oldest.path).Warn("Failed to remove segment")
+			return
+		}
+		total -= oldest.size
+		s.segments = s.segments[1:]
+	}
+}
+
+// writeSegment writes the given flows to a new segment file at path, returning its size. The file is
+// written to a temporary location first and renamed into place so that readers never see a partial segment.
+func writeSegment(path string, flows []*types.Flow) (int64, error) {
+	tmp := path + tmpSuffix
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create segment: %w", err)
+	}
+	defer func() {
+		// No-op if the file has already been renamed into place.
+		_ = os.Remove(tmp)
+	}()
+
+	w := bufio.NewWriter(f)
+	pf := &proto.Flow{}
+	for _, flow := range flows {
+		types.FlowIntoProto(flow, pf)
+		if _, err := protodelim.MarshalTo(w, pf); err != nil {
+			_ = f.Close()
+			return 0, fmt.Errorf("failed to write segment: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return 0, fmt.Errorf("failed to write segment: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return 0, fmt.Errorf("failed to sync segment: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, fmt.Errorf("failed to commit segment: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// readSegment calls fn for each flow in the given segment. Each flow is assigned the time range of the segment.
+func readSegment(seg segment, fn func(*types.Flow) error) error {
+	f, err := os.Open(seg.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		pf := &proto.Flow{}
+		if err := protodelim.UnmarshalFrom(r, pf); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read segment %s: %w", seg.path, err)
+		}
+		flow := types.ProtoToFlow(pf)
+		flow.StartTime = seg.start
+		flow.EndTime = seg.end
+		if err := fn(flow); err != nil {
+			return err
+		}
+	}
+}
+
+// segmentName returns the file name for a segment covering [start, end).
+func segmentName(start, end int64) string {
+	return fmt.Sprintf("%d-%d%s", start, end, segmentSuffix)
+}
+
+// parseSegmentName extracts the time range from a segment file name.
+func parseSegmentName(name string) (int64, int64, bool) {
+	base, ok := strings.CutSuffix(name, segmentSuffix)
+	if !ok {
+		return 0, 0, false
+	}
+	startStr, endStr, ok := strings.Cut(base, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	end, err := strconv.ParseInt(endStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, end, true
+}
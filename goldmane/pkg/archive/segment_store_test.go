@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unique"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectcalico/calico/goldmane/pkg/archive"
+	"github.com/projectcalico/calico/goldmane/pkg/types"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+func testFlow(name string, bytes int64) *types.Flow {
+	return &types.Flow{
+		Key: types.NewFlowKey(
+			&types.FlowKeySource{
+				SourceName:      name,
+				SourceNamespace: "test-ns",
+				SourceType:      proto.EndpointType_WorkloadEndpoint,
+			},
+			&types.FlowKeyDestination{
+				DestName:      "test-dst",
+				DestNamespace: "test-dst-ns",
+				DestType:      proto.EndpointType_WorkloadEndpoint,
+				DestPort:      443,
+			},
+			&types.FlowKeyMeta{
+				Proto:    "tcp",
+				Reporter: proto.Reporter_Src,
+				Action:   proto.Action_Allow,
+			},
+			&proto.PolicyTrace{},
+		),
+		SourceLabels: unique.Make("app=" + name),
+		DestLabels:   unique.Make(""),
+		BytesIn:      bytes,
+		PacketsIn:    1,
+		BytesOut:     bytes,
+		PacketsOut:   1,
+	}
+}
+
+func readAll(t *testing.T, s *archive.SegmentStore, start, end int64) []*types.Flow {
+	var flows []*types.Flow
+	require.NoError(t, s.Read(start, end, func(f *types.Flow) error {
+		flows = append(flows, f)
+		return nil
+	}))
+	return flows
+}
+
+func TestSegmentStoreReadWrite(t *testing.T) {
+	dir := t.TempDir()
+	s, err := archive.NewSegmentStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Write(100, 115, []*types.Flow{testFlow("a", 10), testFlow("b", 20)}))
+	require.NoError(t, s.Write(115, 130, []*types.Flow{testFlow("a", 30)}))
+
+	// Read back all flows. Each flow should carry the time range of its segment.
+	flows := readAll(t, s, 0, 0)
+	require.Len(t, flows, 3)
+	require.Equal(t, int64(100), flows[0].StartTime)
+	require.Equal(t, int64(115), flows[0].EndTime)
+	require.Equal(t, int64(115), flows[2].StartTime)
+	require.Equal(t, int64(30), flows[2].BytesIn)
+	require.Equal(t, *testFlow("a", 0).Key, *flows[2].Key)
+
+	// Read a subset of the time range.
+	flows = readAll(t, s, 115, 0)
+	require.Len(t, flows, 1)
+
+	// Rewriting a segment replaces its contents.
+	require.NoError(t, s.Write(100, 115, []*types.Flow{testFlow("c", 5)}))
+	flows = readAll(t, s, 100, 115)
+	require.Len(t, flows, 1)
+	require.Equal(t, "c", flows[0].Key.SourceName())
+
+	// A new store over the same directory should load the existing segments.
+	s2, err := archive.NewSegmentStore(dir)
+	require.NoError(t, err)
+	require.Len(t, readAll(t, s2, 0, 0), 2)
+}
+
+func TestSegmentStoreRetention(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Unix(1000, 0)
+	s, err := archive.NewSegmentStore(dir,
+		archive.WithMaxAge(100*time.Second),
+		archive.WithNowFunc(func() time.Time { return now }),
+	)
+	require.NoError(t, err)
+
+	// Write a segment that is already older than the max age. It should be pruned immediately.
+	require.NoError(t, s.Write(800, 815, []*types.Flow{testFlow("a", 10)}))
+	require.Empty(t, readAll(t, s, 0, 0))
+
+	// Write a segment within the max age. It should be retained until time moves on.
+	require.NoError(t, s.Write(950, 965, []*types.Flow{testFlow("a", 10)}))
+	require.Len(t, readAll(t, s, 0, 0), 1)
+	now = time.Unix(1100, 0)
+	require.NoError(t, s.Write(1085, 1100, []*types.Flow{testFlow("b", 10)}))
+	flows := readAll(t, s, 0, 0)
+	require.Len(t, flows, 1)
+	require.Equal(t, "b", flows[0].Key.SourceName())
+}
+
+func TestSegmentStoreSizeLimit(t *testing.T) {
+	dir := t.TempDir()
+	s, err := archive.NewSegmentStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, s.Write(100, 115, []*types.Flow{testFlow("a", 10)}))
+	info, err := os.Stat(filepath.Join(dir, "100-115.seg"))
+	require.NoError(t, err)
+
+	// Configure a limit that allows exactly two segments of this size.
+	s, err = archive.NewSegmentStore(dir, archive.WithMaxSize(2*info.Size()))
+	require.NoError(t, err)
+	require.NoError(t, s.Write(115, 130, []*types.Flow{testFlow("a", 10)}))
+	require.NoError(t, s.Write(130, 145, []*types.Flow{testFlow("a", 10)}))
+
+	// The oldest segment should have been removed.
+	flows := readAll(t, s, 0, 0)
+	require.Len(t, flows, 2)
+	require.Equal(t, int64(115), flows[0].StartTime)
+	_, err = os.Stat(filepath.Join(dir, "100-115.seg"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+type policyRecommendationsClient struct {
+	cli proto.PolicyRecommendationsClient
+}
+
+// PolicyRecommendationsClient is a client used for retrieving policy recommendations generated by Goldmane from
+// observed flows.
+type PolicyRecommendationsClient interface {
+	List(context.Context, *proto.PolicyRecommendationRequest) ([]*proto.PolicyRecommendation, error)
+}
+
+func NewPolicyRecommendationsAPIClient(host string, opts ...grpc.DialOption) (PolicyRecommendationsClient, error) {
+	gmCli, err := grpc.NewClient(host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create grpc client: %w", err)
+	}
+
+	return &policyRecommendationsClient{
+		cli: proto.NewPolicyRecommendationsClient(gmCli),
+	}, nil
+}
+
+// List retrieves the recommended policies for the namespace and time window given in the request.
+func (cli *policyRecommendationsClient) List(ctx context.Context, request *proto.PolicyRecommendationRequest) ([]*proto.PolicyRecommendation, error) {
+	result, err := cli.cli.List(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy recommendations: %w", err)
+	}
+	return result.Recommendations, nil
+}
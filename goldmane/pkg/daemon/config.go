@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -31,8 +33,53 @@ type goldmaneFileConfig struct {
 	EmitFlows bool `json:"emitFlows"`
 }
 
+// sinkSet combines the sinks that are currently enabled and passes them to Goldmane. Each sink is enabled
+// independently, by its own sinkManager, while Goldmane only accepts a single sink.
+type sinkSet struct {
+	gm *goldmane.Goldmane
+
+	lock    sync.Mutex
+	names   []string
+	enabled map[string]storage.Sink
+}
+
+func newSinkSet(gm *goldmane.Goldmane) *sinkSet {
+	return &sinkSet{gm: gm, enabled: map[string]storage.Sink{}}
+}
+
+// set enables or disables the named sink, and updates Goldmane with the resulting set of sinks.
+func (s *sinkSet) set(name string, sink storage.Sink, enabled bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !slices.Contains(s.names, name) {
+		// Keep the order in which sinks were first seen, so that sinks always receive flows in the same order.
+		s.names = append(s.names, name)
+	}
+	if enabled {
+		s.enabled[name] = sink
+	} else {
+		delete(s.enabled, name)
+	}
+
+	var sinks storage.Sinks
+	for _, n := range s.names {
+		if sink, ok := s.enabled[n]; ok {
+			sinks = append(sinks, sink)
+		}
+	}
+	if len(sinks) == 0 {
+		// Setting a nil sink allows Goldmane to skip emission altogether.
+		s.gm.SetSink(nil)
+		return
+	}
+	s.gm.SetSink(sinks)
+}
+
+// sinkManager enables and disables a single sink based on the contents of a file on disk.
 type sinkManager struct {
-	gm      *goldmane.Goldmane
+	name    string
+	sinks   *sinkSet
 	sink    storage.Sink
 	upd     chan struct{}
 	watchFn func(context.Context)
@@ -42,7 +89,7 @@ type sinkManager struct {
 	cur bool
 }
 
-func newSinkManager(agg *goldmane.Goldmane, sink storage.Sink, path string) (*sinkManager, error) {
+func newSinkManager(name string, sinks *sinkSet, sink storage.Sink, path string) (*sinkManager, error) {
 	onUpdate := make(chan struct{}, 1)
 
 	// Watch for changes to the input file.
@@ -54,14 +101,15 @@ func newSinkManager(agg *goldmane.Goldmane, sink storage.Sink, path string) (*si
 	if sink == nil {
 		return nil, fmt.Errorf("a sink must be provided")
 	}
-	if agg == nil {
-		return nil, fmt.Errorf("an aggregator must be provided")
+	if sinks == nil {
+		return nil, fmt.Errorf("a sink set must be provided")
 	}
 
 	e := sinkManager{
+		name:    name,
 		upd:     onUpdate,
 		watchFn: watchFn,
-		gm:      agg,
+		sinks:   sinks,
 		sink:    sink,
 		path:    path,
 	}
@@ -69,16 +117,14 @@ func newSinkManager(agg *goldmane.Goldmane, sink storage.Sink, path string) (*si
 }
 
 func (f *sinkManager) run(ctx context.Context) {
-	logrus.WithField("path", f.path).Info("Starting sink manager with config path")
-	defer logrus.Warn("Sink manager exiting")
+	logCtx := logrus.WithFields(logrus.Fields{"sink": f.name, "path": f.path})
+	logCtx.Info("Starting sink manager with config path")
+	defer logCtx.Warn("Sink manager exiting")
 	defer close(f.upd)
 
 	// Start of day - check if we should enable the sink.
-	if sinkEnabled(f.path) {
-		logrus.Debug("Sink enabled at startup")
-		f.gm.SetSink(f.sink)
-	}
-	logrus.Info("Sink manager started")
+	f.set(sinkEnabled(f.path))
+	logCtx.Info("Sink manager started")
 
 	// Start the file watch.
 	go f.watchFn(ctx)
@@ -98,31 +144,27 @@ func (f *sinkManager) set(enabled bool) {
 		// No change.
 		return
 	}
-	logrus.WithField("enabled", enabled).Info("Sink enablement changed")
-	if enabled {
-		f.gm.SetSink(f.sink)
-	} else {
-		f.gm.SetSink(nil)
-	}
+	logrus.WithFields(logrus.Fields{"sink": f.name, "enabled": enabled}).Info("Sink enablement changed")
+	f.sinks.set(f.name, f.sink, enabled)
 	f.cur = enabled
 }
 
 func sinkEnabled(path string) bool {
 	if _, err := os.Stat(path); err != nil {
-		// If the file doesn't exist, the emitter is disabled.
+		// If the file doesn't exist, the sink is disabled.
 		return false
 	}
 
 	// Open the file and read the contents.
 	contents, err := os.ReadFile(path)
 	if err != nil {
-		logrus.WithError(err).Warn("Error reading emitter enabled file")
+		logrus.WithError(err).Warn("Error reading sink enabled file")
 		return false
 	}
 	var cfg goldmaneFileConfig
 	err = json.Unmarshal(contents, &cfg)
 	if err != nil {
-		logrus.WithError(err).Warn("Error unmarshalling emitter enabled file")
+		logrus.WithError(err).Warn("Error unmarshalling sink enabled file")
 		return false
 	}
 	return cfg.EmitFlows
@@ -21,6 +21,8 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -32,9 +34,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	calicotls "github.com/projectcalico/calico/crypto/pkg/tls"
+	"github.com/projectcalico/calico/goldmane/pkg/archive"
 	"github.com/projectcalico/calico/goldmane/pkg/emitter"
 	"github.com/projectcalico/calico/goldmane/pkg/goldmane"
 	"github.com/projectcalico/calico/goldmane/pkg/internal/utils"
+	"github.com/projectcalico/calico/goldmane/pkg/otlp"
 	"github.com/projectcalico/calico/goldmane/pkg/server"
 	"github.com/projectcalico/calico/goldmane/pkg/storage"
 	"github.com/projectcalico/calico/libcalico-go/lib/debugserver"
@@ -93,6 +97,66 @@ type Config struct {
 
 	// PrometheusPort is the port to listen on for serving Prometheus metrics.
 	PrometheusPort int `json:"prometheus_port" envconfig:"PROMETHEUS_PORT" default:"0"`
+
+	// FlowStoragePath is the path to a directory in which to persist aggregated flows. If set, flows are
+	// written to disk as they are aggregated, reloaded on restart, and can be queried beyond the in-memory window.
+	FlowStoragePath string `json:"flow_storage_path" envconfig:"FLOW_STORAGE_PATH"`
+
+	// FlowStorageMaxAge is the maximum age of flows to retain on disk. Zero means no age limit.
+	FlowStorageMaxAge time.Duration `json:"flow_storage_max_age" envconfig:"FLOW_STORAGE_MAX_AGE" default:"24h"`
+
+	// FlowStorageMaxSizeMB is the maximum size in megabytes of flows to retain on disk. Zero means no size limit.
+	FlowStorageMaxSizeMB int64 `json:"flow_storage_max_size_mb" envconfig:"FLOW_STORAGE_MAX_SIZE_MB" default:"1024"`
+
+	// OTLPEndpoint is the address of an OpenTelemetry receiver to export flows to, if set. Flows are exported
+	// as OTLP log records, with byte and packet counts also exported as metrics. This may be used alongside PushURL.
+	OTLPEndpoint string `json:"otlp_endpoint" envconfig:"OTLP_ENDPOINT"`
+
+	// OTLPFileConfigPath is the path to a file that enables or disables OTLP export without a process restart,
+	// using the same format as FileConfigPath. It is separate from FileConfigPath so that OTLP export can be
+	// enabled independently of PushURL. If not set, OTLP export is always enabled when OTLPEndpoint is set.
+	OTLPFileConfigPath string `json:"otlp_file_config_path" envconfig:"OTLP_FILE_CONFIG_PATH"`
+
+	// OTLPProtocol is the OTLP transport to use, either "grpc" or "http".
+	OTLPProtocol string `json:"otlp_protocol" envconfig:"OTLP_PROTOCOL" default:"grpc"`
+
+	// OTLPInsecure disables TLS when connecting to the OTLP receiver.
+	OTLPInsecure bool `json:"otlp_insecure" envconfig:"OTLP_INSECURE" default:"false"`
+
+	// OTLPHeaders are additional headers to send with each OTLP export request, in the form "key1:value1,key2:value2".
+	// Header values are redacted when the configuration is logged, since they commonly carry credentials.
+	OTLPHeaders redactedHeaders `json:"-" envconfig:"OTLP_HEADERS"`
+
+	// OTLPClientKeyPath, OTLPClientCertPath, and OTLPCACertPath are paths to the client key, client cert, and CA cert
+	// used when exporting to the OTLP receiver over TLS.
+	OTLPClientCertPath string `json:"otlp_client_cert_path" envconfig:"OTLP_CLIENT_CERT_PATH"`
+	OTLPClientKeyPath  string `json:"otlp_client_key_path" envconfig:"OTLP_CLIENT_KEY_PATH"`
+	OTLPCACertPath     string `json:"otlp_ca_cert_path" envconfig:"OTLP_CA_CERT_PATH"`
+
+	// OTLPTimeout is the timeout for each OTLP export request.
+	OTLPTimeout time.Duration `json:"otlp_timeout" envconfig:"OTLP_TIMEOUT" default:"10s"`
+
+	// OTLPMaxQueueSize is the maximum number of aggregated flow collections to buffer while waiting for the OTLP receiver.
+	// Once full, the oldest collections are dropped.
+	OTLPMaxQueueSize int `json:"otlp_max_queue_size" envconfig:"OTLP_MAX_QUEUE_SIZE" default:"100"`
+
+	// OTLPMaxBatchSize is the maximum number of flows to send in a single OTLP export request.
+	OTLPMaxBatchSize int `json:"otlp_max_batch_size" envconfig:"OTLP_MAX_BATCH_SIZE" default:"1000"`
+
+	// OTLPMaxRetryTime is the maximum time to spend retrying a failed OTLP export request before dropping it.
+	OTLPMaxRetryTime time.Duration `json:"otlp_max_retry_time" envconfig:"OTLP_MAX_RETRY_TIME" default:"5m"`
+}
+
+// redactedHeaders is a set of headers whose values are omitted when printed.
+type redactedHeaders map[string]string
+
+func (h redactedHeaders) String() string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k+":<redacted>")
+	}
+	sort.Strings(keys)
+	return "map[" + strings.Join(keys, " ") + "]"
 }
 
 func ConfigFromEnv() Config {
@@ -156,8 +220,36 @@ func Run(ctx context.Context, cfg Config) {
 		goldmane.WithPushIndex(cfg.EmitAfterSeconds / int(cfg.AggregationWindow.Seconds())),
 		goldmane.WithHealthAggregator(healthAggregator),
 	}
+
+	if cfg.FlowStoragePath != "" {
+		// Persist flows to disk so that history survives restarts.
+		store, err := archive.NewSegmentStore(
+			cfg.FlowStoragePath,
+			archive.WithMaxAge(cfg.FlowStorageMaxAge),
+			archive.WithMaxSize(cfg.FlowStorageMaxSizeMB*1024*1024),
+		)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create flow storage")
+		}
+		opts = append(opts, goldmane.WithArchive(store))
+	}
 	gm := goldmane.NewGoldmane(opts...)
 
+	// Each sink can be enabled independently, either statically or by a file on disk that is watched so that
+	// the sink can be enabled or disabled without a process restart.
+	sinks := newSinkSet(gm)
+	addSink := func(name string, sink storage.Sink, enabledFilePath string) {
+		if enabledFilePath == "" {
+			sinks.set(name, sink, true)
+			return
+		}
+		mgr, err := newSinkManager(name, sinks, sink, enabledFilePath)
+		if err != nil {
+			logrus.WithError(err).WithField("sink", name).Fatal("Failed to create sink manager")
+		}
+		go mgr.run(ctx)
+	}
+
 	if cfg.PushURL != "" {
 		// Create an emitter, which forwards flows to an upstream HTTP endpoint.
 		logEmitter := emitter.NewEmitter(
@@ -170,20 +262,30 @@ func Run(ctx context.Context, cfg Config) {
 			emitter.WithHealthAggregator(healthAggregator),
 		)
 		go logEmitter.Run(ctx)
+		addSink("emitter", logEmitter, cfg.FileConfigPath)
+	}
 
-		if cfg.FileConfigPath != "" {
-			// Start a goroutine to manage sink enablement. This will monitor a file on disk to determine if
-			// the sink should be enabled or disabled, and update the aggregator configuration accordingly. This
-			// allows the sink to be enabled or disabled without a process restart.
-			mgr, err := newSinkManager(gm, logEmitter, cfg.FileConfigPath)
-			if err != nil {
-				logrus.WithError(err).Fatal("Failed to create sink manager")
-			}
-			go mgr.run(ctx)
-		} else {
-			// Just set the sink directly.
-			gm.SetSink(logEmitter)
+	if cfg.OTLPEndpoint != "" {
+		// Create an OTLP exporter, which forwards flows to an OpenTelemetry receiver.
+		exporter, err := otlp.NewExporter(
+			otlp.WithEndpoint(cfg.OTLPEndpoint),
+			otlp.WithProtocol(cfg.OTLPProtocol),
+			otlp.WithInsecure(cfg.OTLPInsecure),
+			otlp.WithHeaders(map[string]string(cfg.OTLPHeaders)),
+			otlp.WithCACertPath(cfg.OTLPCACertPath),
+			otlp.WithClientKeyPath(cfg.OTLPClientKeyPath),
+			otlp.WithClientCertPath(cfg.OTLPClientCertPath),
+			otlp.WithTimeout(cfg.OTLPTimeout),
+			otlp.WithMaxQueueSize(cfg.OTLPMaxQueueSize),
+			otlp.WithMaxBatchSize(cfg.OTLPMaxBatchSize),
+			otlp.WithMaxRetryTime(cfg.OTLPMaxRetryTime),
+			otlp.WithHealthAggregator(healthAggregator),
+		)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to create OTLP exporter")
 		}
+		go exporter.Run(ctx)
+		addSink("otlp", exporter, cfg.OTLPFileConfigPath)
 	}
 
 	// Create a flow collector to receive flows from clients, connected goldmane.
@@ -202,6 +304,10 @@ func Run(ctx context.Context, cfg Config) {
 	statsServer := server.NewStatisticsServer(gm)
 	statsServer.RegisterWith(grpcServer)
 
+	// Start a policy recommendations server, serving from Goldmane.
+	recommendationServer := server.NewPolicyRecommendationsServer(gm)
+	recommendationServer.RegisterWith(grpcServer)
+
 	// Start the gRPC server.
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
 	if err != nil {
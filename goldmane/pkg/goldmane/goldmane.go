@@ -49,6 +49,9 @@ const (
 
 	// healthName is the name of this component in the health aggregator.
 	healthName = "aggregator"
+
+	// archiveQueueSize is the number of completed buckets that may be waiting to be written to the archive.
+	archiveQueueSize = 10
 )
 
 var (
@@ -119,6 +122,17 @@ type listRequest struct {
 type listResponse struct {
 	results *proto.FlowListResult
 	err     error
+
+	// archived is set instead of results for requests that reach into the archive. Reading the
+	// archive is slow, so the requester runs it off of the main loop.
+	archived *storage.ArchiveList
+}
+
+// flowResultsRequest is an internal helper used to convert flows that were listed off of the main
+// loop into results, which requires access to the flow store.
+type flowResultsRequest struct {
+	respCh chan []*proto.FlowResult
+	flows  []*types.Flow
 }
 
 // filterHintsRequest is an internal helper used to synchronously request filter hints from the aggregator.
@@ -184,11 +198,15 @@ type Goldmane struct {
 	// health is the health aggregator to use for health checks.
 	health *health.HealthAggregator
 
+	// archive is an optional durable store for flows, used to extend history beyond the in-memory buckets.
+	archive storage.Archive
+
 	// ratelimiter is used to rate limit log messages that may happen frequently.
 	rl *logutils.RateLimitedLogger
 
 	// The following channels are input channels to make resuests of the main loop.
 	listRequests        chan listRequest
+	flowResultsRequests chan flowResultsRequest
 	filterHintsRequests chan filterHintsRequest
 	sinkChan            chan *sinkRequest
 	recvChan            chan *types.Flow
@@ -200,6 +218,7 @@ func NewGoldmane(opts ...Option) *Goldmane {
 		bucketDuration:      15 * time.Second,
 		done:                make(chan struct{}),
 		listRequests:        make(chan listRequest),
+		flowResultsRequests: make(chan flowResultsRequest),
 		filterHintsRequests: make(chan filterHintsRequest),
 		sinkChan:            make(chan *sinkRequest, 10),
 		recvChan:            make(chan *types.Flow, channelDepth),
@@ -252,6 +271,14 @@ func (a *Goldmane) Run(startTime int64) {
 		storage.WithStreamReceiver(a.streams),
 		storage.WithNowFunc(a.nowFunc),
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if a.archive != nil {
+		// Write to the archive from a separate goroutine so that disk I/O doesn't hold up the main loop.
+		archive := storage.NewAsyncArchive(a.archive, archiveQueueSize)
+		go archive.Run(ctx)
+		opts = append(opts, storage.WithArchive(archive))
+	}
 	a.flowStore = storage.NewBucketRing(
 		numBuckets,
 		int(a.bucketDuration.Seconds()),
@@ -271,8 +298,6 @@ func (a *Goldmane) Run(startTime int64) {
 
 	// Start the stream manager on its own goroutine so we can process stream creation and closure
 	// requests asynchronously from the main loop.
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	go a.streams.Run(ctx)
 
 	// Schedule the first rollover one aggregation period from now.
@@ -286,6 +311,8 @@ func (a *Goldmane) Run(startTime int64) {
 			rolloverCh = a.rolloverFunc(a.rollover())
 		case req := <-a.listRequests:
 			req.respCh <- a.queryFlows(req.req)
+		case req := <-a.flowResultsRequests:
+			req.respCh <- a.flowsToResult(req.flows)
 		case req := <-a.filterHintsRequests:
 			req.respCh <- a.queryFilterHints(req.req)
 		case stream := <-a.streams.Backfills():
@@ -358,7 +385,27 @@ func (a *Goldmane) List(req *proto.FlowListRequest) (*proto.FlowListResult, erro
 	defer close(respCh)
 	a.listRequests <- listRequest{respCh, req}
 	resp := <-respCh
-	return resp.results, resp.err
+	if resp.archived == nil {
+		return resp.results, resp.err
+	}
+
+	// The request reaches into the archive. Read it here rather than on the main loop, so that a
+	// wide query doesn't hold up ingestion, and only hand the requested page back to the main loop.
+	flows, meta, err := resp.archived.Run()
+	if err != nil {
+		logrus.WithError(err).Warn("Error listing archived flows")
+		return nil, err
+	}
+	resultsCh := make(chan []*proto.FlowResult)
+	defer close(resultsCh)
+	a.flowResultsRequests <- flowResultsRequest{resultsCh, flows}
+	return &proto.FlowListResult{
+		Meta: &proto.ListMetadata{
+			TotalPages:   int64(meta.TotalPages),
+			TotalResults: int64(meta.TotalResults),
+		},
+		Flows: <-resultsCh,
+	}, nil
 }
 
 func (a *Goldmane) Hints(req *proto.FilterHintsRequest) (*proto.FilterHintsResult, error) {
@@ -397,6 +444,12 @@ func (a *Goldmane) Statistics(req *proto.StatisticsRequest) ([]*proto.Statistics
 		logrus.WithField("req", req).WithError(err).Debug("Invalid time range")
 		return nil, err
 	}
+	if req.TopN < 0 {
+		return nil, fmt.Errorf("topN (%d) must not be negative", req.TopN)
+	}
+	if req.PolicyMatch != nil && storage.IsEndpointGroupBy(req.GroupBy) {
+		return nil, fmt.Errorf("policy match is not supported when grouping by %s", req.GroupBy)
+	}
 	return a.flowStore.Statistics(req)
 }
 
@@ -468,22 +521,27 @@ func (a *Goldmane) queryFlows(req *proto.FlowListRequest) *listResponse {
 
 	// Validate the request.
 	if err := a.validateListRequest(req); err != nil {
-		return &listResponse{nil, err}
+		return &listResponse{err: err}
+	}
+
+	if a.flowStore.NeedsArchive(req) {
+		l, err := a.flowStore.NewArchiveList(req)
+		return &listResponse{err: err, archived: l}
 	}
 
 	flowsToReturn, meta, err := a.flowStore.List(req)
 	if err != nil {
 		logrus.WithError(err).Warn("Error listing flows")
-		return &listResponse{nil, err}
+		return &listResponse{err: err}
 	}
 
-	return &listResponse{&proto.FlowListResult{
+	return &listResponse{results: &proto.FlowListResult{
 		Meta: &proto.ListMetadata{
 			TotalPages:   int64(meta.TotalPages),
 			TotalResults: int64(meta.TotalResults),
 		},
 		Flows: a.flowsToResult(flowsToReturn),
-	}, nil}
+	}}
 }
 
 func (a *Goldmane) queryFilterHints(req *proto.FilterHintsRequest) *filterHintsResponse {
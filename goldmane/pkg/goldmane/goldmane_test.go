@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 	googleproto "google.golang.org/protobuf/proto"
 
+	"github.com/projectcalico/calico/goldmane/pkg/archive"
 	"github.com/projectcalico/calico/goldmane/pkg/goldmane"
 	"github.com/projectcalico/calico/goldmane/pkg/internal/utils"
 	"github.com/projectcalico/calico/goldmane/pkg/storage"
@@ -414,6 +415,85 @@ func TestRotation(t *testing.T) {
 	}, 1*time.Second, retryTime).Should(Equal(0), "Flow did not rotate out")
 }
 
+func TestArchive(t *testing.T) {
+	// Create a clock and rollover controller.
+	c := newClock(initialNow)
+	now := c.Now().Unix()
+	roller := &rolloverController{
+		ch:                    make(chan time.Time),
+		aggregationWindowSecs: 1,
+		clock:                 c,
+	}
+	store, err := archive.NewSegmentStore(t.TempDir())
+	require.NoError(t, err)
+	opts := []goldmane.Option{
+		goldmane.WithRolloverTime(1 * time.Second),
+		goldmane.WithRolloverFunc(roller.After),
+		goldmane.WithNowFunc(c.Now),
+		goldmane.WithArchive(store),
+	}
+	defer setupTest(t, opts...)()
+	go gm.Run(now)
+
+	// Create a Flow in the bucket that will be archived on the next rollover.
+	fl := &proto.Flow{
+		Key: &proto.FlowKey{
+			SourceName:      "test-src",
+			SourceNamespace: "test-ns",
+			DestName:        "test-dst",
+			DestNamespace:   "test-dst-ns",
+			Proto:           "tcp",
+			Action:          proto.Action_Allow,
+			Policies:        &proto.PolicyTrace{EnforcedPolicies: []*proto.PolicyHit{}},
+		},
+		StartTime:             now - 1,
+		EndTime:               now,
+		BytesIn:               100,
+		BytesOut:              200,
+		PacketsIn:             10,
+		PacketsOut:            20,
+		NumConnectionsStarted: 1,
+	}
+	gm.Receive(types.ProtoToFlow(fl))
+	Eventually(func() int {
+		results, _ := gm.List(&proto.FlowListRequest{})
+		return len(results.Flows)
+	}, waitTimeout, retryTime).Should(Equal(1), "Didn't receive flow")
+
+	// Rollover Goldmane until the flow is pushed out of the in-memory window.
+	roller.rolloverAndAdvanceClock(240)
+	Eventually(func() int {
+		results, _ := gm.List(&proto.FlowListRequest{})
+		return len(results.Flows)
+	}, waitTimeout, retryTime).Should(Equal(0), "Flow did not rotate out")
+
+	// Querying from an explicit start time before the in-memory window should return the archived flow.
+	var results *proto.FlowListResult
+	Eventually(func() int {
+		results, err = gm.List(&proto.FlowListRequest{StartTimeGte: now - 1})
+		require.NoError(t, err)
+		return len(results.Flows)
+	}, waitTimeout, retryTime).Should(Equal(1), "Didn't receive archived flow")
+	Expect(results.Flows[0].Flow.BytesIn).To(Equal(int64(100)))
+	Expect(results.Flows[0].Flow.StartTime).To(Equal(now - 1))
+	Expect(results.Meta.TotalResults).To(Equal(int64(1)))
+
+	// A new Goldmane whose window covers the flow should rehydrate it from the archive.
+	gm.Stop()
+	c = newClock(initialNow)
+	gm = goldmane.NewGoldmane(
+		goldmane.WithRolloverTime(1*time.Second),
+		goldmane.WithRolloverFunc(roller.After),
+		goldmane.WithNowFunc(c.Now),
+		goldmane.WithArchive(store),
+	)
+	go gm.Run(now)
+	Eventually(func() int {
+		results, _ := gm.List(&proto.FlowListRequest{})
+		return len(results.Flows)
+	}, waitTimeout, retryTime).Should(Equal(1), "Flow was not rehydrated")
+}
+
 func TestManyFlows(t *testing.T) {
 	c := newClock(initialNow)
 	now := c.Now().Unix()
@@ -2031,6 +2111,13 @@ func TestStatistics(t *testing.T) {
 					case proto.Reporter_Dst:
 						require.Equal(t, fl.NumConnectionsLive, stat.AllowedIn[i])
 					}
+				case proto.StatisticType_StartedConnectionCount:
+					switch fl.Key.Reporter {
+					case proto.Reporter_Src:
+						require.Equal(t, fl.NumConnectionsStarted, stat.AllowedOut[i])
+					case proto.Reporter_Dst:
+						require.Equal(t, fl.NumConnectionsStarted, stat.AllowedIn[i])
+					}
 				}
 			}
 
@@ -2299,6 +2386,159 @@ func TestStatistics(t *testing.T) {
 	}
 }
 
+func TestStatisticsByEndpoint(t *testing.T) {
+	c := newClock(initialNow)
+	roller := &rolloverController{
+		ch:                    make(chan time.Time),
+		aggregationWindowSecs: 1,
+		clock:                 c,
+	}
+	opts := []goldmane.Option{
+		goldmane.WithRolloverTime(1 * time.Second),
+		goldmane.WithRolloverFunc(roller.After),
+		goldmane.WithNowFunc(c.Now),
+	}
+	defer setupTest(t, opts...)()
+	go gm.Run(c.Now().Unix())
+
+	newFlow := func(reporter proto.Reporter, action proto.Action, src, dst *proto.StatisticsEndpoint, bytes, started int64) *proto.Flow {
+		fl := testutils.NewRandomFlow(roller.clock.Now().Unix())
+		fl.Key.Reporter = reporter
+		fl.Key.Action = action
+		fl.Key.SourceNamespace, fl.Key.SourceName, fl.Key.SourceType = src.Namespace, src.Name, src.Type
+		fl.Key.DestNamespace, fl.Key.DestName, fl.Key.DestType = dst.Namespace, dst.Name, dst.Type
+		fl.Key.DestServiceNamespace, fl.Key.DestServiceName = "", ""
+		fl.BytesIn, fl.BytesOut = bytes, bytes
+		fl.NumConnectionsStarted = started
+		return fl
+	}
+	client := &proto.StatisticsEndpoint{Namespace: "a", Name: "client-*", Type: proto.EndpointType_WorkloadEndpoint}
+	server := &proto.StatisticsEndpoint{Namespace: "b", Name: "server-*", Type: proto.EndpointType_WorkloadEndpoint}
+	db := &proto.StatisticsEndpoint{Namespace: "c", Name: "db-*", Type: proto.EndpointType_WorkloadEndpoint}
+	public := &proto.StatisticsEndpoint{Name: "pub", Type: proto.EndpointType_Network}
+
+	// Traffic from the client to the server's service, reported at both ends.
+	toServerSrc := newFlow(proto.Reporter_Src, proto.Action_Allow, client, server, 100, 1)
+	toServerSrc.Key.DestServiceNamespace, toServerSrc.Key.DestServiceName = "b", "svc"
+	toServerDst := newFlow(proto.Reporter_Dst, proto.Action_Allow, client, server, 100, 1)
+	toServerDst.Key.DestServiceNamespace, toServerDst.Key.DestServiceName = "b", "svc"
+	flows := []*proto.Flow{
+		toServerSrc,
+		toServerDst,
+		// Traffic from the client allowed out, but denied by the database.
+		newFlow(proto.Reporter_Src, proto.Action_Allow, client, db, 5, 4),
+		newFlow(proto.Reporter_Dst, proto.Action_Deny, client, db, 5, 4),
+		// Traffic from outside the cluster, only reported by the server.
+		newFlow(proto.Reporter_Dst, proto.Action_Allow, public, server, 1000, 2),
+	}
+	for _, fl := range flows {
+		gm.Receive(types.ProtoToFlow(fl))
+	}
+	roller.rolloverAndAdvanceClock(1)
+	Eventually(func() bool {
+		results, _ := gm.List(&proto.FlowListRequest{})
+		return len(results.Flows) == len(flows)
+	}, waitTimeout, retryTime).Should(BeTrue(), "Didn't receive all flows")
+
+	type total struct {
+		endpoint *proto.StatisticsEndpoint
+		value    int64
+		rank     int64
+	}
+	query := func(req *proto.StatisticsRequest) []total {
+		stats, err := gm.Statistics(req)
+		require.NoError(t, err)
+		var totals []total
+		for _, stat := range stats {
+			require.Nil(t, stat.Policy)
+			require.Equal(t, req.GroupBy, stat.GroupBy)
+			totals = append(totals, total{
+				endpoint: stat.Endpoint,
+				value:    sum(stat.AllowedIn) + sum(stat.AllowedOut) + sum(stat.DeniedIn) + sum(stat.DeniedOut),
+				rank:     stat.Rank,
+			})
+		}
+		return totals
+	}
+	workload := func(e *proto.StatisticsEndpoint) *proto.StatisticsEndpoint {
+		return &proto.StatisticsEndpoint{Namespace: e.Namespace, Name: e.Name, Type: e.Type}
+	}
+	namespace := func(e *proto.StatisticsEndpoint) *proto.StatisticsEndpoint {
+		return &proto.StatisticsEndpoint{Namespace: e.Namespace}
+	}
+
+	t.Run("GroupBySourceWorkload", func(t *testing.T) {
+		// Traffic from the client is counted once, from the client's own reports.
+		require.Equal(t, []total{
+			{endpoint: workload(public), value: 2000},
+			{endpoint: workload(client), value: 210},
+		}, query(&proto.StatisticsRequest{
+			Type:    proto.StatisticType_ByteCount,
+			GroupBy: proto.StatisticsGroupBy_GroupBySourceWorkload,
+		}))
+	})
+
+	t.Run("GroupByDestWorkload", func(t *testing.T) {
+		require.Equal(t, []total{
+			{endpoint: workload(server), value: 2200},
+			{endpoint: workload(db), value: 10},
+		}, query(&proto.StatisticsRequest{
+			Type:    proto.StatisticType_ByteCount,
+			GroupBy: proto.StatisticsGroupBy_GroupByDestWorkload,
+		}))
+	})
+
+	t.Run("GroupBySourceNamespace", func(t *testing.T) {
+		require.Equal(t, []total{
+			{endpoint: namespace(public), value: 2000},
+			{endpoint: namespace(client), value: 210},
+		}, query(&proto.StatisticsRequest{
+			Type:    proto.StatisticType_ByteCount,
+			GroupBy: proto.StatisticsGroupBy_GroupBySourceNamespace,
+		}))
+	})
+
+	t.Run("GroupByDestService", func(t *testing.T) {
+		require.Equal(t, []total{
+			{endpoint: &proto.StatisticsEndpoint{Namespace: "b", Name: "svc"}, value: 200},
+		}, query(&proto.StatisticsRequest{
+			Type:    proto.StatisticType_ByteCount,
+			GroupBy: proto.StatisticsGroupBy_GroupByDestService,
+		}))
+	})
+
+	t.Run("TopN by bytes", func(t *testing.T) {
+		require.Equal(t, []total{
+			{endpoint: workload(server), value: 2200, rank: 2200},
+		}, query(&proto.StatisticsRequest{
+			Type:    proto.StatisticType_ByteCount,
+			GroupBy: proto.StatisticsGroupBy_GroupByDestWorkload,
+			TopN:    1,
+			RankBy:  proto.StatisticsRankBy_RankByBytes,
+		}))
+	})
+
+	t.Run("TopN by denied connections", func(t *testing.T) {
+		// The results are ranked by denied connections, but carry the requested statistic.
+		require.Equal(t, []total{
+			{endpoint: workload(db), value: 10, rank: 4},
+		}, query(&proto.StatisticsRequest{
+			Type:    proto.StatisticType_ByteCount,
+			GroupBy: proto.StatisticsGroupBy_GroupByDestWorkload,
+			TopN:    1,
+			RankBy:  proto.StatisticsRankBy_RankByDeniedConnections,
+		}))
+	})
+
+	t.Run("PolicyMatch is rejected", func(t *testing.T) {
+		_, err := gm.Statistics(&proto.StatisticsRequest{
+			GroupBy:     proto.StatisticsGroupBy_GroupByDestWorkload,
+			PolicyMatch: &proto.PolicyMatch{Name: "policy"},
+		})
+		require.Error(t, err)
+	})
+}
+
 func sum(nums []int64) int64 {
 	var sum int64
 	for _, n := range nums {
@@ -17,6 +17,7 @@ package goldmane
 import (
 	"time"
 
+	"github.com/projectcalico/calico/goldmane/pkg/storage"
 	"github.com/projectcalico/calico/libcalico-go/lib/health"
 )
 
@@ -63,3 +64,11 @@ func WithHealthAggregator(ha *health.HealthAggregator) Option {
 		a.health = ha
 	}
 }
+
+// WithArchive configures durable storage for flows, allowing flow history to survive restarts and
+// to be queried beyond the in-memory window.
+func WithArchive(archive storage.Archive) Option {
+	return func(a *Goldmane) {
+		a.archive = archive
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	googleproto "google.golang.org/protobuf/proto"
+
+	calicotls "github.com/projectcalico/calico/crypto/pkg/tls"
+)
+
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+
+	contentTypeProtobuf = "application/x-protobuf"
+)
+
+// client sends export requests to an OTLP receiver.
+type client interface {
+	exportLogs(context.Context, *collogspb.ExportLogsServiceRequest) error
+	exportMetrics(context.Context, *colmetricspb.ExportMetricsServiceRequest) error
+}
+
+// retryableError indicates that an export failed in a way that the OTLP specification allows to be retried,
+// optionally with a delay requested by the receiver.
+type retryableError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+func newClient(e *Exporter) (client, error) {
+	var tlsConfig *tls.Config
+	if !e.insecure {
+		var err error
+		tlsConfig, err = newTLSConfig(e.caCert, e.clientCert, e.clientKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch e.protocol {
+	case ProtocolGRPC, "":
+		creds := insecure.NewCredentials()
+		if tlsConfig != nil {
+			creds = credentials.NewTLS(tlsConfig)
+		}
+		conn, err := grpc.NewClient(e.endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create grpc client: %w", err)
+		}
+		return &grpcClient{
+			logs:    collogspb.NewLogsServiceClient(conn),
+			metrics: colmetricspb.NewMetricsServiceClient(conn),
+			headers: metadata.New(e.headers),
+		}, nil
+	case ProtocolHTTP:
+		endpoint := e.endpoint
+		if !strings.Contains(endpoint, "://") {
+			if e.insecure {
+				endpoint = "http://" + endpoint
+			} else {
+				endpoint = "https://" + endpoint
+			}
+		}
+		endpoint = strings.TrimSuffix(endpoint, "/")
+		return &httpClient{
+			client:     &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+			logsURL:    endpoint + "/v1/logs",
+			metricsURL: endpoint + "/v1/metrics",
+			headers:    e.headers,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown OTLP protocol %q", e.protocol)
+	}
+}
+
+func newTLSConfig(caCert, clientCert, clientKey string) (*tls.Config, error) {
+	tlsConfig, err := calicotls.NewTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TLS Config: %w", err)
+	}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse root certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCert != "" && clientKey != "" {
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error loading cert key pair for OTLP client: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+type grpcClient struct {
+	logs    collogspb.LogsServiceClient
+	metrics colmetricspb.MetricsServiceClient
+	headers metadata.MD
+}
+
+func (c *grpcClient) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	resp, err := c.logs.Export(metadata.NewOutgoingContext(ctx, c.headers), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	if ps := resp.GetPartialSuccess(); ps.GetRejectedLogRecords() > 0 {
+		return fmt.Errorf("receiver rejected %d log records: %s", ps.GetRejectedLogRecords(), ps.GetErrorMessage())
+	}
+	return nil
+}
+
+func (c *grpcClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	resp, err := c.metrics.Export(metadata.NewOutgoingContext(ctx, c.headers), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	if ps := resp.GetPartialSuccess(); ps.GetRejectedDataPoints() > 0 {
+		return fmt.Errorf("receiver rejected %d data points: %s", ps.GetRejectedDataPoints(), ps.GetErrorMessage())
+	}
+	return nil
+}
+
+// grpcError classifies the given gRPC error according to the OTLP specification.
+func grpcError(err error) error {
+	s := status.Convert(err)
+	switch s.Code() {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return &retryableError{err: err}
+	case codes.ResourceExhausted:
+		// The receiver is applying backpressure. This is only retryable if the receiver tells us when to retry.
+		for _, d := range s.Details() {
+			if ri, ok := d.(*errdetails.RetryInfo); ok {
+				return &retryableError{err: err, delay: ri.GetRetryDelay().AsDuration()}
+			}
+		}
+	}
+	return err
+}
+
+type httpClient struct {
+	client     *http.Client
+	logsURL    string
+	metricsURL string
+	headers    map[string]string
+}
+
+func (c *httpClient) exportLogs(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	resp := &collogspb.ExportLogsServiceResponse{}
+	if err := c.post(ctx, c.logsURL, req, resp); err != nil {
+		return err
+	}
+	if ps := resp.GetPartialSuccess(); ps.GetRejectedLogRecords() > 0 {
+		return fmt.Errorf("receiver rejected %d log records: %s", ps.GetRejectedLogRecords(), ps.GetErrorMessage())
+	}
+	return nil
+}
+
+func (c *httpClient) exportMetrics(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) error {
+	resp := &colmetricspb.ExportMetricsServiceResponse{}
+	if err := c.post(ctx, c.metricsURL, req, resp); err != nil {
+		return err
+	}
+	if ps := resp.GetPartialSuccess(); ps.GetRejectedDataPoints() > 0 {
+		return fmt.Errorf("receiver rejected %d data points: %s", ps.GetRejectedDataPoints(), ps.GetErrorMessage())
+	}
+	return nil
+}
+
+func (c *httpClient) post(ctx context.Context, url string, req, resp googleproto.Message) error {
+	body, err := googleproto.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", contentTypeProtobuf)
+	for k, v := range c.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		// Connection level errors are always worth retrying.
+		return &retryableError{err: err}
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return &retryableError{err: err}
+	}
+
+	switch httpResp.StatusCode {
+	case http.StatusOK:
+		if err := googleproto.Unmarshal(respBody, resp); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return nil
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		err := &retryableError{err: fmt.Errorf("unexpected status code: %s", httpResp.Status)}
+		if secs, perr := strconv.Atoi(httpResp.Header.Get("Retry-After")); perr == nil {
+			err.delay = time.Duration(secs) * time.Second
+		}
+		return err
+	default:
+		return fmt.Errorf("unexpected status code: %s", httpResp.Status)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/projectcalico/calico/goldmane/pkg/types"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+const (
+	scopeName   = "github.com/projectcalico/calico/goldmane"
+	serviceName = "goldmane"
+
+	metricBytes   = "calico.flow.bytes"
+	metricPackets = "calico.flow.packets"
+)
+
+// resource identifies Goldmane as the source of all exported telemetry.
+var resource = &resourcepb.Resource{
+	Attributes: []*commonpb.KeyValue{stringAttr("service.name", serviceName)},
+}
+
+// batch is a set of flows covering the same time range, converted to OTLP requests.
+type batch struct {
+	logs    *collogspb.ExportLogsServiceRequest
+	metrics *colmetricspb.ExportMetricsServiceRequest
+
+	// numFlows is the number of flows in the batch, used for metrics.
+	numFlows int
+}
+
+// newBatch converts the given flows into OTLP log and metric export requests. Each flow is represented
+// as one log record, and contributes data points to delta sums of bytes and packets for the time range.
+func newBatch(startTime, endTime int64, flows []types.Flow, observedTime uint64) *batch {
+	records := make([]*logspb.LogRecord, 0, len(flows))
+	bytes := &metricspb.Sum{AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA, IsMonotonic: true}
+	packets := &metricspb.Sum{AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA, IsMonotonic: true}
+	start, end := uint64(startTime)*1e9, uint64(endTime)*1e9
+
+	for i := range flows {
+		f := types.FlowToProto(&flows[i])
+		records = append(records, flowToLogRecord(f, observedTime))
+
+		attrs := flowKeyAttributes(f.Key)
+		for _, dir := range []struct {
+			name           string
+			bytes, packets int64
+		}{
+			{"in", f.BytesIn, f.PacketsIn},
+			{"out", f.BytesOut, f.PacketsOut},
+		} {
+			dirAttrs := append(attrs[:len(attrs):len(attrs)], stringAttr("network.io.direction", dir.name))
+			bytes.DataPoints = append(bytes.DataPoints, intDataPoint(start, end, dir.bytes, dirAttrs))
+			packets.DataPoints = append(packets.DataPoints, intDataPoint(start, end, dir.packets, dirAttrs))
+		}
+	}
+
+	scope := &commonpb.InstrumentationScope{Name: scopeName}
+	return &batch{
+		numFlows: len(flows),
+		logs: &collogspb.ExportLogsServiceRequest{
+			ResourceLogs: []*logspb.ResourceLogs{{
+				Resource:  resource,
+				ScopeLogs: []*logspb.ScopeLogs{{Scope: scope, LogRecords: records}},
+			}},
+		},
+		metrics: &colmetricspb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*metricspb.ResourceMetrics{{
+				Resource: resource,
+				ScopeMetrics: []*metricspb.ScopeMetrics{{
+					Scope: scope,
+					Metrics: []*metricspb.Metric{
+						{
+							Name:        metricBytes,
+							Description: "Number of bytes transferred by the flow.",
+							Unit:        "By",
+							Data:        &metricspb.Metric_Sum{Sum: bytes},
+						},
+						{
+							Name:        metricPackets,
+							Description: "Number of packets transferred by the flow.",
+							Unit:        "{packet}",
+							Data:        &metricspb.Metric_Sum{Sum: packets},
+						},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+// flowToLogRecord returns a log record for the given flow. The body is the same JSON document sent by the
+// HTTP emitter, while the attributes carry the flow's identifying fields for indexing.
+func flowToLogRecord(f *proto.Flow, observedTime uint64) *logspb.LogRecord {
+	attrs := flowKeyAttributes(f.Key)
+	attrs = append(attrs,
+		stringsAttr("source.labels", f.SourceLabels),
+		stringsAttr("destination.labels", f.DestLabels),
+		intAttr("calico.flow.start_time", f.StartTime),
+		intAttr("calico.flow.end_time", f.EndTime),
+		intAttr("calico.flow.bytes_in", f.BytesIn),
+		intAttr("calico.flow.bytes_out", f.BytesOut),
+		intAttr("calico.flow.packets_in", f.PacketsIn),
+		intAttr("calico.flow.packets_out", f.PacketsOut),
+		intAttr("calico.flow.connections_started", f.NumConnectionsStarted),
+		intAttr("calico.flow.connections_completed", f.NumConnectionsCompleted),
+		intAttr("calico.flow.connections_live", f.NumConnectionsLive),
+		stringsAttr("calico.flow.policies.enforced", policyStrings(f.Key.GetPolicies().GetEnforcedPolicies())),
+		stringsAttr("calico.flow.policies.pending", policyStrings(f.Key.GetPolicies().GetPendingPolicies())),
+	)
+
+	body, err := json.Marshal(f)
+	if err != nil {
+		// This should never happen, and the attributes still carry all of the information.
+		logrus.WithError(err).Warn("Failed to marshal flow for OTLP log body")
+	}
+
+	return &logspb.LogRecord{
+		TimeUnixNano:         uint64(f.EndTime) * 1e9,
+		ObservedTimeUnixNano: observedTime,
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(body)}},
+		Attributes:           attrs,
+	}
+}
+
+// flowKeyAttributes returns the attributes identifying the given flow. These are shared between log records and
+// metric data points, so that the two can be correlated.
+func flowKeyAttributes(k *proto.FlowKey) []*commonpb.KeyValue {
+	return []*commonpb.KeyValue{
+		stringAttr("source.name", k.SourceName),
+		stringAttr("source.namespace", k.SourceNamespace),
+		stringAttr("source.type", k.SourceType.String()),
+		stringAttr("destination.name", k.DestName),
+		stringAttr("destination.namespace", k.DestNamespace),
+		stringAttr("destination.type", k.DestType.String()),
+		intAttr("destination.port", k.DestPort),
+		stringAttr("destination.service.name", k.DestServiceName),
+		stringAttr("destination.service.namespace", k.DestServiceNamespace),
+		stringAttr("destination.service.port_name", k.DestServicePortName),
+		intAttr("destination.service.port", k.DestServicePort),
+		stringAttr("network.transport", k.Proto),
+		stringAttr("calico.flow.reporter", k.Reporter.String()),
+		stringAttr("calico.flow.action", k.Action.String()),
+	}
+}
+
+func policyStrings(hits []*proto.PolicyHit) []string {
+	var out []string
+	for _, h := range hits {
+		s, err := h.ToString()
+		if err != nil {
+			logrus.WithError(err).Debug("Skipping invalid policy hit")
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func intDataPoint(start, end uint64, v int64, attrs []*commonpb.KeyValue) *metricspb.NumberDataPoint {
+	return &metricspb.NumberDataPoint{
+		StartTimeUnixNano: start,
+		TimeUnixNano:      end,
+		Value:             &metricspb.NumberDataPoint_AsInt{AsInt: v},
+		Attributes:        attrs,
+	}
+}
+
+func stringAttr(k, v string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: k, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}}
+}
+
+func intAttr(k string, v int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: k, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}}
+}
+
+func stringsAttr(k string, vs []string) *commonpb.KeyValue {
+	values := make([]*commonpb.AnyValue, len(vs))
+	for i, v := range vs {
+		values[i] = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	}
+	return &commonpb.KeyValue{Key: k, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}}
+}
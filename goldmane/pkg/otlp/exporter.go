@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlp implements a storage.Sink that exports aggregated flows to an OpenTelemetry receiver using
+// OTLP over gRPC or HTTP. Each flow is exported as a log record, and byte and packet counts are additionally
+// exported as delta sum metrics.
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/goldmane/pkg/storage"
+	"github.com/projectcalico/calico/libcalico-go/lib/health"
+	"github.com/projectcalico/calico/libcalico-go/lib/logutils"
+)
+
+const healthName = "otlp-exporter"
+
+var (
+	exportedFlows = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "goldmane_otlp_exported_flows_total",
+		Help: "Total number of flows exported over OTLP.",
+	})
+	droppedFlows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "goldmane_otlp_dropped_flows_total",
+		Help: "Total number of flows dropped by the OTLP exporter, by reason.",
+	}, []string{"reason"})
+	exportErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "goldmane_otlp_export_errors_total",
+		Help: "Total number of failed OTLP export attempts, including those that were later retried successfully.",
+	})
+	queueSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "goldmane_otlp_queue_size",
+		Help: "Number of flow collections waiting to be exported over OTLP.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(exportedFlows)
+	prometheus.MustRegister(droppedFlows)
+	prometheus.MustRegister(exportErrors)
+	prometheus.MustRegister(queueSize)
+}
+
+// Exporter is a storage.Sink that exports aggregated Flow objects to an OTLP receiver.
+//
+// Flow collections are buffered in a bounded queue and exported in order by a single goroutine. Failed exports
+// are retried with exponential backoff, honouring any delay requested by the receiver. While the receiver is
+// unavailable or applying backpressure the queue fills up, at which point the oldest collections are dropped so
+// that Goldmane's main loop is never blocked and memory use remains bounded.
+type Exporter struct {
+	client client
+
+	// Configuration for the OTLP endpoint.
+	endpoint   string
+	protocol   string
+	insecure   bool
+	caCert     string
+	clientKey  string
+	clientCert string
+	headers    map[string]string
+	timeout    time.Duration
+
+	// Queueing and retry configuration.
+	maxQueueSize   int
+	maxBatchSize   int
+	maxRetryTime   time.Duration
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	queue chan *storage.FlowCollection
+
+	// For health checking.
+	health *health.HealthAggregator
+
+	// nowFunc allows overriding the current time, used in tests.
+	nowFunc func() time.Time
+
+	// rl is used to rate limit log messages that may happen frequently.
+	rl *logutils.RateLimitedLogger
+}
+
+// Make sure Exporter implements the Sink interface to be able to receive aggregated Flows.
+var _ storage.Sink = &Exporter{}
+
+func NewExporter(opts ...Option) (*Exporter, error) {
+	e := &Exporter{
+		protocol:       ProtocolGRPC,
+		timeout:        10 * time.Second,
+		maxQueueSize:   100,
+		maxBatchSize:   1000,
+		maxRetryTime:   5 * time.Minute,
+		initialBackoff: 1 * time.Second,
+		maxBackoff:     30 * time.Second,
+		nowFunc:        time.Now,
+		rl: logutils.NewRateLimitedLogger(
+			logutils.OptBurst(1),
+			logutils.OptInterval(15*time.Second),
+		),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.endpoint == "" {
+		return nil, fmt.Errorf("an OTLP endpoint must be provided")
+	}
+	if e.maxQueueSize < 1 || e.maxBatchSize < 1 {
+		return nil, fmt.Errorf("queue and batch sizes must be positive")
+	}
+	e.queue = make(chan *storage.FlowCollection, e.maxQueueSize)
+
+	var err error
+	e.client, err = newClient(e)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithFields(logrus.Fields{
+		"endpoint": e.endpoint,
+		"protocol": e.protocol,
+	}).Info("Created OTLP exporter.")
+	return e, nil
+}
+
+// Receive queues a collection of flows for export. It never blocks: if the queue is full, the oldest queued
+// collection is dropped to make room.
+func (e *Exporter) Receive(bucket *storage.FlowCollection) {
+	for {
+		select {
+		case e.queue <- bucket:
+			queueSize.Set(float64(len(e.queue)))
+			return
+		default:
+		}
+
+		// The queue is full. Drop the oldest collection and try again.
+		select {
+		case dropped := <-e.queue:
+			droppedFlows.WithLabelValues("queue_full").Add(float64(len(dropped.Flows)))
+			e.rl.WithField("bucketStart", dropped.StartTime).Warn("OTLP export queue full, dropping oldest flows")
+		default:
+		}
+	}
+}
+
+func (e *Exporter) Run(ctx context.Context) {
+	if e.health != nil {
+		// Register the exporter with the health aggregator. We don't use a timeout here, since the work of the
+		// exporter is fully reactive to the queue. As with the emitter, we never mark ourselves as not ready, since
+		// doing so would prevent Goldmane from receiving any more flows.
+		e.health.RegisterReporter(healthName, &health.HealthReport{Live: true, Ready: true}, 0)
+		e.health.Report(healthName, &health.HealthReport{Live: true, Ready: true})
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Info("Context cancelled, shutting down OTLP exporter.")
+			return
+		case bucket := <-e.queue:
+			queueSize.Set(float64(len(e.queue)))
+			e.export(ctx, bucket)
+		}
+	}
+}
+
+// export sends the given collection to the receiver in batches of at most maxBatchSize flows.
+func (e *Exporter) export(ctx context.Context, bucket *storage.FlowCollection) {
+	for start := 0; start < len(bucket.Flows); start += e.maxBatchSize {
+		end := min(start+e.maxBatchSize, len(bucket.Flows))
+		b := newBatch(bucket.StartTime, bucket.EndTime, bucket.Flows[start:end], uint64(e.nowFunc().UnixNano()))
+
+		logCtx := logrus.WithFields(logrus.Fields{
+			"bucketStart": bucket.StartTime,
+			"bucketEnd":   bucket.EndTime,
+			"flows":       b.numFlows,
+		})
+		if err := e.withRetry(ctx, func(ctx context.Context) error { return e.client.exportLogs(ctx, b.logs) }); err != nil {
+			logCtx.WithError(err).Error("Failed to export flow logs over OTLP, dropping flows.")
+			droppedFlows.WithLabelValues("export_failed").Add(float64(b.numFlows))
+			continue
+		}
+		if err := e.withRetry(ctx, func(ctx context.Context) error { return e.client.exportMetrics(ctx, b.metrics) }); err != nil {
+			// The flows themselves were exported, so don't count them as dropped.
+			logCtx.WithError(err).Error("Failed to export flow metrics over OTLP.")
+		}
+		exportedFlows.Add(float64(b.numFlows))
+		logCtx.Debug("Exported flows over OTLP.")
+	}
+}
+
+// withRetry calls fn until it succeeds, returns a non-retryable error, or the maximum retry time has elapsed.
+func (e *Exporter) withRetry(ctx context.Context, fn func(context.Context) error) error {
+	deadline := e.nowFunc().Add(e.maxRetryTime)
+	backoff := e.initialBackoff
+	for {
+		reqCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		err := fn(reqCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		exportErrors.Inc()
+
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return err
+		}
+		delay := backoff
+		if retryable.delay > 0 {
+			delay = retryable.delay
+		}
+		if e.nowFunc().Add(delay).After(deadline) {
+			return fmt.Errorf("giving up after %s: %w", e.maxRetryTime, err)
+		}
+		e.rl.WithError(err).WithField("delay", delay).Warn("OTLP export failed, will retry")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		backoff = min(backoff*2, e.maxBackoff)
+	}
+}
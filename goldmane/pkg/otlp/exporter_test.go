@@ -0,0 +1,281 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	"unique"
+
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	googleproto "google.golang.org/protobuf/proto"
+
+	"github.com/projectcalico/calico/goldmane/pkg/storage"
+	"github.com/projectcalico/calico/goldmane/pkg/types"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+func testFlow(name string, bytesIn int64) types.Flow {
+	return types.Flow{
+		Key: types.NewFlowKey(
+			&types.FlowKeySource{SourceName: name, SourceNamespace: "default", SourceType: proto.EndpointType_WorkloadEndpoint},
+			&types.FlowKeyDestination{DestName: "dst", DestNamespace: "default", DestType: proto.EndpointType_WorkloadEndpoint, DestPort: 80},
+			&types.FlowKeyMeta{Proto: "tcp", Reporter: proto.Reporter_Src, Action: proto.Action_Allow},
+			&proto.PolicyTrace{},
+		),
+		StartTime:    100,
+		EndTime:      115,
+		SourceLabels: unique.Make("app=" + name),
+		DestLabels:   unique.Make(""),
+		BytesIn:      bytesIn,
+		PacketsIn:    1,
+	}
+}
+
+func collection(flows ...types.Flow) *storage.FlowCollection {
+	c := storage.NewFlowCollection(100, 115)
+	for _, f := range flows {
+		c.AddFlow(f)
+	}
+	return c
+}
+
+// collector is a fake OTLP gRPC receiver.
+type collector struct {
+	collogspb.UnimplementedLogsServiceServer
+	colmetricspb.UnimplementedMetricsServiceServer
+
+	sync.Mutex
+	logs     []*collogspb.ExportLogsServiceRequest
+	metrics  []*colmetricspb.ExportMetricsServiceRequest
+	failures int
+	md       metadata.MD
+}
+
+func (c *collector) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	c.md, _ = metadata.FromIncomingContext(ctx)
+	c.logs = append(c.logs, req)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+type metricsCollector struct {
+	*collector
+}
+
+func (c metricsCollector) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	c.metrics = append(c.metrics, req)
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *collector) numLogs() int {
+	c.Lock()
+	defer c.Unlock()
+	n := 0
+	for _, req := range c.logs {
+		n += len(req.ResourceLogs[0].ScopeLogs[0].LogRecords)
+	}
+	return n
+}
+
+func startCollector(t *testing.T) (*collector, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	c := &collector{}
+	srv := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(srv, c)
+	colmetricspb.RegisterMetricsServiceServer(srv, metricsCollector{c})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return c, lis.Addr().String()
+}
+
+func attr(attrs []*commonpb.KeyValue, key string) *commonpb.AnyValue {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+func TestExportGRPC(t *testing.T) {
+	c, addr := startCollector(t)
+	c.failures = 2
+
+	e, err := NewExporter(
+		WithEndpoint(addr),
+		WithInsecure(true),
+		WithHeaders(map[string]string{"authorization": "Bearer abc"}),
+		WithMaxBatchSize(2),
+		WithRetryBackoff(10*time.Millisecond, 20*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	e.Receive(collection(testFlow("a", 10), testFlow("b", 20), testFlow("c", 30)))
+
+	// The first attempts fail and are retried. Flows are split into batches of at most two.
+	require.Eventually(t, func() bool {
+		c.Lock()
+		defer c.Unlock()
+		return len(c.metrics) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 3, c.numLogs())
+
+	c.Lock()
+	defer c.Unlock()
+	require.Len(t, c.logs, 2)
+	require.Equal(t, []string{"Bearer abc"}, c.md.Get("authorization"))
+
+	rec := c.logs[0].ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+	require.Equal(t, uint64(115e9), rec.TimeUnixNano)
+	require.Equal(t, "a", attr(rec.Attributes, "source.name").GetStringValue())
+	require.Equal(t, int64(80), attr(rec.Attributes, "destination.port").GetIntValue())
+	require.Equal(t, "Allow", attr(rec.Attributes, "calico.flow.action").GetStringValue())
+	require.Equal(t, "app=a", attr(rec.Attributes, "source.labels").GetArrayValue().Values[0].GetStringValue())
+	require.Contains(t, rec.Body.GetStringValue(), `"source_name":"a"`)
+
+	require.Len(t, c.metrics, 2)
+	metrics := c.metrics[0].ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Equal(t, metricBytes, metrics[0].Name)
+	points := metrics[0].GetSum().DataPoints
+	require.Len(t, points, 4, "Expected an in and out data point for each flow")
+	require.Equal(t, int64(10), points[0].GetAsInt())
+	require.Equal(t, "in", attr(points[0].Attributes, "network.io.direction").GetStringValue())
+	require.Equal(t, uint64(100e9), points[0].StartTimeUnixNano)
+	require.Equal(t, uint64(115e9), points[0].TimeUnixNano)
+}
+
+func TestExportHTTP(t *testing.T) {
+	var lock sync.Mutex
+	var logs []*collogspb.ExportLogsServiceRequest
+	var metricReqs int
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		require.Equal(t, contentTypeProtobuf, r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		switch r.URL.Path {
+		case "/v1/logs":
+			attempts++
+			if attempts == 1 {
+				// Apply backpressure on the first attempt.
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			req := &collogspb.ExportLogsServiceRequest{}
+			require.NoError(t, googleproto.Unmarshal(body, req))
+			logs = append(logs, req)
+			out, _ := googleproto.Marshal(&collogspb.ExportLogsServiceResponse{})
+			_, _ = w.Write(out)
+		case "/v1/metrics":
+			metricReqs++
+			out, _ := googleproto.Marshal(&colmetricspb.ExportMetricsServiceResponse{})
+			_, _ = w.Write(out)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	e, err := NewExporter(
+		WithEndpoint(srv.URL),
+		WithProtocol(ProtocolHTTP),
+		WithRetryBackoff(10*time.Millisecond, 20*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	e.Receive(collection(testFlow("a", 10)))
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(logs) == 1 && metricReqs == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 2, attempts)
+}
+
+func TestExportNonRetryable(t *testing.T) {
+	e := &Exporter{
+		timeout:        time.Second,
+		maxRetryTime:   time.Minute,
+		initialBackoff: time.Millisecond,
+		maxBackoff:     time.Millisecond,
+		nowFunc:        time.Now,
+	}
+	calls := 0
+	err := e.withRetry(context.Background(), func(context.Context) error {
+		calls++
+		return grpcError(status.Error(codes.InvalidArgument, "bad data"))
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, calls, "Non-retryable errors should not be retried")
+
+	// Resource exhaustion without retry info from the receiver isn't retryable either.
+	calls = 0
+	err = e.withRetry(context.Background(), func(context.Context) error {
+		calls++
+		return grpcError(status.Error(codes.ResourceExhausted, "slow down"))
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestReceiveDropsOldestWhenFull(t *testing.T) {
+	_, addr := startCollector(t)
+	e, err := NewExporter(WithEndpoint(addr), WithInsecure(true), WithMaxQueueSize(2))
+	require.NoError(t, err)
+
+	// The exporter isn't running, so nothing is drained from the queue.
+	for i := range 3 {
+		c := collection(testFlow("a", 10))
+		c.StartTime = int64(i)
+		e.Receive(c)
+	}
+	require.Len(t, e.queue, 2)
+	require.Equal(t, int64(1), (<-e.queue).StartTime)
+	require.Equal(t, int64(2), (<-e.queue).StartTime)
+}
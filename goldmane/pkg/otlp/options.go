@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"time"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/health"
+)
+
+type Option func(*Exporter)
+
+// WithEndpoint sets the address of the OTLP receiver. For gRPC, this is a host:port. For HTTP, this is a
+// base URL to which the standard /v1/logs and /v1/metrics paths are appended.
+func WithEndpoint(endpoint string) Option {
+	return func(e *Exporter) {
+		e.endpoint = endpoint
+	}
+}
+
+// WithProtocol sets the OTLP transport to use. Valid values are "grpc" and "http".
+func WithProtocol(protocol string) Option {
+	return func(e *Exporter) {
+		e.protocol = protocol
+	}
+}
+
+// WithInsecure disables TLS when connecting to the OTLP receiver.
+func WithInsecure(insecure bool) Option {
+	return func(e *Exporter) {
+		e.insecure = insecure
+	}
+}
+
+func WithCACertPath(path string) Option {
+	return func(e *Exporter) {
+		e.caCert = path
+	}
+}
+
+func WithClientKeyPath(path string) Option {
+	return func(e *Exporter) {
+		e.clientKey = path
+	}
+}
+
+func WithClientCertPath(path string) Option {
+	return func(e *Exporter) {
+		e.clientCert = path
+	}
+}
+
+// WithHeaders sets additional headers (or gRPC metadata) to send with each export request, for example
+// to authenticate with the receiver.
+func WithHeaders(headers map[string]string) Option {
+	return func(e *Exporter) {
+		e.headers = headers
+	}
+}
+
+// WithTimeout sets the timeout for each individual export request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *Exporter) {
+		e.timeout = timeout
+	}
+}
+
+// WithMaxQueueSize sets the maximum number of flow collections to buffer while waiting to be exported. Once
+// full, the oldest collections are dropped to make room for new ones.
+func WithMaxQueueSize(size int) Option {
+	return func(e *Exporter) {
+		e.maxQueueSize = size
+	}
+}
+
+// WithMaxBatchSize sets the maximum number of flows to include in a single export request.
+func WithMaxBatchSize(size int) Option {
+	return func(e *Exporter) {
+		e.maxBatchSize = size
+	}
+}
+
+// WithMaxRetryTime sets the maximum amount of time to spend retrying a single export request before
+// dropping it.
+func WithMaxRetryTime(d time.Duration) Option {
+	return func(e *Exporter) {
+		e.maxRetryTime = d
+	}
+}
+
+// WithRetryBackoff sets the initial and maximum delays between retries of a failed export request.
+func WithRetryBackoff(initial, max time.Duration) Option {
+	return func(e *Exporter) {
+		e.initialBackoff = initial
+		e.maxBackoff = max
+	}
+}
+
+func WithHealthAggregator(agg *health.HealthAggregator) Option {
+	return func(e *Exporter) {
+		e.health = agg
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recommendation synthesises least-privilege policy from observed Flows.
+//
+// Endpoints are grouped by a label selector derived from their labels, rather than by name, so that a
+// recommendation covers every replica of a workload. Each group in the requested namespace that was observed
+// sending or receiving allowed traffic gets one policy, containing one Allow rule per distinct peer and protocol.
+// Peers that aren't endpoints are matched by the private or public network ranges that Felix reported them in;
+// flows whose peer can't be identified at all don't produce rules.
+package recommendation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+const (
+	defaultTier = "default"
+
+	// maxNameLength is the maximum length of a generated policy name, leaving room for a tier prefix
+	// and a de-duplication suffix within the 253 character limit for resource names.
+	maxNameLength = 200
+)
+
+// privateNets are the CIDRs that Felix classifies as private networks when reporting flows to or from
+// addresses that aren't known endpoints (see FlowKey.SourceName).
+var privateNets = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+const (
+	publicNetwork  = "pub"
+	privateNetwork = "pvt"
+)
+
+// identityKeys are label keys that conventionally identify an application. If an endpoint has one of these,
+// it is used on its own to select the endpoint, in order of preference.
+var identityKeys = []string{
+	"app.kubernetes.io/name",
+	"app",
+	"k8s-app",
+	"name",
+}
+
+// ignoredLabelPrefixes are label keys (or prefixes) whose values vary between replicas of the same workload, or which
+// are added by Calico itself. These are never used to group endpoints.
+var ignoredLabelPrefixes = []string{
+	"projectcalico.org/",
+	"pod-template-hash",
+	"pod-template-generation",
+	"controller-revision-hash",
+	"controller-uid",
+	"batch.kubernetes.io/controller-uid",
+	"statefulset.kubernetes.io/pod-name",
+	"apps.kubernetes.io/pod-index",
+}
+
+// Recommend returns a policy recommendation for each group of endpoints in the given namespace that appears in
+// the given flows. Only allowed flows are considered, and each flow is only used from the perspective of the
+// reporter within the namespace, so that a single connection doesn't produce rules twice.
+func Recommend(namespace, tier string, flows []*proto.Flow) []*proto.PolicyRecommendation {
+	if tier == "" {
+		tier = defaultTier
+	}
+
+	groups := map[string]*group{}
+	getGroup := func(labels []string) *group {
+		selector, name := groupSelector(labels)
+		if selector == "" {
+			return nil
+		}
+		g, ok := groups[selector]
+		if !ok {
+			g = &group{
+				selector: selector,
+				name:     name,
+				ingress:  map[ruleKey]map[int64]struct{}{},
+				egress:   map[ruleKey]map[int64]struct{}{},
+			}
+			groups[selector] = g
+		}
+		return g
+	}
+
+	for _, f := range flows {
+		k := f.GetKey()
+		if k == nil || k.Action != proto.Action_Allow {
+			continue
+		}
+		switch {
+		case k.Reporter == proto.Reporter_Dst && k.DestType == proto.EndpointType_WorkloadEndpoint && k.DestNamespace == namespace:
+			g := getGroup(f.DestLabels)
+			if g == nil {
+				logrus.WithField("flow", k).Debug("Destination has no identifying labels, skipping")
+				continue
+			}
+			key, ok := peerKey(namespace, k.SourceType, k.SourceName, k.SourceNamespace, f.SourceLabels, k.Proto)
+			if !ok {
+				logrus.WithField("flow", k).Debug("Source can't be identified, skipping")
+				continue
+			}
+			g.add(g.ingress, key, k.DestPort)
+		case k.Reporter == proto.Reporter_Src && k.SourceType == proto.EndpointType_WorkloadEndpoint && k.SourceNamespace == namespace:
+			g := getGroup(f.SourceLabels)
+			if g == nil {
+				logrus.WithField("flow", k).Debug("Source has no identifying labels, skipping")
+				continue
+			}
+			key, ok := peerKey(namespace, k.DestType, k.DestName, k.DestNamespace, f.DestLabels, k.Proto)
+			if !ok {
+				logrus.WithField("flow", k).Debug("Destination can't be identified, skipping")
+				continue
+			}
+			g.add(g.egress, key, k.DestPort)
+		}
+	}
+
+	var recs []*proto.PolicyRecommendation
+	for _, g := range groups {
+		recs = append(recs, &proto.PolicyRecommendation{
+			Namespace: namespace,
+			Tier:      tier,
+			Selector:  g.selector,
+			Ingress:   g.rules(g.ingress),
+			Egress:    g.rules(g.egress),
+		})
+	}
+	slices.SortFunc(recs, func(a, b *proto.PolicyRecommendation) int {
+		return strings.Compare(a.Selector, b.Selector)
+	})
+
+	// Assign names once the order is stable, so that any de-duplication suffixes are deterministic.
+	used := map[string]bool{}
+	for _, r := range recs {
+		base := "recommended-" + groups[r.Selector].name
+		if tier != defaultTier {
+			base = tier + "." + base
+		}
+		name := base
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		used[name] = true
+		r.Name = name
+	}
+	return recs
+}
+
+// group accumulates the observed traffic for a set of endpoints sharing the same selector.
+type group struct {
+	selector string
+	name     string
+	ingress  map[ruleKey]map[int64]struct{}
+	egress   map[ruleKey]map[int64]struct{}
+}
+
+// ruleKey identifies a single recommended rule. Ports for the same peer and protocol are merged into one rule.
+type ruleKey struct {
+	protocol          string
+	selector          string
+	namespaceSelector string
+	// network is set to "pub" or "pvt" for peers that aren't known endpoints.
+	network string
+}
+
+func (g *group) add(rules map[ruleKey]map[int64]struct{}, key ruleKey, port int64) {
+	ports, ok := rules[key]
+	if !ok {
+		ports = map[int64]struct{}{}
+		rules[key] = ports
+	}
+	if port != 0 {
+		ports[port] = struct{}{}
+	}
+}
+
+func (g *group) rules(rules map[ruleKey]map[int64]struct{}) []*proto.RecommendedRule {
+	var out []*proto.RecommendedRule
+	for key, ports := range rules {
+		r := &proto.RecommendedRule{
+			Protocol:          key.protocol,
+			Selector:          key.selector,
+			NamespaceSelector: key.namespaceSelector,
+		}
+		switch key.network {
+		case privateNetwork:
+			r.Nets = privateNets
+		case publicNetwork:
+			r.NotNets = privateNets
+		}
+		for p := range ports {
+			r.Ports = append(r.Ports, p)
+		}
+		slices.Sort(r.Ports)
+		out = append(out, r)
+	}
+	slices.SortFunc(out, func(a, b *proto.RecommendedRule) int {
+		if c := strings.Compare(a.NamespaceSelector, b.NamespaceSelector); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Selector, b.Selector); c != 0 {
+			return c
+		}
+		if c := slices.Compare(a.Nets, b.Nets); c != 0 {
+			return c
+		}
+		if c := slices.Compare(a.NotNets, b.NotNets); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Protocol, b.Protocol)
+	})
+	return out
+}
+
+// peerKey returns the rule key matching the given peer of an endpoint in the given namespace. It returns false if
+// the peer can't be identified, in which case no rule should be generated: allowing any peer would be far broader
+// than the observed traffic.
+func peerKey(namespace string, t proto.EndpointType, name, peerNamespace string, labels []string, protocol string) (ruleKey, bool) {
+	key := ruleKey{protocol: protocol}
+	switch t {
+	case proto.EndpointType_WorkloadEndpoint, proto.EndpointType_HostEndpoint, proto.EndpointType_NetworkSet:
+		key.selector, _ = groupSelector(labels)
+		if key.selector == "" {
+			key.selector = "all()"
+		}
+		if peerNamespace == "" {
+			// Host endpoints and global network sets aren't namespaced.
+			key.namespaceSelector = "global()"
+		} else if peerNamespace != namespace {
+			key.namespaceSelector = fmt.Sprintf("projectcalico.org/name == '%s'", peerNamespace)
+		}
+	case proto.EndpointType_Network:
+		// Flows only record whether the peer was on a private or public network, not its address, so
+		// match the same ranges that Felix used to classify it.
+		if name != privateNetwork && name != publicNetwork {
+			return ruleKey{}, false
+		}
+		key.network = name
+	default:
+		return ruleKey{}, false
+	}
+	return key, true
+}
+
+// groupSelector returns a selector matching endpoints with the same identity as an endpoint with the given labels,
+// along with a name for the group suitable for use in a policy name. It returns an empty selector if the labels
+// don't identify the endpoint.
+func groupSelector(labels []string) (string, string) {
+	kvs := map[string]string{}
+	for _, l := range labels {
+		k, v, _ := strings.Cut(l, "=")
+		if ignoredLabel(k) {
+			continue
+		}
+		kvs[k] = v
+	}
+
+	var keys []string
+	for _, k := range identityKeys {
+		if _, ok := kvs[k]; ok {
+			keys = []string{k}
+			break
+		}
+	}
+	if keys == nil {
+		for k := range kvs {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+	}
+	if len(keys) == 0 {
+		return "", ""
+	}
+
+	var terms, values []string
+	for _, k := range keys {
+		terms = append(terms, fmt.Sprintf("%s == '%s'", k, kvs[k]))
+		values = append(values, kvs[k])
+	}
+	return strings.Join(terms, " && "), sanitizeName(strings.Join(values, "-"))
+}
+
+func ignoredLabel(key string) bool {
+	for _, p := range ignoredLabelPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeName converts the given string into a valid DNS subdomain for use as a resource name.
+func sanitizeName(s string) string {
+	b := []byte(strings.ToLower(s))
+	for i, c := range b {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			b[i] = '-'
+		}
+	}
+	name := strings.Trim(string(b), "-")
+	if len(name) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength], "-")
+	}
+	if name == "" {
+		name = "endpoints"
+	}
+	return name
+}
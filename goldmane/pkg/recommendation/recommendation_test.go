@@ -0,0 +1,188 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommendation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	googleproto "google.golang.org/protobuf/proto"
+
+	"github.com/projectcalico/calico/goldmane/pkg/recommendation"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+func wepFlow(reporter proto.Reporter, action proto.Action, srcNS string, srcLabels []string, dstNS string, dstLabels []string, port int64) *proto.Flow {
+	return &proto.Flow{
+		Key: &proto.FlowKey{
+			SourceName:      "src-*",
+			SourceNamespace: srcNS,
+			SourceType:      proto.EndpointType_WorkloadEndpoint,
+			DestName:        "dst-*",
+			DestNamespace:   dstNS,
+			DestType:        proto.EndpointType_WorkloadEndpoint,
+			DestPort:        port,
+			Proto:           "tcp",
+			Reporter:        reporter,
+			Action:          action,
+		},
+		SourceLabels: srcLabels,
+		DestLabels:   dstLabels,
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	frontend := []string{"app=frontend", "pod-template-hash=abc123", "projectcalico.org/namespace=shop"}
+	backend := []string{"app=backend", "version=v1"}
+	monitor := []string{"k8s-app=monitor"}
+
+	flows := []*proto.Flow{
+		// frontend -> backend within the namespace, reported at both ends.
+		wepFlow(proto.Reporter_Src, proto.Action_Allow, "shop", frontend, "shop", backend, 8080),
+		wepFlow(proto.Reporter_Dst, proto.Action_Allow, "shop", frontend, "shop", backend, 8080),
+		wepFlow(proto.Reporter_Dst, proto.Action_Allow, "shop", frontend, "shop", backend, 8443),
+
+		// Monitoring from another namespace into the backend.
+		wepFlow(proto.Reporter_Dst, proto.Action_Allow, "monitoring", monitor, "shop", backend, 9090),
+
+		// Denied traffic should never be allowed by a recommendation.
+		wepFlow(proto.Reporter_Dst, proto.Action_Deny, "monitoring", monitor, "shop", frontend, 22),
+
+		// Egress from the frontend to the internet.
+		{
+			Key: &proto.FlowKey{
+				SourceNamespace: "shop",
+				SourceType:      proto.EndpointType_WorkloadEndpoint,
+				DestName:        "pub",
+				DestType:        proto.EndpointType_Network,
+				DestPort:        443,
+				Proto:           "tcp",
+				Reporter:        proto.Reporter_Src,
+				Action:          proto.Action_Allow,
+			},
+			SourceLabels: frontend,
+		},
+
+		// Ingress to the backend from the private network.
+		{
+			Key: &proto.FlowKey{
+				SourceName:    "pvt",
+				SourceType:    proto.EndpointType_Network,
+				DestNamespace: "shop",
+				DestType:      proto.EndpointType_WorkloadEndpoint,
+				DestPort:      8080,
+				Proto:         "tcp",
+				Reporter:      proto.Reporter_Dst,
+				Action:        proto.Action_Allow,
+			},
+			DestLabels: backend,
+		},
+
+		// A peer that can't be identified shouldn't result in a rule that allows any peer.
+		{
+			Key: &proto.FlowKey{
+				SourceNamespace: "shop",
+				SourceType:      proto.EndpointType_WorkloadEndpoint,
+				DestPort:        53,
+				Proto:           "udp",
+				Reporter:        proto.Reporter_Src,
+				Action:          proto.Action_Allow,
+			},
+			SourceLabels: frontend,
+		},
+	}
+
+	recs := recommendation.Recommend("shop", "", flows)
+	expected := []*proto.PolicyRecommendation{
+		{
+			Name:      "recommended-backend",
+			Namespace: "shop",
+			Tier:      "default",
+			Selector:  "app == 'backend'",
+			Ingress: []*proto.RecommendedRule{
+				{Protocol: "tcp", Ports: []int64{8080}, Nets: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}},
+				{Protocol: "tcp", Ports: []int64{8080, 8443}, Selector: "app == 'frontend'"},
+				{Protocol: "tcp", Ports: []int64{9090}, Selector: "k8s-app == 'monitor'", NamespaceSelector: "projectcalico.org/name == 'monitoring'"},
+			},
+		},
+		{
+			Name:      "recommended-frontend",
+			Namespace: "shop",
+			Tier:      "default",
+			Selector:  "app == 'frontend'",
+			Egress: []*proto.RecommendedRule{
+				{Protocol: "tcp", Ports: []int64{443}, NotNets: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}},
+				{Protocol: "tcp", Ports: []int64{8080}, Selector: "app == 'backend'"},
+			},
+		},
+	}
+	require.Len(t, recs, len(expected))
+	for i := range expected {
+		require.True(t, googleproto.Equal(expected[i], recs[i]), "Recommendation %d mismatch:\nexpected %v\ngot %v", i, expected[i], recs[i])
+	}
+}
+
+func TestRecommendGrouping(t *testing.T) {
+	t.Run("labels without an identity key are used together", func(t *testing.T) {
+		flows := []*proto.Flow{
+			wepFlow(proto.Reporter_Dst, proto.Action_Allow, "ns", nil, "ns", []string{"tier=web", "team=Red_Team", "controller-revision-hash=1"}, 80),
+		}
+		recs := recommendation.Recommend("ns", "security", flows)
+		require.Len(t, recs, 1)
+		require.Equal(t, "team == 'Red_Team' && tier == 'web'", recs[0].Selector)
+		require.Equal(t, "security.recommended-red-team-web", recs[0].Name)
+		require.Equal(t, "security", recs[0].Tier)
+
+		// The unlabelled source in the same namespace is matched by all().
+		require.Len(t, recs[0].Ingress, 1)
+		require.Equal(t, "all()", recs[0].Ingress[0].Selector)
+		require.Empty(t, recs[0].Ingress[0].NamespaceSelector)
+	})
+
+	t.Run("endpoints without identifying labels are skipped", func(t *testing.T) {
+		flows := []*proto.Flow{
+			wepFlow(proto.Reporter_Dst, proto.Action_Allow, "ns", nil, "ns", []string{"pod-template-hash=abc"}, 80),
+		}
+		require.Empty(t, recommendation.Recommend("ns", "", flows))
+	})
+
+	t.Run("conflicting names are de-duplicated", func(t *testing.T) {
+		flows := []*proto.Flow{
+			wepFlow(proto.Reporter_Dst, proto.Action_Allow, "ns", nil, "ns", []string{"app=a.b"}, 80),
+			wepFlow(proto.Reporter_Dst, proto.Action_Allow, "ns", nil, "ns", []string{"app=a_b"}, 80),
+		}
+		recs := recommendation.Recommend("ns", "", flows)
+		require.Len(t, recs, 2)
+		require.Equal(t, "recommended-a-b", recs[0].Name)
+		require.Equal(t, "recommended-a-b-2", recs[1].Name)
+	})
+
+	t.Run("flows reported outside the namespace are ignored", func(t *testing.T) {
+		flows := []*proto.Flow{
+			// Egress from another namespace into ns doesn't tell us anything about ns's policy.
+			wepFlow(proto.Reporter_Src, proto.Action_Allow, "other", []string{"app=x"}, "ns", []string{"app=y"}, 80),
+		}
+		require.Empty(t, recommendation.Recommend("ns", "", flows))
+	})
+
+	t.Run("non-namespaced peers use global()", func(t *testing.T) {
+		f := wepFlow(proto.Reporter_Dst, proto.Action_Allow, "", []string{"role=node"}, "ns", []string{"app=y"}, 80)
+		f.Key.SourceType = proto.EndpointType_HostEndpoint
+		recs := recommendation.Recommend("ns", "", []*proto.Flow{f})
+		require.Len(t, recs, 1)
+		require.Equal(t, "role == 'node'", recs[0].Ingress[0].Selector)
+		require.Equal(t, "global()", recs[0].Ingress[0].NamespaceSelector)
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/projectcalico/calico/goldmane/pkg/goldmane"
+	"github.com/projectcalico/calico/goldmane/pkg/recommendation"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+func NewPolicyRecommendationsServer(aggr *goldmane.Goldmane) *PolicyRecommendations {
+	return &PolicyRecommendations{
+		gm: aggr,
+	}
+}
+
+type PolicyRecommendations struct {
+	proto.UnimplementedPolicyRecommendationsServer
+
+	gm *goldmane.Goldmane
+}
+
+func (s *PolicyRecommendations) RegisterWith(srv *grpc.Server) {
+	// Register the server with the gRPC server.
+	proto.RegisterPolicyRecommendationsServer(srv, s)
+	logrus.Info("Registered policy recommendations server")
+}
+
+func (s *PolicyRecommendations) List(ctx context.Context, req *proto.PolicyRecommendationRequest) (*proto.PolicyRecommendationResult, error) {
+	if req.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+
+	// Query for allowed flows leaving the namespace and entering the namespace. Flows within the namespace
+	// will be returned by both queries, which is fine as the recommender merges identical rules.
+	ns := []*proto.StringMatch{{Value: req.Namespace, Type: proto.MatchType_Exact}}
+	filters := []*proto.Filter{
+		{SourceNamespaces: ns, Actions: []proto.Action{proto.Action_Allow}},
+		{DestNamespaces: ns, Actions: []proto.Action{proto.Action_Allow}},
+	}
+
+	var flows []*proto.Flow
+	for _, filter := range filters {
+		results, err := s.gm.List(&proto.FlowListRequest{
+			StartTimeGte: req.StartTimeGte,
+			StartTimeLt:  req.StartTimeLt,
+			Filter:       filter,
+		})
+		if err != nil {
+			logrus.WithError(err).Error("Failed to list flows for policy recommendation")
+			return nil, err
+		}
+		for _, r := range results.Flows {
+			flows = append(flows, r.Flow)
+		}
+	}
+
+	return &proto.PolicyRecommendationResult{
+		Recommendations: recommendation.Recommend(req.Namespace, req.Tier, flows),
+	}, nil
+}
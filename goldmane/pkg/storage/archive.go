@@ -0,0 +1,287 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/goldmane/pkg/types"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+// Archive is an interface for durable storage of bucketed flow data. The BucketRing writes each
+// completed bucket to the Archive, and uses it to rehydrate state on startup as well as to serve
+// queries for time ranges that are older than the in-memory window.
+type Archive interface {
+	// Write persists the given flows as the full contents of the bucket covering [start, end). Writing
+	// a bucket that has already been written replaces its previous contents.
+	Write(start, end int64, flows []*types.Flow) error
+
+	// Read calls fn for each archived flow whose bucket starts within [startGte, startLt). A zero
+	// value for either bound means the range is unbounded in that direction.
+	Read(startGte, startLt int64, fn func(*types.Flow) error) error
+}
+
+// archiveWrite is a pending write of a bucket's contents to an Archive.
+type archiveWrite struct {
+	start int64
+	end   int64
+	flows []*types.Flow
+}
+
+// AsyncArchive wraps an Archive so that writes are performed on a background goroutine, keeping
+// slow disk I/O (such as fsyncs) off of the main aggregation loop. Reads are passed straight
+// through to the underlying Archive.
+type AsyncArchive struct {
+	Archive
+
+	writes chan archiveWrite
+}
+
+// NewAsyncArchive returns an AsyncArchive that buffers up to queueSize pending writes to the given Archive.
+// Run must be called for any writes to be persisted.
+func NewAsyncArchive(a Archive, queueSize int) *AsyncArchive {
+	return &AsyncArchive{
+		Archive: a,
+		writes:  make(chan archiveWrite, queueSize),
+	}
+}
+
+// Write queues the given flows to be written to the underlying Archive. It never blocks; if the
+// queue is full, the write is dropped and an error returned.
+func (a *AsyncArchive) Write(start, end int64, flows []*types.Flow) error {
+	select {
+	case a.writes <- archiveWrite{start: start, end: end, flows: flows}:
+		return nil
+	default:
+		return fmt.Errorf("archive write queue is full")
+	}
+}
+
+// Run persists queued writes until the given context is canceled, at which point any writes that
+// are still queued are flushed before returning.
+func (a *AsyncArchive) Run(ctx context.Context) {
+	for {
+		select {
+		case w := <-a.writes:
+			a.write(w)
+		case <-ctx.Done():
+			for {
+				select {
+				case w := <-a.writes:
+					a.write(w)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (a *AsyncArchive) write(w archiveWrite) {
+	if err := a.Archive.Write(w.start, w.end, w.flows); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"start": w.start,
+			"end":   w.end,
+		}).Warn("Failed to archive bucket")
+	}
+}
+
+// archiveBucket writes the contents of the given bucket to the archive, if one is configured.
+func (r *BucketRing) archiveBucket(b *AggregationBucket) {
+	if r.archive == nil || b.Flows == nil || b.Flows.Len() == 0 {
+		return
+	}
+
+	flows := make([]*types.Flow, 0, b.Flows.Len())
+	b.Flows.Iter(func(d *DiachronicFlow) error {
+		if f := d.Aggregate(b.StartTime, b.EndTime); f != nil {
+			flows = append(flows, f)
+		}
+		return nil
+	})
+	if err := r.archive.Write(b.StartTime, b.EndTime, flows); err != nil {
+		logrus.WithError(err).WithFields(b.Fields()).Warn("Failed to archive bucket")
+	}
+}
+
+// rehydrate loads any archived flows that fall within the ring's time window back into memory.
+func (r *BucketRing) rehydrate() {
+	if r.archive == nil {
+		return
+	}
+
+	var num int
+	err := r.archive.Read(r.BeginningOfHistory(), r.EndOfHistory(), func(f *types.Flow) error {
+		r.AddFlow(f)
+		num++
+
+		// Archived buckets were complete before we restarted, and so may already have been emitted.
+		// Mark them as pushed so that they aren't sent to the sink a second time.
+		if _, b := r.findBucket(f.StartTime); b != nil {
+			b.Pushed = true
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to rehydrate flows from archive")
+	}
+	logrus.WithField("num", num).Info("Rehydrated flows from archive")
+}
+
+// maxArchiveListFlows bounds the number of distinct flows that a list request reaching into the
+// archive may aggregate, so that a wide query can't use unbounded memory.
+const maxArchiveListFlows = 100000
+
+// ArchiveList is a list request whose time range begins before the in-memory window. It holds a
+// snapshot of the matching in-memory flows, so that the archive can be read, and the results
+// merged and paged, without holding up the BucketRing's main loop.
+type ArchiveList struct {
+	archive  Archive
+	req      *proto.FlowListRequest
+	boundary int64
+	recent   []*types.Flow
+	maxFlows int
+}
+
+// NeedsArchive returns true if the given request must consult the archive, in which case it should
+// be served with NewArchiveList rather than List.
+func (r *BucketRing) NeedsArchive(req *proto.FlowListRequest) bool {
+	return r.archive != nil && req.StartTimeGte < r.BeginningOfHistory()
+}
+
+// NewArchiveList snapshots the in-memory flows that match the given request. The returned
+// ArchiveList does not reference the ring, so it may be run on a different goroutine.
+func (r *BucketRing) NewArchiveList(req *proto.FlowListRequest) (*ArchiveList, error) {
+	if len(req.SortBy) > 0 {
+		if _, ok := sortValueFuncs[req.SortBy[0].SortBy]; req.SortBy[0].SortBy != proto.SortBy_Time && !ok {
+			return nil, fmt.Errorf("unsupported sort order: %s", req.SortBy[0].SortBy)
+		}
+	}
+
+	l := &ArchiveList{
+		archive:  r.archive,
+		req:      req,
+		boundary: r.BeginningOfHistory(),
+		maxFlows: maxArchiveListFlows,
+	}
+	if req.StartTimeLt == 0 || req.StartTimeLt > l.boundary {
+		l.recent, _ = r.defaultIndex.List(IndexFindOpts{
+			startTimeGt: l.boundary,
+			startTimeLt: req.StartTimeLt,
+			filter:      req.Filter,
+		})
+	}
+	return l, nil
+}
+
+// Run reads the archived flows for the request, streaming them from the archive and aggregating
+// them by key, then merges in the in-memory flows and returns the requested page.
+func (l *ArchiveList) Run() ([]*types.Flow, *types.ListMeta, error) {
+	req := l.req
+	flowsByKey := map[types.FlowKey]*types.Flow{}
+	add := func(f *types.Flow) error {
+		if existing, ok := flowsByKey[*f.Key]; ok {
+			mergeFlows(existing, f)
+			return nil
+		}
+		if len(flowsByKey) >= l.maxFlows {
+			return fmt.Errorf("request matches more than %d flows, narrow the time range or filter", l.maxFlows)
+		}
+		// Take a copy, since merging modifies the flow in place.
+		cp := *f
+		flowsByKey[*f.Key] = &cp
+		return nil
+	}
+
+	// Load archived flows for the portion of the time range before the in-memory window.
+	archiveLt := l.boundary
+	if req.StartTimeLt != 0 && req.StartTimeLt < l.boundary {
+		archiveLt = req.StartTimeLt
+	}
+	err := l.archive.Read(req.StartTimeGte, archiveLt, func(f *types.Flow) error {
+		if !types.Matches(req.Filter, f.Key) {
+			return nil
+		}
+		return add(f)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Merge in the flows from the ring that fall within the requested range.
+	for _, f := range l.recent {
+		if err := add(f); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	flows := make([]*types.Flow, 0, len(flowsByKey))
+	for _, f := range flowsByKey {
+		flows = append(flows, f)
+	}
+	sortBy := proto.SortBy_Time
+	if len(req.SortBy) > 0 {
+		sortBy = req.SortBy[0].SortBy
+	}
+	if sortBy == proto.SortBy_Time {
+		sort.Slice(flows, func(i, j int) bool {
+			return flows[i].StartTime > flows[j].StartTime
+		})
+	} else {
+		sortValue := sortValueFuncs[sortBy]
+		sort.Slice(flows, func(i, j int) bool {
+			return sortValue(flows[i].Key) < sortValue(flows[j].Key)
+		})
+	}
+
+	total := len(flows)
+	if req.PageSize > 0 {
+		startIdx := req.Page * req.PageSize
+		endIdx := startIdx + req.PageSize
+		if startIdx >= int64(len(flows)) {
+			return nil, &types.ListMeta{}, nil
+		}
+		if endIdx > int64(len(flows)) {
+			endIdx = int64(len(flows))
+		}
+		flows = flows[startIdx:endIdx]
+	}
+	meta := calculateListMeta(total, int(req.PageSize))
+	return flows, &meta, nil
+}
+
+// mergeFlows adds the statistics from src into dst, widening dst's time range as needed.
+func mergeFlows(dst, src *types.Flow) {
+	dst.PacketsIn += src.PacketsIn
+	dst.PacketsOut += src.PacketsOut
+	dst.BytesIn += src.BytesIn
+	dst.BytesOut += src.BytesOut
+	dst.NumConnectionsStarted += src.NumConnectionsStarted
+	dst.NumConnectionsCompleted += src.NumConnectionsCompleted
+	dst.NumConnectionsLive += src.NumConnectionsLive
+	dst.SourceLabels = intersection(dst.SourceLabels, src.SourceLabels)
+	dst.DestLabels = intersection(dst.DestLabels, src.DestLabels)
+	if src.StartTime < dst.StartTime {
+		dst.StartTime = src.StartTime
+	}
+	if src.EndTime > dst.EndTime {
+		dst.EndTime = src.EndTime
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"unique"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/goldmane/pkg/types"
+	"github.com/projectcalico/calico/goldmane/proto"
+)
+
+// memArchive is an in-memory Archive for use in tests.
+type memArchive struct {
+	sync.Mutex
+	flows  []*types.Flow
+	writes int
+
+	// block, if set, is waited on by each call to Write.
+	block chan struct{}
+
+	// reads counts the calls to Read.
+	reads int
+}
+
+func (a *memArchive) Write(start, end int64, flows []*types.Flow) error {
+	if a.block != nil {
+		<-a.block
+	}
+	a.Lock()
+	defer a.Unlock()
+	a.writes++
+	a.flows = append(a.flows, flows...)
+	return nil
+}
+
+func (a *memArchive) Read(startGte, startLt int64, fn func(*types.Flow) error) error {
+	a.Lock()
+	defer a.Unlock()
+	a.reads++
+	for _, f := range a.flows {
+		if (startGte == 0 || f.StartTime >= startGte) && (startLt == 0 || f.StartTime < startLt) {
+			if err := fn(f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *memArchive) numWrites() int {
+	a.Lock()
+	defer a.Unlock()
+	return a.writes
+}
+
+// collectionSink records the flow collections that it receives.
+type collectionSink struct {
+	collections []*FlowCollection
+}
+
+func (s *collectionSink) Receive(c *FlowCollection) {
+	s.collections = append(s.collections, c)
+}
+
+func archiveTestFlow(start int64) *types.Flow {
+	return &types.Flow{
+		Key: types.NewFlowKey(
+			&types.FlowKeySource{SourceName: "src", SourceNamespace: "ns"},
+			&types.FlowKeyDestination{DestName: "dst", DestNamespace: "ns"},
+			&types.FlowKeyMeta{Proto: "tcp", Action: proto.Action_Allow},
+			&proto.PolicyTrace{},
+		),
+		StartTime:    start,
+		EndTime:      start + 1,
+		SourceLabels: unique.Make("app=src"),
+		DestLabels:   unique.Make("app=dst"),
+		BytesIn:      100,
+	}
+}
+
+func TestAsyncArchive(t *testing.T) {
+	defer setupTest(t)()
+
+	mem := &memArchive{block: make(chan struct{})}
+	a := NewAsyncArchive(mem, 1)
+
+	// With nothing consuming the queue, the first write is buffered and the second is rejected
+	// rather than blocking the caller.
+	Expect(a.Write(0, 1, []*types.Flow{archiveTestFlow(0)})).To(Succeed())
+	Expect(a.Write(1, 2, []*types.Flow{archiveTestFlow(1)})).NotTo(Succeed())
+
+	// Writes are persisted in the background, even while the underlying archive is slow.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		a.Run(ctx)
+		close(done)
+	}()
+	Eventually(func() error { return a.Write(1, 2, []*types.Flow{archiveTestFlow(1)}) }).Should(Succeed())
+	close(mem.block)
+	Eventually(mem.numWrites).Should(Equal(2))
+
+	// Queued writes are flushed on shutdown.
+	cancel()
+	Eventually(done).Should(BeClosed())
+	Expect(a.Write(2, 3, []*types.Flow{archiveTestFlow(2)})).To(Succeed())
+	a.Run(ctx)
+	Expect(mem.numWrites()).To(Equal(3))
+
+	// Reads go straight to the underlying archive.
+	var num int
+	Expect(a.Read(0, 0, func(*types.Flow) error {
+		num++
+		return nil
+	})).To(Succeed())
+	Expect(num).To(Equal(3))
+}
+
+func TestRehydratedBucketsNotReemitted(t *testing.T) {
+	defer setupTest(t)()
+
+	now := int64(1000)
+	newRing := func(opts ...BucketRingOption) *BucketRing {
+		opts = append(opts,
+			WithBucketsToAggregate(2),
+			WithPushAfter(1),
+			WithNowFunc(func() time.Time { return time.Unix(now, 0) }),
+		)
+		return NewBucketRing(11, 1, now, opts...)
+	}
+
+	// The flow falls within the next window that the ring will emit, so a flow received live is emitted.
+	r := newRing()
+	r.AddFlow(archiveTestFlow(now - 3))
+	sink := &collectionSink{}
+	r.EmitFlowCollections(sink)
+	Expect(sink.collections).To(HaveLen(1))
+
+	// The same flow loaded from the archive was complete before the restart, so may already have
+	// been emitted. It is available to queries but not emitted again.
+	r = newRing(WithArchive(&memArchive{flows: []*types.Flow{archiveTestFlow(now - 3)}}))
+	flows, _, err := r.List(&proto.FlowListRequest{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(flows).To(HaveLen(1))
+	sink = &collectionSink{}
+	r.EmitFlowCollections(sink)
+	Expect(sink.collections).To(BeEmpty())
+}
+
+func TestArchiveList(t *testing.T) {
+	defer setupTest(t)()
+
+	now := int64(1000)
+	mem := &memArchive{flows: []*types.Flow{archiveTestFlow(now - 100), archiveTestFlow(now - 90)}}
+	r := NewBucketRing(11, 1, now,
+		WithArchive(mem),
+		WithNowFunc(func() time.Time { return time.Unix(now, 0) }),
+	)
+	r.AddFlow(archiveTestFlow(now - 3))
+	reads := mem.reads
+
+	req := &proto.FlowListRequest{StartTimeGte: now - 200}
+	Expect(r.NeedsArchive(req)).To(BeTrue())
+	Expect(r.NeedsArchive(&proto.FlowListRequest{StartTimeGte: now - 5})).To(BeFalse())
+
+	// Preparing the list only snapshots the ring; the archive is read when the list is run, which
+	// may be on another goroutine.
+	l, err := r.NewArchiveList(req)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mem.reads).To(Equal(reads))
+
+	flows, meta, err := l.Run()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mem.reads).To(Equal(reads + 1))
+	Expect(flows).To(HaveLen(1))
+	Expect(meta.TotalResults).To(Equal(1))
+	Expect(flows[0].BytesIn).To(Equal(int64(300)))
+	Expect(flows[0].StartTime).To(Equal(now - 100))
+
+	// Merging doesn't modify the archived flows.
+	Expect(mem.flows[0].BytesIn).To(Equal(int64(100)))
+
+	// A request that matches too many distinct flows fails rather than using unbounded memory.
+	other := archiveTestFlow(now - 80)
+	other.Key = types.NewFlowKey(
+		&types.FlowKeySource{SourceName: "other", SourceNamespace: "ns"},
+		&types.FlowKeyDestination{DestName: "dst", DestNamespace: "ns"},
+		&types.FlowKeyMeta{Proto: "tcp", Action: proto.Action_Allow},
+		&proto.PolicyTrace{},
+	)
+	mem.flows = append(mem.flows, other)
+	l, err = r.NewArchiveList(req)
+	Expect(err).NotTo(HaveOccurred())
+	l.maxFlows = 1
+	_, _, err = l.Run()
+	Expect(err).To(HaveOccurred())
+}
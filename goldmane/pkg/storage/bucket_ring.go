@@ -36,6 +36,14 @@ type StreamReceiver interface {
 
 type lookupFn func(key types.FlowKey) *DiachronicFlow
 
+// sortValueFuncs maps each supported non-time sort order to the function used to extract its sort value from a FlowKey.
+var sortValueFuncs = map[proto.SortBy]func(*types.FlowKey) string{
+	proto.SortBy_DestName:        func(k *types.FlowKey) string { return k.DestName() },
+	proto.SortBy_DestNamespace:   func(k *types.FlowKey) string { return k.DestNamespace() },
+	proto.SortBy_SourceName:      func(k *types.FlowKey) string { return k.SourceName() },
+	proto.SortBy_SourceNamespace: func(k *types.FlowKey) string { return k.SourceNamespace() },
+}
+
 type BucketRing struct {
 	// buckets is a ring buffer of aggregation buckets for efficient rollover.
 	buckets   []AggregationBucket
@@ -79,6 +87,9 @@ type BucketRing struct {
 
 	// nextID is used to assign unique IDs to DiachronicFlows as they are created.
 	nextID int64
+
+	// archive, if set, provides durable storage of bucket contents beyond the lifetime of the ring.
+	archive Archive
 }
 
 func NewBucketRing(n, interval int, now int64, opts ...BucketRingOption) *BucketRing {
@@ -87,12 +98,10 @@ func NewBucketRing(n, interval int, now int64, opts ...BucketRingOption) *Bucket
 		headIndex:   0,
 		interval:    interval,
 		diachronics: make(map[types.FlowKey]*DiachronicFlow),
-		indices: map[proto.SortBy]Index[string]{
-			proto.SortBy_DestName:        NewIndex(func(k *types.FlowKey) string { return k.DestName() }),
-			proto.SortBy_DestNamespace:   NewIndex(func(k *types.FlowKey) string { return k.DestNamespace() }),
-			proto.SortBy_SourceName:      NewIndex(func(k *types.FlowKey) string { return k.SourceName() }),
-			proto.SortBy_SourceNamespace: NewIndex(func(k *types.FlowKey) string { return k.SourceNamespace() }),
-		},
+		indices:     map[proto.SortBy]Index[string]{},
+	}
+	for sortBy, fn := range sortValueFuncs {
+		ring.indices[sortBy] = NewIndex(fn)
 	}
 	// Use a time-based Ring index by default.
 	ring.defaultIndex = NewRingIndex(ring)
@@ -134,6 +143,9 @@ func NewBucketRing(n, interval int, now int64, opts ...BucketRingOption) *Bucket
 		"curBucket":    ring.buckets[ring.headIndex],
 		"oldestBucket": ring.buckets[(ring.headIndex+1)%n],
 	}).Debug("Initialized bucket ring")
+
+	// Load any previously archived flows that fall within the ring's window.
+	ring.rehydrate()
 	return ring
 }
 
@@ -150,6 +162,15 @@ func (r *BucketRing) Size() int64 {
 
 // TODO: Should we not be using proto types here?
 func (r *BucketRing) List(req *proto.FlowListRequest) ([]*types.Flow, *types.ListMeta, error) {
+	// If the request reaches further back than the in-memory window, we need to consult the archive.
+	if r.NeedsArchive(req) {
+		l, err := r.NewArchiveList(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l.Run()
+	}
+
 	// If a sort order was requested, use the corresponding index to find the matching flows.
 	if len(req.SortBy) > 0 && req.SortBy[0].SortBy != proto.SortBy_Time {
 		if idx, ok := r.indices[req.SortBy[0].SortBy]; ok {
@@ -274,6 +295,10 @@ func (r *BucketRing) Rollover(sink Sink) int64 {
 	// Send flows to the stream manager.
 	r.flushToStreams()
 
+	// Persist the same bucket to the archive, if configured. This is the point at which we consider
+	// a bucket's contents complete enough to stream, so it's also a good point at which to archive it.
+	r.archiveBucket(r.streamingBucket())
+
 	// Move the head index to the next bucket.
 	r.headIndex = r.nextBucketIndex(r.headIndex)
 
@@ -601,11 +626,15 @@ func (r *BucketRing) Statistics(req *proto.StatisticsRequest) ([]*proto.Statisti
 			if _, ok := results[k]; !ok {
 				// Initialize a new result object for this hit.
 				results[k] = &proto.StatisticsResult{
-					Policy:    types.PolicyHitToProto(k.ToHit()),
 					Direction: k.RuleDirection(),
 					GroupBy:   req.GroupBy,
 					Type:      req.Type,
 				}
+				if IsEndpointGroupBy(req.GroupBy) {
+					results[k].Endpoint = k.Endpoint.ToProto()
+				} else {
+					results[k].Policy = types.PolicyHitToProto(k.ToHit())
+				}
 			}
 
 			if req.TimeSeries {
@@ -645,12 +674,33 @@ func (r *BucketRing) Statistics(req *proto.StatisticsRequest) ([]*proto.Statisti
 		return nil, err
 	}
 
+	if req.TopN > 0 {
+		ranks, err := r.rankStatistics(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range results {
+			v.Rank = ranks[k]
+		}
+	}
+
 	// Convert the map to a list, and sort it for determinism.
 	var resultsList []*proto.StatisticsResult
 	for _, v := range results {
 		resultsList = append(resultsList, v)
 	}
 	sort.Slice(resultsList, func(i, j int) bool {
+		if e1, e2 := resultsList[i].Endpoint, resultsList[j].Endpoint; e1 != nil && e2 != nil {
+			// Sort namespaces, workloads and services by name.
+			if e1.Namespace != e2.Namespace {
+				return e1.Namespace < e2.Namespace
+			}
+			if e1.Name != e2.Name {
+				return e1.Name < e2.Name
+			}
+			return e1.Type < e2.Type
+		}
+
 		// Sort policy hits by its key fields (via the string representation), and then by direction (which is
 		// a key field only on the Statistics API for rule grouping).
 		p1Str := resultsList[i].Policy.String()
@@ -670,9 +720,55 @@ func (r *BucketRing) Statistics(req *proto.StatisticsRequest) ([]*proto.Statisti
 		}
 		return s1 < s2
 	})
+
+	if req.TopN > 0 {
+		// Keep the highest ranked results, falling back to the order above for ties.
+		sort.SliceStable(resultsList, func(i, j int) bool {
+			return resultsList[i].Rank > resultsList[j].Rank
+		})
+		if int64(len(resultsList)) > req.TopN {
+			resultsList = resultsList[:req.TopN]
+		}
+	}
 	return resultsList, nil
 }
 
+// rankStatistics returns the total of the statistic that the request ranks results by, for each
+// of the results, over the request's time range.
+func (r *BucketRing) rankStatistics(req *proto.StatisticsRequest) (map[StatisticsKey]int64, error) {
+	rankReq := &proto.StatisticsRequest{
+		StartTimeGte: req.StartTimeGte,
+		StartTimeLt:  req.StartTimeLt,
+		GroupBy:      req.GroupBy,
+		PolicyMatch:  req.PolicyMatch,
+	}
+	var total func(c *counts) int64
+	switch req.RankBy {
+	case proto.StatisticsRankBy_RankByBytes:
+		rankReq.Type = proto.StatisticType_ByteCount
+		total = (*counts).total
+	case proto.StatisticsRankBy_RankByPackets:
+		rankReq.Type = proto.StatisticType_PacketCount
+		total = (*counts).total
+	case proto.StatisticsRankBy_RankByDeniedConnections:
+		rankReq.Type = proto.StatisticType_StartedConnectionCount
+		total = func(c *counts) int64 { return c.DeniedIn + c.DeniedOut }
+	default:
+		return nil, fmt.Errorf("unknown rank by: %v", req.RankBy)
+	}
+
+	ranks := map[StatisticsKey]int64{}
+	err := r.iterBucketsTime(rankReq.StartTimeGte, rankReq.StartTimeLt, func(b *AggregationBucket) error {
+		for k, v := range b.QueryStatistics(rankReq) {
+			if v != nil {
+				ranks[k] += total(v)
+			}
+		}
+		return nil
+	})
+	return ranks, err
+}
+
 // flushToStreams sends the flows in the current streaming bucket to the stream receiver.
 func (r *BucketRing) flushToStreams() {
 	start := time.Now()
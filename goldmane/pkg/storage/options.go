@@ -48,3 +48,12 @@ func WithNowFunc(nowFunc func() time.Time) BucketRingOption {
 		r.nowFunc = nowFunc
 	}
 }
+
+// WithArchive configures durable storage for the bucket ring. Archived flows are used to rehydrate
+// the ring on startup, and to serve queries for time ranges older than the in-memory window.
+func WithArchive(a Archive) BucketRingOption {
+	return func(r *BucketRing) {
+		logrus.WithField("archive", a).Debug("Setting flow archive")
+		r.archive = a
+	}
+}
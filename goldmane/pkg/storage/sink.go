@@ -18,3 +18,12 @@ package storage
 type Sink interface {
 	Receive(*FlowCollection)
 }
+
+// Sinks is a Sink that fans out flows to multiple Sinks, in order.
+type Sinks []Sink
+
+func (s Sinks) Receive(c *FlowCollection) {
+	for _, sink := range s {
+		sink.Receive(c)
+	}
+}
@@ -16,6 +16,39 @@ type StatisticsKey struct {
 	Action    proto.Action
 	RuleIndex int64
 	Direction string
+
+	// Endpoint identifies the namespace, workload or service, for statistics that are grouped by
+	// one of them rather than by policy.
+	Endpoint EndpointGroup
+}
+
+// EndpointGroup identifies the namespace, workload or service that a set of statistics is for.
+type EndpointGroup struct {
+	Namespace string
+	Name      string
+	Type      proto.EndpointType
+}
+
+func (g *EndpointGroup) ToProto() *proto.StatisticsEndpoint {
+	return &proto.StatisticsEndpoint{
+		Namespace: g.Namespace,
+		Name:      g.Name,
+		Type:      g.Type,
+	}
+}
+
+// IsEndpointGroupBy returns true if the given grouping is by namespace, workload or service, rather
+// than by policy.
+func IsEndpointGroupBy(g proto.StatisticsGroupBy) bool {
+	switch g {
+	case proto.StatisticsGroupBy_GroupBySourceNamespace,
+		proto.StatisticsGroupBy_GroupByDestNamespace,
+		proto.StatisticsGroupBy_GroupBySourceWorkload,
+		proto.StatisticsGroupBy_GroupByDestWorkload,
+		proto.StatisticsGroupBy_GroupByDestService:
+		return true
+	}
+	return false
 }
 
 // policyID returns a statisticsKey that represents the policy, excluding any rule-specific information.
@@ -66,12 +99,18 @@ type counts struct {
 	PassedOut  int64
 }
 
+// total returns the sum of the counts, in both directions and for all actions.
+func (c *counts) total() int64 {
+	return c.AllowedIn + c.AllowedOut + c.DeniedIn + c.DeniedOut + c.PassedIn + c.PassedOut
+}
+
 // statistics holds the statistics for a given context. This amy be for a particular time window,
 // or for a particular policy within a time window, or for a particular policy rule within a policy.
 type statistics struct {
-	packets     counts
-	bytes       counts
-	connections counts
+	packets            counts
+	bytes              counts
+	connections        counts
+	startedConnections counts
 }
 
 // add adds the statistics from a flow to the statistics object.
@@ -85,8 +124,10 @@ func (s *statistics) add(flow *types.Flow, action proto.Action) {
 		switch direction(flow) {
 		case "ingress":
 			s.connections.AllowedIn += flow.NumConnectionsLive
+			s.startedConnections.AllowedIn += flow.NumConnectionsStarted
 		case "egress":
 			s.connections.AllowedOut += flow.NumConnectionsLive
+			s.startedConnections.AllowedOut += flow.NumConnectionsStarted
 		}
 	case proto.Action_Deny:
 		s.packets.DeniedIn += flow.PacketsIn
@@ -96,8 +137,10 @@ func (s *statistics) add(flow *types.Flow, action proto.Action) {
 		switch direction(flow) {
 		case "ingress":
 			s.connections.DeniedIn += flow.NumConnectionsLive
+			s.startedConnections.DeniedIn += flow.NumConnectionsStarted
 		case "egress":
 			s.connections.DeniedOut += flow.NumConnectionsLive
+			s.startedConnections.DeniedOut += flow.NumConnectionsStarted
 		}
 	case proto.Action_Pass:
 		s.packets.PassedIn += flow.PacketsIn
@@ -107,27 +150,58 @@ func (s *statistics) add(flow *types.Flow, action proto.Action) {
 		switch direction(flow) {
 		case "ingress":
 			s.connections.PassedIn += flow.NumConnectionsLive
+			s.startedConnections.PassedIn += flow.NumConnectionsStarted
 		case "egress":
 			s.connections.PassedOut += flow.NumConnectionsLive
+			s.startedConnections.PassedOut += flow.NumConnectionsStarted
 		}
 	default:
 		logrus.WithField("action", flow.Key.Action()).Error("Unknown action")
 	}
 }
 
-// statisticsIndex is a struct that holds statistics for a set of policies.
+// ofType returns the counts of the given type of statistic.
+func (s *statistics) ofType(t proto.StatisticType) *counts {
+	switch t {
+	case proto.StatisticType_PacketCount:
+		return &s.packets
+	case proto.StatisticType_ByteCount:
+		return &s.bytes
+	case proto.StatisticType_LiveConnectionCount:
+		return &s.connections
+	case proto.StatisticType_StartedConnectionCount:
+		return &s.startedConnections
+	default:
+		logrus.WithField("type", t).Error("Unknown statistic type")
+	}
+	return nil
+}
+
+// statisticsIndex is a struct that holds statistics for a set of policies, and for the namespaces,
+// workloads and services that Flows are sent from and to.
 type statisticsIndex struct {
 	statistics
-	policies map[StatisticsKey]*policyStatistics
+	policies  map[StatisticsKey]*policyStatistics
+	endpoints map[proto.StatisticsGroupBy]map[EndpointGroup]*statistics
 }
 
 func newStatisticsIndex() *statisticsIndex {
 	return &statisticsIndex{
-		policies: make(map[StatisticsKey]*policyStatistics),
+		policies:  make(map[StatisticsKey]*policyStatistics),
+		endpoints: make(map[proto.StatisticsGroupBy]map[EndpointGroup]*statistics),
 	}
 }
 
 func (s *statisticsIndex) QueryStatistics(q *proto.StatisticsRequest) map[StatisticsKey]*counts {
+	if IsEndpointGroupBy(q.GroupBy) {
+		// One result per namespace, workload or service.
+		results := make(map[StatisticsKey]*counts)
+		for g, es := range s.endpoints[q.GroupBy] {
+			results[StatisticsKey{Endpoint: g}] = es.ofType(q.Type)
+		}
+		return results
+	}
+
 	// Top level - group by policy or policy rule.
 	// - If grouped by policy, we return one result per policy that matches the query.
 	// - If grouped by policy rule, we return one result per policy rule that matches the query.
@@ -200,17 +274,7 @@ func (s *statisticsIndex) retrieve(k StatisticsKey, groupBy *proto.StatisticsGro
 	}
 
 	// Return the requested statistic.
-	switch t {
-	case proto.StatisticType_PacketCount:
-		return &data.packets
-	case proto.StatisticType_ByteCount:
-		return &data.bytes
-	case proto.StatisticType_LiveConnectionCount:
-		return &data.connections
-	default:
-		logrus.WithField("type", t).Error("Unknown statistic type")
-	}
-	return nil
+	return data.ofType(t)
 }
 
 func direction(flow *types.Flow) string {
@@ -220,6 +284,48 @@ func direction(flow *types.Flow) string {
 	return "ingress"
 }
 
+// reportsEndpoint returns true if the endpoint of the given type also reports the Flows that it
+// sends and receives.
+func reportsEndpoint(t proto.EndpointType) bool {
+	return t == proto.EndpointType_WorkloadEndpoint || t == proto.EndpointType_HostEndpoint
+}
+
+// endpointGroups returns the namespaces, workloads and services that the given Flow's statistics are
+// counted against, for each of the endpoint groupings.
+//
+// Traffic between two endpoints is reported by both of them, so to avoid counting it twice, a Flow
+// is only counted against its source if it was reported by the source, or the source doesn't report
+// Flows itself (e.g., it is outside the cluster).  Similarly for its destination, except that Flows
+// denied by the source are also counted against the destination, since it never sees them.  Services
+// are counted from the source's point of view, since that's where the service is known.
+func endpointGroups(flow *types.Flow) map[proto.StatisticsGroupBy]EndpointGroup {
+	k := flow.Key
+	groups := make(map[proto.StatisticsGroupBy]EndpointGroup)
+	if k.Reporter() == proto.Reporter_Src || !reportsEndpoint(k.SourceType()) {
+		groups[proto.StatisticsGroupBy_GroupBySourceNamespace] = EndpointGroup{Namespace: k.SourceNamespace()}
+		groups[proto.StatisticsGroupBy_GroupBySourceWorkload] = EndpointGroup{
+			Namespace: k.SourceNamespace(),
+			Name:      k.SourceName(),
+			Type:      k.SourceType(),
+		}
+		if k.DestServiceName() != "" {
+			groups[proto.StatisticsGroupBy_GroupByDestService] = EndpointGroup{
+				Namespace: k.DestServiceNamespace(),
+				Name:      k.DestServiceName(),
+			}
+		}
+	}
+	if k.Reporter() == proto.Reporter_Dst || !reportsEndpoint(k.DestType()) || k.Action() == proto.Action_Deny {
+		groups[proto.StatisticsGroupBy_GroupByDestNamespace] = EndpointGroup{Namespace: k.DestNamespace()}
+		groups[proto.StatisticsGroupBy_GroupByDestWorkload] = EndpointGroup{
+			Namespace: k.DestNamespace(),
+			Name:      k.DestName(),
+			Type:      k.DestType(),
+		}
+	}
+	return groups
+}
+
 func (s *statisticsIndex) AddFlow(flow *types.Flow) {
 	logrus.WithField("flow", flow).Debug("Adding flow to statistics index")
 
@@ -295,4 +401,19 @@ func (s *statisticsIndex) AddFlow(flow *types.Flow) {
 			rs.add(flow, action)
 		}
 	}
+
+	// Add the Flow's stats to the namespaces, workloads and services it is counted against.
+	for groupBy, g := range endpointGroups(flow) {
+		groups, ok := s.endpoints[groupBy]
+		if !ok {
+			groups = make(map[EndpointGroup]*statistics)
+			s.endpoints[groupBy] = groups
+		}
+		es, ok := groups[g]
+		if !ok {
+			es = &statistics{}
+			groups[g] = es
+		}
+		es.add(flow, flow.Key.Action())
+	}
 }
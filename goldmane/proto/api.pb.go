@@ -442,6 +442,9 @@ const (
 	StatisticType_PacketCount         StatisticType = 0
 	StatisticType_ByteCount           StatisticType = 1
 	StatisticType_LiveConnectionCount StatisticType = 2
+	// StartedConnectionCount is the number of connections started, including connection attempts that
+	// were denied.
+	StatisticType_StartedConnectionCount StatisticType = 3
 )
 
 // Enum value maps for StatisticType.
@@ -450,11 +453,13 @@ var (
 		0: "PacketCount",
 		1: "ByteCount",
 		2: "LiveConnectionCount",
+		3: "StartedConnectionCount",
 	}
 	StatisticType_value = map[string]int32{
-		"PacketCount":         0,
-		"ByteCount":           1,
-		"LiveConnectionCount": 2,
+		"PacketCount":            0,
+		"ByteCount":              1,
+		"LiveConnectionCount":    2,
+		"StartedConnectionCount": 3,
 	}
 )
 
@@ -492,6 +497,21 @@ const (
 	StatisticsGroupBy_Policy StatisticsGroupBy = 0
 	// PolicyRule configures statistics groupings on a per-policy-rule basis.
 	StatisticsGroupBy_PolicyRule StatisticsGroupBy = 1
+	// GroupBySourceNamespace configures statistics groupings on a per-namespace basis, using the namespace
+	// of the source of each Flow.
+	StatisticsGroupBy_GroupBySourceNamespace StatisticsGroupBy = 2
+	// GroupByDestNamespace configures statistics groupings on a per-namespace basis, using the namespace
+	// of the destination of each Flow.
+	StatisticsGroupBy_GroupByDestNamespace StatisticsGroupBy = 3
+	// GroupBySourceWorkload configures statistics groupings on a per-workload basis, using the aggregated
+	// name of the source of each Flow (e.g., the pods of a Deployment are grouped together).
+	StatisticsGroupBy_GroupBySourceWorkload StatisticsGroupBy = 4
+	// GroupByDestWorkload configures statistics groupings on a per-workload basis, using the aggregated
+	// name of the destination of each Flow.
+	StatisticsGroupBy_GroupByDestWorkload StatisticsGroupBy = 5
+	// GroupByDestService configures statistics groupings on a per-service basis, using the Kubernetes
+	// service that each Flow was sent to. Flows that weren't sent to a service are omitted.
+	StatisticsGroupBy_GroupByDestService StatisticsGroupBy = 6
 )
 
 // Enum value maps for StatisticsGroupBy.
@@ -499,10 +519,20 @@ var (
 	StatisticsGroupBy_name = map[int32]string{
 		0: "Policy",
 		1: "PolicyRule",
+		2: "GroupBySourceNamespace",
+		3: "GroupByDestNamespace",
+		4: "GroupBySourceWorkload",
+		5: "GroupByDestWorkload",
+		6: "GroupByDestService",
 	}
 	StatisticsGroupBy_value = map[string]int32{
-		"Policy":     0,
-		"PolicyRule": 1,
+		"Policy":                 0,
+		"PolicyRule":             1,
+		"GroupBySourceNamespace": 2,
+		"GroupByDestNamespace":   3,
+		"GroupBySourceWorkload":  4,
+		"GroupByDestWorkload":    5,
+		"GroupByDestService":     6,
 	}
 )
 
@@ -533,6 +563,59 @@ func (StatisticsGroupBy) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{8}
 }
 
+// StatisticsRankBy selects the statistic used to rank results when a StatisticsRequest asks for the top N.
+type StatisticsRankBy int32
+
+const (
+	// RankByBytes ranks results by the total number of bytes, in both directions and for all actions.
+	StatisticsRankBy_RankByBytes StatisticsRankBy = 0
+	// RankByPackets ranks results by the total number of packets, in both directions and for all actions.
+	StatisticsRankBy_RankByPackets StatisticsRankBy = 1
+	// RankByDeniedConnections ranks results by the number of connections that were denied by policy.
+	StatisticsRankBy_RankByDeniedConnections StatisticsRankBy = 2
+)
+
+// Enum value maps for StatisticsRankBy.
+var (
+	StatisticsRankBy_name = map[int32]string{
+		0: "RankByBytes",
+		1: "RankByPackets",
+		2: "RankByDeniedConnections",
+	}
+	StatisticsRankBy_value = map[string]int32{
+		"RankByBytes":             0,
+		"RankByPackets":           1,
+		"RankByDeniedConnections": 2,
+	}
+)
+
+func (x StatisticsRankBy) Enum() *StatisticsRankBy {
+	p := new(StatisticsRankBy)
+	*p = x
+	return p
+}
+
+func (x StatisticsRankBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatisticsRankBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[9].Descriptor()
+}
+
+func (StatisticsRankBy) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[9]
+}
+
+func (x StatisticsRankBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatisticsRankBy.Descriptor instead.
+func (StatisticsRankBy) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{9}
+}
+
 type RuleDirection int32
 
 const (
@@ -566,11 +649,11 @@ func (x RuleDirection) String() string {
 }
 
 func (RuleDirection) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_enumTypes[9].Descriptor()
+	return file_api_proto_enumTypes[10].Descriptor()
 }
 
 func (RuleDirection) Type() protoreflect.EnumType {
-	return &file_api_proto_enumTypes[9]
+	return &file_api_proto_enumTypes[10]
 }
 
 func (x RuleDirection) Number() protoreflect.EnumNumber {
@@ -579,7 +662,7 @@ func (x RuleDirection) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RuleDirection.Descriptor instead.
 func (RuleDirection) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_rawDescGZIP(), []int{10}
 }
 
 // FlowListRequest defines a message to request a particular selection of aggregated Flow objects.
@@ -2077,15 +2160,22 @@ type StatisticsRequest struct {
 	// Configure statistics aggregation.
 	// - Policy: each StatisticsResult will contain statistics for a particular policy.
 	// - PolicyRule: each StatisticsResult will contain statistics for a particular policy rule.
-	// - Any: return both per-Policy and per-PolicyRule results.
+	// - GroupBy(Source|Dest)Namespace: each StatisticsResult will contain statistics for a particular namespace.
+	// - GroupBy(Source|Dest)Workload: each StatisticsResult will contain statistics for a particular workload.
+	// - GroupByDestService: each StatisticsResult will contain statistics for a particular service.
 	GroupBy StatisticsGroupBy `protobuf:"varint,4,opt,name=group_by,json=groupBy,proto3,enum=goldmane.StatisticsGroupBy" json:"group_by,omitempty"`
 	// Optionally configure fields to filter results. If provided, any policies not matching the PolicyMatch
-	// will be omitted from the results.
+	// will be omitted from the results. Only valid when grouping by Policy or PolicyRule.
 	PolicyMatch *PolicyMatch `protobuf:"bytes,5,opt,name=policy_match,json=policyMatch,proto3" json:"policy_match,omitempty"`
 	// TimeSeries configures whether or not to return time-series data in the response. If true,
 	// the response will include multiple datapoints over the given time window. If false, data
 	// across the time window will be aggregated into a single data point.
-	TimeSeries    bool `protobuf:"varint,6,opt,name=time_series,json=timeSeries,proto3" json:"time_series,omitempty"`
+	TimeSeries bool `protobuf:"varint,6,opt,name=time_series,json=timeSeries,proto3" json:"time_series,omitempty"`
+	// TopN optionally limits the response to the N results with the highest totals of the RankBy statistic
+	// over the time window, ordered from highest to lowest. A value of zero returns all results.
+	TopN int64 `protobuf:"varint,7,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+	// RankBy is the statistic used to rank results when TopN is set.
+	RankBy        StatisticsRankBy `protobuf:"varint,8,opt,name=rank_by,json=rankBy,proto3,enum=goldmane.StatisticsRankBy" json:"rank_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2162,6 +2252,86 @@ func (x *StatisticsRequest) GetTimeSeries() bool {
 	return false
 }
 
+func (x *StatisticsRequest) GetTopN() int64 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+func (x *StatisticsRequest) GetRankBy() StatisticsRankBy {
+	if x != nil {
+		return x.RankBy
+	}
+	return StatisticsRankBy_RankByBytes
+}
+
+// StatisticsEndpoint identifies the namespace, workload or service that statistics were grouped by.
+type StatisticsEndpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Namespace is the namespace of the endpoints. It is empty for endpoints that aren't namespaced,
+	// such as host endpoints and global network sets.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name is the aggregated name of the workload, or the name of the service. It is empty when
+	// grouping by namespace.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Type is the type of the workload. It is unspecified when grouping by namespace or service.
+	Type          EndpointType `protobuf:"varint,3,opt,name=type,proto3,enum=goldmane.EndpointType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatisticsEndpoint) Reset() {
+	*x = StatisticsEndpoint{}
+	mi := &file_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatisticsEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatisticsEndpoint) ProtoMessage() {}
+
+func (x *StatisticsEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatisticsEndpoint.ProtoReflect.Descriptor instead.
+func (*StatisticsEndpoint) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StatisticsEndpoint) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *StatisticsEndpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatisticsEndpoint) GetType() EndpointType {
+	if x != nil {
+		return x.Type
+	}
+	return EndpointType_EndpointTypeUnspecified
+}
+
 type StatisticsResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Policy identifies the policy / rule for which this data applies. Its meaning is contextualized
@@ -2193,14 +2363,19 @@ type StatisticsResult struct {
 	PassedOut  []int64 `protobuf:"varint,10,rep,packed,name=passed_out,json=passedOut,proto3" json:"passed_out,omitempty"`
 	// X is the x axis of the data for time-series data. i.e., the timestamp. For non-timeseries data,
 	// this will be nil.
-	X             []int64 `protobuf:"varint,11,rep,packed,name=x,proto3" json:"x,omitempty"`
+	X []int64 `protobuf:"varint,11,rep,packed,name=x,proto3" json:"x,omitempty"`
+	// Endpoint identifies the namespace, workload or service for which this data applies, when grouping
+	// by one of them. It is nil when grouping by Policy or PolicyRule.
+	Endpoint *StatisticsEndpoint `protobuf:"bytes,12,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// Rank is the total of the requested RankBy statistic over the time window, for requests that set TopN.
+	Rank          int64 `protobuf:"varint,13,opt,name=rank,proto3" json:"rank,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StatisticsResult) Reset() {
 	*x = StatisticsResult{}
-	mi := &file_api_proto_msgTypes[20]
+	mi := &file_api_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2212,7 +2387,7 @@ func (x *StatisticsResult) String() string {
 func (*StatisticsResult) ProtoMessage() {}
 
 func (x *StatisticsResult) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[20]
+	mi := &file_api_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2225,7 +2400,7 @@ func (x *StatisticsResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatisticsResult.ProtoReflect.Descriptor instead.
 func (*StatisticsResult) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{20}
+	return file_api_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *StatisticsResult) GetPolicy() *PolicyHit {
@@ -2305,6 +2480,332 @@ func (x *StatisticsResult) GetX() []int64 {
 	return nil
 }
 
+func (x *StatisticsResult) GetEndpoint() *StatisticsEndpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+func (x *StatisticsResult) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type PolicyRecommendationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// StartTimeGte specifies the beginning of the time window over which to consider Flows.
+	//
+	// - A value of zero indicates the oldest start time available by the server.
+	// - A value greater than zero indicates an absolute time in seconds since the Unix epoch.
+	// - A value less than zero indicates a relative number of seconds from "now", as determined by the server.
+	StartTimeGte int64 `protobuf:"varint,1,opt,name=start_time_gte,json=startTimeGte,proto3" json:"start_time_gte,omitempty"`
+	// StartTimeLt specifies the end of the time window over which to consider Flows.
+	//
+	// - A value of zero means "now", as determined by the server at the time of request.
+	// - A value greater than zero indicates an absolute time in seconds since the Unix epoch.
+	// - A value less than zero indicates a relative number of seconds from "now", as determined by the server.
+	StartTimeLt int64 `protobuf:"varint,2,opt,name=start_time_lt,json=startTimeLt,proto3" json:"start_time_lt,omitempty"`
+	// Namespace is the namespace for which to generate recommendations. Required.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Tier is the tier in which recommended policies should be placed. Defaults to "default".
+	Tier          string `protobuf:"bytes,4,opt,name=tier,proto3" json:"tier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyRecommendationRequest) Reset() {
+	*x = PolicyRecommendationRequest{}
+	mi := &file_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyRecommendationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyRecommendationRequest) ProtoMessage() {}
+
+func (x *PolicyRecommendationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyRecommendationRequest.ProtoReflect.Descriptor instead.
+func (*PolicyRecommendationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PolicyRecommendationRequest) GetStartTimeGte() int64 {
+	if x != nil {
+		return x.StartTimeGte
+	}
+	return 0
+}
+
+func (x *PolicyRecommendationRequest) GetStartTimeLt() int64 {
+	if x != nil {
+		return x.StartTimeLt
+	}
+	return 0
+}
+
+func (x *PolicyRecommendationRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PolicyRecommendationRequest) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+type PolicyRecommendationResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Recommendations contains one entry per group of endpoints within the requested namespace
+	// that was observed sending or receiving allowed traffic.
+	Recommendations []*PolicyRecommendation `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PolicyRecommendationResult) Reset() {
+	*x = PolicyRecommendationResult{}
+	mi := &file_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyRecommendationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyRecommendationResult) ProtoMessage() {}
+
+func (x *PolicyRecommendationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyRecommendationResult.ProtoReflect.Descriptor instead.
+func (*PolicyRecommendationResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PolicyRecommendationResult) GetRecommendations() []*PolicyRecommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+// PolicyRecommendation describes a single recommended namespaced policy.
+type PolicyRecommendation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name is the recommended name of the policy. Policies outside of the default tier are prefixed
+	// with the tier name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Namespace is the namespace of the policy.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Tier is the tier of the policy.
+	Tier string `protobuf:"bytes,3,opt,name=tier,proto3" json:"tier,omitempty"`
+	// Selector is a Calico selector identifying the endpoints to which the policy applies.
+	Selector string `protobuf:"bytes,4,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Ingress contains rules allowing the observed inbound traffic to the selected endpoints.
+	Ingress []*RecommendedRule `protobuf:"bytes,5,rep,name=ingress,proto3" json:"ingress,omitempty"`
+	// Egress contains rules allowing the observed outbound traffic from the selected endpoints.
+	Egress        []*RecommendedRule `protobuf:"bytes,6,rep,name=egress,proto3" json:"egress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolicyRecommendation) Reset() {
+	*x = PolicyRecommendation{}
+	mi := &file_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolicyRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyRecommendation) ProtoMessage() {}
+
+func (x *PolicyRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyRecommendation.ProtoReflect.Descriptor instead.
+func (*PolicyRecommendation) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PolicyRecommendation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PolicyRecommendation) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PolicyRecommendation) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *PolicyRecommendation) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *PolicyRecommendation) GetIngress() []*RecommendedRule {
+	if x != nil {
+		return x.Ingress
+	}
+	return nil
+}
+
+func (x *PolicyRecommendation) GetEgress() []*RecommendedRule {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+// RecommendedRule describes a single Allow rule within a recommended policy.
+type RecommendedRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Protocol is the protocol of the observed traffic, e.g., "tcp".
+	Protocol string `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Ports contains the destination ports of the observed traffic.
+	Ports []int64 `protobuf:"varint,2,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	// Selector identifies the peer endpoints by label. Empty for peers identified by Nets or NotNets.
+	Selector string `protobuf:"bytes,3,opt,name=selector,proto3" json:"selector,omitempty"`
+	// NamespaceSelector identifies the namespace(s) of the peer endpoints. Empty means the
+	// namespace of the policy.
+	NamespaceSelector string `protobuf:"bytes,4,opt,name=namespace_selector,json=namespaceSelector,proto3" json:"namespace_selector,omitempty"`
+	// Nets contains the CIDRs of the peers, for peers that aren't known endpoints. Empty means the
+	// peers are identified by selector.
+	Nets []string `protobuf:"bytes,5,rep,name=nets,proto3" json:"nets,omitempty"`
+	// NotNets contains CIDRs that the peers are known not to be in, for peers that aren't known
+	// endpoints.
+	NotNets       []string `protobuf:"bytes,6,rep,name=not_nets,json=notNets,proto3" json:"not_nets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendedRule) Reset() {
+	*x = RecommendedRule{}
+	mi := &file_api_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendedRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendedRule) ProtoMessage() {}
+
+func (x *RecommendedRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendedRule.ProtoReflect.Descriptor instead.
+func (*RecommendedRule) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RecommendedRule) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *RecommendedRule) GetPorts() []int64 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *RecommendedRule) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *RecommendedRule) GetNamespaceSelector() string {
+	if x != nil {
+		return x.NamespaceSelector
+	}
+	return ""
+}
+
+func (x *RecommendedRule) GetNets() []string {
+	if x != nil {
+		return x.Nets
+	}
+	return nil
+}
+
+func (x *RecommendedRule) GetNotNets() []string {
+	if x != nil {
+		return x.NotNets
+	}
+	return nil
+}
+
 var File_api_proto protoreflect.FileDescriptor
 
 const file_api_proto_rawDesc = "" +
@@ -2425,7 +2926,7 @@ const file_api_proto_rawDesc = "" +
 	"\fpolicy_index\x18\x06 \x01(\x03R\vpolicyIndex\x12\x1d\n" +
 	"\n" +
 	"rule_index\x18\a \x01(\x03R\truleIndex\x12-\n" +
-	"\atrigger\x18\b \x01(\v2\x13.goldmane.PolicyHitR\atrigger\"\x9d\x02\n" +
+	"\atrigger\x18\b \x01(\v2\x13.goldmane.PolicyHitR\atrigger\"\xe7\x02\n" +
 	"\x11StatisticsRequest\x12$\n" +
 	"\x0estart_time_gte\x18\x01 \x01(\x03R\fstartTimeGte\x12\"\n" +
 	"\rstart_time_lt\x18\x02 \x01(\x03R\vstartTimeLt\x12+\n" +
@@ -2433,7 +2934,13 @@ const file_api_proto_rawDesc = "" +
 	"\bgroup_by\x18\x04 \x01(\x0e2\x1b.goldmane.StatisticsGroupByR\agroupBy\x128\n" +
 	"\fpolicy_match\x18\x05 \x01(\v2\x15.goldmane.PolicyMatchR\vpolicyMatch\x12\x1f\n" +
 	"\vtime_series\x18\x06 \x01(\bR\n" +
-	"timeSeries\"\xa1\x03\n" +
+	"timeSeries\x12\x13\n" +
+	"\x05top_n\x18\a \x01(\x03R\x04topN\x123\n" +
+	"\arank_by\x18\b \x01(\x0e2\x1a.goldmane.StatisticsRankByR\x06rankBy\"r\n" +
+	"\x12StatisticsEndpoint\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12*\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x16.goldmane.EndpointTypeR\x04type\"\xef\x03\n" +
 	"\x10StatisticsResult\x12+\n" +
 	"\x06policy\x18\x01 \x01(\v2\x13.goldmane.PolicyHitR\x06policy\x125\n" +
 	"\tdirection\x18\x02 \x01(\x0e2\x17.goldmane.RuleDirectionR\tdirection\x126\n" +
@@ -2450,7 +2957,30 @@ const file_api_proto_rawDesc = "" +
 	"\n" +
 	"passed_out\x18\n" +
 	" \x03(\x03R\tpassedOut\x12\f\n" +
-	"\x01x\x18\v \x03(\x03R\x01x*\xc9\x01\n" +
+	"\x01x\x18\v \x03(\x03R\x01x\x128\n" +
+	"\bendpoint\x18\f \x01(\v2\x1c.goldmane.StatisticsEndpointR\bendpoint\x12\x12\n" +
+	"\x04rank\x18\r \x01(\x03R\x04rank\"\x99\x01\n" +
+	"\x1bPolicyRecommendationRequest\x12$\n" +
+	"\x0estart_time_gte\x18\x01 \x01(\x03R\fstartTimeGte\x12\"\n" +
+	"\rstart_time_lt\x18\x02 \x01(\x03R\vstartTimeLt\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04tier\x18\x04 \x01(\tR\x04tier\"f\n" +
+	"\x1aPolicyRecommendationResult\x12H\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x1e.goldmane.PolicyRecommendationR\x0frecommendations\"\xe0\x01\n" +
+	"\x14PolicyRecommendation\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04tier\x18\x03 \x01(\tR\x04tier\x12\x1a\n" +
+	"\bselector\x18\x04 \x01(\tR\bselector\x123\n" +
+	"\aingress\x18\x05 \x03(\v2\x19.goldmane.RecommendedRuleR\aingress\x121\n" +
+	"\x06egress\x18\x06 \x03(\v2\x19.goldmane.RecommendedRuleR\x06egress\"\xbd\x01\n" +
+	"\x0fRecommendedRule\x12\x1a\n" +
+	"\bprotocol\x18\x01 \x01(\tR\bprotocol\x12\x14\n" +
+	"\x05ports\x18\x02 \x03(\x03R\x05ports\x12\x1a\n" +
+	"\bselector\x18\x03 \x01(\tR\bselector\x12-\n" +
+	"\x12namespace_selector\x18\x04 \x01(\tR\x11namespaceSelector\x12\x12\n" +
+	"\x04nets\x18\x05 \x03(\tR\x04nets\x12\x19\n" +
+	"\bnot_nets\x18\x06 \x03(\tR\anotNets*\xc9\x01\n" +
 	"\n" +
 	"FilterType\x12\x19\n" +
 	"\x15FilterTypeUnspecified\x10\x00\x12\x16\n" +
@@ -2502,16 +3032,26 @@ const file_api_proto_rawDesc = "" +
 	"\bReporter\x12\x17\n" +
 	"\x13ReporterUnspecified\x10\x00\x12\a\n" +
 	"\x03Src\x10\x01\x12\a\n" +
-	"\x03Dst\x10\x02*H\n" +
+	"\x03Dst\x10\x02*d\n" +
 	"\rStatisticType\x12\x0f\n" +
 	"\vPacketCount\x10\x00\x12\r\n" +
 	"\tByteCount\x10\x01\x12\x17\n" +
-	"\x13LiveConnectionCount\x10\x02*/\n" +
+	"\x13LiveConnectionCount\x10\x02\x12\x1a\n" +
+	"\x16StartedConnectionCount\x10\x03*\xb1\x01\n" +
 	"\x11StatisticsGroupBy\x12\n" +
 	"\n" +
 	"\x06Policy\x10\x00\x12\x0e\n" +
 	"\n" +
-	"PolicyRule\x10\x01*1\n" +
+	"PolicyRule\x10\x01\x12\x1a\n" +
+	"\x16GroupBySourceNamespace\x10\x02\x12\x18\n" +
+	"\x14GroupByDestNamespace\x10\x03\x12\x19\n" +
+	"\x15GroupBySourceWorkload\x10\x04\x12\x17\n" +
+	"\x13GroupByDestWorkload\x10\x05\x12\x16\n" +
+	"\x12GroupByDestService\x10\x06*S\n" +
+	"\x10StatisticsRankBy\x12\x0f\n" +
+	"\vRankByBytes\x10\x00\x12\x11\n" +
+	"\rRankByPackets\x10\x01\x12\x1b\n" +
+	"\x17RankByDeniedConnections\x10\x02*1\n" +
 	"\rRuleDirection\x12\a\n" +
 	"\x03Any\x10\x00\x12\v\n" +
 	"\aIngress\x10\x01\x12\n" +
@@ -2525,7 +3065,9 @@ const file_api_proto_rawDesc = "" +
 	"\aConnect\x12\x14.goldmane.FlowUpdate\x1a\x15.goldmane.FlowReceipt(\x010\x012O\n" +
 	"\n" +
 	"Statistics\x12A\n" +
-	"\x04List\x12\x1b.goldmane.StatisticsRequest\x1a\x1a.goldmane.StatisticsResult0\x01B\tZ\a./protob\x06proto3"
+	"\x04List\x12\x1b.goldmane.StatisticsRequest\x1a\x1a.goldmane.StatisticsResult0\x012l\n" +
+	"\x15PolicyRecommendations\x12S\n" +
+	"\x04List\x12%.goldmane.PolicyRecommendationRequest\x1a$.goldmane.PolicyRecommendationResultB\tZ\a./protob\x06proto3"
 
 var (
 	file_api_proto_rawDescOnce sync.Once
@@ -2539,98 +3081,112 @@ func file_api_proto_rawDescGZIP() []byte {
 	return file_api_proto_rawDescData
 }
 
-var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
-var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_api_proto_goTypes = []any{
-	(FilterType)(0),            // 0: goldmane.FilterType
-	(Action)(0),                // 1: goldmane.Action
-	(MatchType)(0),             // 2: goldmane.MatchType
-	(PolicyKind)(0),            // 3: goldmane.PolicyKind
-	(SortBy)(0),                // 4: goldmane.SortBy
-	(EndpointType)(0),          // 5: goldmane.EndpointType
-	(Reporter)(0),              // 6: goldmane.Reporter
-	(StatisticType)(0),         // 7: goldmane.StatisticType
-	(StatisticsGroupBy)(0),     // 8: goldmane.StatisticsGroupBy
-	(RuleDirection)(0),         // 9: goldmane.RuleDirection
-	(*FlowListRequest)(nil),    // 10: goldmane.FlowListRequest
-	(*FlowListResult)(nil),     // 11: goldmane.FlowListResult
-	(*FlowStreamRequest)(nil),  // 12: goldmane.FlowStreamRequest
-	(*FilterHintsRequest)(nil), // 13: goldmane.FilterHintsRequest
-	(*FilterHintsResult)(nil),  // 14: goldmane.FilterHintsResult
-	(*ListMetadata)(nil),       // 15: goldmane.ListMetadata
-	(*FilterHint)(nil),         // 16: goldmane.FilterHint
-	(*FlowResult)(nil),         // 17: goldmane.FlowResult
-	(*Filter)(nil),             // 18: goldmane.Filter
-	(*StringMatch)(nil),        // 19: goldmane.StringMatch
-	(*PortMatch)(nil),          // 20: goldmane.PortMatch
-	(*SortOption)(nil),         // 21: goldmane.SortOption
-	(*PolicyMatch)(nil),        // 22: goldmane.PolicyMatch
-	(*FlowReceipt)(nil),        // 23: goldmane.FlowReceipt
-	(*FlowUpdate)(nil),         // 24: goldmane.FlowUpdate
-	(*FlowKey)(nil),            // 25: goldmane.FlowKey
-	(*Flow)(nil),               // 26: goldmane.Flow
-	(*PolicyTrace)(nil),        // 27: goldmane.PolicyTrace
-	(*PolicyHit)(nil),          // 28: goldmane.PolicyHit
-	(*StatisticsRequest)(nil),  // 29: goldmane.StatisticsRequest
-	(*StatisticsResult)(nil),   // 30: goldmane.StatisticsResult
+	(FilterType)(0),                     // 0: goldmane.FilterType
+	(Action)(0),                         // 1: goldmane.Action
+	(MatchType)(0),                      // 2: goldmane.MatchType
+	(PolicyKind)(0),                     // 3: goldmane.PolicyKind
+	(SortBy)(0),                         // 4: goldmane.SortBy
+	(EndpointType)(0),                   // 5: goldmane.EndpointType
+	(Reporter)(0),                       // 6: goldmane.Reporter
+	(StatisticType)(0),                  // 7: goldmane.StatisticType
+	(StatisticsGroupBy)(0),              // 8: goldmane.StatisticsGroupBy
+	(StatisticsRankBy)(0),               // 9: goldmane.StatisticsRankBy
+	(RuleDirection)(0),                  // 10: goldmane.RuleDirection
+	(*FlowListRequest)(nil),             // 11: goldmane.FlowListRequest
+	(*FlowListResult)(nil),              // 12: goldmane.FlowListResult
+	(*FlowStreamRequest)(nil),           // 13: goldmane.FlowStreamRequest
+	(*FilterHintsRequest)(nil),          // 14: goldmane.FilterHintsRequest
+	(*FilterHintsResult)(nil),           // 15: goldmane.FilterHintsResult
+	(*ListMetadata)(nil),                // 16: goldmane.ListMetadata
+	(*FilterHint)(nil),                  // 17: goldmane.FilterHint
+	(*FlowResult)(nil),                  // 18: goldmane.FlowResult
+	(*Filter)(nil),                      // 19: goldmane.Filter
+	(*StringMatch)(nil),                 // 20: goldmane.StringMatch
+	(*PortMatch)(nil),                   // 21: goldmane.PortMatch
+	(*SortOption)(nil),                  // 22: goldmane.SortOption
+	(*PolicyMatch)(nil),                 // 23: goldmane.PolicyMatch
+	(*FlowReceipt)(nil),                 // 24: goldmane.FlowReceipt
+	(*FlowUpdate)(nil),                  // 25: goldmane.FlowUpdate
+	(*FlowKey)(nil),                     // 26: goldmane.FlowKey
+	(*Flow)(nil),                        // 27: goldmane.Flow
+	(*PolicyTrace)(nil),                 // 28: goldmane.PolicyTrace
+	(*PolicyHit)(nil),                   // 29: goldmane.PolicyHit
+	(*StatisticsRequest)(nil),           // 30: goldmane.StatisticsRequest
+	(*StatisticsEndpoint)(nil),          // 31: goldmane.StatisticsEndpoint
+	(*StatisticsResult)(nil),            // 32: goldmane.StatisticsResult
+	(*PolicyRecommendationRequest)(nil), // 33: goldmane.PolicyRecommendationRequest
+	(*PolicyRecommendationResult)(nil),  // 34: goldmane.PolicyRecommendationResult
+	(*PolicyRecommendation)(nil),        // 35: goldmane.PolicyRecommendation
+	(*RecommendedRule)(nil),             // 36: goldmane.RecommendedRule
 }
 var file_api_proto_depIdxs = []int32{
-	21, // 0: goldmane.FlowListRequest.sort_by:type_name -> goldmane.SortOption
-	18, // 1: goldmane.FlowListRequest.filter:type_name -> goldmane.Filter
-	15, // 2: goldmane.FlowListResult.meta:type_name -> goldmane.ListMetadata
-	17, // 3: goldmane.FlowListResult.flows:type_name -> goldmane.FlowResult
-	18, // 4: goldmane.FlowStreamRequest.filter:type_name -> goldmane.Filter
+	22, // 0: goldmane.FlowListRequest.sort_by:type_name -> goldmane.SortOption
+	19, // 1: goldmane.FlowListRequest.filter:type_name -> goldmane.Filter
+	16, // 2: goldmane.FlowListResult.meta:type_name -> goldmane.ListMetadata
+	18, // 3: goldmane.FlowListResult.flows:type_name -> goldmane.FlowResult
+	19, // 4: goldmane.FlowStreamRequest.filter:type_name -> goldmane.Filter
 	0,  // 5: goldmane.FilterHintsRequest.type:type_name -> goldmane.FilterType
-	18, // 6: goldmane.FilterHintsRequest.filter:type_name -> goldmane.Filter
-	15, // 7: goldmane.FilterHintsResult.meta:type_name -> goldmane.ListMetadata
-	16, // 8: goldmane.FilterHintsResult.hints:type_name -> goldmane.FilterHint
-	26, // 9: goldmane.FlowResult.flow:type_name -> goldmane.Flow
-	19, // 10: goldmane.Filter.source_names:type_name -> goldmane.StringMatch
-	19, // 11: goldmane.Filter.source_namespaces:type_name -> goldmane.StringMatch
-	19, // 12: goldmane.Filter.dest_names:type_name -> goldmane.StringMatch
-	19, // 13: goldmane.Filter.dest_namespaces:type_name -> goldmane.StringMatch
-	19, // 14: goldmane.Filter.protocols:type_name -> goldmane.StringMatch
-	20, // 15: goldmane.Filter.dest_ports:type_name -> goldmane.PortMatch
+	19, // 6: goldmane.FilterHintsRequest.filter:type_name -> goldmane.Filter
+	16, // 7: goldmane.FilterHintsResult.meta:type_name -> goldmane.ListMetadata
+	17, // 8: goldmane.FilterHintsResult.hints:type_name -> goldmane.FilterHint
+	27, // 9: goldmane.FlowResult.flow:type_name -> goldmane.Flow
+	20, // 10: goldmane.Filter.source_names:type_name -> goldmane.StringMatch
+	20, // 11: goldmane.Filter.source_namespaces:type_name -> goldmane.StringMatch
+	20, // 12: goldmane.Filter.dest_names:type_name -> goldmane.StringMatch
+	20, // 13: goldmane.Filter.dest_namespaces:type_name -> goldmane.StringMatch
+	20, // 14: goldmane.Filter.protocols:type_name -> goldmane.StringMatch
+	21, // 15: goldmane.Filter.dest_ports:type_name -> goldmane.PortMatch
 	1,  // 16: goldmane.Filter.actions:type_name -> goldmane.Action
-	22, // 17: goldmane.Filter.policies:type_name -> goldmane.PolicyMatch
+	23, // 17: goldmane.Filter.policies:type_name -> goldmane.PolicyMatch
 	2,  // 18: goldmane.StringMatch.type:type_name -> goldmane.MatchType
 	4,  // 19: goldmane.SortOption.sort_by:type_name -> goldmane.SortBy
 	3,  // 20: goldmane.PolicyMatch.kind:type_name -> goldmane.PolicyKind
 	1,  // 21: goldmane.PolicyMatch.action:type_name -> goldmane.Action
-	26, // 22: goldmane.FlowUpdate.flow:type_name -> goldmane.Flow
+	27, // 22: goldmane.FlowUpdate.flow:type_name -> goldmane.Flow
 	5,  // 23: goldmane.FlowKey.source_type:type_name -> goldmane.EndpointType
 	5,  // 24: goldmane.FlowKey.dest_type:type_name -> goldmane.EndpointType
 	6,  // 25: goldmane.FlowKey.reporter:type_name -> goldmane.Reporter
 	1,  // 26: goldmane.FlowKey.action:type_name -> goldmane.Action
-	27, // 27: goldmane.FlowKey.policies:type_name -> goldmane.PolicyTrace
-	25, // 28: goldmane.Flow.Key:type_name -> goldmane.FlowKey
-	28, // 29: goldmane.PolicyTrace.enforced_policies:type_name -> goldmane.PolicyHit
-	28, // 30: goldmane.PolicyTrace.pending_policies:type_name -> goldmane.PolicyHit
+	28, // 27: goldmane.FlowKey.policies:type_name -> goldmane.PolicyTrace
+	26, // 28: goldmane.Flow.Key:type_name -> goldmane.FlowKey
+	29, // 29: goldmane.PolicyTrace.enforced_policies:type_name -> goldmane.PolicyHit
+	29, // 30: goldmane.PolicyTrace.pending_policies:type_name -> goldmane.PolicyHit
 	3,  // 31: goldmane.PolicyHit.kind:type_name -> goldmane.PolicyKind
 	1,  // 32: goldmane.PolicyHit.action:type_name -> goldmane.Action
-	28, // 33: goldmane.PolicyHit.trigger:type_name -> goldmane.PolicyHit
+	29, // 33: goldmane.PolicyHit.trigger:type_name -> goldmane.PolicyHit
 	7,  // 34: goldmane.StatisticsRequest.type:type_name -> goldmane.StatisticType
 	8,  // 35: goldmane.StatisticsRequest.group_by:type_name -> goldmane.StatisticsGroupBy
-	22, // 36: goldmane.StatisticsRequest.policy_match:type_name -> goldmane.PolicyMatch
-	28, // 37: goldmane.StatisticsResult.policy:type_name -> goldmane.PolicyHit
-	9,  // 38: goldmane.StatisticsResult.direction:type_name -> goldmane.RuleDirection
-	8,  // 39: goldmane.StatisticsResult.group_by:type_name -> goldmane.StatisticsGroupBy
-	7,  // 40: goldmane.StatisticsResult.type:type_name -> goldmane.StatisticType
-	10, // 41: goldmane.Flows.List:input_type -> goldmane.FlowListRequest
-	12, // 42: goldmane.Flows.Stream:input_type -> goldmane.FlowStreamRequest
-	13, // 43: goldmane.Flows.FilterHints:input_type -> goldmane.FilterHintsRequest
-	24, // 44: goldmane.FlowCollector.Connect:input_type -> goldmane.FlowUpdate
-	29, // 45: goldmane.Statistics.List:input_type -> goldmane.StatisticsRequest
-	11, // 46: goldmane.Flows.List:output_type -> goldmane.FlowListResult
-	17, // 47: goldmane.Flows.Stream:output_type -> goldmane.FlowResult
-	14, // 48: goldmane.Flows.FilterHints:output_type -> goldmane.FilterHintsResult
-	23, // 49: goldmane.FlowCollector.Connect:output_type -> goldmane.FlowReceipt
-	30, // 50: goldmane.Statistics.List:output_type -> goldmane.StatisticsResult
-	46, // [46:51] is the sub-list for method output_type
-	41, // [41:46] is the sub-list for method input_type
-	41, // [41:41] is the sub-list for extension type_name
-	41, // [41:41] is the sub-list for extension extendee
-	0,  // [0:41] is the sub-list for field type_name
+	23, // 36: goldmane.StatisticsRequest.policy_match:type_name -> goldmane.PolicyMatch
+	9,  // 37: goldmane.StatisticsRequest.rank_by:type_name -> goldmane.StatisticsRankBy
+	5,  // 38: goldmane.StatisticsEndpoint.type:type_name -> goldmane.EndpointType
+	29, // 39: goldmane.StatisticsResult.policy:type_name -> goldmane.PolicyHit
+	10, // 40: goldmane.StatisticsResult.direction:type_name -> goldmane.RuleDirection
+	8,  // 41: goldmane.StatisticsResult.group_by:type_name -> goldmane.StatisticsGroupBy
+	7,  // 42: goldmane.StatisticsResult.type:type_name -> goldmane.StatisticType
+	31, // 43: goldmane.StatisticsResult.endpoint:type_name -> goldmane.StatisticsEndpoint
+	35, // 44: goldmane.PolicyRecommendationResult.recommendations:type_name -> goldmane.PolicyRecommendation
+	36, // 45: goldmane.PolicyRecommendation.ingress:type_name -> goldmane.RecommendedRule
+	36, // 46: goldmane.PolicyRecommendation.egress:type_name -> goldmane.RecommendedRule
+	11, // 47: goldmane.Flows.List:input_type -> goldmane.FlowListRequest
+	13, // 48: goldmane.Flows.Stream:input_type -> goldmane.FlowStreamRequest
+	14, // 49: goldmane.Flows.FilterHints:input_type -> goldmane.FilterHintsRequest
+	25, // 50: goldmane.FlowCollector.Connect:input_type -> goldmane.FlowUpdate
+	30, // 51: goldmane.Statistics.List:input_type -> goldmane.StatisticsRequest
+	33, // 52: goldmane.PolicyRecommendations.List:input_type -> goldmane.PolicyRecommendationRequest
+	12, // 53: goldmane.Flows.List:output_type -> goldmane.FlowListResult
+	18, // 54: goldmane.Flows.Stream:output_type -> goldmane.FlowResult
+	15, // 55: goldmane.Flows.FilterHints:output_type -> goldmane.FilterHintsResult
+	24, // 56: goldmane.FlowCollector.Connect:output_type -> goldmane.FlowReceipt
+	32, // 57: goldmane.Statistics.List:output_type -> goldmane.StatisticsResult
+	34, // 58: goldmane.PolicyRecommendations.List:output_type -> goldmane.PolicyRecommendationResult
+	53, // [53:59] is the sub-list for method output_type
+	47, // [47:53] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_init() }
@@ -2643,10 +3199,10 @@ func file_api_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)),
-			NumEnums:      10,
-			NumMessages:   21,
+			NumEnums:      11,
+			NumMessages:   26,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_api_proto_goTypes,
 		DependencyIndexes: file_api_proto_depIdxs,
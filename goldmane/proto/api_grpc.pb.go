@@ -346,8 +346,8 @@ const (
 // Statistics provides APIs for retrieving Flow statistics.
 type StatisticsClient interface {
 	// List returns statistics data for the given request. One StatisticsResult will be returned for
-	// each matching PolicyHit and direction over the timeframe, containing time-series data covering the
-	// provided time range.
+	// each matching PolicyHit and direction (or namespace, workload or service, depending on the request's
+	// GroupBy) over the timeframe, containing time-series data covering the provided time range.
 	List(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatisticsResult], error)
 }
 
@@ -385,8 +385,8 @@ type Statistics_ListClient = grpc.ServerStreamingClient[StatisticsResult]
 // Statistics provides APIs for retrieving Flow statistics.
 type StatisticsServer interface {
 	// List returns statistics data for the given request. One StatisticsResult will be returned for
-	// each matching PolicyHit and direction over the timeframe, containing time-series data covering the
-	// provided time range.
+	// each matching PolicyHit and direction (or namespace, workload or service, depending on the request's
+	// GroupBy) over the timeframe, containing time-series data covering the provided time range.
 	List(*StatisticsRequest, grpc.ServerStreamingServer[StatisticsResult]) error
 	mustEmbedUnimplementedStatisticsServer()
 }
@@ -449,3 +449,115 @@ var Statistics_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "api.proto",
 }
+
+const (
+	PolicyRecommendations_List_FullMethodName = "/goldmane.PolicyRecommendations/List"
+)
+
+// PolicyRecommendationsClient is the client API for PolicyRecommendations service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PolicyRecommendations provides APIs for generating policy from observed Flow data.
+type PolicyRecommendationsClient interface {
+	// List returns a set of least-privilege policies for the given namespace that cover the allowed
+	// traffic observed over the requested time window. Recommended policies select endpoints by label
+	// rather than by name, and are intended to be applied as StagedNetworkPolicy resources.
+	List(ctx context.Context, in *PolicyRecommendationRequest, opts ...grpc.CallOption) (*PolicyRecommendationResult, error)
+}
+
+type policyRecommendationsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPolicyRecommendationsClient(cc grpc.ClientConnInterface) PolicyRecommendationsClient {
+	return &policyRecommendationsClient{cc}
+}
+
+func (c *policyRecommendationsClient) List(ctx context.Context, in *PolicyRecommendationRequest, opts ...grpc.CallOption) (*PolicyRecommendationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PolicyRecommendationResult)
+	err := c.cc.Invoke(ctx, PolicyRecommendations_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PolicyRecommendationsServer is the server API for PolicyRecommendations service.
+// All implementations must embed UnimplementedPolicyRecommendationsServer
+// for forward compatibility.
+//
+// PolicyRecommendations provides APIs for generating policy from observed Flow data.
+type PolicyRecommendationsServer interface {
+	// List returns a set of least-privilege policies for the given namespace that cover the allowed
+	// traffic observed over the requested time window. Recommended policies select endpoints by label
+	// rather than by name, and are intended to be applied as StagedNetworkPolicy resources.
+	List(context.Context, *PolicyRecommendationRequest) (*PolicyRecommendationResult, error)
+	mustEmbedUnimplementedPolicyRecommendationsServer()
+}
+
+// UnimplementedPolicyRecommendationsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPolicyRecommendationsServer struct{}
+
+func (UnimplementedPolicyRecommendationsServer) List(context.Context, *PolicyRecommendationRequest) (*PolicyRecommendationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedPolicyRecommendationsServer) mustEmbedUnimplementedPolicyRecommendationsServer() {}
+func (UnimplementedPolicyRecommendationsServer) testEmbeddedByValue()                               {}
+
+// UnsafePolicyRecommendationsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PolicyRecommendationsServer will
+// result in compilation errors.
+type UnsafePolicyRecommendationsServer interface {
+	mustEmbedUnimplementedPolicyRecommendationsServer()
+}
+
+func RegisterPolicyRecommendationsServer(s grpc.ServiceRegistrar, srv PolicyRecommendationsServer) {
+	// If the following call pancis, it indicates UnimplementedPolicyRecommendationsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PolicyRecommendations_ServiceDesc, srv)
+}
+
+func _PolicyRecommendations_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyRecommendationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyRecommendationsServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyRecommendations_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyRecommendationsServer).List(ctx, req.(*PolicyRecommendationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PolicyRecommendations_ServiceDesc is the grpc.ServiceDesc for PolicyRecommendations service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PolicyRecommendations_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goldmane.PolicyRecommendations",
+	HandlerType: (*PolicyRecommendationsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _PolicyRecommendations_List_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api.proto",
+}
@@ -91,6 +91,10 @@ func printTree(w io.Writer, indent string, n parser.Node, continueLine bool) {
 		p("(%s ends with %q)", n.LabelName.Value(), n.Value.Value())
 	case *parser.LabelContainsValueNode:
 		p("(%s contains %q)", n.LabelName.Value(), n.Value.Value())
+	case *parser.LabelMatchesNode:
+		p("(%s matches %q)", n.LabelName.Value(), n.Value.Value())
+	case *parser.LabelComparisonNode:
+		p("(%s %s %q)", n.LabelName.Value(), n.Operator, n.Value.Value())
 	case *parser.HasNode:
 		p("has(%s)", n.LabelName.Value())
 	case *parser.NotNode:
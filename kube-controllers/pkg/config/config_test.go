@@ -200,6 +200,7 @@ var _ = Describe("Config", func() {
 							SyncLabels:       v3.Disabled,
 							HostEndpoint:     &v3.AutoHostEndpointConfig{AutoCreate: v3.Enabled, CreateDefaultHostEndpoint: v3.DefaultHostEndpointsEnabled},
 							LeakGracePeriod:  &v1.Duration{Duration: 20 * time.Minute},
+							IPAMDefragPeriod: &v1.Duration{Duration: time.Hour},
 						},
 						Policy: &v3.PolicyControllerConfig{
 							ReconcilerPeriod: &v1.Duration{Duration: time.Second * 30}},
@@ -236,8 +237,9 @@ var _ = Describe("Config", func() {
 						AutoCreate:                true,
 						CreateDefaultHostEndpoint: v3.DefaultHostEndpointsEnabled,
 					},
-					DeleteNodes:     true,
-					LeakGracePeriod: &v1.Duration{Duration: 20 * time.Minute},
+					DeleteNodes:      true,
+					LeakGracePeriod:  &v1.Duration{Duration: 20 * time.Minute},
+					IPAMDefragPeriod: &v1.Duration{Duration: time.Hour},
 				}))
 				Expect(rc.Policy).To(Equal(&config.GenericControllerConfig{
 					ReconcilerPeriod: time.Second * 30,
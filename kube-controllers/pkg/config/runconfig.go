@@ -78,6 +78,9 @@ type NodeControllerConfig struct {
 	// The grace period used by the controller to determine if an IP address is leaked.
 	// Set to 0 to disable IP address garbage collection.
 	LeakGracePeriod *v1.Duration
+
+	// The period at which the controller defragments IPAM blocks.  Nil or 0 disables defragmentation.
+	IPAMDefragPeriod *v1.Duration
 }
 
 type AutoHostEndpointConfig struct {
@@ -362,6 +365,8 @@ func mergeConfig(envVars map[string]string, envCfg Config, apiCfg v3.KubeControl
 		if apiCfg.Controllers.Node != nil {
 			rc.Node.LeakGracePeriod = apiCfg.Controllers.Node.LeakGracePeriod
 			status.RunningConfig.Controllers.Node.LeakGracePeriod = apiCfg.Controllers.Node.LeakGracePeriod
+			rc.Node.IPAMDefragPeriod = apiCfg.Controllers.Node.IPAMDefragPeriod
+			status.RunningConfig.Controllers.Node.IPAMDefragPeriod = apiCfg.Controllers.Node.IPAMDefragPeriod
 		}
 
 		if envCfg.DatastoreType != "kubernetes" {
@@ -60,6 +60,7 @@ type NodeController struct {
 	ipamCtrl               *IPAMController
 	hostEndpointController *autoHostEndpointController
 	nodeLabelController    *nodeLabelController
+	poolMigrationCtrl      *poolMigrationController
 }
 
 // NewNodeController Constructor for NodeController
@@ -118,6 +119,9 @@ func NewNodeController(ctx context.Context,
 		},
 	}
 
+	// Create the sub-controller that carries out IP pool migrations.
+	nc.poolMigrationCtrl = NewPoolMigrationController(calicoClient, k8sClientset, podInformer.GetIndexer())
+
 	// Create the Auto HostEndpoint sub-controller and register it to receive data.
 	// We always launch this controller, even if auto-HEPs are disabled, since the controller
 	// is responsible for cleaning up after itself in case it was previously enabled.
@@ -184,6 +188,7 @@ func (c *NodeController) Run(stopCh chan struct{}) {
 	// We're in-sync. Start the sub-controllers.
 	c.ipamCtrl.Start(stopCh)
 	c.hostEndpointController.Start(stopCh)
+	c.poolMigrationCtrl.Start(stopCh)
 
 	if c.cfg.SyncLabels {
 		c.nodeLabelController.Start(stopCh)
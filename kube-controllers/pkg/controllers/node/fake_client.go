@@ -19,6 +19,8 @@ import (
 	"strings"
 	"sync"
 
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
 	apiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
 	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
@@ -39,15 +41,19 @@ func NewFakeCalicoClient() *FakeCalicoClient {
 		handlesReleased:    make(map[string]bool),
 	}
 	return &FakeCalicoClient{
-		nodeClient: &nc,
-		ipamClient: &ipamClient,
+		nodeClient:            &nc,
+		ipamClient:            &ipamClient,
+		ipPoolClient:          &fakeIPPoolClient{pools: make(map[string]*v3.IPPool)},
+		ipPoolMigrationClient: &fakeIPPoolMigrationClient{migrations: make(map[string]*v3.IPPoolMigration)},
 	}
 }
 
 // FakeCalicoClient is a fake client for use in the IPAM tests.
 type FakeCalicoClient struct {
-	nodeClient clientv3.NodeInterface
-	ipamClient ipam.Interface
+	nodeClient            clientv3.NodeInterface
+	ipamClient            ipam.Interface
+	ipPoolClient          *fakeIPPoolClient
+	ipPoolMigrationClient *fakeIPPoolMigrationClient
 }
 
 // StagedGlobalNetworkPolicies returns an interface for managing staged global network policy resources.
@@ -91,7 +97,7 @@ func (f *FakeCalicoClient) NetworkPolicies() clientv3.NetworkPolicyInterface {
 
 // IPPools returns an interface for managing IP pool resources.
 func (f *FakeCalicoClient) IPPools() clientv3.IPPoolInterface {
-	panic("not implemented")
+	return f.ipPoolClient
 }
 
 // Profiles returns an interface for managing profile resources.
@@ -167,6 +173,14 @@ func (f *FakeCalicoClient) IPReservations() clientv3.IPReservationInterface {
 	panic("not implemented")
 }
 
+func (f *FakeCalicoClient) IPPoolMigrations() clientv3.IPPoolMigrationInterface {
+	return f.ipPoolMigrationClient
+}
+
+func (f *FakeCalicoClient) QoSPolicies() clientv3.QoSPolicyInterface {
+	panic("not implemented")
+}
+
 func (f *FakeCalicoClient) BlockAffinities() clientv3.BlockAffinityInterface {
 	panic("not implemented")
 }
@@ -217,18 +231,170 @@ func (f *fakeNodeClient) Get(ctx context.Context, name string, opts options.GetO
 }
 
 func (f *fakeNodeClient) List(ctx context.Context, opts options.ListOptions) (*apiv3.NodeList, error) {
-	panic("not implemented") // TODO: Implement
+	f.Lock()
+	defer f.Unlock()
+
+	l := &apiv3.NodeList{}
+	for _, n := range f.nodes {
+		l.Items = append(l.Items, *n)
+	}
+	return l, nil
 }
 
 func (f *fakeNodeClient) Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error) {
 	panic("not implemented") // TODO: Implement
 }
 
+// fakeIPPoolClient implements the clientv3 IPPoolInterface for testing purposes.
+type fakeIPPoolClient struct {
+	sync.Mutex
+	pools map[string]*v3.IPPool
+}
+
+func (f *fakeIPPoolClient) Create(ctx context.Context, res *v3.IPPool, opts options.SetOptions) (*v3.IPPool, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if _, ok := f.pools[res.Name]; ok {
+		return nil, cerrors.ErrorResourceAlreadyExists{Identifier: res.Name}
+	}
+	f.pools[res.Name] = res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakeIPPoolClient) Update(ctx context.Context, res *v3.IPPool, opts options.SetOptions) (*v3.IPPool, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if _, ok := f.pools[res.Name]; !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: res.Name}
+	}
+	f.pools[res.Name] = res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakeIPPoolClient) Delete(ctx context.Context, name string, opts options.DeleteOptions) (*v3.IPPool, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	p, ok := f.pools[name]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: name}
+	}
+	delete(f.pools, name)
+	return p, nil
+}
+
+func (f *fakeIPPoolClient) Get(ctx context.Context, name string, opts options.GetOptions) (*v3.IPPool, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	p, ok := f.pools[name]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: name}
+	}
+	return p.DeepCopy(), nil
+}
+
+func (f *fakeIPPoolClient) List(ctx context.Context, opts options.ListOptions) (*v3.IPPoolList, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	l := &v3.IPPoolList{}
+	for _, p := range f.pools {
+		l.Items = append(l.Items, *p.DeepCopy())
+	}
+	return l, nil
+}
+
+func (f *fakeIPPoolClient) Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (f *fakeIPPoolClient) UnsafeCreate(ctx context.Context, res *v3.IPPool, opts options.SetOptions) (*v3.IPPool, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (f *fakeIPPoolClient) UnsafeDelete(ctx context.Context, name string, opts options.DeleteOptions) (*v3.IPPool, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+// fakeIPPoolMigrationClient implements the clientv3 IPPoolMigrationInterface for testing purposes.
+type fakeIPPoolMigrationClient struct {
+	sync.Mutex
+	migrations map[string]*v3.IPPoolMigration
+}
+
+func (f *fakeIPPoolMigrationClient) Create(ctx context.Context, res *v3.IPPoolMigration, opts options.SetOptions) (*v3.IPPoolMigration, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if _, ok := f.migrations[res.Name]; ok {
+		return nil, cerrors.ErrorResourceAlreadyExists{Identifier: res.Name}
+	}
+	f.migrations[res.Name] = res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakeIPPoolMigrationClient) Update(ctx context.Context, res *v3.IPPoolMigration, opts options.SetOptions) (*v3.IPPoolMigration, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if _, ok := f.migrations[res.Name]; !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: res.Name}
+	}
+	f.migrations[res.Name] = res.DeepCopy()
+	return res, nil
+}
+
+func (f *fakeIPPoolMigrationClient) Delete(ctx context.Context, name string, opts options.DeleteOptions) (*v3.IPPoolMigration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (f *fakeIPPoolMigrationClient) Get(ctx context.Context, name string, opts options.GetOptions) (*v3.IPPoolMigration, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	m, ok := f.migrations[name]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: name}
+	}
+	return m.DeepCopy(), nil
+}
+
+func (f *fakeIPPoolMigrationClient) List(ctx context.Context, opts options.ListOptions) (*v3.IPPoolMigrationList, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	list := &v3.IPPoolMigrationList{}
+	for _, m := range f.migrations {
+		list.Items = append(list.Items, *m.DeepCopy())
+	}
+	return list, nil
+}
+
+func (f *fakeIPPoolMigrationClient) Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error) {
+	panic("not implemented") // TODO: Implement
+}
+
 // fakeIPAMClient implements ipam.Interface for testing purposes.
 type fakeIPAMClient struct {
 	sync.Mutex
 	affinitiesReleased map[string]bool
 	handlesReleased    map[string]bool
+	defragPasses       int
+
+	// inUse is the number of addresses that GetUtilization reports as assigned in each pool.
+	inUse map[string]int
+}
+
+func (f *fakeIPAMClient) setInUse(pool string, n int) {
+	f.Lock()
+	defer f.Unlock()
+	if f.inUse == nil {
+		f.inUse = map[string]int{}
+	}
+	f.inUse[pool] = n
 }
 
 func (f *fakeIPAMClient) affinityReleased(aff string) bool {
@@ -369,7 +535,33 @@ func (f *fakeIPAMClient) RemoveIPAMHost(ctx context.Context, affinityCfg ipam.Af
 
 // GetUtilization returns IP utilization info for the specified pools, or for all pools.
 func (f *fakeIPAMClient) GetUtilization(ctx context.Context, args ipam.GetUtilizationArgs) ([]*ipam.PoolUtilization, error) {
-	panic("not implemented") // TODO: Implement
+	f.Lock()
+	defer f.Unlock()
+
+	var usage []*ipam.PoolUtilization
+	for _, name := range args.Pools {
+		usage = append(usage, &ipam.PoolUtilization{
+			Name:   name,
+			Blocks: []ipam.BlockUtilization{{Capacity: 64, Available: 64 - f.inUse[name]}},
+		})
+	}
+	return usage, nil
+}
+
+// DefragBlocks stops assigning addresses from sparsely-used affine blocks, and releases the
+// affinity of those blocks once they are empty.
+func (f *fakeIPAMClient) DefragBlocks(ctx context.Context, args ipam.DefragArgs) (*ipam.DefragResult, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.defragPasses++
+	return &ipam.DefragResult{}, nil
+}
+
+func (f *fakeIPAMClient) numDefragPasses() int {
+	f.Lock()
+	defer f.Unlock()
+	return f.defragPasses
 }
 
 // EnsureBlock returns single IPv4/IPv6 IPAM block for a host as specified by the provided BlockArgs.
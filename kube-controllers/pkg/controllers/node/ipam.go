@@ -311,6 +311,15 @@ func (c *IPAMController) acceptScheduleRequests(stopCh <-chan struct{}) {
 	t := time.NewTicker(period)
 	log.Infof("Will run periodic IPAM sync every %s", period)
 
+	// Periodic defragmentation ticker, if enabled.
+	var defragC <-chan time.Time
+	if c.config.IPAMDefragPeriod != nil && c.config.IPAMDefragPeriod.Duration > 0 {
+		dt := time.NewTicker(c.config.IPAMDefragPeriod.Duration)
+		defer dt.Stop()
+		defragC = dt.C
+		log.Infof("Will defragment IPAM blocks every %s", c.config.IPAMDefragPeriod.Duration)
+	}
+
 	for {
 		// Wait until something wakes us up, or we are stopped.
 		select {
@@ -340,6 +349,8 @@ func (c *IPAMController) acceptScheduleRequests(stopCh <-chan struct{}) {
 				log.WithError(err).Warn("Periodic IPAM sync failed")
 			}
 			log.Debug("Periodic IPAM sync complete")
+		case <-defragC:
+			c.defragBlocks()
 		case <-c.syncChan:
 			// Triggered IPAM sync.
 			log.Debug("Triggered IPAM sync")
@@ -1045,6 +1056,26 @@ func (c *IPAMController) allocationIsValid(a *allocation, preferCache bool) bool
 	return false
 }
 
+// defragBlocks makes a pass of IPAM block defragmentation, draining sparsely-used blocks and releasing
+// the affinity of blocks that have drained.
+func (c *IPAMController) defragBlocks() {
+	if !c.datastoreReady {
+		log.Warn("datastore is locked, skipping IPAM defragmentation")
+		return
+	}
+
+	res, err := c.client.IPAM().DefragBlocks(context.TODO(), ipam.DefragArgs{})
+	if err != nil {
+		log.WithError(err).Warn("Failed to defragment IPAM blocks")
+		return
+	}
+	log.WithFields(log.Fields{
+		"drained":  res.Drained,
+		"draining": res.Draining,
+		"released": res.Released,
+	}).Info("Defragmented IPAM blocks")
+}
+
 func (c *IPAMController) syncIPAM() error {
 	defer logIfSlow(time.Now(), "IPAM sync complete")
 
@@ -1572,6 +1572,23 @@ var _ = Describe("IPAM controller UTs", func() {
 		}, assertionTimeout, 100*time.Millisecond).Should(BeTrue(), "Affinity for dead-node should be released")
 	})
 
+	It("should periodically defragment IPAM blocks if enabled", func() {
+		c.config.IPAMDefragPeriod = &metav1.Duration{Duration: 100 * time.Millisecond}
+
+		// Start the controller.
+		c.Start(stopChan)
+
+		fakeClient := cli.IPAM().(*fakeIPAMClient)
+		Eventually(fakeClient.numDefragPasses, assertionTimeout, 100*time.Millisecond).Should(BeNumerically(">=", 2))
+	})
+
+	It("should not defragment IPAM blocks by default", func() {
+		c.Start(stopChan)
+
+		fakeClient := cli.IPAM().(*fakeIPAMClient)
+		Consistently(fakeClient.numDefragPasses, 500*time.Millisecond, 100*time.Millisecond).Should(Equal(0))
+	})
+
 	Context("with a 1hr grace period", func() {
 		ns := "test-namespace"
 		podsNode1 := []v1.Pod{
@@ -0,0 +1,385 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	client "github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/ipam"
+	cnet "github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+const (
+	// poolMigrationPeriod is how often IP pool migrations are checked for progress.
+	poolMigrationPeriod = 10 * time.Second
+
+	// defaultPoolMigrationBatchSize is the number of pods moved at once if the migration doesn't
+	// specify a batch size.
+	defaultPoolMigrationBatchSize = 10
+
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// NewPoolMigrationController creates a new controller responsible for carrying out IPPoolMigrations:
+// it disables the old pool, evicts the pods using addresses from it in batches, and deletes the pool
+// once no addresses in it are assigned.
+func NewPoolMigrationController(c client.Interface, cs kubernetes.Interface, pi cache.Indexer) *poolMigrationController {
+	return &poolMigrationController{
+		client:     c,
+		clientset:  cs,
+		podIndexer: pi,
+	}
+}
+
+type poolMigrationController struct {
+	client     client.Interface
+	clientset  kubernetes.Interface
+	podIndexer cache.Indexer
+}
+
+func (c *poolMigrationController) Start(stop chan struct{}) {
+	go c.run(stop)
+}
+
+func (c *poolMigrationController) run(stop chan struct{}) {
+	t := time.NewTicker(poolMigrationPeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			if err := c.reconcile(context.Background()); err != nil {
+				log.WithError(err).Warn("Error carrying out IP pool migrations")
+			}
+		}
+	}
+}
+
+// reconcile makes progress on each of the IP pool migrations that hasn't finished.
+func (c *poolMigrationController) reconcile(ctx context.Context) error {
+	migrations, err := c.client.IPPoolMigrations().List(ctx, options.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range migrations.Items {
+		m := &migrations.Items[i]
+		if m.Status.Phase == apiv3.IPPoolMigrationPhaseComplete || m.Status.Phase == apiv3.IPPoolMigrationPhaseFailed {
+			continue
+		}
+		logCtx := log.WithFields(log.Fields{"migration": m.Name, "from": m.Spec.FromPool, "to": m.Spec.ToPool})
+
+		status := m.Status.DeepCopy()
+		if err := c.migrate(ctx, m, status); err != nil {
+			// Leave the status alone so that we try again next time.
+			logCtx.WithError(err).Warn("Error migrating IP pool")
+			continue
+		}
+		if reflect.DeepEqual(*status, m.Status) {
+			continue
+		}
+		m.Status = *status
+		if _, err := c.client.IPPoolMigrations().Update(ctx, m, options.SetOptions{}); err != nil {
+			logCtx.WithError(err).Warn("Failed to update IP pool migration status")
+			continue
+		}
+		logCtx.WithFields(log.Fields{
+			"phase":     status.Phase,
+			"remaining": status.RemainingPods,
+		}).Info(status.Message)
+	}
+	return nil
+}
+
+// migrate makes one round of progress on the migration, recording the outcome in the given status.
+// It returns an error if the round should be retried.
+func (c *poolMigrationController) migrate(ctx context.Context, m *apiv3.IPPoolMigration, status *apiv3.IPPoolMigrationStatus) error {
+	if status.Phase == "" {
+		status.Phase = apiv3.IPPoolMigrationPhasePending
+	}
+
+	to, err := c.client.IPPools().Get(ctx, m.Spec.ToPool, options.GetOptions{})
+	if err != nil {
+		if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+			status.Message = fmt.Sprintf("IP pool %s does not exist", m.Spec.ToPool)
+			return nil
+		}
+		return err
+	}
+	if to.Spec.Disabled {
+		status.Message = fmt.Sprintf("IP pool %s is disabled", m.Spec.ToPool)
+		return nil
+	}
+
+	from, err := c.client.IPPools().Get(ctx, m.Spec.FromPool, options.GetOptions{})
+	if err != nil {
+		if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+			if status.Phase == apiv3.IPPoolMigrationPhaseMigrating {
+				// The pool has been deleted by someone else.
+				c.complete(status)
+				return nil
+			}
+			status.Phase = apiv3.IPPoolMigrationPhaseFailed
+			status.Message = fmt.Sprintf("IP pool %s does not exist", m.Spec.FromPool)
+			return nil
+		}
+		return err
+	}
+	_, fromCIDR, err := cnet.ParseCIDR(from.Spec.CIDR)
+	if err != nil {
+		status.Phase = apiv3.IPPoolMigrationPhaseFailed
+		status.Message = fmt.Sprintf("IP pool %s has an invalid CIDR: %v", m.Spec.FromPool, err)
+		return nil
+	}
+
+	if status.Phase == apiv3.IPPoolMigrationPhasePending {
+		// Only start once the evicted pods can't get addresses from any other pool.
+		msg, err := c.checkOnlyTargetPool(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		if msg != "" {
+			status.Message = msg
+			return nil
+		}
+	}
+
+	// Stop assigning addresses from the old pool, so that evicted pods get addresses from
+	// the new one.
+	if !from.Spec.Disabled {
+		from.Spec.Disabled = true
+		if _, err := c.client.IPPools().Update(ctx, from, options.SetOptions{}); err != nil {
+			return err
+		}
+		log.WithField("pool", from.Name).Info("Disabled IP pool for migration")
+	}
+
+	pods := c.podsInPool(fromCIDR)
+	if status.Phase == apiv3.IPPoolMigrationPhasePending {
+		now := metav1.Now()
+		status.Phase = apiv3.IPPoolMigrationPhaseMigrating
+		status.StartTime = &now
+		status.TotalPods = len(pods)
+	}
+	status.RemainingPods = len(pods)
+
+	if len(pods) == 0 {
+		// Not every address belongs to a running pod: pods that are still being set up or torn
+		// down, tunnel addresses and addresses assigned outside of Kubernetes only show up in
+		// IPAM.  Tunnel addresses move to another pool by themselves now that this one is
+		// disabled.
+		inUse, err := c.addressesInUse(ctx, from)
+		if err != nil {
+			return err
+		}
+		if inUse > 0 {
+			status.Message = fmt.Sprintf("Waiting for %d address(es) in IP pool %s to be released", inUse, from.Name)
+			return nil
+		}
+
+		// Deleting the pool releases the affinity of its blocks, which deletes them now that
+		// they're empty.
+		if _, err := c.client.IPPools().Delete(ctx, from.Name, options.DeleteOptions{}); err != nil {
+			if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+				return err
+			}
+		}
+		c.complete(status)
+		return nil
+	}
+
+	// Evict pods until the batch is full, counting the pods that are already terminating.
+	batchSize := m.Spec.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultPoolMigrationBatchSize
+	}
+	unmanaged, blocked := 0, 0
+	var candidates []*v1.Pod
+	for _, p := range pods {
+		switch {
+		case p.DeletionTimestamp != nil:
+			batchSize--
+		case !isManagedPod(p):
+			unmanaged++
+		default:
+			candidates = append(candidates, p)
+		}
+	}
+	status.UnmanagedPods = unmanaged
+
+	for _, p := range candidates {
+		if batchSize <= 0 {
+			break
+		}
+		logCtx := log.WithFields(log.Fields{"pod": p.Name, "namespace": p.Namespace})
+		err := c.clientset.PolicyV1().Evictions(p.Namespace).Evict(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: p.Name, Namespace: p.Namespace},
+		})
+		if err != nil {
+			if errors.IsTooManyRequests(err) {
+				// Evicting the pod would violate a PodDisruptionBudget.
+				logCtx.Debug("Pod eviction blocked by disruption budget")
+				blocked++
+				continue
+			} else if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		logCtx.Info("Evicted pod to move it to a new IP pool")
+		batchSize--
+	}
+
+	status.Message = fmt.Sprintf("Moving %d pod(s) to IP pool %s", len(pods), m.Spec.ToPool)
+	if blocked > 0 {
+		status.Message += fmt.Sprintf(", %d blocked by PodDisruptionBudgets", blocked)
+	}
+	if unmanaged > 0 {
+		status.Message += fmt.Sprintf(", %d not owned by a controller must be deleted manually", unmanaged)
+	}
+	return nil
+}
+
+// checkOnlyTargetPool checks that the new pool is the only enabled pool that could give addresses to
+// workloads on the nodes that the old pool serves, so that the evicted pods get their new addresses
+// from it.  If not, it returns a message explaining why the migration can't start.
+func (c *poolMigrationController) checkOnlyTargetPool(ctx context.Context, from, to *apiv3.IPPool) (string, error) {
+	_, fromCIDR, err := cnet.ParseCIDR(from.Spec.CIDR)
+	if err != nil {
+		return "", err
+	}
+	pools, err := c.client.IPPools().List(ctx, options.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	var others []apiv3.IPPool
+	for _, p := range pools.Items {
+		if p.Name == from.Name || p.Name == to.Name || !assignsWorkloadAddresses(p, fromCIDR.Version()) {
+			continue
+		}
+		others = append(others, p)
+	}
+
+	nodes, err := c.client.Nodes().List(ctx, options.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, n := range nodes.Items {
+		if ok, err := ipam.SelectsNode(*from, n); err != nil {
+			return "", err
+		} else if !ok {
+			continue
+		}
+		if ok, err := ipam.SelectsNode(*to, n); err != nil {
+			return "", err
+		} else if !ok {
+			return fmt.Sprintf("IP pool %s does not select node %s", to.Name, n.Name), nil
+		}
+		for _, p := range others {
+			if ok, err := ipam.SelectsNode(p, n); err == nil && ok {
+				return fmt.Sprintf("IP pool %s also assigns addresses to pods on node %s; disable it, or change its node selector, so that pods are only moved to IP pool %s",
+					p.Name, n.Name, to.Name), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// assignsWorkloadAddresses returns true if the pool automatically assigns addresses of the given IP
+// version to workloads.
+func assignsWorkloadAddresses(p apiv3.IPPool, version int) bool {
+	_, cidr, err := cnet.ParseCIDR(p.Spec.CIDR)
+	if err != nil || cidr.Version() != version || p.Spec.Disabled {
+		return false
+	}
+	if p.Spec.AssignmentMode != nil && *p.Spec.AssignmentMode == apiv3.Manual {
+		return false
+	}
+	return len(p.Spec.AllowedUses) == 0 || slices.Contains(p.Spec.AllowedUses, apiv3.IPPoolAllowedUseWorkload)
+}
+
+// addressesInUse returns the number of addresses in the pool that IPAM has assigned.
+func (c *poolMigrationController) addressesInUse(ctx context.Context, pool *apiv3.IPPool) (int, error) {
+	usage, err := c.client.IPAM().GetUtilization(ctx, ipam.GetUtilizationArgs{Pools: []string{pool.Name}})
+	if err != nil {
+		return 0, err
+	}
+	inUse := 0
+	for _, poolUse := range usage {
+		for _, b := range poolUse.Blocks {
+			inUse += b.Capacity - b.Available - b.Reserved
+		}
+	}
+	return inUse, nil
+}
+
+func (c *poolMigrationController) complete(status *apiv3.IPPoolMigrationStatus) {
+	now := metav1.Now()
+	status.Phase = apiv3.IPPoolMigrationPhaseComplete
+	status.Message = "Migration complete, the old IP pool has been deleted"
+	status.RemainingPods = 0
+	status.UnmanagedPods = 0
+	status.CompletionTime = &now
+}
+
+// podsInPool returns the running pods that have an address in the given CIDR, sorted by namespace
+// and name.
+func (c *poolMigrationController) podsInPool(cidr *cnet.IPNet) []*v1.Pod {
+	var pods []*v1.Pod
+	for _, obj := range c.podIndexer.List() {
+		p, ok := obj.(*v1.Pod)
+		if !ok || p.Spec.HostNetwork || p.Status.Phase == v1.PodSucceeded || p.Status.Phase == v1.PodFailed {
+			continue
+		}
+		for _, podIP := range p.Status.PodIPs {
+			if ip := cnet.ParseIP(podIP.IP); ip != nil && cidr.Contains(ip.IP) {
+				pods = append(pods, p)
+				break
+			}
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods
+}
+
+// isManagedPod returns true if the pod would be recreated by its controller if it were evicted.
+func isManagedPod(p *v1.Pod) bool {
+	if _, ok := p.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	return metav1.GetControllerOf(p) != nil
+}
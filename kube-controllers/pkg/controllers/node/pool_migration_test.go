@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	libapiv3 "github.com/projectcalico/calico/libcalico-go/lib/apis/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+)
+
+var _ = Describe("IP pool migration controller UTs", func() {
+	var c *poolMigrationController
+	var cli *FakeCalicoClient
+	var cs *fake.Clientset
+	var indexer cache.Indexer
+	var evicted []string
+	var pdbProtected map[string]bool
+	ctx := context.Background()
+
+	addPod := func(name, ip string, managed bool) *v1.Pod {
+		p := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: v1.PodStatus{
+				Phase:  v1.PodRunning,
+				PodIPs: []v1.PodIP{{IP: ip}},
+			},
+		}
+		if managed {
+			isController := true
+			p.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       "rs",
+				Controller: &isController,
+			}}
+		}
+		Expect(indexer.Add(p)).To(Succeed())
+		return p
+	}
+
+	getMigration := func() *apiv3.IPPoolMigration {
+		m, err := cli.IPPoolMigrations().Get(ctx, "migration", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return m
+	}
+
+	BeforeEach(func() {
+		cs = fake.NewSimpleClientset()
+		cli = NewFakeCalicoClient()
+		indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		c = NewPoolMigrationController(cli, cs, indexer)
+
+		// Record evictions, and reject those of pods protected by a disruption budget.
+		evicted = nil
+		pdbProtected = map[string]bool{}
+		cs.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+			if pdbProtected[eviction.Name] {
+				return true, nil, errors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			}
+			evicted = append(evicted, eviction.Name)
+			return true, nil, nil
+		})
+
+		for name, cidr := range map[string]string{"old-pool": "10.0.0.0/16", "new-pool": "10.1.0.0/16"} {
+			pool := apiv3.NewIPPool()
+			pool.Name = name
+			pool.Spec.CIDR = cidr
+			_, err := cli.IPPools().Create(ctx, pool, options.SetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		m := apiv3.NewIPPoolMigration()
+		m.Name = "migration"
+		m.Spec = apiv3.IPPoolMigrationSpec{FromPool: "old-pool", ToPool: "new-pool", BatchSize: 2}
+		_, err := cli.IPPoolMigrations().Create(ctx, m, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should disable the old pool and evict its pods in batches", func() {
+		addPod("pod-a", "10.0.0.1", true)
+		addPod("pod-b", "10.0.0.2", true)
+		addPod("pod-c", "10.0.0.3", true)
+		addPod("bare-pod", "10.0.0.4", false)
+		addPod("new-pod", "10.1.0.1", true)
+		hostNetworked := addPod("host-networked", "10.0.0.5", true)
+		hostNetworked.Spec.HostNetwork = true
+
+		Expect(c.reconcile(ctx)).To(Succeed())
+
+		pool, err := cli.IPPools().Get(ctx, "old-pool", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pool.Spec.Disabled).To(BeTrue())
+
+		Expect(evicted).To(Equal([]string{"pod-a", "pod-b"}))
+		m := getMigration()
+		Expect(m.Status.Phase).To(Equal(apiv3.IPPoolMigrationPhaseMigrating))
+		Expect(m.Status.StartTime).NotTo(BeNil())
+		Expect(m.Status.TotalPods).To(Equal(4))
+		Expect(m.Status.RemainingPods).To(Equal(4))
+		Expect(m.Status.UnmanagedPods).To(Equal(1))
+		Expect(m.Status.Message).To(ContainSubstring("1 not owned by a controller"))
+
+		// The evicted pods are terminating, so no more pods are evicted until they're gone.
+		for _, name := range []string{"pod-a", "pod-b"} {
+			obj, _, err := indexer.GetByKey("default/" + name)
+			Expect(err).NotTo(HaveOccurred())
+			now := metav1.Now()
+			obj.(*v1.Pod).DeletionTimestamp = &now
+		}
+		evicted = nil
+		Expect(c.reconcile(ctx)).To(Succeed())
+		Expect(evicted).To(BeEmpty())
+
+		// Once one of them has gone, the next pod is evicted.
+		Expect(indexer.Delete(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "default"}})).To(Succeed())
+		Expect(c.reconcile(ctx)).To(Succeed())
+		Expect(evicted).To(Equal([]string{"pod-c"}))
+		Expect(getMigration().Status.RemainingPods).To(Equal(3))
+		Expect(getMigration().Status.TotalPods).To(Equal(4))
+	})
+
+	It("should report evictions blocked by disruption budgets", func() {
+		addPod("pod-a", "10.0.0.1", true)
+		addPod("pod-b", "10.0.0.2", true)
+		pdbProtected["pod-a"] = true
+
+		Expect(c.reconcile(ctx)).To(Succeed())
+		Expect(evicted).To(Equal([]string{"pod-b"}))
+		Expect(getMigration().Status.Message).To(ContainSubstring("1 blocked by PodDisruptionBudgets"))
+	})
+
+	It("should delete the old pool once it has no pods", func() {
+		addPod("new-pod", "10.1.0.1", true)
+		Expect(c.reconcile(ctx)).To(Succeed())
+
+		_, err := cli.IPPools().Get(ctx, "old-pool", options.GetOptions{})
+		Expect(err).To(HaveOccurred())
+		m := getMigration()
+		Expect(m.Status.Phase).To(Equal(apiv3.IPPoolMigrationPhaseComplete))
+		Expect(m.Status.CompletionTime).NotTo(BeNil())
+
+		// A completed migration is left alone.
+		Expect(c.reconcile(ctx)).To(Succeed())
+		Expect(getMigration().Status).To(Equal(m.Status))
+	})
+
+	It("should wait for addresses that don't belong to running pods before deleting the old pool", func() {
+		cli.ipamClient.(*fakeIPAMClient).setInUse("old-pool", 2)
+		Expect(c.reconcile(ctx)).To(Succeed())
+
+		_, err := cli.IPPools().Get(ctx, "old-pool", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		m := getMigration()
+		Expect(m.Status.Phase).To(Equal(apiv3.IPPoolMigrationPhaseMigrating))
+		Expect(m.Status.Message).To(Equal("Waiting for 2 address(es) in IP pool old-pool to be released"))
+
+		cli.ipamClient.(*fakeIPAMClient).setInUse("old-pool", 0)
+		Expect(c.reconcile(ctx)).To(Succeed())
+		_, err = cli.IPPools().Get(ctx, "old-pool", options.GetOptions{})
+		Expect(err).To(HaveOccurred())
+		Expect(getMigration().Status.Phase).To(Equal(apiv3.IPPoolMigrationPhaseComplete))
+	})
+
+	It("should not start while another pool could assign addresses on the old pool's nodes", func() {
+		for name, labels := range map[string]map[string]string{
+			"node-a": {"zone": "a"},
+			"node-b": {"zone": "b"},
+		} {
+			n := libapiv3.NewNode()
+			n.Name = name
+			n.Labels = labels
+			_, err := cli.Nodes().Create(ctx, n, options.SetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		other := apiv3.NewIPPool()
+		other.Name = "other-pool"
+		other.Spec.CIDR = "10.2.0.0/16"
+		other.Spec.NodeSelector = "zone == 'b'"
+		_, err := cli.IPPools().Create(ctx, other, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		addPod("pod-a", "10.0.0.1", true)
+
+		Expect(c.reconcile(ctx)).To(Succeed())
+		Expect(evicted).To(BeEmpty())
+		m := getMigration()
+		Expect(m.Status.Phase).To(Equal(apiv3.IPPoolMigrationPhasePending))
+		Expect(m.Status.Message).To(HavePrefix("IP pool other-pool also assigns addresses to pods on node node-b"))
+		old, err := cli.IPPools().Get(ctx, "old-pool", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(old.Spec.Disabled).To(BeFalse())
+
+		// Pools that don't serve the old pool's nodes, or only assign addresses on request, are fine.
+		old.Spec.NodeSelector = "zone == 'a'"
+		_, err = cli.IPPools().Update(ctx, old, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		manual := apiv3.NewIPPool()
+		manual.Name = "manual-pool"
+		manual.Spec.CIDR = "10.3.0.0/16"
+		manualMode := apiv3.Manual
+		manual.Spec.AssignmentMode = &manualMode
+		_, err = cli.IPPools().Create(ctx, manual, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.reconcile(ctx)).To(Succeed())
+		Expect(evicted).To(Equal([]string{"pod-a"}))
+		Expect(getMigration().Status.Phase).To(Equal(apiv3.IPPoolMigrationPhaseMigrating))
+	})
+
+	It("should not start if the new pool doesn't select the old pool's nodes", func() {
+		n := libapiv3.NewNode()
+		n.Name = "node-a"
+		_, err := cli.Nodes().Create(ctx, n, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		pool, err := cli.IPPools().Get(ctx, "new-pool", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		pool.Spec.NodeSelector = "zone == 'b'"
+		_, err = cli.IPPools().Update(ctx, pool, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.reconcile(ctx)).To(Succeed())
+		m := getMigration()
+		Expect(m.Status.Phase).To(Equal(apiv3.IPPoolMigrationPhasePending))
+		Expect(m.Status.Message).To(Equal("IP pool new-pool does not select node node-a"))
+	})
+
+	It("should wait for the new pool to be enabled", func() {
+		pool, err := cli.IPPools().Get(ctx, "new-pool", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		pool.Spec.Disabled = true
+		_, err = cli.IPPools().Update(ctx, pool, options.SetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		addPod("pod-a", "10.0.0.1", true)
+
+		Expect(c.reconcile(ctx)).To(Succeed())
+		Expect(evicted).To(BeEmpty())
+		m := getMigration()
+		Expect(m.Status.Phase).To(Equal(apiv3.IPPoolMigrationPhasePending))
+		Expect(m.Status.Message).To(Equal("IP pool new-pool is disabled"))
+		old, err := cli.IPPools().Get(ctx, "old-pool", options.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(old.Spec.Disabled).To(BeFalse())
+	})
+
+	It("should fail if the old pool doesn't exist", func() {
+		_, err := cli.IPPools().Delete(ctx, "old-pool", options.DeleteOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.reconcile(ctx)).To(Succeed())
+		m := getMigration()
+		Expect(m.Status.Phase).To(Equal(apiv3.IPPoolMigrationPhaseFailed))
+		Expect(m.Status.Message).To(Equal("IP pool old-pool does not exist"))
+	})
+})
@@ -75,6 +75,32 @@ func TestJSONListResponse(t *testing.T) {
 	}))
 }
 
+func TestJSONResponse(t *testing.T) {
+	setupTest(t)
+
+	type Request struct {
+		ReqField string `urlQuery:"reqField"`
+	}
+	type Response struct {
+		RespField string `json:"rspField"`
+	}
+
+	hdlr := apiutil.NewJSONHandler(func(ctx apicontext.Context, params Request) apiutil.Response[Response] {
+		Expect(params.ReqField).To(Equal("value"))
+		return apiutil.NewResponse[Response]().SetStatus(http.StatusOK).SetBody(Response{RespField: "foo"})
+	})
+
+	w := httptest.NewRecorder()
+
+	r, err := http.NewRequest(http.MethodGet, "foobar?reqField=value", nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	hdlr.ServeHTTP(apiutil.NewNOOPRouterConfig(), w, r)
+
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(testutil.MustUnmarshal[Response](t, w.Body.Bytes())).To(Equal(&Response{RespField: "foo"}))
+}
+
 func TestJSONStreamResponse(t *testing.T) {
 	setupTest(t)
 
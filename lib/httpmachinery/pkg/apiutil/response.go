@@ -92,6 +92,40 @@ func (l ListResponse[E]) ResponseWriter() ResponseWriter {
 	return &jsonListResponseWriter[E]{items: l.rsp}
 }
 
+// Response implements the ResponseWriter and writes the response as a single object.
+type Response[E any] struct {
+	baseResponse
+	body E
+}
+
+func NewResponse[E any]() Response[E] {
+	return Response[E]{}
+}
+
+func (r Response[E]) SetStatus(status int) Response[E] {
+	r.status = status
+	return r
+}
+
+func (r Response[E]) SetError(err string) Response[E] {
+	r.errMsg = err
+	return r
+}
+
+func (r Response[E]) SetBody(body E) Response[E] {
+	r.body = body
+	return r
+}
+
+// ResponseWriter returns a ResponseWriter to write the http response as a json object.
+func (r Response[E]) ResponseWriter() ResponseWriter {
+	if r.errMsg != "" {
+		return &jsonErrorResponseWriter{r.errMsg}
+	}
+
+	return &jsonResponseWriter[E]{body: r.body}
+}
+
 // ListOrStreamResponse implements the ResponseWriter and writes the response as either a stream or a list, depending
 // on whether SendStream or SendList was called.
 type ListOrStreamResponse[E any] struct {
@@ -181,6 +215,17 @@ func (rs *jsonListResponseWriter[Body]) WriteResponse(ctx apicontext.Context, st
 	return nil
 }
 
+// jsonResponseWriter is used to write a single json object.
+type jsonResponseWriter[Body any] struct {
+	body Body
+}
+
+func (rs *jsonResponseWriter[Body]) WriteResponse(ctx apicontext.Context, status int, w http.ResponseWriter) error {
+	w.WriteHeader(status)
+	writeJSONResponse(w, rs.body)
+	return nil
+}
+
 // jsonErrorResponseWriter is used to respond with a json error.
 type jsonErrorResponseWriter struct {
 	error string
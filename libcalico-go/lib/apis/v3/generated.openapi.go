@@ -2436,6 +2436,13 @@ func schema_libcalico_go_lib_apis_v3_IPAMBlockSpec(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"draining": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Draining is set on blocks that are being defragmented.  No new addresses are automatically assigned from a draining block, and its affinity is released once it is empty.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"strictAffinity": {
 						SchemaProps: spec.SchemaProps{
 							Description: "StrictAffinity on the IPAMBlock is deprecated and no longer used by the code. Use IPAMConfig StrictAffinity instead.",
@@ -3024,6 +3031,20 @@ func schema_libcalico_go_lib_apis_v3_NodeStatus(ref common.ReferenceCallback) co
 							Format:      "",
 						},
 					},
+					"wireguardNextPublicKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WireguardNextPublicKey is the IPv4 Wireguard public-key that this node is about to switch to as part of a key rotation.  Peers use it to prepare for the switch.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"wireguardNextPublicKeyV6": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WireguardNextPublicKeyV6 is the IPv6 Wireguard public-key that this node is about to switch to as part of a key rotation.  Peers use it to prepare for the switch.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"podCIDRs": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PodCIDR is a reflection of the Kubernetes node's spec.PodCIDRs field.",
@@ -79,6 +79,11 @@ type IPAMBlockSpec struct {
 	// +optional
 	Deleted bool `json:"deleted"`
 
+	// Draining is set on blocks that are being defragmented.  No new addresses are automatically
+	// assigned from a draining block, and its affinity is released once it is empty.
+	// +optional
+	Draining bool `json:"draining,omitempty"`
+
 	// StrictAffinity on the IPAMBlock is deprecated and no longer used by the code. Use IPAMConfig StrictAffinity instead.
 	DeprecatedStrictAffinity bool `json:"strictAffinity"`
 }
@@ -87,6 +87,14 @@ type NodeStatus struct {
 	// wireguardPublicKey validates if the string is a valid base64 encoded key.
 	WireguardPublicKeyV6 string `json:"wireguardPublicKeyV6,omitempty" validate:"omitempty,wireguardPublicKey"`
 
+	// WireguardNextPublicKey is the IPv4 Wireguard public-key that this node is about to switch to as part of a key
+	// rotation.  Peers use it to prepare for the switch.
+	WireguardNextPublicKey string `json:"wireguardNextPublicKey,omitempty" validate:"omitempty,wireguardPublicKey"`
+
+	// WireguardNextPublicKeyV6 is the IPv6 Wireguard public-key that this node is about to switch to as part of a key
+	// rotation.  Peers use it to prepare for the switch.
+	WireguardNextPublicKeyV6 string `json:"wireguardNextPublicKeyV6,omitempty" validate:"omitempty,wireguardPublicKey"`
+
 	// PodCIDR is a reflection of the Kubernetes node's spec.PodCIDRs field.
 	PodCIDRs []string `json:"podCIDRs,omitempty" validate:"omitempty"`
 }
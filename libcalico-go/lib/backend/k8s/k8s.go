@@ -117,6 +117,18 @@ func NewKubeClient(ca *apiconfig.CalicoAPIConfigSpec) (api.Client, error) {
 		apiv3.KindIPReservation,
 		resources.NewIPReservationClient(cs, crdClientV1),
 	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		apiv3.KindIPPoolMigration,
+		resources.NewIPPoolMigrationClient(cs, crdClientV1),
+	)
+	kubeClient.registerResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		apiv3.KindQoSPolicy,
+		resources.NewQoSPolicyClient(cs, crdClientV1),
+	)
 	kubeClient.registerResourceClient(
 		reflect.TypeOf(model.ResourceKey{}),
 		reflect.TypeOf(model.ResourceListOptions{}),
@@ -474,6 +486,8 @@ func (c *KubeClient) Clean() error {
 		apiv3.KindNetworkSet,
 		apiv3.KindIPPool,
 		apiv3.KindIPReservation,
+		apiv3.KindIPPoolMigration,
+		apiv3.KindQoSPolicy,
 		apiv3.KindHostEndpoint,
 		apiv3.KindKubeControllersConfiguration,
 		libapiv3.KindIPAMConfig,
@@ -225,6 +225,7 @@ func IPAMBlockV3toV1(kvpv3 *model.KVPair) (*model.KVPair, error) {
 			Unallocated:                 ab.Spec.Unallocated,
 			Attributes:                  attrs,
 			Deleted:                     ab.Spec.Deleted,
+			Draining:                    ab.Spec.Draining,
 			SequenceNumber:              ab.Spec.SequenceNumber,
 			SequenceNumberForAllocation: ab.Spec.SequenceNumberForAllocation,
 		},
@@ -268,6 +269,7 @@ func IPAMBlockV1toV3(kvpv1 *model.KVPair) *model.KVPair {
 				Affinity:                    ab.Affinity,
 				Attributes:                  attrs,
 				Deleted:                     ab.Deleted,
+				Draining:                    ab.Draining,
 				SequenceNumber:              ab.SequenceNumber,
 				SequenceNumberForAllocation: ab.SequenceNumberForAllocation,
 			},
@@ -41,20 +41,22 @@ import (
 )
 
 const (
-	nodeBgpIpv4AddrAnnotation             = "projectcalico.org/IPv4Address"
-	nodeBgpIpv4IPIPTunnelAddrAnnotation   = "projectcalico.org/IPv4IPIPTunnelAddr"
-	nodeBgpIpv4VXLANTunnelAddrAnnotation  = "projectcalico.org/IPv4VXLANTunnelAddr"
-	nodeBgpVXLANTunnelMACAddrAnnotation   = "projectcalico.org/VXLANTunnelMACAddr"
-	nodeBgpIpv6VXLANTunnelAddrAnnotation  = "projectcalico.org/IPv6VXLANTunnelAddr"
-	nodeBgpVXLANTunnelMACAddrV6Annotation = "projectcalico.org/VXLANTunnelMACAddrV6"
-	nodeBgpIpv6AddrAnnotation             = "projectcalico.org/IPv6Address"
-	nodeBgpAsnAnnotation                  = "projectcalico.org/ASNumber"
-	nodeBgpCIDAnnotation                  = "projectcalico.org/RouteReflectorClusterID"
-	nodeK8sLabelAnnotation                = "projectcalico.org/kube-labels"
-	nodeWireguardIpv4IfaceAddrAnnotation  = "projectcalico.org/IPv4WireguardInterfaceAddr"
-	nodeWireguardIpv6IfaceAddrAnnotation  = "projectcalico.org/IPv6WireguardInterfaceAddr"
-	nodeWireguardPublicKeyAnnotation      = "projectcalico.org/WireguardPublicKey"
-	nodeWireguardPublicKeyV6Annotation    = "projectcalico.org/WireguardPublicKeyV6"
+	nodeBgpIpv4AddrAnnotation              = "projectcalico.org/IPv4Address"
+	nodeBgpIpv4IPIPTunnelAddrAnnotation    = "projectcalico.org/IPv4IPIPTunnelAddr"
+	nodeBgpIpv4VXLANTunnelAddrAnnotation   = "projectcalico.org/IPv4VXLANTunnelAddr"
+	nodeBgpVXLANTunnelMACAddrAnnotation    = "projectcalico.org/VXLANTunnelMACAddr"
+	nodeBgpIpv6VXLANTunnelAddrAnnotation   = "projectcalico.org/IPv6VXLANTunnelAddr"
+	nodeBgpVXLANTunnelMACAddrV6Annotation  = "projectcalico.org/VXLANTunnelMACAddrV6"
+	nodeBgpIpv6AddrAnnotation              = "projectcalico.org/IPv6Address"
+	nodeBgpAsnAnnotation                   = "projectcalico.org/ASNumber"
+	nodeBgpCIDAnnotation                   = "projectcalico.org/RouteReflectorClusterID"
+	nodeK8sLabelAnnotation                 = "projectcalico.org/kube-labels"
+	nodeWireguardIpv4IfaceAddrAnnotation   = "projectcalico.org/IPv4WireguardInterfaceAddr"
+	nodeWireguardIpv6IfaceAddrAnnotation   = "projectcalico.org/IPv6WireguardInterfaceAddr"
+	nodeWireguardPublicKeyAnnotation       = "projectcalico.org/WireguardPublicKey"
+	nodeWireguardPublicKeyV6Annotation     = "projectcalico.org/WireguardPublicKeyV6"
+	nodeWireguardNextPublicKeyAnnotation   = "projectcalico.org/WireguardNextPublicKey"
+	nodeWireguardNextPublicKeyV6Annotation = "projectcalico.org/WireguardNextPublicKeyV6"
 )
 
 func NewNodeClient(c kubernetes.Interface, usePodCIDR bool) K8sResourceClient {
@@ -273,6 +275,8 @@ func K8sNodeToCalico(k8sNode *kapiv1.Node, usePodCIDR bool) (*model.KVPair, erro
 	nodeStatus := libapiv3.NodeStatus{}
 	nodeStatus.WireguardPublicKey = annotations[nodeWireguardPublicKeyAnnotation]
 	nodeStatus.WireguardPublicKeyV6 = annotations[nodeWireguardPublicKeyV6Annotation]
+	nodeStatus.WireguardNextPublicKey = annotations[nodeWireguardNextPublicKeyAnnotation]
+	nodeStatus.WireguardNextPublicKeyV6 = annotations[nodeWireguardNextPublicKeyV6Annotation]
 	if !reflect.DeepEqual(nodeStatus, libapiv3.NodeStatus{}) {
 		calicoNode.Status = nodeStatus
 	}
@@ -432,6 +436,16 @@ func mergeCalicoNodeIntoK8sNode(calicoNode *libapiv3.Node, k8sNode *kapiv1.Node)
 	} else {
 		delete(k8sNode.Annotations, nodeWireguardPublicKeyV6Annotation)
 	}
+	if calicoNode.Status.WireguardNextPublicKey != "" {
+		k8sNode.Annotations[nodeWireguardNextPublicKeyAnnotation] = calicoNode.Status.WireguardNextPublicKey
+	} else {
+		delete(k8sNode.Annotations, nodeWireguardNextPublicKeyAnnotation)
+	}
+	if calicoNode.Status.WireguardNextPublicKeyV6 != "" {
+		k8sNode.Annotations[nodeWireguardNextPublicKeyV6Annotation] = calicoNode.Status.WireguardNextPublicKeyV6
+	} else {
+		delete(k8sNode.Annotations, nodeWireguardNextPublicKeyV6Annotation)
+	}
 
 	return k8sNode, nil
 }
@@ -40,6 +40,7 @@ const (
 	IPAMBlockAttributeTypeWireguard   = "wireguardTunnelAddress"
 	IPAMBlockAttributeTypeWireguardV6 = "wireguardV6TunnelAddress"
 	IPAMBlockAttributeTimestamp       = "timestamp"
+	IPAMBlockAttributeQuotaCounted    = "quotaCounted"
 	IPAMAffinityTypeHost              = "host"
 	IPAMAffinityTypeVirtual           = "virtual"
 )
@@ -143,6 +144,10 @@ type AllocationBlock struct {
 	// deletion will not return a conflict error if the block has been updated.
 	Deleted bool `json:"deleted"`
 
+	// Draining is set on blocks that are being defragmented.  No new addresses are automatically
+	// assigned from a draining block, and its affinity is released once it is empty.
+	Draining bool `json:"draining,omitempty"`
+
 	// HostAffinity is deprecated in favor of Affinity.
 	// This is only to keep compatibility with existing deployments.
 	// The data format should be `Affinity: host:hostname` (not `hostAffinity: hostname`).
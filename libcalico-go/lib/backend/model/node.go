@@ -64,6 +64,8 @@ type Wireguard struct {
 	PublicKey         string  `json:"publicKey,omitempty"`
 	InterfaceIPv6Addr *net.IP `json:"interfaceIPv6Addr,omitempty"`
 	PublicKeyV6       string  `json:"publicKeyV6,omitempty"`
+	NextPublicKey     string  `json:"nextPublicKey,omitempty"`
+	NextPublicKeyV6   string  `json:"nextPublicKeyV6,omitempty"`
 }
 
 type NodeKey struct {
@@ -19,9 +19,11 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/projectcalico/calico/libcalico-go/lib/errors"
 )
@@ -161,6 +163,9 @@ type Policy struct {
 	Types            []string                      `json:"types,omitempty"`
 	PerformanceHints []apiv3.PolicyPerformanceHint `json:"performance_hints,omitempty" validate:"omitempty,unique,dive,oneof=AssumeNeededOnEveryNode"`
 	StagedAction     *apiv3.StagedAction           `json:"staged_action,omitempty"`
+	ActiveFrom       *metav1.Time                  `json:"active_from,omitempty"`
+	ActiveUntil      *metav1.Time                  `json:"active_until,omitempty"`
+	ActiveSchedule   []apiv3.PolicyScheduleWindow  `json:"active_schedule,omitempty"`
 }
 
 func (p Policy) String() string {
@@ -189,5 +194,14 @@ func (p Policy) String() string {
 	if p.StagedAction != nil {
 		parts = append(parts, fmt.Sprintf("staged_action:%v", p.StagedAction))
 	}
+	if p.ActiveFrom != nil {
+		parts = append(parts, fmt.Sprintf("active_from:%v", p.ActiveFrom.UTC().Format(time.RFC3339)))
+	}
+	if p.ActiveUntil != nil {
+		parts = append(parts, fmt.Sprintf("active_until:%v", p.ActiveUntil.UTC().Format(time.RFC3339)))
+	}
+	if len(p.ActiveSchedule) > 0 {
+		parts = append(parts, fmt.Sprintf("active_schedule:%v", p.ActiveSchedule))
+	}
 	return strings.Join(parts, ",")
 }
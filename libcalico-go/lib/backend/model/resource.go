@@ -126,6 +126,16 @@ func init() {
 		"ipreservations",
 		reflect.TypeOf(apiv3.IPReservation{}),
 	)
+	registerResourceInfo(
+		apiv3.KindIPPoolMigration,
+		"ippoolmigrations",
+		reflect.TypeOf(apiv3.IPPoolMigration{}),
+	)
+	registerResourceInfo(
+		apiv3.KindQoSPolicy,
+		"qospolicies",
+		reflect.TypeOf(apiv3.QoSPolicy{}),
+	)
 	registerResourceInfo(
 		apiv3.KindNetworkPolicy,
 		"networkpolicies",
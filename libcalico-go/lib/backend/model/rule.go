@@ -55,6 +55,7 @@ type Rule struct {
 	DstPorts            []numorstring.Port `json:"dst_ports,omitempty" validate:"omitempty,dive"`
 	DstService          string             `json:"dst_service,omitempty" validate:"omitempty"`
 	DstServiceNamespace string             `json:"dst_service_ns,omitempty" validate:"omitempty"`
+	DstDomains          []string           `json:"dst_domains,omitempty" validate:"omitempty"`
 
 	NotSrcTag      string             `json:"!src_tag,omitempty" validate:"omitempty,tag"`
 	NotSrcNet      *net.IPNet         `json:"!src_net,omitempty" validate:"omitempty"`
@@ -84,14 +85,20 @@ type Rule struct {
 	// These fields allow us to pass through application layer selectors from the V3 datamodel.
 	HTTPMatch *HTTPMatch `json:"http,omitempty" validate:"omitempty"`
 
+	// DSCP is the DSCP that a setdscp rule sets on the packets that it matches.
+	DSCP *numorstring.DSCP `json:"dscp,omitempty" validate:"omitempty"`
+
 	LogPrefix string `json:"log_prefix,omitempty" validate:"omitempty"`
 
 	Metadata *RuleMetadata `json:"metadata,omitempty" validate:"omitempty"`
 }
 
 type HTTPMatch struct {
-	Methods []string         `json:"methods,omitempty" validate:"omitempty"`
-	Paths   []apiv3.HTTPPath `json:"paths,omitempty" validate:"omitempty"`
+	Methods     []string                `json:"methods,omitempty" validate:"omitempty"`
+	Paths       []apiv3.HTTPPath        `json:"paths,omitempty" validate:"omitempty"`
+	Headers     []apiv3.HTTPHeaderMatch `json:"headers,omitempty" validate:"omitempty"`
+	Hosts       []string                `json:"hosts,omitempty" validate:"omitempty"`
+	GRPCMethods []apiv3.GRPCMethodMatch `json:"grpc_methods,omitempty" validate:"omitempty"`
 }
 
 type RuleMetadata struct {
@@ -143,6 +150,9 @@ func (r Rule) String() string {
 	} else {
 		parts = append(parts, "Allow")
 	}
+	if r.DSCP != nil {
+		parts = append(parts, r.DSCP.String())
+	}
 
 	// Global packet attributes that don't depend on direction.
 	if r.Protocol != nil {
@@ -230,6 +240,9 @@ func (r Rule) String() string {
 		if len(dstNets) != 0 {
 			toParts = append(toParts, "cidr", joinNets(dstNets))
 		}
+		if len(r.DstDomains) != 0 {
+			toParts = append(toParts, "domains", strings.Join(r.DstDomains, ","))
+		}
 		if len(r.NotDstPorts) > 0 {
 			notDstPorts := make([]string, len(r.NotDstPorts))
 			for ii, port := range r.NotDstPorts {
@@ -256,6 +269,19 @@ func (r Rule) String() string {
 			if len(r.HTTPMatch.Paths) > 0 {
 				toParts = append(toParts, "httpPaths", fmt.Sprintf("%+v", r.HTTPMatch.Paths))
 			}
+			if len(r.HTTPMatch.Headers) > 0 {
+				headers := make([]string, len(r.HTTPMatch.Headers))
+				for ii, h := range r.HTTPMatch.Headers {
+					headers[ii] = headerMatchString(h)
+				}
+				toParts = append(toParts, "httpHeaders", "["+strings.Join(headers, " ")+"]")
+			}
+			if len(r.HTTPMatch.Hosts) > 0 {
+				toParts = append(toParts, "httpHosts", fmt.Sprintf("%+v", r.HTTPMatch.Hosts))
+			}
+			if len(r.HTTPMatch.GRPCMethods) > 0 {
+				toParts = append(toParts, "grpcMethods", fmt.Sprintf("%+v", r.HTTPMatch.GRPCMethods))
+			}
 		}
 
 		if len(toParts) > 0 {
@@ -266,3 +292,17 @@ func (r Rule) String() string {
 
 	return strings.Join(parts, " ")
 }
+
+// headerMatchString returns a compact representation of an HTTP header match, for use in the rule's String().
+func headerMatchString(h apiv3.HTTPHeaderMatch) string {
+	switch {
+	case h.Exact != "":
+		return fmt.Sprintf("%s==%q", h.Name, h.Exact)
+	case h.Regex != "":
+		return fmt.Sprintf("%s=~%q", h.Name, h.Regex)
+	case h.Present != nil && !*h.Present:
+		return "!" + h.Name
+	default:
+		return h.Name
+	}
+}
@@ -47,8 +47,22 @@ var ports2 = []numorstring.Port{
 	numorstring.SinglePort(4567),
 }
 var _, cidr, _ = net.ParseCIDR("10.0.0.0/16")
+var (
+	dscpEF = numorstring.DSCPFromString("EF")
+	dscp10 = numorstring.DSCPFromInt(10)
+)
+
 var httpMethod = &model.HTTPMatch{Methods: []string{"GET", "PUT"}}
 var httpPath = &model.HTTPMatch{Paths: []apiv3.HTTPPath{{Exact: "/foo"}, {Prefix: "/bar"}}}
+var notPresent = false
+var httpHeaders = &model.HTTPMatch{Headers: []apiv3.HTTPHeaderMatch{
+	{Name: "x-version", Exact: "v2"},
+	{Name: "x-user", Regex: "admin-.*"},
+	{Name: "x-debug", Present: &notPresent},
+	{Name: "authorization"},
+}}
+var httpHosts = &model.HTTPMatch{Hosts: []string{"example.com", "*.example.org"}}
+var grpcMethods = &model.HTTPMatch{GRPCMethods: []apiv3.GRPCMethodMatch{{Service: "helloworld.Greeter", Method: "SayHello"}}}
 
 var ruleStringTests = []ruleTest{
 	// Empty
@@ -94,10 +108,18 @@ var ruleStringTests = []ruleTest{
 	{model.Rule{NotDstTag: "foo"}, "Allow to !tag foo"},
 	{model.Rule{NotDstSelector: "bar"}, "Allow to !selector \"bar\""},
 	{model.Rule{NotDstNet: cidr}, "Allow to !cidr 10.0.0.0/16"},
+	{model.Rule{DstDomains: []string{"example.com", "*.example.org"}}, "Allow to domains example.com,*.example.org"},
 
 	// Application layer rules.
 	{model.Rule{HTTPMatch: httpMethod}, "Allow to httpMethods [GET PUT]"},
 	{model.Rule{HTTPMatch: httpPath}, "Allow to httpPaths [{Exact:/foo Prefix:} {Exact: Prefix:/bar}]"},
+	{model.Rule{HTTPMatch: httpHeaders}, `Allow to httpHeaders [x-version=="v2" x-user=~"admin-.*" !x-debug authorization]`},
+	{model.Rule{HTTPMatch: httpHosts}, "Allow to httpHosts [example.com *.example.org]"},
+	{model.Rule{HTTPMatch: grpcMethods}, "Allow to grpcMethods [{Service:helloworld.Greeter Method:SayHello}]"},
+
+	// DSCP marking rules.
+	{model.Rule{Action: "setdscp", DSCP: &dscpEF}, "setdscp EF"},
+	{model.Rule{Action: "setdscp", DSCP: &dscp10, Protocol: &tcpProto}, "setdscp 10 TCP"},
 
 	// Complex rule.
 	{model.Rule{Protocol: &tcpProto,
@@ -205,6 +205,19 @@ func (c *FelixNodeUpdateProcessor) Process(kvp *model.KVPair) ([]*model.KVPair,
 			}
 		}
 
+		// The next public-keys are only published during a key rotation.  An invalid key is ignored rather than
+		// treated as an error, since the node can still be reached using its current key.
+		wgNextPubKey := node.Status.WireguardNextPublicKey
+		if _, err := wg.ParseKey(wgNextPubKey); wgNextPubKey != "" && err != nil {
+			log.WithField("WireguardNextPublicKey", wgNextPubKey).Warn("Failed to parse next IPv4 Wireguard public-key")
+			wgNextPubKey = ""
+		}
+		wgNextPubKeyV6 := node.Status.WireguardNextPublicKeyV6
+		if _, err := wg.ParseKey(wgNextPubKeyV6); wgNextPubKeyV6 != "" && err != nil {
+			log.WithField("WireguardNextPublicKeyV6", wgNextPubKeyV6).Warn("Failed to parse next IPv6 Wireguard public-key")
+			wgNextPubKeyV6 = ""
+		}
+
 		// If either of interface address or public-key is set, set the WireguardKey value.
 		// If we failed to parse both the values, leave the WireguardKey value empty.
 		if wgIfaceIpv4Addr != nil || wgPubKey != "" || wgIfaceIpv6Addr != nil || wgPubKeyV6 != "" {
@@ -213,6 +226,8 @@ func (c *FelixNodeUpdateProcessor) Process(kvp *model.KVPair) ([]*model.KVPair,
 				PublicKey:         wgPubKey,
 				InterfaceIPv6Addr: wgIfaceIpv6Addr,
 				PublicKeyV6:       wgPubKeyV6,
+				NextPublicKey:     wgNextPubKey,
+				NextPublicKeyV6:   wgNextPubKeyV6,
 			}
 		}
 	}
@@ -205,6 +205,34 @@ var _ = Describe("Test the (Felix) Node update processor", func() {
 			expected,
 		)
 
+		By("converting a Node with a Wireguard public-key and next public-key")
+		nextKey := "mVqaFGXodI4J6NUc4a5ogwXw8LdYMh/ciPZdmw6W6lA="
+		res = libapiv3.NewNode()
+		res.Name = "mynode"
+		res.Status = libapiv3.NodeStatus{
+			WireguardPublicKey:       key,
+			WireguardNextPublicKey:   nextKey,
+			WireguardNextPublicKeyV6: "not-a-key",
+		}
+		expected = map[string]interface{}{
+			nodeMarker: res,
+			wireguardMarker: &model.Wireguard{
+				PublicKey:     key,
+				NextPublicKey: nextKey,
+			},
+		}
+		kvps, err = up.Process(&model.KVPair{
+			Key:   v3NodeKey1,
+			Value: res,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		checkExpectedConfigs(
+			kvps,
+			isNodeFelixConfig,
+			numFelixConfigs,
+			expected,
+		)
+
 		By("converting a Node with IPv4 and IPv6 networks and no other config")
 		res = libapiv3.NewNode()
 		res.Name = "mynode"
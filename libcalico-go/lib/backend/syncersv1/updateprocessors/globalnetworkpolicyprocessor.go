@@ -83,6 +83,9 @@ func ConvertGlobalNetworkPolicyV3ToV1Value(val interface{}) (interface{}, error)
 		PreDNAT:          spec.PreDNAT,
 		ApplyOnForward:   spec.ApplyOnForward,
 		PerformanceHints: v3res.Spec.PerformanceHints,
+		ActiveFrom:       spec.ActiveFrom,
+		ActiveUntil:      spec.ActiveUntil,
+		ActiveSchedule:   spec.ActiveSchedule,
 	}
 
 	return v1value, nil
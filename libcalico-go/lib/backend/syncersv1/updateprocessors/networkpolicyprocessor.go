@@ -80,6 +80,9 @@ func ConvertNetworkPolicyV3ToV1Value(val interface{}) (interface{}, error) {
 		Types:            policyTypesAPIV3ToBackend(spec.Types),
 		ApplyOnForward:   false,
 		PerformanceHints: v3res.Spec.PerformanceHints,
+		ActiveFrom:       spec.ActiveFrom,
+		ActiveUntil:      spec.ActiveUntil,
+		ActiveSchedule:   spec.ActiveSchedule,
 	}
 
 	return v1value, nil
@@ -181,6 +181,7 @@ func RuleAPIV3ToBackend(ar apiv3.Rule, ns string) model.Rule {
 		DstPorts:            ar.Destination.Ports,
 		DstService:          dstService,
 		DstServiceNamespace: dstServiceNS,
+		DstDomains:          ar.Destination.Domains,
 
 		NotSrcNets:     ConvertStringsToNets(ar.Source.NotNets),
 		NotSrcSelector: ar.Source.NotSelector,
@@ -202,7 +203,17 @@ func RuleAPIV3ToBackend(ar apiv3.Rule, ns string) model.Rule {
 		OriginalDstServiceAccountSelector: dstServiceAcctMatch.Selector,
 	}
 	if ar.HTTP != nil {
-		r.HTTPMatch = &model.HTTPMatch{Methods: ar.HTTP.Methods, Paths: ar.HTTP.Paths}
+		r.HTTPMatch = &model.HTTPMatch{
+			Methods:     ar.HTTP.Methods,
+			Paths:       ar.HTTP.Paths,
+			Headers:     ar.HTTP.Headers,
+			Hosts:       ar.HTTP.Hosts,
+			GRPCMethods: ar.HTTP.GRPCMethods,
+		}
+	}
+	if ar.DSCP != nil {
+		dscp := *ar.DSCP
+		r.DSCP = &dscp
 	}
 	if ar.Metadata != nil {
 		if ar.Metadata.Annotations != nil {
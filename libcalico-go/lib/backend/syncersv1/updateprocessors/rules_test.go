@@ -69,8 +69,11 @@ var _ = Describe("Test the Rules Conversion Functions", func() {
 				NotPorts:    []numorstring.Port{port80},
 			},
 			HTTP: &apiv3.HTTPMatch{
-				Methods: []string{"GET", "PUT"},
-				Paths:   []apiv3.HTTPPath{{Exact: "/bar"}, {Prefix: "/foo1"}},
+				Methods:     []string{"GET", "PUT"},
+				Paths:       []apiv3.HTTPPath{{Exact: "/bar"}, {Prefix: "/foo1"}},
+				Headers:     []apiv3.HTTPHeaderMatch{{Name: "x-version", Exact: "v2"}},
+				Hosts:       []string{"*.example.com"},
+				GRPCMethods: []apiv3.GRPCMethodMatch{{Service: "helloworld.Greeter"}},
 			},
 			Metadata: &apiv3.RuleMetadata{
 				Annotations: map[string]string{"fizz": "buzz"}},
@@ -120,6 +123,9 @@ var _ = Describe("Test the Rules Conversion Functions", func() {
 
 		Expect(rulev1.HTTPMatch.Methods).To(Equal([]string{"GET", "PUT"}))
 		Expect(rulev1.HTTPMatch.Paths).To(Equal([]apiv3.HTTPPath{{Exact: "/bar"}, {Prefix: "/foo1"}}))
+		Expect(rulev1.HTTPMatch.Headers).To(Equal([]apiv3.HTTPHeaderMatch{{Name: "x-version", Exact: "v2"}}))
+		Expect(rulev1.HTTPMatch.Hosts).To(Equal([]string{"*.example.com"}))
+		Expect(rulev1.HTTPMatch.GRPCMethods).To(Equal([]apiv3.GRPCMethodMatch{{Service: "helloworld.Greeter"}}))
 
 		Expect(rulev1.Metadata.Annotations).To(Equal(map[string]string{"fizz": "buzz"}))
 
@@ -325,6 +331,25 @@ var _ = Describe("Test the Rules Conversion Functions", func() {
 
 	})
 
+	It("should parse a SetDSCP rule", func() {
+		dscp := numorstring.DSCPFromString("AF41")
+		r := apiv3.Rule{
+			Action: apiv3.SetDSCP,
+			DSCP:   &dscp,
+		}
+
+		// Process the rule and get the corresponding v1 representation.
+		rulev1 := updateprocessors.RuleAPIV3ToBackend(r, "")
+
+		By("generating the correct action", func() {
+			Expect(rulev1.Action).To(Equal("setdscp"))
+		})
+
+		By("copying the DSCP", func() {
+			Expect(rulev1.DSCP).To(Equal(&dscp))
+		})
+	})
+
 	It("should parse a rule with both a selector and namespace selector", func() {
 		r := apiv3.Rule{
 			Action: apiv3.Allow,
@@ -474,6 +499,26 @@ var _ = Describe("Test the Rules Conversion Functions", func() {
 		})
 	})
 
+	It("should parse a destination rule domains match", func() {
+		r := apiv3.Rule{
+			Action: apiv3.Allow,
+			Destination: apiv3.EntityRule{
+				Domains: []string{"example.com", "*.example.org"},
+			},
+		}
+
+		// Process the rule and get the corresponding v1 representation.
+		rulev1 := updateprocessors.RuleAPIV3ToBackend(r, "")
+
+		By("generating an empty destination selector", func() {
+			Expect(rulev1.DstSelector).To(Equal(""))
+		})
+
+		By("copying the domains", func() {
+			Expect(rulev1.DstDomains).To(Equal([]string{"example.com", "*.example.org"}))
+		})
+	})
+
 	It("should parse a source rule services match", func() {
 		r := apiv3.Rule{
 			Action: apiv3.Allow,
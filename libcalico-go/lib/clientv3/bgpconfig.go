@@ -165,6 +165,13 @@ func (r bgpConfigurations) ValidateDefaultOnlyFields(res *apiv3.BGPConfiguration
 				Reason: "Cannot set nodeMeshMaxRestartTime on a non default BGP Configuration.",
 			})
 		}
+
+		if res.Spec.NodeMeshBFD != nil {
+			errFields = append(errFields, cerrors.ErroredField{
+				Name:   "BGPConfiguration.Spec.NodeMeshBFD",
+				Reason: "Cannot set nodeMeshBFD on a non default BGP Configuration.",
+			})
+		}
 	}
 
 	if len(errFields) > 0 {
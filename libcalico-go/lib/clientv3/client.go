@@ -114,6 +114,16 @@ func (c client) IPReservations() IPReservationInterface {
 	return ipReservations{client: c}
 }
 
+// IPPoolMigrations returns an interface for managing IP pool migration resources.
+func (c client) IPPoolMigrations() IPPoolMigrationInterface {
+	return ipPoolMigrations{client: c}
+}
+
+// QoSPolicies returns an interface for managing QoS policy resources.
+func (c client) QoSPolicies() QoSPolicyInterface {
+	return qosPolicies{client: c}
+}
+
 // Profiles returns an interface for managing profile resources.
 func (c client) Profiles() ProfileInterface {
 	return profiles{client: c}
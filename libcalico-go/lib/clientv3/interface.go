@@ -26,6 +26,8 @@ type Interface interface {
 	NetworkPoliciesClient
 	IPPoolsClient
 	IPReservationsClient
+	IPPoolMigrationsClient
+	QoSPoliciesClient
 	ProfilesClient
 	GlobalNetworkSetsClient
 	NetworkSetsClient
@@ -84,6 +86,16 @@ type IPReservationsClient interface {
 	IPReservations() IPReservationInterface
 }
 
+type IPPoolMigrationsClient interface {
+	// IPPoolMigrations returns an interface for managing IP pool migration resources.
+	IPPoolMigrations() IPPoolMigrationInterface
+}
+
+type QoSPoliciesClient interface {
+	// QoSPolicies returns an interface for managing QoS policy resources.
+	QoSPolicies() QoSPolicyInterface
+}
+
 type ProfilesClient interface {
 	// Profiles returns an interface for managing profile resources.
 	Profiles() ProfileInterface
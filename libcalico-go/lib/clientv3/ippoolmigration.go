@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// IPPoolMigrationInterface has methods to work with IPPoolMigration resources.
+type IPPoolMigrationInterface interface {
+	Create(ctx context.Context, res *apiv3.IPPoolMigration, opts options.SetOptions) (*apiv3.IPPoolMigration, error)
+	Update(ctx context.Context, res *apiv3.IPPoolMigration, opts options.SetOptions) (*apiv3.IPPoolMigration, error)
+	Delete(ctx context.Context, name string, opts options.DeleteOptions) (*apiv3.IPPoolMigration, error)
+	Get(ctx context.Context, name string, opts options.GetOptions) (*apiv3.IPPoolMigration, error)
+	List(ctx context.Context, opts options.ListOptions) (*apiv3.IPPoolMigrationList, error)
+	Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error)
+}
+
+// ipPoolMigrations implements IPPoolMigrationInterface
+type ipPoolMigrations struct {
+	client client
+}
+
+// Create takes the representation of an IPPoolMigration and creates it.  Returns the stored
+// representation of the IPPoolMigration, and an error, if there is any.
+func (r ipPoolMigrations) Create(ctx context.Context, res *apiv3.IPPoolMigration, opts options.SetOptions) (*apiv3.IPPoolMigration, error) {
+	// Validate the IPPoolMigration before creating the resource.
+	if err := validator.Validate(res); err != nil {
+		return nil, err
+	}
+
+	out, err := r.client.resources.Create(ctx, opts, apiv3.KindIPPoolMigration, res)
+	if out != nil {
+		return out.(*apiv3.IPPoolMigration), err
+	}
+	return nil, err
+
+}
+
+// Update takes the representation of an IPPoolMigration and updates it. Returns the stored
+// representation of the IPPoolMigration, and an error, if there is any.
+func (r ipPoolMigrations) Update(ctx context.Context, res *apiv3.IPPoolMigration, opts options.SetOptions) (*apiv3.IPPoolMigration, error) {
+	if err := validator.Validate(res); err != nil {
+		return nil, err
+	}
+
+	out, err := r.client.resources.Update(ctx, opts, apiv3.KindIPPoolMigration, res)
+	if out != nil {
+		return out.(*apiv3.IPPoolMigration), err
+	}
+	return nil, err
+}
+
+// Delete takes name of the IPPoolMigration and deletes it. Returns an error if one occurs.
+func (r ipPoolMigrations) Delete(ctx context.Context, name string, opts options.DeleteOptions) (*apiv3.IPPoolMigration, error) {
+	log.WithField("name", name).Info("Deleting IP pool migration")
+	out, err := r.client.resources.Delete(ctx, opts, apiv3.KindIPPoolMigration, noNamespace, name)
+	if out != nil {
+		return out.(*apiv3.IPPoolMigration), err
+	}
+	return nil, err
+}
+
+// Get takes name of the IPPoolMigration, and returns the corresponding IPPoolMigration object,
+// and an error if there is any.
+func (r ipPoolMigrations) Get(ctx context.Context, name string, opts options.GetOptions) (*apiv3.IPPoolMigration, error) {
+	out, err := r.client.resources.Get(ctx, opts, apiv3.KindIPPoolMigration, noNamespace, name)
+	if out != nil {
+		return out.(*apiv3.IPPoolMigration), err
+	}
+
+	return nil, err
+}
+
+// List returns the list of IPPoolMigration objects that match the supplied options.
+func (r ipPoolMigrations) List(ctx context.Context, opts options.ListOptions) (*apiv3.IPPoolMigrationList, error) {
+	res := &apiv3.IPPoolMigrationList{}
+	if err := r.client.resources.List(ctx, opts, apiv3.KindIPPoolMigration, apiv3.KindIPPoolMigrationList, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Watch returns a watch.Interface that watches the IPPoolMigrations that match the
+// supplied options.
+func (r ipPoolMigrations) Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error) {
+	return r.client.resources.Watch(ctx, opts, apiv3.KindIPPoolMigration, nil)
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"sort"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// DefaultDefragThreshold is the default percentage of a block's addresses that may be in use for
+// defragmentation to drain the block.
+const DefaultDefragThreshold = 10
+
+// DefragBlocks stops assigning addresses from sparsely-used affine blocks, and releases the affinity
+// of those blocks once they are empty, so that their addresses can be used by other hosts.
+//
+// A block is only drained if the host's other blocks in the same pool have enough free addresses to
+// hold the addresses in use in the block, so that the workloads using them can be recreated on the
+// host without claiming a new block.  Blocks holding tunnel addresses are never drained.
+func (c ipamClient) DefragBlocks(ctx context.Context, args DefragArgs) (*DefragResult, error) {
+	threshold := args.Threshold
+	if threshold == 0 {
+		threshold = DefaultDefragThreshold
+	}
+
+	allPools, err := c.pools.GetAllPools(ctx)
+	if err != nil {
+		log.WithError(err).Errorf("Error getting IP pools")
+		return nil, err
+	}
+	wantedPools := set.FromArray(args.Pools)
+	var pools []v3.IPPool
+	for _, pool := range allPools {
+		if len(args.Pools) == 0 ||
+			wantedPools.Contains(pool.Name) ||
+			wantedPools.Contains(pool.Spec.CIDR) {
+			pools = append(pools, pool)
+		}
+	}
+
+	// Group the affine blocks by pool.
+	blocks, err := c.client.List(ctx, model.BlockListOptions{}, "")
+	if err != nil {
+		return nil, err
+	}
+	blocksByPool := map[string][]*model.KVPair{}
+	for _, kvp := range blocks.KVPairs {
+		b := kvp.Value.(*model.AllocationBlock)
+		if b.AffinityType() != model.IPAMAffinityTypeHost {
+			continue
+		}
+		pool, err := findContainingPool(pools, b.CIDR.IP)
+		if err != nil {
+			return nil, err
+		}
+		if pool != nil {
+			blocksByPool[pool.Spec.CIDR] = append(blocksByPool[pool.Spec.CIDR], kvp)
+		}
+	}
+
+	result := &DefragResult{}
+	for _, pool := range pools {
+		kvps := blocksByPool[pool.Spec.CIDR]
+		logCtx := log.WithField("pool", pool.Spec.CIDR)
+
+		if args.Cancel {
+			for _, kvp := range kvps {
+				b := kvp.Value.(*model.AllocationBlock)
+				if !b.Draining {
+					continue
+				}
+				if !args.DryRun {
+					b.Draining = false
+					if _, err := c.blockReaderWriter.updateBlock(ctx, kvp); err != nil {
+						return nil, err
+					}
+				}
+				logCtx.WithField("block", b.CIDR).Info("Stopped draining block")
+				result.Restored = append(result.Restored, b.CIDR)
+			}
+			continue
+		}
+
+		var allocBlocks []*model.AllocationBlock
+		kvpByCIDR := map[string]*model.KVPair{}
+		for _, kvp := range kvps {
+			b := kvp.Value.(*model.AllocationBlock)
+			allocBlocks = append(allocBlocks, b)
+			kvpByCIDR[b.CIDR.String()] = kvp
+		}
+
+		// Start draining the blocks that are sparsely used.  Updating the block is a compare-and-swap,
+		// so if an address is assigned from the block in the meantime we'll skip it until the next pass.
+		for _, b := range defragCandidates(allocBlocks, threshold) {
+			b.Draining = true
+			if !args.DryRun {
+				kvp := kvpByCIDR[b.CIDR.String()]
+				if _, err := c.blockReaderWriter.updateBlock(ctx, kvp); err != nil {
+					if _, ok := err.(cerrors.ErrorResourceUpdateConflict); ok {
+						logCtx.WithField("block", b.CIDR).Info("Block was updated, will try to drain it next time")
+						b.Draining = false
+						continue
+					}
+					return nil, err
+				}
+			}
+			logCtx.WithFields(log.Fields{"block": b.CIDR, "host": b.Host()}).Info("Draining sparsely-used block")
+			result.Drained = append(result.Drained, b.CIDR)
+		}
+
+		// Release the affinity of the blocks that have drained.
+		for _, b := range allocBlocks {
+			if !b.Draining {
+				continue
+			}
+			if !(allocationBlock{b}).empty() {
+				result.Draining = append(result.Draining, b.CIDR)
+				continue
+			}
+			if !args.DryRun {
+				affinityCfg := AffinityConfig{AffinityType: AffinityTypeHost, Host: b.Host()}
+				if err := c.blockReaderWriter.releaseBlockAffinity(ctx, affinityCfg, b.CIDR, true); err != nil {
+					if _, ok := err.(errBlockClaimConflict); ok {
+						// Claimed by a different host - ignore.
+					} else if _, ok := err.(errBlockNotEmpty); ok {
+						// An address has been assigned since we listed the blocks - try again next time.
+						result.Draining = append(result.Draining, b.CIDR)
+						continue
+					} else if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+						// Block does not exist - ignore.
+					} else {
+						return nil, err
+					}
+				}
+			}
+			logCtx.WithFields(log.Fields{"block": b.CIDR, "host": b.Host()}).Info("Released affinity of drained block")
+			result.Released = append(result.Released, b.CIDR)
+		}
+	}
+	return result, nil
+}
+
+// defragCandidates returns the blocks that defragmentation should drain out of the given blocks, which
+// must all be from the same pool.  Each host's emptiest blocks are drained first, as long as the host's
+// remaining blocks have room for the addresses in use in them.  A host always keeps at least one block.
+func defragCandidates(blocks []*model.AllocationBlock, threshold int) []*model.AllocationBlock {
+	byHost := map[string][]*model.AllocationBlock{}
+	var hosts []string
+	for _, b := range blocks {
+		if b.AffinityType() != model.IPAMAffinityTypeHost {
+			continue
+		}
+		host := b.Host()
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], b)
+	}
+	sort.Strings(hosts)
+
+	var candidates []*model.AllocationBlock
+	for _, host := range hosts {
+		hostBlocks := byHost[host]
+		sort.SliceStable(hostBlocks, func(i, j int) bool {
+			if ni, nj := numInUse(hostBlocks[i]), numInUse(hostBlocks[j]); ni != nj {
+				return ni < nj
+			}
+			return hostBlocks[i].CIDR.String() < hostBlocks[j].CIDR.String()
+		})
+
+		// Track the free addresses in, and number of, the host's blocks that aren't draining.
+		free, active := 0, 0
+		for _, b := range hostBlocks {
+			if !b.Draining {
+				free += len(b.Unallocated)
+				active++
+			}
+		}
+
+		for _, b := range hostBlocks {
+			if b.Draining || active <= 1 {
+				continue
+			}
+			inUse := numInUse(b)
+			if inUse*100 > threshold*b.NumAddresses() {
+				// The blocks are sorted, so the rest of the host's blocks are fuller still.
+				break
+			}
+			if inUse > free-len(b.Unallocated) || holdsTunnelAddress(b) {
+				continue
+			}
+			free -= len(b.Unallocated)
+			active--
+			candidates = append(candidates, b)
+		}
+	}
+	return candidates
+}
+
+// numInUse returns the number of addresses in use in the block.
+func numInUse(b *model.AllocationBlock) int {
+	n := 0
+	for _, attrIdx := range b.Allocations {
+		if attrIdx != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// holdsTunnelAddress returns true if one of the block's addresses is a host's tunnel address.  These are
+// only released if the host's tunnel configuration changes, so the block would never drain.
+func holdsTunnelAddress(b *model.AllocationBlock) bool {
+	for _, attrIdx := range b.Allocations {
+		if attrIdx == nil || *attrIdx >= len(b.Attributes) {
+			continue
+		}
+		switch b.Attributes[*attrIdx].AttrSecondary[AttributeType] {
+		case AttributeTypeIPIP, AttributeTypeVXLAN, AttributeTypeVXLANV6, AttributeTypeWireguard, AttributeTypeWireguardV6:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+// defragTestBlock returns a /29 block affine to the given host with the given number of addresses in use.
+func defragTestBlock(cidr, host string, inUse int, attrs map[string]string) *model.AllocationBlock {
+	b := newBlock(net.MustParseCIDR(cidr), nil)
+	aff := "host:" + host
+	b.Affinity = &aff
+	if inUse > 0 {
+		handle := "h"
+		_, err := b.autoAssign(inUse, &handle, AffinityConfig{AffinityType: AffinityTypeHost, Host: host}, attrs, true, nilAddrFilter{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+	return b.AllocationBlock
+}
+
+func cidrsOf(blocks []*model.AllocationBlock) []string {
+	var cidrs []string
+	for _, b := range blocks {
+		cidrs = append(cidrs, b.CIDR.String())
+	}
+	return cidrs
+}
+
+func TestDefragCandidates(t *testing.T) {
+	RegisterTestingT(t)
+
+	blocks := []*model.AllocationBlock{
+		// host-a has two sparse blocks, and a fuller one with room for their addresses.
+		defragTestBlock("10.0.0.16/29", "host-a", 6, nil),
+		defragTestBlock("10.0.0.8/29", "host-a", 1, nil),
+		defragTestBlock("10.0.0.0/29", "host-a", 0, nil),
+
+		// host-b's only block is never drained, even though it's empty.
+		defragTestBlock("10.0.1.0/29", "host-b", 0, nil),
+
+		// host-c's fuller block has no room for the address in its sparse block.
+		defragTestBlock("10.0.2.0/29", "host-c", 1, nil),
+		defragTestBlock("10.0.2.8/29", "host-c", 8, nil),
+
+		// host-d's sparse block holds its tunnel address.
+		defragTestBlock("10.0.3.0/29", "host-d", 1, map[string]string{AttributeType: AttributeTypeVXLAN}),
+		defragTestBlock("10.0.3.8/29", "host-d", 2, nil),
+	}
+
+	Expect(cidrsOf(defragCandidates(blocks, 20))).To(Equal([]string{"10.0.0.0/29", "10.0.0.8/29"}))
+
+	// With a lower threshold, only the empty block is drained.
+	Expect(cidrsOf(defragCandidates(blocks, 10))).To(Equal([]string{"10.0.0.0/29"}))
+}
+
+func TestDefragCandidatesSkipsDrainingBlocks(t *testing.T) {
+	RegisterTestingT(t)
+
+	draining := defragTestBlock("10.0.0.0/29", "host-a", 1, nil)
+	draining.Draining = true
+	blocks := []*model.AllocationBlock{
+		draining,
+		defragTestBlock("10.0.0.8/29", "host-a", 0, nil),
+	}
+
+	// The host's only block that isn't draining is kept.
+	Expect(defragCandidates(blocks, 20)).To(BeEmpty())
+}
+
+func TestAutoAssignSkipsDrainingBlock(t *testing.T) {
+	RegisterTestingT(t)
+
+	b := newBlock(net.MustParseCIDR("10.0.0.0/29"), nil)
+	b.Draining = true
+	handle := "h"
+	ips, err := b.autoAssign(1, &handle, AffinityConfig{AffinityType: AffinityTypeHost, Host: "host"}, nil, false, nilAddrFilter{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(ips).To(BeEmpty())
+	Expect(b.Unallocated).To(HaveLen(8))
+}
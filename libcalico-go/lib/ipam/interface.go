@@ -100,6 +100,11 @@ type Interface interface {
 	// GetUtilization returns IP utilization info for the specified pools, or for all pools.
 	GetUtilization(ctx context.Context, args GetUtilizationArgs) ([]*PoolUtilization, error)
 
+	// DefragBlocks stops assigning addresses from sparsely-used affine blocks, and releases the
+	// affinity of those blocks once they are empty, so that their addresses can be used by other
+	// hosts.  Each call makes one pass; call it periodically to complete the defragmentation.
+	DefragBlocks(ctx context.Context, args DefragArgs) (*DefragResult, error)
+
 	// EnsureBlock returns single IPv4/IPv6 IPAM block for a host as specified by the provided BlockArgs.
 	// If there is no block allocated already for this host, allocate one and return its CIDR.
 	// Otherwise, return the CIDR of the IPAM block allocated for this host.
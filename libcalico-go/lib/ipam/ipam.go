@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/bits"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -49,6 +51,7 @@ const (
 	AttributeNamespace       = model.IPAMBlockAttributeNamespace
 	AttributeNode            = model.IPAMBlockAttributeNode
 	AttributeTimestamp       = model.IPAMBlockAttributeTimestamp
+	AttributeQuotaCounted    = model.IPAMBlockAttributeQuotaCounted
 	AttributeType            = model.IPAMBlockAttributeType
 	AttributeService         = model.IPAMBlockAttributeService
 	AttributeTypeIPIP        = model.IPAMBlockAttributeTypeIPIP
@@ -529,6 +532,10 @@ func (s *blockAssignState) findOrClaimBlock(ctx context.Context, minFreeIps int)
 
 			// Pull out the block.
 			block := allocationBlock{b.Value.(*model.AllocationBlock)}
+			if block.Draining {
+				logCtx.Debugf("Block '%s' is draining, try next one", cidr.String())
+				break
+			}
 			numFreeAddresses := block.NumFreeAddresses(s.reservations)
 			if numFreeAddresses >= minFreeIps {
 				logCtx.Debugf("Block '%s' has %d free ips which is more than %d ips required.", cidr.String(), numFreeAddresses, minFreeIps)
@@ -694,6 +701,18 @@ func (c ipamClient) autoAssign(ctx context.Context, num int, handleID *string, a
 		return nil, err
 	}
 
+	// Drop any pools in which the workload's namespace has used up its quota.
+	pools, affBlocks, quotas, err := c.filterPoolsByQuota(ctx, pools, affBlocks, version, attrs[AttributeNamespace], num)
+	if err != nil {
+		return nil, err
+	}
+	if quotas != nil {
+		// Mark the addresses as counted against the namespace's quota, so that releasing them
+		// updates the count.
+		attrs = maps.Clone(attrs)
+		attrs[AttributeQuotaCounted] = "true"
+	}
+
 	logCtx.Debugf("Found %d affine IPv%d blocks for host: %v", len(affBlocks), version, affBlocks)
 
 	// Record how many blocks we own so we can check against the limit later.
@@ -875,6 +894,11 @@ func (c ipamClient) autoAssign(ctx context.Context, num int, handleID *string, a
 		}
 	}
 
+	// Check that concurrent assignments didn't take the namespace over its quota.
+	if err := c.checkQuotasAfterAssign(ctx, quotas, ia); err != nil {
+		return ia, err
+	}
+
 	logCtx.Infof("Auto-assigned %d out of %d IPv%ds: %v", len(ia.IPs), num, version, ia.IPs)
 	return ia, nil
 }
@@ -908,6 +932,16 @@ func (c ipamClient) AssignIP(ctx context.Context, args AssignIPArgs) error {
 		return errors.New("The provided IP address is not in a configured pool\n")
 	}
 
+	// Quotas aren't enforced for specific addresses, but the address still counts towards its
+	// namespace's usage.
+	attrs := args.Attrs
+	namespace := attrs[AttributeNamespace]
+	countQuota := namespace != "" && len(pool.Spec.Quotas) > 0
+	if countQuota {
+		attrs = maps.Clone(attrs)
+		attrs[AttributeQuotaCounted] = "true"
+	}
+
 	cfg, err := c.GetIPAMConfig(ctx)
 	if err != nil {
 		log.Errorf("Error getting IPAM Config: %v", err)
@@ -956,7 +990,7 @@ func (c ipamClient) AssignIP(ctx context.Context, args AssignIPArgs) error {
 		}
 
 		block := allocationBlock{obj.Value.(*model.AllocationBlock)}
-		err = block.assign(cfg.StrictAffinity, args.IP, args.HandleID, args.Attrs, affinityCfg)
+		err = block.assign(cfg.StrictAffinity, args.IP, args.HandleID, attrs, affinityCfg)
 		if err != nil {
 			log.Errorf("Failed to assign address %v: %v", args.IP, err)
 			return err
@@ -985,6 +1019,9 @@ func (c ipamClient) AssignIP(ctx context.Context, args AssignIPArgs) error {
 			}
 			return err
 		}
+		if countQuota {
+			c.adjustQuotaUsage(ctx, blockCIDR, map[string]int{namespace: 1}, 1)
+		}
 		return nil
 	}
 	return errors.New("Max retries hit - excessive concurrent IPAM requests")
@@ -1167,6 +1204,7 @@ func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[strin
 
 		// Release the IPs.
 		b := allocationBlock{obj.Value.(*model.AllocationBlock)}
+		counted := quotaCountedAllocations(b.AllocationBlock)
 		unallocated, handles, err2 := b.release(ips)
 		if err2 != nil {
 			return nil, err2
@@ -1177,6 +1215,8 @@ func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[strin
 			logCtx.Debug("No IPs need to be released")
 			return unallocated, nil
 		}
+		released := quotaCountsReleased(counted, b.AllocationBlock)
+		c.adjustQuotaUsage(ctx, blockCIDR, released, -1)
 
 		// If the block is empty and has no affinity, we can delete it.
 		// Otherwise, update the block using CAS.  There is no need to update
@@ -1192,6 +1232,7 @@ func (c ipamClient) releaseIPsFromBlock(ctx context.Context, handleMap map[strin
 		}
 
 		if updateErr != nil {
+			c.adjustQuotaUsage(ctx, blockCIDR, released, 1)
 			if _, ok := updateErr.(cerrors.ErrorResourceUpdateConflict); ok {
 				// Comparison error - retry.
 				logCtx.Warningf("Failed to update block - retry #%d", i)
@@ -1676,6 +1717,7 @@ func (c ipamClient) releaseByHandle(ctx context.Context, blockCIDR net.IPNet, op
 
 		// Release the IP by handle.
 		block := allocationBlock{obj.Value.(*model.AllocationBlock)}
+		counted := quotaCountedAllocations(block.AllocationBlock)
 		num := block.releaseByHandle(opts)
 		if num == 0 {
 			// Block has no addresses with this handle, so
@@ -1684,11 +1726,16 @@ func (c ipamClient) releaseByHandle(ctx context.Context, blockCIDR net.IPNet, op
 			return nil
 		}
 		logCtx.Debugf("Block has %d IPs with the given handle", num)
+		released := quotaCountsReleased(counted, block.AllocationBlock)
+		c.adjustQuotaUsage(ctx, blockCIDR, released, -1)
 
 		if block.empty() && block.Affinity == nil {
 			logCtx.Info("Deleting block because it is now empty and has no affinity")
 			err = c.blockReaderWriter.deleteBlock(ctx, obj)
 			if err != nil {
+				if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+					c.adjustQuotaUsage(ctx, blockCIDR, released, 1)
+				}
 				if _, ok := err.(cerrors.ErrorResourceUpdateConflict); ok {
 					logCtx.Debug("CAD error deleting block - retry")
 					continue
@@ -1708,6 +1755,7 @@ func (c ipamClient) releaseByHandle(ctx context.Context, blockCIDR net.IPNet, op
 			logCtx.Debug("Updating block to release IPs")
 			obj, err = c.blockReaderWriter.updateBlock(ctx, obj)
 			if err != nil {
+				c.adjustQuotaUsage(ctx, blockCIDR, released, 1)
 				if _, ok := err.(cerrors.ErrorResourceUpdateConflict); ok {
 					// Comparison failed - retry.
 					logCtx.Warningf("CAS error for block, retry #%d: %v", i, err)
@@ -2132,14 +2180,21 @@ func (c ipamClient) GetUtilization(ctx context.Context, args GetUtilizationArgs)
 	// Identify the ones we want and create a PoolUtilization for each of those.
 	wantAllPools := len(args.Pools) == 0
 	wantedPools := set.FromArray(args.Pools)
+	quotaPools := map[*PoolUtilization]v3.IPPool{}
+	poolBlocks := map[*PoolUtilization][]*model.AllocationBlock{}
 	for _, pool := range allPools {
 		if wantAllPools ||
 			wantedPools.Contains(pool.Name) ||
 			wantedPools.Contains(pool.Spec.CIDR) {
-			usage = append(usage, &PoolUtilization{
+			poolUse := &PoolUtilization{
 				Name: pool.Name,
 				CIDR: net.MustParseNetwork(pool.Spec.CIDR).IPNet,
-			})
+			}
+			usage = append(usage, poolUse)
+			poolBlocks[poolUse] = nil
+			if len(pool.Spec.Quotas) > 0 {
+				quotaPools[poolUse] = pool
+			}
 		}
 	}
 
@@ -2159,6 +2214,7 @@ func (c ipamClient) GetUtilization(ctx context.Context, args GetUtilizationArgs)
 	if err != nil {
 		return nil, err
 	}
+	nsInUse := map[*PoolUtilization]map[string]int{}
 	for _, kvp := range blocks.KVPairs {
 		b := kvp.Value.(*model.AllocationBlock)
 		log.Debugf("Got block: %v", b)
@@ -2171,11 +2227,62 @@ func (c ipamClient) GetUtilization(ctx context.Context, args GetUtilizationArgs)
 					CIDR:      b.CIDR.IPNet,
 					Capacity:  b.NumAddresses(),
 					Available: len(b.Unallocated),
+					Reserved:  (&allocationBlock{b}).numReservedIPs(),
+					Draining:  b.Draining,
 				})
+				if _, ok := poolBlocks[poolUse]; ok {
+					poolBlocks[poolUse] = append(poolBlocks[poolUse], b)
+				}
+				if _, ok := quotaPools[poolUse]; ok {
+					if nsInUse[poolUse] == nil {
+						nsInUse[poolUse] = map[string]int{}
+					}
+					for ns, n := range namespaceAllocations(b) {
+						nsInUse[poolUse][ns] += n
+					}
+				}
 				break
 			}
 		}
 	}
+
+	// Report the capacity that defragmentation would return to each pool.
+	threshold := args.DefragThreshold
+	if threshold == 0 {
+		threshold = DefaultDefragThreshold
+	}
+	for poolUse, blocks := range poolBlocks {
+		for _, b := range blocks {
+			if b.Draining && b.AffinityType() == model.IPAMAffinityTypeHost {
+				poolUse.Reclaimable += b.NumAddresses()
+			}
+		}
+		for _, b := range defragCandidates(blocks, threshold) {
+			poolUse.Reclaimable += b.NumAddresses()
+		}
+	}
+
+	// Report the usage of each namespace that is limited by a quota.
+	nsLabels := map[string]map[string]string{}
+	for poolUse, pool := range quotaPools {
+		for ns, inUse := range nsInUse[poolUse] {
+			if nsLabels[ns] == nil {
+				if nsLabels[ns], err = c.namespaceLabels(ctx, ns); err != nil {
+					return nil, err
+				}
+			}
+			limit, ok, err := QuotaLimit(pool, nsLabels[ns])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				poolUse.Quotas = append(poolUse.Quotas, QuotaUtilization{Namespace: ns, InUse: inUse, Limit: limit})
+			}
+		}
+		sort.Slice(poolUse.Quotas, func(i, j int) bool {
+			return poolUse.Quotas[i].Namespace < poolUse.Quotas[j].Namespace
+		})
+	}
 	return usage, nil
 }
 
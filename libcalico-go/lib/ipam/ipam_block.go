@@ -104,6 +104,13 @@ func (b *allocationBlock) autoAssign(num int, handleID *string, affinityCfg Affi
 		}
 	}
 
+	// Draining blocks are being emptied so that their affinity can be released - don't
+	// assign any more addresses from them.
+	if b.Draining {
+		log.WithField("cidr", b.CIDR).Debug("Block is draining, not assigning from it")
+		return nil, nil
+	}
+
 	// Search the "unallocated" list for IPs that we can use. We want to preserve the order of the ordinals list
 	// so we copy unused ordinals to the updatedUnallocated slice as we go.
 	_, mask, _ := cnet.ParseCIDR(b.CIDR.String())
@@ -260,6 +267,22 @@ func (b *allocationBlock) containsOnlyReservedIPs() bool {
 	return true
 }
 
+// numReservedIPs returns the number of addresses in the block that are reserved rather than
+// assigned.
+func (b *allocationBlock) numReservedIPs() int {
+	n := 0
+	for _, attrIdx := range b.Allocations {
+		if attrIdx == nil {
+			continue
+		}
+		attrs := b.Attributes[*attrIdx]
+		if attrs.AttrPrimary != nil && strings.ToLower(*attrs.AttrPrimary) == WindowsReservedHandle {
+			n++
+		}
+	}
+	return n
+}
+
 func (b *allocationBlock) release(addresses []ReleaseOptions) ([]cnet.IP, map[string]int, error) {
 	// Store return values.
 	unallocated := []cnet.IP{}
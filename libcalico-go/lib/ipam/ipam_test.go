@@ -21,6 +21,8 @@ import (
 	"net"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -59,6 +61,7 @@ type pool struct {
 	nodeSelector   string
 	allowedUses    []v3.IPPoolAllowedUse
 	assignmentMode v3.AssignmentMode
+	quotas         []v3.IPPoolQuota
 }
 
 func (i *ipPoolAccessor) GetEnabledPools(ctx context.Context, ipVersion int) ([]v3.IPPool, error) {
@@ -89,6 +92,7 @@ func (i *ipPoolAccessor) getPools(sorted []string, ipVersion int, caller string)
 				NodeSelector:   i.pools[p].nodeSelector,
 				AllowedUses:    i.pools[p].allowedUses,
 				AssignmentMode: &automatic,
+				Quotas:         i.pools[p].quotas,
 			}}
 			if len(pool.Spec.AllowedUses) == 0 {
 				pool.Spec.AllowedUses = []v3.IPPoolAllowedUse{v3.IPPoolAllowedUseWorkload, v3.IPPoolAllowedUseTunnel}
@@ -1368,6 +1372,188 @@ var _ = testutils.E2eDatastoreDescribe("IPAM tests", testutils.DatastoreAll, fun
 			Expect(v4ia).ToNot(BeNil())
 			Expect(len(v4ia.IPs)).To(Equal(1))
 		})
+
+		It("should respect IP pool quotas", func() {
+			ctx := context.Background()
+			bc.Clean()
+			deleteAllPools()
+
+			err := applyNode(bc, kc, node1, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			applyPoolWithQuotas("10.0.0.0/24", true, "", []v3.IPPoolQuota{
+				{NamespaceSelector: "projectcalico.org/name == 'ns1'", MaxAddresses: 2},
+			})
+
+			assign := func(namespace string) (*IPAMAssignments, error) {
+				handle := fmt.Sprintf("%s-%d", namespace, time.Now().UnixNano())
+				v4ia, _, err := ic.AutoAssign(ctx, AutoAssignArgs{
+					IntendedUse: v3.IPPoolAllowedUseWorkload,
+					Num4:        1,
+					Hostname:    node1,
+					HandleID:    &handle,
+					Attrs:       map[string]string{AttributeNamespace: namespace, AttributePod: handle},
+				})
+				return v4ia, err
+			}
+
+			// ns1 may have two addresses from the pool.
+			var assigned []cnet.IPNet
+			for i := 0; i < 2; i++ {
+				v4ia, err := assign("ns1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(v4ia.IPs).To(HaveLen(1))
+				assigned = append(assigned, v4ia.IPs...)
+			}
+			_, err = assign("ns1")
+			Expect(err).To(Equal(QuotaExceededError{Namespace: "ns1", Pools: []string{"10.0.0.0/24"}}))
+
+			// Releasing an address makes room for another.
+			_, _, err = ic.ReleaseIPs(ctx, ReleaseOptions{Address: assigned[0].IP.String()})
+			Expect(err).NotTo(HaveOccurred())
+			v4ia, err := assign("ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v4ia.IPs).To(HaveLen(1))
+			_, err = assign("ns1")
+			Expect(err).To(BeAssignableToTypeOf(QuotaExceededError{}))
+
+			// Other namespaces aren't limited.
+			for i := 0; i < 3; i++ {
+				v4ia, err := assign("ns2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(v4ia.IPs).To(HaveLen(1))
+			}
+
+			// Once ns1 has hit its quota in one pool, its addresses come from another pool.
+			applyPool("10.0.1.0/24", true, "")
+			v4ia, err = assign("ns1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v4ia.IPs).To(HaveLen(1))
+			Expect(v4ia.IPs[0].IP.String()).To(HavePrefix("10.0.1."))
+
+			usage, err := ic.GetUtilization(ctx, GetUtilizationArgs{Pools: []string{"10.0.0.0/24"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(HaveLen(1))
+			Expect(usage[0].Quotas).To(Equal([]QuotaUtilization{{Namespace: "ns1", InUse: 2, Limit: 2}}))
+		})
+
+		It("should not exceed IP pool quotas when assigning concurrently", func() {
+			ctx := context.Background()
+			bc.Clean()
+			deleteAllPools()
+
+			// Assign on several hosts so that the assignments race on different blocks.
+			hosts := []string{node1, node2, "host3", "host4"}
+			for _, host := range hosts {
+				err := applyNode(bc, kc, host, nil)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			applyPoolWithQuotas("10.0.0.0/24", true, "", []v3.IPPoolQuota{
+				{NamespaceSelector: "all()", MaxAddresses: 3},
+			})
+
+			var wg sync.WaitGroup
+			var assigned atomic.Int32
+			for i := 0; i < 4*len(hosts); i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					handle := fmt.Sprintf("ns1-%d", i)
+					v4ia, _, err := ic.AutoAssign(ctx, AutoAssignArgs{
+						IntendedUse: v3.IPPoolAllowedUseWorkload,
+						Num4:        1,
+						Hostname:    hosts[i%len(hosts)],
+						HandleID:    &handle,
+						Attrs:       map[string]string{AttributeNamespace: "ns1", AttributePod: handle},
+					})
+					if err != nil {
+						Expect(err).To(BeAssignableToTypeOf(QuotaExceededError{}))
+						Expect(v4ia.IPs).To(BeEmpty())
+						return
+					}
+					Expect(v4ia.IPs).To(HaveLen(1))
+					assigned.Add(1)
+				}(i)
+			}
+			wg.Wait()
+
+			// Racing assignments may all back off, but the namespace never ends up over its quota, and the
+			// addresses that were released are not left assigned.
+			Expect(assigned.Load()).To(BeNumerically("<=", 3))
+			usage, err := ic.GetUtilization(ctx, GetUtilizationArgs{Pools: []string{"10.0.0.0/24"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(HaveLen(1))
+			if assigned.Load() > 0 {
+				Expect(usage[0].Quotas).To(Equal([]QuotaUtilization{{Namespace: "ns1", InUse: int(assigned.Load()), Limit: 3}}))
+			} else {
+				Expect(usage[0].Quotas).To(BeEmpty())
+			}
+		})
+
+		It("should defragment sparsely-used blocks", func() {
+			ctx := context.Background()
+			bc.Clean()
+			deleteAllPools()
+
+			err := applyNode(bc, kc, node1, nil)
+			Expect(err).NotTo(HaveOccurred())
+			applyPool("10.0.0.0/24", true, "")
+
+			// Claim all four of the pool's blocks, then fill the first and put a single address in the second.
+			_, _, err = ic.ClaimAffinity(ctx, cnet.MustParseCIDR("10.0.0.0/24"), AffinityConfig{AffinityType: AffinityTypeHost, Host: node1})
+			Expect(err).NotTo(HaveOccurred())
+			assignIP := func(ip, handle string) {
+				err := ic.AssignIP(ctx, AssignIPArgs{IP: cnet.MustParseIP(ip), Hostname: node1, HandleID: &handle})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			for i := 1; i <= 40; i++ {
+				assignIP(fmt.Sprintf("10.0.0.%d", i), "dense")
+			}
+			assignIP("10.0.0.65", "sparse")
+
+			// The empty blocks are released straight away, and the sparse block starts draining.
+			res, err := ic.DefragBlocks(ctx, DefragArgs{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Drained).To(ConsistOf(
+				cnet.MustParseCIDR("10.0.0.64/26"),
+				cnet.MustParseCIDR("10.0.0.128/26"),
+				cnet.MustParseCIDR("10.0.0.192/26"),
+			))
+			Expect(res.Draining).To(ConsistOf(cnet.MustParseCIDR("10.0.0.64/26")))
+			Expect(res.Released).To(ConsistOf(cnet.MustParseCIDR("10.0.0.128/26"), cnet.MustParseCIDR("10.0.0.192/26")))
+
+			// New addresses don't come from the draining block.
+			handle := "new"
+			v4ia, _, err := ic.AutoAssign(ctx, AutoAssignArgs{
+				IntendedUse: v3.IPPoolAllowedUseWorkload,
+				Num4:        1,
+				Hostname:    node1,
+				HandleID:    &handle,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v4ia.IPs).To(HaveLen(1))
+			firstBlock := cnet.MustParseCIDR("10.0.0.0/26")
+			Expect(firstBlock.Contains(v4ia.IPs[0].IP)).To(BeTrue())
+
+			usage, err := ic.GetUtilization(ctx, GetUtilizationArgs{Pools: []string{"10.0.0.0/24"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(HaveLen(1))
+			Expect(usage[0].Blocks).To(HaveLen(2))
+			Expect(usage[0].Reclaimable).To(Equal(64))
+
+			// Once the draining block is empty, its affinity is released.
+			Expect(ic.ReleaseByHandle(ctx, "sparse")).To(Succeed())
+			res, err = ic.DefragBlocks(ctx, DefragArgs{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Drained).To(BeEmpty())
+			Expect(res.Released).To(ConsistOf(cnet.MustParseCIDR("10.0.0.64/26")))
+
+			usage, err = ic.GetUtilization(ctx, GetUtilizationArgs{Pools: []string{"10.0.0.0/24"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage[0].Blocks).To(HaveLen(1))
+			Expect(usage[0].Reclaimable).To(Equal(0))
+		})
 	})
 
 	Describe("IPAM AutoAssign from any pool", func() {
@@ -3438,6 +3624,10 @@ func applyPoolWithBlockSize(cidr string, enabled bool, nodeSelector string, bloc
 	ipPools.pools[cidr] = pool{enabled: enabled, nodeSelector: nodeSelector, blockSize: blockSize, assignmentMode: v3.Automatic}
 }
 
+func applyPoolWithQuotas(cidr string, enabled bool, nodeSelector string, quotas []v3.IPPoolQuota) {
+	ipPools.pools[cidr] = pool{enabled: enabled, nodeSelector: nodeSelector, quotas: quotas, assignmentMode: v3.Automatic}
+}
+
 func deletePool(cidr string) {
 	delete(ipPools.pools, cidr)
 }
@@ -115,6 +115,10 @@ type GetUtilizationArgs struct {
 	// If specified, the pools whose utilization should be reported.  Each string here
 	// can be a pool name or CIDR.  If not specified, this defaults to all pools.
 	Pools []string
+
+	// The threshold used to determine which blocks defragmentation would drain when
+	// reporting reclaimable capacity.  Defaults to DefaultDefragThreshold.
+	DefragThreshold int
 }
 
 // BlockUtilization reports IP utilization for a single allocation block.
@@ -127,6 +131,13 @@ type BlockUtilization struct {
 
 	// Number of available IPs in this block.
 	Available int
+
+	// Number of IPs in this block that are reserved rather than assigned, such as those that
+	// Windows nodes reserve.  They are not included in Available.
+	Reserved int
+
+	// Whether this block is draining, so that its affinity can be released.
+	Draining bool
 }
 
 // PoolUtilization reports IP utilization for a single IP pool.
@@ -139,6 +150,60 @@ type PoolUtilization struct {
 
 	// Utilization for each of this pool's blocks.
 	Blocks []BlockUtilization
+
+	// Number of addresses in blocks that are draining, or that defragmentation would drain.
+	// These addresses become available to any host once the blocks are released.
+	Reclaimable int
+
+	// Utilization for each namespace that is using addresses from this pool and is limited by
+	// one of the pool's quotas.
+	Quotas []QuotaUtilization
+}
+
+// QuotaUtilization reports a namespace's use of an IP pool against its quota.
+type QuotaUtilization struct {
+	// The namespace.
+	Namespace string
+
+	// Number of addresses from the pool that are assigned to pods in the namespace.
+	InUse int
+
+	// Maximum number of addresses from the pool that may be assigned to pods in the namespace.
+	Limit int
+}
+
+// DefragArgs defines the set of arguments for defragmenting IPAM blocks.
+type DefragArgs struct {
+	// If specified, the pools whose blocks should be defragmented.  Each string here
+	// can be a pool name or CIDR.  If not specified, this defaults to all pools.
+	Pools []string
+
+	// Affine blocks with this percentage of their addresses in use, or fewer, are drained.
+	// Defaults to DefaultDefragThreshold.
+	Threshold int
+
+	// If DryRun is true, report the blocks that would be drained and released without
+	// changing them.
+	DryRun bool
+
+	// If Cancel is true, stop draining the blocks in the pools instead, so that addresses
+	// can be assigned from them again.
+	Cancel bool
+}
+
+// DefragResult reports the blocks changed by defragmentation.
+type DefragResult struct {
+	// Blocks that started draining.
+	Drained []cnet.IPNet
+
+	// Blocks that are still draining because they have addresses in use.
+	Draining []cnet.IPNet
+
+	// Blocks that finished draining and whose affinity was released.
+	Released []cnet.IPNet
+
+	// Blocks that stopped draining because the defragmentation was cancelled.
+	Restored []cnet.IPNet
 }
 
 type HostReservedAttr struct {
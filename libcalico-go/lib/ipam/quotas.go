@@ -0,0 +1,527 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/k8s/conversion"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
+	"github.com/projectcalico/calico/libcalico-go/lib/selector"
+)
+
+// QuotaExceededError is returned by AutoAssign when assigning the requested addresses would take a
+// namespace over its quota in every pool that the addresses could come from.
+type QuotaExceededError struct {
+	Namespace string
+	Pools     []string
+}
+
+func (e QuotaExceededError) Error() string {
+	return fmt.Sprintf("IP address quota for namespace %s exceeded in IP pool(s): %s",
+		e.Namespace, strings.Join(e.Pools, ", "))
+}
+
+// QuotaLimit returns the maximum number of addresses from the given pool that may be assigned to the
+// pods in a namespace with the given labels.  It returns false if none of the pool's quotas select the
+// namespace.
+func QuotaLimit(pool v3.IPPool, namespaceLabels map[string]string) (int, bool, error) {
+	limit, found := 0, false
+	for _, q := range pool.Spec.Quotas {
+		sel, err := selector.Parse(q.NamespaceSelector)
+		if err != nil {
+			return 0, false, err
+		}
+		if !sel.Evaluate(namespaceLabels) {
+			continue
+		}
+		if !found || q.MaxAddresses < limit {
+			limit, found = q.MaxAddresses, true
+		}
+	}
+	return limit, found, nil
+}
+
+// namespaceLabels returns the labels of the given namespace, as recorded on the namespace's profile.
+// The returned labels always include the namespace name label, even if the profile doesn't exist.
+func (c ipamClient) namespaceLabels(ctx context.Context, namespace string) (map[string]string, error) {
+	labels := map[string]string{conversion.NameLabel: namespace}
+	kvp, err := c.client.Get(ctx, model.ResourceKey{
+		Kind: v3.KindProfile,
+		Name: conversion.NamespaceProfileNamePrefix + namespace,
+	}, "")
+	if err != nil {
+		if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+			return labels, nil
+		}
+		return nil, err
+	}
+	profile, ok := kvp.Value.(*v3.Profile)
+	if !ok {
+		return nil, fmt.Errorf("datastore returned malformed profile object")
+	}
+	for k, v := range profile.Spec.LabelsToApply {
+		if name, ok := strings.CutPrefix(k, conversion.NamespaceLabelPrefix); ok {
+			labels[name] = v
+		}
+	}
+	return labels, nil
+}
+
+// namespaceAllocations returns the number of addresses in the block that are assigned to pods in
+// each namespace.
+func namespaceAllocations(b *model.AllocationBlock) map[string]int {
+	counts := map[string]int{}
+	for _, attrIdx := range b.Allocations {
+		if attrIdx == nil || *attrIdx >= len(b.Attributes) {
+			continue
+		}
+		if ns := b.Attributes[*attrIdx].AttrSecondary[AttributeNamespace]; ns != "" {
+			counts[ns]++
+		}
+	}
+	return counts
+}
+
+// quotaCountedAllocations returns the number of addresses in the block that were counted against
+// each namespace's quota when they were assigned.
+func quotaCountedAllocations(b *model.AllocationBlock) map[string]int {
+	counts := map[string]int{}
+	for _, attrIdx := range b.Allocations {
+		if attrIdx == nil || *attrIdx >= len(b.Attributes) {
+			continue
+		}
+		attrs := b.Attributes[*attrIdx].AttrSecondary
+		if ns := attrs[AttributeNamespace]; ns != "" && attrs[AttributeQuotaCounted] == "true" {
+			counts[ns]++
+		}
+	}
+	return counts
+}
+
+// quotaCountsReleased returns the number of counted addresses that were released from the block in
+// each namespace, given the counts from before the release.
+func quotaCountsReleased(before map[string]int, b *model.AllocationBlock) map[string]int {
+	after := quotaCountedAllocations(b)
+	released := map[string]int{}
+	for ns, n := range before {
+		if n > after[ns] {
+			released[ns] = n - after[ns]
+		}
+	}
+	return released
+}
+
+// The number of addresses that each namespace uses in the pools that limit it is kept in an IPAM
+// handle per namespace, so that checking a quota doesn't need to read every block.  The handle has no
+// allocations of its own; its Block map holds a count per pool rather than per block.
+const quotaHandlePrefix = "ipam-quota-"
+
+func quotaHandleID(namespace string) string {
+	return quotaHandlePrefix + namespace
+}
+
+// IsQuotaHandle returns true if the given handle ID is that of a namespace's quota usage handle,
+// which doesn't correspond to any assigned addresses.
+func IsQuotaHandle(handleID string) bool {
+	return strings.HasPrefix(handleID, quotaHandlePrefix)
+}
+
+// quotaCountKey returns the key of the pool's count in a quota usage handle.  The key includes the
+// pool's resource version, so that the count is rebuilt from the blocks whenever the pool changes;
+// addresses assigned while the pool had no quota weren't counted.
+func quotaCountKey(p v3.IPPool) string {
+	return p.Spec.CIDR + "@" + p.ResourceVersion
+}
+
+// quotaCountCIDR returns the CIDR of the pool that the given quota usage handle key counts.
+func quotaCountCIDR(key string) string {
+	cidr, _, _ := strings.Cut(key, "@")
+	return cidr
+}
+
+// namespaceQuotas holds the quotas that limit the addresses assigned to pods in a namespace, for
+// the pools of one IP version.
+type namespaceQuotas struct {
+	namespace string
+	version   int
+	pools     []v3.IPPool
+	limits    map[string]int
+}
+
+// exceeded returns whether adding num addresses to the given usage would take the namespace over its
+// quota in any of the pools.
+func (q *namespaceQuotas) exceeded(inUse map[string]int, num int) bool {
+	for cidr, limit := range q.limits {
+		if inUse[cidr]+num > limit {
+			return true
+		}
+	}
+	return false
+}
+
+// filterPoolsByQuota removes the pools in which assigning num more addresses to pods in the given
+// namespace would exceed the namespace's quota, along with the affine blocks from those pools.  If
+// that removes every pool, it returns a QuotaExceededError.  It also returns the quotas that limit
+// the namespace, or nil if there are none, for checking the assignment with checkQuotasAfterAssign.
+func (c ipamClient) filterPoolsByQuota(ctx context.Context, pools []v3.IPPool, affBlocks []net.IPNet, version int, namespace string, num int) ([]v3.IPPool, []net.IPNet, *namespaceQuotas, error) {
+	var withQuotas []v3.IPPool
+	for _, p := range pools {
+		if len(p.Spec.Quotas) > 0 {
+			withQuotas = append(withQuotas, p)
+		}
+	}
+	if namespace == "" || len(withQuotas) == 0 {
+		return pools, affBlocks, nil, nil
+	}
+	logCtx := log.WithField("namespace", namespace)
+
+	labels, err := c.namespaceLabels(ctx, namespace)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to look up namespace labels for IP quota: %w", err)
+	}
+
+	quotas := &namespaceQuotas{namespace: namespace, version: version, limits: map[string]int{}}
+	for _, p := range withQuotas {
+		limit, ok, err := QuotaLimit(p, labels)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid quota on IP pool %s: %w", poolDisplayName(p), err)
+		}
+		if ok {
+			quotas.limits[p.Spec.CIDR] = limit
+			quotas.pools = append(quotas.pools, p)
+		}
+	}
+	if len(quotas.pools) == 0 {
+		return pools, affBlocks, nil, nil
+	}
+
+	inUse, err := c.quotaUsage(ctx, quotas, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if quotas.exceeded(inUse, num) {
+		// The counts can be higher than the real usage, for example if a client failed to update
+		// them after releasing addresses, so recount before turning any pool down.
+		logCtx.Debug("Namespace may have reached its IP quota, recounting its addresses")
+		if inUse, err = c.quotaUsage(ctx, quotas, true); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var allowed []v3.IPPool
+	var exceeded []string
+	for _, p := range pools {
+		if limit, ok := quotas.limits[p.Spec.CIDR]; ok && inUse[p.Spec.CIDR]+num > limit {
+			logCtx.WithFields(log.Fields{
+				"pool":  p.Spec.CIDR,
+				"inUse": inUse[p.Spec.CIDR],
+				"limit": limit,
+			}).Info("Namespace has reached its IP quota in pool, skipping pool")
+			exceeded = append(exceeded, poolDisplayName(p))
+			continue
+		}
+		allowed = append(allowed, p)
+	}
+	if len(allowed) == 0 {
+		return nil, nil, nil, QuotaExceededError{Namespace: namespace, Pools: exceeded}
+	}
+
+	allowedAffBlocks, _, err := filterBlocksByPools(affBlocks, allowed)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return allowed, allowedAffBlocks, quotas, nil
+}
+
+// checkQuotasAfterAssign adds the addresses just assigned to a pod to its namespace's usage counts
+// and checks that they didn't take the namespace over its quota in any pool.  filterPoolsByQuota
+// checks the quotas against the usage before the assignment, so concurrent assignments on different
+// hosts could otherwise take a namespace over its quota.  If they did, this releases the addresses
+// that are over quota and returns a QuotaExceededError, leaving the caller to retry.  When
+// assignments race, each of them may back off in this way, but the namespace is never left over its
+// quota.
+func (c ipamClient) checkQuotasAfterAssign(ctx context.Context, quotas *namespaceQuotas, ia *IPAMAssignments) error {
+	if quotas == nil || len(ia.IPs) == 0 {
+		return nil
+	}
+	added := map[string]int{}
+	for _, ipNet := range ia.IPs {
+		pool, err := findContainingPool(quotas.pools, ipNet.IP)
+		if err != nil {
+			return err
+		}
+		if pool != nil {
+			added[pool.Spec.CIDR]++
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	inUse, err := c.addQuotaUsage(ctx, quotas, added)
+	if err != nil {
+		return err
+	}
+	if inUse == nil {
+		// The counts were removed or the pool changed since filterPoolsByQuota read them.  Rebuild
+		// them from the blocks, which already include the new addresses.  A recount that only
+		// started after filterPoolsByQuota may have missed them, so rebuild every count.
+		if inUse, err = c.quotaUsage(ctx, quotas, true); err != nil {
+			return err
+		}
+	}
+	if quotas.exceeded(inUse, 0) {
+		// As in filterPoolsByQuota, make sure that the usage isn't overestimated before releasing
+		// anything.
+		if inUse, err = c.quotaUsage(ctx, quotas, true); err != nil {
+			return err
+		}
+	}
+
+	var kept []net.IPNet
+	var release []ReleaseOptions
+	var exceeded []string
+	for _, ipNet := range ia.IPs {
+		pool, err := findContainingPool(quotas.pools, ipNet.IP)
+		if err != nil {
+			return err
+		}
+		if pool == nil || inUse[pool.Spec.CIDR] <= quotas.limits[pool.Spec.CIDR] {
+			kept = append(kept, ipNet)
+			continue
+		}
+		log.WithFields(log.Fields{
+			"namespace": quotas.namespace,
+			"pool":      pool.Spec.CIDR,
+			"inUse":     inUse[pool.Spec.CIDR],
+			"limit":     quotas.limits[pool.Spec.CIDR],
+			"ip":        ipNet.IP,
+		}).Info("Concurrent assignments took namespace over its IP quota in pool, releasing IP")
+		release = append(release, ReleaseOptions{Address: ipNet.IP.String()})
+		if name := poolDisplayName(*pool); !slices.Contains(exceeded, name) {
+			exceeded = append(exceeded, name)
+		}
+	}
+	if len(release) == 0 {
+		return nil
+	}
+	if _, _, err := c.ReleaseIPs(ctx, release...); err != nil {
+		return err
+	}
+	ia.IPs = kept
+	return QuotaExceededError{Namespace: quotas.namespace, Pools: exceeded}
+}
+
+// quotaUsage returns the number of addresses assigned to pods in the namespace in each of the pools
+// that limit it, keyed by pool CIDR, from the namespace's quota usage handle.  Counts that are
+// missing from the handle, or all of them if recount is true, are rebuilt by listing the blocks of
+// the IP version.
+func (c ipamClient) quotaUsage(ctx context.Context, quotas *namespaceQuotas, recount bool) (map[string]int, error) {
+	handleID := quotaHandleID(quotas.namespace)
+	logCtx := log.WithField("handle", handleID)
+	for i := 0; i < datastoreRetries; i++ {
+		obj, err := c.blockReaderWriter.queryHandle(ctx, handleID, "")
+		if err != nil {
+			if _, ok := err.(cerrors.ErrorResourceDoesNotExist); !ok {
+				return nil, err
+			}
+			obj = &model.KVPair{
+				Key:   model.IPAMHandleKey{HandleID: handleID},
+				Value: &model.IPAMHandle{HandleID: handleID},
+			}
+		}
+		handle := obj.Value.(*model.IPAMHandle)
+		if handle.Block == nil {
+			handle.Block = map[string]int{}
+		}
+
+		var stale []v3.IPPool
+		for _, p := range quotas.pools {
+			if _, ok := handle.Block[quotaCountKey(p)]; recount || !ok {
+				stale = append(stale, p)
+			}
+		}
+		if len(stale) > 0 {
+			// The blocks are read after the handle, so an assignment or release that the
+			// recount misses updates the handle in between and the write below fails with a
+			// conflict.
+			counts, err := c.countNamespaceAllocations(ctx, quotas.namespace, quotas.version, stale)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range stale {
+				for key := range handle.Block {
+					if quotaCountCIDR(key) == p.Spec.CIDR {
+						delete(handle.Block, key)
+					}
+				}
+				handle.Block[quotaCountKey(p)] = counts[p.Spec.CIDR]
+			}
+
+			logCtx.WithField("counts", handle.Block).Debug("Writing recounted quota usage")
+			if obj.Revision == "" {
+				_, err = c.client.Create(ctx, obj)
+			} else {
+				_, err = c.blockReaderWriter.updateHandle(ctx, obj)
+			}
+			if err != nil {
+				switch err.(type) {
+				case cerrors.ErrorResourceUpdateConflict, cerrors.ErrorResourceAlreadyExists, cerrors.ErrorResourceDoesNotExist:
+					logCtx.Debugf("Quota usage handle changed while recounting - retry #%d", i)
+					continue
+				}
+				return nil, err
+			}
+		}
+
+		inUse := map[string]int{}
+		for _, p := range quotas.pools {
+			inUse[p.Spec.CIDR] = handle.Block[quotaCountKey(p)]
+		}
+		return inUse, nil
+	}
+	return nil, errors.New("Max retries hit - excessive concurrent IPAM requests")
+}
+
+// countNamespaceAllocations counts the addresses assigned to pods in the namespace in each of the
+// given pools, keyed by pool CIDR.  Since the allocations are only recorded in the blocks, this lists
+// every block of the IP version.
+func (c ipamClient) countNamespaceAllocations(ctx context.Context, namespace string, version int, pools []v3.IPPool) (map[string]int, error) {
+	blocks, err := c.client.List(ctx, model.BlockListOptions{IPVersion: version}, "")
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	for _, kvp := range blocks.KVPairs {
+		b := kvp.Value.(*model.AllocationBlock)
+		pool, err := findContainingPool(pools, b.CIDR.IP)
+		if err != nil {
+			return nil, err
+		}
+		if pool != nil {
+			counts[pool.Spec.CIDR] += namespaceAllocations(b)[namespace]
+		}
+	}
+	return counts, nil
+}
+
+// addQuotaUsage adds the given numbers of addresses, keyed by pool CIDR, to the namespace's quota
+// usage handle and returns the new counts for each of the pools that limit it.  It returns nil
+// without updating the handle if the handle doesn't have current counts for those pools.
+func (c ipamClient) addQuotaUsage(ctx context.Context, quotas *namespaceQuotas, added map[string]int) (map[string]int, error) {
+	var inUse map[string]int
+	err := c.updateQuotaUsage(ctx, quotas.namespace, func(counts map[string]int) bool {
+		inUse = map[string]int{}
+		for _, p := range quotas.pools {
+			n, ok := counts[quotaCountKey(p)]
+			if !ok {
+				inUse = nil
+				return false
+			}
+			inUse[p.Spec.CIDR] = n + added[p.Spec.CIDR]
+			counts[quotaCountKey(p)] = inUse[p.Spec.CIDR]
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inUse, nil
+}
+
+// adjustQuotaUsage adds the given numbers of counted addresses in the block, keyed by namespace and
+// multiplied by sign, to the namespaces' quota usage handles.  Releases subtract their addresses
+// before updating the block, and add them back if that fails, so that a concurrent recount can only
+// ever leave the usage too high, which is corrected before refusing an assignment.  Failures are
+// only logged for the same reason.
+func (c ipamClient) adjustQuotaUsage(ctx context.Context, blockCIDR net.IPNet, nums map[string]int, sign int) {
+	for ns, num := range nums {
+		err := c.updateQuotaUsage(ctx, ns, func(counts map[string]int) bool {
+			for key, n := range counts {
+				_, cidr, err := net.ParseCIDR(quotaCountCIDR(key))
+				if err == nil && cidr.Contains(blockCIDR.IP) {
+					counts[key] = max(n+sign*num, 0)
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"namespace": ns,
+				"cidr":      blockCIDR,
+			}).Warn("Failed to update IP quota usage")
+		}
+	}
+}
+
+// updateQuotaUsage applies the given update to the counts in the namespace's quota usage handle.  It
+// does nothing if the handle doesn't exist, or if update returns false.  If every count drops to
+// zero, the handle is deleted.
+func (c ipamClient) updateQuotaUsage(ctx context.Context, namespace string, update func(counts map[string]int) bool) error {
+	handleID := quotaHandleID(namespace)
+	for i := 0; i < datastoreRetries; i++ {
+		obj, err := c.blockReaderWriter.queryHandle(ctx, handleID, "")
+		if err != nil {
+			if _, ok := err.(cerrors.ErrorResourceDoesNotExist); ok {
+				return nil
+			}
+			return err
+		}
+		handle := obj.Value.(*model.IPAMHandle)
+		if handle.Block == nil || !update(handle.Block) {
+			return nil
+		}
+
+		inUse := false
+		for _, n := range handle.Block {
+			inUse = inUse || n > 0
+		}
+		if inUse {
+			_, err = c.blockReaderWriter.updateHandle(ctx, obj)
+		} else {
+			err = c.blockReaderWriter.deleteHandle(ctx, obj)
+		}
+		if err != nil {
+			switch err.(type) {
+			case cerrors.ErrorResourceUpdateConflict:
+				log.WithField("handle", handleID).Debugf("Conflict updating quota usage handle - retry #%d", i)
+				continue
+			case cerrors.ErrorResourceDoesNotExist:
+				return nil
+			}
+			return err
+		}
+		return nil
+	}
+	return errors.New("Max retries hit - excessive concurrent IPAM requests")
+}
+
+func poolDisplayName(p v3.IPPool) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.Spec.CIDR
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	bapi "github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
+)
+
+func TestQuotaLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	pool := v3.IPPool{Spec: v3.IPPoolSpec{Quotas: []v3.IPPoolQuota{
+		{NamespaceSelector: "all()", MaxAddresses: 100},
+		{NamespaceSelector: "tier == 'small'", MaxAddresses: 10},
+		{NamespaceSelector: "projectcalico.org/name == 'big'", MaxAddresses: 1000},
+	}}}
+
+	limit, ok, err := QuotaLimit(pool, map[string]string{"projectcalico.org/name": "default"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(ok).To(BeTrue())
+	Expect(limit).To(Equal(100))
+
+	// The lowest of the matching limits applies.
+	limit, ok, err = QuotaLimit(pool, map[string]string{"projectcalico.org/name": "dev", "tier": "small"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(ok).To(BeTrue())
+	Expect(limit).To(Equal(10))
+	limit, _, _ = QuotaLimit(pool, map[string]string{"projectcalico.org/name": "big"})
+	Expect(limit).To(Equal(100))
+
+	_, ok, err = QuotaLimit(v3.IPPool{}, map[string]string{"projectcalico.org/name": "default"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(ok).To(BeFalse())
+
+	_, _, err = QuotaLimit(v3.IPPool{Spec: v3.IPPoolSpec{Quotas: []v3.IPPoolQuota{
+		{NamespaceSelector: "not a selector", MaxAddresses: 1},
+	}}}, nil)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestNamespaceAllocations(t *testing.T) {
+	RegisterTestingT(t)
+
+	b := newBlock(net.MustParseCIDR("10.0.0.0/29"), nil)
+	handle := "h"
+	affinityCfg := AffinityConfig{AffinityType: AffinityTypeHost, Host: "host"}
+	_, err := b.autoAssign(2, &handle, affinityCfg, map[string]string{AttributeNamespace: "ns1", AttributePod: "a"}, false, nilAddrFilter{})
+	Expect(err).NotTo(HaveOccurred())
+	_, err = b.autoAssign(1, &handle, affinityCfg, map[string]string{AttributeNamespace: "ns2", AttributePod: "b"}, false, nilAddrFilter{})
+	Expect(err).NotTo(HaveOccurred())
+	_, err = b.autoAssign(1, &handle, affinityCfg, map[string]string{AttributeType: AttributeTypeVXLAN}, false, nilAddrFilter{})
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(namespaceAllocations(b.AllocationBlock)).To(Equal(map[string]int{
+		"ns1": 2,
+		"ns2": 1,
+	}))
+}
+
+func TestQuotaCountsReleased(t *testing.T) {
+	RegisterTestingT(t)
+
+	b := newBlock(net.MustParseCIDR("10.0.0.0/29"), nil)
+	handle := "h"
+	affinityCfg := AffinityConfig{AffinityType: AffinityTypeHost, Host: "host"}
+	counted, err := b.autoAssign(2, &handle, affinityCfg, map[string]string{AttributeNamespace: "ns1", AttributeQuotaCounted: "true"}, false, nilAddrFilter{})
+	Expect(err).NotTo(HaveOccurred())
+	uncounted, err := b.autoAssign(1, &handle, affinityCfg, map[string]string{AttributeNamespace: "ns1"}, false, nilAddrFilter{})
+	Expect(err).NotTo(HaveOccurred())
+	before := quotaCountedAllocations(b.AllocationBlock)
+	Expect(before).To(Equal(map[string]int{"ns1": 2}))
+
+	// Only the addresses that were counted when they were assigned are taken off the count.
+	_, _, err = b.release([]ReleaseOptions{{Address: counted[0].IP.String()}, {Address: uncounted[0].IP.String()}})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(quotaCountsReleased(before, b.AllocationBlock)).To(Equal(map[string]int{"ns1": 1}))
+}
+
+// quotaBackend serves IPAM handles and blocks from memory, with the datastore's compare-and-swap
+// semantics.  Any other use of the backend panics.
+type quotaBackend struct {
+	bapi.Client
+
+	kvps     map[string]*model.KVPair
+	revision int
+
+	blockLists int
+	// onListBlocks, if set, is called before blocks are listed.
+	onListBlocks func()
+}
+
+func newQuotaBackend() *quotaBackend {
+	return &quotaBackend{kvps: map[string]*model.KVPair{}}
+}
+
+func (f *quotaBackend) copyKVP(kvp *model.KVPair) *model.KVPair {
+	c := *kvp
+	if h, ok := kvp.Value.(*model.IPAMHandle); ok {
+		hc := *h
+		hc.Block = maps.Clone(h.Block)
+		c.Value = &hc
+	}
+	return &c
+}
+
+func (f *quotaBackend) write(kvp *model.KVPair) *model.KVPair {
+	f.revision++
+	kvp = f.copyKVP(kvp)
+	kvp.Revision = strconv.Itoa(f.revision)
+	f.kvps[kvp.Key.String()] = kvp
+	return f.copyKVP(kvp)
+}
+
+func (f *quotaBackend) Get(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
+	kvp, ok := f.kvps[key.String()]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: key}
+	}
+	return f.copyKVP(kvp), nil
+}
+
+func (f *quotaBackend) Create(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	if _, ok := f.kvps[kvp.Key.String()]; ok {
+		return nil, cerrors.ErrorResourceAlreadyExists{Identifier: kvp.Key}
+	}
+	return f.write(kvp), nil
+}
+
+func (f *quotaBackend) Update(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	current, ok := f.kvps[kvp.Key.String()]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: kvp.Key}
+	}
+	if current.Revision != kvp.Revision {
+		return nil, cerrors.ErrorResourceUpdateConflict{Identifier: kvp.Key}
+	}
+	return f.write(kvp), nil
+}
+
+func (f *quotaBackend) DeleteKVP(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	current, ok := f.kvps[kvp.Key.String()]
+	if !ok {
+		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: kvp.Key}
+	}
+	if current.Revision != kvp.Revision {
+		return nil, cerrors.ErrorResourceUpdateConflict{Identifier: kvp.Key}
+	}
+	delete(f.kvps, kvp.Key.String())
+	return current, nil
+}
+
+func (f *quotaBackend) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
+	opts := list.(model.BlockListOptions)
+	f.blockLists++
+	if f.onListBlocks != nil {
+		f.onListBlocks()
+	}
+	kvps := &model.KVPairList{}
+	for _, kvp := range f.kvps {
+		if b, ok := kvp.Value.(*model.AllocationBlock); ok && b.CIDR.Version() == opts.IPVersion {
+			kvps.KVPairs = append(kvps.KVPairs, f.copyKVP(kvp))
+		}
+	}
+	return kvps, nil
+}
+
+// addBlock adds a block with the given number of addresses assigned to pods in each namespace.
+func (f *quotaBackend) addBlock(cidr string, perNamespace map[string]int) {
+	b := newBlock(net.MustParseCIDR(cidr), nil)
+	handle := "h"
+	affinityCfg := AffinityConfig{AffinityType: AffinityTypeHost, Host: "host"}
+	for ns, num := range perNamespace {
+		_, err := b.autoAssign(num, &handle, affinityCfg, map[string]string{AttributeNamespace: ns}, false, nilAddrFilter{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+	f.write(&model.KVPair{Key: model.BlockKey{CIDR: b.CIDR}, Value: b.AllocationBlock})
+}
+
+func (f *quotaBackend) quotaCounts(namespace string) map[string]int {
+	kvp, ok := f.kvps[model.IPAMHandleKey{HandleID: quotaHandleID(namespace)}.String()]
+	if !ok {
+		return nil
+	}
+	return kvp.Value.(*model.IPAMHandle).Block
+}
+
+func quotaTestPool(name, cidr string, limit int) v3.IPPool {
+	return v3.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: "1"},
+		Spec: v3.IPPoolSpec{
+			CIDR:   cidr,
+			Quotas: []v3.IPPoolQuota{{NamespaceSelector: "all()", MaxAddresses: limit}},
+		},
+	}
+}
+
+func TestQuotaUsage(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.Background()
+
+	be := newQuotaBackend()
+	be.addBlock("10.0.0.0/28", map[string]int{"ns1": 3, "ns2": 1})
+	be.addBlock("10.0.0.16/28", map[string]int{"ns1": 2})
+	be.addBlock("10.1.0.0/28", map[string]int{"ns1": 1})
+	be.addBlock("10.2.0.0/28", map[string]int{"ns1": 4})
+	c := ipamClient{client: be, blockReaderWriter: blockReaderWriter{client: be}}
+	pool1 := quotaTestPool("pool1", "10.0.0.0/16", 10)
+	pool2 := quotaTestPool("pool2", "10.1.0.0/16", 10)
+	quotas := &namespaceQuotas{
+		namespace: "ns1",
+		version:   4,
+		pools:     []v3.IPPool{pool1, pool2},
+		limits:    map[string]int{"10.0.0.0/16": 10, "10.1.0.0/16": 10},
+	}
+
+	// The first check counts the namespace's addresses in the blocks and records the counts.
+	inUse, err := c.quotaUsage(ctx, quotas, false)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(inUse).To(Equal(map[string]int{"10.0.0.0/16": 5, "10.1.0.0/16": 1}))
+	Expect(be.blockLists).To(Equal(1))
+	Expect(be.quotaCounts("ns1")).To(Equal(map[string]int{"10.0.0.0/16@1": 5, "10.1.0.0/16@1": 1}))
+
+	// Later checks and assignments use the recorded counts without listing the blocks.
+	inUse, err = c.addQuotaUsage(ctx, quotas, map[string]int{"10.0.0.0/16": 2})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(inUse).To(Equal(map[string]int{"10.0.0.0/16": 7, "10.1.0.0/16": 1}))
+	inUse, err = c.quotaUsage(ctx, quotas, false)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(inUse).To(Equal(map[string]int{"10.0.0.0/16": 7, "10.1.0.0/16": 1}))
+	Expect(be.blockLists).To(Equal(1))
+
+	// Releases are taken off the count for the pool that contains the block.
+	c.adjustQuotaUsage(ctx, net.MustParseCIDR("10.1.0.0/28"), map[string]int{"ns1": 1, "ns3": 1}, -1)
+	Expect(be.quotaCounts("ns1")).To(Equal(map[string]int{"10.0.0.0/16@1": 7, "10.1.0.0/16@1": 0}))
+	Expect(be.quotaCounts("ns3")).To(BeNil())
+
+	// A recount corrects the counts from the blocks.
+	inUse, err = c.quotaUsage(ctx, quotas, true)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(inUse).To(Equal(map[string]int{"10.0.0.0/16": 5, "10.1.0.0/16": 1}))
+	Expect(be.blockLists).To(Equal(2))
+
+	// A changed pool is recounted, since addresses that were assigned while it had no quota
+	// weren't counted, and its old count is dropped.
+	pool2.ResourceVersion = "2"
+	quotas.pools = []v3.IPPool{pool1, pool2}
+	inUse, err = c.addQuotaUsage(ctx, quotas, map[string]int{"10.1.0.0/16": 1})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(inUse).To(BeNil())
+	_, err = c.quotaUsage(ctx, quotas, false)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(be.blockLists).To(Equal(3))
+	Expect(be.quotaCounts("ns1")).To(Equal(map[string]int{"10.0.0.0/16@1": 5, "10.1.0.0/16@2": 1}))
+
+	// The handle is removed once the namespace has no addresses left.
+	c.adjustQuotaUsage(ctx, net.MustParseCIDR("10.0.0.16/28"), map[string]int{"ns1": 5}, -1)
+	c.adjustQuotaUsage(ctx, net.MustParseCIDR("10.1.0.0/28"), map[string]int{"ns1": 1}, -1)
+	Expect(be.quotaCounts("ns1")).To(BeNil())
+}
+
+func TestQuotaUsageRecountRetriesOnConflict(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.Background()
+
+	be := newQuotaBackend()
+	be.addBlock("10.0.0.0/28", map[string]int{"ns1": 3})
+	c := ipamClient{client: be, blockReaderWriter: blockReaderWriter{client: be}}
+	quotas := &namespaceQuotas{
+		namespace: "ns1",
+		version:   4,
+		pools:     []v3.IPPool{quotaTestPool("pool1", "10.0.0.0/16", 10)},
+		limits:    map[string]int{"10.0.0.0/16": 10},
+	}
+	_, err := c.quotaUsage(ctx, quotas, false)
+	Expect(err).NotTo(HaveOccurred())
+
+	// Another assignment updates the count while the blocks are being recounted, so the recount
+	// has to start again and include that assignment.
+	be.onListBlocks = func() {
+		be.onListBlocks = nil
+		be.addBlock("10.0.0.16/28", map[string]int{"ns1": 1})
+		_, err := c.addQuotaUsage(ctx, quotas, map[string]int{"10.0.0.0/16": 1})
+		Expect(err).NotTo(HaveOccurred())
+	}
+	inUse, err := c.quotaUsage(ctx, quotas, true)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(inUse).To(Equal(map[string]int{"10.0.0.0/16": 4}))
+	Expect(be.blockLists).To(Equal(3))
+}
@@ -15,10 +15,15 @@
 package parser
 
 import (
+	"cmp"
 	_ "crypto/sha256" // register hash func
 	"fmt"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/projectcalico/calico/lib/std/uniquestr"
@@ -64,11 +69,51 @@ type LabelRestriction struct {
 	// Note: non-nil empty slice means "selector cannot match anything". For
 	// example an inconsistent selector such as: "a == 'B' && a == 'C'"
 	MustHaveOneOfValues []uniquestr.Handle
+	// ValuePredicates if non-empty, lists predicates that the label's value
+	// must satisfy in order to match the selector.  These come from operators
+	// that can't be expressed as a set of values, for example
+	// "labelName matches 'regex'" or "labelName >= 3".  Only set when
+	// MustBePresent is also set; if MustHaveOneOfValues is known, the values
+	// are filtered by the predicates instead.
+	ValuePredicates []ValuePredicate
 }
 
 func (l LabelRestriction) String() string {
-	return fmt.Sprintf("{MustBePresent:%v, MustBeAbsent:%v, MustHaveOneOfValues:%v}",
-		l.MustBePresent, l.MustBeAbsent, uniquestr.HandleSliceStringer(l.MustHaveOneOfValues))
+	return fmt.Sprintf("{MustBePresent:%v, MustBeAbsent:%v, MustHaveOneOfValues:%v, ValuePredicates:%v}",
+		l.MustBePresent, l.MustBeAbsent, uniquestr.HandleSliceStringer(l.MustHaveOneOfValues), l.ValuePredicates)
+}
+
+// MatchesValuePredicates returns true if the given label value satisfies all
+// the ValuePredicates of the restriction.
+func (l LabelRestriction) MatchesValuePredicates(value *LabelValue) bool {
+	for _, p := range l.ValuePredicates {
+		if !p.MatchesValue(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValuePredicate is implemented by the selector nodes that match a label's
+// value using something other than string equality.
+type ValuePredicate interface {
+	MatchesValue(value *LabelValue) bool
+	fmt.Stringer
+}
+
+// LabelValue is a label value along with the parsed forms that the
+// ValuePredicates need.  Indexes that check many predicates against the same
+// value should parse it once, with ParseLabelValue, and reuse the result.
+type LabelValue struct {
+	Handle       uniquestr.Handle
+	comparable   comparableValue
+	isComparable bool
+}
+
+func ParseLabelValue(value uniquestr.Handle) *LabelValue {
+	v := &LabelValue{Handle: value}
+	v.comparable, v.isComparable = parseComparableValue(value.Value())
+	return v
 }
 
 func (r LabelRestriction) PossibleToSatisfy() bool {
@@ -104,6 +149,10 @@ func (v PrefixVisitor) Visit(n interface{}) {
 		np.LabelName = uniquestr.Make(fmt.Sprintf("%s%s", v.Prefix, np.LabelName.Value()))
 	case *LabelEndsWithValueNode:
 		np.LabelName = uniquestr.Make(fmt.Sprintf("%s%s", v.Prefix, np.LabelName.Value()))
+	case *LabelMatchesNode:
+		np.LabelName = uniquestr.Make(fmt.Sprintf("%s%s", v.Prefix, np.LabelName.Value()))
+	case *LabelComparisonNode:
+		np.LabelName = uniquestr.Make(fmt.Sprintf("%s%s", v.Prefix, np.LabelName.Value()))
 	case *HasNode:
 		np.LabelName = uniquestr.Make(fmt.Sprintf("%s%s", v.Prefix, np.LabelName.Value()))
 	case *LabelInSetNode:
@@ -292,6 +341,196 @@ func (node *LabelEndsWithValueNode) collectFragments(fragments []string) []strin
 	return appendLabelOpAndQuotedString(fragments, node.LabelName.Value(), " ends with ", node.Value.Value())
 }
 
+// LabelMatchesNode matches labels whose value matches a regular expression.
+// The expression uses Go's RE2 syntax and is not anchored, so "^" and "$"
+// must be used to match the whole value.
+type LabelMatchesNode struct {
+	LabelName uniquestr.Handle
+	Value     uniquestr.Handle
+	regexp    *regexp.Regexp
+}
+
+func NewLabelMatchesNode(labelName, value string) (*LabelMatchesNode, error) {
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", value, err)
+	}
+	return &LabelMatchesNode{
+		LabelName: uniquestr.Make(labelName),
+		Value:     uniquestr.Make(value),
+		regexp:    re,
+	}, nil
+}
+
+func (node *LabelMatchesNode) Evaluate(labels Labels) bool {
+	val, ok := labels.GetHandle(node.LabelName)
+	if ok {
+		return node.MatchesValue(ParseLabelValue(val))
+	}
+	return false
+}
+
+func (node *LabelMatchesNode) MatchesValue(value *LabelValue) bool {
+	return node.regexp.MatchString(value.Handle.Value())
+}
+
+func (node *LabelMatchesNode) LabelRestrictions() map[uniquestr.Handle]LabelRestriction {
+	return map[uniquestr.Handle]LabelRestriction{
+		node.LabelName: {
+			MustBePresent:   true,
+			ValuePredicates: []ValuePredicate{node},
+		},
+	}
+}
+
+func (node *LabelMatchesNode) AcceptVisitor(v Visitor) {
+	v.Visit(node)
+}
+
+func (node *LabelMatchesNode) collectFragments(fragments []string) []string {
+	return appendLabelOpAndQuotedString(fragments, node.LabelName.Value(), " matches ", node.Value.Value())
+}
+
+func (node *LabelMatchesNode) String() string {
+	return strings.Join(node.collectFragments(nil), "")
+}
+
+type ComparisonOperator int
+
+const (
+	OpLt ComparisonOperator = iota
+	OpLe
+	OpGt
+	OpGe
+)
+
+func (op ComparisonOperator) String() string {
+	switch op {
+	case OpLt:
+		return "<"
+	case OpLe:
+		return "<="
+	case OpGt:
+		return ">"
+	case OpGe:
+		return ">="
+	}
+	return fmt.Sprintf("ComparisonOperator(%d)", int(op))
+}
+
+// LabelComparisonNode matches labels whose value compares with a number or
+// a version using one of the <, <=, > and >= operators.  If both values are
+// numbers, they are compared numerically; otherwise, if both are versions
+// (such as "v1.2", "1.2.3" or "1.2.3-rc.1"), they are compared according to
+// semver precedence.  A label value that can't be compared doesn't match.
+//
+// Since numbers take precedence, a value such as "1.10" that is both a number
+// and a version is compared as the number 1.1, so "version < 1.2" matches
+// "1.10".  To compare versions with two components, prefix the version in the
+// selector with "v", as in "version < 'v1.2'": "v1.2" is not a number so the
+// label value is then compared as a version.
+type LabelComparisonNode struct {
+	LabelName uniquestr.Handle
+	Operator  ComparisonOperator
+	Value     uniquestr.Handle
+	operand   comparableValue
+}
+
+func NewLabelComparisonNode(labelName string, op ComparisonOperator, value string) (*LabelComparisonNode, error) {
+	operand, ok := parseComparableValue(value)
+	if !ok {
+		return nil, fmt.Errorf("expected number or version after %s, not %q", op, value)
+	}
+	return &LabelComparisonNode{
+		LabelName: uniquestr.Make(labelName),
+		Operator:  op,
+		Value:     uniquestr.Make(value),
+		operand:   operand,
+	}, nil
+}
+
+func (node *LabelComparisonNode) Evaluate(labels Labels) bool {
+	val, ok := labels.GetHandle(node.LabelName)
+	if ok {
+		return node.MatchesValue(ParseLabelValue(val))
+	}
+	return false
+}
+
+func (node *LabelComparisonNode) MatchesValue(value *LabelValue) bool {
+	if !value.isComparable {
+		return false
+	}
+	c, ok := value.comparable.compare(node.operand)
+	if !ok {
+		return false
+	}
+	switch node.Operator {
+	case OpLt:
+		return c < 0
+	case OpLe:
+		return c <= 0
+	case OpGt:
+		return c > 0
+	case OpGe:
+		return c >= 0
+	}
+	return false
+}
+
+func (node *LabelComparisonNode) LabelRestrictions() map[uniquestr.Handle]LabelRestriction {
+	return map[uniquestr.Handle]LabelRestriction{
+		node.LabelName: {
+			MustBePresent:   true,
+			ValuePredicates: []ValuePredicate{node},
+		},
+	}
+}
+
+func (node *LabelComparisonNode) AcceptVisitor(v Visitor) {
+	v.Visit(node)
+}
+
+func (node *LabelComparisonNode) collectFragments(fragments []string) []string {
+	return appendLabelOpAndQuotedString(fragments, node.LabelName.Value(), " "+node.Operator.String()+" ", node.Value.Value())
+}
+
+func (node *LabelComparisonNode) String() string {
+	return strings.Join(node.collectFragments(nil), "")
+}
+
+// comparableValue is a label value parsed for use with the comparison
+// operators.  A value may be both a number and a version, for example "2".
+type comparableValue struct {
+	number   float64
+	isNumber bool
+	version  *semver.Version
+}
+
+func parseComparableValue(s string) (v comparableValue, ok bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		v.number = f
+		v.isNumber = true
+	}
+	if ver, err := semver.NewVersion(s); err == nil {
+		v.version = ver
+	}
+	return v, v.isNumber || v.version != nil
+}
+
+// compare compares the values numerically if both are numbers, falling back
+// to comparing them as versions.  Returns false if the values can't be
+// compared.
+func (v comparableValue) compare(other comparableValue) (int, bool) {
+	if v.isNumber && other.isNumber {
+		return cmp.Compare(v.number, other.number), true
+	}
+	if v.version != nil && other.version != nil {
+		return v.version.Compare(other.version), true
+	}
+	return 0, false
+}
+
 type LabelInSetNode struct {
 	LabelName uniquestr.Handle
 	Value     StringSet
@@ -486,9 +725,26 @@ func (node *AndNode) LabelRestrictions() map[uniquestr.Handle]LabelRestriction {
 			} else if r.MustHaveOneOfValues != nil {
 				base.MustHaveOneOfValues = intersectStringSlicesInPlace(base.MustHaveOneOfValues, r.MustHaveOneOfValues)
 			}
+			base.ValuePredicates = append(base.ValuePredicates, r.ValuePredicates...)
 			lr[ln] = base
 		}
 	}
+	for ln, r := range lr {
+		if r.MustHaveOneOfValues == nil || len(r.ValuePredicates) == 0 {
+			continue
+		}
+		// We know the possible values so we can apply the predicates now.
+		// For example, "a in {'1', '5'} && a > 3" can only match "5".
+		filtered := r.MustHaveOneOfValues[:0]
+		for _, v := range r.MustHaveOneOfValues {
+			if r.MatchesValuePredicates(ParseLabelValue(v)) {
+				filtered = append(filtered, v)
+			}
+		}
+		r.MustHaveOneOfValues = filtered
+		r.ValuePredicates = nil
+		lr[ln] = r
+	}
 	if len(lr) == 0 {
 		return nil
 	}
@@ -555,6 +811,8 @@ func (node *OrNode) LabelRestrictions() map[uniquestr.Handle]LabelRestriction {
 					r.MustHaveOneOfValues = unionStringSlicesInPlace(r.MustHaveOneOfValues, opr.MustHaveOneOfValues)
 				}
 			}
+			// We can't combine predicates with "or" so we have to drop them.
+			r.ValuePredicates = nil
 			r.MustBeAbsent = r.MustBeAbsent && opr.MustBeAbsent
 			if r.MustBePresent || r.MustBeAbsent {
 				lr[ln] = r
@@ -52,6 +52,16 @@ var labelRestrictionsTests = []struct {
 		"a": {MustBePresent: true},
 	}},
 	{"a != 'value'", nil},
+	{"a in {'1','5'} && a > 3", map[string]LabelRestriction{
+		"a": {MustBePresent: true, MustHaveOneOfValues: handleSlice("5")},
+	}},
+	{"a == 'foo' && a matches '^b'", map[string]LabelRestriction{
+		"a": {MustBePresent: true, MustHaveOneOfValues: handleSlice()},
+	}},
+	{"a > 3 || a matches 'foo'", map[string]LabelRestriction{
+		"a": {MustBePresent: true},
+	}},
+	{"!(a > 3)", nil},
 
 	// AND
 	{"a == 'v1' && a == 'v1'", map[string]LabelRestriction{
@@ -156,3 +166,27 @@ func handleSlice(ss ...string) []uniquestr.Handle {
 	}
 	return hs
 }
+
+func TestLabelRestrictionsValuePredicates(t *testing.T) {
+	RegisterTestingT(t)
+
+	sel, err := Parse("a > 3 && a < 10 && a matches '^[0-9]$' && b >= v1.2")
+	Expect(err).NotTo(HaveOccurred())
+	lrs := sel.LabelRestrictions()
+	Expect(lrs).To(HaveLen(2))
+
+	a := lrs[uniquestr.Make("a")]
+	Expect(a.MustBePresent).To(BeTrue())
+	Expect(a.MustHaveOneOfValues).To(BeNil())
+	Expect(a.ValuePredicates).To(HaveLen(3))
+	Expect(fmt.Sprint(a.ValuePredicates)).To(Equal(`[a > "3" a < "10" a matches "^[0-9]$"]`))
+	Expect(a.MatchesValuePredicates(ParseLabelValue(uniquestr.Make("5")))).To(BeTrue())
+	Expect(a.MatchesValuePredicates(ParseLabelValue(uniquestr.Make("3")))).To(BeFalse())
+	Expect(a.MatchesValuePredicates(ParseLabelValue(uniquestr.Make("5.5")))).To(BeFalse())
+
+	b := lrs[uniquestr.Make("b")]
+	Expect(b.MustBePresent).To(BeTrue())
+	Expect(b.ValuePredicates).To(HaveLen(1))
+	Expect(b.MatchesValuePredicates(ParseLabelValue(uniquestr.Make("1.3.0")))).To(BeTrue())
+	Expect(b.MatchesValuePredicates(ParseLabelValue(uniquestr.Make("1.1")))).To(BeFalse())
+}
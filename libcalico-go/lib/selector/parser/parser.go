@@ -218,6 +218,13 @@ var (
 	ErrExpectedSetLit = errors.New("expected set literal")
 )
 
+var comparisonOperators = map[tokenizer.Kind]ComparisonOperator{
+	tokenizer.TokLt: OpLt,
+	tokenizer.TokLe: OpLe,
+	tokenizer.TokGt: OpGt,
+	tokenizer.TokGe: OpGe,
+}
+
 // parseOperations parses a single, possibly negated operation (i.e. ==, !=, has()).
 // It also handles calling parseOrExpression recursively for parenthesized expressions.
 func (p *Parser) parseOperation(tokens []tokenizer.Token, validateOnly bool) (sel Node, remTokens []tokenizer.Token, err error) {
@@ -309,6 +316,36 @@ func (p *Parser) parseOperation(tokens []tokenizer.Token, validateOnly bool) (se
 			} else {
 				err = ErrExpectedString
 			}
+		case tokenizer.TokMatches:
+			if tokens[2].Kind == tokenizer.TokStringLiteral {
+				// Always compile the expression, even when only validating,
+				// so that we reject invalid expressions.
+				var node *LabelMatchesNode
+				node, err = NewLabelMatchesNode(tokens[0].Value, tokens[2].Value)
+				if err != nil {
+					return
+				}
+				if !validateOnly {
+					sel = node
+				}
+				remTokens = tokens[3:]
+			} else {
+				err = ErrExpectedString
+			}
+		case tokenizer.TokLt, tokenizer.TokLe, tokenizer.TokGt, tokenizer.TokGe:
+			if tokens[2].Kind == tokenizer.TokStringLiteral {
+				var node *LabelComparisonNode
+				node, err = NewLabelComparisonNode(tokens[0].Value, comparisonOperators[tokens[1].Kind], tokens[2].Value)
+				if err != nil {
+					return
+				}
+				if !validateOnly {
+					sel = node
+				}
+				remTokens = tokens[3:]
+			} else {
+				err = ErrExpectedString
+			}
 		case tokenizer.TokIn, tokenizer.TokNotIn:
 			if tokens[2].Kind == tokenizer.TokLBrace {
 				remTokens = tokens[3:]
@@ -349,7 +386,7 @@ func (p *Parser) parseOperation(tokens []tokenizer.Token, validateOnly bool) (se
 				err = ErrExpectedSetLit
 			}
 		default:
-			err = fmt.Errorf("expected == or != not: %v", tokens[1])
+			err = fmt.Errorf("expected operator not: %v", tokens[1])
 			return
 		}
 	case tokenizer.TokLParen:
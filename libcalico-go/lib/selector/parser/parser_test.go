@@ -165,6 +165,50 @@ var selectorTests = []selectorTest{
 	{`a != 'a1' || b == 'b1'`, []map[string]string{{"a": "a1", "b": "b1"}}, []map[string]string{}},
 	{`a != 'a1' || b != 'b1'`, []map[string]string{}, []map[string]string{{"a": "a1", "b": "b1"}}},
 	{`! a == 'a1' || ! b == 'b1'`, []map[string]string{}, []map[string]string{{"a": "a1", "b": "b1"}}},
+
+	// Regex matches.
+	{`a matches "^b[0-9]+$"`,
+		[]map[string]string{{"a": "b1"}, {"a": "b123"}},
+		[]map[string]string{{}, {"a": "b"}, {"a": "ab1"}, {"a": "b1c"}, {"b": "b1"}}},
+	{`a matches 'foo|bar'`,
+		[]map[string]string{{"a": "foo"}, {"a": "xbarx"}},
+		[]map[string]string{{}, {"a": "baz"}}},
+	{`!a matches "^b"`,
+		[]map[string]string{{}, {"a": "ab"}},
+		[]map[string]string{{"a": "b"}}},
+
+	// Numeric comparisons.
+	{`tier >= 3`,
+		[]map[string]string{{"tier": "3"}, {"tier": "3.0"}, {"tier": "10"}, {"tier": "4.5"}},
+		[]map[string]string{{}, {"tier": "2"}, {"tier": "-3"}, {"tier": "2.99"}, {"tier": "high"}, {"tier": ""}}},
+	{`tier > "3"`,
+		[]map[string]string{{"tier": "4"}, {"tier": "10"}},
+		[]map[string]string{{}, {"tier": "3"}, {"tier": "2"}}},
+	{`tier <= -1.5`,
+		[]map[string]string{{"tier": "-1.5"}, {"tier": "-20"}},
+		[]map[string]string{{}, {"tier": "-1"}, {"tier": "0"}}},
+	{`tier < 3 && tier > 1`,
+		[]map[string]string{{"tier": "2"}, {"tier": "1.5"}},
+		[]map[string]string{{}, {"tier": "1"}, {"tier": "3"}}},
+	{`tier < 3 || tier > 5`,
+		[]map[string]string{{"tier": "2"}, {"tier": "6"}},
+		[]map[string]string{{}, {"tier": "4"}}},
+
+	// Version comparisons.
+	{`version < 2`,
+		[]map[string]string{{"version": "1"}, {"version": "1.9.9"}, {"version": "v1.10"}, {"version": "2.0.0-rc.1"}},
+		[]map[string]string{{}, {"version": "2"}, {"version": "2.0.0"}, {"version": "v2.0.1"}, {"version": "latest"}}},
+	{`version >= v1.10.0`,
+		[]map[string]string{{"version": "1.10"}, {"version": "v1.10.1"}, {"version": "2"}},
+		[]map[string]string{{}, {"version": "1.9"}, {"version": "1.10.0-beta.1"}, {"version": "dev"}}},
+	// Values that are numbers are compared as numbers, so "1.10" is 1.1...
+	{`version < 1.2`,
+		[]map[string]string{{"version": "1.1"}, {"version": "1.10"}},
+		[]map[string]string{{}, {"version": "1.2"}, {"version": "1.20"}}},
+	// ...unless the selector's value is only a version.
+	{`version < v1.2`,
+		[]map[string]string{{"version": "1.1"}, {"version": "1.1.5"}},
+		[]map[string]string{{}, {"version": "1.2"}, {"version": "1.10"}, {"version": "1.20"}}},
 }
 
 var badSelectors = []string{
@@ -194,6 +238,11 @@ var badSelectors = []string{
 	`a == "b" || %`,   // Unexpected char
 	`a `,              // should be followed by operator
 	`has(foo) &&`,     // should be followed by operator
+	`a matches "("`,   // Invalid regex
+	`a matches b`,     // Expect string
+	`a > "foo"`,       // Not a number or version
+	`a >= `,           // Expect value
+	`a < {"1"}`,       // Expect value
 }
 
 var canonicalisationTests = []struct {
@@ -213,6 +262,11 @@ var canonicalisationTests = []struct {
 	{`a startswith '"'`, `a starts with '"'`, ""},
 	{`a endswith "'"`, `a ends with "'"`, ""},
 	{`a!='"'`, `a != '"'`, ""},
+	{`a matches "^b.*"`, `a matches "^b.*"`, ""},
+	{`a>3`, `a > "3"`, ""},
+	{`a >= '3'`, `a >= "3"`, ""},
+	{`a<v1.2.3`, `a < "v1.2.3"`, ""},
+	{`a <= 1.5`, `a <= "1.5"`, ""},
 	// Set items get sorted/de-duped.
 	{`a in {"d"}`, `a in {"d"}`, ""},
 	{`a in {"a", "b"}`, `a in {"a", "b"}`, ""},
@@ -339,6 +393,8 @@ var _ = Describe("Visitor", func() {
 		Entry("should visit a LabelContainsValueNode", "k contains 'v'", "visited/k contains \"v\"", testVisitor),
 		Entry("should visit a LabelStartWithValueNode", "k starts with 'v'", "visited/k starts with \"v\"", testVisitor),
 		Entry("should visit a LabelEndsWithValueNode", "k ends with 'v'", "visited/k ends with \"v\"", testVisitor),
+		Entry("should visit a LabelMatchesNode", "k matches 'v'", "visited/k matches \"v\"", testVisitor),
+		Entry("should visit a LabelComparisonNode", "k >= 3", "visited/k >= \"3\"", testVisitor),
 		Entry("should visit an AndNode", "k == 'v' && x == 'y'", "(visited/k == \"v\" && visited/x == \"y\")", testVisitor),
 		Entry("should visit an OrNode", "k == 'v' || has(x)", "(visited/k == \"v\" || has(visited/x))", testVisitor),
 		Entry("should visit a NotNode", "!(k == 'v')", "!visited/k == \"v\"", testVisitor),
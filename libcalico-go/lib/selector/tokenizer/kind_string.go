@@ -22,19 +22,24 @@ func _() {
 	_ = x[TokContains-11]
 	_ = x[TokStartsWith-12]
 	_ = x[TokEndsWith-13]
-	_ = x[TokAll-14]
-	_ = x[TokHas-15]
-	_ = x[TokLParen-16]
-	_ = x[TokRParen-17]
-	_ = x[TokAnd-18]
-	_ = x[TokOr-19]
-	_ = x[TokGlobal-20]
-	_ = x[TokEOF-21]
+	_ = x[TokMatches-14]
+	_ = x[TokLt-15]
+	_ = x[TokLe-16]
+	_ = x[TokGt-17]
+	_ = x[TokGe-18]
+	_ = x[TokAll-19]
+	_ = x[TokHas-20]
+	_ = x[TokLParen-21]
+	_ = x[TokRParen-22]
+	_ = x[TokAnd-23]
+	_ = x[TokOr-24]
+	_ = x[TokGlobal-25]
+	_ = x[TokEOF-26]
 }
 
-const _Kind_name = "TokNoneTokLabelTokStringLiteralTokLBraceTokRBraceTokCommaTokEqTokNeTokInTokNotTokNotInTokContainsTokStartsWithTokEndsWithTokAllTokHasTokLParenTokRParenTokAndTokOrTokGlobalTokEOF"
+const _Kind_name = "TokNoneTokLabelTokStringLiteralTokLBraceTokRBraceTokCommaTokEqTokNeTokInTokNotTokNotInTokContainsTokStartsWithTokEndsWithTokMatchesTokLtTokLeTokGtTokGeTokAllTokHasTokLParenTokRParenTokAndTokOrTokGlobalTokEOF"
 
-var _Kind_index = [...]uint8{0, 7, 15, 31, 40, 49, 57, 62, 67, 72, 78, 86, 97, 110, 121, 127, 133, 142, 151, 157, 162, 171, 177}
+var _Kind_index = [...]uint8{0, 7, 15, 31, 40, 49, 57, 62, 67, 72, 78, 86, 97, 110, 121, 131, 136, 141, 146, 151, 157, 163, 172, 181, 187, 192, 201, 207}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
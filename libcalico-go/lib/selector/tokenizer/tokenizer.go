@@ -41,6 +41,11 @@ const (
 	TokContains
 	TokStartsWith
 	TokEndsWith
+	TokMatches
+	TokLt
+	TokLe
+	TokGt
+	TokGe
 	TokAll
 	TokHas
 	TokLParen
@@ -134,6 +139,20 @@ func AppendTokens(tokens []Token, input string) ([]Token, error) {
 				tokens = append(tokens, Token{Kind: TokNot})
 				input = input[1:]
 			}
+		case '<':
+			if input, found = strings.CutPrefix(input, "<="); found {
+				tokens = append(tokens, Token{Kind: TokLe})
+			} else {
+				tokens = append(tokens, Token{Kind: TokLt})
+				input = input[1:]
+			}
+		case '>':
+			if input, found = strings.CutPrefix(input, ">="); found {
+				tokens = append(tokens, Token{Kind: TokGe})
+			} else {
+				tokens = append(tokens, Token{Kind: TokGt})
+				input = input[1:]
+			}
 		case '&':
 			if input, found = strings.CutPrefix(input, "&&"); found {
 				tokens = append(tokens, Token{Kind: TokAnd})
@@ -158,6 +177,8 @@ func AppendTokens(tokens []Token, input string) ([]Token, error) {
 					tokens = append(tokens, Token{Kind: TokStartsWith})
 				} else if input, found = cutMultiWordPrefixCheckBreak(input, "ends", "with"); found {
 					tokens = append(tokens, Token{Kind: TokEndsWith})
+				} else if input, found = cutPrefixCheckBreak(input, "matches"); found {
+					tokens = append(tokens, Token{Kind: TokMatches})
 				} else if input, found = cutMultiWordPrefixCheckBreak(input, "not", "in"); found {
 					tokens = append(tokens, Token{Kind: TokNotIn})
 				} else if input, found = cutPrefixCheckBreak(input, "in"); found {
@@ -166,6 +187,13 @@ func AppendTokens(tokens []Token, input string) ([]Token, error) {
 					return nil, fmt.Errorf("expected operator after label %q",
 						tokens[len(tokens)-1].Value)
 				}
+			} else if isComparison(lastTokKind) {
+				// Numbers and versions may be written without quotes after a
+				// comparison operator, for example "tier >= 3".
+				if ident, input, err = cutIdentifier(input); err != nil {
+					return nil, fmt.Errorf("expected number or version after comparison operator")
+				}
+				tokens = append(tokens, Token{TokStringLiteral, ident})
 			} else if input, found = strings.CutPrefix(input, "has("); found {
 				// Found "has()" ?
 				input = trimWhitespace(input)
@@ -206,6 +234,10 @@ func AppendTokens(tokens []Token, input string) ([]Token, error) {
 	}
 }
 
+func isComparison(kind Kind) bool {
+	return kind == TokLt || kind == TokLe || kind == TokGt || kind == TokGe
+}
+
 func trimWhitespace(input string) string {
 	end := 0
 	for ; end < len(input); end++ {
@@ -247,6 +247,41 @@ var tokenTests = []struct {
 		{Kind: tokenizer.TokRBrace},
 		{Kind: tokenizer.TokEOF},
 	}},
+	{`a matches "^b.*"`, []tokenizer.Token{
+		{Kind: tokenizer.TokLabel, Value: "a"},
+		{Kind: tokenizer.TokMatches},
+		{Kind: tokenizer.TokStringLiteral, Value: "^b.*"},
+		{Kind: tokenizer.TokEOF},
+	}},
+	{`a matchesx "b"`, nil},
+	{`a<3`, []tokenizer.Token{
+		{Kind: tokenizer.TokLabel, Value: "a"},
+		{Kind: tokenizer.TokLt},
+		{Kind: tokenizer.TokStringLiteral, Value: "3"},
+		{Kind: tokenizer.TokEOF},
+	}},
+	{`a <= "3"`, []tokenizer.Token{
+		{Kind: tokenizer.TokLabel, Value: "a"},
+		{Kind: tokenizer.TokLe},
+		{Kind: tokenizer.TokStringLiteral, Value: "3"},
+		{Kind: tokenizer.TokEOF},
+	}},
+	{`a > 1.5 && b >= v1.2.3-rc.1`, []tokenizer.Token{
+		{Kind: tokenizer.TokLabel, Value: "a"},
+		{Kind: tokenizer.TokGt},
+		{Kind: tokenizer.TokStringLiteral, Value: "1.5"},
+		{Kind: tokenizer.TokAnd},
+		{Kind: tokenizer.TokLabel, Value: "b"},
+		{Kind: tokenizer.TokGe},
+		{Kind: tokenizer.TokStringLiteral, Value: "v1.2.3-rc.1"},
+		{Kind: tokenizer.TokEOF},
+	}},
+	{`a >= `, []tokenizer.Token{
+		{Kind: tokenizer.TokLabel, Value: "a"},
+		{Kind: tokenizer.TokGe},
+		{Kind: tokenizer.TokEOF},
+	}},
+	{`a > ~1`, nil},
 	{`global()`, []tokenizer.Token{
 		{Kind: tokenizer.TokGlobal},
 		{Kind: tokenizer.TokEOF},
@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	api "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
 	"github.com/projectcalico/api/pkg/lib/numorstring"
@@ -87,7 +88,7 @@ var (
 	bgpFilterPrefixLengthV6 = regexp.MustCompile("^([0-9]|[1-9][0-9]|1[0-1][0-9]|12[0-8])$")
 	ignoredInterfaceRegex   = regexp.MustCompile("^[a-zA-Z0-9_.*-]{1,15}$")
 	ifaceFilterRegex        = regexp.MustCompile("^[a-zA-Z0-9:._+-]{1,15}$")
-	actionRegex             = regexp.MustCompile("^(Allow|Deny|Log|Pass)$")
+	actionRegex             = regexp.MustCompile("^(Allow|Deny|Log|Pass|SetDSCP)$")
 	protocolRegex           = regexp.MustCompile("^(TCP|UDP|ICMP|ICMPv6|SCTP|UDPLite)$")
 	ipipModeRegex           = regexp.MustCompile("^(Always|CrossSubnet|Never)$")
 	vxlanModeRegex          = regexp.MustCompile("^(Always|CrossSubnet|Never)$")
@@ -109,6 +110,12 @@ var (
 	number                  = regexp.MustCompile(`(\d+)`)
 	IPv4PortFormat          = regexp.MustCompile(`^(\d+).(\d+).(\d+).(\d+):(\d+)$`)
 	IPv6PortFormat          = regexp.MustCompile(`^\[[0-9a-fA-F:.]+\]:(\d+)$`)
+	httpHeaderNameRegex     = regexp.MustCompile("^[-!#$%&'*+.^_`|~0-9A-Za-z]+$")
+	httpHostRegex           = regexp.MustCompile(`^((\*\.)?[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*|\[[0-9a-fA-F:.]+\])(:\d{1,5})?$`)
+	grpcServiceRegex        = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
+	grpcMethodRegex         = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	domainRegex             = regexp.MustCompile(`^(\*\.)?[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9])?)*\.?$`)
+	timeOfDayRegex          = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
 	reasonString            = "Reason: "
 	poolUnstictCIDR         = "IP pool CIDR is not strictly masked"
 	overlapsV4LinkLocal     = "IP pool range overlaps with IPv4 Link Local range 169.254.0.0/16"
@@ -175,6 +182,7 @@ func init() {
 	registerFieldValidator("bgpFilterInterface", validateBGPFilterInterface)
 	registerFieldValidator("bgpFilterPrefixLengthV4", validateBGPFilterPrefixLengthV4)
 	registerFieldValidator("bgpFilterPrefixLengthV6", validateBGPFilterPrefixLengthV6)
+	registerFieldValidator("bgpCommunityValue", validateBGPCommunityValue)
 	registerFieldValidator("ignoredInterface", validateIgnoredInterface)
 	registerFieldValidator("datastoreType", validateDatastoreType)
 	registerFieldValidator("name", validateName)
@@ -216,6 +224,8 @@ func init() {
 	registerFieldValidator("wireguardPublicKey", validateWireguardPublicKey)
 	registerFieldValidator("IP:port", validateIPPort)
 	registerFieldValidator("reachableBy", validateReachableByField)
+	registerFieldValidator("timeOfDay", RegexValidator("TimeOfDay", timeOfDayRegex))
+	registerFieldValidator("timeZone", validateTimeZone)
 
 	// Register filter action and match operator validators (used in BGPFilter)
 	registerFieldValidator("filterAction", RegexValidator("FilterAction", filterActionRegex))
@@ -248,6 +258,8 @@ func init() {
 	registerStructValidator(validate, validateIPNAT, libapi.IPNAT{})
 	registerStructValidator(validate, validateICMPFields, api.ICMPFields{})
 	registerStructValidator(validate, validateIPPoolSpec, api.IPPoolSpec{})
+	registerStructValidator(validate, validateIPPoolMigrationSpec, api.IPPoolMigrationSpec{})
+	registerStructValidator(validate, validateQoSPolicyControls, api.QoSPolicyControls{})
 	registerStructValidator(validate, validateNodeSpec, libapi.NodeSpec{})
 	registerStructValidator(validate, validateIPAMConfigSpec, libapi.IPAMConfigSpec{})
 	registerStructValidator(validate, validateObjectMeta, metav1.ObjectMeta{})
@@ -257,10 +269,13 @@ func init() {
 	registerStructValidator(validate, validateWorkloadEndpointSpec, libapi.WorkloadEndpointSpec{})
 	registerStructValidator(validate, validateHostEndpointSpec, api.HostEndpointSpec{})
 	registerStructValidator(validate, validateRule, api.Rule{})
+	registerStructValidator(validate, validateProfileSpec, api.ProfileSpec{})
 	registerStructValidator(validate, validateEntityRule, api.EntityRule{})
 	registerStructValidator(validate, validateBGPPeerSpec, api.BGPPeerSpec{})
+	registerStructValidator(validate, validateBGPBFD, api.BGPBFD{})
 	registerStructValidator(validate, validateBGPFilterRuleV4, api.BGPFilterRuleV4{})
 	registerStructValidator(validate, validateBGPFilterRuleV6, api.BGPFilterRuleV6{})
+	registerStructValidator(validate, validateBGPFilterOperation, api.BGPFilterOperation{})
 	registerStructValidator(validate, validateNetworkPolicy, api.NetworkPolicy{})
 	registerStructValidator(validate, validateGlobalNetworkPolicy, api.GlobalNetworkPolicy{})
 	registerStructValidator(validate, validateStagedGlobalNetworkPolicy, api.StagedGlobalNetworkPolicy{})
@@ -336,6 +351,23 @@ func validateBGPFilterPrefixLengthV6(fl validator.FieldLevel) bool {
 	return s == "*" || bgpFilterPrefixLengthV6.MatchString(s)
 }
 
+func validateBGPCommunityValue(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	log.Debugf("Validate BGP community value: %s", s)
+	bitSize := 16
+	if largeCommunity.MatchString(s) {
+		bitSize = 32
+	} else if !standardCommunity.MatchString(s) {
+		return false
+	}
+	for _, v := range number.FindAllString(s, -1) {
+		if _, err := strconv.ParseUint(v, 10, bitSize); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func validateIgnoredInterface(fl validator.FieldLevel) bool {
 	s := fl.Field().String()
 	log.Debugf("Validate ignored interface name: %s", s)
@@ -391,6 +423,13 @@ func validateRegexp(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+func validateTimeZone(fl validator.FieldLevel) bool {
+	s := fl.Field().String()
+	log.Debugf("Validate time zone: %s", s)
+	_, err := time.LoadLocation(s)
+	return err == nil
+}
+
 func validateRouteSource(fl validator.FieldLevel) bool {
 	s := fl.Field().String()
 	log.Debugf("Validate routeSource: %s", s)
@@ -887,6 +926,55 @@ func validateHTTPPaths(paths []api.HTTPPath) error {
 	return nil
 }
 
+// validateHTTPHeaders checks if the HTTP header match clauses are valid.
+func validateHTTPHeaders(headers []api.HTTPHeaderMatch) error {
+	for _, header := range headers {
+		if !httpHeaderNameRegex.MatchString(header.Name) {
+			return fmt.Errorf("Invalid header name %q", header.Name)
+		}
+		numSet := 0
+		if header.Exact != "" {
+			numSet++
+		}
+		if header.Regex != "" {
+			numSet++
+			if _, err := regexp.Compile(header.Regex); err != nil {
+				return fmt.Errorf("Invalid regex %q for header %s: %v", header.Regex, header.Name, err)
+			}
+		}
+		if header.Present != nil {
+			numSet++
+		}
+		if numSet > 1 {
+			return fmt.Errorf("Invalid match for header %s. Only one of 'exact', 'regex' or 'present' may be set", header.Name)
+		}
+	}
+	return nil
+}
+
+// validateHTTPHosts checks if the HTTP host match clauses are valid.
+func validateHTTPHosts(hosts []string) error {
+	for _, host := range hosts {
+		if !httpHostRegex.MatchString(host) {
+			return fmt.Errorf("Invalid host %q", host)
+		}
+	}
+	return nil
+}
+
+// validateGRPCMethods checks if the gRPC method match clauses are valid.
+func validateGRPCMethods(methods []api.GRPCMethodMatch) error {
+	for _, m := range methods {
+		if !grpcServiceRegex.MatchString(m.Service) {
+			return fmt.Errorf("Invalid gRPC service name %q", m.Service)
+		}
+		if m.Method != "" && !grpcMethodRegex.MatchString(m.Method) {
+			return fmt.Errorf("Invalid gRPC method name %q", m.Method)
+		}
+	}
+	return nil
+}
+
 func validateHTTPRule(structLevel validator.StructLevel) {
 	h := structLevel.Current().Interface().(api.HTTPMatch)
 	log.Debugf("Validate HTTP Rule: %v", h)
@@ -896,6 +984,15 @@ func validateHTTPRule(structLevel validator.StructLevel) {
 	if err := validateHTTPPaths(h.Paths); err != nil {
 		structLevel.ReportError(reflect.ValueOf(h.Paths), "Paths", "", reason(err.Error()), "")
 	}
+	if err := validateHTTPHeaders(h.Headers); err != nil {
+		structLevel.ReportError(reflect.ValueOf(h.Headers), "Headers", "", reason(err.Error()), "")
+	}
+	if err := validateHTTPHosts(h.Hosts); err != nil {
+		structLevel.ReportError(reflect.ValueOf(h.Hosts), "Hosts", "", reason(err.Error()), "")
+	}
+	if err := validateGRPCMethods(h.GRPCMethods); err != nil {
+		structLevel.ReportError(reflect.ValueOf(h.GRPCMethods), "GRPCMethods", "", reason(err.Error()), "")
+	}
 }
 
 func validatePort(structLevel validator.StructLevel) {
@@ -1347,6 +1444,20 @@ func validateRule(structLevel validator.StructLevel) {
 	scanNets(rule.Destination.Nets, "Destination.Nets")
 	scanNets(rule.Destination.NotNets, "Destination.NotNets")
 
+	// Check that the DSCP is specified if, and only if, the rule sets the DSCP.
+	if rule.Action == api.SetDSCP {
+		if rule.DSCP == nil {
+			structLevel.ReportError(reflect.ValueOf(rule.DSCP),
+				"DSCP", "", reason("must be specified for SetDSCP rules"), "")
+		} else if _, err := rule.DSCP.NumValue(); err != nil {
+			structLevel.ReportError(reflect.ValueOf(rule.DSCP),
+				"DSCP", "", reason(err.Error()), "")
+		}
+	} else if rule.DSCP != nil {
+		structLevel.ReportError(reflect.ValueOf(rule.DSCP),
+			"DSCP", "", reason("only valid for SetDSCP rules"), "")
+	}
+
 	usesALP, alpValue, alpField := ruleUsesAppLayerPolicy(&rule)
 	if rule.Action != api.Allow && usesALP {
 		structLevel.ReportError(alpValue, alpField,
@@ -1365,6 +1476,22 @@ func validateRule(structLevel validator.StructLevel) {
 	}
 }
 
+func validateProfileSpec(structLevel validator.StructLevel) {
+	spec := structLevel.Current().Interface().(api.ProfileSpec)
+
+	// The DSCP can only be set by policy rules.
+	for _, rules := range [][]api.Rule{spec.Ingress, spec.Egress} {
+		for _, r := range rules {
+			if r.Action == api.SetDSCP {
+				structLevel.ReportError(
+					reflect.ValueOf(r.Action), "Action", "",
+					reason("SetDSCP not allowed in profile rules"), "",
+				)
+			}
+		}
+	}
+}
+
 func validateEntityRule(structLevel validator.StructLevel) {
 	rule := structLevel.Current().Interface().(api.EntityRule)
 	if strings.Contains(rule.Selector, globalSelector) {
@@ -1411,6 +1538,30 @@ func validateEntityRule(structLevel validator.StructLevel) {
 				"Services field", "", reason("cannot specify Nets/NotNets and Services on the same rule"), "")
 		}
 	}
+
+	if len(rule.Domains) != 0 {
+		for _, d := range rule.Domains {
+			if len(d) > 253 || !domainRegex.MatchString(d) {
+				structLevel.ReportError(reflect.ValueOf(d),
+					"Domains field", "", reason(fmt.Sprintf("invalid domain name %q", d)), "")
+			}
+		}
+
+		// Domain rules match on the IPs that the domains resolve to, so they can't be combined with
+		// anything else that restricts the destination IP.
+		if rule.NamespaceSelector != "" || rule.Selector != "" || rule.NotSelector != "" {
+			structLevel.ReportError(reflect.ValueOf(rule.Domains),
+				"Domains field", "", reason("cannot specify selectors and Domains on the same rule"), "")
+		}
+		if len(rule.Nets) != 0 || len(rule.NotNets) != 0 {
+			structLevel.ReportError(reflect.ValueOf(rule.Domains),
+				"Domains field", "", reason("cannot specify Nets/NotNets and Domains on the same rule"), "")
+		}
+		if rule.Services != nil {
+			structLevel.ReportError(reflect.ValueOf(rule.Domains),
+				"Domains field", "", reason("cannot specify Services and Domains on the same rule"), "")
+		}
+	}
 }
 
 func validateIPAMConfigSpec(structLevel validator.StructLevel) {
@@ -1467,6 +1618,20 @@ func validateBGPPeerSpec(structLevel validator.StructLevel) {
 	}
 }
 
+func validateBGPBFD(structLevel validator.StructLevel) {
+	bfd := structLevel.Current().Interface().(api.BGPBFD)
+
+	// BIRD is configured with the intervals in milliseconds.
+	if bfd.MinRxInterval != nil && bfd.MinRxInterval.Duration < time.Millisecond {
+		structLevel.ReportError(reflect.ValueOf(bfd.MinRxInterval), "MinRxInterval", "",
+			reason("MinRxInterval must be at least 1ms"), "")
+	}
+	if bfd.MinTxInterval != nil && bfd.MinTxInterval.Duration < time.Millisecond {
+		structLevel.ReportError(reflect.ValueOf(bfd.MinTxInterval), "MinTxInterval", "",
+			reason("MinTxInterval must be at least 1ms"), "")
+	}
+}
+
 func validateReachableBy(reachableBy, peerIP string) (bool, string) {
 	if reachableBy == "" {
 		return true, ""
@@ -1509,12 +1674,12 @@ func validateReachableByField(fl validator.FieldLevel) bool {
 
 func validateBGPFilterRuleV4(structLevel validator.StructLevel) {
 	fs := structLevel.Current().Interface().(api.BGPFilterRuleV4)
-	validateBGPFilterRule(structLevel, fs.CIDR, fs.MatchOperator, fs.PrefixLength, nil)
+	validateBGPFilterRule(structLevel, fs.CIDR, fs.MatchOperator, fs.PrefixLength, nil, fs.Operations, fs.Action)
 }
 
 func validateBGPFilterRuleV6(structLevel validator.StructLevel) {
 	fs := structLevel.Current().Interface().(api.BGPFilterRuleV6)
-	validateBGPFilterRule(structLevel, fs.CIDR, fs.MatchOperator, nil, fs.PrefixLength)
+	validateBGPFilterRule(structLevel, fs.CIDR, fs.MatchOperator, nil, fs.PrefixLength, fs.Operations, fs.Action)
 }
 
 func validateBGPFilterRule(
@@ -1523,7 +1688,13 @@ func validateBGPFilterRule(
 	op api.BGPFilterMatchOperator,
 	prefixLengthV4 *api.BGPFilterPrefixLengthV4,
 	prefixLengthV6 *api.BGPFilterPrefixLengthV6,
+	operations []api.BGPFilterOperation,
+	action api.BGPFilterAction,
 ) {
+	if len(operations) > 0 && action == api.Reject {
+		structLevel.ReportError(operations, "Operations", "",
+			reason("Operations cannot be used with the Reject action"), "")
+	}
 	if cidr != "" && op == "" {
 		structLevel.ReportError(cidr, "CIDR", "",
 			reason("MatchOperator cannot be empty when CIDR is not"), "")
@@ -1542,6 +1713,31 @@ func validateBGPFilterRule(
 	}
 }
 
+func validateBGPFilterOperation(structLevel validator.StructLevel) {
+	o := structLevel.Current().Interface().(api.BGPFilterOperation)
+
+	numSet := 0
+	if o.AddCommunity != "" {
+		numSet++
+	}
+	if o.RemoveCommunity != "" {
+		numSet++
+	}
+	if o.SetMED != nil {
+		numSet++
+	}
+	if o.SetLocalPreference != nil {
+		numSet++
+	}
+	if len(o.PrependASPath) > 0 {
+		numSet++
+	}
+	if numSet != 1 {
+		structLevel.ReportError(reflect.ValueOf(o), "BGPFilterOperation", "",
+			reason("exactly one operation must be specified"), "")
+	}
+}
+
 func validateEndpointPort(structLevel validator.StructLevel) {
 	port := structLevel.Current().Interface().(api.EndpointPort)
 
@@ -1712,6 +1908,14 @@ func validateNetworkPolicySpec(spec *api.NetworkPolicySpec, structLevel validato
 			)
 		}
 
+		// Domains are only allowed as a destination on Egress rules.
+		if len(r.Source.Domains) != 0 {
+			structLevel.ReportError(
+				reflect.ValueOf(r.Source.Domains), "Domains", "",
+				reason("not allowed in egress rule source"), "",
+			)
+		}
+
 		// Check (and disallow) rules with application layer policy for egress rules.
 		useALP, v, f := ruleUsesAppLayerPolicy(&r)
 		if useALP {
@@ -1727,6 +1931,20 @@ func validateNetworkPolicySpec(spec *api.NetworkPolicySpec, structLevel validato
 				reason("not allowed in ingress rule destination"), "",
 			)
 		}
+		if len(r.Source.Domains) != 0 || len(r.Destination.Domains) != 0 {
+			structLevel.ReportError(
+				reflect.ValueOf(r.Destination.Domains), "Domains", "",
+				reason("not allowed in ingress rules"), "",
+			)
+		}
+
+		// The DSCP can only be set on egress.
+		if r.Action == api.SetDSCP {
+			structLevel.ReportError(
+				reflect.ValueOf(r.Action), "Action", "",
+				reason("SetDSCP not allowed in ingress rules"), "",
+			)
+		}
 	}
 
 	// Check that the selector doesn't have the global() selector which is only
@@ -1748,6 +1966,8 @@ func validateNetworkPolicySpec(spec *api.NetworkPolicySpec, structLevel validato
 			reason(globalSelectorEntRule),
 			"")
 	}
+
+	validatePolicyActiveTimes(spec.ActiveFrom, spec.ActiveUntil, "NetworkPolicySpec", structLevel)
 }
 
 func validateNetworkPolicy(structLevel validator.StructLevel) {
@@ -1905,11 +2125,27 @@ func validateGlobalNetworkPolicySpec(spec *api.GlobalNetworkPolicySpec, structLe
 			)
 		}
 
+		// Domains are only allowed as a destination on Egress rules.
+		if len(r.Source.Domains) != 0 {
+			structLevel.ReportError(
+				reflect.ValueOf(r.Source.Domains), "Domains", "",
+				reason("not allowed in egress rule source"), "",
+			)
+		}
+
 		// Check (and disallow) rules with application layer policy for egress rules.
 		useALP, v, f := ruleUsesAppLayerPolicy(&r)
 		if useALP {
 			structLevel.ReportError(v, f, "", reason("not allowed in egress rules"), "")
 		}
+
+		// SetDSCP rules are not supported in untracked policy.
+		if spec.DoNotTrack && r.Action == api.SetDSCP {
+			structLevel.ReportError(
+				reflect.ValueOf(r.Action), "Action", "",
+				reason("SetDSCP not allowed in DoNotTrack policy"), "",
+			)
+		}
 	}
 
 	// Services are only allowed as a source on Ingress rules.
@@ -1920,6 +2156,20 @@ func validateGlobalNetworkPolicySpec(spec *api.GlobalNetworkPolicySpec, structLe
 				reason("not allowed in ingress rule destination"), "",
 			)
 		}
+		if len(r.Source.Domains) != 0 || len(r.Destination.Domains) != 0 {
+			structLevel.ReportError(
+				reflect.ValueOf(r.Destination.Domains), "Domains", "",
+				reason("not allowed in ingress rules"), "",
+			)
+		}
+
+		// The DSCP can only be set on egress.
+		if r.Action == api.SetDSCP {
+			structLevel.ReportError(
+				reflect.ValueOf(r.Action), "Action", "",
+				reason("SetDSCP not allowed in ingress rules"), "",
+			)
+		}
 	}
 
 	// If a ServiceSelector is specified by name, we also need a namespace. At a global scope,
@@ -1969,6 +2219,16 @@ func validateGlobalNetworkPolicySpec(spec *api.GlobalNetworkPolicySpec, structLe
 			reason(globalSelectorEntRule),
 			"")
 	}
+
+	validatePolicyActiveTimes(spec.ActiveFrom, spec.ActiveUntil, "GlobalNetworkPolicySpec", structLevel)
+}
+
+// validatePolicyActiveTimes checks that a policy isn't scheduled to stop being enforced before it starts.
+func validatePolicyActiveTimes(from, until *metav1.Time, specName string, structLevel validator.StructLevel) {
+	if from != nil && until != nil && !until.After(from.Time) {
+		structLevel.ReportError(reflect.ValueOf(until), specName+".ActiveUntil", "",
+			reason("must be after ActiveFrom"), "")
+	}
 }
 
 func validateGlobalNetworkPolicy(structLevel validator.StructLevel) {
@@ -2256,6 +2516,41 @@ func validateBGPConfigurationSpec(structLevel validator.StructLevel) {
 	if spec.NodeMeshMaxRestartTime != nil && spec.NodeToNodeMeshEnabled != nil && !*spec.NodeToNodeMeshEnabled {
 		structLevel.ReportError(reflect.ValueOf(spec), "Spec.NodeMeshMaxRestartTime", "", reason("spec.NodeMeshMaxRestartTime cannot be set if spec.NodeToNodeMesh is disabled"), "")
 	}
+
+	// Check that node mesh BFD cannot be set if node to node mesh is disabled.
+	if spec.NodeMeshBFD != nil && spec.NodeToNodeMeshEnabled != nil && !*spec.NodeToNodeMeshEnabled {
+		structLevel.ReportError(reflect.ValueOf(spec), "Spec.NodeMeshBFD", "", reason("spec.NodeMeshBFD cannot be set if spec.NodeToNodeMesh is disabled"), "")
+	}
+}
+
+func validateIPPoolMigrationSpec(structLevel validator.StructLevel) {
+	spec := structLevel.Current().Interface().(api.IPPoolMigrationSpec)
+	if spec.FromPool == spec.ToPool {
+		structLevel.ReportError(reflect.ValueOf(spec.ToPool), "IPPoolMigrationSpec.ToPool", "", reason("must be a different IP pool to FromPool"), "")
+	}
+}
+
+func validateQoSPolicyControls(structLevel validator.StructLevel) {
+	c := structLevel.Current().Interface().(api.QoSPolicyControls)
+
+	if (c == api.QoSPolicyControls{}) {
+		structLevel.ReportError(reflect.ValueOf(c), "QoSPolicyControls", "", reason("at least one control must be set"), "")
+	}
+
+	// A burst only makes sense alongside the bandwidth that it applies to, and can't be smaller
+	// than it.
+	validateBurst := func(field string, burst, bandwidth *int64) {
+		if burst == nil {
+			return
+		}
+		if bandwidth == nil {
+			structLevel.ReportError(reflect.ValueOf(*burst), "QoSPolicyControls."+field, "", reason("may only be set along with the bandwidth"), "")
+		} else if *burst < *bandwidth {
+			structLevel.ReportError(reflect.ValueOf(*burst), "QoSPolicyControls."+field, "", reason("must be at least the bandwidth"), "")
+		}
+	}
+	validateBurst("IngressBurst", c.IngressBurst, c.IngressBandwidth)
+	validateBurst("EgressBurst", c.EgressBurst, c.EgressBandwidth)
 }
 
 func validateBlockAffinitySpec(structLevel validator.StructLevel) {
@@ -80,6 +80,16 @@ func init() {
 
 	as61234, _ := numorstring.ASNumberFromString("61234")
 
+	dscpEF := numorstring.DSCPFromString("EF")
+	dscp46 := numorstring.DSCPFromInt(46)
+	dscp64 := numorstring.DSCPFromInt(64)
+	dscpUnknown := numorstring.DSCPFromString("AF44")
+
+	// BFD multipliers.
+	var mult0, mult3, mult256 int32 = 0, 3, 256
+	var med100 uint32 = 100
+	var qosBandwidth, qosBurst, qosMaxConnections, qosTooLowPacketRate int64 = 1000000, 4294967296, 100, 1
+
 	validWireguardPortOrRulePriority := 12345
 	invalidWireguardPortOrRulePriority := 99999
 
@@ -172,6 +182,12 @@ func init() {
 		Entry("should reject unknown action", api.Rule{Action: "unknown"}, false),
 		Entry("should reject unknown action", api.Rule{Action: "allowfoo"}, false),
 		Entry("should reject rule with no action", api.Rule{}, false),
+		Entry("should accept SetDSCP action with a DSCP class", api.Rule{Action: "SetDSCP", DSCP: &dscpEF}, true),
+		Entry("should accept SetDSCP action with a DSCP value", api.Rule{Action: "SetDSCP", DSCP: &dscp46}, true),
+		Entry("should reject SetDSCP action without a DSCP", api.Rule{Action: "SetDSCP"}, false),
+		Entry("should reject SetDSCP action with an out of range DSCP", api.Rule{Action: "SetDSCP", DSCP: &dscp64}, false),
+		Entry("should reject SetDSCP action with an unknown DSCP class", api.Rule{Action: "SetDSCP", DSCP: &dscpUnknown}, false),
+		Entry("should reject DSCP with an Allow action", api.Rule{Action: "Allow", DSCP: &dscpEF}, false),
 
 		// (API model) EndpointPorts.
 		Entry("should accept EndpointPort with tcp protocol", libapiv3.WorkloadEndpointPort{
@@ -533,6 +549,27 @@ func init() {
 				NodeMeshMaxRestartTime: &v1.Duration{Duration: 200 * time.Second},
 			}, false,
 		),
+		Entry("should reject node mesh BFD if node to node mesh is disabled",
+			api.BGPConfigurationSpec{
+				NodeToNodeMeshEnabled: &Vfalse,
+				NodeMeshBFD:           &api.BGPBFD{Enabled: true},
+			}, false,
+		),
+		Entry("should accept node mesh BFD with intervals and a multiplier",
+			api.BGPConfigurationSpec{
+				NodeMeshBFD: &api.BGPBFD{
+					Enabled:       true,
+					MinRxInterval: &v1.Duration{Duration: 100 * time.Millisecond},
+					MinTxInterval: &v1.Duration{Duration: 100 * time.Millisecond},
+					Multiplier:    &mult3,
+				},
+			}, true,
+		),
+		Entry("should reject node mesh BFD with a sub-millisecond interval",
+			api.BGPConfigurationSpec{
+				NodeMeshBFD: &api.BGPBFD{Enabled: true, MinRxInterval: &v1.Duration{Duration: 500 * time.Microsecond}},
+			}, false,
+		),
 		Entry("should accept valid interface names",
 			api.BGPConfigurationSpec{
 				IgnoredInterfaces: []string{"valid_iface*", "interface_name"},
@@ -697,6 +734,37 @@ func init() {
 				Spec: api.IPPoolSpec{CIDR: netv4_3, NodeSelector: "this is not valid selector syntax"},
 			}, false,
 		),
+		Entry("should allow valid quotas",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "pool.name",
+				},
+				Spec: api.IPPoolSpec{CIDR: netv4_3, Quotas: []api.IPPoolQuota{
+					{NamespaceSelector: "all()", MaxAddresses: 100},
+					{NamespaceSelector: "projectcalico.org/name == 'dev'", MaxAddresses: 0},
+				}},
+			}, true,
+		),
+		Entry("should disallow a quota with an invalid namespaceSelector",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "pool.name",
+				},
+				Spec: api.IPPoolSpec{CIDR: netv4_3, Quotas: []api.IPPoolQuota{
+					{NamespaceSelector: "this is not valid selector syntax", MaxAddresses: 100},
+				}},
+			}, false,
+		),
+		Entry("should disallow a quota with a negative limit",
+			api.IPPool{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "pool.name",
+				},
+				Spec: api.IPPoolSpec{CIDR: netv4_3, Quotas: []api.IPPoolQuota{
+					{NamespaceSelector: "all()", MaxAddresses: -1},
+				}},
+			}, false,
+		),
 
 		// (API) Interface.
 		Entry("should accept a valid interface", libapiv3.WorkloadEndpointSpec{InterfaceName: "Valid_Iface.0-9"}, true),
@@ -1933,6 +2001,43 @@ func init() {
 				Min: int32Helper(120),
 			},
 		}, false),
+		Entry("should accept BGPFilter rule matching communities and AS path", api.BGPFilterRuleV4{
+			Communities:  []string{"65000:100", "65000:100:200"},
+			ASPathPrefix: []numorstring.ASNumber{65001, 65002},
+			Action:       "Accept",
+		}, true),
+		Entry("should reject BGPFilter rule with an invalid community", api.BGPFilterRuleV6{
+			Communities: []string{"65536:100"},
+			Action:      "Accept",
+		}, false),
+		Entry("should accept BGPFilter rule with operations", api.BGPFilterRuleV4{
+			CIDR:          "10.0.0.0/16",
+			MatchOperator: "In",
+			Operations: []api.BGPFilterOperation{
+				{AddCommunity: "65000:100"},
+				{RemoveCommunity: "65000:100:200"},
+				{SetMED: &med100},
+				{SetLocalPreference: &med100},
+				{PrependASPath: []numorstring.ASNumber{65001, 65001}},
+			},
+			Action: "Accept",
+		}, true),
+		Entry("should reject BGPFilter rule with operations and the Reject action", api.BGPFilterRuleV6{
+			Operations: []api.BGPFilterOperation{{AddCommunity: "65000:100"}},
+			Action:     "Reject",
+		}, false),
+		Entry("should reject BGPFilter operation with no fields set", api.BGPFilterRuleV4{
+			Operations: []api.BGPFilterOperation{{}},
+			Action:     "Accept",
+		}, false),
+		Entry("should reject BGPFilter operation with more than one field set", api.BGPFilterRuleV4{
+			Operations: []api.BGPFilterOperation{{AddCommunity: "65000:100", SetMED: &med100}},
+			Action:     "Accept",
+		}, false),
+		Entry("should reject BGPFilter operation with an invalid community", api.BGPFilterRuleV4{
+			Operations: []api.BGPFilterOperation{{AddCommunity: "no-export"}},
+			Action:     "Accept",
+		}, false),
 
 		// (API) BGPPeerSpec
 		Entry("should accept valid BGPPeerSpec", api.BGPPeerSpec{PeerIP: ipv4_1}, true),
@@ -1967,6 +2072,18 @@ func init() {
 			PeerIP:                ipv4_1,
 			ASNumber:              as61234,
 		}, false),
+		Entry("should accept BGPPeerSpec with BFD enabled", api.BGPPeerSpec{
+			PeerIP: ipv4_1,
+			BFD:    &api.BGPBFD{Enabled: true, MinTxInterval: &v1.Duration{Duration: 300 * time.Millisecond}},
+		}, true),
+		Entry("should reject BGPPeerSpec with a zero BFD multiplier", api.BGPPeerSpec{
+			PeerIP: ipv4_1,
+			BFD:    &api.BGPBFD{Enabled: true, Multiplier: &mult0},
+		}, false),
+		Entry("should reject BGPPeerSpec with a BFD multiplier above 255", api.BGPPeerSpec{
+			PeerIP: ipv4_1,
+			BFD:    &api.BGPBFD{Enabled: true, Multiplier: &mult256},
+		}, false),
 		Entry("should reject BGPPeer with ReachableBy but without PeerIP", api.BGPPeerSpec{
 			ReachableBy: ipv4_2,
 		}, false),
@@ -2173,6 +2290,51 @@ func init() {
 				},
 			}, false,
 		),
+		Entry("should accept GlobalNetworkPolicy SetDSCP egress rules",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					Egress: []api.Rule{{Action: "SetDSCP", DSCP: &dscpEF}},
+				},
+			}, true,
+		),
+		Entry("should reject GlobalNetworkPolicy SetDSCP ingress rules",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					Ingress: []api.Rule{{Action: "SetDSCP", DSCP: &dscpEF}},
+				},
+			}, false,
+		),
+		Entry("should reject DoNotTrack GlobalNetworkPolicy SetDSCP egress rules",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					DoNotTrack:     true,
+					ApplyOnForward: true,
+					Egress:         []api.Rule{{Action: "SetDSCP", DSCP: &dscpEF}},
+				},
+			}, false,
+		),
+		Entry("should accept NetworkPolicy SetDSCP egress rules",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing", Namespace: "default"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{{Action: "SetDSCP", DSCP: &dscp46}},
+				},
+			}, true,
+		),
+		Entry("should reject NetworkPolicy SetDSCP ingress rules",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing", Namespace: "default"},
+				Spec: api.NetworkPolicySpec{
+					Ingress: []api.Rule{{Action: "SetDSCP", DSCP: &dscp46}},
+				},
+			}, false,
+		),
+		Entry("should reject Profile SetDSCP rules",
+			api.ProfileSpec{Egress: []api.Rule{{Action: "SetDSCP", DSCP: &dscpEF}}}, false,
+		),
 		Entry("should accept pre-DNAT GlobalNetworkPolicy ingress rules",
 			&api.GlobalNetworkPolicy{
 				ObjectMeta: v1.ObjectMeta{Name: "thing"},
@@ -2912,6 +3074,78 @@ func init() {
 				},
 			}, false,
 		),
+		Entry("NetworkPolicy: allow ActiveFrom before ActiveUntil",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					ActiveFrom:  &v1.Time{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+					ActiveUntil: &v1.Time{Time: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)},
+				},
+			}, true,
+		),
+		Entry("NetworkPolicy: disallow ActiveUntil before ActiveFrom",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					ActiveFrom:  &v1.Time{Time: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)},
+					ActiveUntil: &v1.Time{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			}, false,
+		),
+		Entry("GlobalNetworkPolicy: disallow ActiveUntil equal to ActiveFrom",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					ActiveFrom:  &v1.Time{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+					ActiveUntil: &v1.Time{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			}, false,
+		),
+		Entry("GlobalNetworkPolicy: allow an ActiveSchedule window",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					ActiveSchedule: []api.PolicyScheduleWindow{{
+						Days:      []string{"Saturday", "Sunday"},
+						StartTime: "22:00",
+						EndTime:   "02:30",
+						TimeZone:  "UTC",
+					}},
+				},
+			}, true,
+		),
+		Entry("GlobalNetworkPolicy: disallow an ActiveSchedule window with an invalid day",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					ActiveSchedule: []api.PolicyScheduleWindow{{Days: []string{"Caturday"}, StartTime: "22:00", EndTime: "23:00"}},
+				},
+			}, false,
+		),
+		Entry("NetworkPolicy: disallow an ActiveSchedule window with an invalid start time",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					ActiveSchedule: []api.PolicyScheduleWindow{{StartTime: "24:00", EndTime: "23:00"}},
+				},
+			}, false,
+		),
+		Entry("NetworkPolicy: disallow an ActiveSchedule window without an end time",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					ActiveSchedule: []api.PolicyScheduleWindow{{StartTime: "09:00"}},
+				},
+			}, false,
+		),
+		Entry("NetworkPolicy: disallow an ActiveSchedule window with an unknown time zone",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					ActiveSchedule: []api.PolicyScheduleWindow{{StartTime: "09:00", EndTime: "17:00", TimeZone: "Mars/Olympus_Mons"}},
+				},
+			}, false,
+		),
 		Entry("allow global() and projectcalico.org/name in EntityRule namespaceSelector field",
 			&api.NetworkPolicy{
 				ObjectMeta: v1.ObjectMeta{Name: "thing"},
@@ -2966,6 +3200,161 @@ func init() {
 				},
 			}, false,
 		),
+		Entry("allow Domains in an egress rule destination",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action: "Allow",
+							Destination: api.EntityRule{
+								Domains: []string{"example.com", "*.example.com", "api.example.com."},
+							},
+						},
+					},
+				},
+			}, true,
+		),
+		Entry("allow Domains in a global egress rule destination",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action:   "Allow",
+							Protocol: &protoTCP,
+							Destination: api.EntityRule{
+								Domains: []string{"example.com"},
+								Ports:   []numorstring.Port{numorstring.SinglePort(443)},
+							},
+						},
+					},
+				},
+			}, true,
+		),
+		Entry("disallow Domains in an egress rule source",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action: "Allow",
+							Source: api.EntityRule{
+								Domains: []string{"example.com"},
+							},
+						},
+					},
+				},
+			}, false,
+		),
+		Entry("disallow Domains in an ingress rule",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Ingress: []api.Rule{
+						{
+							Action: "Allow",
+							Source: api.EntityRule{
+								Domains: []string{"example.com"},
+							},
+						},
+					},
+				},
+			}, false,
+		),
+		Entry("disallow Domains in a global ingress rule",
+			&api.GlobalNetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.GlobalNetworkPolicySpec{
+					Ingress: []api.Rule{
+						{
+							Action: "Allow",
+							Destination: api.EntityRule{
+								Domains: []string{"example.com"},
+							},
+						},
+					},
+				},
+			}, false,
+		),
+		Entry("disallow an invalid domain",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action: "Allow",
+							Destination: api.EntityRule{
+								Domains: []string{"example..com"},
+							},
+						},
+					},
+				},
+			}, false,
+		),
+		Entry("disallow a wildcard that isn't a leading label",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action: "Allow",
+							Destination: api.EntityRule{
+								Domains: []string{"api.*.example.com"},
+							},
+						},
+					},
+				},
+			}, false,
+		),
+		Entry("disallow Domains and Nets on the same rule",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action: "Allow",
+							Destination: api.EntityRule{
+								Domains: []string{"example.com"},
+								Nets:    []string{"10.0.0.0/8"},
+							},
+						},
+					},
+				},
+			}, false,
+		),
+		Entry("disallow Domains and a Selector on the same rule",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action: "Allow",
+							Destination: api.EntityRule{
+								Domains:  []string{"example.com"},
+								Selector: "all()",
+							},
+						},
+					},
+				},
+			}, false,
+		),
+		Entry("disallow Domains and Services on the same rule",
+			&api.NetworkPolicy{
+				ObjectMeta: v1.ObjectMeta{Name: "thing"},
+				Spec: api.NetworkPolicySpec{
+					Egress: []api.Rule{
+						{
+							Action: "Allow",
+							Destination: api.EntityRule{
+								Domains:  []string{"example.com"},
+								Services: &api.ServiceMatch{Name: "service1", Namespace: "default"},
+							},
+						},
+					},
+				},
+			}, false,
+		),
 		Entry("allow a Service match in an ingress rule source",
 			&api.NetworkPolicy{
 				ObjectMeta: v1.ObjectMeta{Name: "thing"},
@@ -3318,6 +3707,59 @@ func init() {
 			&api.HTTPMatch{Methods: []string{"GET", "GET", "Foo"}},
 			false,
 		),
+		Entry("allow HTTP Headers with permitted match clauses",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{
+				{Name: "x-version", Exact: "v2"},
+				{Name: "X-User", Regex: "admin-[0-9]+"},
+				{Name: "x-debug", Present: &Vfalse},
+				{Name: "authorization"},
+			}},
+			true,
+		),
+		Entry("disallow HTTP Header with multiple match clauses",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Name: "x-version", Exact: "v2", Regex: "v.*"}}},
+			false,
+		),
+		Entry("disallow HTTP Header with invalid regex",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Name: "x-version", Regex: "v(2"}}},
+			false,
+		),
+		Entry("disallow HTTP Header with invalid name",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Name: "x version", Exact: "v2"}}},
+			false,
+		),
+		Entry("disallow HTTP Header with missing name",
+			&api.HTTPMatch{Headers: []api.HTTPHeaderMatch{{Exact: "v2"}}},
+			false,
+		),
+		Entry("allow HTTP Hosts with permitted values",
+			&api.HTTPMatch{Hosts: []string{"example.com", "*.example.com", "api.example.com:8443", "10.0.0.1:80", "[fd00::1]:80"}},
+			true,
+		),
+		Entry("disallow HTTP Host with a non-prefix wildcard",
+			&api.HTTPMatch{Hosts: []string{"api.*.com"}},
+			false,
+		),
+		Entry("disallow HTTP Host with a path",
+			&api.HTTPMatch{Hosts: []string{"example.com/foo"}},
+			false,
+		),
+		Entry("allow gRPC methods with permitted values",
+			&api.HTTPMatch{GRPCMethods: []api.GRPCMethodMatch{{Service: "helloworld.Greeter", Method: "SayHello"}, {Service: "Health"}}},
+			true,
+		),
+		Entry("disallow gRPC method with invalid service name",
+			&api.HTTPMatch{GRPCMethods: []api.GRPCMethodMatch{{Service: "helloworld/Greeter"}}},
+			false,
+		),
+		Entry("disallow gRPC method with invalid method name",
+			&api.HTTPMatch{GRPCMethods: []api.GRPCMethodMatch{{Service: "helloworld.Greeter", Method: "Say.Hello"}}},
+			false,
+		),
+		Entry("disallow gRPC method without a service",
+			&api.HTTPMatch{GRPCMethods: []api.GRPCMethodMatch{{Method: "SayHello"}}},
+			false,
+		),
 		Entry("should not accept an invalid IP address",
 			api.FelixConfigurationSpec{NATOutgoingAddress: bad_ipv4_1}, false,
 		),
@@ -3577,6 +4019,42 @@ func init() {
 			LocalWorkloadPeeringIPV6: bad_ipv6_1,
 		}, false),
 
+		// IP pool migrations.
+		Entry("should accept an IP pool migration", api.IPPoolMigrationSpec{FromPool: "old-pool", ToPool: "new-pool"}, true),
+		Entry("should accept an IP pool migration with a batch size", api.IPPoolMigrationSpec{FromPool: "old-pool", ToPool: "new-pool", BatchSize: 5}, true),
+		Entry("should reject an IP pool migration with a negative batch size", api.IPPoolMigrationSpec{FromPool: "old-pool", ToPool: "new-pool", BatchSize: -1}, false),
+		Entry("should reject an IP pool migration without a source pool", api.IPPoolMigrationSpec{ToPool: "new-pool"}, false),
+		Entry("should reject an IP pool migration to the same pool", api.IPPoolMigrationSpec{FromPool: "old-pool", ToPool: "old-pool"}, false),
+
+		// QoS policies.
+		Entry("should accept a QoS policy", api.QoSPolicySpec{
+			Selector: "app == 'web'",
+			Controls: api.QoSPolicyControls{IngressBandwidth: &qosBandwidth, IngressBurst: &qosBurst},
+		}, true),
+		Entry("should accept a QoS policy with a namespace selector and annotation override", api.QoSPolicySpec{
+			NamespaceSelector:     "team == 'a'",
+			Controls:              api.QoSPolicyControls{EgressMaxConnections: &qosMaxConnections},
+			PodAnnotationOverride: api.QoSPodAnnotationOverrideStricterOnly,
+		}, true),
+		Entry("should reject a QoS policy without any controls", api.QoSPolicySpec{Selector: "all()"}, false),
+		Entry("should reject a QoS policy with a bad selector", api.QoSPolicySpec{
+			Selector: "app === 'web'",
+			Controls: api.QoSPolicyControls{IngressBandwidth: &qosBandwidth},
+		}, false),
+		Entry("should reject a QoS policy with a bad annotation override", api.QoSPolicySpec{
+			Controls:              api.QoSPolicyControls{IngressBandwidth: &qosBandwidth},
+			PodAnnotationOverride: "Sometimes",
+		}, false),
+		Entry("should reject a QoS policy with a burst but no bandwidth", api.QoSPolicySpec{
+			Controls: api.QoSPolicyControls{EgressBurst: &qosBurst},
+		}, false),
+		Entry("should reject a QoS policy with a burst smaller than the bandwidth", api.QoSPolicySpec{
+			Controls: api.QoSPolicyControls{IngressBandwidth: &qosBurst, IngressBurst: &qosBandwidth},
+		}, false),
+		Entry("should reject a QoS policy with too low a packet rate", api.QoSPolicySpec{
+			Controls: api.QoSPolicyControls{IngressPacketRate: &qosTooLowPacketRate},
+		}, false),
+
 		// Block Affinities validation in BlockAffinitySpec
 		Entry("should accept non-deleted block affinities", libapiv3.BlockAffinitySpec{
 			Deleted: "false",
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"time"
+	_ "time/tzdata" // Embed the time zone database, used for policy schedules, since images may not have one.
 
 	"github.com/sirupsen/logrus"
 
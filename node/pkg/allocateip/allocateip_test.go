@@ -1496,6 +1496,14 @@ func (c shimClient) IPReservations() client.IPReservationInterface {
 	return c.client.IPReservations()
 }
 
+func (c shimClient) IPPoolMigrations() client.IPPoolMigrationInterface {
+	return c.client.IPPoolMigrations()
+}
+
+func (c shimClient) QoSPolicies() client.QoSPolicyInterface {
+	return c.client.QoSPolicies()
+}
+
 func newShimClientWithPoolAccessor(c client.Interface, be bapi.Client, pool ipam.PoolAccessorInterface) shimClient {
 	return shimClient{client: c, ic: ipam.NewIPAMClient(be, pool, c.IPReservations())}
 }
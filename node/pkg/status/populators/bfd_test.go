@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package populator
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Test BIRD BFD session Scanner", func() {
+
+	It("should be able to scan a table of sessions", func() {
+		table := `0001 BIRD v0.3.3+birdv1.6.8 ready.
+1020-bfd1:
+ IP address                Interface  State      Since         Interval  Timeout
+ 172.17.8.102              eth0       Up         2016-11-21       0.100    0.500
+ 172.17.9.1                ---        Down       10:15:01         1.000    0.000
+ not-an-ip                 eth0       Up         2016-11-21       0.100    0.500
+0000
+We never get here
+`
+		expectedSessions := []*bfdSession{
+			{
+				peerIP:   "172.17.8.102",
+				iface:    "eth0",
+				state:    "Up",
+				since:    "2016-11-21",
+				interval: 100 * time.Millisecond,
+				timeout:  500 * time.Millisecond,
+			},
+			{
+				peerIP:   "172.17.9.1",
+				state:    "Down",
+				since:    "10:15:01",
+				interval: time.Second,
+				timeout:  0,
+			},
+		}
+		sessions, err := readBIRDBFDSessions(getMockBirdConn(IPFamilyV4, table))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sessions).To(Equal(expectedSessions))
+
+		Expect(sessions[0].toNodeStatusAPI()).To(Equal(v3.CalicoNodeBFDSession{
+			PeerIP:    "172.17.8.102",
+			Interface: "eth0",
+			State:     v3.BFDSessionStateUp,
+			Since:     "2016-11-21",
+			Interval:  &metav1.Duration{Duration: 100 * time.Millisecond},
+			Timeout:   &metav1.Duration{Duration: 500 * time.Millisecond},
+		}))
+
+		// Check we can print sessions.
+		printBFDSessions(sessions, GinkgoWriter)
+	})
+
+	It("should be able to scan an ipv6 table with a multi-word since column", func() {
+		table := `0001 BIRD v0.3.3+birdv1.6.8 ready.
+1020-bfd1:
+ IP address                Interface  State      Since         Interval  Timeout
+ 2001:20::8                eth0       Init       2016-11-21 10:15:01  0.300    0.900
+0000
+`
+		sessions, err := readBIRDBFDSessions(getMockBirdConn(IPFamilyV6, table))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sessions).To(Equal([]*bfdSession{{
+			peerIP:   "2001:20::8",
+			iface:    "eth0",
+			state:    "Init",
+			since:    "2016-11-21 10:15:01",
+			interval: 300 * time.Millisecond,
+			timeout:  900 * time.Millisecond,
+		}}))
+	})
+
+	It("should return no sessions if BFD is not running", func() {
+		table := `0001 BIRD v0.3.3+birdv1.6.8 ready.
+9001 There is no BFD protocol running
+`
+		sessions, err := readBIRDBFDSessions(getMockBirdConn(IPFamilyV4, table))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sessions).To(BeEmpty())
+	})
+
+	It("should not allow a table with a rogue entry", func() {
+		table := `0001 BIRD v0.3.3+birdv1.6.8 ready.
+1020-bfd1:
+ IP address                Interface  State      Since         Interval  Timeout
+9000
+`
+		_, err := readBIRDBFDSessions(getMockBirdConn(IPFamilyV4, table))
+		Expect(err).To(HaveOccurred())
+	})
+})
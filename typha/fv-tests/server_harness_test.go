@@ -74,6 +74,8 @@ func NewHarness() *ServerHarness {
 		MaxBatchSize: 10,
 		// Reduce the wake-up interval from the default to give us faster tear down.
 		WakeUpInterval: 50 * time.Millisecond,
+		// Keep the history short so that we can test resuming a session that is too old.
+		MaxHistoryAge: time.Second,
 	})
 	h.BGPDecoupler = calc.NewSyncerCallbacksDecoupler()
 	h.BGPCache = snapcache.New(snapcache.Config{
@@ -161,6 +163,32 @@ func (h *ServerHarness) CreateClient(id interface{}, syncType syncproto.SyncerTy
 	return c
 }
 
+// CreateNodeFilteringClient creates a recording client that asks for a node-filtered view of the datastore.  The
+// client's hostname is "test-host-<id>".
+func (h *ServerHarness) CreateNodeFilteringClient(id interface{}, syncType syncproto.SyncerType) *ClientState {
+	recorder := NewRecorder()
+	c := h.createClient(id, syncclient.Options{SyncerType: syncType, RequestNodeFiltering: true}, recorder)
+	c.recorder = recorder
+	go recorder.Loop(c.recorderCtx)
+	h.ClientStates = append(h.ClientStates, c)
+	return c
+}
+
+// CreateResumingClient creates a recording client that asks to resume a previous session from the given
+// breadcrumb.  Since the recorder starts empty, it only records the updates that were sent after that breadcrumb.
+func (h *ServerHarness) CreateResumingClient(id interface{}, cacheID string, seqNo uint64) *ClientState {
+	recorder := NewRecorder()
+	c := h.createClient(id, syncclient.Options{
+		SyncerType:    syncproto.SyncerTypeFelix,
+		ResumeCacheID: cacheID,
+		ResumeSeqNo:   seqNo,
+	}, recorder)
+	c.recorder = recorder
+	go recorder.Loop(c.recorderCtx)
+	h.ClientStates = append(h.ClientStates, c)
+	return c
+}
+
 func (h *ServerHarness) CreateClientNoDecodeRestart(id interface{}, syncType syncproto.SyncerType) *ClientState {
 	recorder := NewRecorder()
 	c := h.createClient(id, syncclient.Options{SyncerType: syncType, DisableDecoderRestart: true}, recorder)
@@ -404,6 +404,273 @@ var _ = Describe("With an in-process Server", func() {
 	})
 
 	// Simulate an old client.
+	Describe("with node-filtering clients", func() {
+		const localHost = "test-host-local"
+		const remoteHost = "test-host-remote"
+
+		var (
+			filteredClient, unfilteredClient, bgpClient *ClientState
+
+			localCfgKey, remoteCfgKey             model.HostConfigKey
+			remoteVXLANAddrKey, remoteVXLANMACKey model.HostConfigKey
+			localWEPKey, remoteWEPKey, noIPWEPKey model.WorkloadEndpointKey
+			mac                                   *calinet.MAC
+		)
+
+		wepKey := func(hostname, workload string) model.WorkloadEndpointKey {
+			return model.WorkloadEndpointKey{
+				Hostname:       hostname,
+				OrchestratorID: "k8s",
+				WorkloadID:     "default/" + workload,
+				EndpointID:     "eth0",
+			}
+		}
+		wepUpdate := func(key model.WorkloadEndpointKey, ips ...string) api.Update {
+			wep := &model.WorkloadEndpoint{
+				State: "active",
+				Name:  "cali" + key.WorkloadID[len("default/"):],
+				Mac:   mac,
+			}
+			for _, ip := range ips {
+				wep.IPv4Nets = append(wep.IPv4Nets, calinet.MustParseCIDR(ip))
+			}
+			return api.Update{
+				KVPair:     model.KVPair{Key: key, Value: wep, Revision: "1"},
+				UpdateType: api.UpdateTypeKVNew,
+			}
+		}
+		path := func(key model.Key) string {
+			p, err := model.KeyToDefaultPath(key)
+			Expect(err).NotTo(HaveOccurred())
+			return p
+		}
+		keysOf := func(c *ClientState) func() []string {
+			return func() []string {
+				var keys []string
+				for k := range c.recorder.KVs() {
+					keys = append(keys, k)
+				}
+				return keys
+			}
+		}
+
+		BeforeEach(func() {
+			hwAddr, err := net.ParseMAC("01:02:03:04:05:06")
+			Expect(err).NotTo(HaveOccurred())
+			mac = &calinet.MAC{HardwareAddr: hwAddr}
+
+			localCfgKey = model.HostConfigKey{Hostname: localHost, Name: "LogSeverityScreen"}
+			remoteCfgKey = model.HostConfigKey{Hostname: remoteHost, Name: "LogSeverityScreen"}
+			remoteVXLANAddrKey = model.HostConfigKey{Hostname: remoteHost, Name: "IPv4VXLANTunnelAddr"}
+			remoteVXLANMACKey = model.HostConfigKey{Hostname: remoteHost, Name: "VXLANTunnelMACAddr"}
+			localWEPKey = wepKey(localHost, "local")
+			remoteWEPKey = wepKey(remoteHost, "remote")
+			noIPWEPKey = wepKey(remoteHost, "noip")
+
+			h.FelixCache.OnStatusUpdated(api.ResyncInProgress)
+			h.FelixCache.OnUpdates([]api.Update{
+				configFoobarBazzBiff,
+				{KVPair: model.KVPair{Key: localCfgKey, Value: "Debug", Revision: "1"}, UpdateType: api.UpdateTypeKVNew},
+				{KVPair: model.KVPair{Key: remoteCfgKey, Value: "Debug", Revision: "1"}, UpdateType: api.UpdateTypeKVNew},
+				{KVPair: model.KVPair{Key: remoteVXLANAddrKey, Value: "10.0.1.0", Revision: "1"}, UpdateType: api.UpdateTypeKVNew},
+				{KVPair: model.KVPair{Key: remoteVXLANMACKey, Value: "66:01:02:03:04:05", Revision: "1"}, UpdateType: api.UpdateTypeKVNew},
+				wepUpdate(localWEPKey, "10.0.0.1/32"),
+				wepUpdate(remoteWEPKey, "10.0.0.2/32"),
+				wepUpdate(noIPWEPKey),
+			})
+			h.FelixCache.OnStatusUpdated(api.InSync)
+
+			filteredClient = h.CreateNodeFilteringClient("local", syncproto.SyncerTypeFelix)
+			unfilteredClient = h.CreateClient("unfiltered", syncproto.SyncerTypeFelix)
+			bgpClient = h.CreateNodeFilteringClient("bgp", syncproto.SyncerTypeBGP)
+		})
+
+		It("should only enable node filtering for Felix clients that request it", func() {
+			Eventually(filteredClient.client.NodeFilteringEnabled).Should(BeTrue())
+			Eventually(unfilteredClient.recorder.Status).Should(Equal(api.InSync))
+			Expect(unfilteredClient.client.NodeFilteringEnabled()).To(BeFalse())
+			_, err := bgpClient.client.SupportsNodeResourceUpdates(10 * time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Consistently(bgpClient.client.NodeFilteringEnabled, "200ms").Should(BeFalse())
+		})
+
+		It("should send a filtered snapshot and deltas", func() {
+			Eventually(filteredClient.recorder.Status).Should(Equal(api.InSync))
+			Eventually(keysOf(filteredClient)).Should(ConsistOf(
+				"/calico/v1/config/foobar",
+				path(localCfgKey),
+				path(remoteVXLANAddrKey),
+				path(remoteVXLANMACKey),
+				path(localWEPKey),
+				path(remoteWEPKey),
+			))
+			Eventually(keysOf(unfilteredClient)).Should(ConsistOf(
+				"/calico/v1/config/foobar",
+				path(localCfgKey),
+				path(remoteCfgKey),
+				path(remoteVXLANAddrKey),
+				path(remoteVXLANMACKey),
+				path(localWEPKey),
+				path(remoteWEPKey),
+				path(noIPWEPKey),
+			))
+
+			// The local endpoint is sent in full, the remote one without the fields that only its own
+			// node needs.
+			kvs := filteredClient.recorder.KVs()
+			Expect(kvs[path(localWEPKey)].Value.(*model.WorkloadEndpoint).Mac).To(Equal(mac))
+			remoteWEP := kvs[path(remoteWEPKey)].Value.(*model.WorkloadEndpoint)
+			Expect(remoteWEP.Mac).To(BeNil())
+			Expect(remoteWEP.IPv4Nets).To(Equal([]calinet.IPNet{calinet.MustParseCIDR("10.0.0.2/32")}))
+
+			// Other nodes' tunnel addresses are needed to program routes to them.
+			Expect(kvs[path(remoteVXLANAddrKey)].Value).To(Equal("10.0.1.0"))
+			Expect(kvs[path(remoteVXLANMACKey)].Value).To(Equal("66:01:02:03:04:05"))
+
+			// A remote endpoint that loses its IPs is removed; one that gains IPs is added.
+			lostIPs := wepUpdate(remoteWEPKey)
+			lostIPs.UpdateType = api.UpdateTypeKVUpdated
+			gainedIPs := wepUpdate(noIPWEPKey, "10.0.0.3/32")
+			gainedIPs.UpdateType = api.UpdateTypeKVUpdated
+			h.FelixCache.OnUpdates([]api.Update{lostIPs, gainedIPs})
+			Eventually(keysOf(filteredClient)).Should(ConsistOf(
+				"/calico/v1/config/foobar",
+				path(localCfgKey),
+				path(remoteVXLANAddrKey),
+				path(remoteVXLANMACKey),
+				path(localWEPKey),
+				path(noIPWEPKey),
+			))
+			Eventually(func() []calinet.IPNet {
+				upd, ok := unfilteredClient.recorder.KVs()[path(remoteWEPKey)]
+				if !ok {
+					return nil
+				}
+				return upd.Value.(*model.WorkloadEndpoint).IPv4Nets
+			}).Should(BeEmpty())
+			Expect(keysOf(unfilteredClient)()).To(HaveLen(8))
+		})
+
+		It("should share the filtered snapshot between clients on the same node", func() {
+			Eventually(filteredClient.recorder.Status).Should(Equal(api.InSync))
+			reused, err := getPerSyncerCounter(syncproto.SyncerTypeFelix, "typha_snapshots_reused")
+			Expect(err).NotTo(HaveOccurred())
+
+			secondClient := h.CreateNodeFilteringClient("local", syncproto.SyncerTypeFelix)
+			Eventually(secondClient.recorder.Status).Should(Equal(api.InSync))
+			Expect(secondClient.recorder.KVs()).To(Equal(filteredClient.recorder.KVs()))
+			Expect(getPerSyncerCounter(syncproto.SyncerTypeFelix, "typha_snapshots_reused")).To(BeNumerically("==", reused+1))
+		})
+	})
+
+	Describe("with a client that resumes its session", func() {
+		var (
+			firstClient    *ClientState
+			cacheID        string
+			seqNo          uint64
+			resumedBefore  float64
+			rejectedBefore float64
+		)
+
+		getCounters := func() (resumed, rejected float64) {
+			resumed, err := getPerSyncerCounter(syncproto.SyncerTypeFelix, "typha_connections_resumed")
+			Expect(err).NotTo(HaveOccurred())
+			rejected, err = getPerSyncerCounter(syncproto.SyncerTypeFelix, "typha_connections_resume_rejected")
+			Expect(err).NotTo(HaveOccurred())
+			return
+		}
+
+		BeforeEach(func() {
+			h.Decoupler.OnStatusUpdated(api.ResyncInProgress)
+			h.Decoupler.OnUpdates([]api.Update{configFoobarBazzBiff})
+			h.Decoupler.OnStatusUpdated(api.InSync)
+
+			firstClient = h.CreateClient("first", syncproto.SyncerTypeFelix)
+			Eventually(firstClient.recorder.Status).Should(Equal(api.InSync))
+			Eventually(firstClient.recorder.KVs).Should(HaveLen(1))
+			Eventually(func() uint64 {
+				_, seqNo := firstClient.client.LastAppliedBreadcrumb()
+				return seqNo
+			}).ShouldNot(BeZero())
+
+			// Simulate the connection failing.
+			firstClient.clientCancel()
+			firstClient.client.Finished.Wait()
+			cacheID, seqNo = firstClient.client.LastAppliedBreadcrumb()
+			Expect(cacheID).To(Equal(h.FelixCache.ID()))
+			resumedBefore, rejectedBefore = getCounters()
+		})
+
+		It("should only send the updates that the client missed", func() {
+			h.Decoupler.OnUpdates([]api.Update{configFoobar2BazzBiff})
+			Eventually(func() uint64 { return h.FelixCache.CurrentBreadcrumb().SequenceNumber }).Should(BeNumerically(">", seqNo))
+
+			resumed := h.CreateResumingClient("resumed", cacheID, seqNo)
+			Eventually(resumed.recorder.KVs).Should(Equal(map[string]api.Update{
+				"/calico/v1/config/foobar2": configFoobar2BazzBiff,
+			}))
+			Consistently(resumed.recorder.KVs, "200ms").Should(HaveLen(1))
+			Eventually(func() uint64 {
+				_, newSeqNo := resumed.client.LastAppliedBreadcrumb()
+				return newSeqNo
+			}).Should(BeNumerically(">", seqNo))
+
+			resumedAfter, rejectedAfter := getCounters()
+			Expect(resumedAfter).To(BeNumerically("==", resumedBefore+1))
+			Expect(rejectedAfter).To(BeNumerically("==", rejectedBefore))
+		})
+
+		expectResumeRejected := func(c *ClientState) {
+			// The client can't apply a snapshot on top of its existing state, so it should disconnect
+			// without passing on any updates and signal that a full resync is needed.
+			c.client.Finished.Wait()
+			_, newSeqNo := c.client.LastAppliedBreadcrumb()
+			Expect(newSeqNo).To(BeZero())
+			Consistently(c.recorder.KVs, "200ms").Should(BeEmpty())
+
+			resumedAfter, rejectedAfter := getCounters()
+			Expect(resumedAfter).To(BeNumerically("==", resumedBefore))
+			Expect(rejectedAfter).To(BeNumerically("==", rejectedBefore+1))
+		}
+
+		It("should refuse to resume a session from a different cache", func() {
+			expectResumeRejected(h.CreateResumingClient("resumed", "other-typha", seqNo))
+		})
+
+		It("should fall back to a full resync after Typha restarts", func() {
+			// The restarted Typha has a new cache, which has the same contents but its own breadcrumbs.
+			restarted := NewHarness()
+			restarted.Start()
+			defer restarted.Stop()
+			restarted.Decoupler.OnStatusUpdated(api.ResyncInProgress)
+			restarted.Decoupler.OnUpdates([]api.Update{configFoobarBazzBiff})
+			restarted.Decoupler.OnStatusUpdated(api.InSync)
+			Expect(restarted.FelixCache.ID()).NotTo(Equal(cacheID))
+
+			expectResumeRejected(restarted.CreateResumingClient("resumed", cacheID, seqNo))
+
+			// Starting over, the client gets a snapshot from the restarted Typha.
+			fresh := restarted.CreateClient("fresh", syncproto.SyncerTypeFelix)
+			Eventually(fresh.recorder.Status).Should(Equal(api.InSync))
+			Eventually(fresh.recorder.KVs).Should(Equal(map[string]api.Update{
+				"/calico/v1/config/foobar": configFoobarBazzBiff,
+			}))
+		})
+
+		It("should refuse to resume a session that is too old", func() {
+			// Wait for the breadcrumb to age out of the history, which happens when the next one is
+			// published.
+			time.Sleep(1100 * time.Millisecond)
+			h.Decoupler.OnUpdates([]api.Update{configFoobar2BazzBiff})
+			Eventually(func() bool {
+				_, ok := h.FelixCache.BreadcrumbAt(seqNo)
+				return ok
+			}).Should(BeFalse())
+
+			expectResumeRejected(h.CreateResumingClient("resumed", cacheID, seqNo))
+		})
+	})
+
 	Describe("with a client that doesn't support connection restart", func() {
 		BeforeEach(func() {
 			h.CreateClientNoDecodeRestart("no decoder restart", syncproto.SyncerTypeFelix)
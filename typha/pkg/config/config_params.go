@@ -113,6 +113,10 @@ type Config struct {
 	PrometheusProcessMetricsEnabled bool   `config:"bool;true"`
 
 	SnapshotCacheMaxBatchSize int `config:"int(1,);100"`
+	// SnapshotCacheMaxHistorySecs is how long the snapshot cache retains old breadcrumbs so that
+	// reconnecting clients can resume their previous session instead of receiving a new snapshot.  Sessions
+	// can only be resumed on the same Typha process.
+	SnapshotCacheMaxHistorySecs time.Duration `config:"seconds;60"`
 
 	ServerMaxMessageSize                 int           `config:"int(1,);100"`
 	ServerMaxFallBehindSecs              time.Duration `config:"seconds;300"`
@@ -351,6 +351,7 @@ func (t *TyphaDaemon) addSyncerPipeline(
 	// Create our snapshot cache, which stores point-in-time copies of the datastore contents.
 	cache := snapcache.New(snapcache.Config{
 		MaxBatchSize:     t.ConfigParams.SnapshotCacheMaxBatchSize,
+		MaxHistoryAge:    t.ConfigParams.SnapshotCacheMaxHistorySecs,
 		HealthAggregator: t.healthAggregator,
 		Name:             string(syncerType),
 	})
@@ -410,6 +410,14 @@ func (m *mockDatastore) IPReservations() clientv3.IPReservationInterface {
 	panic("not implemented") // TODO: Implement
 }
 
+func (m *mockDatastore) IPPoolMigrations() clientv3.IPPoolMigrationInterface {
+	panic("not implemented") // TODO: Implement
+}
+
+func (m *mockDatastore) QoSPolicies() clientv3.QoSPolicyInterface {
+	panic("not implemented") // TODO: Implement
+}
+
 func (b *mockDatastore) getNumInitCalls() int {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
@@ -23,6 +23,7 @@ import (
 	"unsafe"
 
 	"github.com/google/btree"
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
@@ -37,6 +38,7 @@ import (
 const (
 	defaultMaxBatchSize   = 100
 	defaultWakeUpInterval = time.Second
+	defaultMaxHistoryAge  = time.Minute
 )
 
 var (
@@ -118,9 +120,26 @@ func init() {
 // to one slow client) and keep track of what we'd sent to each channel.  All doable but, I think,
 // more fiddly than using a non-blocking linked list and a condition variable and letting each
 // client look after itself.
+//
+// # Resuming
+//
+// Breadcrumb sequence numbers are local to a particular Cache, which is identified by a random ID.  The
+// Cache retains recent Breadcrumbs (see Config.MaxHistoryAge) so that a client that reconnects after
+// applying a known Breadcrumb from this Cache can be sent only the deltas that it missed instead of a new
+// snapshot.
+//
+// The ID is deliberately random rather than derived from the datastore revision: each Typha batches
+// updates into Breadcrumbs differently (and may see a different subset of intermediate revisions), so a
+// sequence number from one Typha says nothing about which updates another Typha's client has applied.
+// Resuming is therefore limited to reconnecting to the same Typha process, which covers transient
+// connection failures but not Typha restarts.  The typha_connections_resumed and
+// typha_connections_resume_rejected metrics show how often resuming succeeds in practice.
 type Cache struct {
 	config Config
 
+	// id uniquely identifies this Cache's sequence of Breadcrumbs.
+	id string
+
 	inputC chan interface{}
 
 	pendingStatus  api.SyncStatus
@@ -138,6 +157,12 @@ type Cache struct {
 	// blocking.
 	currentBreadcrumb unsafe.Pointer
 
+	// historyLock protects history, which is appended to by the main loop and read by connection
+	// goroutines.
+	historyLock sync.Mutex
+	// history contains the recently-published Breadcrumbs in order of sequence number.
+	history []*Breadcrumb
+
 	wakeUpTicker *jitter.Ticker
 	healthTicks  <-chan time.Time
 
@@ -160,8 +185,11 @@ type healthAggregator interface {
 }
 
 type Config struct {
-	MaxBatchSize     int
-	WakeUpInterval   time.Duration
+	MaxBatchSize   int
+	WakeUpInterval time.Duration
+	// MaxHistoryAge is the length of time for which old Breadcrumbs are retained for clients that are
+	// resuming a previous session.
+	MaxHistoryAge    time.Duration
 	HealthAggregator healthAggregator
 	Name             string
 	HealthName       string
@@ -182,6 +210,13 @@ func (config *Config) ApplyDefaults() {
 		}).Info("Defaulting WakeUpInterval.")
 		config.WakeUpInterval = defaultWakeUpInterval
 	}
+	if config.MaxHistoryAge <= 0 {
+		log.WithFields(log.Fields{
+			"value":   config.MaxHistoryAge,
+			"default": defaultMaxHistoryAge,
+		}).Info("Defaulting MaxHistoryAge.")
+		config.MaxHistoryAge = defaultMaxHistoryAge
+	}
 	if config.HealthName == "" {
 		if config.Name == "" {
 			config.HealthName = "cache"
@@ -203,6 +238,7 @@ func New(config Config) *Cache {
 
 	c := &Cache{
 		config:         config,
+		id:             uuid.NewString(),
 		inputC:         make(chan interface{}, config.MaxBatchSize*2),
 		breadcrumbCond: cond,
 		kvs:            kvs,
@@ -238,6 +274,7 @@ func New(config Config) *Cache {
 		counterBreadcrumbNonBlock: c.counterBreadcrumbNonBlock,
 	}
 	c.currentBreadcrumb = (unsafe.Pointer)(snap)
+	c.history = []*Breadcrumb{snap}
 
 	if config.HealthAggregator != nil {
 		config.HealthAggregator.RegisterReporter(config.HealthName, &health.HealthReport{Live: true, Ready: true}, healthInterval*2)
@@ -253,6 +290,49 @@ func (c *Cache) CurrentBreadcrumb() *Breadcrumb {
 	return (*Breadcrumb)(atomic.LoadPointer(&c.currentBreadcrumb))
 }
 
+// ID returns the random ID of this Cache.  Breadcrumb sequence numbers are only meaningful in the
+// context of a particular Cache ID.
+func (c *Cache) ID() string {
+	return c.id
+}
+
+// BreadcrumbAt returns the retained Breadcrumb with the given sequence number, if it is still available.
+// A client that has already applied that Breadcrumb can follow its Next() chain to receive only the
+// deltas that it has missed.  It is safe to call from any goroutine.
+func (c *Cache) BreadcrumbAt(seqNo uint64) (*Breadcrumb, bool) {
+	c.historyLock.Lock()
+	defer c.historyLock.Unlock()
+
+	if len(c.history) == 0 {
+		return nil, false
+	}
+	// Sequence numbers are contiguous so we can index straight into the history.
+	first := c.history[0].SequenceNumber
+	if seqNo < first || seqNo-first >= uint64(len(c.history)) {
+		return nil, false
+	}
+	return c.history[seqNo-first], true
+}
+
+// recordHistory adds the given Breadcrumb to the history and discards any Breadcrumbs that are older
+// than the configured maximum age.  We always keep the latest Breadcrumb.
+func (c *Cache) recordHistory(crumb *Breadcrumb) {
+	c.historyLock.Lock()
+	defer c.historyLock.Unlock()
+
+	c.history = append(c.history, crumb)
+	cutoff := crumb.Timestamp.Add(-c.config.MaxHistoryAge)
+	numToDrop := 0
+	for numToDrop < len(c.history)-1 && c.history[numToDrop].Timestamp.Before(cutoff) {
+		numToDrop++
+	}
+	if numToDrop > 0 {
+		// Copy to a new slice rather than re-slicing so that the dropped Breadcrumbs can be
+		// garbage collected.
+		c.history = append([]*Breadcrumb(nil), c.history[numToDrop:]...)
+	}
+}
+
 // OnStatusUpdated implements the SyncerCallbacks API.  It shouldn't be called directly.
 func (c *Cache) OnStatusUpdated(status api.SyncStatus) {
 	c.inputC <- status
@@ -439,6 +519,8 @@ func (c *Cache) publishBreadcrumb() {
 			updToStore.UpdateType = api.UpdateTypeKVNew
 			c.kvs.ReplaceOrInsert(updToStore)
 		}
+		// Work out whether clients on other nodes that receive a node-filtered view need this update.
+		newUpd.CompareRemote(oldUpd, exists)
 
 		// Record the update in the new Breadcrumb so that clients following the chain of
 		// Breadcrumbs can apply it as a delta.
@@ -467,6 +549,7 @@ func (c *Cache) publishBreadcrumb() {
 	atomic.StorePointer(&(oldCrumb.next), (unsafe.Pointer)(newCrumb))
 	atomic.StorePointer(&c.currentBreadcrumb, (unsafe.Pointer)(newCrumb))
 	c.breadcrumbCond.L.Unlock()
+	c.recordHistory(newCrumb)
 	// Then wake up any watching clients.  Note: Go's Cond doesn't require us to hold the lock
 	// while calling Broadcast.
 	log.WithField("seqNo", newCrumb.SequenceNumber).Debug("Broadcasting new Breadcrumb")
@@ -516,6 +599,40 @@ func (b *Breadcrumb) Next(ctx context.Context) (*Breadcrumb, error) {
 	return next, nil
 }
 
+// AscendKVsForNode calls fn for each KV in the snapshot, in order, as it should be sent to a client that
+// only needs the full detail of resources that belong to the given node.  KVs that should not be sent to
+// that node are skipped.  If hostname is "", the unfiltered snapshot is returned.  Iteration stops if fn
+// returns false.
+func (b *Breadcrumb) AscendKVsForNode(hostname string, fn func(syncproto.SerializedUpdate) bool) {
+	if hostname == "" {
+		b.KVs.Ascend(fn)
+		return
+	}
+	b.KVs.Ascend(func(upd syncproto.SerializedUpdate) bool {
+		upd, ok := upd.ForNode(hostname)
+		if !ok {
+			return true
+		}
+		return fn(upd)
+	})
+}
+
+// DeltasForNode returns the deltas from this Breadcrumb as they should be sent to a client that only needs
+// the full detail of resources that belong to the given node.  If hostname is "", the unfiltered deltas
+// are returned without copying.
+func (b *Breadcrumb) DeltasForNode(hostname string) []syncproto.SerializedUpdate {
+	if hostname == "" {
+		return b.Deltas
+	}
+	deltas := make([]syncproto.SerializedUpdate, 0, len(b.Deltas))
+	for _, upd := range b.Deltas {
+		if upd, ok := upd.ForNode(hostname); ok {
+			deltas = append(deltas, upd)
+		}
+	}
+	return deltas
+}
+
 // loadNext does an atomic load of the next pointer.  It returns nil or the next Breadcrumb.
 func (b *Breadcrumb) loadNext() *Breadcrumb {
 	return (*Breadcrumb)(atomic.LoadPointer(&b.next))
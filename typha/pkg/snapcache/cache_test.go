@@ -201,6 +201,36 @@ var _ = Describe("Snapshot cache FV tests", func() {
 			// Deltas should only contain the new update.
 			Expect(deserialiseUpdates(crumb.Deltas)).To(ConsistOf(updateBiff), "Should only receive the second update as a delta")
 		})
+
+		It("should retain breadcrumbs for resuming clients", func() {
+			Expect(cache.ID()).NotTo(BeEmpty())
+			Expect(snapcache.New(cacheConfig).ID()).NotTo(Equal(cache.ID()), "Each cache should have its own ID")
+
+			updateBiff := api.Update{
+				KVPair: model.KVPair{
+					Key:      model.GlobalConfigKey{Name: "biff"},
+					Value:    "baz",
+					Revision: "12",
+				},
+				UpdateType: api.UpdateTypeKVNew,
+			}
+			cache.OnUpdates([]api.Update{updateBiff})
+			nextCrumb, err := crumb.Next(cxt)
+			Expect(err).NotTo(HaveOccurred())
+
+			// A client that applied the first crumb should be able to follow the chain from there.
+			resumeCrumb, ok := cache.BreadcrumbAt(crumb.SequenceNumber)
+			Expect(ok).To(BeTrue())
+			Expect(resumeCrumb).To(BeIdenticalTo(crumb))
+			resumeNext, err := resumeCrumb.Next(cxt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resumeNext).To(BeIdenticalTo(nextCrumb))
+			Expect(deserialiseUpdates(resumeNext.Deltas)).To(ConsistOf(updateBiff))
+
+			// Future breadcrumbs aren't available.
+			_, ok = cache.BreadcrumbAt(nextCrumb.SequenceNumber + 1)
+			Expect(ok).To(BeFalse())
+		})
 	})
 
 	Describe("after sending GlobalConfigKey{foo}=bar @ rev 10", func() {
@@ -25,6 +25,7 @@ import (
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/snappy"
@@ -60,6 +61,23 @@ type Options struct {
 	// it (such as compression).  Useful for simulating an older client in UT.
 	DisableDecoderRestart bool
 
+	// RequestNodeFiltering asks Typha to send a node-filtered view of the datastore, containing full detail
+	// only for resources that belong to this client's hostname.  Only supported for SyncerTypeFelix.  Older
+	// Typha instances ignore the request and send the full datastore.
+	RequestNodeFiltering bool
+
+	// ResumeCacheID and ResumeSeqNo, if set, ask Typha to resume a previous session from the given
+	// breadcrumb, as returned by a previous client's LastAppliedBreadcrumb() method.  The client's callbacks
+	// must already hold the state up to that breadcrumb.  If Typha can't resume the session, the client
+	// disconnects without processing any updates; LastAppliedBreadcrumb() then returns a zero sequence
+	// number so that the caller knows to fall back to a full resync.
+	//
+	// Breadcrumbs are only meaningful to the Typha process that sent them.  The client connects to
+	// whichever instance discovery picks, as it would for a new session, so that it doesn't undo Typha's
+	// connection rebalancing; the session is only resumed if that turns out to be the same process.
+	ResumeCacheID string
+	ResumeSeqNo   uint64
+
 	// DebugLogReads tells the client to wrap each connection with a Reader that
 	// logs every read.  Intended only for use in tests!
 	DebugLogReads bool
@@ -125,7 +143,9 @@ func New(
 		cbskn = callbacksWithKeysKnownAdapter{cbs}
 	}
 	return &SyncerClient{
-		ID: id,
+		ID:               id,
+		lastCacheID:      options.ResumeCacheID,
+		lastAppliedSeqNo: options.ResumeSeqNo,
 		logCxt: log.WithFields(log.Fields{
 			"myID": id,
 			"type": options.SyncerType,
@@ -158,6 +178,14 @@ type SyncerClient struct {
 	decoder                     *gob.Decoder
 	handshakeStatus             *handshakeStatus
 	supportsNodeResourceUpdates bool
+	// nodeFilteringEnabled is set once the server has agreed to filter updates for our node.
+	nodeFilteringEnabled atomic.Bool
+
+	// resumeLock protects lastCacheID and lastAppliedSeqNo, which identify the last breadcrumb from the
+	// server that we passed to our callbacks.
+	resumeLock       sync.Mutex
+	lastCacheID      string
+	lastAppliedSeqNo uint64
 
 	callbacks callbacksWithKeysKnown
 	Finished  sync.WaitGroup
@@ -249,6 +277,23 @@ func (s *SyncerClient) calculateConnectionAttemptLimit(numDiscoveredTyphas int)
 	return maxTries
 }
 
+// LastAppliedBreadcrumb returns the cache ID and sequence number of the last breadcrumb from the server that
+// has been fully passed to the callbacks.  They can be passed to a new client (via Options.ResumeCacheID and
+// Options.ResumeSeqNo) to resume the session after this client's connection has failed.  Returns a zero
+// sequence number if the session can't be resumed.
+func (s *SyncerClient) LastAppliedBreadcrumb() (cacheID string, seqNo uint64) {
+	s.resumeLock.Lock()
+	defer s.resumeLock.Unlock()
+	return s.lastCacheID, s.lastAppliedSeqNo
+}
+
+func (s *SyncerClient) setLastAppliedBreadcrumb(cacheID string, seqNo uint64) {
+	s.resumeLock.Lock()
+	defer s.resumeLock.Unlock()
+	s.lastCacheID = cacheID
+	s.lastAppliedSeqNo = seqNo
+}
+
 // SupportsNodeResourceUpdates waits for the Typha server to send a hello and returns true if
 // the server supports node resource updates. If the given timeout is reached, an error is returned.
 func (s *SyncerClient) SupportsNodeResourceUpdates(timeout time.Duration) (bool, error) {
@@ -269,6 +314,12 @@ func (s *SyncerClient) SupportsNodeResourceUpdates(timeout time.Duration) (bool,
 	return false, fmt.Errorf("Timed out waiting for handshake to complete")
 }
 
+// NodeFilteringEnabled returns true if the server agreed, during the handshake, to filter the updates
+// that it sends to this client by node.  It returns false until the handshake has completed.
+func (s *SyncerClient) NodeFilteringEnabled() bool {
+	return s.nodeFilteringEnabled.Load()
+}
+
 func (s *SyncerClient) connect(cxt context.Context, typhaAddr discovery.Typha) error {
 	log.Info("Starting Typha client")
 	var err error
@@ -431,6 +482,9 @@ func (s *SyncerClient) loop(cxt context.Context, cancelFn context.CancelFunc) {
 			SyncerType:                     ourSyncerType,
 			SupportsDecoderRestart:         !s.options.DisableDecoderRestart,
 			SupportedCompressionAlgorithms: compAlgs,
+			SupportsNodeFiltering:          s.options.RequestNodeFiltering,
+			ResumeCacheID:                  s.options.ResumeCacheID,
+			ResumeSeqNo:                    s.options.ResumeSeqNo,
 			ClientConnID:                   s.ID,
 		},
 	)
@@ -460,6 +514,24 @@ func (s *SyncerClient) loop(cxt context.Context, cancelFn context.CancelFunc) {
 	s.supportsNodeResourceUpdates = serverHello.SupportsNodeResourceUpdates
 	s.handshakeStatus.helloReceivedChan <- struct{}{}
 
+	if s.options.RequestNodeFiltering && !serverHello.SupportsNodeFiltering {
+		logCxt.Info("Server responded without support for node filtering, expecting unfiltered updates.")
+	}
+	s.nodeFilteringEnabled.Store(s.options.RequestNodeFiltering && serverHello.SupportsNodeFiltering)
+
+	if s.options.ResumeSeqNo != 0 && !serverHello.Resumed {
+		// The server is going to send a snapshot, but our callbacks already hold state from the previous
+		// session so they can't simply apply it.  Bail out and let the caller do a full resync.
+		logCxt.Info("Server was unable to resume our previous session, disconnecting.")
+		s.setLastAppliedBreadcrumb("", 0)
+		return
+	}
+	cacheID := serverHello.CacheID
+	if cacheID == "" {
+		// Server doesn't support resuming sessions; make sure we don't record any sequence numbers.
+		s.setLastAppliedBreadcrumb("", 0)
+	}
+
 	// Check the SyncerType reported by the server.  If the server is too old to support SyncerType then
 	// the message will have an empty string in place of the SyncerType.  In that case we only proceed if
 	// the client wants the felix syncer.
@@ -519,6 +591,9 @@ func (s *SyncerClient) loop(cxt context.Context, cancelFn context.CancelFunc) {
 				keys = append(keys, kv.Key)
 			}
 			s.callbacks.OnUpdatesKeysKnown(updates, keys)
+			if msg.SequenceNumber != 0 && cacheID != "" {
+				s.setLastAppliedBreadcrumb(cacheID, msg.SequenceNumber)
+			}
 		case syncproto.MsgDecoderRestart:
 			if s.options.DisableDecoderRestart {
 				log.Error("Server sent MsgDecoderRestart but we signalled no support.")
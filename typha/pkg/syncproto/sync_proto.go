@@ -83,6 +83,17 @@
 //	|<-----------------------|
 //	|                        |
 //
+// # Resuming a session
+//
+// Each KVs message that completes a snapshot or carries deltas includes the sequence number of the
+// server's breadcrumb that the client will be in sync with once it applies the message.  If the client
+// loses its connection, it can send that sequence number (along with the CacheID from the ServerHello)
+// in its next ClientHello.  If the server still has that breadcrumb, it sets Resumed in the ServerHello
+// and skips straight to sending deltas; otherwise it sends a snapshot as normal.  Sequence numbers are
+// specific to a particular Typha process (identified by CacheID) so a session is only resumed if the
+// client happens to reconnect to the same Typha, before it restarts.  Clients don't seek out the same
+// Typha, since that would undo connection rebalancing.
+//
 // # Wire format
 //
 // The protocol uses gob to encode messages.  Each message is wrapped in an Envelope
@@ -174,6 +185,7 @@
 package syncproto
 
 import (
+	"bytes"
 	"encoding/gob"
 	"errors"
 	"fmt"
@@ -260,6 +272,19 @@ type MsgClientHello struct {
 	SupportsDecoderRestart         bool
 	SupportedCompressionAlgorithms []CompressionAlgorithm
 
+	// SupportsNodeFiltering is set by clients that only need the full detail of resources that are local to
+	// Hostname.  If the server agrees (by setting the corresponding field in MsgServerHello), it omits other
+	// nodes' host-scoped configuration and sends a reduced form of remote workload endpoints that contains
+	// only the fields needed to calculate IP set membership.  Remote workload endpoints without IPs, and updates
+	// that don't change the reduced form, are omitted.  Only supported for SyncerTypeFelix.
+	SupportsNodeFiltering bool
+
+	// ResumeCacheID and ResumeSeqNo identify the last breadcrumb that the client applied in a previous
+	// session (see MsgServerHello.CacheID and MsgKVs.SequenceNumber).  If ResumeSeqNo is non-zero and the
+	// server still has that breadcrumb, it skips the snapshot and sends only the subsequent deltas.
+	ResumeCacheID string
+	ResumeSeqNo   uint64
+
 	ClientConnID uint64
 }
 
@@ -274,6 +299,17 @@ type MsgServerHello struct {
 	// SupportsNodeResourceUpdates provides to the client whether this Typha supports node resource updates.
 	SupportsNodeResourceUpdates bool
 
+	// SupportsNodeFiltering is set if the server accepted the client's request for a node-filtered view of the
+	// datastore.  See MsgClientHello.SupportsNodeFiltering.
+	SupportsNodeFiltering bool
+
+	// CacheID identifies the server's sequence of breadcrumbs.  Sequence numbers sent in MsgKVs are only
+	// meaningful in the context of this ID.  Empty if the server doesn't support resuming sessions.
+	CacheID string
+	// Resumed is set if the server accepted the client's request to resume from a previous breadcrumb.  In
+	// that case, no snapshot is sent; the server starts sending deltas immediately.
+	Resumed bool
+
 	ServerConnID uint64
 }
 
@@ -300,6 +336,11 @@ type MsgPong struct {
 }
 type MsgKVs struct {
 	KVs []SerializedUpdate
+
+	// SequenceNumber, if non-zero, is the sequence number of the server's breadcrumb that the client
+	// will be in sync with once it has applied this message.  It is set on each message of deltas but,
+	// for a snapshot, only on the last message.
+	SequenceNumber uint64
 }
 
 func (m MsgKVs) String() string {
@@ -346,6 +387,7 @@ func SerializeUpdate(u api.Update) (su SerializedUpdate, err error) {
 	su.TTL = u.TTL
 	su.Revision = u.Revision // This relies on the revision being a basic type.
 	su.UpdateType = u.UpdateType
+	su.populateNodeScope(u)
 
 	if u.Value == nil {
 		log.Debug("Value is nil, passing through as a deletion.")
@@ -380,6 +422,137 @@ type SerializedUpdate struct {
 	V3ResourceVersion string
 	TTL               time.Duration
 	UpdateType        api.UpdateType
+
+	// The fields below are used to serve node-filtered views of the datastore.  They are unexported so that
+	// gob doesn't send them over the wire.
+
+	// hostname is the node that the resource belongs to, or "" if the resource is global.
+	hostname string
+	// remoteValue, if non-nil, is the reduced form of Value that should be sent to other nodes.
+	remoteValue []byte
+	// localOnly is true if the resource should not be sent to other nodes at all.
+	localOnly bool
+	// remoteOmitted is true if other nodes don't currently need the resource, for example, a workload
+	// endpoint that doesn't have any IP addresses yet and so can't contribute to other nodes' IP sets.
+	remoteOmitted bool
+
+	// The fields below are only set on deltas, by CompareRemote.
+
+	// remoteWasPresent is true if other nodes were sent the previous value of the resource.
+	remoteWasPresent bool
+	// remoteUnchanged is true if the form of the update sent to other nodes is the same as that of the
+	// previous value, so other nodes don't need to be sent the update.
+	remoteUnchanged bool
+}
+
+// nodeSharedHostConfig contains the names of the per-host config values that are derived from the Node
+// resource and that other nodes' Felixes need, for example the VXLAN resolver needs remote nodes' VXLAN
+// tunnel addresses and MACs.  Other per-host config, such as per-node FelixConfiguration overrides, is
+// only used by the node's own Felix.
+var nodeSharedHostConfig = map[string]bool{
+	"IpInIpTunnelAddr":     true,
+	"IPv4VXLANTunnelAddr":  true,
+	"VXLANTunnelMACAddr":   true,
+	"IPv6VXLANTunnelAddr":  true,
+	"VXLANTunnelMACAddrV6": true,
+}
+
+// populateNodeScope records which node (if any) the update belongs to and, for workload endpoints,
+// pre-calculates the reduced value to send to other nodes.  This is done once, here, rather than once
+// per client.
+func (s *SerializedUpdate) populateNodeScope(u api.Update) {
+	switch key := u.Key.(type) {
+	case model.HostConfigKey:
+		if nodeSharedHostConfig[key.Name] {
+			// Other nodes need these to program routes and tunnels to this node.
+			return
+		}
+		s.hostname = key.Hostname
+		s.localOnly = true
+	case model.WorkloadEndpointKey:
+		s.hostname = key.Hostname
+		wep, ok := u.Value.(*model.WorkloadEndpoint)
+		if !ok {
+			return
+		}
+		if len(wep.IPv4Nets) == 0 && len(wep.IPv6Nets) == 0 {
+			// Other nodes only use remote endpoints for their IP addresses (in IP sets and routes), so
+			// there's no need to send them endpoints that don't have any yet.
+			s.remoteOmitted = true
+			return
+		}
+		// Other nodes only need the fields that feed into selector/IP set calculations (and flow log
+		// enrichment).  Interface-level details such as the MAC, gateways and QoS settings are only
+		// used by the local Felix.
+		remoteWEP := &model.WorkloadEndpoint{
+			State:        wep.State,
+			Name:         wep.Name,
+			ProfileIDs:   wep.ProfileIDs,
+			IPv4Nets:     wep.IPv4Nets,
+			IPv6Nets:     wep.IPv6Nets,
+			IPv4NAT:      wep.IPv4NAT,
+			IPv6NAT:      wep.IPv6NAT,
+			Labels:       wep.Labels,
+			Ports:        wep.Ports,
+			GenerateName: wep.GenerateName,
+		}
+		remoteValue, err := model.SerializeValue(&model.KVPair{Key: key, Value: remoteWEP})
+		if err != nil {
+			log.WithError(err).WithField("key", key).Warn(
+				"Failed to serialize reduced workload endpoint, sending full value to all nodes.")
+			return
+		}
+		s.remoteValue = remoteValue
+	}
+}
+
+// ForNode returns the form of this update that should be sent to a client that only needs the full
+// detail of resources that belong to the given node.  The second return value is false if the update
+// should not be sent to that client at all.
+func (s SerializedUpdate) ForNode(hostname string) (SerializedUpdate, bool) {
+	if s.hostname == "" || s.hostname == hostname {
+		return s, true
+	}
+	if s.localOnly {
+		return SerializedUpdate{}, false
+	}
+	if s.Value == nil {
+		// Always pass through deletions.
+		return s, true
+	}
+	if s.remoteOmitted {
+		if s.remoteWasPresent {
+			// Other nodes had the previous value but no longer need the resource; remove it.
+			s.Value = nil
+			s.UpdateType = api.UpdateTypeKVDeleted
+			return s, true
+		}
+		return SerializedUpdate{}, false
+	}
+	if s.remoteUnchanged {
+		return SerializedUpdate{}, false
+	}
+	if !s.remoteWasPresent {
+		// Either this is a snapshot KV, or other nodes didn't have the previous value, so it's new to them.
+		s.UpdateType = api.UpdateTypeKVNew
+	}
+	if s.remoteValue != nil {
+		s.Value = s.remoteValue
+	}
+	return s, true
+}
+
+// CompareRemote records how the form of this update that is sent to other nodes (see ForNode) differs from that
+// of the previous value of the same key, if it exists.  It should only be called on updates that are going to be
+// sent as deltas; it allows ForNode to skip sending the update to other nodes if their form hasn't changed.
+func (s *SerializedUpdate) CompareRemote(prev SerializedUpdate, prevExists bool) {
+	if s.hostname == "" || s.localOnly || s.Value == nil {
+		return
+	}
+	s.remoteWasPresent = prevExists && !prev.remoteOmitted
+	if s.remoteWasPresent && !s.remoteOmitted && s.remoteValue != nil && bytes.Equal(prev.remoteValue, s.remoteValue) {
+		s.remoteUnchanged = true
+	}
 }
 
 var ErrBadKey = errors.New("Unable to parse key.")
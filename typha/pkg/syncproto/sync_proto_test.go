@@ -18,9 +18,14 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/gob"
+	gonet "net"
 	"testing"
 
 	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/net"
 )
 
 const cannedEnvelopeWithHello = "Iv+BAwEBCEVudmVsb3BlAf+CAAEBAQdNZXNzYWdlARAAAAD/jP+CATtnaXRodWIuY29tL3Byb2plY3R" +
@@ -78,3 +83,169 @@ func TestEncodeCanned(t *testing.T) {
 
 	t.Logf("%q", b2.String())
 }
+
+func TestForNode(t *testing.T) {
+	RegisterTestingT(t)
+
+	hwAddr, err := gonet.ParseMAC("01:02:03:04:05:06")
+	Expect(err).NotTo(HaveOccurred())
+	mac := &net.MAC{HardwareAddr: hwAddr}
+	wepKey := model.WorkloadEndpointKey{
+		Hostname:       "host-a",
+		OrchestratorID: "k8s",
+		WorkloadID:     "ns/pod",
+		EndpointID:     "eth0",
+	}
+	wepUpd, err := SerializeUpdate(api.Update{
+		KVPair: model.KVPair{
+			Key: wepKey,
+			Value: &model.WorkloadEndpoint{
+				Name:     "cali1234",
+				Mac:      mac,
+				IPv4Nets: []net.IPNet{net.MustParseCIDR("10.0.0.1/32")},
+			},
+		},
+		UpdateType: api.UpdateTypeKVNew,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	// Local node gets the full value.
+	upd, ok := wepUpd.ForNode("host-a")
+	Expect(ok).To(BeTrue())
+	Expect(upd.Value).To(Equal(wepUpd.Value))
+
+	// Remote nodes get the reduced value.
+	upd, ok = wepUpd.ForNode("host-b")
+	Expect(ok).To(BeTrue())
+	Expect(upd.Key).To(Equal(wepUpd.Key))
+	parsed, err := upd.ToUpdate()
+	Expect(err).NotTo(HaveOccurred())
+	wep := parsed.Value.(*model.WorkloadEndpoint)
+	Expect(wep.Name).To(Equal("cali1234"))
+	Expect(wep.Mac).To(BeNil())
+	Expect(wep.IPv4Nets).To(Equal([]net.IPNet{net.MustParseCIDR("10.0.0.1/32")}))
+
+	// Host-scoped config is only sent to the host that it belongs to.
+	cfgUpd, err := SerializeUpdate(api.Update{
+		KVPair: model.KVPair{
+			Key:   model.HostConfigKey{Hostname: "host-a", Name: "LogSeverityScreen"},
+			Value: "debug",
+		},
+		UpdateType: api.UpdateTypeKVNew,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	_, ok = cfgUpd.ForNode("host-a")
+	Expect(ok).To(BeTrue())
+	_, ok = cfgUpd.ForNode("host-b")
+	Expect(ok).To(BeFalse())
+
+	// Except for the config that is derived from the node and that other nodes need.
+	tunnelUpd, err := SerializeUpdate(api.Update{
+		KVPair: model.KVPair{
+			Key:   model.HostConfigKey{Hostname: "host-a", Name: "IPv4VXLANTunnelAddr"},
+			Value: "10.0.0.1",
+		},
+		UpdateType: api.UpdateTypeKVNew,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	forB, ok := tunnelUpd.ForNode("host-b")
+	Expect(ok).To(BeTrue())
+	Expect(forB).To(Equal(tunnelUpd))
+
+	// Global resources are sent unchanged.
+	globalUpd, err := SerializeUpdate(api.Update{
+		KVPair: model.KVPair{
+			Key:   model.GlobalConfigKey{Name: "LogSeverityScreen"},
+			Value: "info",
+		},
+		UpdateType: api.UpdateTypeKVNew,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	upd, ok = globalUpd.ForNode("host-b")
+	Expect(ok).To(BeTrue())
+	Expect(upd).To(Equal(globalUpd))
+}
+
+func TestForNodeDeltas(t *testing.T) {
+	RegisterTestingT(t)
+
+	wepKey := model.WorkloadEndpointKey{
+		Hostname:       "host-a",
+		OrchestratorID: "k8s",
+		WorkloadID:     "ns/pod",
+		EndpointID:     "eth0",
+	}
+	serializeWEP := func(wep *model.WorkloadEndpoint, updType api.UpdateType) SerializedUpdate {
+		upd, err := SerializeUpdate(api.Update{
+			KVPair:     model.KVPair{Key: wepKey, Value: wep},
+			UpdateType: updType,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return upd
+	}
+	ips := []net.IPNet{net.MustParseCIDR("10.0.0.1/32")}
+
+	// An endpoint without IPs is only sent to its own node.
+	noIPs := serializeWEP(&model.WorkloadEndpoint{Name: "cali1234"}, api.UpdateTypeKVNew)
+	_, ok := noIPs.ForNode("host-a")
+	Expect(ok).To(BeTrue())
+	_, ok = noIPs.ForNode("host-b")
+	Expect(ok).To(BeFalse())
+
+	// Once it gets an IP, it's new as far as other nodes are concerned.
+	withIPs := serializeWEP(&model.WorkloadEndpoint{Name: "cali1234", IPv4Nets: ips}, api.UpdateTypeKVUpdated)
+	withIPs.CompareRemote(noIPs, true)
+	upd, ok := withIPs.ForNode("host-a")
+	Expect(ok).To(BeTrue())
+	Expect(upd.UpdateType).To(Equal(api.UpdateTypeKVUpdated))
+	upd, ok = withIPs.ForNode("host-b")
+	Expect(ok).To(BeTrue())
+	Expect(upd.UpdateType).To(Equal(api.UpdateTypeKVNew))
+
+	// A change to a field that other nodes don't use is only sent to the local node.
+	hwAddr, err := gonet.ParseMAC("01:02:03:04:05:06")
+	Expect(err).NotTo(HaveOccurred())
+	withMAC := serializeWEP(&model.WorkloadEndpoint{
+		Name:     "cali1234",
+		IPv4Nets: ips,
+		Mac:      &net.MAC{HardwareAddr: hwAddr},
+	}, api.UpdateTypeKVUpdated)
+	withMAC.CompareRemote(withIPs, true)
+	_, ok = withMAC.ForNode("host-a")
+	Expect(ok).To(BeTrue())
+	_, ok = withMAC.ForNode("host-b")
+	Expect(ok).To(BeFalse())
+
+	// A change to a field that other nodes do use is sent to everyone.
+	withProfile := serializeWEP(&model.WorkloadEndpoint{
+		Name:       "cali1234",
+		IPv4Nets:   ips,
+		Mac:        &net.MAC{HardwareAddr: hwAddr},
+		ProfileIDs: []string{"kns.ns"},
+	}, api.UpdateTypeKVUpdated)
+	withProfile.CompareRemote(withMAC, true)
+	upd, ok = withProfile.ForNode("host-b")
+	Expect(ok).To(BeTrue())
+	Expect(upd.UpdateType).To(Equal(api.UpdateTypeKVUpdated))
+
+	// If the endpoint loses its IPs, other nodes see a deletion.
+	lostIPs := serializeWEP(&model.WorkloadEndpoint{Name: "cali1234"}, api.UpdateTypeKVUpdated)
+	lostIPs.CompareRemote(withProfile, true)
+	upd, ok = lostIPs.ForNode("host-a")
+	Expect(ok).To(BeTrue())
+	Expect(upd.Value).NotTo(BeNil())
+	upd, ok = lostIPs.ForNode("host-b")
+	Expect(ok).To(BeTrue())
+	Expect(upd.UpdateType).To(Equal(api.UpdateTypeKVDeleted))
+	Expect(upd.Value).To(BeNil())
+
+	// Deletions are always sent.
+	deleted, err := SerializeUpdate(api.Update{
+		KVPair:     model.KVPair{Key: wepKey},
+		UpdateType: api.UpdateTypeKVDeleted,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	deleted.CompareRemote(lostIPs, true)
+	_, ok = deleted.ForNode("host-b")
+	Expect(ok).To(BeTrue())
+}
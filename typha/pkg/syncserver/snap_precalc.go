@@ -68,10 +68,13 @@ type SnappySnapshotCache struct {
 
 	cache BreadcrumbProvider
 
-	lock           sync.Mutex
-	cond           sync.Cond
-	activeSnapshot *snapshot
-	lastSnapSize   int
+	lock sync.Mutex
+	cond sync.Cond
+	// activeSnapshots contains the current snapshot for each node that has requested a node-filtered
+	// view of the datastore, as well as the unfiltered snapshot, which is stored under the key "".
+	activeSnapshots  map[string]*snapshot
+	lastSnapSize     int
+	lastNodeSnapSize int
 
 	counterBinSnapsGenerated prometheus.Counter
 	counterBinSnapsReused    prometheus.Counter
@@ -91,6 +94,7 @@ func NewSnappySnapCache(
 		snapValidityTimeout: snapValidityTimeout,
 		writeTimeout:        writeTimeout,
 		cache:               cache,
+		activeSnapshots:     map[string]*snapshot{},
 		logCtx: logrus.WithFields(logrus.Fields{
 			"thread": "snapshotter",
 			"syncer": syncerName,
@@ -108,10 +112,13 @@ func NewSnappySnapCache(
 // on the given connection.  Since the stream is cached, it starts with fresh snappy/gob headers.  Hence, the
 // decoder at the client side must also be reset before sending such a snapshot.  The snapshot ends with
 // a MsgDecoderRestart, so the caller should wait for an ACK and then reset their encoder.
-func (s *SnappySnapshotCache) SendSnapshot(ctx context.Context, w io.Writer, conn WriteDeadlineSetter) (*snapcache.Breadcrumb, error) {
+//
+// If nodeFilterHostname is non-empty, the snapshot is filtered for that node.  Filtered snapshots are cached per
+// node, so that clients on the same node (or a client that reconnects) can share the work.
+func (s *SnappySnapshotCache) SendSnapshot(ctx context.Context, nodeFilterHostname string, w io.Writer, conn WriteDeadlineSetter) (*snapcache.Breadcrumb, error) {
 	// activeBinarySnapshot ensures there is an active snapshot and returns it.  The snapshot may or may not
 	// be complete yet.
-	snap := s.activeBinarySnapshot()
+	snap := s.activeBinarySnapshot(nodeFilterHostname)
 	if err := snap.sendToClient(ctx, s.logCtx, w, conn, s.writeTimeout); err != nil {
 		return nil, err
 	}
@@ -124,26 +131,33 @@ type WriteDeadlineSetter interface {
 
 // activeBinarySnapshot either returns the current active snapshot (which may still be being created on a background
 // goroutine), or it starts a new snapshot.  The returned snapshot's complete flag will be set once it is finished.
-func (s *SnappySnapshotCache) activeBinarySnapshot() *snapshot {
+func (s *SnappySnapshotCache) activeBinarySnapshot(nodeFilterHostname string) *snapshot {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if s.activeSnapshot == nil {
+	snap := s.activeSnapshots[nodeFilterHostname]
+	if snap == nil {
 		breadcrumb := s.cache.CurrentBreadcrumb()
-		bufSize := s.lastSnapSize * 110 / 100
+		lastSize := s.lastSnapSize
+		if nodeFilterHostname != "" {
+			lastSize = s.lastNodeSnapSize
+		}
+		bufSize := lastSize * 110 / 100
 		const defaultBufSize = 128 * 1024
 		if bufSize == 0 {
 			bufSize = defaultBufSize
 		}
-		s.activeSnapshot = &snapshot{
-			crumb: breadcrumb,
-			buf:   multireadbuf.New(bufSize),
+		snap = &snapshot{
+			crumb:              breadcrumb,
+			nodeFilterHostname: nodeFilterHostname,
+			buf:                multireadbuf.New(bufSize),
 		}
-		go s.populateSnapshot(s.activeSnapshot)
+		s.activeSnapshots[nodeFilterHostname] = snap
+		go s.populateSnapshot(snap)
 	} else {
 		s.counterBinSnapsReused.Inc()
 	}
-	return s.activeSnapshot
+	return snap
 }
 
 func (s *SnappySnapshotCache) populateSnapshot(snap *snapshot) {
@@ -152,12 +166,12 @@ func (s *SnappySnapshotCache) populateSnapshot(snap *snapshot) {
 	time.Sleep(s.snapValidityTimeout)
 	// No point in expiring the snapshot until there's a new one...
 	_, _ = snap.crumb.Next(context.Background())
-	s.clearSnapshot()
+	s.clearSnapshot(snap)
 }
 
-func (s *SnappySnapshotCache) clearSnapshot() {
+func (s *SnappySnapshotCache) clearSnapshot(snap *snapshot) {
 	s.lock.Lock()
-	s.activeSnapshot = nil
+	delete(s.activeSnapshots, snap.nodeFilterHostname)
 	s.lock.Unlock()
 }
 
@@ -191,6 +205,7 @@ func (s *SnappySnapshotCache) writeDataToSnapshot(snap *snapshot) {
 		context.Background(),
 		s.logCtx.WithField("destination", "compressed in-memory cache"),
 		snap.crumb,
+		snap.nodeFilterHostname,
 		writeMsg,
 		1000, // Allow bigger messages in the snapshot.
 	)
@@ -221,23 +236,32 @@ func (s *SnappySnapshotCache) writeDataToSnapshot(snap *snapshot) {
 		s.logCtx.WithError(err).Panic("Failed to close datastore snapshot.")
 	}
 
-	s.gaugeSnapBytesRaw.Set(float64(progressW.BytesWritten))
 	snapSize := snap.buf.Len()
-	s.gaugeSnapBytesComp.Set(float64(snapSize))
+	if snap.nodeFilterHostname == "" {
+		// Only report the size of the unfiltered snapshot; node-filtered snapshots vary by node.
+		s.gaugeSnapBytesRaw.Set(float64(progressW.BytesWritten))
+		s.gaugeSnapBytesComp.Set(float64(snapSize))
+	}
 
 	// Record snapshot size so that we have a good guess for next time.
-	s.setLastSnapSize(snapSize)
+	s.setLastSnapSize(snap.nodeFilterHostname, snapSize)
 }
 
-func (s *SnappySnapshotCache) setLastSnapSize(snapSize int) {
+func (s *SnappySnapshotCache) setLastSnapSize(nodeFilterHostname string, snapSize int) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.lastSnapSize = snapSize
+	if nodeFilterHostname == "" {
+		s.lastSnapSize = snapSize
+	} else {
+		s.lastNodeSnapSize = snapSize
+	}
 }
 
 type snapshot struct {
 	crumb *snapcache.Breadcrumb
-	buf   *multireadbuf.MultiReaderSingleWriterBuffer
+	// nodeFilterHostname is the node that this snapshot has been filtered for, or "" if it is unfiltered.
+	nodeFilterHostname string
+	buf                *multireadbuf.MultiReaderSingleWriterBuffer
 }
 
 func (s *snapshot) sendToClient(ctx context.Context, logCtx *logrus.Entry, w io.Writer, conn WriteDeadlineSetter, writeTimeout time.Duration) error {
@@ -78,6 +78,15 @@ var (
 		Help: "Total number of connections that made use of the grace period to catch up after sending the initial " +
 			"snapshot.",
 	}, []string{"syncer"})
+	counterVecConnectionsResumed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "typha_connections_resumed",
+		Help: "Total number of connections that resumed a previous session instead of receiving a snapshot.",
+	}, []string{"syncer"})
+	counterVecConnectionsResumeRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "typha_connections_resume_rejected",
+		Help: "Total number of connections that asked to resume a previous session but were sent a snapshot " +
+			"because the session was from another Typha instance or was too old.",
+	}, []string{"syncer"})
 )
 
 func init() {
@@ -89,6 +98,10 @@ func init() {
 	promutils.PreCreateGaugePerSyncer(gaugeVecNumConnectionsStreaming)
 	prometheus.MustRegister(counterVecGracePeriodUsed)
 	promutils.PreCreateCounterPerSyncer(counterVecGracePeriodUsed)
+	prometheus.MustRegister(counterVecConnectionsResumed)
+	promutils.PreCreateCounterPerSyncer(counterVecConnectionsResumed)
+	prometheus.MustRegister(counterVecConnectionsResumeRejected)
+	promutils.PreCreateCounterPerSyncer(counterVecConnectionsResumeRejected)
 }
 
 const (
@@ -130,6 +143,14 @@ type BreadcrumbProvider interface {
 	CurrentBreadcrumb() *snapcache.Breadcrumb
 }
 
+// ResumableBreadcrumbProvider is a BreadcrumbProvider that retains recent Breadcrumbs so that clients
+// can resume a previous session without receiving a new snapshot.
+type ResumableBreadcrumbProvider interface {
+	BreadcrumbProvider
+	ID() string
+	BreadcrumbAt(seqNo uint64) (*snapcache.Breadcrumb, bool)
+}
+
 type Config struct {
 	Port                           int
 	MaxMessageSize                 int
@@ -690,6 +711,12 @@ type connection struct {
 	logCxt                       *log.Entry
 	chosenCompression            syncproto.CompressionAlgorithm
 	clientSupportsDecoderRestart bool
+	// nodeFilterHostname is set to the client's hostname if we agreed to send it a node-filtered view of the
+	// datastore.
+	nodeFilterHostname string
+	// resumeBreadcrumb is set to the client's last-applied Breadcrumb if we agreed to resume its previous
+	// session.
+	resumeBreadcrumb *snapcache.Breadcrumb
 
 	// Similarly to allCaches, allMetrics contains all the metrics relevant to a particular syncer.  We copy one
 	// of them to the unnamed field after the handshake.
@@ -698,7 +725,7 @@ type connection struct {
 }
 
 type snapshotCache interface {
-	SendSnapshot(ctx context.Context, w io.Writer, conn WriteDeadlineSetter) (*snapcache.Breadcrumb, error)
+	SendSnapshot(ctx context.Context, nodeFilterHostname string, w io.Writer, conn WriteDeadlineSetter) (*snapcache.Breadcrumb, error)
 }
 
 func (h *connection) handle(finishedWG *sync.WaitGroup) (err error) {
@@ -737,7 +764,9 @@ func (h *connection) handle(finishedWG *sync.WaitGroup) (err error) {
 	// Figure out if we should restart the decoder with new settings.
 	var binSnapCache snapshotCache
 	if h.clientSupportsDecoderRestart {
-		binSnapCache = h.allSnapshotters[h.chosenCompression][h.syncerType]
+		if h.resumeBreadcrumb == nil {
+			binSnapCache = h.allSnapshotters[h.chosenCompression][h.syncerType]
+		}
 		var reasonsToRestart []string
 		if h.chosenCompression != "" {
 			reasonsToRestart = append(reasonsToRestart, fmt.Sprintf("enable compression: %v", h.chosenCompression))
@@ -757,11 +786,17 @@ func (h *connection) handle(finishedWG *sync.WaitGroup) (err error) {
 	}
 
 	var breadcrumb *snapcache.Breadcrumb
-	if binSnapCache != nil {
+	if h.resumeBreadcrumb != nil {
+		// Client already has the state up to this breadcrumb, we just need to send it the deltas that
+		// follow.
+		h.logCxt.WithField("seqNo", h.resumeBreadcrumb.SequenceNumber).Info("Resuming client's previous session.")
+		breadcrumb = h.resumeBreadcrumb
+		h.counterConnectionsResumed.Inc()
+	} else if binSnapCache != nil {
 		// We have a binary snapshot cache that supports this compression mode; send the compressed
 		// binary snapshot instead of a streamed snapshot.
 		snapStart := time.Now()
-		breadcrumb, err = binSnapCache.SendSnapshot(h.cxt, h.connW, h.conn)
+		breadcrumb, err = binSnapCache.SendSnapshot(h.cxt, h.nodeFilterHostname, h.connW, h.conn)
 		if err != nil {
 			log.WithError(err).Info("Failed to send snapshot to client, tearing down connection.")
 			return
@@ -924,6 +959,27 @@ func (h *connection) doHandshake() error {
 	}
 	h.cache = desiredSyncerCache
 
+	var cacheID string
+	if resumableCache, ok := desiredSyncerCache.(ResumableBreadcrumbProvider); ok {
+		cacheID = resumableCache.ID()
+		if hello.ResumeSeqNo != 0 {
+			logCxt := h.logCxt.WithFields(log.Fields{
+				"resumeCacheID": hello.ResumeCacheID,
+				"resumeSeqNo":   hello.ResumeSeqNo,
+			})
+			if hello.ResumeCacheID != cacheID {
+				logCxt.Info("Client asked to resume a session from a different cache, will send snapshot.")
+				h.counterConnectionsResumeRejected.Inc()
+			} else if crumb, ok := resumableCache.BreadcrumbAt(hello.ResumeSeqNo); ok {
+				logCxt.Info("Client asked to resume a session and breadcrumb is still available.")
+				h.resumeBreadcrumb = crumb
+			} else {
+				logCxt.Info("Client asked to resume a session but breadcrumb is no longer available, will send snapshot.")
+				h.counterConnectionsResumeRejected.Inc()
+			}
+		}
+	}
+
 	for _, alg := range hello.SupportedCompressionAlgorithms {
 		switch alg {
 		case syncproto.CompressionSnappy:
@@ -936,6 +992,15 @@ func (h *connection) doHandshake() error {
 		h.chosenCompression = ""
 	}
 
+	if hello.SupportsNodeFiltering {
+		if syncerType == syncproto.SyncerTypeFelix && hello.Hostname != "" {
+			h.logCxt.WithField("hostname", hello.Hostname).Info("Client requested node filtering, enabling.")
+			h.nodeFilterHostname = hello.Hostname
+		} else {
+			h.logCxt.Info("Client requested node filtering but it is only supported for Felix clients with a hostname.")
+		}
+	}
+
 	// Respond to client's hello.
 	err = h.sendMsg(syncproto.MsgServerHello{
 		Version: buildinfo.Version,
@@ -943,6 +1008,9 @@ func (h *connection) doHandshake() error {
 		// clients will ignore.
 		SyncerType:                  syncerType,
 		SupportsNodeResourceUpdates: true,
+		SupportsNodeFiltering:       h.nodeFilterHostname != "",
+		CacheID:                     cacheID,
+		Resumed:                     h.resumeBreadcrumb != nil,
 		ServerConnID:                h.ID,
 	})
 	if err != nil {
@@ -1169,13 +1237,13 @@ func (h *connection) sendDeltaUpdatesToClient(logCxt *log.Entry, breadcrumb *sna
 			if crumbAge < h.config.MinBatchingAgeThreshold && deltas == nil {
 				// We're not behind and we haven't already started to batch up updates.  Avoid
 				// copying the deltas and just send them
-				deltas = breadcrumb.Deltas
+				deltas = breadcrumb.DeltasForNode(h.nodeFilterHostname)
 				break
 			}
 
 			// Either we're already batching up updates or we're behind.  Append the deltas to the
 			// buffer.
-			deltas = append(deltas, breadcrumb.Deltas...)
+			deltas = append(deltas, breadcrumb.DeltasForNode(h.nodeFilterHostname)...)
 			h.summaryNextCatchupLatency.Observe(timeSpentInNext.Seconds())
 
 			if crumbAge < h.config.MinBatchingAgeThreshold {
@@ -1189,7 +1257,8 @@ func (h *connection) sendDeltaUpdatesToClient(logCxt *log.Entry, breadcrumb *sna
 			logCxt.WithField("num", len(deltas)).Debug("Sending deltas")
 			h.summaryNumKVsPerMsg.Observe(float64(len(deltas)))
 			err := h.sendMsg(syncproto.MsgKVs{
-				KVs: deltas,
+				KVs:            deltas,
+				SequenceNumber: breadcrumb.SequenceNumber,
 			})
 			if err != nil {
 				logCxt.WithError(err).Info("Failed to send to client.")
@@ -1211,6 +1280,7 @@ func (h *connection) streamSnapshotToClient(logCxt *log.Entry, breadcrumb *snapc
 		h.cxt,
 		h.logCxt.WithField("destination", "direct to client"),
 		breadcrumb,
+		h.nodeFilterHostname,
 		h.sendMsg,
 		h.config.MaxMessageSize,
 	)
@@ -1223,10 +1293,12 @@ func (h *connection) streamSnapshotToClient(logCxt *log.Entry, breadcrumb *snapc
 }
 
 // writeSnapshotMessages chunks the given breadcrumb up into syncproto.MsgKVs objects and calls writeMsg for each one.
+// If nodeFilterHostname is non-empty, the snapshot is filtered for that node.
 func writeSnapshotMessages(
 	ctx context.Context,
 	logCxt *log.Entry,
 	breadcrumb *snapcache.Breadcrumb,
+	nodeFilterHostname string,
 	writeMsg func(any) error,
 	maxMsgSize int,
 ) (err error) {
@@ -1237,16 +1309,18 @@ func writeSnapshotMessages(
 	logCxt.Info("Starting to write snapshot")
 
 	// writeKVs is a utility function that sends the kvs buffer to the client (if non-empty) and clears the buffer.
+	// The sequence number should only be set for the final message of the snapshot.
 	var kvs []syncproto.SerializedUpdate
 	var numKeys int
-	writeKVs := func() error {
+	writeKVs := func(seqNo uint64) error {
 		if len(kvs) == 0 {
 			return nil
 		}
 		logCxt.WithField("numKVs", len(kvs)).Debug("Writing snapshot KVs.")
 		numKeys += len(kvs)
 		err := writeMsg(syncproto.MsgKVs{
-			KVs: kvs,
+			KVs:            kvs,
+			SequenceNumber: seqNo,
 		})
 		if err != nil {
 			logCxt.WithError(err).Info("Failed to write snapshot KVs")
@@ -1255,26 +1329,26 @@ func writeSnapshotMessages(
 		return err
 	}
 
-	breadcrumb.KVs.Ascend(func(entry syncproto.SerializedUpdate) bool {
+	breadcrumb.AscendKVsForNode(nodeFilterHostname, func(entry syncproto.SerializedUpdate) bool {
 		if ctx.Err() != nil {
 			err = ctx.Err()
 			return false
 		}
-		kvs = append(kvs, entry)
 		if len(kvs) >= maxMsgSize {
-			// Buffer is full, send the next batch.
-			err = writeKVs()
+			// Buffer is full and there's more to come, send the next batch.
+			err = writeKVs(0)
 			if err != nil {
 				return false
 			}
 		}
+		kvs = append(kvs, entry)
 		return true
 	})
 	if err != nil {
 		return
 	}
 
-	err = writeKVs()
+	err = writeKVs(breadcrumb.SequenceNumber)
 	if err != nil {
 		return
 	}
@@ -1315,14 +1389,16 @@ func (h *connection) sendPingsToClient(logCxt *log.Entry) {
 // perSyncerConnMetrics contains a set of Prometheus metrics that each connection needs to update.  There is one
 // set per syncer type.
 type perSyncerConnMetrics struct {
-	counterGracePeriodUsed       prometheus.Counter
-	summarySnapshotSendTime      prometheus.Summary
-	summaryClientLatency         prometheus.Summary
-	summaryWriteLatency          prometheus.Summary
-	summaryNextCatchupLatency    prometheus.Summary
-	summaryPingLatency           prometheus.Summary
-	summaryNumKVsPerMsg          prometheus.Summary
-	gaugeNumConnectionsStreaming prometheus.Gauge
+	counterGracePeriodUsed           prometheus.Counter
+	counterConnectionsResumed        prometheus.Counter
+	counterConnectionsResumeRejected prometheus.Counter
+	summarySnapshotSendTime          prometheus.Summary
+	summaryClientLatency             prometheus.Summary
+	summaryWriteLatency              prometheus.Summary
+	summaryNextCatchupLatency        prometheus.Summary
+	summaryPingLatency               prometheus.Summary
+	summaryNumKVsPerMsg              prometheus.Summary
+	gaugeNumConnectionsStreaming     prometheus.Gauge
 }
 
 func makePerSyncerConnMetrics(syncerType syncproto.SyncerType) perSyncerConnMetrics {
@@ -1364,6 +1440,8 @@ func makePerSyncerConnMetrics(syncerType syncproto.SyncerType) perSyncerConnMetr
 		ConstLabels: syncerLabels,
 	}))
 	c.counterGracePeriodUsed = counterVecGracePeriodUsed.WithLabelValues(string(syncerType))
+	c.counterConnectionsResumed = counterVecConnectionsResumed.WithLabelValues(string(syncerType))
+	c.counterConnectionsResumeRejected = counterVecConnectionsResumeRejected.WithLabelValues(string(syncerType))
 	c.gaugeNumConnectionsStreaming = gaugeVecNumConnectionsStreaming.WithLabelValues(string(syncerType))
 	return c
 }
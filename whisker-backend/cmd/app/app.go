@@ -16,6 +16,7 @@ package app
 
 import (
 	"context"
+	"slices"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -41,6 +42,11 @@ func Run(ctx context.Context, cfg *config.Config) {
 		logrus.WithError(err).Fatal("Failed to create goldmane client.")
 	}
 
+	recCli, err := client.NewPolicyRecommendationsAPIClient(cfg.GoldmaneHost, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to create goldmane policy recommendations client.")
+	}
+
 	opts := []server.Option{
 		server.WithAddr(cfg.HostAddr()),
 	}
@@ -51,10 +57,12 @@ func Run(ctx context.Context, cfg *config.Config) {
 	}
 
 	flowsAPI := v1.NewFlows(gmCli)
+	recommendationsAPI := v1.NewPolicyRecommendations(recCli)
+	graphAPI := v1.NewGraph(gmCli)
 
 	srv, err := server.NewHTTPServer(
 		gorillaadpt.NewRouter(),
-		flowsAPI.APIs(),
+		slices.Concat(flowsAPI.APIs(), recommendationsAPI.APIs(), graphAPI.APIs()),
 		opts...,
 	)
 	if err != nil {
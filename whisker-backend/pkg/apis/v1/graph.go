@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+const (
+	GraphPath = sep + "graph"
+)
+
+const (
+	GraphGroupByWorkload  = "workload"
+	GraphGroupByNamespace = "namespace"
+)
+
+type GraphNodeType string
+
+const (
+	GraphNodeTypeWorkload     GraphNodeType = "workload"
+	GraphNodeTypeHostEndpoint GraphNodeType = "hostendpoint"
+	GraphNodeTypeNetworkSet   GraphNodeType = "networkset"
+	GraphNodeTypeNetwork      GraphNodeType = "network"
+	GraphNodeTypeService      GraphNodeType = "service"
+	GraphNodeTypeNamespace    GraphNodeType = "namespace"
+)
+
+type GraphParams struct {
+	StartTimeGte int64   `urlQuery:"startTimeGte"`
+	StartTimeLt  int64   `urlQuery:"startTimeLt"`
+	Filters      Filters `urlQuery:"filters"`
+
+	// GroupBy controls the granularity of the workload nodes in the graph: either one node per (aggregated) workload,
+	// or one node per namespace. Defaults to workload.
+	GroupBy string `urlQuery:"groupBy" validate:"omitempty,oneof=workload namespace"`
+}
+
+// GraphResponse is a dependency graph built from the flows in the requested time window.
+type GraphResponse struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	ID        string        `json:"id"`
+	Type      GraphNodeType `json:"type"`
+	Name      string        `json:"name"`
+	Namespace string        `json:"namespace"`
+
+	// Ingress and Egress are the totals of the traffic on the node's incoming and outgoing edges.
+	Ingress GraphTraffic `json:"ingress"`
+	Egress  GraphTraffic `json:"egress"`
+}
+
+type GraphEdge struct {
+	Source string `json:"source"`
+	Dest   string `json:"dest"`
+
+	Allowed GraphTraffic `json:"allowed"`
+	Denied  GraphTraffic `json:"denied"`
+}
+
+type GraphTraffic struct {
+	Packets     int64 `json:"packets"`
+	Bytes       int64 `json:"bytes"`
+	Connections int64 `json:"connections"`
+}
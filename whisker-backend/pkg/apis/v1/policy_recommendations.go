@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+const (
+	PolicyRecommendationsPath = sep + "policy-recommendations"
+)
+
+type ListPolicyRecommendationsParams struct {
+	// Namespace is the namespace to generate policy recommendations for.
+	Namespace string `urlQuery:"namespace" validate:"required"`
+
+	// Tier is the tier to place the recommended policies in. Defaults to the default tier.
+	Tier string `urlQuery:"tier"`
+
+	StartTimeGte int64 `urlQuery:"startTimeGte"`
+	StartTimeLt  int64 `urlQuery:"startTimeLt"`
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	"github.com/projectcalico/calico/goldmane/pkg/client"
+	"github.com/projectcalico/calico/goldmane/proto"
+	"github.com/projectcalico/calico/lib/httpmachinery/pkg/apiutil"
+	apictx "github.com/projectcalico/calico/lib/httpmachinery/pkg/context"
+	whiskerv1 "github.com/projectcalico/calico/whisker-backend/pkg/apis/v1"
+)
+
+// graphPageSize is the number of flows that the graph handler requests from Goldmane at a time, so that neither side
+// has to hold all the matching flows in a single message.
+const graphPageSize = 1000
+
+type graphHdlr struct {
+	flowCli client.FlowsClient
+}
+
+func NewGraph(cli client.FlowsClient) *graphHdlr {
+	return &graphHdlr{cli}
+}
+
+func (hdlr *graphHdlr) APIs() []apiutil.Endpoint {
+	return []apiutil.Endpoint{
+		{
+			Method:  http.MethodGet,
+			Path:    whiskerv1.GraphPath,
+			Handler: apiutil.NewJSONHandler(hdlr.Get),
+		},
+	}
+}
+
+// Get returns the dependency graph for the flows matching the requested filters and time window, so that the UI can
+// render a service map without having to fetch and aggregate the individual flows.
+func (hdlr *graphHdlr) Get(ctx apictx.Context, params whiskerv1.GraphParams) apiutil.Response[whiskerv1.GraphResponse] {
+	logger := ctx.Logger()
+	logger.Debug("Get graph called.")
+
+	// Page through the flows, adding each page to the graph as it arrives.
+	b := newGraphBuilder(params.GroupBy == whiskerv1.GraphGroupByNamespace)
+	filter := toProtoFilter(params.Filters)
+	for page := int64(0); ; page++ {
+		meta, flows, err := hdlr.flowCli.List(ctx, &proto.FlowListRequest{
+			Filter:       filter,
+			StartTimeGte: params.StartTimeGte,
+			StartTimeLt:  params.StartTimeLt,
+			Page:         page,
+			PageSize:     graphPageSize,
+		})
+		if err != nil {
+			logger.WithError(err).Error("failed to list flows")
+			return apiutil.NewResponse[whiskerv1.GraphResponse]().
+				SetStatus(http.StatusInternalServerError).
+				SetError("Internal Server Error")
+		}
+		for _, flow := range flows {
+			b.add(flow.Flow)
+		}
+		if len(flows) == 0 || meta == nil || page+1 >= meta.TotalPages {
+			break
+		}
+	}
+
+	return apiutil.NewResponse[whiskerv1.GraphResponse]().
+		SetStatus(http.StatusOK).
+		SetBody(b.build())
+}
+
+type edgeKey struct {
+	source, dest string
+}
+
+// reportedTraffic is the traffic on an edge as reported by one end of the connection.
+type reportedTraffic struct {
+	reported        bool
+	allowed, denied whiskerv1.GraphTraffic
+}
+
+type edgeTraffic struct {
+	bySource, byDest reportedTraffic
+}
+
+// graphBuilder aggregates flows into a graph.
+//
+// Both ends of a connection between two Calico endpoints report a flow for it, so the traffic on each edge is tracked
+// separately for each reporter to avoid counting it twice. Traffic that is denied at the source never reaches the
+// destination, so denied traffic is the sum of the two. Traffic that the source allows may still be denied at the
+// destination, so the destination's view of the allowed traffic is used when it has one.
+type graphBuilder struct {
+	byNamespace bool
+	nodes       map[string]*whiskerv1.GraphNode
+	edges       map[edgeKey]*edgeTraffic
+}
+
+func newGraphBuilder(byNamespace bool) *graphBuilder {
+	return &graphBuilder{
+		byNamespace: byNamespace,
+		nodes:       map[string]*whiskerv1.GraphNode{},
+		edges:       map[edgeKey]*edgeTraffic{},
+	}
+}
+
+func (b *graphBuilder) add(flow *proto.Flow) {
+	key := flow.Key
+	src := b.endpointNode(key.SourceType, key.SourceName, key.SourceNamespace)
+	dst := b.endpointNode(key.DestType, key.DestName, key.DestNamespace)
+
+	traffic := whiskerv1.GraphTraffic{
+		Packets:     flow.PacketsIn + flow.PacketsOut,
+		Bytes:       flow.BytesIn + flow.BytesOut,
+		Connections: flow.NumConnectionsStarted,
+	}
+
+	// Traffic to a service goes via a node for the service, unless we're only showing namespaces.
+	if key.DestServiceName != "" && !b.byNamespace {
+		svc := b.node(whiskerv1.GraphNodeTypeService, key.DestServiceName, key.DestServiceNamespace)
+		b.addEdgeTraffic(src, svc, key.Reporter, key.Action, traffic)
+		b.addEdgeTraffic(svc, dst, key.Reporter, key.Action, traffic)
+		return
+	}
+	b.addEdgeTraffic(src, dst, key.Reporter, key.Action, traffic)
+}
+
+// endpointNode returns the ID of the node for the given endpoint, creating the node if necessary.
+func (b *graphBuilder) endpointNode(epType proto.EndpointType, name, namespace string) string {
+	var nodeType whiskerv1.GraphNodeType
+	switch epType {
+	case proto.EndpointType_WorkloadEndpoint:
+		nodeType = whiskerv1.GraphNodeTypeWorkload
+	case proto.EndpointType_HostEndpoint:
+		nodeType = whiskerv1.GraphNodeTypeHostEndpoint
+	case proto.EndpointType_NetworkSet:
+		nodeType = whiskerv1.GraphNodeTypeNetworkSet
+	default:
+		nodeType = whiskerv1.GraphNodeTypeNetwork
+	}
+
+	if b.byNamespace && namespace != "" && namespace != "-" {
+		return b.node(whiskerv1.GraphNodeTypeNamespace, namespace, "")
+	}
+	return b.node(nodeType, protoToName(name), namespace)
+}
+
+func (b *graphBuilder) node(nodeType whiskerv1.GraphNodeType, name, namespace string) string {
+	if namespace == "-" {
+		namespace = ""
+	}
+	id := string(nodeType) + "/" + name
+	if namespace != "" {
+		id = string(nodeType) + "/" + namespace + "/" + name
+	}
+	if _, ok := b.nodes[id]; !ok {
+		b.nodes[id] = &whiskerv1.GraphNode{
+			ID:        id,
+			Type:      nodeType,
+			Name:      name,
+			Namespace: namespace,
+		}
+	}
+	return id
+}
+
+func (b *graphBuilder) addEdgeTraffic(src, dst string, reporter proto.Reporter, action proto.Action, traffic whiskerv1.GraphTraffic) {
+	k := edgeKey{source: src, dest: dst}
+	e, ok := b.edges[k]
+	if !ok {
+		e = &edgeTraffic{}
+		b.edges[k] = e
+	}
+
+	r := &e.bySource
+	if reporter == proto.Reporter_Dst {
+		r = &e.byDest
+	}
+	r.reported = true
+	if action == proto.Action_Deny {
+		addTraffic(&r.denied, traffic)
+	} else {
+		addTraffic(&r.allowed, traffic)
+	}
+}
+
+func (b *graphBuilder) build() whiskerv1.GraphResponse {
+	edges := make([]whiskerv1.GraphEdge, 0, len(b.edges))
+	for k, e := range b.edges {
+		edge := whiskerv1.GraphEdge{
+			Source:  k.source,
+			Dest:    k.dest,
+			Allowed: e.bySource.allowed,
+		}
+		if e.byDest.reported {
+			edge.Allowed = e.byDest.allowed
+		}
+		addTraffic(&edge.Denied, e.bySource.denied)
+		addTraffic(&edge.Denied, e.byDest.denied)
+
+		addTraffic(&b.nodes[k.source].Egress, edge.Allowed)
+		addTraffic(&b.nodes[k.source].Egress, edge.Denied)
+		addTraffic(&b.nodes[k.dest].Ingress, edge.Allowed)
+		addTraffic(&b.nodes[k.dest].Ingress, edge.Denied)
+		edges = append(edges, edge)
+	}
+	slices.SortFunc(edges, func(a, b whiskerv1.GraphEdge) int {
+		return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.Dest, b.Dest))
+	})
+
+	nodes := make([]whiskerv1.GraphNode, 0, len(b.nodes))
+	for _, n := range b.nodes {
+		nodes = append(nodes, *n)
+	}
+	slices.SortFunc(nodes, func(a, b whiskerv1.GraphNode) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return whiskerv1.GraphResponse{Nodes: nodes, Edges: edges}
+}
+
+func addTraffic(t *whiskerv1.GraphTraffic, other whiskerv1.GraphTraffic) {
+	t.Packets += other.Packets
+	t.Bytes += other.Bytes
+	t.Connections += other.Connections
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	climocks "github.com/projectcalico/calico/goldmane/pkg/client/mocks"
+	"github.com/projectcalico/calico/goldmane/proto"
+	"github.com/projectcalico/calico/lib/httpmachinery/pkg/testutil"
+	whiskerv1 "github.com/projectcalico/calico/whisker-backend/pkg/apis/v1"
+	hdlrv1 "github.com/projectcalico/calico/whisker-backend/pkg/handlers/v1"
+)
+
+func graphFlow(reporter proto.Reporter, action proto.Action, srcName, srcNs, dstName, dstNs string, bytes int64) *proto.FlowResult {
+	return &proto.FlowResult{
+		Flow: &proto.Flow{
+			Key: &proto.FlowKey{
+				SourceName:      srcName,
+				SourceNamespace: srcNs,
+				SourceType:      proto.EndpointType_WorkloadEndpoint,
+				DestName:        dstName,
+				DestNamespace:   dstNs,
+				DestType:        proto.EndpointType_WorkloadEndpoint,
+				Reporter:        reporter,
+				Action:          action,
+			},
+			BytesOut:              bytes,
+			PacketsOut:            1,
+			NumConnectionsStarted: 1,
+		},
+	}
+}
+
+func getGraph(t *testing.T, flows []*proto.FlowResult, params whiskerv1.GraphParams) *whiskerv1.GraphResponse {
+	sc := setupTest(t)
+
+	fsCli := new(climocks.FlowsClient)
+	fsCli.On("List", mock.Anything, mock.Anything).Return(&proto.ListMetadata{TotalPages: 1}, flows, nil)
+
+	rsp := hdlrv1.NewGraph(fsCli).Get(sc.apiCtx, params)
+	Expect(rsp.Status()).Should(Equal(http.StatusOK))
+	recorder := httptest.NewRecorder()
+	Expect(rsp.ResponseWriter().WriteResponse(sc.apiCtx, http.StatusOK, recorder)).ShouldNot(HaveOccurred())
+	return testutil.MustUnmarshal[whiskerv1.GraphResponse](t, recorder.Body.Bytes())
+}
+
+func TestGraphDeduplicatesReporters(t *testing.T) {
+	// Both ends report the allowed traffic; the destination denies some of what the source allowed.
+	graph := getGraph(t, []*proto.FlowResult{
+		graphFlow(proto.Reporter_Src, proto.Action_Allow, "client-*", "ns1", "server-*", "ns2", 300),
+		graphFlow(proto.Reporter_Dst, proto.Action_Allow, "client-*", "ns1", "server-*", "ns2", 200),
+		graphFlow(proto.Reporter_Dst, proto.Action_Deny, "client-*", "ns1", "server-*", "ns2", 100),
+		graphFlow(proto.Reporter_Src, proto.Action_Deny, "client-*", "ns1", "server-*", "ns2", 10),
+	}, whiskerv1.GraphParams{})
+
+	Expect(graph.Edges).To(Equal([]whiskerv1.GraphEdge{{
+		Source:  "workload/ns1/client-*",
+		Dest:    "workload/ns2/server-*",
+		Allowed: whiskerv1.GraphTraffic{Packets: 1, Bytes: 200, Connections: 1},
+		Denied:  whiskerv1.GraphTraffic{Packets: 2, Bytes: 110, Connections: 2},
+	}}))
+	Expect(graph.Nodes).To(Equal([]whiskerv1.GraphNode{
+		{
+			ID:        "workload/ns1/client-*",
+			Type:      whiskerv1.GraphNodeTypeWorkload,
+			Name:      "client-*",
+			Namespace: "ns1",
+			Egress:    whiskerv1.GraphTraffic{Packets: 3, Bytes: 310, Connections: 3},
+		},
+		{
+			ID:        "workload/ns2/server-*",
+			Type:      whiskerv1.GraphNodeTypeWorkload,
+			Name:      "server-*",
+			Namespace: "ns2",
+			Ingress:   whiskerv1.GraphTraffic{Packets: 3, Bytes: 310, Connections: 3},
+		},
+	}))
+}
+
+func TestGraphServicesAndNetworks(t *testing.T) {
+	toSvc := graphFlow(proto.Reporter_Src, proto.Action_Allow, "client-*", "ns1", "server-*", "ns2", 100)
+	toSvc.Flow.Key.DestServiceName = "server"
+	toSvc.Flow.Key.DestServiceNamespace = "ns2"
+	toPub := graphFlow(proto.Reporter_Src, proto.Action_Allow, "client-*", "ns1", "pub", "", 50)
+	toPub.Flow.Key.DestType = proto.EndpointType_Network
+
+	graph := getGraph(t, []*proto.FlowResult{toSvc, toPub}, whiskerv1.GraphParams{})
+
+	var ids []string
+	for _, n := range graph.Nodes {
+		ids = append(ids, n.ID)
+	}
+	Expect(ids).To(Equal([]string{
+		"network/PUBLIC NETWORK",
+		"service/ns2/server",
+		"workload/ns1/client-*",
+		"workload/ns2/server-*",
+	}))
+	Expect(graph.Edges).To(HaveLen(3))
+	Expect(graph.Edges[0].Source).To(Equal("service/ns2/server"))
+	Expect(graph.Edges[0].Dest).To(Equal("workload/ns2/server-*"))
+	Expect(graph.Edges[1].Dest).To(Equal("network/PUBLIC NETWORK"))
+	Expect(graph.Edges[2].Dest).To(Equal("service/ns2/server"))
+	Expect(graph.Edges[2].Allowed.Bytes).To(Equal(int64(100)))
+}
+
+func TestGraphGroupByNamespace(t *testing.T) {
+	toSvc := graphFlow(proto.Reporter_Src, proto.Action_Allow, "client-*", "ns1", "server-*", "ns2", 100)
+	toSvc.Flow.Key.DestServiceName = "server"
+	toSvc.Flow.Key.DestServiceNamespace = "ns2"
+
+	graph := getGraph(t, []*proto.FlowResult{
+		toSvc,
+		graphFlow(proto.Reporter_Src, proto.Action_Allow, "other-*", "ns1", "server-*", "ns2", 20),
+	}, whiskerv1.GraphParams{GroupBy: whiskerv1.GraphGroupByNamespace})
+
+	Expect(graph.Nodes).To(HaveLen(2))
+	Expect(graph.Nodes[0].ID).To(Equal("namespace/ns1"))
+	Expect(graph.Nodes[0].Type).To(Equal(whiskerv1.GraphNodeTypeNamespace))
+	Expect(graph.Edges).To(Equal([]whiskerv1.GraphEdge{{
+		Source:  "namespace/ns1",
+		Dest:    "namespace/ns2",
+		Allowed: whiskerv1.GraphTraffic{Packets: 2, Bytes: 120, Connections: 2},
+	}}))
+}
+
+func TestGraphPagesThroughFlows(t *testing.T) {
+	sc := setupTest(t)
+
+	pageRequest := func(page int64) interface{} {
+		return mock.MatchedBy(func(req *proto.FlowListRequest) bool {
+			return req.Page == page && req.PageSize > 0
+		})
+	}
+	fsCli := new(climocks.FlowsClient)
+	fsCli.On("List", mock.Anything, pageRequest(0)).Return(&proto.ListMetadata{TotalPages: 2}, []*proto.FlowResult{
+		graphFlow(proto.Reporter_Src, proto.Action_Allow, "client-*", "ns1", "server-*", "ns2", 100),
+	}, nil).Once()
+	fsCli.On("List", mock.Anything, pageRequest(1)).Return(&proto.ListMetadata{TotalPages: 2}, []*proto.FlowResult{
+		graphFlow(proto.Reporter_Src, proto.Action_Allow, "client-*", "ns1", "server-*", "ns2", 50),
+	}, nil).Once()
+
+	rsp := hdlrv1.NewGraph(fsCli).Get(sc.apiCtx, whiskerv1.GraphParams{})
+	Expect(rsp.Status()).Should(Equal(http.StatusOK))
+	recorder := httptest.NewRecorder()
+	Expect(rsp.ResponseWriter().WriteResponse(sc.apiCtx, http.StatusOK, recorder)).ShouldNot(HaveOccurred())
+	graph := testutil.MustUnmarshal[whiskerv1.GraphResponse](t, recorder.Body.Bytes())
+
+	fsCli.AssertExpectations(t)
+	Expect(graph.Edges).To(HaveLen(1))
+	Expect(graph.Edges[0].Allowed).To(Equal(whiskerv1.GraphTraffic{Packets: 2, Bytes: 150, Connections: 2}))
+}
+
+func TestGraphListError(t *testing.T) {
+	sc := setupTest(t)
+
+	fsCli := new(climocks.FlowsClient)
+	fsCli.On("List", mock.Anything, mock.Anything).Return(nil, nil, errors.New("unavailable"))
+
+	rsp := hdlrv1.NewGraph(fsCli).Get(sc.apiCtx, whiskerv1.GraphParams{})
+	Expect(rsp.Status()).Should(Equal(http.StatusInternalServerError))
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcalico/calico/goldmane/pkg/client"
+	"github.com/projectcalico/calico/goldmane/proto"
+	"github.com/projectcalico/calico/lib/httpmachinery/pkg/apiutil"
+	apictx "github.com/projectcalico/calico/lib/httpmachinery/pkg/context"
+	whiskerv1 "github.com/projectcalico/calico/whisker-backend/pkg/apis/v1"
+)
+
+type policyRecommendationsHdlr struct {
+	cli client.PolicyRecommendationsClient
+}
+
+func NewPolicyRecommendations(cli client.PolicyRecommendationsClient) *policyRecommendationsHdlr {
+	return &policyRecommendationsHdlr{cli}
+}
+
+func (hdlr *policyRecommendationsHdlr) APIs() []apiutil.Endpoint {
+	return []apiutil.Endpoint{
+		{
+			Method:  http.MethodGet,
+			Path:    whiskerv1.PolicyRecommendationsPath,
+			Handler: apiutil.NewJSONListHandler(hdlr.List),
+		},
+	}
+}
+
+// List returns StagedNetworkPolicy resources covering the allowed traffic observed in the requested namespace. The
+// policies are returned with a staged action of Learn, so that they can be reviewed before being enforced.
+func (hdlr *policyRecommendationsHdlr) List(ctx apictx.Context, params whiskerv1.ListPolicyRecommendationsParams) apiutil.ListResponse[v3.StagedNetworkPolicy] {
+	logger := ctx.Logger()
+	logger.Debug("List policy recommendations called.")
+
+	recs, err := hdlr.cli.List(ctx, &proto.PolicyRecommendationRequest{
+		Namespace:    params.Namespace,
+		Tier:         params.Tier,
+		StartTimeGte: params.StartTimeGte,
+		StartTimeLt:  params.StartTimeLt,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to list policy recommendations")
+		return apiutil.NewListResponse[v3.StagedNetworkPolicy]().
+			SetStatus(http.StatusInternalServerError).
+			SetError("Internal Server Error")
+	}
+
+	policies := make([]v3.StagedNetworkPolicy, len(recs))
+	for i, rec := range recs {
+		policies[i] = protoToStagedNetworkPolicy(rec)
+	}
+
+	return apiutil.NewListResponse[v3.StagedNetworkPolicy]().
+		SetStatus(http.StatusOK).
+		SetMeta(apiutil.ListMeta{TotalPages: 1}).
+		SetItems(policies)
+}
+
+func protoToStagedNetworkPolicy(rec *proto.PolicyRecommendation) v3.StagedNetworkPolicy {
+	snp := v3.StagedNetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v3.KindStagedNetworkPolicy,
+			APIVersion: v3.GroupVersionCurrent,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rec.Name,
+			Namespace: rec.Namespace,
+		},
+		Spec: v3.StagedNetworkPolicySpec{
+			StagedAction: v3.StagedActionLearn,
+			Tier:         rec.Tier,
+			Selector:     rec.Selector,
+		},
+	}
+
+	// Always include both policy types, so that any traffic not observed is denied once the policy is enforced.
+	snp.Spec.Types = []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress}
+	for _, r := range rec.Ingress {
+		rule := v3.Rule{Action: v3.Allow, Protocol: protoToProtocol(r.Protocol)}
+		rule.Source = v3.EntityRule{
+			Selector:          r.Selector,
+			NamespaceSelector: r.NamespaceSelector,
+			Nets:              r.Nets,
+			NotNets:           r.NotNets,
+		}
+		rule.Destination.Ports = protoToPorts(rule.Protocol, r.Ports)
+		snp.Spec.Ingress = append(snp.Spec.Ingress, rule)
+	}
+	for _, r := range rec.Egress {
+		rule := v3.Rule{Action: v3.Allow, Protocol: protoToProtocol(r.Protocol)}
+		rule.Destination = v3.EntityRule{
+			Selector:          r.Selector,
+			NamespaceSelector: r.NamespaceSelector,
+			Nets:              r.Nets,
+			NotNets:           r.NotNets,
+			Ports:             protoToPorts(rule.Protocol, r.Ports),
+		}
+		snp.Spec.Egress = append(snp.Spec.Egress, rule)
+	}
+	return snp
+}
+
+func protoToProtocol(p string) *numorstring.Protocol {
+	if p == "" {
+		return nil
+	}
+	if n, err := strconv.ParseUint(p, 10, 8); err == nil {
+		protocol := numorstring.ProtocolFromInt(uint8(n))
+		return &protocol
+	}
+	protocol := numorstring.ProtocolFromString(p)
+	return &protocol
+}
+
+// protoToPorts converts the given ports, omitting them for protocols that don't support ports.
+func protoToPorts(protocol *numorstring.Protocol, ports []int64) []numorstring.Port {
+	if protocol == nil || !protocol.SupportsPorts() {
+		return nil
+	}
+	var out []numorstring.Port
+	for _, p := range ports {
+		out = append(out, numorstring.SinglePort(uint16(p)))
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	climocks "github.com/projectcalico/calico/goldmane/pkg/client/mocks"
+	"github.com/projectcalico/calico/goldmane/proto"
+	"github.com/projectcalico/calico/lib/httpmachinery/pkg/apiutil"
+	"github.com/projectcalico/calico/lib/httpmachinery/pkg/testutil"
+	whiskerv1 "github.com/projectcalico/calico/whisker-backend/pkg/apis/v1"
+	hdlrv1 "github.com/projectcalico/calico/whisker-backend/pkg/handlers/v1"
+)
+
+func TestListPolicyRecommendations(t *testing.T) {
+	sc := setupTest(t)
+
+	var req *proto.PolicyRecommendationRequest
+	recCli := new(climocks.PolicyRecommendationsClient)
+	recCli.On("List", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		req = args.Get(1).(*proto.PolicyRecommendationRequest)
+	}).Return([]*proto.PolicyRecommendation{
+		{
+			Name:      "recommended-backend",
+			Namespace: "shop",
+			Tier:      "default",
+			Selector:  "app == 'backend'",
+			Ingress: []*proto.RecommendedRule{
+				{Protocol: "tcp", Ports: []int64{8080}, Selector: "app == 'frontend'"},
+				{Protocol: "tcp", Ports: []int64{8080}, Nets: []string{"10.0.0.0/8"}},
+			},
+			Egress: []*proto.RecommendedRule{
+				{Protocol: "udp", Ports: []int64{53}, Selector: "k8s-app == 'kube-dns'", NamespaceSelector: "projectcalico.org/name == 'kube-system'"},
+				{Protocol: "icmp", Ports: []int64{0}, NotNets: []string{"10.0.0.0/8"}},
+			},
+		},
+	}, nil)
+
+	hdlr := hdlrv1.NewPolicyRecommendations(recCli)
+	rsp := hdlr.List(sc.apiCtx, whiskerv1.ListPolicyRecommendationsParams{Namespace: "shop", StartTimeGte: -3600})
+	Expect(rsp.Status()).Should(Equal(http.StatusOK))
+	Expect(req.Namespace).Should(Equal("shop"))
+	Expect(req.StartTimeGte).Should(Equal(int64(-3600)))
+
+	recorder := httptest.NewRecorder()
+	Expect(rsp.ResponseWriter().WriteResponse(sc.apiCtx, http.StatusOK, recorder)).ShouldNot(HaveOccurred())
+	policies := testutil.MustUnmarshal[apiutil.List[v3.StagedNetworkPolicy]](t, recorder.Body.Bytes())
+
+	tcp := numorstring.ProtocolFromString(numorstring.ProtocolTCP)
+	udp := numorstring.ProtocolFromString(numorstring.ProtocolUDP)
+	icmp := numorstring.ProtocolFromString(numorstring.ProtocolICMP)
+	Expect(policies.Items).Should(Equal([]v3.StagedNetworkPolicy{
+		{
+			TypeMeta:   metav1.TypeMeta{Kind: v3.KindStagedNetworkPolicy, APIVersion: v3.GroupVersionCurrent},
+			ObjectMeta: metav1.ObjectMeta{Name: "recommended-backend", Namespace: "shop"},
+			Spec: v3.StagedNetworkPolicySpec{
+				StagedAction: v3.StagedActionLearn,
+				Tier:         "default",
+				Selector:     "app == 'backend'",
+				Types:        []v3.PolicyType{v3.PolicyTypeIngress, v3.PolicyTypeEgress},
+				Ingress: []v3.Rule{
+					{
+						Action:      v3.Allow,
+						Protocol:    &tcp,
+						Source:      v3.EntityRule{Selector: "app == 'frontend'"},
+						Destination: v3.EntityRule{Ports: []numorstring.Port{numorstring.SinglePort(8080)}},
+					},
+					{
+						Action:      v3.Allow,
+						Protocol:    &tcp,
+						Source:      v3.EntityRule{Nets: []string{"10.0.0.0/8"}},
+						Destination: v3.EntityRule{Ports: []numorstring.Port{numorstring.SinglePort(8080)}},
+					},
+				},
+				Egress: []v3.Rule{
+					{
+						Action:   v3.Allow,
+						Protocol: &udp,
+						Destination: v3.EntityRule{
+							Selector:          "k8s-app == 'kube-dns'",
+							NamespaceSelector: "projectcalico.org/name == 'kube-system'",
+							Ports:             []numorstring.Port{numorstring.SinglePort(53)},
+						},
+					},
+					{
+						Action:      v3.Allow,
+						Protocol:    &icmp,
+						Destination: v3.EntityRule{NotNets: []string{"10.0.0.0/8"}},
+					},
+				},
+			},
+		},
+	}))
+}
+
+func TestListPolicyRecommendationsError(t *testing.T) {
+	sc := setupTest(t)
+
+	recCli := new(climocks.PolicyRecommendationsClient)
+	recCli.On("List", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("goldmane unavailable"))
+
+	hdlr := hdlrv1.NewPolicyRecommendations(recCli)
+	rsp := hdlr.List(sc.apiCtx, whiskerv1.ListPolicyRecommendationsParams{Namespace: "shop"})
+	Expect(rsp.Status()).Should(Equal(http.StatusInternalServerError))
+}